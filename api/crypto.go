@@ -756,7 +756,7 @@ func (c *CryptoAPI) notifyPendingCryptoTransaction(ctx *gin.Context, payload cry
 
 // GetCoinPriceHistory godoc
 // @Summary      Get Coin Price History
-// @Description  Retrieves historical price data for a specific cryptocurrency coin over a defined time period using the CoinRanking provider.
+// @Description  Retrieves historical price data for a specific cryptocurrency coin over a defined time period. Served from the local price history store when it covers the period, otherwise from the CoinRanking provider.
 // @Tags         Crypto
 // @Accept       json
 // @Produce      json
@@ -776,6 +776,14 @@ func (c *CryptoAPI) GetCoinPriceHistory(ctx *gin.Context) {
 		return
 	}
 
+	// Serve from the local price history store when it covers the period; fall back to CoinRanking otherwise
+	if c.server.priceHistoryService != nil {
+		if local, err := c.server.priceHistoryService.GetCoinHistory(ctx, coin, timePeriod); err == nil {
+			ctx.JSON(http.StatusOK, basemodels.NewSuccess("Get coin price data is successful", basemodels.NewSuccess("coin price data", local)))
+			return
+		}
+	}
+
 	provider, exists := c.server.provider.GetProvider(providers.CoinRanking)
 	if !exists {
 		c.server.logger.Error("failed to get provider")
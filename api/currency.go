@@ -9,6 +9,7 @@ import (
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/currency"
 	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 )
 
 type Currency struct {
@@ -36,7 +37,7 @@ func (c *Currency) setPairRate(ctx *gin.Context) {
 		ctx.JSON(http.StatusUnauthorized, basemodels.NewError("unauthorized"))
 		return
 	}
-	if user.Role == models.ADMIN {
+	if user.Role == models.USER {
 		ctx.JSON(http.StatusUnauthorized, basemodels.NewError("unauthorized"))
 		return
 	}
@@ -53,13 +54,32 @@ func (c *Currency) setPairRate(ctx *gin.Context) {
 		return
 	}
 
-	exchObj, err := c.currencyService.SetExchangeRate(ctx, nil, request.BaseCurrency, request.QuoteCurrency, request.Rate)
+	rate, err := decimal.NewFromString(request.Rate)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, basemodels.NewError("please check currency pair and rate"))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, basemodels.NewSuccess("pair set successfully", exchObj))
+	admin, err := c.server.queries.GetUserByID(ctx, user.UserID)
+	if err != nil {
+		c.server.logger.Error(err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get user"))
+		return
+	}
+
+	// manual rates reprice conversions, so they wait for a second admin like other rate manager changes
+	change, err := c.server.rateManager.SubmitManualRate(ctx, request.BaseCurrency, request.QuoteCurrency, rate, &admin)
+	if err != nil {
+		if status, ok := rateChangeErrorStatus(err); ok {
+			ctx.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		c.server.logger.Error(err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to submit rate"))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, basemodels.NewSuccess("rate submitted for approval", change))
 }
 
 func (c *Currency) getPairRate(ctx *gin.Context) {
@@ -114,13 +134,13 @@ func (c *Currency) getAllRates(ctx *gin.Context) {
 // toggleRateSource allows admin to toggle between manual rates and exchange service rates
 // godoc
 // @Summary Toggle Rate Source
-// @Description Switch between manual rates set by admin or rates from the exchange rate service
+// @Description Submit a switch between manual rates set by admin or rates from the exchange rate service. The switch takes effect once a second admin approves it.
 // @Tags currency
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body object{currency_pair=string,rate_source=string} true "Toggle Request"
-// @Success 200 {object} basemodels.SuccessResponse
+// @Success 202 {object} ratemanager.RateChangeRequest
 // @Failure 400 {object} basemodels.ErrorResponse
 // @Failure 401 {object} basemodels.ErrorResponse
 // @Failure 500 {object} basemodels.ErrorResponse
@@ -131,7 +151,7 @@ func (c *Currency) toggleRateSource(ctx *gin.Context) {
 		ctx.JSON(http.StatusUnauthorized, basemodels.NewError("unauthorized"))
 		return
 	}
-	if user.Role == models.ADMIN {
+	if user.Role == models.USER {
 		ctx.JSON(http.StatusUnauthorized, basemodels.NewError("only admins can toggle rate sources"))
 		return
 	}
@@ -153,27 +173,24 @@ func (c *Currency) toggleRateSource(ctx *gin.Context) {
 		return
 	}
 
-	// Convert to proper type
-	var preference any = request.RateSource
-	if request.RateSource == "manual" {
-		preference = "manual"
-	} else {
-		preference = "exchange_service"
+	admin, err := c.server.queries.GetUserByID(ctx, user.UserID)
+	if err != nil {
+		c.server.logger.Error(err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get user"))
+		return
 	}
 
-	// Set the preference
-	err = c.server.rateManager.SetRateSourcePreference(ctx, request.CurrencyPair, interface{}(preference).(string))
+	change, err := c.server.rateManager.SubmitRateSourcePreference(ctx, request.CurrencyPair, request.RateSource, &admin)
 	if err != nil {
+		if status, ok := rateChangeErrorStatus(err); ok {
+			ctx.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
 		c.server.logger.Error(err)
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to toggle rate source"))
 		return
 	}
 
-	response := map[string]any{
-		"currency_pair": request.CurrencyPair,
-		"rate_source":   request.RateSource,
-		"message":       fmt.Sprintf("Rate source for %s switched to %s", request.CurrencyPair, request.RateSource),
-	}
-
-	ctx.JSON(http.StatusOK, basemodels.NewSuccess("rate source toggled successfully", response))
+	ctx.JSON(http.StatusAccepted, basemodels.NewSuccess(
+		fmt.Sprintf("switch of %s to %s submitted for approval", request.CurrencyPair, request.RateSource), change))
 }
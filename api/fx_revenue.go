@@ -0,0 +1,199 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SwiftFiat/SwiftFiat-Backend/api/apistrings"
+	"github.com/SwiftFiat/SwiftFiat-Backend/api/models"
+	basemodels "github.com/SwiftFiat/SwiftFiat-Backend/models"
+	fxrevenue "github.com/SwiftFiat/SwiftFiat-Backend/services/fx_revenue"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type FxRevenueHandler struct {
+	server  *Server
+	logger  *logging.Logger
+	revenue *fxrevenue.Service
+}
+
+func (h FxRevenueHandler) router(server *Server) {
+	h.server = server
+	h.logger = server.logger
+	h.revenue = server.fxRevenueService
+
+	v1 := server.router.Group("/api/v1/admin/fx-revenue")
+	v1.Use(h.server.authMiddleware.AuthenticatedMiddleware())
+	{
+		v1.GET("/summary", h.GetSummary)
+		v1.GET("/export", h.ExportCSV)
+		v1.GET("/reconciliation", h.GetReconciliation)
+	}
+}
+
+// GetSummary godoc
+// @Summary Get FX revenue summary
+// @Description Spread and fee revenue per daily, weekly or monthly period grouped by pair, rate rule, VIP level or channel. Revenue is in each group's target currency and in USD.
+// @Tags FX Revenue
+// @Produce json
+// @Param period query string false "daily, weekly or monthly" default(daily)
+// @Param group_by query string false "pair, rule, vip_level or channel" default(pair)
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339), defaults to 30 days ago"
+// @Param to query string false "End date (YYYY-MM-DD inclusive or RFC3339 exclusive), defaults to now"
+// @Success 200 {object} basemodels.SuccessResponse{data=fxrevenue.Summary}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/admin/fx-revenue/summary [get]
+// @Security BearerAuth
+func (h *FxRevenueHandler) GetSummary(c *gin.Context) {
+	if !h.requireAdmin(c) {
+		return
+	}
+
+	from, to, err := parseRevenueRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	summary, err := h.revenue.Summary(c.Request.Context(), c.DefaultQuery("period", fxrevenue.PeriodDaily), c.DefaultQuery("group_by", fxrevenue.GroupByPair), from, to)
+	if err != nil {
+		if isRevenueRequestError(err) {
+			c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		h.logger.Errorf("failed to summarize fx revenue: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to summarize fx revenue"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("fx revenue summary fetched successfully", summary))
+}
+
+// ExportCSV godoc
+// @Summary Export FX revenue entries
+// @Description Downloads every revenue entry in the window as CSV, one row per conversion, ramp payout or card funding
+// @Tags FX Revenue
+// @Produce text/csv
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339), defaults to 30 days ago"
+// @Param to query string false "End date (YYYY-MM-DD inclusive or RFC3339 exclusive), defaults to now"
+// @Success 200 {file} file
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/admin/fx-revenue/export [get]
+// @Security BearerAuth
+func (h *FxRevenueHandler) ExportCSV(c *gin.Context) {
+	if !h.requireAdmin(c) {
+		return
+	}
+
+	from, to, err := parseRevenueRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	// buffer the export so a failure midway still produces a JSON error
+	var buf bytes.Buffer
+	if err := h.revenue.ExportCSV(c.Request.Context(), &buf, from, to); err != nil {
+		if isRevenueRequestError(err) {
+			c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		h.logger.Errorf("failed to export fx revenue: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to export fx revenue"))
+		return
+	}
+
+	filename := fmt.Sprintf("fx-revenue-%s-%s.csv", from.Format("20060102"), to.Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Data(http.StatusOK, "text/csv", buf.Bytes())
+}
+
+// GetReconciliation godoc
+// @Summary Reconcile FX revenue
+// @Description Compares revenue entries with completed conversions, ramp payouts and card fundings per channel and pair, and lists records missing an entry
+// @Tags FX Revenue
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339), defaults to 30 days ago"
+// @Param to query string false "End date (YYYY-MM-DD inclusive or RFC3339 exclusive), defaults to now"
+// @Success 200 {object} basemodels.SuccessResponse{data=fxrevenue.Reconciliation}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/admin/fx-revenue/reconciliation [get]
+// @Security BearerAuth
+func (h *FxRevenueHandler) GetReconciliation(c *gin.Context) {
+	if !h.requireAdmin(c) {
+		return
+	}
+
+	from, to, err := parseRevenueRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	result, err := h.revenue.Reconcile(c.Request.Context(), from, to)
+	if err != nil {
+		if isRevenueRequestError(err) {
+			c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		h.logger.Errorf("failed to reconcile fx revenue: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to reconcile fx revenue"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("fx revenue reconciliation fetched successfully", result))
+}
+
+func (h *FxRevenueHandler) requireAdmin(c *gin.Context) bool {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil || activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return false
+	}
+	return true
+}
+
+// parseRevenueRange reads from/to as dates or RFC3339 times. A date-only "to"
+// includes that whole day.
+func parseRevenueRange(c *gin.Context) (time.Time, time.Time, error) {
+	now := time.Now()
+	from := now.AddDate(0, 0, -30)
+	to := now
+
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			if t, err = time.Parse(time.RFC3339, v); err != nil {
+				return from, to, errors.New("invalid from date")
+			}
+		}
+		from = t
+	}
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err == nil {
+			t = t.AddDate(0, 0, 1)
+		} else if t, err = time.Parse(time.RFC3339, v); err != nil {
+			return from, to, errors.New("invalid to date")
+		}
+		to = t
+	}
+	return from, to, nil
+}
+
+func isRevenueRequestError(err error) bool {
+	return errors.Is(err, fxrevenue.ErrInvalidPeriod) ||
+		errors.Is(err, fxrevenue.ErrInvalidGroupBy) ||
+		errors.Is(err, fxrevenue.ErrInvalidRange)
+}
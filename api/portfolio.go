@@ -0,0 +1,109 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SwiftFiat/SwiftFiat-Backend/api/apistrings"
+	basemodels "github.com/SwiftFiat/SwiftFiat-Backend/models"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/portfolio"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type PortfolioHandler struct {
+	server    *Server
+	logger    *logging.Logger
+	portfolio *portfolio.PortfolioService
+}
+
+func (h PortfolioHandler) router(server *Server) {
+	h.server = server
+	h.logger = server.logger
+	h.portfolio = server.portfolioService
+
+	v1 := server.router.Group("/api/v1/portfolio")
+	v1.Use(h.server.authMiddleware.AuthenticatedMiddleware())
+	{
+		v1.GET("", h.GetPortfolio)
+		v1.GET("/history", h.GetNetWorthHistory)
+	}
+}
+
+// GetPortfolio godoc
+// @Summary Get portfolio valuation
+// @Description Values the user's wallet, vault and card balances in a display currency with cost basis and realised/unrealised P&L per asset
+// @Tags Portfolio
+// @Produce json
+// @Param currency query string false "Display currency (NGN, USD, USDT, USDC)" default(USD)
+// @Success 200 {object} basemodels.SuccessResponse{data=portfolio.Portfolio}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/portfolio [get]
+// @Security BearerAuth
+func (h *PortfolioHandler) GetPortfolio(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	result, err := h.portfolio.GetPortfolio(c.Request.Context(), activeUser.UserID, c.DefaultQuery("currency", "USD"))
+	if err != nil {
+		if errors.Is(err, portfolio.ErrUnsupportedDisplayCurrency) {
+			c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		h.logger.Errorf("failed to value portfolio: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to value portfolio"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("portfolio fetched successfully", result))
+}
+
+// GetNetWorthHistory godoc
+// @Summary Get net-worth history
+// @Description Returns the user's daily net-worth series from scheduled snapshots, converted at the rates recorded with each snapshot
+// @Tags Portfolio
+// @Produce json
+// @Param currency query string false "Display currency (NGN, USD, USDT, USDC)" default(USD)
+// @Param days query int false "Number of days to return (1-365)" default(30)
+// @Success 200 {object} basemodels.SuccessResponse{data=portfolio.NetWorthHistory}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/portfolio/history [get]
+// @Security BearerAuth
+func (h *PortfolioHandler) GetNetWorthHistory(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	days := portfolio.DefaultHistoryDays
+	if v := c.Query("days"); v != "" {
+		days, err = strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, basemodels.NewError(portfolio.ErrInvalidHistoryRange.Error()))
+			return
+		}
+	}
+
+	history, err := h.portfolio.GetNetWorthHistory(c.Request.Context(), activeUser.UserID, c.DefaultQuery("currency", "USD"), days)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrUnsupportedDisplayCurrency) || errors.Is(err, portfolio.ErrInvalidHistoryRange) {
+			c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		h.logger.Errorf("failed to get net-worth history: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get net-worth history"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("net-worth history fetched successfully", history))
+}
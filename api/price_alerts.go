@@ -1,6 +1,7 @@
 package api
 
 import (
+	"database/sql"
 	"net/http"
 	"strconv"
 	"time"
@@ -61,7 +62,7 @@ func (h PriceAlertHandler) router(server *Server) {
 
 // CreateAlert godoc
 // @Summary Create a new price alert
-// @Description Creates a custom price alert for crypto-to-fiat rate monitoring
+// @Description Creates a custom price alert for crypto-to-fiat rate monitoring. delivery_channels picks any of push, email, sms, in_app, websocket and webhook; the webhook channel needs an HTTPS webhook_url, and its deliveries are signed with the returned webhook secret in the X-SwiftFiat-Signature header. An optional convert_action converts between the user's wallets each time the alert triggers; it is authorised with the transaction pin and runs within the alert conversion limits.
 // @Tags PriceAlerts
 // @Accept json
 // @Produce json
@@ -104,6 +105,14 @@ func (h *PriceAlertHandler) CreateAlert(c *gin.Context) {
 		return
 	}
 
+	// a conversion action spends from the user's wallet, so it is authorised up front
+	if req.ConvertAction != nil {
+		if err = utils.VerifyHashValue(req.Pin, user.HashedPin.String); err != nil {
+			c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.InvalidTransactionPIN))
+			return
+		}
+	}
+
 	alert, err := h.alertService.CreateAlert(c.Request.Context(), activeUser.UserID, &req)
 	if err != nil {
 		h.logger.Error("Failed to create price alert", "error", err)
@@ -123,6 +132,10 @@ func (h *PriceAlertHandler) CreateAlert(c *gin.Context) {
 		)
 		h.audit.Log(entry)
 
+		if status, ok := conversionErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
 		return
 	}
@@ -140,11 +153,13 @@ func (h *PriceAlertHandler) CreateAlert(c *gin.Context) {
 		nil,
 	)
 	entry.Metadata = map[string]any{
-		"source_currency": alert.SourceCurrency,
-		"target_currency": alert.TargetCurrency,
-		"alert_condition": alert.AlertCondition,
-		"alert_type":      alert.AlertType,
-		"priority":        alert.Priority,
+		"source_currency":   alert.SourceCurrency,
+		"target_currency":   alert.TargetCurrency,
+		"alert_condition":   alert.AlertCondition,
+		"alert_type":        alert.AlertType,
+		"priority":          alert.Priority,
+		"delivery_channels": alert.DeliveryChannels,
+		"convert_action":    alert.ConvertAction,
 	}
 	h.audit.Log(entry)
 
@@ -450,13 +465,13 @@ func (h *PriceAlertHandler) GetAlertStats(c *gin.Context) {
 
 // GetAlertHistory godoc
 // @Summary Get alert trigger history
-// @Description Retrieves the history of when an alert was triggered
+// @Description Retrieves the history of when an alert was triggered, with the delivery status on each channel and the outcome of any conversion it ran
 // @Tags PriceAlerts
 // @Produce json
 // @Param alert_id path string true "Alert ID (UUID)"
 // @Param limit query int false "Number of records" default(20)
 // @Param offset query int false "Offset for pagination" default(0)
-// @Success 200 {object} basemodels.SuccessResponse
+// @Success 200 {object} []pricealert.AlertTrigger
 // @Failure 400 {object} basemodels.ErrorResponse
 // @Failure 401 {object} basemodels.ErrorResponse
 // @Router /api/v1/price-alerts/history/{alert_id} [get]
@@ -485,12 +500,8 @@ func (h *PriceAlertHandler) GetAlertHistory(c *gin.Context) {
 		return
 	}
 
-	// Get trigger history from database
-	history, err := h.server.queries.GetAlertTriggerHistory(c.Request.Context(), db.GetAlertTriggerHistoryParams{
-		AlertID: alertID,
-		Limit:   int32(limit),
-		Offset:  int32(offset),
-	})
+	// Get trigger history with per-channel deliveries
+	history, err := h.alertService.GetAlertHistory(c.Request.Context(), alertID, activeUser.UserID, int32(limit), int32(offset))
 	if err != nil {
 		h.logger.Error("Failed to fetch alert history", "error", err)
 		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to fetch alert history"))
@@ -544,6 +555,11 @@ func (h *PriceAlertHandler) GetAllAlerts(c *gin.Context) {
 		return
 	}
 
+	// webhook secrets belong to the alert owner
+	for i := range alerts {
+		alerts[i].WebhookSecret = sql.NullString{}
+	}
+
 	c.JSON(http.StatusOK, basemodels.NewSuccess("", gin.H{
 		"alerts": alerts,
 		"limit":  limit,
@@ -612,7 +628,7 @@ func (h *PriceAlertHandler) TriggerManualCheck(c *gin.Context) {
 
 // GetSystemMetrics godoc
 // @Summary Get system-wide metrics (Admin)
-// @Description Retrieves comprehensive metrics about the alert system
+// @Description Retrieves comprehensive metrics about the alert system, including evaluation lag and throughput for this replica and the shard ownership of every live replica
 // @Tags PriceAlerts
 // @Produce json
 // @Success 200 {object} basemodels.SuccessResponse
@@ -635,8 +651,16 @@ func (h *PriceAlertHandler) GetSystemMetrics(c *gin.Context) {
 
 	metrics := h.alertScheduler.GetMetrics()
 
+	evaluation, err := h.alertService.EvaluationMetrics(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to fetch alert evaluation metrics", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to fetch alert evaluation metrics"))
+		return
+	}
+
 	c.JSON(http.StatusOK, basemodels.NewSuccess("", gin.H{
-		"scheduler_metrics": metrics,
-		"timestamp":         time.Now(),
+		"scheduler_metrics":  metrics,
+		"evaluation_metrics": evaluation,
+		"timestamp":          time.Now(),
 	}))
 }
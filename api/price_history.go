@@ -0,0 +1,154 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	basemodels "github.com/SwiftFiat/SwiftFiat-Backend/models"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	pricehistory "github.com/SwiftFiat/SwiftFiat-Backend/services/price_history"
+	"github.com/gin-gonic/gin"
+)
+
+type PriceHistoryHandler struct {
+	server       *Server
+	logger       *logging.Logger
+	priceHistory *pricehistory.PriceHistoryService
+}
+
+func (h PriceHistoryHandler) router(server *Server) {
+	h.server = server
+	h.logger = server.logger
+	h.priceHistory = server.priceHistoryService
+
+	v1 := server.router.Group("/api/v1/price-history")
+	v1.Use(h.server.authMiddleware.AuthenticatedMiddleware())
+	{
+		v1.GET("/pairs", h.GetTrackedPairs)
+		v1.GET("/chart", h.GetChart)
+		v1.GET("/rate-at", h.GetRateAt)
+	}
+}
+
+// GetTrackedPairs godoc
+// @Summary List tracked currency pairs
+// @Description Lists the currency pairs sampled by the background price history collector
+// @Tags PriceHistory
+// @Produce json
+// @Success 200 {object} basemodels.SuccessResponse{data=[]pricehistory.TrackedPair}
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Router /api/v1/price-history/pairs [get]
+// @Security BearerAuth
+func (h *PriceHistoryHandler) GetTrackedPairs(c *gin.Context) {
+	c.JSON(http.StatusOK, basemodels.NewSuccess("tracked pairs fetched successfully", h.priceHistory.TrackedPairs()))
+}
+
+// GetChart godoc
+// @Summary Get OHLC chart data
+// @Description Returns OHLC candles for a currency pair from the local price history store
+// @Tags PriceHistory
+// @Produce json
+// @Param base query string true "Base currency (e.g. USDT)"
+// @Param quote query string true "Quote currency (e.g. NGN)"
+// @Param interval query string false "Candle interval (1m, 1h, 1d)" default(1h)
+// @Param start query string false "Start time (RFC3339), defaults to 24 hours ago"
+// @Param end query string false "End time (RFC3339), defaults to now"
+// @Success 200 {object} basemodels.SuccessResponse{data=pricehistory.ChartResponse}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/price-history/chart [get]
+// @Security BearerAuth
+func (h *PriceHistoryHandler) GetChart(c *gin.Context) {
+	base := strings.ToUpper(c.Query("base"))
+	quote := strings.ToUpper(c.Query("quote"))
+	if base == "" || quote == "" {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("base and quote are required"))
+		return
+	}
+
+	interval := c.DefaultQuery("interval", string(pricehistory.Interval1h))
+	if !pricehistory.IsCandleIntervalValid(interval) {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(pricehistory.ErrInvalidInterval.Error()))
+		return
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-24 * time.Hour)
+
+	if v := c.Query("start"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, basemodels.NewError("invalid start time, use RFC3339"))
+			return
+		}
+		start = t
+	}
+
+	if v := c.Query("end"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, basemodels.NewError("invalid end time, use RFC3339"))
+			return
+		}
+		end = t
+	}
+
+	chart, err := h.priceHistory.GetChart(c.Request.Context(), base, quote, pricehistory.CandleInterval(interval), start, end)
+	if err != nil {
+		if errors.Is(err, pricehistory.ErrInvalidTimeRange) || errors.Is(err, pricehistory.ErrInvalidInterval) {
+			c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		h.logger.Errorf("failed to get chart data: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get chart data"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("chart data fetched successfully", chart))
+}
+
+// GetRateAt godoc
+// @Summary Get historical rate
+// @Description Returns the rate that was in effect for a currency pair at a past moment
+// @Tags PriceHistory
+// @Produce json
+// @Param base query string true "Base currency (e.g. USDT)"
+// @Param quote query string true "Quote currency (e.g. NGN)"
+// @Param at query string true "Moment to look up (RFC3339)"
+// @Success 200 {object} basemodels.SuccessResponse{data=pricehistory.HistoricalRate}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/price-history/rate-at [get]
+// @Security BearerAuth
+func (h *PriceHistoryHandler) GetRateAt(c *gin.Context) {
+	base := strings.ToUpper(c.Query("base"))
+	quote := strings.ToUpper(c.Query("quote"))
+	if base == "" || quote == "" {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("base and quote are required"))
+		return
+	}
+
+	at, err := time.Parse(time.RFC3339, c.Query("at"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid or missing 'at', use RFC3339"))
+		return
+	}
+
+	rate, err := h.priceHistory.GetRateAt(c.Request.Context(), base, quote, at)
+	if err != nil {
+		if errors.Is(err, pricehistory.ErrNoHistoricalRate) {
+			c.JSON(http.StatusNotFound, basemodels.NewError(err.Error()))
+			return
+		}
+		h.logger.Errorf("failed to get historical rate: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get historical rate"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("historical rate fetched successfully", rate))
+}
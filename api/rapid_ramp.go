@@ -43,6 +43,7 @@ func (q QRCodeHandler) router(server *Server) {
 	v1.Use(q.server.authMiddleware.AuthenticatedMiddleware())
 	{
 		v1.POST("", q.CreateQRCode)
+		v1.POST("/quotes", q.CreateRampQuote)
 		v1.GET("", q.GetQRCodes)
 		v1.DELETE("/:qr_id", q.DeleteQRCode)
 		v1.GET("/transactions", q.GetQRTransactions)
@@ -106,7 +107,7 @@ func (q *QRCodeHandler) CreateQRCode(c *gin.Context) {
 			c,
 			audit.CategoryRapidRamp,
 			audit.EventCreateQrCode,
-			qrCode.ID.String(),
+			"",
 			"Failed to create QR code",
 			&activeUser.UserID,
 			activeUser.Role,
@@ -115,6 +116,10 @@ func (q *QRCodeHandler) CreateQRCode(c *gin.Context) {
 		)
 		q.audit.Log(e)
 
+		if status, ok := quoteErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -136,6 +141,52 @@ func (q *QRCodeHandler) CreateQRCode(c *gin.Context) {
 	c.JSON(http.StatusCreated, basemodels.NewSuccess("QR code generated successfully", qrCode))
 }
 
+// CreateRampQuote godoc
+// @Summary Quote a crypto payment
+// @Description Locks the NGN payout for a crypto amount in a single-use quote. Pass the returned quote_id when creating the QR code; the first payment of exactly that amount received before the quote expires is paid out at these terms.
+// @Tags QR Codes
+// @Accept json
+// @Produce json
+// @Param request body rapidramp.RampQuoteRequest true "Quote details"
+// @Success 200 {object} ratequote.Quote
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Router /api/v1/qr-codes/quotes [post]
+// @Security BearerAuth
+func (q *QRCodeHandler) CreateRampQuote(c *gin.Context) {
+	settings, err := q.server.queries.GetSystemSettings(c)
+	if err != nil {
+		q.server.logger.Error("Failed to get system settings", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.RapidRampEnabled.Bool {
+		c.JSON(http.StatusForbidden, basemodels.NewError("rapid ramp is disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		q.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	var req rapidramp.RampQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	quote, err := q.qrCodeService.QuoteRamp(c.Request.Context(), activeUser.UserID, &req)
+	if err != nil {
+		q.server.logger.Error("Failed to create ramp quote", "error", err)
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Quote created successfully", quote))
+}
+
 // GetQRCodes godoc
 // @Summary Get all QR codes
 // @Description Retrieves all QR codes for the authenticated user
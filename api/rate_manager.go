@@ -1,9 +1,12 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/SwiftFiat/SwiftFiat-Backend/api/apistrings"
 	"github.com/SwiftFiat/SwiftFiat-Backend/api/models"
@@ -40,9 +43,26 @@ func (r RateManagerHandler) router(server *Server) {
 	v.GET("/admin/rules/:id", r.GetRateAdjustmentRule)
 	v.GET("/admin/rules", r.ListRateAdjustmentRules)
 	v.PUT("/admin/rules/:id", r.UpdateRateAdjustmentRule)
+	v.PUT("/admin/rules/:id/toggle", r.ToggleRateAdjustmentRule)
 	v.DELETE("/admin/rules/:id", r.DeleteRateAdjustmentRule)
 	v.POST("/admin/simulate", r.SimulateRateAdjustment)
+	v.POST("/admin/explain-rate", r.ExplainRateForUser)
 	v.POST("/admin/vip-assignments", r.AssignUserToVIPLevel)
+
+	// consensus rate audit trail
+	v.GET("/admin/published-rates", r.ListPublishedRates)
+
+	// rate changes wait here until a second admin approves them
+	v.GET("/admin/change-requests", r.ListRateChangeRequests)
+	v.GET("/admin/change-requests/:id", r.GetRateChangeRequest)
+	v.POST("/admin/change-requests/:id/approve", r.ApproveRateChangeRequest)
+	v.POST("/admin/change-requests/:id/reject", r.RejectRateChangeRequest)
+	v.POST("/admin/change-requests/:id/cancel", r.CancelRateChangeRequest)
+
+	// public holidays for holiday time windows and exclusions
+	v.GET("/admin/holidays", r.ListPublicHolidays)
+	v.POST("/admin/holidays", r.CreatePublicHoliday)
+	v.DELETE("/admin/holidays/:id", r.DeletePublicHoliday)
 }
 
 // CreateVIPLevel godoc
@@ -270,13 +290,13 @@ func (r *RateManagerHandler) DeleteVIPLevel(c *gin.Context) {
 
 // CreateRateAdjustmentRule godoc
 // @Summary Create rate adjustment rule
-// @Description Create a new rate adjustment rule for VIP levels or global
+// @Description Submit a new rate adjustment rule for VIP levels or global. The rule takes effect once a second admin approves it.
 // @Tags Rate Manager - Rules
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body ratemanager.CreateRateAdjustmentRuleRequest true "Rule creation request"
-// @Success 201 {object} ratemanager.RateAdjustmentRule
+// @Success 202 {object} ratemanager.RateChangeRequest
 // @Failure 400 {object} basemodels.ErrorResponse
 // @Failure 409 {object} basemodels.ErrorResponse
 // @Router /admin/rate-manager/rules [post]
@@ -294,10 +314,10 @@ func (r *RateManagerHandler) CreateRateAdjustmentRule(c *gin.Context) {
 		return
 	}
 
-	// if activeUser.Role == models.USER {
-	// 	c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
-	// 	return
-	// }
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
 
 	user, err := r.server.queries.GetUserByID(c, activeUser.UserID)
 	if err != nil {
@@ -306,14 +326,18 @@ func (r *RateManagerHandler) CreateRateAdjustmentRule(c *gin.Context) {
 		return
 	}
 
-	rule, err := r.service.CreateRateAdjustmentRule(c.Request.Context(), &req, &user)
+	change, err := r.service.SubmitRuleCreation(c.Request.Context(), &req, &user)
 	if err != nil {
-		r.server.logger.Errorf("failed to create adjustment rule: %v", err)
-		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to create adjustment rule"))
+		if status, ok := rateChangeErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		r.server.logger.Errorf("failed to submit adjustment rule: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to submit adjustment rule"))
 		return
 	}
 
-	c.JSON(http.StatusCreated, basemodels.NewSuccess("Rate adjustment rule created successfully", rule))
+	c.JSON(http.StatusAccepted, basemodels.NewSuccess("Rate adjustment rule submitted for approval", change))
 }
 
 // GetRateAdjustmentRule godoc
@@ -404,14 +428,14 @@ func (r *RateManagerHandler) ListRateAdjustmentRules(c *gin.Context) {
 
 // UpdateRateAdjustmentRule godoc
 // @Summary Update rate adjustment rule
-// @Description Update rate adjustment rule details
+// @Description Submit an update to a rate adjustment rule. The update takes effect once a second admin approves it.
 // @Tags Rate Manager - Rules
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Rule ID"
 // @Param request body ratemanager.UpdateRateAdjustmentRuleRequest true "Rule update request"
-// @Success 200 {object} ratemanager.RateAdjustmentRule
+// @Success 202 {object} ratemanager.RateChangeRequest
 // @Failure 400 {object} basemodels.ErrorResponse
 // @Failure 404 {object} basemodels.ErrorResponse
 // @Router /admin/rate-manager/rules/{id} [put]
@@ -423,10 +447,10 @@ func (r *RateManagerHandler) UpdateRateAdjustmentRule(c *gin.Context) {
 		return
 	}
 
-	// if activeUser.Role == models.USER {
-	// 	c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
-	// 	return
-	// }
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
 
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -454,15 +478,78 @@ func (r *RateManagerHandler) UpdateRateAdjustmentRule(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get user"))
 		return
 	}
-	updatedRule, err := r.service.UpdateRateAdjustmentRule(c.Request.Context(), rule.ID, &req, &user)
+	change, err := r.service.SubmitRuleUpdate(c.Request.Context(), rule.ID, &req, &user)
+	if err != nil {
+		if status, ok := rateChangeErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		r.server.logger.Errorf("failed to submit adjustment rule update: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to submit adjustment rule update"))
+		return
+	}
+
+	r.server.logger.Info(fmt.Sprintf("UpdateRateAdjustmentRule submitted change request: %s", change.ID))
+	c.JSON(http.StatusAccepted, basemodels.NewSuccess("Rule update submitted for approval", change))
+}
+
+// ToggleRateAdjustmentRule godoc
+// @Summary Enable or disable rate adjustment rule
+// @Description Submit enabling or disabling a rate adjustment rule. The change takes effect once a second admin approves it.
+// @Tags Rate Manager - Rules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Rule ID"
+// @Param request body ratemanager.ToggleRateAdjustmentRuleRequest true "Toggle request"
+// @Success 202 {object} ratemanager.RateChangeRequest
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/rules/{id}/toggle [put]
+func (r *RateManagerHandler) ToggleRateAdjustmentRule(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		r.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid rule id"))
+		return
+	}
+
+	var req ratemanager.ToggleRateAdjustmentRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	user, err := r.server.queries.GetUserByID(c, activeUser.UserID)
+	if err != nil {
+		r.server.logger.Errorf("failed to get user: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get user"))
+		return
+	}
+
+	change, err := r.service.SubmitRuleToggle(c.Request.Context(), id, *req.Enabled, &user)
 	if err != nil {
-		r.server.logger.Errorf("failed to update adjustment rule: %v", err)
-		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to update adjustment rule"))
+		if status, ok := rateChangeErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		r.server.logger.Errorf("failed to submit adjustment rule toggle: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to submit adjustment rule toggle"))
 		return
 	}
 
-	r.server.logger.Info(fmt.Sprintf("UpdateRateAdjustmentRule updated rule: %+v", updatedRule))
-	c.JSON(http.StatusOK, basemodels.NewSuccess("Rule updated successfully", updatedRule))
+	c.JSON(http.StatusAccepted, basemodels.NewSuccess("Rule status change submitted for approval", change))
 }
 
 // DeleteRateAdjustmentRule godoc
@@ -548,6 +635,49 @@ func (r *RateManagerHandler) SimulateRateAdjustment(c *gin.Context) {
 	c.JSON(http.StatusOK, basemodels.NewSuccess("", simulation))
 }
 
+// ExplainRateForUser godoc
+// @Summary Explain a user's rate
+// @Description Show which rule would price a user's quote on a channel, optionally at another time, and why every other candidate rule lost or did not match
+// @Tags Rate Manager - Simulation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ratemanager.ExplainRateRequest true "Quote to explain"
+// @Success 200 {object} ratemanager.RateSimulationResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/explain-rate [post]
+func (r *RateManagerHandler) ExplainRateForUser(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	var req ratemanager.ExplainRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	rate, err := r.service.ExplainRateForUser(c.Request.Context(), req)
+	if err != nil {
+		if status, ok := rateChangeErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		r.server.logger.Errorf("failed to explain rate: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("Failed to explain rate"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", rate))
+}
+
 // GetCurrentRateWithAdjustment godoc
 // @Summary Get current rate with adjustment
 // @Description Get current exchange rate with VIP adjustment applied
@@ -557,6 +687,7 @@ func (r *RateManagerHandler) SimulateRateAdjustment(c *gin.Context) {
 // @Param from query string true "Source currency"
 // @Param to query string true "Target currency"
 // @Param amount query string true "Amount to convert"
+// @Param channel query string false "Pricing channel: app (default), rapid_ramp or smart_conversion"
 // @Success 200 {object} ratemanager.RateSimulationResponse
 // @Failure 400 {object} basemodels.ErrorResponse
 // @Router /rate-manager/current-rate [get]
@@ -578,13 +709,24 @@ func (r *RateManagerHandler) GetCurrentRateWithAdjustment(c *gin.Context) {
 		return
 	}
 
-	rate, err := r.service.GetAdjustedRateForUser(c.Request.Context(), user_id, from, to, amount)
+	channel := ratemanager.RateChannel(c.DefaultQuery("channel", string(ratemanager.ChannelApp)))
+	if !channel.IsValid() {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("channel must be one of app, rapid_ramp, smart_conversion"))
+		return
+	}
+
+	rate, err := r.service.GetAdjustedRateForUser(c.Request.Context(), user_id, from, to, amount, channel)
 	if err != nil {
 		r.server.logger.Errorf("failed to get adjusted rate: %v", err)
 		c.JSON(http.StatusInternalServerError, basemodels.NewError("Failed to get adjusted rate"))
 		return
 	}
 
+	// Rule internals are for admins only
+	if activeUser.Role == models.USER {
+		rate.Explanation = nil
+	}
+
 	c.JSON(http.StatusOK, basemodels.NewSuccess("", rate))
 }
 
@@ -663,3 +805,439 @@ func (r *RateManagerHandler) GetUserVIPLevel(c *gin.Context) {
 
 	c.JSON(http.StatusOK, basemodels.NewSuccess("User vip status retrieved successfully", vipStatus))
 }
+
+// ListPublishedRates godoc
+// @Summary List published consensus rates
+// @Description Lists the rates published by the multi-source aggregator for a pair, newest first, with each source's quote and why it was accepted or rejected
+// @Tags Rate Manager - Rates
+// @Produce json
+// @Security BearerAuth
+// @Param base query string true "Base currency (e.g. USD)"
+// @Param quote query string true "Quote currency (e.g. NGN)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Number of items per page" default(20)
+// @Success 200 {object} basemodels.SuccessResponse{data=[]db.PublishedRate}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/published-rates [get]
+func (r *RateManagerHandler) ListPublishedRates(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	base := strings.ToUpper(c.Query("base"))
+	quote := strings.ToUpper(c.Query("quote"))
+	if base == "" || quote == "" {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("base and quote are required"))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	rates, err := r.server.scExchangeRateservice.ListPublishedRates(c.Request.Context(), base, quote, int32(limit), int32(offset))
+	if err != nil {
+		r.server.logger.Errorf("failed to list published rates: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("Failed to list published rates"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", rates))
+}
+
+// ListRateChangeRequests godoc
+// @Summary List rate change requests
+// @Description List rate manager changes awaiting or past review, newest first
+// @Tags Rate Manager - Approvals
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "pending, approved, rejected, cancelled or failed"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Number of items per page" default(20)
+// @Success 200 {object} ratemanager.PaginatedResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/change-requests [get]
+func (r *RateManagerHandler) ListRateChangeRequests(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	var params ratemanager.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	changes, err := r.service.ListRateChangeRequests(c.Request.Context(), c.Query("status"), &params)
+	if err != nil {
+		r.server.logger.Errorf("failed to list rate change requests: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("Failed to list rate change requests"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", changes))
+}
+
+// GetRateChangeRequest godoc
+// @Summary Get rate change request
+// @Description Get a rate change request. Pending requests include a before/after preview priced at current rates.
+// @Tags Rate Manager - Approvals
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change request ID"
+// @Success 200 {object} ratemanager.RateChangeRequest
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/change-requests/{id} [get]
+func (r *RateManagerHandler) GetRateChangeRequest(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid change request id"))
+		return
+	}
+
+	change, err := r.service.GetRateChangeRequest(c.Request.Context(), id)
+	if err != nil {
+		if status, ok := rateChangeErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		r.server.logger.Errorf("failed to get rate change request: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("Failed to get rate change request"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", change))
+}
+
+// ApproveRateChangeRequest godoc
+// @Summary Approve rate change request
+// @Description Apply a pending rate change. Only a super admin other than the requester can approve.
+// @Tags Rate Manager - Approvals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change request ID"
+// @Param request body ratemanager.ReviewRateChangeRequest false "Review note"
+// @Success 200 {object} ratemanager.RateChangeRequest
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/change-requests/{id}/approve [post]
+func (r *RateManagerHandler) ApproveRateChangeRequest(c *gin.Context) {
+	r.reviewRateChangeRequest(c, true)
+}
+
+// RejectRateChangeRequest godoc
+// @Summary Reject rate change request
+// @Description Close a pending rate change without applying it. Only a super admin other than the requester can reject.
+// @Tags Rate Manager - Approvals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change request ID"
+// @Param request body ratemanager.ReviewRateChangeRequest false "Review note"
+// @Success 200 {object} ratemanager.RateChangeRequest
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/change-requests/{id}/reject [post]
+func (r *RateManagerHandler) RejectRateChangeRequest(c *gin.Context) {
+	r.reviewRateChangeRequest(c, false)
+}
+
+func (r *RateManagerHandler) reviewRateChangeRequest(c *gin.Context, approve bool) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role != models.SUPER_ADMIN {
+		c.JSON(http.StatusForbidden, basemodels.NewError("Only super admins can review rate changes"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid change request id"))
+		return
+	}
+
+	var req ratemanager.ReviewRateChangeRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+	}
+
+	user, err := r.server.queries.GetUserByID(c, activeUser.UserID)
+	if err != nil {
+		r.server.logger.Errorf("failed to get user: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get user"))
+		return
+	}
+
+	var change *ratemanager.RateChangeRequest
+	message := "Rate change approved and applied"
+	if approve {
+		change, err = r.service.ApproveRateChange(c.Request.Context(), id, &user, req.Note)
+	} else {
+		change, err = r.service.RejectRateChange(c.Request.Context(), id, &user, req.Note)
+		message = "Rate change rejected"
+	}
+	if err != nil {
+		if status, ok := rateChangeErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		r.server.logger.Errorf("failed to review rate change request %s: %v", id, err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(fmt.Sprintf("Failed to apply rate change: %v", err)))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess(message, change))
+}
+
+// CancelRateChangeRequest godoc
+// @Summary Cancel rate change request
+// @Description Withdraw a pending rate change you submitted
+// @Tags Rate Manager - Approvals
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Change request ID"
+// @Success 200 {object} ratemanager.RateChangeRequest
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/change-requests/{id}/cancel [post]
+func (r *RateManagerHandler) CancelRateChangeRequest(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid change request id"))
+		return
+	}
+
+	user, err := r.server.queries.GetUserByID(c, activeUser.UserID)
+	if err != nil {
+		r.server.logger.Errorf("failed to get user: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get user"))
+		return
+	}
+
+	change, err := r.service.CancelRateChange(c.Request.Context(), id, &user)
+	if err != nil {
+		if status, ok := rateChangeErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		r.server.logger.Errorf("failed to cancel rate change request: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("Failed to cancel rate change request"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Rate change cancelled", change))
+}
+
+// ListPublicHolidays godoc
+// @Summary List public holidays
+// @Description List public holidays used by rate rule time windows, defaulting to the current year
+// @Tags Rate Manager - Holidays
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {object} basemodels.SuccessResponse{data=[]ratemanager.PublicHoliday}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/holidays [get]
+func (r *RateManagerHandler) ListPublicHolidays(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	year := time.Now().Year()
+	from, err := time.Parse("2006-01-02", c.DefaultQuery("from", fmt.Sprintf("%d-01-01", year)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("from must be YYYY-MM-DD"))
+		return
+	}
+	to, err := time.Parse("2006-01-02", c.DefaultQuery("to", fmt.Sprintf("%d-12-31", year)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("to must be YYYY-MM-DD"))
+		return
+	}
+
+	holidays, err := r.service.ListPublicHolidays(c.Request.Context(), from, to)
+	if err != nil {
+		r.server.logger.Errorf("failed to list public holidays: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("Failed to list public holidays"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", holidays))
+}
+
+// CreatePublicHoliday godoc
+// @Summary Add public holiday
+// @Description Add a date that holiday time windows and holiday exclusions on rate rules match
+// @Tags Rate Manager - Holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ratemanager.CreatePublicHolidayRequest true "Holiday"
+// @Success 201 {object} ratemanager.PublicHoliday
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/holidays [post]
+func (r *RateManagerHandler) CreatePublicHoliday(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	var req ratemanager.CreatePublicHolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	user, err := r.server.queries.GetUserByID(c, activeUser.UserID)
+	if err != nil {
+		r.server.logger.Errorf("failed to get user: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get user"))
+		return
+	}
+
+	holiday, err := r.service.CreatePublicHoliday(c.Request.Context(), &req, &user)
+	if err != nil {
+		if status, ok := rateChangeErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		r.server.logger.Errorf("failed to create public holiday: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("Failed to create public holiday"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, basemodels.NewSuccess("Public holiday added", holiday))
+}
+
+// DeletePublicHoliday godoc
+// @Summary Remove public holiday
+// @Description Remove a public holiday
+// @Tags Rate Manager - Holidays
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Holiday ID"
+// @Success 200 {object} basemodels.SuccessResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/rate-manager/admin/holidays/{id} [delete]
+func (r *RateManagerHandler) DeletePublicHoliday(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid holiday id"))
+		return
+	}
+
+	user, err := r.server.queries.GetUserByID(c, activeUser.UserID)
+	if err != nil {
+		r.server.logger.Errorf("failed to get user: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get user"))
+		return
+	}
+
+	if err := r.service.DeletePublicHoliday(c.Request.Context(), id, &user); err != nil {
+		if status, ok := rateChangeErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		r.server.logger.Errorf("failed to delete public holiday: %v", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("Failed to delete public holiday"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Public holiday removed", nil))
+}
+
+// rateChangeErrorStatus maps rate manager errors a client can act on to an HTTP status
+func rateChangeErrorStatus(err error) (int, bool) {
+	var rmErr *ratemanager.RateManagerError
+	if !errors.As(err, &rmErr) {
+		return 0, false
+	}
+
+	switch rmErr {
+	case ratemanager.ErrRuleNotFound, ratemanager.ErrChangeRequestNotFound, ratemanager.ErrHolidayNotFound:
+		return http.StatusNotFound, true
+	case ratemanager.ErrChangeRequestNotPending, ratemanager.ErrDuplicateGlobalRule, ratemanager.ErrHolidayExists:
+		return http.StatusConflict, true
+	case ratemanager.ErrSelfApproval:
+		return http.StatusForbidden, true
+	}
+	return http.StatusBadRequest, true
+}
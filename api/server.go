@@ -26,12 +26,16 @@ import (
 	chatsupport "github.com/SwiftFiat/SwiftFiat-Backend/services/chat_support"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/currency"
 	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
+	fxrevenue "github.com/SwiftFiat/SwiftFiat-Backend/services/fx_revenue"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/tasks"
 	service "github.com/SwiftFiat/SwiftFiat-Backend/services/notification"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/portfolio"
 	pricealert "github.com/SwiftFiat/SwiftFiat-Backend/services/price_alert"
+	pricehistory "github.com/SwiftFiat/SwiftFiat-Backend/services/price_history"
 	rapidramp "github.com/SwiftFiat/SwiftFiat-Backend/services/rapid_ramp"
 	ratemanager "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_manager"
+	ratequote "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_quote"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/redis"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/rewards"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/security"
@@ -83,7 +87,11 @@ type Server struct {
 	smartConversionScheduler *smartconversion.Scheduler
 	auditService             *audit.Service
 	rateManager              *ratemanager.Service
+	rateQuoteService         *ratequote.Service
+	fxRevenueService         *fxrevenue.Service
 	virtualcard              *virtualcard.Service
+	cardBillingScheduler     *virtualcard.BillingScheduler
+	cardDisputeScheduler     *virtualcard.DisputeScheduler
 	bridgecard               *bridgecards.BridgeCardProvider
 	subscriptions            *subscriptions.Service
 	subscriptionScheduler    *subscriptions.Scheduler
@@ -97,6 +105,11 @@ type Server struct {
 	marketInsightsService    *coindesk.MarketInsightsService
 	priceAlertSvc            *pricealert.PriceAlertService
 	priceAlertScheduler      *pricealert.AlertScheduler
+	priceHistoryService      *pricehistory.PriceHistoryService
+	priceHistoryScheduler    *pricehistory.PriceHistoryScheduler
+	publishedRateScheduler   *exchangerate.PublishedRateScheduler
+	portfolioService         *portfolio.PortfolioService
+	portfolioScheduler       *portfolio.PortfolioScheduler
 	sessionManager           *SessionManager  // refresh-token + multi-device sessions
 	anomalyDetector          *AnomalyDetector // real-time auth threat signals
 }
@@ -234,8 +247,9 @@ func NewServer(envPath string) *Server {
 	// currency service
 	cs := currency.NewCurrencyService(q, l)
 
-	// smart conversion exchange rate service
-	scex := exchangerate.NewExchangeRateService(cryptomus, l)
+	// exchange rate service (multi-source consensus rates)
+	scex := exchangerate.NewExchangeRateService(q, cryptomus, rp, ns, l)
+	prs := exchangerate.NewPublishedRateScheduler(t, scex, l, 0)
 
 	// Rates manager
 	rm := ratemanager.NewService(q, scex, ads, l, pn, r)
@@ -243,8 +257,12 @@ func NewServer(envPath string) *Server {
 	// transaction service
 	txs := transaction.NewTransactionService(q, cs, ws, l, c, ns, pn, streakScheduler, bp, rs, ads, r, fp, rm)
 
+	// fx spread and fee revenue attribution
+	fxr := fxrevenue.NewService(q, scex, l)
+
 	// qrcode service
-	qr := rapidramp.NewQRCodeService(q, l, cryptomus, p, c, rm)
+	rq := ratequote.NewService(q, c.QuoteSigningKey, l)
+	qr := rapidramp.NewQRCodeService(q, l, cryptomus, p, c, rm, rq, fxr)
 
 	qrScheduler := rapidramp.NewRapidRampScheduler(
 		t,
@@ -255,13 +273,27 @@ func NewServer(envPath string) *Server {
 	)
 
 	// smart conversion service
-	scs := smartconversion.NewConversionService(q, l, rm, scex, txs, streakScheduler, ns, pn)
+	scs := smartconversion.NewConversionService(q, l, rm, scex, rq, fxr, txs, streakScheduler, ns, pn)
 
 	// smart conversion scheduler
 	scsScheduler := smartconversion.NewScheduler(t, q, l, scs, 0)
 
 	// virtual card service
-	vcs := virtualcard.NewService(q, l, bridgecard, ws, streakScheduler, ns, email, pn, ss, c)
+	vcs := virtualcard.NewService(q, l, bridgecard, ws, streakScheduler, ns, email, pn, ss, fxr, c)
+
+	// vault auto-save rules react to bill, transfer, crypto and card money movements
+	txs.SetAutoSaver(vs)
+	vcs.SetAutoSaver(vs)
+
+	// card maintenance billing scheduler
+	cbScheduler := virtualcard.NewBillingScheduler(t, vcs, l, 24*time.Hour)
+
+	// card dispute SLA scheduler
+	cdScheduler := virtualcard.NewDisputeScheduler(t, vcs, l, 1*time.Hour)
+
+	// vaults can be funded from a wallet in another currency through smart conversion
+	vs.SetFundingConverter(scs)
+	vs.SetYieldService(ys)
 
 	// subscription scheduler
 	ssScheduler := subscriptions.NewScheduler(t, ss, q, l, 1*time.Hour)
@@ -275,16 +307,28 @@ func NewServer(envPath string) *Server {
 	// tickets
 	ticket := chatsupport.NewTicketService(q, l, ns, email, pn)
 
+	// card disputes are worked in a linked support ticket
+	vcs.SetTicketService(ticket)
+
 	// admin support
 	support := chatsupport.NewSupportAdminService(q, l)
 
-	// price alert
-	pa := pricealert.NewPriceAlertService(q, l, scex, ns, pn, 0)
+	// price history (local rate time-series + OHLC candles)
+	ph := pricehistory.NewPriceHistoryService(q, scex, l, nil)
+	phs := pricehistory.NewPriceHistoryScheduler(t, ph, l, 0)
+
+	// price alert (indicator conditions read candles from price history)
+	pa := pricealert.NewPriceAlertService(q, l, scex, ns, pn, email, c, ph, scs, 0)
 	pas := pricealert.NewAlertScheduler(t, q, pa, l, 0)
 
+	// portfolio valuation + daily net-worth snapshots
+	pf := portfolio.NewPortfolioService(q, scex, vcs, l)
+	pfs := portfolio.NewPortfolioScheduler(t, pf, l, 0)
+
 	// Initialize WebSocket Hub
 	wsHub := NewHub(l)
 	go wsHub.Run()
+	pa.SetRealtimePublisher(wsHub)
 
 	// market insight
 	insights := coindesk.NewMarketInsightsService(l, pn, us)
@@ -341,7 +385,11 @@ func NewServer(envPath string) *Server {
 		smartConversionScheduler: scsScheduler,
 		auditService:             ads,
 		rateManager:              rm,
+		rateQuoteService:         rq,
+		fxRevenueService:         fxr,
 		virtualcard:              vcs,
+		cardBillingScheduler:     cbScheduler,
+		cardDisputeScheduler:     cdScheduler,
 		bridgecard:               bridgecard,
 		subscriptions:            ss,
 		subscriptionScheduler:    ssScheduler,
@@ -355,6 +403,11 @@ func NewServer(envPath string) *Server {
 		marketInsightsService:    insights,
 		priceAlertSvc:            pa,
 		priceAlertScheduler:      pas,
+		priceHistoryService:      ph,
+		priceHistoryScheduler:    phs,
+		publishedRateScheduler:   prs,
+		portfolioService:         pf,
+		portfolioScheduler:       pfs,
 		sessionManager:           sm,
 		anomalyDetector:          ad,
 	}
@@ -417,6 +470,9 @@ func (s *Server) Start() error {
 	WebSocketHandler{}.router(s)
 	MarketInsights{}.router(s)
 	PriceAlertHandler{}.router(s)
+	PriceHistoryHandler{}.router(s)
+	PortfolioHandler{}.router(s)
+	FxRevenueHandler{}.router(s)
 
 	/// TODO: Register all server dependent services to be accessible from SERVER
 	// e.g. s.RegisterService({services.wallet, WalletService})
@@ -460,6 +516,22 @@ func (s *Server) Start() error {
 		}
 	}
 
+	// Start card maintenance billing scheduler
+	if s.cardBillingScheduler != nil {
+		if err := s.cardBillingScheduler.Start(); err != nil {
+			s.logger.Error("Failed to start card billing scheduler", "error", err)
+			s.inAppnotificationService.CreateAdminAlert(context.Background(), "error", "Failed to start card billing scheduler", err.Error(), "card-billing-scheduler")
+		}
+	}
+
+	// Start card dispute SLA scheduler
+	if s.cardDisputeScheduler != nil {
+		if err := s.cardDisputeScheduler.Start(); err != nil {
+			s.logger.Error("Failed to start card dispute scheduler", "error", err)
+			s.inAppnotificationService.CreateAdminAlert(context.Background(), "error", "Failed to start card dispute scheduler", err.Error(), "card-dispute-scheduler")
+		}
+	}
+
 	// Start streak scheduler
 	if s.streakScheduler != nil {
 		if err := s.streakScheduler.Start(); err != nil {
@@ -476,6 +548,29 @@ func (s *Server) Start() error {
 		}
 	}
 
+	// start price history scheduler
+	if s.priceHistoryScheduler != nil {
+		if err := s.priceHistoryScheduler.Start(); err != nil {
+			s.logger.Error("Failed to start price history scheduler", "error", err)
+			s.inAppnotificationService.CreateAdminAlert(context.Background(), "error", "Failed to start price history scheduler", err.Error(), "price-history-scheduler")
+		}
+	}
+
+	// start published rate retention
+	if s.publishedRateScheduler != nil {
+		if err := s.publishedRateScheduler.Start(); err != nil {
+			s.logger.Error("Failed to start published rate scheduler", "error", err)
+			s.inAppnotificationService.CreateAdminAlert(context.Background(), "error", "Failed to start published rate scheduler", err.Error(), "published-rate-scheduler")
+		}
+	}
+
+	if s.portfolioScheduler != nil {
+		if err := s.portfolioScheduler.Start(); err != nil {
+			s.logger.Error("Failed to start portfolio scheduler", "error", err)
+			s.inAppnotificationService.CreateAdminAlert(context.Background(), "error", "Failed to start portfolio scheduler", err.Error(), "portfolio-scheduler")
+		}
+	}
+
 	// Start bill transaction reconciler
 	// Fixes the crash-between-debit-and-commit window for airtime, data, TV, and electricity purchases
 	go func() {
@@ -539,6 +634,20 @@ func (s *Server) Shutdown(ctx context.Context) error {
 			}
 		}
 
+		// Stop card maintenance billing scheduler
+		if s.cardBillingScheduler != nil {
+			if err := s.cardBillingScheduler.Stop(); err != nil {
+				s.logger.Warn("Error stopping card billing scheduler", "error", err)
+			}
+		}
+
+		// Stop card dispute SLA scheduler
+		if s.cardDisputeScheduler != nil {
+			if err := s.cardDisputeScheduler.Stop(); err != nil {
+				s.logger.Warn("Error stopping card dispute scheduler", "error", err)
+			}
+		}
+
 		// Stop streak scheduler
 		if s.streakScheduler != nil {
 			if err := s.streakScheduler.Stop(); err != nil {
@@ -553,6 +662,26 @@ func (s *Server) Shutdown(ctx context.Context) error {
 			}
 		}
 
+		// stop price history scheduler
+		if s.priceHistoryScheduler != nil {
+			if err := s.priceHistoryScheduler.Stop(); err != nil {
+				s.logger.Error("Failed to stop price history scheduler", "error", err)
+			}
+		}
+
+		// stop published rate retention
+		if s.publishedRateScheduler != nil {
+			if err := s.publishedRateScheduler.Stop(); err != nil {
+				s.logger.Error("Failed to stop published rate scheduler", "error", err)
+			}
+		}
+
+		if s.portfolioScheduler != nil {
+			if err := s.portfolioScheduler.Stop(); err != nil {
+				s.logger.Error("Failed to stop portfolio scheduler", "error", err)
+			}
+		}
+
 		// Close Redis connection with context awareness
 		if err := s.redis.Close(); err != nil {
 			s.logger.Error("Error closing Redis connection", "error", err)
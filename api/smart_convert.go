@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -11,6 +13,7 @@ import (
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/audit"
 	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	ratequote "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_quote"
 	smartconversion "github.com/SwiftFiat/SwiftFiat-Backend/services/smart_conversion"
 	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
 	"github.com/gin-gonic/gin"
@@ -22,6 +25,7 @@ type SmartConvertHandler struct {
 	logger          *logging.Logger
 	exchangeRateSvc *exchangerate.ExchangeRateService
 	conversionSvc   *smartconversion.ConversionService
+	quoteSvc        *ratequote.Service
 	audit           *audit.Service
 }
 
@@ -30,6 +34,7 @@ func (s SmartConvertHandler) router(server *Server) {
 	s.logger = s.server.logger
 	s.exchangeRateSvc = s.server.scExchangeRateservice
 	s.conversionSvc = s.server.smartConvertService
+	s.quoteSvc = s.server.rateQuoteService
 	s.audit = s.server.auditService
 
 	v1 := server.router.Group("/api/v1/smart-convert")
@@ -41,9 +46,23 @@ func (s SmartConvertHandler) router(server *Server) {
 		v1.POST("/rules/:rule_id/pause", s.PauseConversionRule)
 		v1.POST("/rules/:rule_id/resume", s.ResumeConversionRule)
 		v1.DELETE("/rules/:rule_id", s.DeleteConversionRule)
+		v1.POST("/quotes", s.CreateConversionQuote)
+		v1.GET("/quotes/:quote_id", s.GetConversionQuote)
 		v1.POST("/execute", s.ExecuteManualConversion)
+		v1.POST("/orders", s.PlaceLimitOrder)
+		v1.GET("/orders", s.ListLimitOrders)
+		v1.GET("/orders/:order_id", s.GetLimitOrder)
+		v1.POST("/orders/:order_id/cancel", s.CancelLimitOrder)
+		v1.POST("/dca", s.CreateDCAPlan)
+		v1.GET("/dca", s.ListDCAPlans)
+		v1.GET("/dca/:plan_id", s.GetDCAPlan)
+		v1.PATCH("/dca/:plan_id", s.UpdateDCAPlan)
+		v1.POST("/dca/:plan_id/pause", s.PauseDCAPlan)
+		v1.POST("/dca/:plan_id/resume", s.ResumeDCAPlan)
+		v1.DELETE("/dca/:plan_id", s.CancelDCAPlan)
 		v1.GET("/admin/rules", s.GetAllConversionRules)
 		v1.GET("/admin/history", s.GetAllConversionHistory)
+		v1.GET("/admin/quotes/:quote_id", s.GetQuoteAdmin)
 	}
 }
 
@@ -501,6 +520,14 @@ func (s *SmartConvertHandler) ExecuteManualConversion(c *gin.Context) {
 			c.JSON(http.StatusServiceUnavailable, basemodels.NewError("exchange rate not available for the requested currency pair"))
 			return
 		}
+		if errors.Is(err, exchangerate.ErrNoConsensusRate) {
+			c.JSON(http.StatusServiceUnavailable, basemodels.NewError("conversions for this currency pair are temporarily halted, please try again shortly"))
+			return
+		}
+		if status, ok := quoteErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
 		s.logger.Error("Failed to execute conversion", "error", err)
 		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
 		return
@@ -525,12 +552,717 @@ func (s *SmartConvertHandler) ExecuteManualConversion(c *gin.Context) {
 		"source_amount": result.SourceAmount,
 		"target_amount": result.TargetAmount,
 		"status":        result.Status,
+		"quote_id":      result.QuoteID,
 	}
 	s.audit.Log(entry)
 
 	c.JSON(http.StatusOK, basemodels.NewSuccess("Conversion executed successfully", result))
 }
 
+// CreateConversionQuote godoc
+// @Summary Quote a manual conversion
+// @Description Locks the rate, fees and net amount for a conversion in a single-use quote. Pass the returned quote_id to /execute before it expires to convert at exactly these terms.
+// @Tags Conversion
+// @Accept json
+// @Produce json
+// @Param request body smartconversion.ConversionQuoteRequest true "Quote details"
+// @Success 200 {object} ratequote.Quote
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Router /api/v1/smart-convert/quotes [post]
+// @Security BearerAuth
+func (s *SmartConvertHandler) CreateConversionQuote(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	var req smartconversion.ConversionQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	user, err := s.server.queries.GetUserByID(c.Request.Context(), activeUser.UserID)
+	if err != nil {
+		s.logger.Error("Failed to fetch user", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+	if !user.IsActive {
+		c.JSON(http.StatusForbidden, basemodels.NewError(apistrings.DeactivatedAccount))
+		return
+	}
+
+	quote, err := s.conversionSvc.QuoteConversion(c.Request.Context(), &req, &user)
+	if err != nil {
+		if errors.Is(err, exchangerate.ErrNoConsensusRate) {
+			c.JSON(http.StatusServiceUnavailable, basemodels.NewError("conversions for this currency pair are temporarily halted, please try again shortly"))
+			return
+		}
+		s.logger.Error("Failed to create conversion quote", "error", err)
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Quote created successfully", quote))
+}
+
+// GetConversionQuote godoc
+// @Summary Get a quote
+// @Description Retrieves one of the authenticated user's quotes, including whether and by which transaction it was used
+// @Tags Conversion
+// @Produce json
+// @Param quote_id path string true "Quote ID"
+// @Success 200 {object} ratequote.Quote
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/smart-convert/quotes/{quote_id} [get]
+// @Security BearerAuth
+func (s *SmartConvertHandler) GetConversionQuote(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	quote, err := s.quoteSvc.Get(c.Request.Context(), c.Param("quote_id"))
+	if err == nil && quote.UserID != activeUser.UserID {
+		err = ratequote.ErrQuoteNotFound
+	}
+	if err != nil {
+		if status, ok := quoteErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		s.logger.Error("Failed to fetch quote", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", quote))
+}
+
+// GetQuoteAdmin godoc
+// @Summary Get any quote
+// @Description Retrieves a conversion or ramp quote by quote ID or bare UUID for dispute resolution
+// @Tags Conversion
+// @Produce json
+// @Param quote_id path string true "Quote ID or UUID"
+// @Success 200 {object} ratequote.Quote
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/smart-convert/admin/quotes/{quote_id} [get]
+// @Security BearerAuth
+func (s *SmartConvertHandler) GetQuoteAdmin(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusForbidden, basemodels.NewError("unauthorized access"))
+		return
+	}
+
+	quote, err := s.quoteSvc.Get(c.Request.Context(), c.Param("quote_id"))
+	if err != nil {
+		if status, ok := quoteErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		s.logger.Error("Failed to fetch quote", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", quote))
+}
+
+// quoteErrorStatus maps a rate quote error to its HTTP status
+func quoteErrorStatus(err error) (int, bool) {
+	var quoteErr *ratequote.QuoteError
+	if !errors.As(err, &quoteErr) {
+		return 0, false
+	}
+
+	switch quoteErr {
+	case ratequote.ErrQuoteNotFound:
+		return http.StatusNotFound, true
+	case ratequote.ErrQuoteExpired, ratequote.ErrQuoteUsed, ratequote.ErrQuoteUnavailable:
+		return http.StatusConflict, true
+	default:
+		return http.StatusBadRequest, true
+	}
+}
+
+// PlaceLimitOrder godoc
+// @Summary Place a limit order
+// @Description Holds the source amount and converts it once the user's rate reaches limit_rate. Orders fill oldest first within a per-pair liquidity cap each interval, so large orders may fill in parts. gtc orders stay open until cancelled; gtd orders expire at expires_at and return any unfilled amount.
+// @Tags Conversion
+// @Accept json
+// @Produce json
+// @Param request body smartconversion.PlaceLimitOrderRequest true "Order details"
+// @Success 201 {object} smartconversion.LimitOrder
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Router /api/v1/smart-convert/orders [post]
+// @Security BearerAuth
+func (s *SmartConvertHandler) PlaceLimitOrder(c *gin.Context) {
+	settings, err := s.server.queries.GetSystemSettings(c)
+	if err != nil {
+		s.server.logger.Error("Failed to get system settings", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.SmartConversionsEnabled.Bool {
+		c.JSON(http.StatusForbidden, basemodels.NewError("smart conversions are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	var req smartconversion.PlaceLimitOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	user, err := s.server.queries.GetUserByID(c.Request.Context(), activeUser.UserID)
+	if err != nil {
+		s.logger.Error("Failed to fetch user", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+	if !user.IsActive {
+		c.JSON(http.StatusForbidden, basemodels.NewError(apistrings.DeactivatedAccount))
+		return
+	}
+
+	if err = utils.VerifyHashValue(req.Pin, user.HashedPin.String); err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.InvalidTransactionPIN))
+		return
+	}
+
+	order, err := s.conversionSvc.PlaceLimitOrder(c.Request.Context(), &user, &req)
+	if err != nil {
+		if status, ok := conversionErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		s.logger.Error("Failed to place limit order", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	// audit log
+	entry := audit.NewLog(
+		c,
+		audit.CategoryConversion,
+		audit.EventPlaceLimitOrder,
+		order.ID.String(),
+		"Limit order placed successfully",
+		&activeUser.UserID,
+		activeUser.Role,
+		true,
+		nil,
+	)
+	entry.Metadata = map[string]any{
+		"source_currency": order.SourceCurrency,
+		"target_currency": order.TargetCurrency,
+		"source_amount":   order.SourceAmount,
+		"limit_rate":      order.LimitRate,
+		"time_in_force":   order.TimeInForce,
+		"expires_at":      order.ExpiresAt,
+	}
+	s.audit.Log(entry)
+
+	c.JSON(http.StatusCreated, basemodels.NewSuccess("Limit order placed successfully", order))
+}
+
+// ListLimitOrders godoc
+// @Summary List limit orders
+// @Description Retrieves the authenticated user's limit orders with their fill history, newest first
+// @Tags Conversion
+// @Produce json
+// @Param open_only query bool false "Only open and partially filled orders" default(false)
+// @Param limit query int false "Number of records" default(20)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} []smartconversion.LimitOrder
+// @Router /api/v1/smart-convert/orders [get]
+// @Security BearerAuth
+func (s *SmartConvertHandler) ListLimitOrders(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	openOnly, _ := strconv.ParseBool(c.DefaultQuery("open_only", "false"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	orders, err := s.conversionSvc.ListLimitOrders(c.Request.Context(), activeUser.UserID, openOnly, int32(limit), int32(offset))
+	if err != nil {
+		s.logger.Error("Failed to fetch limit orders", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to fetch limit orders"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", gin.H{
+		"orders": orders,
+		"limit":  limit,
+		"offset": offset,
+	}))
+}
+
+// GetLimitOrder godoc
+// @Summary Get a limit order
+// @Description Retrieves one of the authenticated user's limit orders with its fill history
+// @Tags Conversion
+// @Produce json
+// @Param order_id path string true "Order ID" format(uuid)
+// @Success 200 {object} smartconversion.LimitOrder
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/smart-convert/orders/{order_id} [get]
+// @Security BearerAuth
+func (s *SmartConvertHandler) GetLimitOrder(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("order_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid order ID"))
+		return
+	}
+
+	order, err := s.conversionSvc.GetLimitOrder(c.Request.Context(), activeUser.UserID, orderID)
+	if err != nil {
+		if status, ok := conversionErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		s.logger.Error("Failed to fetch limit order", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to fetch limit order"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", order))
+}
+
+// CancelLimitOrder godoc
+// @Summary Cancel a limit order
+// @Description Cancels an open or partially filled limit order and returns the unfilled amount to the source wallet. Fills already made are kept.
+// @Tags Conversion
+// @Produce json
+// @Param order_id path string true "Order ID" format(uuid)
+// @Success 200 {object} smartconversion.LimitOrder
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse "Order is no longer open"
+// @Router /api/v1/smart-convert/orders/{order_id}/cancel [post]
+// @Security BearerAuth
+func (s *SmartConvertHandler) CancelLimitOrder(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	orderID, err := uuid.Parse(c.Param("order_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid order ID"))
+		return
+	}
+
+	order, err := s.conversionSvc.CancelLimitOrder(c.Request.Context(), activeUser.UserID, orderID)
+	if err != nil {
+		if status, ok := conversionErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		s.logger.Error("Failed to cancel limit order", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to cancel limit order"))
+		return
+	}
+
+	// audit log
+	entry := audit.NewLog(
+		c,
+		audit.CategoryConversion,
+		audit.EventCancelLimitOrder,
+		orderID.String(),
+		"Limit order cancelled successfully",
+		&activeUser.UserID,
+		activeUser.Role,
+		true,
+		nil,
+	)
+	entry.Metadata = map[string]any{
+		"filled_amount":    order.FilledAmount,
+		"remaining_amount": order.RemainingAmount,
+	}
+	s.audit.Log(entry)
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Limit order cancelled", order))
+}
+
+// CreateDCAPlan godoc
+// @Summary Create a DCA plan
+// @Description Converts a fixed NGN amount into USDT or USDC every day, week or month. Runs priced above rate_ceiling (NGN per unit of target) are skipped; runs the wallet cannot cover are skipped or pause the plan, depending on insufficient_funds_policy.
+// @Tags Conversion
+// @Accept json
+// @Produce json
+// @Param request body smartconversion.CreateDCAPlanRequest true "Plan details"
+// @Success 201 {object} smartconversion.DCAPlan
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Router /api/v1/smart-convert/dca [post]
+// @Security BearerAuth
+func (s *SmartConvertHandler) CreateDCAPlan(c *gin.Context) {
+	settings, err := s.server.queries.GetSystemSettings(c)
+	if err != nil {
+		s.server.logger.Error("Failed to get system settings", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.SmartConversionsEnabled.Bool {
+		c.JSON(http.StatusForbidden, basemodels.NewError("smart conversions are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	var req smartconversion.CreateDCAPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	user, err := s.server.queries.GetUserByID(c.Request.Context(), activeUser.UserID)
+	if err != nil {
+		s.logger.Error("Failed to fetch user", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+	if !user.IsActive {
+		c.JSON(http.StatusForbidden, basemodels.NewError(apistrings.DeactivatedAccount))
+		return
+	}
+
+	if err = utils.VerifyHashValue(req.Pin, user.HashedPin.String); err != nil {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.InvalidTransactionPIN))
+		return
+	}
+
+	plan, err := s.conversionSvc.CreateDCAPlan(c.Request.Context(), &user, &req)
+	if err != nil {
+		if status, ok := conversionErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		s.logger.Error("Failed to create DCA plan", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	// audit log
+	entry := audit.NewLog(
+		c,
+		audit.CategoryConversion,
+		audit.EventCreateDCAPlan,
+		plan.ID.String(),
+		"DCA plan created successfully",
+		&activeUser.UserID,
+		activeUser.Role,
+		true,
+		nil,
+	)
+	entry.Metadata = map[string]any{
+		"target_currency":           plan.TargetCurrency,
+		"amount":                    plan.Amount,
+		"frequency":                 plan.Frequency,
+		"rate_ceiling":              plan.RateCeiling,
+		"insufficient_funds_policy": plan.InsufficientFundsPolicy,
+	}
+	s.audit.Log(entry)
+
+	c.JSON(http.StatusCreated, basemodels.NewSuccess("DCA plan created successfully", plan))
+}
+
+// ListDCAPlans godoc
+// @Summary List DCA plans
+// @Description Retrieves the authenticated user's active and paused DCA plans with their average cost and accumulated totals
+// @Tags Conversion
+// @Produce json
+// @Success 200 {object} []smartconversion.DCAPlan
+// @Router /api/v1/smart-convert/dca [get]
+// @Security BearerAuth
+func (s *SmartConvertHandler) ListDCAPlans(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	plans, err := s.conversionSvc.ListDCAPlans(c.Request.Context(), activeUser.UserID)
+	if err != nil {
+		s.logger.Error("Failed to fetch DCA plans", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to fetch DCA plans"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", plans))
+}
+
+// GetDCAPlan godoc
+// @Summary Get a DCA plan
+// @Description Retrieves one of the authenticated user's DCA plans with its summary and run history, including skipped and failed runs
+// @Tags Conversion
+// @Produce json
+// @Param plan_id path string true "Plan ID" format(uuid)
+// @Param limit query int false "Number of runs" default(50)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} smartconversion.DCAPlanDetail
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/smart-convert/dca/{plan_id} [get]
+// @Security BearerAuth
+func (s *SmartConvertHandler) GetDCAPlan(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	planID, err := uuid.Parse(c.Param("plan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid plan ID"))
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	plan, err := s.conversionSvc.GetDCAPlan(c.Request.Context(), activeUser.UserID, planID, int32(limit), int32(offset))
+	if err != nil {
+		if status, ok := conversionErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		s.logger.Error("Failed to fetch DCA plan", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to fetch DCA plan"))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("", plan))
+}
+
+// UpdateDCAPlan godoc
+// @Summary Update a DCA plan
+// @Description Changes the amount, schedule, rate ceiling, insufficient funds policy or label of an active or paused plan. Schedule changes move the next run.
+// @Tags Conversion
+// @Accept json
+// @Produce json
+// @Param plan_id path string true "Plan ID" format(uuid)
+// @Param request body smartconversion.UpdateDCAPlanRequest true "Fields to change"
+// @Success 200 {object} smartconversion.DCAPlan
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/smart-convert/dca/{plan_id} [patch]
+// @Security BearerAuth
+func (s *SmartConvertHandler) UpdateDCAPlan(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	planID, err := uuid.Parse(c.Param("plan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid plan ID"))
+		return
+	}
+
+	var req smartconversion.UpdateDCAPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	plan, err := s.conversionSvc.UpdateDCAPlan(c.Request.Context(), activeUser.UserID, planID, &req)
+	if err != nil {
+		if status, ok := conversionErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		s.logger.Error("Failed to update DCA plan", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to update DCA plan"))
+		return
+	}
+
+	// audit log
+	entry := audit.NewLog(
+		c,
+		audit.CategoryConversion,
+		audit.EventUpdateDCAPlan,
+		planID.String(),
+		"DCA plan updated successfully",
+		&activeUser.UserID,
+		activeUser.Role,
+		true,
+		nil,
+	)
+	entry.Metadata = map[string]any{
+		"amount":                    plan.Amount,
+		"frequency":                 plan.Frequency,
+		"rate_ceiling":              plan.RateCeiling,
+		"insufficient_funds_policy": plan.InsufficientFundsPolicy,
+		"next_execution_at":         plan.NextExecutionAt,
+	}
+	s.audit.Log(entry)
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("DCA plan updated", plan))
+}
+
+// PauseDCAPlan godoc
+// @Summary Pause a DCA plan
+// @Description Stops an active plan's runs until it is resumed
+// @Tags Conversion
+// @Produce json
+// @Param plan_id path string true "Plan ID" format(uuid)
+// @Success 200 {object} smartconversion.DCAPlan
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse "Plan is not active"
+// @Router /api/v1/smart-convert/dca/{plan_id}/pause [post]
+// @Security BearerAuth
+func (s *SmartConvertHandler) PauseDCAPlan(c *gin.Context) {
+	s.changeDCAPlanStatus(c, s.conversionSvc.PauseDCAPlan, audit.EventPauseDCAPlan, "DCA plan paused")
+}
+
+// ResumeDCAPlan godoc
+// @Summary Resume a DCA plan
+// @Description Reactivates a paused plan from its next scheduled time. Runs missed while paused are not made up.
+// @Tags Conversion
+// @Produce json
+// @Param plan_id path string true "Plan ID" format(uuid)
+// @Success 200 {object} smartconversion.DCAPlan
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse "Plan is not paused"
+// @Router /api/v1/smart-convert/dca/{plan_id}/resume [post]
+// @Security BearerAuth
+func (s *SmartConvertHandler) ResumeDCAPlan(c *gin.Context) {
+	s.changeDCAPlanStatus(c, s.conversionSvc.ResumeDCAPlan, audit.EventResumeDCAPlan, "DCA plan resumed")
+}
+
+// CancelDCAPlan godoc
+// @Summary Cancel a DCA plan
+// @Description Ends a plan for good. Its run history stays available.
+// @Tags Conversion
+// @Produce json
+// @Param plan_id path string true "Plan ID" format(uuid)
+// @Success 200 {object} smartconversion.DCAPlan
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse "Plan is already cancelled"
+// @Router /api/v1/smart-convert/dca/{plan_id} [delete]
+// @Security BearerAuth
+func (s *SmartConvertHandler) CancelDCAPlan(c *gin.Context) {
+	s.changeDCAPlanStatus(c, s.conversionSvc.CancelDCAPlan, audit.EventCancelDCAPlan, "DCA plan cancelled")
+}
+
+// changeDCAPlanStatus pauses, resumes or cancels the plan in the path and audits it
+func (s *SmartConvertHandler) changeDCAPlanStatus(
+	c *gin.Context,
+	change func(ctx context.Context, userID, planID uuid.UUID) (*smartconversion.DCAPlan, error),
+	event string,
+	message string,
+) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		s.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	planID, err := uuid.Parse(c.Param("plan_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid plan ID"))
+		return
+	}
+
+	plan, err := change(c.Request.Context(), activeUser.UserID, planID)
+	if err != nil {
+		if status, ok := conversionErrorStatus(err); ok {
+			c.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		s.logger.Error("Failed to change DCA plan status", "error", err)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError("failed to change DCA plan status"))
+		return
+	}
+
+	// audit log
+	entry := audit.NewLog(
+		c,
+		audit.CategoryConversion,
+		event,
+		planID.String(),
+		message,
+		&activeUser.UserID,
+		activeUser.Role,
+		true,
+		nil,
+	)
+	entry.Metadata = map[string]any{
+		"status": plan.Status,
+	}
+	s.audit.Log(entry)
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess(message, plan))
+}
+
+// conversionErrorStatus maps a limit order or DCA plan error to its HTTP status
+func conversionErrorStatus(err error) (int, bool) {
+	if errors.Is(err, exchangerate.ErrInvalidCurrencyPair) {
+		return http.StatusBadRequest, true
+	}
+
+	var convErr *smartconversion.ConversionError
+	if !errors.As(err, &convErr) {
+		return 0, false
+	}
+
+	switch convErr {
+	case smartconversion.ErrOrderNotFound, smartconversion.ErrPlanNotFound, smartconversion.ErrWalletNotFound:
+		return http.StatusNotFound, true
+	case smartconversion.ErrOrderNotOpen, smartconversion.ErrPlanStatus:
+		return http.StatusConflict, true
+	case smartconversion.ErrInsufficientBalance:
+		return http.StatusBadRequest, true
+	}
+	if convErr.Code == "INVALID_ORDER" || convErr.Code == "INVALID_PLAN" || convErr.Code == "INVALID_ALERT_ACTION" {
+		return http.StatusBadRequest, true
+	}
+	return 0, false
+}
+
 // GetExchangeRate godoc
 // @Summary Get current exchange rate
 // @Description Retrieves real-time exchange rate between two currencies
@@ -1,10 +1,12 @@
 package api
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/SwiftFiat/SwiftFiat-Backend/api/apistrings"
@@ -12,7 +14,9 @@ import (
 	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
 	basemodels "github.com/SwiftFiat/SwiftFiat-Backend/models"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/audit"
+	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
 	service "github.com/SwiftFiat/SwiftFiat-Backend/services/notification"
+	smartconversion "github.com/SwiftFiat/SwiftFiat-Backend/services/smart_conversion"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/transaction"
 	vaultsavings "github.com/SwiftFiat/SwiftFiat-Backend/services/vault_savings"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/wallet"
@@ -64,6 +68,7 @@ func (v Vault) router(server *Server) {
 
 		// Transactions
 		vaultGroup.POST("/goals/:id/deposit", v.deposit)
+		vaultGroup.POST("/goals/:id/funding-quote", v.quoteVaultFunding)
 		vaultGroup.POST("/admin/goals/deposit", v.adminDeposit)
 		vaultGroup.POST("/goals/:id/withdraw", v.withdraw)
 		vaultGroup.POST("/admin/goals/withdraw", v.adminWithdraw)
@@ -71,8 +76,43 @@ func (v Vault) router(server *Server) {
 		vaultGroup.GET("/transactions", v.getAllTransactions)
 		vaultGroup.GET("/admin/transactions", v.adminGetVaultTxsByUser)
 
+		// Locked Vaults
+		vaultGroup.GET("/locked-products", v.listLockedProducts)
+		vaultGroup.POST("/locked", v.createLockedVault)
+		vaultGroup.GET("/goals/:id/early-withdrawal", v.getEarlyWithdrawalQuote)
+		vaultGroup.POST("/goals/:id/early-withdrawal", v.breakLock)
+		vaultGroup.PUT("/goals/:id/maturity-action", v.updateMaturityAction)
+
+		// Group Vaults
+		vaultGroup.POST("/groups", v.createGroupVault)
+		vaultGroup.GET("/groups", v.listGroupVaults)
+		vaultGroup.GET("/groups/invites", v.listGroupInvites)
+		vaultGroup.GET("/groups/:id", v.getGroupVault)
+		vaultGroup.GET("/groups/:id/activity", v.getGroupActivity)
+		vaultGroup.POST("/groups/:id/members", v.inviteGroupMembers)
+		vaultGroup.DELETE("/groups/:id/members/:user_id", v.removeGroupMember)
+		vaultGroup.POST("/groups/:id/respond", v.respondToGroupInvite)
+		vaultGroup.POST("/groups/:id/leave", v.leaveGroupVault)
+		vaultGroup.POST("/groups/:id/withdrawal-requests", v.requestGroupWithdrawal)
+		vaultGroup.POST("/groups/:id/withdrawal-requests/:request_id/vote", v.voteGroupWithdrawal)
+
+		// Auto-Save Rules
+		vaultGroup.POST("/auto-save-rules", v.createAutoSaveRule)
+		vaultGroup.GET("/auto-save-rules", v.listAutoSaveRules)
+		vaultGroup.PUT("/auto-save-rules/:id", v.updateAutoSaveRule)
+		vaultGroup.DELETE("/auto-save-rules/:id", v.deleteAutoSaveRule)
+
+		// Sweep Rules
+		vaultGroup.POST("/sweep-rules", v.createSweepRule)
+		vaultGroup.GET("/sweep-rules", v.listSweepRules)
+		vaultGroup.PUT("/sweep-rules/:id", v.updateSweepRule)
+		vaultGroup.DELETE("/sweep-rules/:id", v.deleteSweepRule)
+		vaultGroup.GET("/sweep-history", v.listSweepRuns)
+
 		// Recurring Rules
 		vaultGroup.PUT("/goals/:id/recurring", v.updateRecurringRule)
+		vaultGroup.POST("/goals/:id/recurring/preview", v.previewRecurringRuleUpdate)
+		vaultGroup.POST("/recurring/preview", v.previewRecurringRule)
 		vaultGroup.POST("/goals/:id/recurring/pause", v.pauseRecurring)
 		vaultGroup.POST("/goals/:id/recurring/resume", v.resumeRecurring)
 		vaultGroup.PATCH("/admin/goals/:id/recurring/pause", v.AdminPauseRecurring)
@@ -87,6 +127,7 @@ func (v Vault) router(server *Server) {
 		vaultGroup.GET("/goals/:id/yield-history", v.getYieldHistory)
 		vaultGroup.GET("/goals/:id/yield-projection", v.getYieldProjection)
 		vaultGroup.GET("/yield-summary", v.getYieldSummary)
+		vaultGroup.GET("/goals/:id/yield-statement", v.getYieldStatement)
 
 		// Yield Routes (Admin)
 		vaultGroup.GET("/admin/yield-configs", v.listYieldConfigs)
@@ -95,6 +136,8 @@ func (v Vault) router(server *Server) {
 		vaultGroup.POST("/admin/yield-configs/:id/delete", v.deleteYieldConfig)
 		vaultGroup.POST("/admin/process-yields-now", v.processYieldsNow)
 		vaultGroup.GET("/admin/yield-scheduler/stats", v.getYieldSchedulerStats)
+		vaultGroup.GET("/admin/withholding-tax", v.getWithholdingTaxLiability)
+		vaultGroup.POST("/admin/withholding-tax/remit", v.remitWithholdingTax)
 		vaultGroup.GET("/admin/goals", v.AdminListGoals)
 	}
 }
@@ -114,6 +157,7 @@ func (v Vault) router(server *Server) {
 // @Success 201 {object} vaultsavings.VaultSavingResponse
 // @Failure 400 {object} basemodels.ErrorResponse
 // @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
 // @Failure 500 {object} basemodels.ErrorResponse
 // @Router /api/v1/vault/goals [post]
 func (v *Vault) createGoal(ctx *gin.Context) {
@@ -155,7 +199,15 @@ func (v *Vault) createGoal(ctx *gin.Context) {
 	if err != nil {
 		v.server.logger.Error(fmt.Sprintf("failed to create vault goal: %v", err))
 		if errors.Is(err, vaultsavings.ErrInvalidCurrency) {
-			ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid currency"))
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		if errors.Is(err, vaultsavings.ErrVaultNameTaken) {
+			ctx.JSON(http.StatusConflict, basemodels.NewError(err.Error()))
+			return
+		}
+		if errors.Is(err, vaultsavings.ErrInvalidRecurringRule) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
@@ -193,6 +245,7 @@ func (v *Vault) createGoal(ctx *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param status query string false "Filter by status (active, completed, paused)"
+// @Param currency query string false "Filter by vault currency (NGN, USD, USDC, USDT)"
 // @Success 200 {object} []vaultsavings.VaultSavingResponse
 // @Failure 401 {object} basemodels.ErrorResponse
 // @Failure 500 {object} basemodels.ErrorResponse
@@ -217,11 +270,15 @@ func (v *Vault) listGoals(ctx *gin.Context) {
 	}
 
 	status := ctx.Query("status")
+	currency := strings.ToUpper(ctx.Query("currency"))
 
 	var goals []db.VaultSaving
-	if status == "active" {
+	switch {
+	case currency != "":
+		goals, err = v.vaultService.GetUserVaultsByCurrency(ctx.Request.Context(), activeUser.UserID, currency, status == "active")
+	case status == "active":
 		goals, err = v.vaultService.GetUserActiveVaults(ctx.Request.Context(), activeUser.UserID)
-	} else {
+	default:
 		goals, err = v.vaultService.GetUserVaults(ctx.Request.Context(), activeUser.UserID)
 	}
 
@@ -358,12 +415,14 @@ func (v *Vault) getGoal(ctx *gin.Context) {
 
 // getSummary godoc
 // @Summary Get Vault Summary
-// @Description Get summary of all vaults for the authenticated user
+// @Description Get summary of all vaults for the authenticated user, per currency and consolidated in a display currency
 // @Tags vault
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} basemodels.SuccessResponse{data=db.GetUserVaultsSummaryRow}
+// @Param display_currency query string false "Currency totals are valued in (NGN, USD, USDC, USDT)" default(USD)
+// @Success 200 {object} basemodels.SuccessResponse{data=vaultsavings.VaultSummaryResponse}
+// @Failure 400 {object} basemodels.ErrorResponse
 // @Failure 401 {object} basemodels.ErrorResponse
 // @Failure 500 {object} basemodels.ErrorResponse
 // @Router /api/v1/vault/summary [get]
@@ -386,8 +445,12 @@ func (v *Vault) getSummary(ctx *gin.Context) {
 		return
 	}
 
-	summary, err := v.vaultService.GetUserVaultSummary(ctx.Request.Context(), activeUser.UserID)
+	summary, err := v.vaultService.GetConsolidatedSummary(ctx.Request.Context(), activeUser.UserID, ctx.DefaultQuery("display_currency", "USD"))
 	if err != nil {
+		if errors.Is(err, vaultsavings.ErrUnsupportedDisplayCurrency) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
 		v.server.logger.Error(fmt.Sprintf("failed to fetch vault summary: %v", err))
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to fetch summary"))
 		return
@@ -653,17 +716,18 @@ func (v *Vault) GetTotalVaultYields(ctx *gin.Context) {
 
 // deposit godoc
 // @Summary Deposit to Vault
-// @Description Deposit funds from wallet to vault savings goal
+// @Description Deposit funds from wallet to vault savings goal. A wallet in another currency is converted into the vault currency first, at the rate of quote_id from the funding-quote endpoint or at the current rate, and needs the transaction PIN; amount is then in the wallet currency.
 // @Tags vault
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Vault Goal ID"
-// @Param depositRequest body object{from_wallet_id=string,amount=string,description=string} true "Deposit Request"
+// @Param depositRequest body object{from_wallet_id=string,amount=string,description=string,idempotency_key=string,quote_id=string,pin=string} true "Deposit Request"
 // @Success 200 {object} vaultsavings.DepositResponse
 // @Failure 400 {object} basemodels.ErrorResponse
 // @Failure 401 {object} basemodels.ErrorResponse
 // @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
 // @Failure 500 {object} basemodels.ErrorResponse
 // @Router /api/v1/vault/goals/{id}/deposit [post]
 func (v *Vault) deposit(ctx *gin.Context) {
@@ -696,6 +760,9 @@ func (v *Vault) deposit(ctx *gin.Context) {
 		Amount         string `json:"amount" binding:"required"`
 		Description    string `json:"description"`
 		IdempotencyKey string `json:"idempotency_key" binding:"required"`
+		// only used when the wallet is in another currency than the vault
+		QuoteID string `json:"quote_id"`
+		Pin     string `json:"pin"`
 	}
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -731,31 +798,82 @@ func (v *Vault) deposit(ctx *gin.Context) {
 		return
 	}
 
-	if goal.UserID != activeUser.UserID {
+	// Active members can pay into a group vault they don't own
+	if goal.UserID != activeUser.UserID && !v.vaultService.IsGroupMember(ctx.Request.Context(), vaultID, activeUser.UserID) {
 		ctx.JSON(http.StatusForbidden, basemodels.NewError("access denied"))
 		return
 	}
 
-	// TODO: check if deposit currency == vault currency
-
-	// Create deposit request
-	depositReq := vaultsavings.DepositRequest{
-		UserID:         activeUser.UserID,
-		VaultID:        vaultID,
-		FromWalletID:   walletID,
-		Amount:         req.Amount,
-		Currency:       goal.Currency,
-		Description:    req.Description,
-		IdempotencyKey: req.IdempotencyKey,
+	sourceWallet, err := v.server.queries.GetWallet(ctx, walletID)
+	if err != nil || sourceWallet.CustomerID != activeUser.UserID {
+		ctx.JSON(http.StatusNotFound, basemodels.NewError("wallet not found"))
+		return
 	}
 
-	tx, err := v.vaultService.Deposit(ctx.Request.Context(), depositReq)
+	var tx *db.VaultTransaction
+	if sourceWallet.Currency == goal.Currency {
+		tx, err = v.vaultService.Deposit(ctx.Request.Context(), vaultsavings.DepositRequest{
+			UserID:         activeUser.UserID,
+			VaultID:        vaultID,
+			FromWalletID:   walletID,
+			Amount:         req.Amount,
+			Currency:       goal.Currency,
+			Description:    req.Description,
+			IdempotencyKey: req.IdempotencyKey,
+		})
+	} else {
+		// funding from another currency runs a conversion, which needs the transaction PIN
+		user, userErr := v.server.queries.GetUserByID(ctx, activeUser.UserID)
+		if userErr != nil {
+			v.server.logger.Error(fmt.Sprintf("failed to fetch user: %v", userErr))
+			ctx.JSON(http.StatusInternalServerError, basemodels.NewError(apistrings.UserNotFound))
+			return
+		}
+		if err := utils.VerifyHashValue(req.Pin, user.HashedPin.String); err != nil {
+			ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.InvalidTransactionPIN))
+			return
+		}
+
+		tx, err = v.vaultService.FundFromWallet(ctx.Request.Context(), vaultsavings.FundVaultRequest{
+			UserID:         activeUser.UserID,
+			VaultID:        vaultID,
+			SourceCurrency: sourceWallet.Currency,
+			Amount:         req.Amount,
+			Description:    req.Description,
+			IdempotencyKey: req.IdempotencyKey,
+			QuoteID:        req.QuoteID,
+		})
+	}
 	if err != nil {
 		v.server.logger.Error(fmt.Sprintf("failed to process deposit: %v", err))
-		if errors.Is(err, vaultsavings.ErrInsufficientBalance) {
+		if errors.Is(err, vaultsavings.ErrInsufficientBalance) || errors.Is(err, smartconversion.ErrInsufficientBalance) {
 			ctx.JSON(http.StatusBadRequest, basemodels.NewError("insufficient wallet balance"))
 			return
 		}
+		if status, ok := quoteErrorStatus(err); ok {
+			ctx.JSON(status, basemodels.NewError(err.Error()))
+			return
+		}
+		if errors.Is(err, vaultsavings.ErrInvalidCurrency) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		if errors.Is(err, vaultsavings.ErrFundingUnavailable) {
+			ctx.JSON(http.StatusServiceUnavailable, basemodels.NewError(err.Error()))
+			return
+		}
+		if errors.Is(err, vaultsavings.ErrFundingKeyReused) {
+			ctx.JSON(http.StatusConflict, basemodels.NewError(err.Error()))
+			return
+		}
+		if errors.Is(err, vaultsavings.ErrVaultLocked) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		if errors.Is(err, vaultsavings.ErrNotGroupMember) {
+			ctx.JSON(http.StatusForbidden, basemodels.NewError(err.Error()))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
 		return
 	}
@@ -765,20 +883,89 @@ func (v *Vault) deposit(ctx *gin.Context) {
 	auditLog.Description = fmt.Sprintf("Deposit of %s %s to vault %s initiated by user %s", amount.String(), goal.Currency, goal.ID.String(), activeUser.UserID)
 	auditLog.OldValues = nil
 	auditLog.NewValues = map[string]any{
-		"transaction_id": tx.ID,
-		"from_wallet_id": walletID,
-		"user_id":        activeUser.UserID,
-		"vault_id":       goal.ID,
-		"amount":         amount.String(),
-		"currency":       goal.Currency,
-		"status":         tx.Status,
-		"created_at":     time.Now(),
+		"transaction_id":  tx.ID,
+		"from_wallet_id":  walletID,
+		"user_id":         activeUser.UserID,
+		"vault_id":        goal.ID,
+		"amount":          amount.String(),
+		"currency":        goal.Currency,
+		"source_currency": sourceWallet.Currency,
+		"credited_amount": tx.Amount,
+		"status":          tx.Status,
+		"created_at":      time.Now(),
 	}
 	v.audit.Log(auditLog)
 
 	ctx.JSON(http.StatusOK, basemodels.NewSuccess("deposit successful", vaultsavings.MapVaultTxToDepositResponse(tx)))
 }
 
+// quoteVaultFunding godoc
+// @Summary Quote Cross-Currency Vault Deposit
+// @Description Price a deposit into the vault from the wallet in another currency. Pass the returned quote_id to the deposit endpoint to convert at exactly these terms before it expires.
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Param fundingQuoteRequest body vaultsavings.FundingQuoteRequest true "Funding Quote Request"
+// @Success 200 {object} basemodels.SuccessResponse{data=ratequote.Quote}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 503 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/goals/{id}/funding-quote [post]
+func (v *Vault) quoteVaultFunding(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	var req vaultsavings.FundingQuoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+	req.SourceCurrency = strings.ToUpper(req.SourceCurrency)
+
+	goal, err := v.vaultService.GetVaultByID(ctx.Request.Context(), vaultID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, basemodels.NewError("vault goal not found"))
+		return
+	}
+	if goal.UserID != activeUser.UserID && !v.vaultService.IsGroupMember(ctx.Request.Context(), vaultID, activeUser.UserID) {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("access denied"))
+		return
+	}
+
+	quote, err := v.vaultService.QuoteVaultFunding(ctx.Request.Context(), vaultID, activeUser.UserID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, vaultsavings.ErrFundingUnavailable),
+			errors.Is(err, exchangerate.ErrRateNotAvailable),
+			errors.Is(err, exchangerate.ErrNoConsensusRate):
+			ctx.JSON(http.StatusServiceUnavailable, basemodels.NewError(err.Error()))
+		case errors.Is(err, vaultsavings.ErrVaultLocked),
+			errors.Is(err, vaultsavings.ErrInvalidCurrency):
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		default:
+			v.server.logger.Error(fmt.Sprintf("failed to quote vault funding: %v", err))
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("funding quote created", quote))
+}
+
 // adminDeposit godoc
 // @Summary Admin Deposit to Vault
 // @Description Admin deposit funds to vault savings goal
@@ -907,6 +1094,10 @@ func (v *Vault) adminDeposit(ctx *gin.Context) {
 			ctx.JSON(http.StatusBadRequest, basemodels.NewError("insufficient wallet balance"))
 			return
 		}
+		if errors.Is(err, vaultsavings.ErrVaultLocked) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
 		return
 	}
@@ -1041,6 +1232,10 @@ func (v *Vault) withdraw(ctx *gin.Context) {
 			ctx.JSON(http.StatusBadRequest, basemodels.NewError("insufficient vault balance"))
 			return
 		}
+		if errors.Is(err, vaultsavings.ErrVaultLocked) || errors.Is(err, vaultsavings.ErrGroupWithdrawalRestricted) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to process withdrawal"))
 		return
 	}
@@ -1200,6 +1395,10 @@ func (v *Vault) adminWithdraw(ctx *gin.Context) {
 			ctx.JSON(http.StatusBadRequest, basemodels.NewError("insufficient vault balance"))
 			return
 		}
+		if errors.Is(err, vaultsavings.ErrVaultLocked) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to process withdrawal"))
 		return
 	}
@@ -1546,6 +1745,10 @@ func (v *Vault) updateGoal(ctx *gin.Context) {
 
 	err = v.vaultService.UpdateVaultDetails(ctx.Request.Context(), vaultID, req.Name, req.Description, req.GoalAmount)
 	if err != nil {
+		if errors.Is(err, vaultsavings.ErrVaultNameTaken) {
+			ctx.JSON(http.StatusConflict, basemodels.NewError(err.Error()))
+			return
+		}
 		v.server.logger.Error(fmt.Sprintf("failed to update vault goal: %v", err))
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to update savings goal"))
 		return
@@ -1806,6 +2009,10 @@ func (v *Vault) updateRecurringRule(ctx *gin.Context) {
 	err = v.vaultService.UpdateRecurringRule(ctx.Request.Context(), vaultID, req)
 	if err != nil {
 		v.server.logger.Error(fmt.Sprintf("failed to update recurring rule: %v", err))
+		if errors.Is(err, vaultsavings.ErrInvalidRecurringRule) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to update recurring deposits"))
 		return
 	}
@@ -1824,6 +2031,117 @@ func (v *Vault) updateRecurringRule(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, basemodels.NewSuccess("recurring deposits updated successfully", nil))
 }
 
+// previewRecurringRule godoc
+// @Summary Preview Recurring Rule
+// @Description List the next executions of a recurring rule before saving it. Supports cron expressions, the last business day of the month, skipping Nigerian public holidays and an IANA timezone.
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param count query int false "Number of executions to list (max 24)" default(5)
+// @Param recurringRule body vaultsavings.RecurringRule true "Recurring Rule"
+// @Success 200 {object} vaultsavings.RecurringPreviewResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/recurring/preview [post]
+func (v *Vault) previewRecurringRule(ctx *gin.Context) {
+	if _, err := utils.GetActiveUser(ctx); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	count, err := strconv.Atoi(ctx.DefaultQuery("count", "5"))
+	if err != nil || count <= 0 {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid count"))
+		return
+	}
+
+	var rule vaultsavings.RecurringRule
+	if err := ctx.ShouldBindJSON(&rule); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid request body"))
+		return
+	}
+
+	preview, err := v.vaultService.PreviewRecurringRule(ctx.Request.Context(), rule, count)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to preview recurring rule: %v", err))
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("recurring rule preview", preview))
+}
+
+// previewRecurringRuleUpdate godoc
+// @Summary Preview Recurring Rule Update
+// @Description List the next executions a vault's recurring rule would make with the given changes, without saving them
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Param count query int false "Number of executions to list (max 24)" default(5)
+// @Param updateRecurringRequest body vaultsavings.UpdateRecurringRuleRequest true "Update Recurring Request"
+// @Success 200 {object} vaultsavings.RecurringPreviewResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/goals/{id}/recurring/preview [post]
+func (v *Vault) previewRecurringRuleUpdate(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	count, err := strconv.Atoi(ctx.DefaultQuery("count", "5"))
+	if err != nil || count <= 0 {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid count"))
+		return
+	}
+
+	var req vaultsavings.UpdateRecurringRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid request body"))
+		return
+	}
+
+	// Verify ownership
+	goal, err := v.vaultService.GetVaultByID(ctx.Request.Context(), vaultID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, basemodels.NewError("vault goal not found"))
+		return
+	}
+	if goal.UserID != activeUser.UserID {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("access denied"))
+		return
+	}
+
+	preview, err := v.vaultService.PreviewRecurringRuleUpdate(ctx.Request.Context(), vaultID, req, count)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to preview recurring rule: %v", err))
+		if errors.Is(err, vaultsavings.ErrInvalidRecurringRule) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to preview recurring rule"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("recurring rule preview", preview))
+}
+
 // pauseRecurring godoc
 // @Summary Pause Recurring Deposits
 // @Description Pause automatic recurring deposits for a vault goal
@@ -2542,6 +2860,10 @@ func (v *Vault) createYieldConfig(ctx *gin.Context) {
 
 	config, err := v.yieldService.CreateYieldConfig(ctx.Request.Context(), req)
 	if err != nil {
+		if isYieldConfigError(err) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
 		v.server.logger.Error(fmt.Sprintf("Failed to create yield config: %v", err))
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to create yield config"))
 		return
@@ -2551,16 +2873,22 @@ func (v *Vault) createYieldConfig(ctx *gin.Context) {
 	auditLog := audit.NewVaultLog(ctx, audit.EventYieldConfigCreated, "vault", config.ID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
 	auditLog.Description = fmt.Sprintf("Yield config %s created by user %s", config.ID.String(), activeUser.UserID)
 	auditLog.NewValues = map[string]interface{}{
-		"id":                    config.ID,
-		"currency":              config.Currency,
-		"apy_rate":              config.ApyRate,
-		"min_balance_for_yield": config.MinBalanceForYield,
-		"compound_frequency":    config.CompoundFrequency,
-		"is_active":             config.IsActive,
-		"effective_from":        config.EffectiveFrom,
-		"effective_until":       config.EffectiveUntil,
-		"notes":                 config.Notes,
-		"created_at":            config.CreatedAt,
+		"id":                            config.ID,
+		"currency":                      config.Currency,
+		"apy_rate":                      config.ApyRate,
+		"min_balance_for_yield":         config.MinBalanceForYield,
+		"compound_frequency":            config.CompoundFrequency,
+		"is_active":                     config.IsActive,
+		"effective_from":                config.EffectiveFrom,
+		"effective_until":               config.EffectiveUntil,
+		"notes":                         config.Notes,
+		"product_type":                  config.ProductType,
+		"lock_term_days":                config.LockTermDays,
+		"early_withdrawal_policy":       config.EarlyWithdrawalPolicy,
+		"early_withdrawal_penalty_rate": config.EarlyWithdrawalPenaltyRate,
+		"apy_tiers":                     config.ApyTiers,
+		"withholding_tax_rate":          config.WithholdingTaxRate,
+		"created_at":                    config.CreatedAt,
 	}
 	v.audit.Log(auditLog)
 
@@ -2627,6 +2955,10 @@ func (v *Vault) updateYieldConfig(ctx *gin.Context) {
 	}
 
 	if err := v.yieldService.UpdateYieldConfig(ctx.Request.Context(), configID, req); err != nil {
+		if isYieldConfigError(err) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
 		v.server.logger.Error(fmt.Sprintf("Failed to update yield config: %v", err))
 		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to update yield config"))
 		return
@@ -2636,21 +2968,29 @@ func (v *Vault) updateYieldConfig(ctx *gin.Context) {
 	auditLog := audit.NewVaultLog(ctx, audit.EventYieldConfigUpdated, "vault", configID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
 	auditLog.Description = fmt.Sprintf("Yield config %s updated by user %s", configID.String(), activeUser.UserID)
 	auditLog.NewValues = map[string]any{
-		"apy_rate":              req.ApyRate,
-		"min_balance_for_yield": req.MinBalanceForYield,
-		"compound_frequency":    req.CompoundFrequency,
-		"is_active":             req.IsActive,
-		"effective_until":       req.EffectiveUntil,
-		"notes":                 req.Notes,
+		"apy_rate":                      req.ApyRate,
+		"min_balance_for_yield":         req.MinBalanceForYield,
+		"compound_frequency":            req.CompoundFrequency,
+		"is_active":                     req.IsActive,
+		"effective_until":               req.EffectiveUntil,
+		"notes":                         req.Notes,
+		"early_withdrawal_policy":       req.EarlyWithdrawalPolicy,
+		"early_withdrawal_penalty_rate": req.EarlyWithdrawalPenaltyRate,
+		"apy_tiers":                     req.ApyTiers,
+		"withholding_tax_rate":          req.WithholdingTaxRate,
 	}
 
 	auditLog.OldValues = map[string]any{
-		"apy_rate":              existingConfig.ApyRate,
-		"min_balance_for_yield": existingConfig.MinBalanceForYield,
-		"compound_frequency":    existingConfig.CompoundFrequency,
-		"is_active":             existingConfig.IsActive,
-		"effective_until":       existingConfig.EffectiveUntil,
-		"notes":                 existingConfig.Notes,
+		"apy_rate":                      existingConfig.ApyRate,
+		"min_balance_for_yield":         existingConfig.MinBalanceForYield,
+		"compound_frequency":            existingConfig.CompoundFrequency,
+		"is_active":                     existingConfig.IsActive,
+		"effective_until":               existingConfig.EffectiveUntil,
+		"notes":                         existingConfig.Notes,
+		"early_withdrawal_policy":       existingConfig.EarlyWithdrawalPolicy,
+		"early_withdrawal_penalty_rate": existingConfig.EarlyWithdrawalPenaltyRate,
+		"apy_tiers":                     existingConfig.ApyTiers,
+		"withholding_tax_rate":          existingConfig.WithholdingTaxRate,
 	}
 	v.audit.Log(auditLog)
 
@@ -2831,3 +3171,1610 @@ func (v *Vault) getYieldSchedulerStats(ctx *gin.Context) {
 func (v *Vault) ProcessVaultNow(ctx *gin.Context) {} // use ProcessVaultNow in vault scheduler
 
 func (v *Vault) ProcessVaultYieldNow(ctx *gin.Context) {} // use ProcessVaultYieldNow in yield_scheduler
+
+// ============================================================================
+// LOCKED VAULTS
+// ============================================================================
+
+// listLockedProducts godoc
+// @Summary List Locked Vault Products
+// @Description Get the lock terms currently offered, with their APY, minimum amount and early-withdrawal policy
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param currency query string false "Filter by currency"
+// @Success 200 {object} []vaultsavings.LockedProductResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/locked-products [get]
+func (v *Vault) listLockedProducts(ctx *gin.Context) {
+	settings, err := v.server.queries.GetSystemSettings(ctx)
+	if err != nil {
+		v.server.logger.Error("Failed to get system settings", "error", err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.VaultsEnabled.Bool {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("vaults are disabled"))
+		return
+	}
+
+	if _, err := utils.GetActiveUser(ctx); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	products, err := v.vaultService.ListLockedProducts(ctx.Request.Context(), ctx.Query("currency"))
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to list locked products: %v", err))
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to list locked products"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("locked products retrieved", products))
+}
+
+// createLockedVault godoc
+// @Summary Create Locked Vault
+// @Description Lock funds from the wallet in the vault currency for a fixed term at a fixed APY
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param createLockedVaultRequest body vaultsavings.CreateLockedVaultRequest true "Create Locked Vault Request"
+// @Success 201 {object} vaultsavings.VaultSavingResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.SuccessResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/locked [post]
+func (v *Vault) createLockedVault(ctx *gin.Context) {
+	settings, err := v.server.queries.GetSystemSettings(ctx)
+	if err != nil {
+		v.server.logger.Error("Failed to get system settings", "error", err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.VaultsEnabled.Bool {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("vaults are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	var req vaultsavings.CreateLockedVaultRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	t, err := v.server.queries.GetTransactionByIdempotencyKey(ctx, req.IdempotencyKey)
+	if err == nil {
+		ctx.JSON(http.StatusConflict, basemodels.NewSuccess("Transaction was successful", t))
+		return
+	}
+
+	goal, err := v.vaultService.CreateLockedVault(ctx.Request.Context(), req, activeUser.UserID)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to create locked vault: %v", err))
+		switch {
+		case errors.Is(err, vaultsavings.ErrInsufficientBalance):
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError("insufficient wallet balance"))
+		case errors.Is(err, vaultsavings.ErrVaultNameTaken):
+			ctx.JSON(http.StatusConflict, basemodels.NewError(err.Error()))
+		case errors.Is(err, vaultsavings.ErrInvalidCurrency),
+			errors.Is(err, vaultsavings.ErrInvalidAmount),
+			errors.Is(err, vaultsavings.ErrInvalidLockTerm),
+			errors.Is(err, vaultsavings.ErrInvalidMaturityAction),
+			errors.Is(err, vaultsavings.ErrLockedProductUnavailable),
+			errors.Is(err, vaultsavings.ErrBelowMinimumLock):
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		default:
+			ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		}
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventVaultCreated, "vault", goal.ID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("Locked vault %s created by user %s", goal.ID.String(), activeUser.UserID)
+	auditLog.OldValues = nil
+	auditLog.NewValues = map[string]any{
+		"id":         goal.ID,
+		"user_id":    goal.UserID,
+		"name":       goal.VaultName,
+		"currency":   goal.Currency,
+		"amount":     req.Amount,
+		"lock":       goal.Lock,
+		"status":     goal.Status,
+		"created_at": time.Now(),
+	}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusCreated, basemodels.NewSuccess("locked vault created successfully", goal))
+}
+
+// getEarlyWithdrawalQuote godoc
+// @Summary Quote Early Withdrawal
+// @Description Show what breaking a locked vault's lock now would pay out and what it would cost
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Success 200 {object} vaultsavings.EarlyWithdrawalQuote
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/goals/{id}/early-withdrawal [get]
+func (v *Vault) getEarlyWithdrawalQuote(ctx *gin.Context) {
+	settings, err := v.server.queries.GetSystemSettings(ctx)
+	if err != nil {
+		v.server.logger.Error("Failed to get system settings", "error", err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.VaultsEnabled.Bool {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("vaults are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	goal, err := v.vaultService.GetVaultByID(ctx.Request.Context(), vaultID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, basemodels.NewError("vault goal not found"))
+		return
+	}
+
+	if goal.UserID != activeUser.UserID {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("access denied"))
+		return
+	}
+
+	quote, err := v.vaultService.GetEarlyWithdrawalQuote(ctx.Request.Context(), vaultID)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to quote early withdrawal: %v", err))
+		if errors.Is(err, vaultsavings.ErrVaultNotLocked) || errors.Is(err, vaultsavings.ErrVaultMatured) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		if errors.Is(err, vaultsavings.ErrVaultNotFound) {
+			ctx.JSON(http.StatusNotFound, basemodels.NewError("vault goal not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("early withdrawal quote retrieved", quote))
+}
+
+// breakLock godoc
+// @Summary Withdraw From Locked Vault Early
+// @Description Close a locked vault before maturity. The early-withdrawal policy is applied and the rest is paid to the wallet in the vault currency.
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Success 200 {object} vaultsavings.EarlyWithdrawalResult
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/goals/{id}/early-withdrawal [post]
+func (v *Vault) breakLock(ctx *gin.Context) {
+	settings, err := v.server.queries.GetSystemSettings(ctx)
+	if err != nil {
+		v.server.logger.Error("Failed to get system settings", "error", err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.VaultsEnabled.Bool {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("vaults are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	result, err := v.vaultService.BreakLock(ctx.Request.Context(), activeUser.UserID, vaultID)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to break vault lock: %v", err))
+		if errors.Is(err, vaultsavings.ErrVaultNotLocked) || errors.Is(err, vaultsavings.ErrVaultMatured) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		if errors.Is(err, vaultsavings.ErrVaultNotFound) {
+			ctx.JSON(http.StatusNotFound, basemodels.NewError("vault goal not found"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventSavingsWithdrawn, "vault", vaultID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("Early withdrawal of %s %s from locked vault %s by user %s", result.Payout, result.Currency, vaultID, activeUser.UserID)
+	auditLog.OldValues = nil
+	auditLog.NewValues = map[string]any{
+		"vault_id":        vaultID,
+		"user_id":         activeUser.UserID,
+		"to_wallet_id":    result.ToWalletID,
+		"reference":       result.Reference,
+		"policy":          result.Policy,
+		"payout":          result.Payout,
+		"forfeited_yield": result.ForfeitedYield,
+		"penalty":         result.Penalty,
+		"currency":        result.Currency,
+		"created_at":      result.CompletedAt,
+	}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("early withdrawal successful", result))
+}
+
+// updateMaturityAction godoc
+// @Summary Update Locked Vault Maturity Action
+// @Description Choose whether a locked vault rolls over into another term or pays out when it matures
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Param updateMaturityActionRequest body object{maturity_action=string} true "Maturity Action (rollover or payout)"
+// @Success 200 {object} basemodels.SuccessResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/goals/{id}/maturity-action [put]
+func (v *Vault) updateMaturityAction(ctx *gin.Context) {
+	settings, err := v.server.queries.GetSystemSettings(ctx)
+	if err != nil {
+		v.server.logger.Error("Failed to get system settings", "error", err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.VaultsEnabled.Bool {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("vaults are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	var req struct {
+		MaturityAction string `json:"maturity_action" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	goal, err := v.vaultService.GetVaultByID(ctx.Request.Context(), vaultID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, basemodels.NewError("vault goal not found"))
+		return
+	}
+
+	if goal.UserID != activeUser.UserID {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("access denied"))
+		return
+	}
+
+	if err := v.vaultService.UpdateMaturityAction(ctx.Request.Context(), vaultID, req.MaturityAction); err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to update maturity action: %v", err))
+		if errors.Is(err, vaultsavings.ErrInvalidMaturityAction) ||
+			errors.Is(err, vaultsavings.ErrVaultNotLocked) ||
+			errors.Is(err, vaultsavings.ErrVaultMatured) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventVaultUpdated, "vault", vaultID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("Maturity action of locked vault %s set to %s by user %s", vaultID, req.MaturityAction, activeUser.UserID)
+	auditLog.OldValues = map[string]any{"maturity_action": goal.Lock.MaturityAction}
+	auditLog.NewValues = map[string]any{"maturity_action": req.MaturityAction}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("maturity action updated", nil))
+}
+
+// ============================================================================
+// GROUP VAULTS
+// ============================================================================
+
+// createGroupVault godoc
+// @Summary Create Group Vault
+// @Description Create a shared vault, choose how money leaves it and invite members by user tag
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param createGroupVaultRequest body vaultsavings.CreateGroupVaultRequest true "Create Group Vault Request"
+// @Success 201 {object} vaultsavings.GroupVaultResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups [post]
+func (v *Vault) createGroupVault(ctx *gin.Context) {
+	settings, err := v.server.queries.GetSystemSettings(ctx)
+	if err != nil {
+		v.server.logger.Error("Failed to get system settings", "error", err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.VaultsEnabled.Bool {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("vaults are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	var req vaultsavings.CreateGroupVaultRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	amount, err := decimal.NewFromString(req.TargetAmount)
+	if err != nil || amount.LessThanOrEqual(decimal.Zero) {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid target amount"))
+		return
+	}
+
+	group, err := v.vaultService.CreateGroupVault(ctx.Request.Context(), req, activeUser.UserID, ctx.ClientIP(), ctx.Request.UserAgent())
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to create group vault: %v", err))
+		v.groupVaultError(ctx, err)
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventVaultCreated, "vault", group.Vault.ID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("Group vault %s created by user %s", group.Vault.ID.String(), activeUser.UserID)
+	auditLog.OldValues = nil
+	auditLog.NewValues = map[string]any{
+		"id":              group.Vault.ID,
+		"owner_id":        group.OwnerID,
+		"name":            group.Vault.VaultName,
+		"target_amount":   group.Vault.GoalAmount,
+		"currency":        group.Vault.Currency,
+		"withdrawal_rule": group.WithdrawalRule,
+		"member_tags":     req.MemberTags,
+		"created_at":      time.Now(),
+	}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusCreated, basemodels.NewSuccess("group vault created successfully", group))
+}
+
+// listGroupVaults godoc
+// @Summary List Group Vaults
+// @Description Get the group vaults the authenticated user is an active member of
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} []vaultsavings.VaultSavingResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups [get]
+func (v *Vault) listGroupVaults(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaults, err := v.vaultService.ListUserGroupVaults(ctx.Request.Context(), activeUser.UserID)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to list group vaults: %v", err))
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to list group vaults"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("group vaults retrieved", vaults))
+}
+
+// listGroupInvites godoc
+// @Summary List Group Vault Invites
+// @Description Get the authenticated user's pending group vault invites
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} []vaultsavings.GroupInviteResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups/invites [get]
+func (v *Vault) listGroupInvites(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	invites, err := v.vaultService.ListGroupInvites(ctx.Request.Context(), activeUser.UserID)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to list group invites: %v", err))
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to list group invites"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("group invites retrieved", invites))
+}
+
+// getGroupVault godoc
+// @Summary Get Group Vault
+// @Description Get a group vault with each member's contributions, share of the total and progress toward the goal
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Success 200 {object} vaultsavings.GroupVaultResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups/{id} [get]
+func (v *Vault) getGroupVault(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	group, err := v.vaultService.GetGroupVault(ctx.Request.Context(), vaultID, activeUser.UserID)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to get group vault: %v", err))
+		v.groupVaultError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("group vault retrieved", group))
+}
+
+// getGroupActivity godoc
+// @Summary Get Group Vault Activity
+// @Description Get the group vault's activity feed, newest first
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} []vaultsavings.GroupActivityResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups/{id}/activity [get]
+func (v *Vault) getGroupActivity(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	// Parse pagination
+	limit := int32(20)
+	offset := int32(0)
+	if l := ctx.Query("limit"); l != "" {
+		if parsedLimit, err := strconv.Atoi(l); err == nil && parsedLimit > 0 {
+			limit = int32(parsedLimit)
+		}
+	}
+	if o := ctx.Query("offset"); o != "" {
+		if parsedOffset, err := strconv.Atoi(o); err == nil && parsedOffset >= 0 {
+			offset = int32(parsedOffset)
+		}
+	}
+
+	activity, err := v.vaultService.GetGroupActivity(ctx.Request.Context(), vaultID, activeUser.UserID, limit, offset)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to get group activity: %v", err))
+		v.groupVaultError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("group activity retrieved", activity))
+}
+
+// inviteGroupMembers godoc
+// @Summary Invite Group Vault Members
+// @Description Invite users to a group vault by user tag. Only the owner can invite.
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Param inviteRequest body object{member_tags=[]string} true "Invite Request"
+// @Success 200 {object} basemodels.SuccessResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups/{id}/members [post]
+func (v *Vault) inviteGroupMembers(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	var req struct {
+		MemberTags []string `json:"member_tags" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	invited, err := v.vaultService.InviteGroupMembers(ctx.Request.Context(), vaultID, activeUser.UserID, req.MemberTags)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to invite group members: %v", err))
+		v.groupVaultError(ctx, err)
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventVaultUpdated, "vault", vaultID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("User %s invited %d members to group vault %s", activeUser.UserID, invited, vaultID)
+	auditLog.OldValues = nil
+	auditLog.NewValues = map[string]any{"member_tags": req.MemberTags}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("members invited", map[string]any{"invited": invited}))
+}
+
+// removeGroupMember godoc
+// @Summary Remove Group Vault Member
+// @Description Remove a member from a group vault. Only the owner can remove members; their contributions stay in the vault.
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Param user_id path string true "Member User ID"
+// @Success 200 {object} basemodels.SuccessResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups/{id}/members/{user_id} [delete]
+func (v *Vault) removeGroupMember(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	memberID, err := uuid.Parse(ctx.Param("user_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid user ID"))
+		return
+	}
+
+	if err := v.vaultService.RemoveGroupMember(ctx.Request.Context(), vaultID, activeUser.UserID, memberID); err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to remove group member: %v", err))
+		v.groupVaultError(ctx, err)
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventVaultUpdated, "vault", vaultID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("User %s removed member %s from group vault %s", activeUser.UserID, memberID, vaultID)
+	auditLog.OldValues = map[string]any{"member_status": "active"}
+	auditLog.NewValues = map[string]any{"member_status": "removed", "member_id": memberID}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("member removed", nil))
+}
+
+// respondToGroupInvite godoc
+// @Summary Respond to Group Vault Invite
+// @Description Accept or decline a pending group vault invite
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Param respondRequest body object{accept=bool} true "Invite Response"
+// @Success 200 {object} basemodels.SuccessResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups/{id}/respond [post]
+func (v *Vault) respondToGroupInvite(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	var req struct {
+		Accept *bool `json:"accept" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	if err := v.vaultService.RespondToGroupInvite(ctx.Request.Context(), vaultID, activeUser.UserID, *req.Accept); err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to respond to group invite: %v", err))
+		v.groupVaultError(ctx, err)
+		return
+	}
+
+	message := "invite declined"
+	if *req.Accept {
+		message = "joined group vault"
+	}
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess(message, nil))
+}
+
+// leaveGroupVault godoc
+// @Summary Leave Group Vault
+// @Description Leave a group vault. What you contributed stays in the vault.
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Success 200 {object} basemodels.SuccessResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups/{id}/leave [post]
+func (v *Vault) leaveGroupVault(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	if err := v.vaultService.LeaveGroupVault(ctx.Request.Context(), vaultID, activeUser.UserID); err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to leave group vault: %v", err))
+		v.groupVaultError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("left group vault", nil))
+}
+
+// requestGroupWithdrawal godoc
+// @Summary Request Group Vault Withdrawal
+// @Description Ask the group to approve paying an amount to your wallet. Needs a majority of active members; requests expire after 72 hours.
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Param withdrawalRequest body object{amount=string,reason=string} true "Withdrawal Request"
+// @Success 201 {object} vaultsavings.GroupWithdrawalRequestResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups/{id}/withdrawal-requests [post]
+func (v *Vault) requestGroupWithdrawal(ctx *gin.Context) {
+	settings, err := v.server.queries.GetSystemSettings(ctx)
+	if err != nil {
+		v.server.logger.Error("Failed to get system settings", "error", err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.VaultsEnabled.Bool {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("vaults are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	var req struct {
+		Amount string `json:"amount" binding:"required"`
+		Reason string `json:"reason"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	request, err := v.vaultService.RequestGroupWithdrawal(ctx.Request.Context(), vaultID, activeUser.UserID, req.Amount, req.Reason)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to request group withdrawal: %v", err))
+		v.groupVaultError(ctx, err)
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventSavingsWithdrawn, "vault", vaultID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("Group withdrawal of %s from vault %s requested by user %s", request.Amount, vaultID, activeUser.UserID)
+	auditLog.OldValues = nil
+	auditLog.NewValues = map[string]any{
+		"request_id":         request.ID,
+		"amount":             request.Amount,
+		"reason":             request.Reason,
+		"status":             request.Status,
+		"approvals_required": request.ApprovalsRequired,
+		"created_at":         request.CreatedAt,
+	}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusCreated, basemodels.NewSuccess("withdrawal request created", request))
+}
+
+// voteGroupWithdrawal godoc
+// @Summary Vote on Group Vault Withdrawal
+// @Description Approve or reject a pending withdrawal request. The request is paid or rejected as soon as the outcome is certain.
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Vault Goal ID"
+// @Param request_id path string true "Withdrawal Request ID"
+// @Param voteRequest body object{approve=bool} true "Vote"
+// @Success 200 {object} vaultsavings.GroupWithdrawalRequestResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/groups/{id}/withdrawal-requests/{request_id}/vote [post]
+func (v *Vault) voteGroupWithdrawal(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	requestID, err := uuid.Parse(ctx.Param("request_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid request ID"))
+		return
+	}
+
+	var req struct {
+		Approve *bool `json:"approve" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	request, err := v.vaultService.VoteGroupWithdrawal(ctx.Request.Context(), vaultID, requestID, activeUser.UserID, *req.Approve)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to vote on group withdrawal: %v", err))
+		v.groupVaultError(ctx, err)
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventVaultUpdated, "vault", vaultID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("User %s voted on group withdrawal request %s", activeUser.UserID, requestID)
+	auditLog.OldValues = nil
+	auditLog.NewValues = map[string]any{
+		"request_id": requestID,
+		"approve":    *req.Approve,
+		"status":     request.Status,
+	}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("vote recorded", request))
+}
+
+// groupVaultError maps group vault errors to a response
+func (v *Vault) groupVaultError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, vaultsavings.ErrNotGroupVault):
+		ctx.JSON(http.StatusNotFound, basemodels.NewError(err.Error()))
+	case errors.Is(err, vaultsavings.ErrNotGroupMember),
+		errors.Is(err, vaultsavings.ErrNotGroupOwner):
+		ctx.JSON(http.StatusForbidden, basemodels.NewError(err.Error()))
+	case errors.Is(err, vaultsavings.ErrWithdrawalRequestPending),
+		errors.Is(err, vaultsavings.ErrAlreadyVoted),
+		errors.Is(err, vaultsavings.ErrVaultNameTaken):
+		ctx.JSON(http.StatusConflict, basemodels.NewError(err.Error()))
+	case errors.Is(err, vaultsavings.ErrInvalidWithdrawalRule),
+		errors.Is(err, vaultsavings.ErrGroupWithdrawalRestricted),
+		errors.Is(err, vaultsavings.ErrUserTagNotFound),
+		errors.Is(err, vaultsavings.ErrNoGroupInvite),
+		errors.Is(err, vaultsavings.ErrWithdrawalRequestClosed),
+		errors.Is(err, vaultsavings.ErrInvalidCurrency),
+		errors.Is(err, vaultsavings.ErrInvalidAmount),
+		errors.Is(err, vaultsavings.ErrInvalidLockTerm),
+		errors.Is(err, vaultsavings.ErrInsufficientBalance):
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+	}
+}
+
+// ============================================================================
+// AUTO-SAVE RULES
+// ============================================================================
+
+// createAutoSaveRule godoc
+// @Summary Create Auto-Save Rule
+// @Description Save into a vault whenever money moves: round debits up and save the change daily, or save a percentage of every inflow
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param createAutoSaveRuleRequest body vaultsavings.CreateAutoSaveRuleRequest true "Create Auto-Save Rule Request"
+// @Success 201 {object} vaultsavings.AutoSaveRuleResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/auto-save-rules [post]
+func (v *Vault) createAutoSaveRule(ctx *gin.Context) {
+	settings, err := v.server.queries.GetSystemSettings(ctx)
+	if err != nil {
+		v.server.logger.Error("Failed to get system settings", "error", err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.VaultsEnabled.Bool {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("vaults are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	var req vaultsavings.CreateAutoSaveRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	rule, err := v.vaultService.CreateAutoSaveRule(ctx.Request.Context(), activeUser.UserID, req)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to create auto-save rule: %v", err))
+		v.autoSaveError(ctx, err)
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventVaultUpdated, "vault", rule.VaultID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("Auto-save rule %s added to vault %s by user %s", rule.ID, rule.VaultID, activeUser.UserID)
+	auditLog.OldValues = nil
+	auditLog.NewValues = map[string]any{
+		"rule_id":             rule.ID,
+		"rule_type":           rule.RuleType,
+		"triggers":            rule.Triggers,
+		"round_to":            rule.RoundTo,
+		"percentage":          rule.Percentage,
+		"max_per_transaction": rule.MaxPerTransaction,
+	}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusCreated, basemodels.NewSuccess("auto-save rule created successfully", rule))
+}
+
+// listAutoSaveRules godoc
+// @Summary List Auto-Save Rules
+// @Description Get the authenticated user's auto-save rules with what each has saved so far
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} []vaultsavings.AutoSaveRuleResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/auto-save-rules [get]
+func (v *Vault) listAutoSaveRules(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	rules, err := v.vaultService.ListAutoSaveRules(ctx.Request.Context(), activeUser.UserID)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to list auto-save rules: %v", err))
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to list auto-save rules"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("auto-save rules retrieved successfully", rules))
+}
+
+// updateAutoSaveRule godoc
+// @Summary Update Auto-Save Rule
+// @Description Change an auto-save rule's triggers or amounts, or pause and resume it
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Auto-Save Rule ID"
+// @Param updateAutoSaveRuleRequest body vaultsavings.UpdateAutoSaveRuleRequest true "Update Auto-Save Rule Request"
+// @Success 200 {object} vaultsavings.AutoSaveRuleResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/auto-save-rules/{id} [put]
+func (v *Vault) updateAutoSaveRule(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid rule ID"))
+		return
+	}
+
+	var req vaultsavings.UpdateAutoSaveRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	rule, err := v.vaultService.UpdateAutoSaveRule(ctx.Request.Context(), activeUser.UserID, ruleID, req)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to update auto-save rule: %v", err))
+		v.autoSaveError(ctx, err)
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventVaultUpdated, "vault", rule.VaultID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("Auto-save rule %s updated by user %s", rule.ID, activeUser.UserID)
+	auditLog.OldValues = nil
+	auditLog.NewValues = map[string]any{
+		"rule_id":             rule.ID,
+		"triggers":            rule.Triggers,
+		"round_to":            rule.RoundTo,
+		"percentage":          rule.Percentage,
+		"max_per_transaction": rule.MaxPerTransaction,
+		"is_active":           rule.IsActive,
+	}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("auto-save rule updated successfully", rule))
+}
+
+// deleteAutoSaveRule godoc
+// @Summary Delete Auto-Save Rule
+// @Description Remove an auto-save rule. Round-ups not yet deposited are dropped with it.
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Auto-Save Rule ID"
+// @Success 200 {object} basemodels.SuccessResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/auto-save-rules/{id} [delete]
+func (v *Vault) deleteAutoSaveRule(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid rule ID"))
+		return
+	}
+
+	if err := v.vaultService.DeleteAutoSaveRule(ctx.Request.Context(), activeUser.UserID, ruleID); err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to delete auto-save rule: %v", err))
+		v.autoSaveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("auto-save rule deleted", nil))
+}
+
+// autoSaveError maps auto-save rule errors to a response
+func (v *Vault) autoSaveError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, vaultsavings.ErrAutoSaveRuleNotFound),
+		errors.Is(err, vaultsavings.ErrVaultNotFound):
+		ctx.JSON(http.StatusNotFound, basemodels.NewError(err.Error()))
+	case errors.Is(err, vaultsavings.ErrInvalidAutoSaveRule),
+		errors.Is(err, vaultsavings.ErrVaultLocked):
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+	}
+}
+
+// ============================================================================
+// YIELD STATEMENTS & WITHHOLDING TAX
+// ============================================================================
+
+// getYieldStatement godoc
+// @Summary Get Vault Yield Statement
+// @Description Yield credited to a vault in a tax year with the withholding tax deducted from each credit. Pass format=csv to download the statement.
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param id path string true "Vault ID"
+// @Param tax_year query int false "Tax year, defaults to the current year"
+// @Param format query string false "json or csv" default(json)
+// @Success 200 {object} basemodels.SuccessResponse{data=vaultsavings.YieldStatement}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/goals/{id}/yield-statement [get]
+func (v *Vault) getYieldStatement(ctx *gin.Context) {
+	settings, err := v.server.queries.GetSystemSettings(ctx)
+	if err != nil {
+		v.server.logger.Error("Failed to get system settings", "error", err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.VaultsEnabled.Bool {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("vaults are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	vaultID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid vault ID"))
+		return
+	}
+
+	// Verify ownership
+	vault, err := v.vaultService.GetVaultByID(ctx.Request.Context(), vaultID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, basemodels.NewError("vault not found"))
+		return
+	}
+	if vault.UserID != activeUser.UserID {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("access denied"))
+		return
+	}
+
+	taxYear := time.Now().Year()
+	if y := ctx.Query("tax_year"); y != "" {
+		if taxYear, err = strconv.Atoi(y); err != nil {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(vaultsavings.ErrInvalidTaxYear.Error()))
+			return
+		}
+	}
+
+	statement, err := v.yieldService.GetVaultYieldStatement(ctx.Request.Context(), vaultID, int32(taxYear))
+	if err != nil {
+		if errors.Is(err, vaultsavings.ErrInvalidTaxYear) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		v.server.logger.Error(fmt.Sprintf("Failed to get yield statement: %v", err))
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get yield statement"))
+		return
+	}
+
+	if ctx.Query("format") != "csv" {
+		ctx.JSON(http.StatusOK, basemodels.NewSuccess("yield statement retrieved", statement))
+		return
+	}
+
+	// buffer the statement so a failure midway still produces a JSON error
+	var buf bytes.Buffer
+	if err := vaultsavings.WriteYieldStatementCSV(&buf, statement); err != nil {
+		v.server.logger.Error(fmt.Sprintf("Failed to write yield statement: %v", err))
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to export yield statement"))
+		return
+	}
+
+	filename := fmt.Sprintf("yield-statement-%s-%d.csv", vaultID, taxYear)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	ctx.Data(http.StatusOK, "text/csv", buf.Bytes())
+}
+
+// getWithholdingTaxLiability godoc
+// @Summary Get Withholding Tax Liability (Admin)
+// @Description Tax withheld from vault yield per currency and tax year, split into what is still owed to the tax authority and what has been remitted
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD or RFC3339), defaults to 30 days ago"
+// @Param to query string false "End date (YYYY-MM-DD inclusive or RFC3339 exclusive), defaults to now"
+// @Success 200 {object} basemodels.SuccessResponse{data=[]vaultsavings.WithholdingTaxLiability}
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/admin/withholding-tax [get]
+func (v *Vault) getWithholdingTaxLiability(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("forbidden"))
+		return
+	}
+
+	from, to, err := parseRevenueRange(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	liability, err := v.yieldService.GetWithholdingTaxLiability(ctx.Request.Context(), from, to)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("Failed to get withholding tax liability: %v", err))
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get withholding tax liability"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("withholding tax liability retrieved", liability))
+}
+
+// remitWithholdingTax godoc
+// @Summary Remit Withholding Tax (Admin)
+// @Description Marks the tax withheld in a currency and tax year before the cutoff as paid to the tax authority under the given reference
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param remitWithholdingTaxRequest body vaultsavings.RemitWithholdingTaxParams true "Remittance"
+// @Success 200 {object} basemodels.SuccessResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 403 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/admin/withholding-tax/remit [post]
+func (v *Vault) remitWithholdingTax(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("forbidden"))
+		return
+	}
+
+	var req vaultsavings.RemitWithholdingTaxParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	remitted, err := v.yieldService.RemitWithholdingTax(ctx.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, vaultsavings.ErrInvalidTaxYear) {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+		v.server.logger.Error(fmt.Sprintf("Failed to remit withholding tax: %v", err))
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to remit withholding tax"))
+		return
+	}
+
+	result := map[string]any{
+		"currency":         req.Currency,
+		"tax_year":         req.TaxYear,
+		"entries_remitted": remitted,
+		"reference":        req.Reference,
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventWithholdingTaxRemitted, "vault", req.Reference, activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("Withholding tax for %s %d remitted by admin user %s", req.Currency, req.TaxYear, activeUser.UserID)
+	auditLog.NewValues = result
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("withholding tax remitted", result))
+}
+
+// isYieldConfigError reports whether a yield config was rejected for its terms
+func isYieldConfigError(err error) bool {
+	return errors.Is(err, vaultsavings.ErrInvalidYieldTiers) ||
+		errors.Is(err, vaultsavings.ErrInvalidWithholdingTax) ||
+		errors.Is(err, vaultsavings.ErrInvalidLockTerm)
+}
+
+// ============================================================================
+// SWEEP RULES
+// ============================================================================
+
+// createSweepRule godoc
+// @Summary Create Sweep Rule
+// @Description Keep a wallet at a target balance: sweep the excess into a vault every day at run_time, or top the wallet up from a vault when it drops below the target
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param createSweepRuleRequest body vaultsavings.CreateSweepRuleRequest true "Create Sweep Rule Request"
+// @Success 201 {object} vaultsavings.SweepRuleResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/sweep-rules [post]
+func (v *Vault) createSweepRule(ctx *gin.Context) {
+	settings, err := v.server.queries.GetSystemSettings(ctx)
+	if err != nil {
+		v.server.logger.Error("Failed to get system settings", "error", err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to get system settings"))
+		return
+	}
+	if !settings.VaultsEnabled.Bool {
+		ctx.JSON(http.StatusForbidden, basemodels.NewError("vaults are disabled"))
+		return
+	}
+
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	var req vaultsavings.CreateSweepRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	rule, err := v.vaultService.CreateSweepRule(ctx.Request.Context(), activeUser.UserID, req)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to create sweep rule: %v", err))
+		v.sweepError(ctx, err)
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventVaultUpdated, "vault", rule.VaultID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("Sweep rule %s added to vault %s by user %s", rule.ID, rule.VaultID, activeUser.UserID)
+	auditLog.OldValues = nil
+	auditLog.NewValues = map[string]any{
+		"rule_id":        rule.ID,
+		"rule_type":      rule.RuleType,
+		"currency":       rule.Currency,
+		"target_balance": rule.TargetBalance,
+		"min_amount":     rule.MinAmount,
+		"run_time":       rule.RunTime,
+	}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusCreated, basemodels.NewSuccess("sweep rule created successfully", rule))
+}
+
+// listSweepRules godoc
+// @Summary List Sweep Rules
+// @Description Get the authenticated user's wallet sweep rules
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} []vaultsavings.SweepRuleResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/sweep-rules [get]
+func (v *Vault) listSweepRules(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	rules, err := v.vaultService.ListSweepRules(ctx.Request.Context(), activeUser.UserID)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to list sweep rules: %v", err))
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to list sweep rules"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("sweep rules retrieved successfully", rules))
+}
+
+// updateSweepRule godoc
+// @Summary Update Sweep Rule
+// @Description Change a sweep rule's target balance, minimum amount or run time, or pause and resume it
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sweep Rule ID"
+// @Param updateSweepRuleRequest body vaultsavings.UpdateSweepRuleRequest true "Update Sweep Rule Request"
+// @Success 200 {object} vaultsavings.SweepRuleResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/sweep-rules/{id} [put]
+func (v *Vault) updateSweepRule(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid rule ID"))
+		return
+	}
+
+	var req vaultsavings.UpdateSweepRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	rule, err := v.vaultService.UpdateSweepRule(ctx.Request.Context(), activeUser.UserID, ruleID, req)
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to update sweep rule: %v", err))
+		v.sweepError(ctx, err)
+		return
+	}
+
+	// audit log
+	auditLog := audit.NewVaultLog(ctx, audit.EventVaultUpdated, "vault", rule.VaultID.String(), activeUser.Role, &activeUser.UserID, audit.SeverityInfo)
+	auditLog.Description = fmt.Sprintf("Sweep rule %s updated by user %s", rule.ID, activeUser.UserID)
+	auditLog.OldValues = nil
+	auditLog.NewValues = map[string]any{
+		"rule_id":        rule.ID,
+		"target_balance": rule.TargetBalance,
+		"min_amount":     rule.MinAmount,
+		"run_time":       rule.RunTime,
+		"is_active":      rule.IsActive,
+	}
+	v.audit.Log(auditLog)
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("sweep rule updated successfully", rule))
+}
+
+// deleteSweepRule godoc
+// @Summary Delete Sweep Rule
+// @Description Delete a sweep rule and its history
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sweep Rule ID"
+// @Success 200 {object} basemodels.SuccessResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/sweep-rules/{id} [delete]
+func (v *Vault) deleteSweepRule(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	ruleID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid rule ID"))
+		return
+	}
+
+	if err := v.vaultService.DeleteSweepRule(ctx.Request.Context(), activeUser.UserID, ruleID); err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to delete sweep rule: %v", err))
+		v.sweepError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("sweep rule deleted", nil))
+}
+
+// listSweepRuns godoc
+// @Summary List Sweep History
+// @Description Get every run of the user's sweep rules, newest first, including runs that had nothing to move
+// @Tags vault
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule_id query string false "Only runs of this sweep rule"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} []vaultsavings.SweepRunResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/vault/sweep-history [get]
+func (v *Vault) listSweepRuns(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	var ruleID *uuid.UUID
+	if id := ctx.Query("rule_id"); id != "" {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid rule ID"))
+			return
+		}
+		ruleID = &parsed
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid limit"))
+		return
+	}
+
+	offset, err := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError("invalid offset"))
+		return
+	}
+
+	runs, err := v.vaultService.ListSweepRuns(ctx.Request.Context(), activeUser.UserID, ruleID, int32(limit), int32(offset))
+	if err != nil {
+		v.server.logger.Error(fmt.Sprintf("failed to list sweep runs: %v", err))
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError("failed to list sweep history"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("sweep history retrieved successfully", runs))
+}
+
+// sweepError maps sweep rule errors to a response
+func (v *Vault) sweepError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, vaultsavings.ErrSweepRuleNotFound),
+		errors.Is(err, vaultsavings.ErrVaultNotFound):
+		ctx.JSON(http.StatusNotFound, basemodels.NewError(err.Error()))
+	case errors.Is(err, vaultsavings.ErrSweepRuleConflict):
+		ctx.JSON(http.StatusConflict, basemodels.NewError(err.Error()))
+	case errors.Is(err, vaultsavings.ErrInvalidSweepRule),
+		errors.Is(err, vaultsavings.ErrVaultLocked):
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+	default:
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+	}
+}
@@ -1,9 +1,16 @@
 package api
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"time"
 
@@ -64,6 +71,20 @@ func (v Virtualcard) router(server *Server) {
 		v1.GET("/admin/get-issuing-wallet-balance", server.authMiddleware.AuthenticatedMiddleware(), v.GetIssuingWalletBalance)        //done
 		v1.GET("/admin/get-all-issued-cards", server.authMiddleware.AuthenticatedMiddleware(), v.GetAllIssuedCards)                    //done
 		v1.GET("/admin/list-card-transactions-by-user", server.authMiddleware.AuthenticatedMiddleware(), v.ListCardTransactionsByUser) //done
+		v1.GET("/spending-controls", server.authMiddleware.AuthenticatedMiddleware(), v.GetSpendingControls)
+		v1.PUT("/spending-controls", server.authMiddleware.AuthenticatedMiddleware(), v.UpdateSpendingControls)
+		v1.POST("/admin/billing-runs", server.authMiddleware.AuthenticatedMiddleware(), v.RunMaintenanceBilling)
+		v1.GET("/admin/billing-runs", server.authMiddleware.AuthenticatedMiddleware(), v.ListBillingRuns)
+		v1.GET("/admin/billing-runs/:run_id", server.authMiddleware.AuthenticatedMiddleware(), v.GetBillingRunReport)
+		v1.POST("/disputes", server.authMiddleware.AuthenticatedMiddleware(), v.OpenDispute)
+		v1.GET("/disputes", server.authMiddleware.AuthenticatedMiddleware(), v.ListDisputes)
+		v1.GET("/disputes/:dispute_id", server.authMiddleware.AuthenticatedMiddleware(), v.GetDispute)
+		v1.POST("/disputes/:dispute_id/evidence", server.authMiddleware.AuthenticatedMiddleware(), v.AddDisputeEvidence)
+		v1.GET("/admin/disputes", server.authMiddleware.AuthenticatedMiddleware(), v.ListDisputeQueue)
+		v1.GET("/admin/disputes/:dispute_id", server.authMiddleware.AuthenticatedMiddleware(), v.AdminGetDispute)
+		v1.POST("/admin/disputes/:dispute_id/review", server.authMiddleware.AuthenticatedMiddleware(), v.StartDisputeReview)
+		v1.POST("/admin/disputes/:dispute_id/provisional-credit", server.authMiddleware.AuthenticatedMiddleware(), v.GrantDisputeCredit)
+		v1.POST("/admin/disputes/:dispute_id/resolve", server.authMiddleware.AuthenticatedMiddleware(), v.ResolveDispute)
 		
 	}
 
@@ -79,6 +100,7 @@ type UpdateCardPlanParams struct {
 	DailySpendingLimit    *string `json:"daily_spending_limit"`
 	IsActive              *bool   `json:"is_active"`
 	CardLimit             *string `json:"card_limit"`
+	UsageType             *string `json:"usage_type" binding:"omitempty,oneof=standard single_use merchant_locked"`
 }
 
 // AdminUpdateCardPlan godoc
@@ -165,6 +187,10 @@ func (v *Virtualcard) AdminUpdateCardPlan(c *gin.Context) {
 			Bool:  derefBool(req.IsActive),
 			Valid: req.IsActive != nil,
 		},
+		UsageType: sql.NullString{
+			String: derefString(req.UsageType),
+			Valid:  req.UsageType != nil,
+		},
 	}
 
 	plan, err := v.server.queries.UpdateCardPlan(c, params)
@@ -209,6 +235,7 @@ func (v *Virtualcard) AdminUpdateCardPlan(c *gin.Context) {
 		"daily_spending_limit":    oldCardPlan.DailySpendingLimit,
 		"is_active":               oldCardPlan.IsActive,
 		"card_limit":              oldCardPlan.CardLimit,
+		"usage_type":              oldCardPlan.UsageType,
 	}
 	entry.NewValues = map[string]any{
 		"name":                    plan.Name,
@@ -220,6 +247,7 @@ func (v *Virtualcard) AdminUpdateCardPlan(c *gin.Context) {
 		"daily_spending_limit":    plan.DailySpendingLimit,
 		"is_active":               plan.IsActive,
 		"card_limit":              plan.CardLimit,
+		"usage_type":              plan.UsageType,
 	}
 	v.audit.Log(entry)
 
@@ -1282,6 +1310,782 @@ func (v *Virtualcard) GetCardDetails(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetSpendingControls godoc
+// @Summary Get card spending controls
+// @Description Get the blocked merchant categories, channel toggles and custom limits of a virtual card
+// @Tags Cards
+// @Produce json
+// @Param card_id query string true "Card ID"
+// @Success 200 {object} virtualcard.SpendingControlsResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/spending-controls [get]
+func (v *Virtualcard) GetSpendingControls(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	cardID := c.Query("card_id")
+	if cardID == "" {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("missing card_id query parameter"))
+		return
+	}
+
+	response, err := v.virtualCardSvc.GetSpendingControls(c, cardID, activeUser.UserID)
+	if err != nil {
+		c.JSON(spendingControlsErrorStatus(err), basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Spending controls retrieved", response))
+}
+
+// UpdateSpendingControls godoc
+// @Summary Update card spending controls
+// @Description Replace the blocked merchant categories, channel toggles and custom limits of a virtual card. Custom limits cannot exceed the card plan's. Bridgecard settles debits before we see them, so a debit that breaks a control freezes the card.
+// @Tags Cards
+// @Accept json
+// @Produce json
+// @Param card_id query string true "Card ID"
+// @Param request body virtualcard.SpendingControlsRequest true "Spending controls"
+// @Success 200 {object} virtualcard.SpendingControlsResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/spending-controls [put]
+func (v *Virtualcard) UpdateSpendingControls(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	cardID := c.Query("card_id")
+	if cardID == "" {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("missing card_id query parameter"))
+		return
+	}
+
+	var req virtualcard.SpendingControlsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	response, err := v.virtualCardSvc.UpdateSpendingControls(c, cardID, activeUser.UserID, req)
+	if err != nil {
+		errMsg := err.Error()
+		entry := audit.NewLog(
+			c,
+			audit.CategoryCard,
+			audit.EventUpdateCardControls,
+			cardID,
+			fmt.Sprintf("user %s updated spending controls of card %s", activeUser.UserID, cardID),
+			&activeUser.UserID,
+			activeUser.Role,
+			false,
+			&errMsg,
+		)
+		v.audit.Log(entry)
+		c.JSON(spendingControlsErrorStatus(err), basemodels.NewError(err.Error()))
+		return
+	}
+
+	entry := audit.NewLog(
+		c,
+		audit.CategoryCard,
+		audit.EventUpdateCardControls,
+		cardID,
+		fmt.Sprintf("user %s updated spending controls of card %s", activeUser.UserID, cardID),
+		&activeUser.UserID,
+		activeUser.Role,
+		true,
+		nil,
+	)
+	v.audit.Log(entry)
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Spending controls updated", response))
+}
+
+// RunMaintenanceBilling godoc
+// @Summary Run card maintenance billing [admin]
+// @Description Charge the monthly maintenance fee of every card that is due now, instead of waiting for the daily scheduled run
+// @Tags Cards
+// @Produce json
+// @Success 200 {object} virtualcard.BillingRunReport
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/admin/billing-runs [post]
+func (v *Virtualcard) RunMaintenanceBilling(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	// The run must finish and record its totals even if the admin disconnects.
+	report, err := v.virtualCardSvc.RunMaintenanceBilling(context.WithoutCancel(c), virtualcard.BillingRunManual, &activeUser.UserID)
+	if err != nil {
+		errMsg := err.Error()
+		entry := audit.NewLog(
+			c,
+			audit.CategoryCard,
+			audit.EventRunCardBilling,
+			"",
+			fmt.Sprintf("admin %s ran card maintenance billing", activeUser.UserID),
+			&activeUser.UserID,
+			activeUser.Role,
+			false,
+			&errMsg,
+		)
+		v.audit.Log(entry)
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	entry := audit.NewLog(
+		c,
+		audit.CategoryCard,
+		audit.EventRunCardBilling,
+		report.Run.ID.String(),
+		fmt.Sprintf("admin %s ran card maintenance billing: %d due, %d charged", activeUser.UserID, report.Run.CardsDue, report.Run.ChargedCount),
+		&activeUser.UserID,
+		activeUser.Role,
+		true,
+		nil,
+	)
+	v.audit.Log(entry)
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Billing run completed", report))
+}
+
+// ListBillingRuns godoc
+// @Summary List card maintenance billing runs [admin]
+// @Description List scheduled and manual maintenance billing runs, newest first
+// @Tags Cards
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {array} virtualcard.BillingRunResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/admin/billing-runs [get]
+func (v *Virtualcard) ListBillingRuns(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid limit query parameter"))
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid offset query parameter"))
+		return
+	}
+
+	runs, err := v.virtualCardSvc.ListBillingRuns(c, int32(limit), int32(offset))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Billing runs retrieved", runs))
+}
+
+// GetBillingRunReport godoc
+// @Summary Get a card maintenance billing run report [admin]
+// @Description Get a billing run's totals and every maintenance charge it attempted
+// @Tags Cards
+// @Produce json
+// @Param run_id path string true "Billing run ID"
+// @Success 200 {object} virtualcard.BillingRunReport
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/admin/billing-runs/{run_id} [get]
+func (v *Virtualcard) GetBillingRunReport(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	runID, err := uuid.Parse(c.Param("run_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid run_id"))
+		return
+	}
+
+	report, err := v.virtualCardSvc.GetBillingRunReport(c, runID)
+	if errors.Is(err, virtualcard.ErrBillingRunNotFound) {
+		c.JSON(http.StatusNotFound, basemodels.NewError(err.Error()))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Billing run report retrieved", report))
+}
+
+// DisputeReviewRequest starts the review of a card dispute.
+type DisputeReviewRequest struct {
+	ProviderReference string `json:"provider_reference"`
+	Note              string `json:"note"`
+}
+
+// DisputeNoteRequest carries an optional admin note for a dispute action.
+type DisputeNoteRequest struct {
+	Note string `json:"note"`
+}
+
+// ResolveDisputeRequest closes a card dispute.
+type ResolveDisputeRequest struct {
+	Outcome string `json:"outcome" binding:"required,oneof=won lost"`
+	Note    string `json:"note"`
+}
+
+// OpenDispute godoc
+// @Summary Dispute a card transaction
+// @Description Dispute an unrecognised, duplicated or otherwise wrong card debit. Evidence files (PNG, JPG, JPEG or PDF, up to 5MB each) can be attached in the "evidence" field. A support ticket is opened for the case.
+// @Tags Cards
+// @Accept multipart/form-data
+// @Produce json
+// @Param card_transaction_id formData string true "Card transaction ID"
+// @Param reason formData string true "unrecognised, duplicate, incorrect_amount, not_received, cancelled_recurring or other"
+// @Param description formData string true "What went wrong"
+// @Param evidence formData file false "Evidence files"
+// @Success 201 {object} virtualcard.DisputeResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/disputes [post]
+func (v *Virtualcard) OpenDispute(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	var req virtualcard.OpenDisputeRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	var files []*multipart.FileHeader
+	if form, err := c.MultipartForm(); err == nil {
+		files = form.File["evidence"]
+	}
+	if len(files) > virtualcard.MaxDisputeEvidence {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(virtualcard.ErrTooMuchEvidence.Error()))
+		return
+	}
+	for _, header := range files {
+		if err := validateDisputeEvidence(header); err != nil {
+			c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+			return
+		}
+	}
+
+	dispute, err := v.virtualCardSvc.OpenDispute(c, activeUser.UserID, req)
+	if err != nil {
+		errMsg := err.Error()
+		entry := audit.NewLog(
+			c,
+			audit.CategoryCard,
+			audit.EventOpenCardDispute,
+			req.CardTransactionID,
+			fmt.Sprintf("user %s disputed card transaction %s", activeUser.UserID, req.CardTransactionID),
+			&activeUser.UserID,
+			activeUser.Role,
+			false,
+			&errMsg,
+		)
+		v.audit.Log(entry)
+		c.JSON(disputeErrorStatus(err), basemodels.NewError(err.Error()))
+		return
+	}
+
+	entry := audit.NewLog(
+		c,
+		audit.CategoryCard,
+		audit.EventOpenCardDispute,
+		dispute.ID.String(),
+		fmt.Sprintf("user %s disputed card transaction %s", activeUser.UserID, req.CardTransactionID),
+		&activeUser.UserID,
+		activeUser.Role,
+		true,
+		nil,
+	)
+	v.audit.Log(entry)
+
+	// The dispute stands even if an upload fails; the user can add the
+	// file again from the dispute screen.
+	for _, header := range files {
+		if _, err := v.saveDisputeEvidence(c, dispute.ID, activeUser.UserID, header); err != nil {
+			v.server.logger.Errorf("dispute %s: save evidence %s: %v", dispute.ID, header.Filename, err)
+		}
+	}
+
+	c.JSON(http.StatusCreated, basemodels.NewSuccess("Dispute opened", dispute))
+}
+
+// AddDisputeEvidence godoc
+// @Summary Add evidence to a card dispute
+// @Description Upload a PNG, JPG, JPEG or PDF file (up to 5MB) as evidence for an open dispute
+// @Tags Cards
+// @Accept multipart/form-data
+// @Produce json
+// @Param dispute_id path string true "Dispute ID"
+// @Param file formData file true "Evidence file"
+// @Success 201 {object} db.CardDisputeEvidence
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/disputes/{dispute_id}/evidence [post]
+func (v *Virtualcard) AddDisputeEvidence(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	disputeID, err := uuid.Parse(c.Param("dispute_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid dispute_id"))
+		return
+	}
+
+	_, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("file is required"))
+		return
+	}
+	if err := validateDisputeEvidence(header); err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	evidence, err := v.saveDisputeEvidence(c, disputeID, activeUser.UserID, header)
+	if err != nil {
+		c.JSON(disputeErrorStatus(err), basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, basemodels.NewSuccess("Evidence added", evidence))
+}
+
+// ListDisputes godoc
+// @Summary List my card disputes
+// @Description List the active user's card disputes, newest first
+// @Tags Cards
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {array} virtualcard.DisputeResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/disputes [get]
+func (v *Virtualcard) ListDisputes(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid limit query parameter"))
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid offset query parameter"))
+		return
+	}
+
+	disputes, err := v.virtualCardSvc.ListUserDisputes(c, activeUser.UserID, int32(limit), int32(offset))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Disputes retrieved", disputes))
+}
+
+// GetDispute godoc
+// @Summary Get a card dispute
+// @Description Get one of the active user's card disputes with its evidence and timeline
+// @Tags Cards
+// @Produce json
+// @Param dispute_id path string true "Dispute ID"
+// @Success 200 {object} virtualcard.DisputeDetail
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/disputes/{dispute_id} [get]
+func (v *Virtualcard) GetDispute(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	disputeID, err := uuid.Parse(c.Param("dispute_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid dispute_id"))
+		return
+	}
+
+	detail, err := v.virtualCardSvc.GetDispute(c, disputeID, &activeUser.UserID)
+	if err != nil {
+		c.JSON(disputeErrorStatus(err), basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Dispute retrieved", detail))
+}
+
+// ListDisputeQueue godoc
+// @Summary List the card dispute case queue [admin]
+// @Description List card disputes with open cases first, ordered by resolution deadline, flagging cases past their review or resolution SLA
+// @Tags Cards
+// @Produce json
+// @Param status query string false "opened, under_review, provisional_credit, won or lost"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {array} virtualcard.DisputeQueueItem
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/admin/disputes [get]
+func (v *Virtualcard) ListDisputeQueue(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid limit query parameter"))
+		return
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid offset query parameter"))
+		return
+	}
+
+	queue, err := v.virtualCardSvc.ListDisputeQueue(c, c.Query("status"), int32(limit), int32(offset))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Dispute queue retrieved", queue))
+}
+
+// AdminGetDispute godoc
+// @Summary Get a card dispute [admin]
+// @Description Get any card dispute with its evidence and timeline
+// @Tags Cards
+// @Produce json
+// @Param dispute_id path string true "Dispute ID"
+// @Success 200 {object} virtualcard.DisputeDetail
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/admin/disputes/{dispute_id} [get]
+func (v *Virtualcard) AdminGetDispute(c *gin.Context) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	disputeID, err := uuid.Parse(c.Param("dispute_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid dispute_id"))
+		return
+	}
+
+	detail, err := v.virtualCardSvc.GetDispute(c, disputeID, nil)
+	if err != nil {
+		c.JSON(disputeErrorStatus(err), basemodels.NewError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Dispute retrieved", detail))
+}
+
+// StartDisputeReview godoc
+// @Summary Start reviewing a card dispute [admin]
+// @Description Assign an opened dispute to the active admin and move it under review, optionally recording the card issuer's case reference
+// @Tags Cards
+// @Accept json
+// @Produce json
+// @Param dispute_id path string true "Dispute ID"
+// @Param request body DisputeReviewRequest true "Review details"
+// @Success 200 {object} virtualcard.DisputeResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/admin/disputes/{dispute_id}/review [post]
+func (v *Virtualcard) StartDisputeReview(c *gin.Context) {
+	var req DisputeReviewRequest
+	v.updateDispute(c, &req, "started review of", func(disputeID, adminID uuid.UUID) (*virtualcard.DisputeResponse, error) {
+		return v.virtualCardSvc.StartDisputeReview(c, disputeID, adminID, req.ProviderReference, req.Note)
+	})
+}
+
+// GrantDisputeCredit godoc
+// @Summary Grant provisional credit on a card dispute [admin]
+// @Description Credit the disputed amount to the customer's USD wallet while the case is worked. The credit is reversed if the dispute is lost.
+// @Tags Cards
+// @Accept json
+// @Produce json
+// @Param dispute_id path string true "Dispute ID"
+// @Param request body DisputeNoteRequest false "Admin note"
+// @Success 200 {object} virtualcard.DisputeResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/admin/disputes/{dispute_id}/provisional-credit [post]
+func (v *Virtualcard) GrantDisputeCredit(c *gin.Context) {
+	var req DisputeNoteRequest
+	v.updateDispute(c, &req, "granted provisional credit on", func(disputeID, adminID uuid.UUID) (*virtualcard.DisputeResponse, error) {
+		return v.virtualCardSvc.GrantProvisionalCredit(context.WithoutCancel(c), disputeID, adminID, req.Note)
+	})
+}
+
+// ResolveDispute godoc
+// @Summary Resolve a card dispute [admin]
+// @Description Close a dispute as won or lost. A won dispute keeps or receives the wallet credit; a lost one has any provisional credit reversed.
+// @Tags Cards
+// @Accept json
+// @Produce json
+// @Param dispute_id path string true "Dispute ID"
+// @Param request body ResolveDisputeRequest true "Outcome"
+// @Success 200 {object} virtualcard.DisputeResponse
+// @Failure 400 {object} basemodels.ErrorResponse
+// @Failure 401 {object} basemodels.ErrorResponse
+// @Failure 404 {object} basemodels.ErrorResponse
+// @Failure 409 {object} basemodels.ErrorResponse
+// @Failure 500 {object} basemodels.ErrorResponse
+// @Router /api/v1/cards/admin/disputes/{dispute_id}/resolve [post]
+func (v *Virtualcard) ResolveDispute(c *gin.Context) {
+	var req ResolveDisputeRequest
+	v.updateDispute(c, &req, "resolved", func(disputeID, adminID uuid.UUID) (*virtualcard.DisputeResponse, error) {
+		return v.virtualCardSvc.ResolveDispute(context.WithoutCancel(c), disputeID, adminID, virtualcard.CardDisputeStatus(req.Outcome), req.Note)
+	})
+}
+
+// updateDispute runs an admin dispute action: it checks the role, parses
+// the dispute ID and optional JSON body, and audits the outcome.
+func (v *Virtualcard) updateDispute(c *gin.Context, req any, action string, apply func(disputeID, adminID uuid.UUID) (*virtualcard.DisputeResponse, error)) {
+	activeUser, err := utils.GetActiveUser(c)
+	if err != nil {
+		v.server.logger.Error(err.Error())
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	if activeUser.Role == models.USER {
+		c.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UnauthorizedAccess))
+		return
+	}
+
+	disputeID, err := uuid.Parse(c.Param("dispute_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, basemodels.NewError("invalid dispute_id"))
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	dispute, err := apply(disputeID, activeUser.UserID)
+	if err != nil {
+		errMsg := err.Error()
+		entry := audit.NewLog(
+			c,
+			audit.CategoryCard,
+			audit.EventUpdateCardDispute,
+			disputeID.String(),
+			fmt.Sprintf("admin %s %s dispute %s", activeUser.UserID, action, disputeID),
+			&activeUser.UserID,
+			activeUser.Role,
+			false,
+			&errMsg,
+		)
+		v.audit.Log(entry)
+		c.JSON(disputeErrorStatus(err), basemodels.NewError(err.Error()))
+		return
+	}
+
+	entry := audit.NewLog(
+		c,
+		audit.CategoryCard,
+		audit.EventUpdateCardDispute,
+		disputeID.String(),
+		fmt.Sprintf("admin %s %s dispute %s, now %s", activeUser.UserID, action, disputeID, dispute.Status),
+		&activeUser.UserID,
+		activeUser.Role,
+		true,
+		nil,
+	)
+	v.audit.Log(entry)
+
+	c.JSON(http.StatusOK, basemodels.NewSuccess("Dispute updated", dispute))
+}
+
+func validateDisputeEvidence(header *multipart.FileHeader) error {
+	if header.Size > 5*1024*1024 {
+		return fmt.Errorf("%s exceeds 5MB", header.Filename)
+	}
+	allowedContentTypes := []string{"image/png", "image/jpeg", "image/jpg", "application/pdf"}
+	if !slices.Contains(allowedContentTypes, header.Header.Get("Content-Type")) {
+		return fmt.Errorf("%s must be PNG, JPG, JPEG, or PDF", header.Filename)
+	}
+	return nil
+}
+
+// saveDisputeEvidence stores an uploaded file under assets/images and
+// attaches it to the dispute, removing the file again if that fails.
+func (v *Virtualcard) saveDisputeEvidence(c *gin.Context, disputeID, userID uuid.UUID, header *multipart.FileHeader) (*db.CardDisputeEvidence, error) {
+	contentType := header.Header.Get("Content-Type")
+	ext := filepath.Ext(header.Filename)
+	if ext == "" {
+		switch contentType {
+		case "image/png":
+			ext = ".png"
+		case "image/jpeg", "image/jpg":
+			ext = ".jpg"
+		case "application/pdf":
+			ext = ".pdf"
+		}
+	}
+
+	filename := fmt.Sprintf("dispute_%s_%d%s", disputeID, time.Now().UnixNano(), ext)
+	filePath := filepath.Join("assets/images", filename)
+	if err := c.SaveUploadedFile(header, filePath); err != nil {
+		return nil, fmt.Errorf("failed to save uploaded file: %w", err)
+	}
+
+	evidence, err := v.virtualCardSvc.AddDisputeEvidence(c, disputeID, userID, virtualcard.DisputeEvidenceFile{
+		URL:      "/assets/images/" + filename,
+		Name:     filepath.Base(header.Filename),
+		Size:     header.Size,
+		MimeType: contentType,
+	})
+	if err != nil {
+		os.Remove(filePath)
+		return nil, err
+	}
+	return evidence, nil
+}
+
+func spendingControlsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, virtualcard.ErrCardNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, virtualcard.ErrUnknownCategory),
+		errors.Is(err, virtualcard.ErrInvalidControlLimit),
+		errors.Is(err, virtualcard.ErrControlAbovePlanLimit),
+		errors.Is(err, virtualcard.ErrCardAlreadyTerminated):
+		return http.StatusBadRequest
+	case errors.Is(err, virtualcard.ErrCardNotOwned):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func disputeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, virtualcard.ErrCardTxNotFound),
+		errors.Is(err, virtualcard.ErrDisputeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, virtualcard.ErrInvalidDisputeReason),
+		errors.Is(err, virtualcard.ErrDisputeNotAllowed),
+		errors.Is(err, virtualcard.ErrDisputeWindowClosed),
+		errors.Is(err, virtualcard.ErrTooMuchEvidence):
+		return http.StatusBadRequest
+	case errors.Is(err, virtualcard.ErrDisputeExists),
+		errors.Is(err, virtualcard.ErrInvalidDisputeAction),
+		errors.Is(err, virtualcard.ErrDisputeClosed):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (v *Virtualcard) Webhook(c *gin.Context) {
 	// 1. Extract and verify webhook signature
 	// signature := c.GetHeader("x-webhook-signature")
@@ -1429,6 +2233,7 @@ type CreateCardPlanRequest struct {
 	MaxCardsPerUser          int32   `json:"max_cards_per_user" binding:"required"`
 	CardLimit                *string `json:"card_limit" binding:"required" enum:"5000,10000"`
 	FailedTxCountBeforeBlock *int32  `json:"failed_tx_count_before_block"`
+	UsageType                string  `json:"usage_type" binding:"omitempty,oneof=standard single_use merchant_locked"`
 }
 
 // CreateCardPlan godoc
@@ -1460,6 +2265,11 @@ func (v *Virtualcard) createCardPlan(c *gin.Context) {
 		return
 	}
 
+	usageType := req.UsageType
+	if usageType == "" {
+		usageType = string(virtualcard.CardUsageStandard)
+	}
+
 	params := db.CreateCardPlanParams{
 		Name:                     req.Name,
 		Description:              sql.NullString{String: *req.Description, Valid: req.Description != nil},
@@ -1471,6 +2281,7 @@ func (v *Virtualcard) createCardPlan(c *gin.Context) {
 		MaxCardsPerUser:          req.MaxCardsPerUser,
 		CardLimit:                sql.NullString{String: *req.CardLimit, Valid: req.CardLimit != nil},
 		FailedTxCountBeforeBlock: sql.NullInt32{Int32: *req.FailedTxCountBeforeBlock, Valid: req.FailedTxCountBeforeBlock != nil},
+		UsageType:                usageType,
 	}
 
 	plan, err := v.server.queries.CreateCardPlan(c.Request.Context(), params)
@@ -1498,6 +2309,7 @@ func (v *Virtualcard) createCardPlan(c *gin.Context) {
 			"max_cards_per_user":           plan.MaxCardsPerUser,
 			"card_limit":                   plan.CardLimit.String,
 			"failed_tx_count_before_block": plan.FailedTxCountBeforeBlock.Int32,
+			"usage_type":                   plan.UsageType,
 		}
 		v.audit.Log(entry)
 		c.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
@@ -1527,6 +2339,7 @@ func (v *Virtualcard) createCardPlan(c *gin.Context) {
 		"max_cards_per_user":           plan.MaxCardsPerUser,
 		"card_limit":                   plan.CardLimit.String,
 		"failed_tx_count_before_block": plan.FailedTxCountBeforeBlock.Int32,
+		"usage_type":                   plan.UsageType,
 	}
 	v.audit.Log(entry)
 
@@ -1627,6 +2440,7 @@ func (v *Virtualcard) DeleteCardPlan(c *gin.Context) {
 		"daily_spending_limit":    plan.DailySpendingLimit,
 		"is_active":               plan.IsActive,
 		"card_limit":              plan.CardLimit,
+		"usage_type":              plan.UsageType,
 	}
 	v.audit.Log(entry)
 
@@ -1838,6 +2652,7 @@ type CardPlanResponse struct {
 	CreatedAt                time.Time  `json:"created_at"`
 	UpdatedAt                time.Time  `json:"updated_at"`
 	DeletedAt                *time.Time `json:"deleted_at"`
+	UsageType                string     `json:"usage_type"`
 }
 
 func mapCardPlanToCardPlanResponse(cardPlan db.CardPlan) CardPlanResponse {
@@ -1857,6 +2672,7 @@ func mapCardPlanToCardPlanResponse(cardPlan db.CardPlan) CardPlanResponse {
 		CreatedAt:                cardPlan.CreatedAt,
 		UpdatedAt:                cardPlan.UpdatedAt,
 		DeletedAt:                &cardPlan.DeletedAt.Time,
+		UsageType:                cardPlan.UsageType,
 	}
 }
 
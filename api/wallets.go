@@ -10,6 +10,7 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -54,6 +55,9 @@ func (w Wallet) router(server *Server) {
 	serverGroupV1.GET("", w.server.authMiddleware.AuthenticatedMiddleware(), w.getUserWallets)
 	serverGroupV1.GET("transactions/:id", w.server.authMiddleware.AuthenticatedMiddleware(), w.getTransaction)
 	serverGroupV1.POST("transfer", w.server.authMiddleware.AuthenticatedMiddleware(), w.walletTransfer)
+	serverGroupV1.POST("crypto-transfer", w.server.authMiddleware.AuthenticatedMiddleware(), w.cryptoTagTransfer)
+	serverGroupV1.GET("crypto-transfers", w.server.authMiddleware.AuthenticatedMiddleware(), w.listCryptoTagTransfers)
+	serverGroupV1.GET("crypto-transfers/:reference/receipt", w.server.authMiddleware.AuthenticatedMiddleware(), w.getCryptoTagTransferReceipt)
 	serverGroupV1.GET("banks", w.server.authMiddleware.AuthenticatedMiddleware(), w.banks)
 	serverGroupV1.GET("resolve-bank-account", w.server.authMiddleware.AuthenticatedMiddleware(), w.resolveBankAccount)
 	serverGroupV1.GET("resolve-user-tag", w.server.authMiddleware.AuthenticatedMiddleware(), w.resolveUserTag)
@@ -317,6 +321,147 @@ func (w *Wallet) walletTransfer(ctx *gin.Context) {
 	}
 }
 
+// cryptoTagTransfer godoc
+// @Summary      Send Stablecoin By User Tag
+// @Description  Instantly sends USDT or USDC to another SwiftFiat user identified by tag. The transfer is settled off-chain between internal wallets, so no network fee is charged.
+// @Tags         Wallets
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      transaction.CryptoTagTransferRequest  true  "Crypto Tag Transfer Request"
+// @Success      200      {object}  basemodels.SuccessResponse{data=transaction.CryptoTagTransferReceipt}
+// @Failure      400      {object}  basemodels.ErrorResponse
+// @Failure      401      {object}  basemodels.ErrorResponse
+// @Failure      403      {object}  basemodels.ErrorResponse
+// @Failure      500      {object}  basemodels.ErrorResponse
+// @Router       /api/v1/wallets/crypto-transfer [post]
+func (w *Wallet) cryptoTagTransfer(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	var request transaction.CryptoTagTransferRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		return
+	}
+
+	user, err := w.server.queries.GetUserByID(ctx, activeUser.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, basemodels.NewError("user does not exist"))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(fmt.Sprintf("an error occurred retrieving the user %v", err.Error())))
+		return
+	}
+
+	if err = utils.VerifyHashValue(request.Pin, user.HashedPin.String); err != nil {
+		ctx.JSON(http.StatusBadRequest, basemodels.NewError(apistrings.InvalidTransactionPIN))
+		return
+	}
+
+	receipt, err := w.transactionService.HandleCryptoTagTransfer(ctx, &user, request)
+	if err != nil {
+		if wallError, ok := err.(*wallet.WalletError); ok {
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(wallError.ErrorOut()))
+			return
+		}
+
+		switch {
+		case errors.Is(err, transaction.ErrTagTransferBlocked):
+			ctx.JSON(http.StatusForbidden, basemodels.NewError(err.Error()))
+		case errors.Is(err, transaction.ErrUnsupportedTagTransferCurrency),
+			errors.Is(err, transaction.ErrTagTransferToSelf),
+			errors.Is(err, transaction.ErrTagTransferAmountPrecision),
+			errors.Is(err, transaction.ErrTagTransferBelowMinimum),
+			errors.Is(err, transaction.ErrTagTransferAboveMaximum),
+			errors.Is(err, transaction.ErrTagTransferDailyLimit),
+			errors.Is(err, transaction.ErrTagTransferDuplicate),
+			errors.Is(err, wallet.ErrInsufficientFunds):
+			ctx.JSON(http.StatusBadRequest, basemodels.NewError(err.Error()))
+		default:
+			w.server.logger.Error(fmt.Sprintf("crypto tag transfer failed: %v", err))
+			ctx.JSON(http.StatusInternalServerError, basemodels.NewError(err.Error()))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("Transfer Successful", receipt))
+}
+
+// listCryptoTagTransfers godoc
+// @Summary      List Stablecoin Tag Transfers
+// @Description  Lists the authenticated user's sent and received off-chain stablecoin transfers, newest first.
+// @Tags         Wallets
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit   query     int  false  "Page size (default 20)"
+// @Param        offset  query     int  false  "Offset (default 0)"
+// @Success      200     {object}  basemodels.SuccessResponse{data=[]transaction.CryptoTagTransferReceipt}
+// @Failure      401     {object}  basemodels.ErrorResponse
+// @Failure      500     {object}  basemodels.ErrorResponse
+// @Router       /api/v1/wallets/crypto-transfers [get]
+func (w *Wallet) listCryptoTagTransfers(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	receipts, err := w.transactionService.ListCryptoTagTransfers(ctx, activeUser.UserID, int32(limit), int32(offset))
+	if err != nil {
+		w.server.logger.Error(err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(apistrings.ServerError))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("transfers fetched successfully", receipts))
+}
+
+// getCryptoTagTransferReceipt godoc
+// @Summary      Get Stablecoin Tag Transfer Receipt
+// @Description  Returns the receipt of an off-chain stablecoin transfer sent or received by the authenticated user.
+// @Tags         Wallets
+// @Produce      json
+// @Security     BearerAuth
+// @Param        reference  path      string  true  "Transfer reference (sender idempotency key)"
+// @Success      200        {object}  basemodels.SuccessResponse{data=transaction.CryptoTagTransferReceipt}
+// @Failure      401        {object}  basemodels.ErrorResponse
+// @Failure      404        {object}  basemodels.ErrorResponse
+// @Failure      500        {object}  basemodels.ErrorResponse
+// @Router       /api/v1/wallets/crypto-transfers/{reference}/receipt [get]
+func (w *Wallet) getCryptoTagTransferReceipt(ctx *gin.Context) {
+	activeUser, err := utils.GetActiveUser(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, basemodels.NewError(apistrings.UserNotFound))
+		return
+	}
+
+	receipt, err := w.transactionService.GetCryptoTagTransferReceipt(ctx, activeUser.UserID, ctx.Param("reference"))
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusNotFound, basemodels.NewError("transfer not found"))
+		return
+	} else if err != nil {
+		w.server.logger.Error(err)
+		ctx.JSON(http.StatusInternalServerError, basemodels.NewError(apistrings.ServerError))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, basemodels.NewSuccess("receipt fetched successfully", receipt))
+}
+
 // banks godoc
 // @Summary      Get Bank List
 // @Description  Retrieves a list of supported banks for fiat transactions. Can be filtered using a query parameter.
@@ -46,7 +46,7 @@ func (ws WebSocketHandler) router(server *Server) {
 }
 
 type WSMessage struct {
-	Type      string         `json:"type"` // message:new, ticket:assigned, ticket:updated, notification:new
+	Type      string         `json:"type"` // message:new, ticket:assigned, ticket:updated, notification:new, price_alert:triggered
 	TicketID  int64          `json:"ticket_id,omitempty"`
 	Data      any            `json:"data"`
 	Timestamp time.Time      `json:"timestamp"`
@@ -135,7 +135,7 @@ func (h *Hub) shouldSendToClient(client *Client, message WSMessage) bool {
 			return client.UserID == metadata
 		}
 		return false
-	case "notification:new":
+	case "notification:new", "price_alert:triggered":
 		// send only to specific user
 		if metadata, ok := message.Metadata["user_id"].(uuid.UUID); ok {
 			return client.UserID == metadata
@@ -151,6 +151,31 @@ func (h *Hub) BroadcastMessage(message WSMessage) {
 	h.broadcast <- message
 }
 
+// PublishToUser sends an event to the user's open connections. It fails when the user has none
+// so callers can retry later.
+func (h *Hub) PublishToUser(userID uuid.UUID, eventType string, data any) error {
+	h.mu.RLock()
+	connected := false
+	for client := range h.clients {
+		if client.UserID == userID {
+			connected = true
+			break
+		}
+	}
+	h.mu.RUnlock()
+
+	if !connected {
+		return fmt.Errorf("user %s has no open websocket connection", userID)
+	}
+
+	h.BroadcastMessage(WSMessage{
+		Type:     eventType,
+		Data:     data,
+		Metadata: map[string]any{"user_id": userID},
+	})
+	return nil
+}
+
 // readPump pumps messages from the websocket connection to the hub.
 func (c *Client) readPump() {
 	defer func() {
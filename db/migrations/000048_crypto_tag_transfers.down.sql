@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS crypto_tag_transfers;
+
+ALTER TABLE wallet_transfer_metadata
+    ALTER COLUMN currency TYPE VARCHAR(3);
@@ -0,0 +1,46 @@
+-- Migration: Off-chain stablecoin transfers between SwiftFiat users
+-- Description: Receipt table for instant USDT/USDC transfers resolved by user tag
+
+-- Stablecoin tickers do not fit the original 3-char currency column
+ALTER TABLE wallet_transfer_metadata
+    ALTER COLUMN currency TYPE VARCHAR(10);
+
+-- =====================================================
+-- Crypto Tag Transfers Table
+-- =====================================================
+CREATE TABLE IF NOT EXISTS crypto_tag_transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reference VARCHAR(100) NOT NULL UNIQUE,
+
+    -- Parties
+    sender_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sender_wallet_id UUID NOT NULL REFERENCES swift_wallets(id),
+    recipient_wallet_id UUID NOT NULL REFERENCES swift_wallets(id),
+    sender_tag VARCHAR(50) NOT NULL,
+    recipient_tag VARCHAR(50) NOT NULL,
+
+    -- Amounts
+    currency VARCHAR(10) NOT NULL CHECK (currency IN ('USDT', 'USDC')),
+    amount DECIMAL(20, 8) NOT NULL CHECK (amount > 0),
+    fee DECIMAL(20, 8) NOT NULL DEFAULT 0,
+    amount_usd DECIMAL(20, 2) NOT NULL,
+
+    -- Linked ledger transactions
+    debit_transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    credit_transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+
+    -- Risk assessment at time of transfer
+    risk_score INTEGER NOT NULL DEFAULT 0,
+    risk_flags TEXT[] NOT NULL DEFAULT '{}',
+
+    description TEXT,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('successful', 'failed', 'blocked')),
+    failure_reason TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_crypto_tag_transfers_sender ON crypto_tag_transfers(sender_id, created_at DESC);
+CREATE INDEX idx_crypto_tag_transfers_recipient ON crypto_tag_transfers(recipient_id, created_at DESC);
+CREATE INDEX idx_crypto_tag_transfers_pair ON crypto_tag_transfers(sender_id, recipient_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS rate_candles;
+DROP TABLE IF EXISTS rate_samples;
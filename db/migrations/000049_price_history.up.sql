@@ -0,0 +1,38 @@
+-- Migration: Local price history store
+-- Description: Raw rate samples collected in the background and their OHLC roll-ups
+
+-- =====================================================
+-- Rate Samples Table (raw ticks, short retention)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS rate_samples (
+    id BIGSERIAL PRIMARY KEY,
+    base_currency VARCHAR(10) NOT NULL,
+    quote_currency VARCHAR(10) NOT NULL,
+    rate NUMERIC(30, 10) NOT NULL CHECK (rate > 0),
+    source VARCHAR(100) NOT NULL,
+    sampled_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rate_samples_pair_time ON rate_samples(base_currency, quote_currency, sampled_at DESC);
+CREATE INDEX idx_rate_samples_time ON rate_samples(sampled_at);
+
+-- =====================================================
+-- Rate Candles Table (OHLC roll-ups)
+-- =====================================================
+CREATE TABLE IF NOT EXISTS rate_candles (
+    id BIGSERIAL PRIMARY KEY,
+    base_currency VARCHAR(10) NOT NULL,
+    quote_currency VARCHAR(10) NOT NULL,
+    interval VARCHAR(5) NOT NULL CHECK (interval IN ('1m', '1h', '1d')),
+    bucket_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    open NUMERIC(30, 10) NOT NULL,
+    high NUMERIC(30, 10) NOT NULL,
+    low NUMERIC(30, 10) NOT NULL,
+    close NUMERIC(30, 10) NOT NULL,
+    sample_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_rate_candle UNIQUE (base_currency, quote_currency, interval, bucket_start)
+);
+
+CREATE INDEX idx_rate_candles_lookup ON rate_candles(base_currency, quote_currency, interval, bucket_start DESC);
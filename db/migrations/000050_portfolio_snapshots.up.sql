@@ -0,0 +1,20 @@
+-- Migration: Portfolio snapshots
+-- Description: Daily net-worth snapshots used to plot a user's portfolio value over time
+
+CREATE TABLE IF NOT EXISTS portfolio_snapshots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    snapshot_date DATE NOT NULL,
+    -- all values are stored in USD; usd_rates holds the USD price of every currency at snapshot time
+    -- so the series can be re-expressed in any display currency at historical rates
+    total_usd NUMERIC(30, 8) NOT NULL DEFAULT 0,
+    wallets_usd NUMERIC(30, 8) NOT NULL DEFAULT 0,
+    vaults_usd NUMERIC(30, 8) NOT NULL DEFAULT 0,
+    cards_usd NUMERIC(30, 8) NOT NULL DEFAULT 0,
+    usd_rates JSONB NOT NULL DEFAULT '{}'::jsonb,
+    holdings JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, snapshot_date)
+);
+
+CREATE INDEX idx_portfolio_snapshots_user_date ON portfolio_snapshots(user_id, snapshot_date DESC);
@@ -0,0 +1,19 @@
+-- Migration: Published consensus rates
+-- Description: Every rate published by the multi-source aggregator, with the quotes that produced it
+
+CREATE TABLE IF NOT EXISTS published_rates (
+    id BIGSERIAL PRIMARY KEY,
+    base_currency VARCHAR(10) NOT NULL,
+    quote_currency VARCHAR(10) NOT NULL,
+    rate NUMERIC(30, 10) NOT NULL CHECK (rate > 0),
+    method VARCHAR(30) NOT NULL,
+    source_count INTEGER NOT NULL,
+    accepted_count INTEGER NOT NULL,
+    -- one entry per queried source: name, rate, weight, fetched_at, accepted, reason
+    sources JSONB NOT NULL DEFAULT '[]'::jsonb,
+    published_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_published_rates_pair_time ON published_rates(base_currency, quote_currency, published_at DESC);
+CREATE INDEX idx_published_rates_time ON published_rates(published_at);
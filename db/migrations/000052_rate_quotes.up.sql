@@ -0,0 +1,30 @@
+-- Migration: Rate quotes
+-- Description: Signed, single-use quotes that lock the rate, fees and net amount for conversions and ramps
+
+CREATE TABLE IF NOT EXISTS rate_quotes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(20) NOT NULL CHECK (purpose IN ('conversion', 'ramp')),
+    source_currency VARCHAR(10) NOT NULL,
+    target_currency VARCHAR(10) NOT NULL,
+    source_amount NUMERIC(30, 8) NOT NULL CHECK (source_amount > 0),
+    rate NUMERIC(30, 10) NOT NULL CHECK (rate > 0),
+    fees NUMERIC(30, 8) NOT NULL DEFAULT 0,
+    target_amount NUMERIC(30, 8) NOT NULL,
+    net_amount NUMERIC(30, 8) NOT NULL,
+    rate_provider VARCHAR(255) NOT NULL DEFAULT '',
+    -- HMAC over the quoted terms; the client-facing quote ID is "<id>.<signature>"
+    signature VARCHAR(128) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'used', 'expired')),
+    -- ramp quotes are bound to the QR code whose first payment they price
+    qr_code_id UUID REFERENCES qr_codes(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    -- transaction reference the quote was consumed by, kept for dispute resolution
+    consumed_reference VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rate_quotes_user ON rate_quotes(user_id, created_at DESC);
+CREATE INDEX idx_rate_quotes_qr_code ON rate_quotes(qr_code_id) WHERE qr_code_id IS NOT NULL;
+CREATE INDEX idx_rate_quotes_reference ON rate_quotes(consumed_reference) WHERE consumed_reference IS NOT NULL;
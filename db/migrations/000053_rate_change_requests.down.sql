@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_rate_history_change_request;
+
+ALTER TABLE rate_change_history
+    DROP COLUMN IF EXISTS change_request_id,
+    DROP COLUMN IF EXISTS approved_by;
+
+DROP TABLE IF EXISTS rate_change_requests;
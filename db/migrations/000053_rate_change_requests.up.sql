@@ -0,0 +1,36 @@
+-- Migration: Rate change requests
+-- Description: Maker-checker workflow for rate manager changes; every change waits for a second admin
+
+CREATE TABLE IF NOT EXISTS rate_change_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    change_type VARCHAR(30) NOT NULL CHECK (change_type IN ('create_rule', 'update_rule', 'toggle_rule', 'set_rate_source', 'set_manual_rate')),
+    -- the rule being changed, NULL for rule creation and pair-level changes
+    target_id UUID,
+    source_currency VARCHAR(10) NOT NULL,
+    target_currency VARCHAR(10) NOT NULL,
+    -- the change as submitted, replayed verbatim on approval
+    payload JSONB NOT NULL,
+    -- before/after simulation and projected revenue impact at submission time
+    preview JSONB,
+    -- human-readable description of the change
+    summary TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'cancelled', 'failed')),
+    requested_by UUID NOT NULL REFERENCES users(id),
+    reviewed_by UUID REFERENCES users(id),
+    review_note TEXT,
+    failure_reason TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    applied_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT rate_change_requests_four_eyes CHECK (reviewed_by IS NULL OR reviewed_by <> requested_by)
+);
+
+CREATE INDEX idx_rate_change_requests_status ON rate_change_requests(status, created_at DESC);
+CREATE INDEX idx_rate_change_requests_pair ON rate_change_requests(source_currency, target_currency);
+
+ALTER TABLE rate_change_history
+    ADD COLUMN approved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN change_request_id UUID REFERENCES rate_change_requests(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_rate_history_change_request ON rate_change_history(change_request_id) WHERE change_request_id IS NOT NULL;
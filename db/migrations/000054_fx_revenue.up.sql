@@ -0,0 +1,37 @@
+-- Migration: FX revenue attribution
+-- Description: One row per conversion, rapid-ramp payout and card funding recording what the platform earned from spread and fees
+
+CREATE TABLE IF NOT EXISTS fx_revenue_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    channel VARCHAR(30) NOT NULL CHECK (channel IN ('smart_conversion', 'rapid_ramp', 'card_funding')),
+    -- id of the channel's own record: conversion_history, qr_transactions or card_funding_history
+    reference_id UUID NOT NULL,
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_currency VARCHAR(10) NOT NULL,
+    target_currency VARCHAR(10) NOT NULL,
+    source_amount NUMERIC(30, 8) NOT NULL,
+    mid_rate NUMERIC(30, 10) NOT NULL,
+    -- where mid_rate came from: rate_manager, exchange_service or applied when no reference rate was available
+    mid_rate_source VARCHAR(30) NOT NULL,
+    applied_rate NUMERIC(30, 10) NOT NULL,
+    -- spread, fee and total revenue are in target_currency
+    spread_revenue NUMERIC(30, 8) NOT NULL,
+    fee_revenue NUMERIC(30, 8) NOT NULL DEFAULT 0,
+    total_revenue NUMERIC(30, 8) NOT NULL,
+    -- USD value at the time of the transaction so revenue can be summed across pairs
+    revenue_usd NUMERIC(30, 8),
+    rule_id UUID REFERENCES rate_adjustment_rules(id) ON DELETE SET NULL,
+    rule_name VARCHAR(100),
+    vip_level_id UUID REFERENCES vip_levels(id) ON DELETE SET NULL,
+    vip_level_name VARCHAR(50),
+    rate_provider VARCHAR(255),
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (channel, reference_id)
+);
+
+CREATE INDEX idx_fx_revenue_occurred ON fx_revenue_entries(occurred_at);
+CREATE INDEX idx_fx_revenue_pair ON fx_revenue_entries(source_currency, target_currency, occurred_at);
+CREATE INDEX idx_fx_revenue_rule ON fx_revenue_entries(rule_id) WHERE rule_id IS NOT NULL;
+CREATE INDEX idx_fx_revenue_vip_level ON fx_revenue_entries(vip_level_id) WHERE vip_level_id IS NOT NULL;
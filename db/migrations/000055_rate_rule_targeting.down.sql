@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS public_holidays;
+
+DROP INDEX IF EXISTS unique_active_global_rule_idx;
+CREATE UNIQUE INDEX unique_active_global_rule_idx
+    ON rate_adjustment_rules(source_currency, target_currency, is_global_rule)
+    WHERE is_global_rule = TRUE
+      AND is_active = TRUE
+      AND deleted_at IS NULL;
+
+ALTER TABLE rate_adjustment_rules
+    DROP CONSTRAINT IF EXISTS valid_time_windows,
+    DROP CONSTRAINT IF EXISTS valid_rule_channels,
+    DROP COLUMN IF EXISTS exclude_holidays,
+    DROP COLUMN IF EXISTS schedule_timezone,
+    DROP COLUMN IF EXISTS time_windows,
+    DROP COLUMN IF EXISTS channels;
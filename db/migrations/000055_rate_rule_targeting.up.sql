@@ -0,0 +1,39 @@
+-- Migration: Rate rule targeting
+-- Description: Channel targeting, recurring time windows and public-holiday handling for rate adjustment rules
+
+ALTER TABLE rate_adjustment_rules
+    -- empty = every channel; otherwise any of 'app', 'rapid_ramp', 'smart_conversion'
+    ADD COLUMN IF NOT EXISTS channels TEXT[] NOT NULL DEFAULT '{}',
+    -- NULL or [] = any time; otherwise a JSON array of
+    -- {"days": ["sat","sun"], "start": "22:00", "end": "06:00", "holidays": false}
+    ADD COLUMN IF NOT EXISTS time_windows JSONB,
+    -- timezone the time windows and holiday dates are read in
+    ADD COLUMN IF NOT EXISTS schedule_timezone VARCHAR(50) NOT NULL DEFAULT 'Africa/Lagos',
+    -- when true the rule never applies on a public holiday
+    ADD COLUMN IF NOT EXISTS exclude_holidays BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE rate_adjustment_rules
+    ADD CONSTRAINT valid_rule_channels CHECK (channels <@ ARRAY['app', 'rapid_ramp', 'smart_conversion']::TEXT[]),
+    ADD CONSTRAINT valid_time_windows CHECK (time_windows IS NULL OR jsonb_typeof(time_windows) = 'array');
+
+-- only the untargeted default global rule is unique per pair; targeted global rules
+-- (weekends, large tickets, one channel) sit alongside it
+DROP INDEX IF EXISTS unique_active_global_rule_idx;
+CREATE UNIQUE INDEX unique_active_global_rule_idx
+    ON rate_adjustment_rules(source_currency, target_currency, is_global_rule)
+    WHERE is_global_rule = TRUE
+      AND is_active = TRUE
+      AND deleted_at IS NULL
+      AND cardinality(channels) = 0
+      AND (time_windows IS NULL OR time_windows = '[]'::jsonb)
+      AND min_conversion_amount IS NULL
+      AND max_conversion_amount IS NULL;
+
+-- Public holidays that time-windowed rate rules can target or exclude
+CREATE TABLE IF NOT EXISTS public_holidays (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    holiday_date DATE NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
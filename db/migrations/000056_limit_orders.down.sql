@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS conversion_order_fills;
+DROP TABLE IF EXISTS conversion_orders;
+DROP TABLE IF EXISTS balance_holds;
@@ -0,0 +1,76 @@
+-- Migration: Limit orders
+-- Description: Balance holds, limit orders with expiry, and the fills that execute them
+
+-- Funds on hold are taken out of the wallet balance so no other debit can spend them.
+-- Fills draw down remaining; releasing a hold credits what is left back to the wallet.
+CREATE TABLE IF NOT EXISTS balance_holds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    wallet_id UUID NOT NULL REFERENCES swift_wallets(id) ON DELETE CASCADE,
+    currency VARCHAR(10) NOT NULL,
+    amount DECIMAL(19,4) NOT NULL CHECK (amount > 0),
+    remaining DECIMAL(19,4) NOT NULL CHECK (remaining >= 0 AND remaining <= amount),
+    -- what the hold reserves funds for, and that record's id
+    purpose VARCHAR(30) NOT NULL CHECK (purpose IN ('limit_order')),
+    reference_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'consumed', 'released')),
+    released_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_holds_wallet ON balance_holds(wallet_id) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_balance_holds_reference ON balance_holds(purpose, reference_id);
+
+CREATE TABLE IF NOT EXISTS conversion_orders (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_currency VARCHAR(10) NOT NULL,
+    target_currency VARCHAR(10) NOT NULL,
+    source_wallet_id UUID NOT NULL REFERENCES swift_wallets(id) ON DELETE CASCADE,
+    target_wallet_id UUID NOT NULL REFERENCES swift_wallets(id) ON DELETE CASCADE,
+    -- worst acceptable rate in target per unit of source; fills happen at this rate or better
+    limit_rate NUMERIC(30, 10) NOT NULL CHECK (limit_rate > 0),
+    source_amount DECIMAL(19,4) NOT NULL CHECK (source_amount > 0),
+    filled_amount DECIMAL(19,4) NOT NULL DEFAULT 0,
+    received_amount DECIMAL(19,4) NOT NULL DEFAULT 0,
+    fees_paid DECIMAL(19,4) NOT NULL DEFAULT 0,
+    -- gtc: good till cancelled, gtd: good till expires_at
+    time_in_force VARCHAR(3) NOT NULL CHECK (time_in_force IN ('gtc', 'gtd')),
+    expires_at TIMESTAMPTZ,
+    hold_id UUID NOT NULL REFERENCES balance_holds(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'partially_filled', 'filled', 'cancelled', 'expired')),
+    label VARCHAR(100),
+    last_filled_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT valid_order_expiry CHECK (time_in_force = 'gtc' OR expires_at IS NOT NULL),
+    CONSTRAINT valid_order_fill CHECK (filled_amount >= 0 AND filled_amount <= source_amount)
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversion_orders_user ON conversion_orders(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_conversion_orders_open ON conversion_orders(source_currency, target_currency, created_at)
+    WHERE status IN ('open', 'partially_filled');
+CREATE INDEX IF NOT EXISTS idx_conversion_orders_expiry ON conversion_orders(expires_at)
+    WHERE time_in_force = 'gtd' AND status IN ('open', 'partially_filled');
+
+CREATE TABLE IF NOT EXISTS conversion_order_fills (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES conversion_orders(id) ON DELETE CASCADE,
+    conversion_history_id UUID REFERENCES conversion_history(id) ON DELETE SET NULL,
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    source_currency VARCHAR(10) NOT NULL,
+    target_currency VARCHAR(10) NOT NULL,
+    source_amount DECIMAL(19,4) NOT NULL CHECK (source_amount > 0),
+    executed_rate NUMERIC(30, 10) NOT NULL,
+    target_amount DECIMAL(19,4) NOT NULL,
+    fees DECIMAL(19,4) NOT NULL DEFAULT 0,
+    net_amount DECIMAL(19,4) NOT NULL,
+    -- USD value of source_amount, counted against the per-interval liquidity cap
+    source_amount_usd DECIMAL(19,4) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversion_order_fills_order ON conversion_order_fills(order_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_conversion_order_fills_pair ON conversion_order_fills(source_currency, target_currency, created_at);
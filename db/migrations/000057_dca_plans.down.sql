@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dca_plan_executions;
+DROP TABLE IF EXISTS dca_plans;
@@ -0,0 +1,60 @@
+-- Migration: DCA plans
+-- Description: Recurring dollar-cost-averaging conversions into stablecoins and the runs that execute them
+
+CREATE TABLE IF NOT EXISTS dca_plans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_currency VARCHAR(10) NOT NULL,
+    target_currency VARCHAR(10) NOT NULL CHECK (target_currency IN ('USDT', 'USDC')),
+    source_wallet_id UUID NOT NULL REFERENCES swift_wallets(id) ON DELETE CASCADE,
+    target_wallet_id UUID NOT NULL REFERENCES swift_wallets(id) ON DELETE CASCADE,
+    -- source amount converted on every run
+    amount DECIMAL(19,4) NOT NULL CHECK (amount > 0),
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+    schedule_day_of_week INTEGER CHECK (schedule_day_of_week BETWEEN 0 AND 6),
+    schedule_day_of_month INTEGER CHECK (schedule_day_of_month BETWEEN 1 AND 31),
+    schedule_time TIME NOT NULL DEFAULT '09:00',
+    timezone VARCHAR(50) NOT NULL DEFAULT 'Africa/Lagos',
+    -- most source currency paid per unit of target; runs priced above it are skipped
+    rate_ceiling NUMERIC(30, 10) CHECK (rate_ceiling IS NULL OR rate_ceiling > 0),
+    -- skip: miss the run and try again next time, pause: pause the plan until the user resumes it
+    insufficient_funds_policy VARCHAR(10) NOT NULL DEFAULT 'skip' CHECK (insufficient_funds_policy IN ('skip', 'pause')),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'paused', 'cancelled')),
+    next_execution_at TIMESTAMPTZ NOT NULL,
+    last_executed_at TIMESTAMPTZ,
+    execution_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    total_invested DECIMAL(19,4) NOT NULL DEFAULT 0,
+    -- target received across all runs, net of fees
+    total_accumulated DECIMAL(19,4) NOT NULL DEFAULT 0,
+    label VARCHAR(100),
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT valid_dca_pair CHECK (source_currency <> target_currency),
+    CONSTRAINT valid_dca_schedule CHECK (
+        (frequency <> 'weekly' OR schedule_day_of_week IS NOT NULL) AND
+        (frequency <> 'monthly' OR schedule_day_of_month IS NOT NULL)
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_dca_plans_user ON dca_plans(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_dca_plans_due ON dca_plans(next_execution_at) WHERE status = 'active';
+
+CREATE TABLE IF NOT EXISTS dca_plan_executions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    plan_id UUID NOT NULL REFERENCES dca_plans(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('executed', 'skipped', 'failed')),
+    -- why a run was skipped or failed: rate_above_ceiling, insufficient_balance, rate_unavailable, conversion_failed
+    reason VARCHAR(50),
+    conversion_history_id UUID REFERENCES conversion_history(id) ON DELETE SET NULL,
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    source_amount DECIMAL(19,4) NOT NULL,
+    -- net of fees
+    target_amount DECIMAL(19,4),
+    executed_rate NUMERIC(30, 10),
+    scheduled_for TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dca_plan_executions_plan ON dca_plan_executions(plan_id, created_at DESC);
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS alert_trigger_deliveries;
+
+ALTER TABLE alert_trigger_history
+    DROP COLUMN IF EXISTS body,
+    DROP COLUMN IF EXISTS title;
+
+ALTER TABLE price_alerts
+    DROP CONSTRAINT IF EXISTS valid_webhook_channel,
+    DROP CONSTRAINT IF EXISTS valid_delivery_channels,
+    DROP COLUMN IF EXISTS webhook_secret,
+    DROP COLUMN IF EXISTS webhook_url,
+    DROP COLUMN IF EXISTS delivery_channels;
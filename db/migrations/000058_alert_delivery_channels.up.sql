@@ -0,0 +1,51 @@
+-- Migration: Price alert delivery channels
+-- Description: Per-alert delivery channels and per-channel delivery tracking for triggered alerts
+
+ALTER TABLE price_alerts
+    ADD COLUMN IF NOT EXISTS delivery_channels TEXT[] NOT NULL DEFAULT ARRAY['in_app']::TEXT[],
+    ADD COLUMN IF NOT EXISTS webhook_url TEXT,
+    -- HMAC-SHA256 key for the X-SwiftFiat-Signature header on webhook deliveries
+    ADD COLUMN IF NOT EXISTS webhook_secret TEXT;
+
+-- carry the old push/in-app switches over to channels
+UPDATE price_alerts
+SET delivery_channels = ARRAY_REMOVE(ARRAY[
+        CASE WHEN notify_in_app THEN 'in_app' END,
+        CASE WHEN notify_push THEN 'push' END
+    ], NULL);
+
+ALTER TABLE price_alerts
+    ADD CONSTRAINT valid_delivery_channels CHECK (
+        delivery_channels <@ ARRAY['push', 'email', 'sms', 'in_app', 'websocket', 'webhook']::TEXT[]
+    ),
+    ADD CONSTRAINT valid_webhook_channel CHECK (
+        NOT ('webhook' = ANY(delivery_channels)) OR (webhook_url IS NOT NULL AND webhook_secret IS NOT NULL)
+    );
+
+-- the rendered notification, kept so failed channels can be retried with the same content
+ALTER TABLE alert_trigger_history
+    ADD COLUMN IF NOT EXISTS title TEXT,
+    ADD COLUMN IF NOT EXISTS body TEXT;
+
+-- One row per channel each time an alert triggers. Failed deliveries are retried
+-- until attempts reaches the configured maximum; suppressed rows were over the
+-- user's daily cap for the channel and are never sent.
+CREATE TABLE IF NOT EXISTS alert_trigger_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    trigger_id BIGINT NOT NULL REFERENCES alert_trigger_history(id) ON DELETE CASCADE,
+    alert_id UUID NOT NULL REFERENCES price_alerts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('push', 'email', 'sms', 'in_app', 'websocket', 'webhook')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'suppressed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_trigger_deliveries_trigger ON alert_trigger_deliveries(trigger_id);
+CREATE INDEX IF NOT EXISTS idx_alert_trigger_deliveries_user_channel ON alert_trigger_deliveries(user_id, channel, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_alert_trigger_deliveries_retry ON alert_trigger_deliveries(next_attempt_at)
+    WHERE status = 'failed';
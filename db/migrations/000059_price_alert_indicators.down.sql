@@ -0,0 +1,19 @@
+DELETE FROM price_alerts
+WHERE alert_condition NOT IN ('above', 'below', 'equals', 'percent_up', 'percent_down', 'range', 'breakout');
+
+ALTER TABLE price_alerts
+    DROP CONSTRAINT IF EXISTS valid_indicator_crossover,
+    DROP CONSTRAINT IF EXISTS valid_indicator_config,
+    DROP COLUMN IF EXISTS indicator_threshold,
+    DROP COLUMN IF EXISTS indicator_fast_period,
+    DROP COLUMN IF EXISTS indicator_period,
+    DROP COLUMN IF EXISTS indicator_interval;
+
+ALTER TABLE price_alerts DROP CONSTRAINT IF EXISTS price_alerts_alert_condition_check;
+
+ALTER TABLE price_alerts
+    ADD CONSTRAINT price_alerts_alert_condition_check CHECK (
+        alert_condition IN ('above', 'below', 'equals', 'percent_up', 'percent_down', 'range', 'breakout')
+    );
+
+COMMENT ON COLUMN price_alerts.alert_condition IS 'Type of price condition: above, below, equals, percent_up, percent_down, range, breakout';
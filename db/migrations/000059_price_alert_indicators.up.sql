@@ -0,0 +1,40 @@
+-- Migration: Technical-indicator price alerts
+-- Description: Alert conditions evaluated over the rate candles kept by the price history service
+
+ALTER TABLE price_alerts DROP CONSTRAINT IF EXISTS price_alerts_alert_condition_check;
+
+ALTER TABLE price_alerts
+    ADD CONSTRAINT price_alerts_alert_condition_check CHECK (
+        alert_condition IN (
+            'above', 'below', 'equals', 'percent_up', 'percent_down', 'range', 'breakout',
+            'sma_cross_up', 'sma_cross_down', 'ema_cross_up', 'ema_cross_down',
+            'rsi_above', 'rsi_below', 'bollinger_upper', 'bollinger_lower',
+            'period_high', 'period_low'
+        )
+    );
+
+ALTER TABLE price_alerts
+    -- candle width the indicator is computed on
+    ADD COLUMN IF NOT EXISTS indicator_interval VARCHAR(3) CHECK (indicator_interval IN ('1m', '1h', '1d')),
+    -- slow window for crossovers, window for RSI, Bollinger and period high/low
+    ADD COLUMN IF NOT EXISTS indicator_period INTEGER CHECK (indicator_period BETWEEN 2 AND 200),
+    -- fast window for crossovers
+    ADD COLUMN IF NOT EXISTS indicator_fast_period INTEGER CHECK (indicator_fast_period BETWEEN 1 AND 199),
+    -- RSI level, or the standard deviation multiplier for Bollinger bands
+    ADD COLUMN IF NOT EXISTS indicator_threshold TEXT;
+
+ALTER TABLE price_alerts
+    ADD CONSTRAINT valid_indicator_config CHECK (
+        alert_condition NOT IN (
+            'sma_cross_up', 'sma_cross_down', 'ema_cross_up', 'ema_cross_down',
+            'rsi_above', 'rsi_below', 'bollinger_upper', 'bollinger_lower',
+            'period_high', 'period_low'
+        )
+        OR (indicator_interval IS NOT NULL AND indicator_period IS NOT NULL)
+    ),
+    ADD CONSTRAINT valid_indicator_crossover CHECK (
+        alert_condition NOT IN ('sma_cross_up', 'sma_cross_down', 'ema_cross_up', 'ema_cross_down')
+        OR (indicator_fast_period IS NOT NULL AND indicator_fast_period < indicator_period)
+    );
+
+COMMENT ON COLUMN price_alerts.alert_condition IS 'Type of price condition: above, below, equals, percent_up, percent_down, range, breakout, or an indicator condition (sma/ema crossovers, rsi, bollinger, period high/low)';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS alert_conversion_runs;
+DROP TABLE IF EXISTS alert_conversion_actions;
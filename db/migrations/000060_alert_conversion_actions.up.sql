@@ -0,0 +1,44 @@
+-- Migration: Price alert conversion actions
+-- Description: Conversions a price alert runs when it triggers, and the result of each run
+
+-- One per alert. The user confirms the action with their transaction PIN when creating the alert.
+CREATE TABLE IF NOT EXISTS alert_conversion_actions (
+    alert_id UUID PRIMARY KEY REFERENCES price_alerts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_currency VARCHAR(10) NOT NULL,
+    target_currency VARCHAR(10) NOT NULL,
+    source_wallet_id UUID NOT NULL REFERENCES swift_wallets(id) ON DELETE CASCADE,
+    target_wallet_id UUID NOT NULL REFERENCES swift_wallets(id) ON DELETE CASCADE,
+    -- source amount converted each time the alert triggers
+    amount DECIMAL(19,4) NOT NULL CHECK (amount > 0),
+    authorized_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT valid_alert_conversion_pair CHECK (source_currency <> target_currency)
+);
+
+-- One per trigger of an alert with a conversion action
+CREATE TABLE IF NOT EXISTS alert_conversion_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trigger_id BIGINT NOT NULL UNIQUE REFERENCES alert_trigger_history(id) ON DELETE CASCADE,
+    alert_id UUID NOT NULL REFERENCES price_alerts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('executed', 'failed')),
+    -- why a run failed: insufficient_balance, amount_limit_exceeded, daily_limit_exceeded, rate_unavailable, account_inactive, conversion_failed
+    reason VARCHAR(50),
+    conversion_history_id UUID REFERENCES conversion_history(id) ON DELETE SET NULL,
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    source_currency VARCHAR(10) NOT NULL,
+    target_currency VARCHAR(10) NOT NULL,
+    source_amount DECIMAL(19,4) NOT NULL,
+    -- USD value of the source amount, counted against the daily limit
+    amount_usd DECIMAL(19,4),
+    -- net of fees
+    target_amount DECIMAL(19,4),
+    executed_rate NUMERIC(30, 10),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_conversion_runs_alert ON alert_conversion_runs(alert_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_alert_conversion_runs_user_executed ON alert_conversion_runs(user_id, created_at DESC)
+    WHERE status = 'executed';
@@ -0,0 +1,11 @@
+CREATE OR REPLACE FUNCTION update_price_alert_timestamp()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_price_alerts_updated_at;
+DROP TABLE IF EXISTS price_alert_shard_leases;
+DROP TABLE IF EXISTS price_alert_evaluators;
@@ -0,0 +1,44 @@
+-- Migration: Sharded price alert evaluation
+-- Description: Replicas split alert evaluation by currency pair shard, each shard held under a lease
+
+-- One row per running evaluator. The heartbeat carries the replica's counters so any replica
+-- can report cluster-wide lag and throughput.
+CREATE TABLE IF NOT EXISTS price_alert_evaluators (
+    node_id VARCHAR(100) PRIMARY KEY,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    heartbeat_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    alerts_evaluated BIGINT NOT NULL DEFAULT 0,
+    alerts_triggered BIGINT NOT NULL DEFAULT 0,
+    -- triggers another replica had already claimed
+    duplicate_triggers BIGINT NOT NULL DEFAULT 0,
+    last_cycle_ms INT NOT NULL DEFAULT 0,
+    -- age of the stalest rate check across the replica's pairs
+    max_lag_ms INT NOT NULL DEFAULT 0
+);
+
+-- A pair belongs to shard hash(source/target) mod the shard count. A replica evaluates a
+-- shard's pairs only while its lease is unexpired.
+CREATE TABLE IF NOT EXISTS price_alert_shard_leases (
+    shard INT PRIMARY KEY CHECK (shard >= 0),
+    node_id VARCHAR(100) NOT NULL,
+    acquired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_price_alert_shard_leases_node ON price_alert_shard_leases(node_id);
+
+-- Evaluators refresh their threshold index from alerts changed since the last refresh
+CREATE INDEX idx_price_alerts_updated_at ON price_alerts(updated_at);
+
+-- last_checked_at is bookkeeping; leaving updated_at alone keeps the refresh down to
+-- alerts whose configuration or state actually changed
+CREATE OR REPLACE FUNCTION update_price_alert_timestamp()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (to_jsonb(NEW) - 'last_checked_at' - 'updated_at') IS DISTINCT FROM
+       (to_jsonb(OLD) - 'last_checked_at' - 'updated_at') THEN
+        NEW.updated_at = NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
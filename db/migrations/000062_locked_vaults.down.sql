@@ -0,0 +1,28 @@
+DELETE FROM vault_transactions WHERE transaction_type IN ('yield_forfeit', 'early_withdrawal_penalty', 'maturity_payout', 'rollover');
+ALTER TABLE vault_transactions DROP CONSTRAINT IF EXISTS vault_transactions_transaction_type_check;
+ALTER TABLE vault_transactions ADD CONSTRAINT vault_transactions_transaction_type_check CHECK (
+    transaction_type IN ('deposit', 'withdrawal', 'auto_save', 'yield_credit')
+);
+
+DROP INDEX IF EXISTS idx_vault_savings_matures_at;
+ALTER TABLE vault_savings
+    DROP CONSTRAINT IF EXISTS vault_savings_locked_terms_check,
+    DROP COLUMN IF EXISTS rollover_count,
+    DROP COLUMN IF EXISTS early_withdrawal_penalty_rate,
+    DROP COLUMN IF EXISTS early_withdrawal_policy,
+    DROP COLUMN IF EXISTS maturity_action,
+    DROP COLUMN IF EXISTS matures_at,
+    DROP COLUMN IF EXISTS locked_at,
+    DROP COLUMN IF EXISTS locked_principal,
+    DROP COLUMN IF EXISTS locked_apy,
+    DROP COLUMN IF EXISTS lock_term_days,
+    DROP COLUMN IF EXISTS yield_config_id;
+
+DROP INDEX IF EXISTS idx_yield_config_locked_term;
+DELETE FROM vault_yield_configs WHERE product_type = 'locked';
+ALTER TABLE vault_yield_configs
+    DROP CONSTRAINT IF EXISTS vault_yield_configs_locked_terms_check,
+    DROP COLUMN IF EXISTS early_withdrawal_penalty_rate,
+    DROP COLUMN IF EXISTS early_withdrawal_policy,
+    DROP COLUMN IF EXISTS lock_term_days,
+    DROP COLUMN IF EXISTS product_type;
@@ -0,0 +1,46 @@
+-- Migration: Fixed-term locked vaults
+-- Description: Locked vault products priced through vault_yield_configs, with terms copied onto each vault
+
+-- A config row prices either flexible savings or one locked term in a currency. Locked rows
+-- also carry what breaking the lock early costs.
+ALTER TABLE vault_yield_configs
+    ADD COLUMN product_type VARCHAR(20) NOT NULL DEFAULT 'flexible' CHECK (product_type IN ('flexible', 'locked')),
+    ADD COLUMN lock_term_days INT CHECK (lock_term_days IN (30, 60, 90, 180, 365)),
+    ADD COLUMN early_withdrawal_policy VARCHAR(20) CHECK (early_withdrawal_policy IN ('forfeit_yield', 'penalty')),
+    -- percentage of the locked principal charged under the penalty policy
+    ADD COLUMN early_withdrawal_penalty_rate DECIMAL(19, 4) NOT NULL DEFAULT 0 CHECK (early_withdrawal_penalty_rate >= 0 AND early_withdrawal_penalty_rate <= 100),
+    ADD CONSTRAINT vault_yield_configs_locked_terms_check CHECK (
+        (product_type = 'flexible' AND lock_term_days IS NULL)
+        OR (product_type = 'locked' AND lock_term_days IS NOT NULL AND early_withdrawal_policy IS NOT NULL)
+    );
+
+CREATE INDEX idx_yield_config_locked_term ON vault_yield_configs(currency, lock_term_days) WHERE product_type = 'locked' AND is_active = TRUE;
+
+-- The terms of the current lock are copied from the config at creation and at each rollover,
+-- so later config changes never reprice a running lock
+ALTER TABLE vault_savings
+    ADD COLUMN yield_config_id UUID REFERENCES vault_yield_configs(id) ON DELETE SET NULL,
+    ADD COLUMN lock_term_days INT CHECK (lock_term_days IN (30, 60, 90, 180, 365)),
+    ADD COLUMN locked_apy DECIMAL(19, 4),
+    -- balance at the start of the current term; yield is what the vault earns above it
+    ADD COLUMN locked_principal DECIMAL(19, 4),
+    ADD COLUMN locked_at TIMESTAMPTZ,
+    ADD COLUMN matures_at TIMESTAMPTZ,
+    ADD COLUMN maturity_action VARCHAR(10) CHECK (maturity_action IN ('rollover', 'payout')),
+    ADD COLUMN early_withdrawal_policy VARCHAR(20) CHECK (early_withdrawal_policy IN ('forfeit_yield', 'penalty')),
+    ADD COLUMN early_withdrawal_penalty_rate DECIMAL(19, 4),
+    ADD COLUMN rollover_count INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT vault_savings_locked_terms_check CHECK (
+        vault_type = 'flexible'
+        OR (lock_term_days IS NOT NULL AND locked_apy IS NOT NULL AND locked_principal IS NOT NULL
+            AND matures_at IS NOT NULL AND maturity_action IS NOT NULL AND early_withdrawal_policy IS NOT NULL)
+    );
+
+CREATE INDEX idx_vault_savings_matures_at ON vault_savings(matures_at) WHERE vault_type = 'locked' AND status = 'active';
+
+-- Early withdrawals record what the vault gave up before the payout; maturities record the
+-- payout or the rollover into a new term
+ALTER TABLE vault_transactions DROP CONSTRAINT IF EXISTS vault_transactions_transaction_type_check;
+ALTER TABLE vault_transactions ADD CONSTRAINT vault_transactions_transaction_type_check CHECK (
+    transaction_type IN ('deposit', 'withdrawal', 'auto_save', 'yield_credit', 'yield_forfeit', 'early_withdrawal_penalty', 'maturity_payout', 'rollover')
+);
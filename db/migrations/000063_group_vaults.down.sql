@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS vault_group_activity;
+DROP TABLE IF EXISTS vault_group_withdrawal_votes;
+DROP TABLE IF EXISTS vault_group_withdrawal_requests;
+DROP TABLE IF EXISTS vault_group_members;
+DROP TABLE IF EXISTS vault_groups;
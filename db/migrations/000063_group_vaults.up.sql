@@ -0,0 +1,90 @@
+-- Migration: Group vaults
+-- Description: Vault goals shared between several users, with per-member contributions, withdrawal rules and an activity feed
+
+-- A group vault is a vault_savings goal owned by the user who created it, plus this row
+CREATE TABLE IF NOT EXISTS vault_groups (
+    vault_id UUID PRIMARY KEY REFERENCES vault_savings(id) ON DELETE CASCADE,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- owner_only: the owner withdraws as from any vault
+    -- majority_approval: a member asks and more than half of the active members must approve
+    -- rotating: the whole balance goes to one member per cycle, in payout_position order
+    withdrawal_rule VARCHAR(20) NOT NULL DEFAULT 'owner_only' CHECK (withdrawal_rule IN ('owner_only', 'majority_approval', 'rotating')),
+    -- amount each member is expected to put in per cycle, shown to members
+    contribution_amount DECIMAL(19, 4) CHECK (contribution_amount > 0),
+    rotation_interval_days INT CHECK (rotation_interval_days > 0),
+    next_payout_at TIMESTAMPTZ,
+    -- payout_position of the member paid at the next payout
+    next_payout_position INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT vault_groups_rotation_check CHECK (
+        (withdrawal_rule = 'rotating' AND rotation_interval_days IS NOT NULL AND next_payout_at IS NOT NULL)
+        OR (withdrawal_rule <> 'rotating' AND rotation_interval_days IS NULL)
+    )
+);
+
+CREATE INDEX idx_vault_groups_next_payout ON vault_groups(next_payout_at) WHERE withdrawal_rule = 'rotating';
+
+-- The owner is a member too. Contributions stay in the vault when a member leaves.
+CREATE TABLE IF NOT EXISTS vault_group_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    vault_id UUID NOT NULL REFERENCES vault_groups(vault_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(10) NOT NULL CHECK (role IN ('owner', 'member')),
+    status VARCHAR(10) NOT NULL DEFAULT 'invited' CHECK (status IN ('invited', 'active', 'declined', 'left', 'removed')),
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- order in a rotating payout, given when the member joins
+    payout_position INT,
+    total_contributed DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    total_received DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    joined_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (vault_id, user_id)
+);
+
+CREATE INDEX idx_vault_group_members_user ON vault_group_members(user_id, status);
+
+-- Withdrawals from majority_approval groups. The requester is paid once enough members approve.
+CREATE TABLE IF NOT EXISTS vault_group_withdrawal_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    vault_id UUID NOT NULL REFERENCES vault_groups(vault_id) ON DELETE CASCADE,
+    requested_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    reason TEXT,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'executed', 'rejected', 'expired', 'failed')),
+    -- more than half of the active members when the request was made
+    approvals_required INT NOT NULL CHECK (approvals_required > 0),
+    vault_transaction_id UUID REFERENCES vault_transactions(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One open request per group at a time
+CREATE UNIQUE INDEX idx_vault_group_withdrawal_pending ON vault_group_withdrawal_requests(vault_id) WHERE status = 'pending';
+CREATE INDEX idx_vault_group_withdrawal_expiry ON vault_group_withdrawal_requests(expires_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS vault_group_withdrawal_votes (
+    request_id UUID NOT NULL REFERENCES vault_group_withdrawal_requests(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    approve BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (request_id, user_id)
+);
+
+-- What members see happening in the group
+CREATE TABLE IF NOT EXISTS vault_group_activity (
+    id BIGSERIAL PRIMARY KEY,
+    vault_id UUID NOT NULL REFERENCES vault_groups(vault_id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- created, member_invited, member_joined, member_declined, member_left, member_removed, contribution,
+    -- withdrawal_requested, withdrawal_voted, withdrawal_executed, withdrawal_rejected, withdrawal_expired, rotation_payout
+    activity_type VARCHAR(30) NOT NULL,
+    amount DECIMAL(19, 4),
+    message TEXT NOT NULL,
+    metadata JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_vault_group_activity_vault ON vault_group_activity(vault_id, created_at DESC);
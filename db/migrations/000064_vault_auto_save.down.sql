@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS vault_auto_save_summaries;
+DROP TABLE IF EXISTS vault_auto_save_accruals;
+DROP TABLE IF EXISTS vault_auto_save_rules;
@@ -0,0 +1,60 @@
+-- Migration: Vault auto-save rules
+-- Description: Round-up and percentage rules that save into a vault when money moves, batched into daily deposits
+
+-- round_up: every matching debit is rounded up to the next multiple of round_to and the difference saved
+-- percent_of_inflow: percentage of every matching credit is saved straight away
+CREATE TABLE IF NOT EXISTS vault_auto_save_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    vault_id UUID NOT NULL REFERENCES vault_savings(id) ON DELETE CASCADE,
+    rule_type VARCHAR(20) NOT NULL CHECK (rule_type IN ('round_up', 'percent_of_inflow')),
+    -- card_debit, bill_payment, transfer_out for round_up; transfer_in, crypto_inflow for percent_of_inflow
+    triggers TEXT[] NOT NULL,
+    round_to DECIMAL(19, 4) CHECK (round_to > 0),
+    percentage DECIMAL(5, 2) CHECK (percentage > 0 AND percentage <= 50),
+    -- cap on what a single transaction can save
+    max_per_transaction DECIMAL(19, 4) CHECK (max_per_transaction > 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT vault_auto_save_rules_amount_check CHECK (
+        (rule_type = 'round_up' AND round_to IS NOT NULL AND percentage IS NULL)
+        OR (rule_type = 'percent_of_inflow' AND percentage IS NOT NULL AND round_to IS NULL)
+    ),
+    CONSTRAINT vault_auto_save_rules_triggers_check CHECK (cardinality(triggers) > 0)
+);
+
+CREATE INDEX idx_vault_auto_save_rules_user ON vault_auto_save_rules(user_id) WHERE is_active;
+CREATE INDEX idx_vault_auto_save_rules_vault ON vault_auto_save_rules(vault_id);
+
+-- One row per rule per transaction it saved from. Rows wait as pending until the daily batch
+-- (or, for percent_of_inflow, the immediate deposit) moves them into the vault.
+CREATE TABLE IF NOT EXISTS vault_auto_save_accruals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    rule_id UUID NOT NULL REFERENCES vault_auto_save_rules(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    vault_id UUID NOT NULL REFERENCES vault_savings(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL,
+    source_transaction_id UUID NOT NULL,
+    source_amount DECIMAL(19, 4) NOT NULL,
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'deposited', 'failed')),
+    -- idempotency key of the vault deposit that moved this row
+    batch_reference VARCHAR(100),
+    failure_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMPTZ,
+    UNIQUE (rule_id, source_transaction_id)
+);
+
+CREATE INDEX idx_vault_auto_save_accruals_pending ON vault_auto_save_accruals(rule_id, created_at) WHERE status = 'pending';
+CREATE INDEX idx_vault_auto_save_accruals_user_processed ON vault_auto_save_accruals(user_id, processed_at) WHERE status = 'deposited';
+
+-- One row per user per month the summary was sent, so a restart doesn't send it twice
+CREATE TABLE IF NOT EXISTS vault_auto_save_summaries (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, period_start)
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_vault_yields_vault_credited;
+DROP TABLE IF EXISTS vault_withholding_tax_entries;
+DROP TABLE IF EXISTS vault_yield_accruals;
+
+ALTER TABLE vault_yield_configs
+    DROP CONSTRAINT IF EXISTS vault_yield_configs_apy_tiers_check,
+    DROP COLUMN IF EXISTS withholding_tax_rate,
+    DROP COLUMN IF EXISTS apy_tiers;
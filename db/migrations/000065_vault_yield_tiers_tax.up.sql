@@ -0,0 +1,56 @@
+-- Migration: Tiered APY, daily yield accrual and withholding tax
+-- Description: Balance bands on yield configs, a daily accrual ledger capitalised on the config's compound frequency, and tax withheld at source
+
+-- apy_tiers is a JSON array of {"min_balance", "apy_rate"} in ascending min_balance order starting at 0.
+-- A band runs up to the next band's min_balance; the last band is open-ended. NULL keeps the flat apy_rate.
+ALTER TABLE vault_yield_configs
+    ADD COLUMN apy_tiers JSONB,
+    ADD COLUMN withholding_tax_rate DECIMAL(5, 2) NOT NULL DEFAULT 0 CHECK (withholding_tax_rate >= 0 AND withholding_tax_rate <= 100),
+    ADD CONSTRAINT vault_yield_configs_apy_tiers_check CHECK (apy_tiers IS NULL OR jsonb_typeof(apy_tiers) = 'array');
+
+COMMENT ON COLUMN vault_yield_configs.apy_tiers IS 'Balance bands with their own APY; each band''s rate applies to the part of the balance inside it';
+
+-- One row per vault per day of yield earned. Rows stay uncapitalised until the config's compound
+-- frequency (or maturity) moves their sum, less withholding tax, into the vault.
+CREATE TABLE IF NOT EXISTS vault_yield_accruals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    vault_id UUID NOT NULL REFERENCES vault_savings(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    accrual_date DATE NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    balance DECIMAL(19, 4) NOT NULL,
+    -- blended APY of the balance across the config's tiers
+    apy_rate DECIMAL(19, 4) NOT NULL,
+    amount DECIMAL(19, 8) NOT NULL CHECK (amount > 0),
+    -- set when the accrual is capitalised
+    yield_id UUID REFERENCES vault_yields(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (vault_id, accrual_date)
+);
+
+CREATE INDEX idx_vault_yield_accruals_pending ON vault_yield_accruals(vault_id, accrual_date) WHERE yield_id IS NULL;
+
+-- The withholding tax liability account: tax deducted from every capitalised yield, owed to the
+-- tax authority until remitted. User and vault carry no foreign key so the record outlives both.
+CREATE TABLE IF NOT EXISTS vault_withholding_tax_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    yield_id UUID UNIQUE REFERENCES vault_yields(id) ON DELETE SET NULL,
+    user_id UUID NOT NULL,
+    vault_id UUID NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    gross_yield DECIMAL(19, 4) NOT NULL,
+    tax_rate DECIMAL(5, 2) NOT NULL,
+    tax_amount DECIMAL(19, 4) NOT NULL CHECK (tax_amount > 0),
+    net_yield DECIMAL(19, 4) NOT NULL,
+    -- calendar year the yield accrued in (the year of its accrual period end)
+    tax_year INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'accrued' CHECK (status IN ('accrued', 'remitted')),
+    remittance_reference VARCHAR(100),
+    remitted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_vault_wht_liability ON vault_withholding_tax_entries(currency, created_at) WHERE status = 'accrued';
+CREATE INDEX idx_vault_wht_vault_year ON vault_withholding_tax_entries(vault_id, tax_year);
+CREATE INDEX idx_vault_yields_vault_credited ON vault_yields(vault_id, credited_at) WHERE status = 'credited';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS vault_sweep_runs;
+DROP TABLE IF EXISTS vault_sweep_rules;
@@ -0,0 +1,49 @@
+-- Migration: Wallet sweep rules
+-- Description: Nightly rules that keep a wallet at a target balance by sweeping the excess into a vault or topping it up from one
+
+-- sweep_excess: wallet balance above target_balance is deposited into the vault
+-- top_up: when the wallet is below target_balance the vault tops it back up to it
+CREATE TABLE IF NOT EXISTS vault_sweep_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    vault_id UUID NOT NULL REFERENCES vault_savings(id) ON DELETE CASCADE,
+    -- wallet currency, always the vault's
+    currency VARCHAR(10) NOT NULL,
+    rule_type VARCHAR(20) NOT NULL CHECK (rule_type IN ('sweep_excess', 'top_up')),
+    target_balance DECIMAL(19, 4) NOT NULL CHECK (target_balance >= 0),
+    -- moves smaller than this are skipped
+    min_amount DECIMAL(19, 4) NOT NULL DEFAULT 0 CHECK (min_amount >= 0),
+    -- "HH:MM" in server time
+    run_time VARCHAR(5) NOT NULL DEFAULT '00:00',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One active rule of each type per wallet, otherwise two rules would race for the same balance
+CREATE UNIQUE INDEX idx_vault_sweep_rules_wallet_type ON vault_sweep_rules(user_id, currency, rule_type) WHERE is_active;
+CREATE INDEX idx_vault_sweep_rules_due ON vault_sweep_rules(next_run_at) WHERE is_active;
+CREATE INDEX idx_vault_sweep_rules_vault ON vault_sweep_rules(vault_id);
+
+-- One row per rule per run, including runs that had nothing to move
+CREATE TABLE IF NOT EXISTS vault_sweep_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    rule_id UUID NOT NULL REFERENCES vault_sweep_rules(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    vault_id UUID NOT NULL REFERENCES vault_savings(id) ON DELETE CASCADE,
+    rule_type VARCHAR(20) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    wallet_balance_before DECIMAL(19, 4) NOT NULL,
+    vault_balance_before DECIMAL(19, 4) NOT NULL,
+    target_balance DECIMAL(19, 4) NOT NULL,
+    amount DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('completed', 'skipped', 'failed')),
+    vault_transaction_id UUID REFERENCES vault_transactions(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_vault_sweep_runs_rule ON vault_sweep_runs(rule_id, created_at DESC);
+CREATE INDEX idx_vault_sweep_runs_user ON vault_sweep_runs(user_id, created_at DESC);
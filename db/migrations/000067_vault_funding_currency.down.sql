@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_vault_savings_user_name;
+ALTER TABLE vault_savings DROP COLUMN IF EXISTS funding_currency;
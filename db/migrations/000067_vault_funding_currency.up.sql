@@ -0,0 +1,11 @@
+-- Migration: Cross-currency vault funding
+-- Description: A vault can be funded from a wallet in another currency, converted at a quoted rate
+
+-- Recurring deposits into the vault are debited from the wallet in this currency and converted
+-- into the vault currency, e.g. a USDT vault that earns USDT yield but is funded from USD.
+-- NULL funds from the wallet in the vault currency.
+ALTER TABLE vault_savings
+    ADD COLUMN funding_currency VARCHAR(4) CHECK (funding_currency IN ('USDT', 'USDC', 'NGN', 'USD'));
+
+-- Vaults are told apart by name now that a user can hold several in one currency
+CREATE INDEX idx_vault_savings_user_name ON vault_savings(user_id, LOWER(vault_name)) WHERE status != 'cancelled';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS card_spending_controls;
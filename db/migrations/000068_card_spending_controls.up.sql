@@ -0,0 +1,26 @@
+-- Migration: Card spending controls
+-- Description: Per-card merchant category blocks, channel toggles and custom limits set by the cardholder
+
+-- Bridgecard has no authorisation webhook, so controls are checked when the debit
+-- webhook arrives and a violating card is frozen straight away.
+CREATE TABLE IF NOT EXISTS card_spending_controls (
+    card_id UUID PRIMARY KEY REFERENCES virtual_cards(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- merchant category groups, e.g. betting, adult, crypto
+    blocked_categories TEXT[] NOT NULL DEFAULT '{}',
+    -- only card-not-present payments are allowed
+    online_only BOOLEAN NOT NULL DEFAULT FALSE,
+    atm_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    -- custom caps in card currency, never above the card plan's limits
+    transaction_limit DECIMAL(19, 4) CHECK (transaction_limit > 0),
+    daily_limit DECIMAL(19, 4) CHECK (daily_limit > 0),
+    monthly_limit DECIMAL(19, 4) CHECK (monthly_limit > 0),
+    -- declined debits since the last successful one, checked against card_plans.failed_tx_count_before_block
+    consecutive_declines INT NOT NULL DEFAULT 0,
+    last_violation_reason TEXT,
+    last_violation_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_spending_controls_user ON card_spending_controls(user_id);
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_card_billing_run;
+DROP INDEX IF EXISTS idx_card_billing_provider_ref;
+DROP INDEX IF EXISTS idx_card_billing_maintenance_period;
+
+ALTER TABLE card_billing_history
+    DROP COLUMN IF EXISTS billing_run_id,
+    DROP COLUMN IF EXISTS provider_reference,
+    DROP COLUMN IF EXISTS next_retry_at,
+    DROP COLUMN IF EXISTS attempt_count,
+    DROP COLUMN IF EXISTS charge_source;
+
+DELETE FROM card_billing_history WHERE source_wallet_id IS NULL;
+ALTER TABLE card_billing_history ALTER COLUMN source_wallet_id SET NOT NULL;
+
+DROP TABLE IF EXISTS card_billing_runs;
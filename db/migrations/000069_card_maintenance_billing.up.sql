@@ -0,0 +1,44 @@
+-- Migration: Card maintenance fee billing
+-- Description: Monthly maintenance fee runs with retries, card-balance charges and an admin run report
+
+-- One row per billing run, scheduled or triggered by an admin
+CREATE TABLE IF NOT EXISTS card_billing_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trigger_type VARCHAR(20) NOT NULL CHECK (trigger_type IN ('scheduled', 'manual')),
+    triggered_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'completed', 'failed')),
+    cards_due INT NOT NULL DEFAULT 0,
+    charged_count INT NOT NULL DEFAULT 0,
+    charged_amount DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    -- card-balance charges waiting on the unload webhook
+    pending_count INT NOT NULL DEFAULT 0,
+    failed_count INT NOT NULL DEFAULT 0,
+    frozen_count INT NOT NULL DEFAULT 0,
+    -- plans without a maintenance fee
+    waived_count INT NOT NULL DEFAULT 0,
+    skipped_count INT NOT NULL DEFAULT 0,
+    reminders_sent INT NOT NULL DEFAULT 0,
+    error TEXT,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_card_billing_runs_started ON card_billing_runs(started_at DESC);
+
+-- Fees taken from the card balance have no source wallet
+ALTER TABLE card_billing_history ALTER COLUMN source_wallet_id DROP NOT NULL;
+
+ALTER TABLE card_billing_history
+    ADD COLUMN charge_source VARCHAR(10) CHECK (charge_source IN ('wallet', 'card')),
+    ADD COLUMN attempt_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN next_retry_at TIMESTAMPTZ,
+    -- Bridgecard unload reference for card-balance charges
+    ADD COLUMN provider_reference VARCHAR(100),
+    -- last run that attempted the charge
+    ADD COLUMN billing_run_id UUID REFERENCES card_billing_runs(id) ON DELETE SET NULL;
+
+-- A card is billed its maintenance fee once per period
+CREATE UNIQUE INDEX idx_card_billing_maintenance_period ON card_billing_history(card_id, billing_period_start)
+    WHERE billing_type = 'monthly_maintenance';
+CREATE INDEX idx_card_billing_provider_ref ON card_billing_history(provider_reference) WHERE provider_reference IS NOT NULL;
+CREATE INDEX idx_card_billing_run ON card_billing_history(billing_run_id) WHERE billing_run_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS card_dispute_events;
+DROP TABLE IF EXISTS card_dispute_evidence;
+DROP TABLE IF EXISTS card_disputes;
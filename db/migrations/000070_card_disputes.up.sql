@@ -0,0 +1,70 @@
+-- Migration: Card disputes
+-- Description: Cardholder disputes of card debits with evidence, SLA timers, provisional credit and a linked support ticket
+
+-- One dispute per card debit. Bridgecard has no dispute API, so the case is worked
+-- by our support team; provider_reference records any case raised with the issuer.
+CREATE TABLE IF NOT EXISTS card_disputes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    card_transaction_id UUID NOT NULL UNIQUE REFERENCES card_transactions(id) ON DELETE CASCADE,
+    card_id UUID NOT NULL REFERENCES virtual_cards(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(30) NOT NULL CHECK (reason IN ('unrecognised', 'duplicate', 'incorrect_amount', 'not_received', 'cancelled_recurring', 'other')),
+    description TEXT NOT NULL,
+    -- disputed amount in USD, from the card debit
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    status VARCHAR(20) NOT NULL DEFAULT 'opened' CHECK (status IN ('opened', 'under_review', 'provisional_credit', 'won', 'lost')),
+    -- conversation with support about the case
+    ticket_id BIGINT REFERENCES tickets(id) ON DELETE SET NULL,
+    assigned_to UUID REFERENCES users(id) ON DELETE SET NULL,
+    provider_reference VARCHAR(100),
+
+    -- SLA timers; each breach alerts admins once
+    review_due_at TIMESTAMPTZ NOT NULL,
+    resolution_due_at TIMESTAMPTZ NOT NULL,
+    review_breach_alerted_at TIMESTAMPTZ,
+    resolution_breach_alerted_at TIMESTAMPTZ,
+
+    -- wallet credit while the case is open, reversed if the dispute is lost
+    credit_wallet_id UUID REFERENCES swift_wallets(id) ON DELETE SET NULL,
+    credit_transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    credited_at TIMESTAMPTZ,
+    reversal_transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    -- part of the credit the wallet could not cover when it was reversed
+    reversal_shortfall DECIMAL(19, 4) NOT NULL DEFAULT 0,
+
+    resolution_note TEXT,
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_disputes_user ON card_disputes(user_id, created_at DESC);
+CREATE INDEX idx_card_disputes_open ON card_disputes(status, resolution_due_at) WHERE status NOT IN ('won', 'lost');
+
+CREATE TABLE IF NOT EXISTS card_dispute_evidence (
+    id BIGSERIAL PRIMARY KEY,
+    dispute_id UUID NOT NULL REFERENCES card_disputes(id) ON DELETE CASCADE,
+    uploaded_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_url TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    file_size INT NOT NULL, -- in bytes
+    mime_type VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_dispute_evidence_dispute ON card_dispute_evidence(dispute_id);
+
+-- Case timeline: every status change and the admin or user who made it
+CREATE TABLE IF NOT EXISTS card_dispute_events (
+    id BIGSERIAL PRIMARY KEY,
+    dispute_id UUID NOT NULL REFERENCES card_disputes(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_dispute_events_dispute ON card_dispute_events(dispute_id, created_at);
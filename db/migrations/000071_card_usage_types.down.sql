@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS card_usage_locks;
+
+ALTER TABLE card_plans DROP COLUMN IF EXISTS usage_type;
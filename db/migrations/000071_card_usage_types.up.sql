@@ -0,0 +1,31 @@
+-- Migration: Single-use and merchant-locked cards
+-- Description: Card plans can issue disposable cards that close after their first debit,
+-- or cards that only the first merchant to charge them may keep charging
+
+ALTER TABLE card_plans
+    ADD COLUMN IF NOT EXISTS usage_type VARCHAR(20) NOT NULL DEFAULT 'standard'
+        CHECK (usage_type IN ('standard', 'single_use', 'merchant_locked'));
+
+-- One row per card issued on a single-use or merchant-locked plan. The usage
+-- type is copied from the plan at issue so later plan edits do not change
+-- how an existing card behaves.
+CREATE TABLE IF NOT EXISTS card_usage_locks (
+    card_id UUID PRIMARY KEY REFERENCES virtual_cards(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    usage_type VARCHAR(20) NOT NULL CHECK (usage_type IN ('single_use', 'merchant_locked')),
+    -- merchant_locked: the first merchant charged, as matched and as shown
+    locked_merchant VARCHAR(255),
+    locked_merchant_display VARCHAR(255),
+    locked_at TIMESTAMPTZ,
+    -- single_use: the debit that used the card up, and the unload of what was
+    -- left on it back to the wallet before the card is deleted
+    used_by_card_transaction_id UUID REFERENCES card_transactions(id) ON DELETE SET NULL,
+    termination_status VARCHAR(20) CHECK (termination_status IN ('unloading', 'terminated', 'failed')),
+    unload_reference VARCHAR(100) UNIQUE,
+    unload_amount DECIMAL(19, 4),
+    failure_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_usage_locks_user ON card_usage_locks(user_id);
@@ -0,0 +1,6 @@
+ALTER TABLE rate_quotes
+    DROP COLUMN IF EXISTS vip_level_name,
+    DROP COLUMN IF EXISTS vip_level_id,
+    DROP COLUMN IF EXISTS rule_name,
+    DROP COLUMN IF EXISTS rule_id,
+    DROP COLUMN IF EXISTS mid_rate;
@@ -0,0 +1,10 @@
+-- Migration: Revenue attribution on rate quotes
+-- Description: A quote keeps the mid rate, rate rule and VIP level it was priced with, so
+-- revenue from a quoted conversion or ramp is measured against the rate the user was shown
+
+ALTER TABLE rate_quotes
+    ADD COLUMN mid_rate NUMERIC(30, 10),
+    ADD COLUMN rule_id UUID REFERENCES rate_adjustment_rules(id) ON DELETE SET NULL,
+    ADD COLUMN rule_name VARCHAR(100),
+    ADD COLUMN vip_level_id UUID REFERENCES vip_levels(id) ON DELETE SET NULL,
+    ADD COLUMN vip_level_name VARCHAR(50);
@@ -0,0 +1,4 @@
+DELETE FROM vault_yield_configs
+WHERE currency IN ('USDT', 'USDC')
+  AND product_type = 'flexible'
+  AND notes = 'stablecoin vault yield';
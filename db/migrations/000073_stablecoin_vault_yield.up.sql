@@ -0,0 +1,12 @@
+-- Migration: Stablecoin vault yield
+-- Description: Flexible yield configs for USDT and USDC vaults, so stablecoin vaults earn yield in their own currency
+
+INSERT INTO vault_yield_configs (currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes)
+SELECT c.currency, 3.5, 0, 'daily', TRUE, NOW(), NULL, 'stablecoin vault yield'
+FROM (VALUES ('USDT'), ('USDC')) AS c(currency)
+WHERE NOT EXISTS (
+    SELECT 1 FROM vault_yield_configs v
+    WHERE v.currency = c.currency
+      AND v.product_type = 'flexible'
+      AND v.is_active = TRUE
+);
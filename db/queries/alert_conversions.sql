@@ -0,0 +1,47 @@
+-- name: CreateAlertConversionAction :one
+INSERT INTO alert_conversion_actions (
+    alert_id,
+    user_id,
+    source_currency,
+    target_currency,
+    source_wallet_id,
+    target_wallet_id,
+    amount,
+    authorized_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetAlertConversionAction :one
+SELECT * FROM alert_conversion_actions
+WHERE alert_id = $1;
+
+-- name: CreateAlertConversionRun :one
+INSERT INTO alert_conversion_runs (
+    trigger_id,
+    alert_id,
+    user_id,
+    status,
+    reason,
+    conversion_history_id,
+    transaction_id,
+    source_currency,
+    target_currency,
+    source_amount,
+    amount_usd,
+    target_amount,
+    executed_rate
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+) RETURNING *;
+
+-- name: ListAlertConversionRuns :many
+SELECT * FROM alert_conversion_runs
+WHERE trigger_id = ANY(sqlc.arg(trigger_ids)::bigint[]);
+
+-- name: SumUserAlertConversionsSince :one
+SELECT COALESCE(SUM(amount_usd), 0)::TEXT AS total_usd
+FROM alert_conversion_runs
+WHERE user_id = $1
+  AND status = 'executed'
+  AND created_at >= $2;
@@ -0,0 +1,72 @@
+-- name: CreateAlertTriggerDelivery :one
+INSERT INTO alert_trigger_deliveries (
+    trigger_id,
+    alert_id,
+    user_id,
+    channel,
+    status
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: MarkAlertDeliverySent :one
+UPDATE alert_trigger_deliveries
+SET status = 'sent',
+    attempts = attempts + 1,
+    last_error = NULL,
+    next_attempt_at = NULL,
+    delivered_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- A NULL next_attempt_at leaves the delivery failed for good.
+-- name: MarkAlertDeliveryFailed :one
+UPDATE alert_trigger_deliveries
+SET status = 'failed',
+    attempts = attempts + 1,
+    last_error = $2,
+    next_attempt_at = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- Leases due retries to the caller by pushing next_attempt_at out, so replicas never resend the same delivery.
+-- name: ClaimDueAlertDeliveries :many
+UPDATE alert_trigger_deliveries
+SET next_attempt_at = NOW() + make_interval(secs => sqlc.arg(lease_seconds)::int),
+    updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM alert_trigger_deliveries
+    WHERE status = 'failed'
+      AND next_attempt_at IS NOT NULL
+      AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ListAlertTriggerDeliveries :many
+SELECT * FROM alert_trigger_deliveries
+WHERE trigger_id = ANY(sqlc.arg(trigger_ids)::bigint[])
+ORDER BY trigger_id, id;
+
+-- Suppressed deliveries do not count against the cap.
+-- name: CountUserAlertDeliveriesSince :one
+SELECT COUNT(*) FROM alert_trigger_deliveries
+WHERE user_id = $1
+  AND channel = $2
+  AND status <> 'suppressed'
+  AND created_at >= $3;
+
+-- name: GetAlertTriggerHistoryEntry :one
+SELECT * FROM alert_trigger_history
+WHERE id = $1 LIMIT 1;
+
+-- name: UpdateAlertTriggerNotificationStatus :exec
+UPDATE alert_trigger_history
+SET push_notification_sent = $2,
+    in_app_notification_sent = $3,
+    notification_error = $4
+WHERE id = $1;
@@ -0,0 +1,107 @@
+-- name: UpsertAlertEvaluator :exec
+INSERT INTO price_alert_evaluators (
+    node_id,
+    alerts_evaluated,
+    alerts_triggered,
+    duplicate_triggers,
+    last_cycle_ms,
+    max_lag_ms
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (node_id) DO UPDATE
+SET heartbeat_at = NOW(),
+    alerts_evaluated = EXCLUDED.alerts_evaluated,
+    alerts_triggered = EXCLUDED.alerts_triggered,
+    duplicate_triggers = EXCLUDED.duplicate_triggers,
+    last_cycle_ms = EXCLUDED.last_cycle_ms,
+    max_lag_ms = EXCLUDED.max_lag_ms;
+
+-- name: ListLiveAlertEvaluators :many
+SELECT * FROM price_alert_evaluators
+WHERE heartbeat_at > NOW() - make_interval(secs => sqlc.arg(ttl_seconds)::int)
+ORDER BY node_id;
+
+-- name: DeleteAlertEvaluator :exec
+DELETE FROM price_alert_evaluators
+WHERE node_id = $1;
+
+-- name: DeleteStaleAlertEvaluators :exec
+DELETE FROM price_alert_evaluators
+WHERE heartbeat_at < NOW() - make_interval(secs => sqlc.arg(ttl_seconds)::int);
+
+-- Takes the shard when it is free, expired or already held by the node; holding it renews it.
+-- name: AcquireAlertShardLease :one
+INSERT INTO price_alert_shard_leases (
+    shard,
+    node_id,
+    expires_at
+) VALUES (
+    $1, $2, NOW() + make_interval(secs => sqlc.arg(ttl_seconds)::int)
+)
+ON CONFLICT (shard) DO UPDATE
+SET node_id = EXCLUDED.node_id,
+    acquired_at = CASE
+        WHEN price_alert_shard_leases.node_id = EXCLUDED.node_id THEN price_alert_shard_leases.acquired_at
+        ELSE NOW()
+    END,
+    expires_at = EXCLUDED.expires_at
+WHERE price_alert_shard_leases.node_id = EXCLUDED.node_id
+   OR price_alert_shard_leases.expires_at < NOW()
+RETURNING *;
+
+-- name: RenewAlertShardLeases :many
+UPDATE price_alert_shard_leases
+SET expires_at = NOW() + make_interval(secs => sqlc.arg(ttl_seconds)::int)
+WHERE node_id = $1
+RETURNING *;
+
+-- name: ReleaseAlertShardLease :exec
+DELETE FROM price_alert_shard_leases
+WHERE shard = $1 AND node_id = $2;
+
+-- name: ListAlertShardLeases :many
+SELECT * FROM price_alert_shard_leases
+ORDER BY shard;
+
+-- name: ListActivePriceAlertPairs :many
+SELECT DISTINCT source_currency, target_currency FROM price_alerts
+WHERE is_active = true
+AND deleted_at IS NULL
+AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY source_currency, target_currency;
+
+-- name: GetActivePriceAlertsByPairs :many
+SELECT * FROM price_alerts
+WHERE is_active = true
+AND deleted_at IS NULL
+AND (expires_at IS NULL OR expires_at > NOW())
+AND (source_currency, target_currency) IN (
+    SELECT UNNEST(sqlc.arg(source_currencies)::text[]), UNNEST(sqlc.arg(target_currencies)::text[])
+)
+ORDER BY priority DESC, last_checked_at ASC NULLS FIRST;
+
+-- Includes deactivated and deleted alerts so evaluators can drop them from their index.
+-- name: ListPriceAlertsChangedSince :many
+SELECT * FROM price_alerts
+WHERE updated_at > $1
+ORDER BY updated_at;
+
+-- Only the evaluator that still sees the alert's current triggered_count claims the trigger,
+-- so it fires once even while two replicas briefly hold the same shard.
+-- name: ClaimAlertTrigger :one
+UPDATE price_alerts
+SET
+    triggered_count = triggered_count + 1,
+    last_triggered_at = $3,
+    last_checked_at = $3,
+    is_active = COALESCE(sqlc.narg(is_active), is_active),
+    baseline_rate = COALESCE(sqlc.narg(baseline_rate), baseline_rate)
+WHERE id = $1 AND triggered_count = $2 AND is_active = true AND deleted_at IS NULL
+RETURNING *;
+
+-- name: MarkPairAlertsChecked :exec
+UPDATE price_alerts
+SET last_checked_at = NOW()
+WHERE source_currency = $1 AND target_currency = $2
+AND is_active = true AND deleted_at IS NULL;
@@ -0,0 +1,127 @@
+-- name: CreateCardBillingRun :one
+INSERT INTO card_billing_runs (trigger_type, triggered_by)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: FinishCardBillingRun :one
+UPDATE card_billing_runs
+SET status = $2,
+    cards_due = $3,
+    charged_count = $4,
+    charged_amount = $5,
+    pending_count = $6,
+    failed_count = $7,
+    frozen_count = $8,
+    waived_count = $9,
+    skipped_count = $10,
+    reminders_sent = $11,
+    error = $12,
+    finished_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetCardBillingRun :one
+SELECT * FROM card_billing_runs
+WHERE id = $1;
+
+-- name: ListCardBillingRuns :many
+SELECT * FROM card_billing_runs
+ORDER BY started_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: ListCardBillingRunEntries :many
+SELECT
+    cbh.id,
+    cbh.card_id,
+    cbh.user_id,
+    cbh.amount,
+    cbh.currency,
+    cbh.billing_period_start,
+    cbh.billing_period_end,
+    cbh.status,
+    cbh.failure_reason,
+    cbh.charge_source,
+    cbh.attempt_count,
+    cbh.next_retry_at,
+    cbh.processed_at,
+    vc.card_name,
+    vc.status AS card_status,
+    cp.name AS plan_name
+FROM card_billing_history cbh
+JOIN virtual_cards vc ON vc.id = cbh.card_id
+JOIN card_plans cp ON cp.id = cbh.card_plan_id
+WHERE cbh.billing_run_id = $1
+ORDER BY cbh.created_at ASC;
+
+-- name: GetMaintenanceBilling :one
+SELECT * FROM card_billing_history
+WHERE card_id = $1
+  AND billing_type = 'monthly_maintenance'
+  AND billing_period_start = $2;
+
+-- name: CreateMaintenanceBilling :one
+INSERT INTO card_billing_history (
+    card_id, user_id, card_plan_id, billing_type, amount, currency,
+    billing_period_start, billing_period_end, status, transaction_id, billing_run_id
+) VALUES (
+    $1, $2, $3, 'monthly_maintenance', $4, $5, $6, $7, 'pending', $8, $9
+) RETURNING *;
+
+-- Takes a short lease on the charge so overlapping runs cannot both attempt it.
+-- Card-balance charges in flight are left to the unload webhook.
+-- name: ClaimMaintenanceBilling :one
+UPDATE card_billing_history
+SET billing_run_id = $2,
+    attempt_count = attempt_count + 1,
+    next_retry_at = NOW() + INTERVAL '10 minutes'
+WHERE id = $1
+  AND (status = 'failed' OR (status = 'pending' AND charge_source IS NULL))
+  AND (next_retry_at IS NULL OR next_retry_at <= NOW())
+RETURNING *;
+
+-- name: CompleteMaintenanceBilling :one
+UPDATE card_billing_history
+SET status = 'successful',
+    source_wallet_id = $2,
+    charge_source = $3,
+    failure_reason = NULL,
+    next_retry_at = NULL,
+    processed_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkMaintenanceBillingAwaitingCard :exec
+UPDATE card_billing_history
+SET status = 'pending',
+    charge_source = 'card',
+    provider_reference = $2,
+    next_retry_at = NULL
+WHERE id = $1;
+
+-- name: FailMaintenanceBilling :exec
+UPDATE card_billing_history
+SET status = 'failed',
+    failure_reason = $2,
+    next_retry_at = $3,
+    processed_at = NOW()
+WHERE id = $1;
+
+-- name: GetCardBillingByProviderReference :one
+SELECT * FROM card_billing_history
+WHERE provider_reference = $1;
+
+-- Active cards whose maintenance fee falls due in [$1, $2)
+-- name: GetCardsDueForBillingReminder :many
+SELECT
+    vc.id,
+    vc.user_id,
+    vc.card_name,
+    vc.next_billing_date,
+    cp.monthly_maintenance_fee
+FROM virtual_cards vc
+JOIN card_plans cp ON cp.id = vc.card_plan_id
+WHERE vc.status = 'active'
+  AND vc.terminated_at IS NULL
+  AND vc.next_billing_date >= $1
+  AND vc.next_billing_date < $2
+  AND cp.monthly_maintenance_fee > 0;
@@ -0,0 +1,49 @@
+-- name: GetCardSpendingControls :one
+SELECT * FROM card_spending_controls
+WHERE card_id = $1;
+
+-- name: UpsertCardSpendingControls :one
+INSERT INTO card_spending_controls (
+    card_id,
+    user_id,
+    blocked_categories,
+    online_only,
+    atm_enabled,
+    transaction_limit,
+    daily_limit,
+    monthly_limit
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (card_id) DO UPDATE
+SET blocked_categories = EXCLUDED.blocked_categories,
+    online_only = EXCLUDED.online_only,
+    atm_enabled = EXCLUDED.atm_enabled,
+    transaction_limit = EXCLUDED.transaction_limit,
+    daily_limit = EXCLUDED.daily_limit,
+    monthly_limit = EXCLUDED.monthly_limit,
+    updated_at = NOW()
+RETURNING *;
+
+-- Cards without a controls row get one so the count survives
+-- name: IncrementCardDeclines :one
+INSERT INTO card_spending_controls (card_id, user_id, consecutive_declines)
+VALUES ($1, $2, 1)
+ON CONFLICT (card_id) DO UPDATE
+SET consecutive_declines = card_spending_controls.consecutive_declines + 1,
+    updated_at = NOW()
+RETURNING consecutive_declines;
+
+-- name: ResetCardDeclines :exec
+UPDATE card_spending_controls
+SET consecutive_declines = 0,
+    updated_at = NOW()
+WHERE card_id = $1 AND consecutive_declines > 0;
+
+-- name: RecordCardControlViolation :exec
+INSERT INTO card_spending_controls (card_id, user_id, last_violation_reason, last_violation_at)
+VALUES ($1, $2, $3, NOW())
+ON CONFLICT (card_id) DO UPDATE
+SET last_violation_reason = EXCLUDED.last_violation_reason,
+    last_violation_at = EXCLUDED.last_violation_at,
+    updated_at = NOW();
@@ -0,0 +1,140 @@
+-- name: CreateCardDispute :one
+INSERT INTO card_disputes (
+    card_transaction_id, card_id, user_id, reason, description,
+    amount, currency, review_due_at, resolution_due_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetCardDispute :one
+SELECT * FROM card_disputes
+WHERE id = $1;
+
+-- name: GetCardDisputeForUpdate :one
+SELECT * FROM card_disputes
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetCardDisputeByTransaction :one
+SELECT * FROM card_disputes
+WHERE card_transaction_id = $1;
+
+-- name: LinkCardDisputeTicket :exec
+UPDATE card_disputes
+SET ticket_id = $2,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: ListUserCardDisputes :many
+SELECT * FROM card_disputes
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- Open cases first, most urgent resolution deadline first.
+-- name: ListCardDisputeQueue :many
+SELECT
+    cd.id,
+    cd.card_transaction_id,
+    cd.card_id,
+    cd.user_id,
+    cd.reason,
+    cd.amount,
+    cd.currency,
+    cd.status,
+    cd.ticket_id,
+    cd.assigned_to,
+    cd.review_due_at,
+    cd.resolution_due_at,
+    cd.created_at,
+    vc.card_name,
+    ct.merchant_name,
+    ct.transaction_date,
+    u.first_name,
+    u.last_name,
+    u.email
+FROM card_disputes cd
+JOIN virtual_cards vc ON vc.id = cd.card_id
+JOIN card_transactions ct ON ct.id = cd.card_transaction_id
+JOIN users u ON u.id = cd.user_id
+WHERE (sqlc.narg('status')::VARCHAR IS NULL OR cd.status = sqlc.narg('status'))
+ORDER BY cd.status IN ('won', 'lost'), cd.resolution_due_at ASC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: StartCardDisputeReview :one
+UPDATE card_disputes
+SET status = 'under_review',
+    assigned_to = $2,
+    provider_reference = COALESCE($3, provider_reference),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: RecordCardDisputeCredit :one
+UPDATE card_disputes
+SET status = $2,
+    credit_wallet_id = $3,
+    credit_transaction_id = $4,
+    credited_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ResolveCardDispute :one
+UPDATE card_disputes
+SET status = $2,
+    resolution_note = $3,
+    resolved_by = $4,
+    reversal_transaction_id = $5,
+    reversal_shortfall = $6,
+    resolved_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListCardDisputesBreachingReview :many
+SELECT * FROM card_disputes
+WHERE status = 'opened'
+  AND review_due_at <= NOW()
+  AND review_breach_alerted_at IS NULL
+ORDER BY review_due_at ASC;
+
+-- name: ListCardDisputesBreachingResolution :many
+SELECT * FROM card_disputes
+WHERE status NOT IN ('won', 'lost')
+  AND resolution_due_at <= NOW()
+  AND resolution_breach_alerted_at IS NULL
+ORDER BY resolution_due_at ASC;
+
+-- name: MarkCardDisputeReviewBreachAlerted :exec
+UPDATE card_disputes
+SET review_breach_alerted_at = NOW()
+WHERE id = $1;
+
+-- name: MarkCardDisputeResolutionBreachAlerted :exec
+UPDATE card_disputes
+SET resolution_breach_alerted_at = NOW()
+WHERE id = $1;
+
+-- name: CreateCardDisputeEvidence :one
+INSERT INTO card_dispute_evidence (dispute_id, uploaded_by, file_url, file_name, file_size, mime_type)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListCardDisputeEvidence :many
+SELECT * FROM card_dispute_evidence
+WHERE dispute_id = $1
+ORDER BY created_at ASC;
+
+-- name: CountCardDisputeEvidence :one
+SELECT COUNT(*) FROM card_dispute_evidence
+WHERE dispute_id = $1;
+
+-- name: CreateCardDisputeEvent :exec
+INSERT INTO card_dispute_events (dispute_id, from_status, to_status, actor_id, note)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListCardDisputeEvents :many
+SELECT * FROM card_dispute_events
+WHERE dispute_id = $1
+ORDER BY created_at ASC, id ASC;
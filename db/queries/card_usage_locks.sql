@@ -0,0 +1,57 @@
+-- name: CreateCardUsageLock :one
+INSERT INTO card_usage_locks (card_id, user_id, usage_type)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetCardUsageLock :one
+SELECT * FROM card_usage_locks
+WHERE card_id = $1;
+
+-- name: GetCardUsageLockByUnloadReference :one
+SELECT * FROM card_usage_locks
+WHERE unload_reference = $1;
+
+-- Only the first merchant wins; concurrent debits re-read the lock.
+-- name: LockCardMerchant :one
+UPDATE card_usage_locks
+SET locked_merchant = $2,
+    locked_merchant_display = $3,
+    locked_at = NOW(),
+    updated_at = NOW()
+WHERE card_id = $1
+  AND usage_type = 'merchant_locked'
+  AND locked_merchant IS NULL
+RETURNING *;
+
+-- Claims the termination of a single-use card so a replayed or second debit
+-- webhook cannot start it again.
+-- name: ClaimSingleUseTermination :one
+UPDATE card_usage_locks
+SET used_by_card_transaction_id = $2,
+    termination_status = 'unloading',
+    updated_at = NOW()
+WHERE card_id = $1
+  AND usage_type = 'single_use'
+  AND termination_status IS NULL
+RETURNING *;
+
+-- name: RecordCardUsageUnload :exec
+UPDATE card_usage_locks
+SET unload_reference = $2,
+    unload_amount = $3,
+    updated_at = NOW()
+WHERE card_id = $1;
+
+-- name: CompleteCardUsageTermination :exec
+UPDATE card_usage_locks
+SET termination_status = 'terminated',
+    failure_reason = NULL,
+    updated_at = NOW()
+WHERE card_id = $1;
+
+-- name: FailCardUsageTermination :exec
+UPDATE card_usage_locks
+SET termination_status = 'failed',
+    failure_reason = $2,
+    updated_at = NOW()
+WHERE card_id = $1;
@@ -0,0 +1,140 @@
+-- ============================================================
+-- BALANCE HOLD QUERIES
+-- ============================================================
+
+-- name: CreateBalanceHold :one
+INSERT INTO balance_holds (
+    user_id,
+    wallet_id,
+    currency,
+    amount,
+    remaining,
+    purpose,
+    reference_id
+) VALUES (
+    $1, $2, $3, $4, $4, $5, $6
+) RETURNING *;
+
+-- name: ConsumeBalanceHold :one
+UPDATE balance_holds
+SET remaining = remaining - sqlc.arg(amount)::numeric,
+    status = CASE WHEN remaining - sqlc.arg(amount)::numeric = 0 THEN 'consumed' ELSE status END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'active'
+  AND remaining >= sqlc.arg(amount)::numeric
+RETURNING *;
+
+-- name: ReleaseBalanceHold :one
+UPDATE balance_holds
+SET status = 'released',
+    released_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- ============================================================
+-- CONVERSION ORDER QUERIES
+-- ============================================================
+
+-- name: CreateConversionOrder :one
+INSERT INTO conversion_orders (
+    id,
+    user_id,
+    source_currency,
+    target_currency,
+    source_wallet_id,
+    target_wallet_id,
+    limit_rate,
+    source_amount,
+    time_in_force,
+    expires_at,
+    hold_id,
+    label
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: GetConversionOrder :one
+SELECT * FROM conversion_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetConversionOrderForUpdate :one
+SELECT * FROM conversion_orders
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListConversionOrdersByUser :many
+SELECT * FROM conversion_orders
+WHERE user_id = sqlc.arg(user_id)
+  AND (NOT sqlc.arg(open_only)::boolean OR status IN ('open', 'partially_filled'))
+ORDER BY created_at DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: ListOpenConversionOrders :many
+SELECT * FROM conversion_orders
+WHERE status IN ('open', 'partially_filled')
+  AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY source_currency, target_currency, created_at, id;
+
+-- name: ListExpiredConversionOrders :many
+SELECT * FROM conversion_orders
+WHERE time_in_force = 'gtd'
+  AND status IN ('open', 'partially_filled')
+  AND expires_at <= $1
+ORDER BY expires_at;
+
+-- name: RecordConversionOrderFill :one
+UPDATE conversion_orders
+SET filled_amount = filled_amount + sqlc.arg(fill_amount)::numeric,
+    received_amount = received_amount + sqlc.arg(received_amount)::numeric,
+    fees_paid = fees_paid + sqlc.arg(fees)::numeric,
+    status = CASE WHEN filled_amount + sqlc.arg(fill_amount)::numeric >= source_amount THEN 'filled' ELSE 'partially_filled' END,
+    closed_at = CASE WHEN filled_amount + sqlc.arg(fill_amount)::numeric >= source_amount THEN NOW() ELSE NULL END,
+    last_filled_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status IN ('open', 'partially_filled')
+  AND filled_amount + sqlc.arg(fill_amount)::numeric <= source_amount
+RETURNING *;
+
+-- name: CloseConversionOrder :one
+UPDATE conversion_orders
+SET status = $2,
+    closed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status IN ('open', 'partially_filled')
+RETURNING *;
+
+-- ============================================================
+-- CONVERSION ORDER FILL QUERIES
+-- ============================================================
+
+-- name: CreateConversionOrderFill :one
+INSERT INTO conversion_order_fills (
+    order_id,
+    conversion_history_id,
+    transaction_id,
+    source_currency,
+    target_currency,
+    source_amount,
+    executed_rate,
+    target_amount,
+    fees,
+    net_amount,
+    source_amount_usd
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: ListConversionOrderFills :many
+SELECT * FROM conversion_order_fills
+WHERE order_id = $1
+ORDER BY created_at;
+
+-- name: SumConversionOrderFillsSince :one
+SELECT COALESCE(SUM(source_amount_usd), 0)::numeric AS total_usd
+FROM conversion_order_fills
+WHERE source_currency = $1
+  AND target_currency = $2
+  AND created_at >= $3;
@@ -0,0 +1,52 @@
+-- name: CreateCryptoTagTransfer :one
+INSERT INTO crypto_tag_transfers (
+    reference,
+    sender_id,
+    recipient_id,
+    sender_wallet_id,
+    recipient_wallet_id,
+    sender_tag,
+    recipient_tag,
+    currency,
+    amount,
+    fee,
+    amount_usd,
+    debit_transaction_id,
+    credit_transaction_id,
+    risk_score,
+    risk_flags,
+    description,
+    status,
+    failure_reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+) RETURNING *;
+
+-- name: GetCryptoTagTransferByReference :one
+SELECT * FROM crypto_tag_transfers
+WHERE reference = $1 AND (sender_id = $2 OR recipient_id = $2)
+LIMIT 1;
+
+-- name: ListUserCryptoTagTransfers :many
+SELECT * FROM crypto_tag_transfers
+WHERE (sender_id = $1 OR recipient_id = $1) AND status = 'successful'
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetUserCryptoTagTransferVolumeSince :one
+SELECT
+    CAST(COALESCE(SUM(amount_usd), 0) AS TEXT) AS total_usd,
+    COUNT(*) AS transfer_count
+FROM crypto_tag_transfers
+WHERE sender_id = $1 AND status = 'successful' AND created_at >= $2;
+
+-- name: CheckCryptoTagTransferReference :one
+SELECT EXISTS (
+    SELECT 1
+    FROM crypto_tag_transfers
+    WHERE reference = $1
+) AS exists;
+
+-- name: CountCryptoTagTransfersBetween :one
+SELECT COUNT(*) FROM crypto_tag_transfers
+WHERE sender_id = $1 AND recipient_id = $2 AND status = 'successful';
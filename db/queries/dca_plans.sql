@@ -0,0 +1,128 @@
+-- ============================================================
+-- DCA PLAN QUERIES
+-- ============================================================
+
+-- name: CreateDCAPlan :one
+INSERT INTO dca_plans (
+    user_id,
+    source_currency,
+    target_currency,
+    source_wallet_id,
+    target_wallet_id,
+    amount,
+    frequency,
+    schedule_day_of_week,
+    schedule_day_of_month,
+    schedule_time,
+    timezone,
+    rate_ceiling,
+    insufficient_funds_policy,
+    next_execution_at,
+    label
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+) RETURNING *;
+
+-- name: GetDCAPlan :one
+SELECT * FROM dca_plans
+WHERE id = $1 LIMIT 1;
+
+-- name: ListDCAPlansByUser :many
+SELECT * FROM dca_plans
+WHERE user_id = $1
+  AND status <> 'cancelled'
+ORDER BY created_at DESC;
+
+-- name: ListDueDCAPlans :many
+SELECT * FROM dca_plans
+WHERE status = 'active'
+  AND next_execution_at <= $1
+ORDER BY next_execution_at;
+
+-- name: UpdateDCAPlan :one
+UPDATE dca_plans
+SET amount = $2,
+    frequency = $3,
+    schedule_day_of_week = $4,
+    schedule_day_of_month = $5,
+    schedule_time = $6,
+    timezone = $7,
+    rate_ceiling = $8,
+    insufficient_funds_policy = $9,
+    label = $10,
+    next_execution_at = $11,
+    updated_at = NOW()
+WHERE id = $1 AND status <> 'cancelled'
+RETURNING *;
+
+-- name: PauseDCAPlan :one
+UPDATE dca_plans
+SET status = 'paused',
+    updated_at = NOW()
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: ResumeDCAPlan :one
+UPDATE dca_plans
+SET status = 'active',
+    next_execution_at = $2,
+    updated_at = NOW()
+WHERE id = $1 AND status = 'paused'
+RETURNING *;
+
+-- name: CancelDCAPlan :one
+UPDATE dca_plans
+SET status = 'cancelled',
+    cancelled_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status IN ('active', 'paused')
+RETURNING *;
+
+-- name: RecordDCAPlanRun :one
+UPDATE dca_plans
+SET execution_count = execution_count + 1,
+    total_invested = total_invested + sqlc.arg(invested)::numeric,
+    total_accumulated = total_accumulated + sqlc.arg(accumulated)::numeric,
+    last_executed_at = NOW(),
+    next_execution_at = sqlc.arg(next_execution_at),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'active'
+  AND next_execution_at = sqlc.arg(scheduled_for)
+RETURNING *;
+
+-- name: RecordDCAPlanSkip :one
+UPDATE dca_plans
+SET skipped_count = skipped_count + 1,
+    status = sqlc.arg(status),
+    next_execution_at = sqlc.arg(next_execution_at),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND status = 'active'
+  AND next_execution_at = sqlc.arg(scheduled_for)
+RETURNING *;
+
+-- ============================================================
+-- DCA PLAN EXECUTION QUERIES
+-- ============================================================
+
+-- name: CreateDCAPlanExecution :one
+INSERT INTO dca_plan_executions (
+    plan_id,
+    status,
+    reason,
+    conversion_history_id,
+    transaction_id,
+    source_amount,
+    target_amount,
+    executed_rate,
+    scheduled_for
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: ListDCAPlanExecutions :many
+SELECT * FROM dca_plan_executions
+WHERE plan_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
@@ -0,0 +1,121 @@
+-- name: CreateFxRevenueEntry :exec
+INSERT INTO fx_revenue_entries (
+    channel,
+    reference_id,
+    transaction_id,
+    user_id,
+    source_currency,
+    target_currency,
+    source_amount,
+    mid_rate,
+    mid_rate_source,
+    applied_rate,
+    spread_revenue,
+    fee_revenue,
+    total_revenue,
+    revenue_usd,
+    rule_id,
+    rule_name,
+    vip_level_id,
+    vip_level_name,
+    rate_provider,
+    occurred_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
+)
+ON CONFLICT (channel, reference_id) DO NOTHING;
+
+-- name: SummarizeFxRevenue :many
+SELECT
+    date_trunc(sqlc.arg(bucket)::text, occurred_at)::timestamptz AS period_start,
+    channel,
+    source_currency,
+    target_currency,
+    rule_id,
+    rule_name,
+    vip_level_id,
+    vip_level_name,
+    COUNT(*)::bigint AS entry_count,
+    SUM(source_amount)::numeric AS source_volume,
+    SUM(spread_revenue)::numeric AS spread_revenue,
+    SUM(fee_revenue)::numeric AS fee_revenue,
+    SUM(total_revenue)::numeric AS total_revenue,
+    COALESCE(SUM(revenue_usd), 0)::numeric AS revenue_usd,
+    COUNT(*) FILTER (WHERE revenue_usd IS NULL)::bigint AS unpriced_count
+FROM fx_revenue_entries
+WHERE occurred_at >= sqlc.arg(start_time)
+  AND occurred_at < sqlc.arg(end_time)
+GROUP BY 1, channel, source_currency, target_currency, rule_id, rule_name, vip_level_id, vip_level_name
+ORDER BY 1, channel, source_currency, target_currency;
+
+-- name: ListFxRevenueEntries :many
+SELECT * FROM fx_revenue_entries
+WHERE occurred_at >= sqlc.arg(start_time)
+  AND occurred_at < sqlc.arg(end_time)
+ORDER BY occurred_at ASC, id ASC;
+
+-- name: ReconcileFxRevenue :many
+WITH source_records AS (
+    SELECT 'smart_conversion'::text AS channel, ch.id AS reference_id, ch.source_currency, ch.target_currency,
+           ch.source_amount::numeric AS source_amount, COALESCE(ch.fees, 0)::numeric AS fees, ch.created_at AS occurred_at
+    FROM conversion_history ch
+    WHERE ch.status = 'success'
+      AND ch.created_at >= sqlc.arg(start_time)
+      AND ch.created_at < sqlc.arg(end_time)
+    UNION ALL
+    SELECT 'rapid_ramp'::text, qt.id, qt.crypto_currency, COALESCE(qt.fiat_currency, ''),
+           qt.crypto_amount::numeric, qt.total_fees::numeric, qt.conversion_completed_at
+    FROM qr_transactions qt
+    WHERE qt.conversion_completed_at >= sqlc.arg(start_time)
+      AND qt.conversion_completed_at < sqlc.arg(end_time)
+    UNION ALL
+    SELECT 'card_funding'::text, cf.id, cf.source_currency, cf.currency,
+           cf.amount::numeric, 0::numeric, cf.created_at
+    FROM card_funding_history cf
+    WHERE cf.status = 'successful'
+      AND cf.created_at >= sqlc.arg(start_time)
+      AND cf.created_at < sqlc.arg(end_time)
+)
+SELECT
+    sr.channel,
+    sr.source_currency,
+    sr.target_currency,
+    COUNT(*)::bigint AS source_count,
+    COALESCE(SUM(sr.source_amount), 0)::numeric AS source_volume,
+    COALESCE(SUM(sr.fees), 0)::numeric AS source_fees,
+    COUNT(e.id)::bigint AS attributed_count,
+    COALESCE(SUM(e.source_amount), 0)::numeric AS attributed_volume,
+    COALESCE(SUM(e.fee_revenue), 0)::numeric AS attributed_fees
+FROM source_records sr
+LEFT JOIN fx_revenue_entries e ON e.channel = sr.channel AND e.reference_id = sr.reference_id
+GROUP BY sr.channel, sr.source_currency, sr.target_currency
+ORDER BY sr.channel, sr.source_currency, sr.target_currency;
+
+-- name: ListUnattributedFxRecords :many
+WITH source_records AS (
+    SELECT 'smart_conversion'::text AS channel, ch.id AS reference_id, ch.source_currency, ch.target_currency,
+           ch.source_amount::numeric AS source_amount, ch.created_at AS occurred_at
+    FROM conversion_history ch
+    WHERE ch.status = 'success'
+      AND ch.created_at >= sqlc.arg(start_time)
+      AND ch.created_at < sqlc.arg(end_time)
+    UNION ALL
+    SELECT 'rapid_ramp'::text, qt.id, qt.crypto_currency, COALESCE(qt.fiat_currency, ''),
+           qt.crypto_amount::numeric, qt.conversion_completed_at
+    FROM qr_transactions qt
+    WHERE qt.conversion_completed_at >= sqlc.arg(start_time)
+      AND qt.conversion_completed_at < sqlc.arg(end_time)
+    UNION ALL
+    SELECT 'card_funding'::text, cf.id, cf.source_currency, cf.currency,
+           cf.amount::numeric, cf.created_at
+    FROM card_funding_history cf
+    WHERE cf.status = 'successful'
+      AND cf.created_at >= sqlc.arg(start_time)
+      AND cf.created_at < sqlc.arg(end_time)
+)
+SELECT sr.channel, sr.reference_id, sr.source_currency, sr.target_currency, sr.source_amount, sr.occurred_at
+FROM source_records sr
+LEFT JOIN fx_revenue_entries e ON e.channel = sr.channel AND e.reference_id = sr.reference_id
+WHERE e.id IS NULL
+ORDER BY sr.occurred_at ASC
+LIMIT sqlc.arg(row_limit);
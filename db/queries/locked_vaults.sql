@@ -0,0 +1,76 @@
+-- name: CreateLockedVault :one
+INSERT INTO vault_savings (
+    user_id,
+    vault_name,
+    description,
+    goal_amount,
+    current_balance,
+    currency,
+    category,
+    status,
+    vault_type,
+    yield_config_id,
+    lock_term_days,
+    locked_apy,
+    locked_principal,
+    locked_at,
+    matures_at,
+    maturity_action,
+    early_withdrawal_policy,
+    early_withdrawal_penalty_rate,
+    next_yield_calculation
+) VALUES (
+    $1, $2, $3, $4, 0, $5, $6, 'active', 'locked', $7, $8, $9, $10, $11, $12, $13, $14, $15, NOW() + INTERVAL '1 day'
+) RETURNING *;
+
+-- name: GetActiveLockedYieldConfig :one
+SELECT * FROM vault_yield_configs
+WHERE currency = $1
+  AND lock_term_days = $2
+  AND product_type = 'locked'
+  AND is_active = TRUE
+  AND effective_from <= NOW()
+  AND (effective_until IS NULL OR effective_until > NOW())
+ORDER BY effective_from DESC
+LIMIT 1;
+
+-- The current terms of every locked product, optionally for one currency
+-- name: ListActiveLockedYieldConfigs :many
+SELECT DISTINCT ON (currency, lock_term_days) * FROM vault_yield_configs
+WHERE product_type = 'locked'
+  AND is_active = TRUE
+  AND effective_from <= NOW()
+  AND (effective_until IS NULL OR effective_until > NOW())
+  AND (sqlc.narg(currency)::text IS NULL OR currency = sqlc.narg(currency)::text)
+ORDER BY currency, lock_term_days, effective_from DESC;
+
+-- name: GetMaturedLockedVaults :many
+SELECT * FROM vault_savings
+WHERE vault_type = 'locked'
+  AND status = 'active'
+  AND matures_at <= NOW()
+ORDER BY matures_at ASC
+LIMIT $1;
+
+-- Starts the next term on the balance the vault matured with
+-- name: RolloverLockedVault :exec
+UPDATE vault_savings
+SET yield_config_id = $2,
+    locked_apy = $3,
+    locked_principal = $4,
+    goal_amount = $4,
+    locked_at = $5,
+    matures_at = $6,
+    early_withdrawal_policy = $7,
+    early_withdrawal_penalty_rate = $8,
+    last_yield_calculation = $5,
+    next_yield_calculation = $5 + INTERVAL '1 day',
+    rollover_count = rollover_count + 1,
+    updated_at = NOW()
+WHERE id = $1 AND vault_type = 'locked';
+
+-- name: UpdateMaturityAction :exec
+UPDATE vault_savings
+SET maturity_action = $2,
+    updated_at = NOW()
+WHERE id = $1 AND vault_type = 'locked' AND status = 'active';
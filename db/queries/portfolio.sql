@@ -0,0 +1,63 @@
+-- name: UpsertPortfolioSnapshot :one
+INSERT INTO portfolio_snapshots (
+    user_id,
+    snapshot_date,
+    total_usd,
+    wallets_usd,
+    vaults_usd,
+    cards_usd,
+    usd_rates,
+    holdings
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (user_id, snapshot_date) DO UPDATE SET
+    total_usd = EXCLUDED.total_usd,
+    wallets_usd = EXCLUDED.wallets_usd,
+    vaults_usd = EXCLUDED.vaults_usd,
+    cards_usd = EXCLUDED.cards_usd,
+    usd_rates = EXCLUDED.usd_rates,
+    holdings = EXCLUDED.holdings,
+    created_at = NOW()
+RETURNING *;
+
+-- name: ListPortfolioSnapshots :many
+SELECT * FROM portfolio_snapshots
+WHERE user_id = sqlc.arg(user_id)
+  AND snapshot_date >= sqlc.arg(start_date)
+  AND snapshot_date <= sqlc.arg(end_date)
+ORDER BY snapshot_date ASC;
+
+-- name: ListPortfolioUserIDs :many
+SELECT DISTINCT customer_id FROM swift_wallets
+ORDER BY customer_id;
+
+-- name: ListUserConversionCosts :many
+SELECT
+    ch.source_currency,
+    ch.target_currency,
+    ch.source_amount,
+    ch.net_amount,
+    t.amount_usd,
+    ch.executed_at
+FROM conversion_history ch
+LEFT JOIN transactions t ON t.id = ch.transaction_id
+WHERE ch.user_id = $1
+  AND ch.status = 'success'
+ORDER BY ch.executed_at ASC;
+
+-- name: ListUserCryptoInflowCosts :many
+SELECT
+    sw.currency,
+    ctm.received_amount,
+    ctm.sent_amount,
+    ctm.rate,
+    t.amount_usd,
+    t.created_at
+FROM transactions t
+JOIN crypto_transaction_metadata ctm ON ctm.transaction_id = t.id
+JOIN swift_wallets sw ON sw.id = ctm.destination_wallet
+WHERE t.user_id = $1
+  AND t.type = 'crypto'
+  AND t.status = 'successful'
+ORDER BY t.created_at ASC;
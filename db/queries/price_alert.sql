@@ -18,10 +18,18 @@ INSERT INTO price_alerts (
     label,
     expires_at,
     notify_push,
-    notify_in_app
+    notify_in_app,
+    delivery_channels,
+    webhook_url,
+    webhook_secret,
+    indicator_interval,
+    indicator_period,
+    indicator_fast_period,
+    indicator_threshold
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-    $11, $12, $13, $14, $15, $16, $17, $18, $19
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26
 ) RETURNING *;
 
 -- name: GetPriceAlert :one
@@ -68,7 +76,13 @@ SET
     label = COALESCE(sqlc.narg(label), label),
     expires_at = COALESCE(sqlc.narg(expires_at), expires_at),
     notify_push = COALESCE(sqlc.narg(notify_push), notify_push),
-    notify_in_app = COALESCE(sqlc.narg(notify_in_app), notify_in_app)
+    notify_in_app = COALESCE(sqlc.narg(notify_in_app), notify_in_app),
+    delivery_channels = COALESCE(sqlc.narg(delivery_channels)::text[], delivery_channels),
+    webhook_url = COALESCE(sqlc.narg(webhook_url), webhook_url),
+    webhook_secret = COALESCE(sqlc.narg(webhook_secret), webhook_secret),
+    indicator_period = COALESCE(sqlc.narg(indicator_period), indicator_period),
+    indicator_fast_period = COALESCE(sqlc.narg(indicator_fast_period), indicator_fast_period),
+    indicator_threshold = COALESCE(sqlc.narg(indicator_threshold), indicator_threshold)
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
@@ -130,9 +144,11 @@ INSERT INTO alert_trigger_history (
     alert_condition,
     target_rate,
     push_notification_sent,
-    in_app_notification_sent
+    in_app_notification_sent,
+    title,
+    body
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 ) RETURNING *;
 
 -- name: GetAlertTriggerHistory :many
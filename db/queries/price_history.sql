@@ -0,0 +1,78 @@
+-- name: InsertRateSample :one
+INSERT INTO rate_samples (
+    base_currency,
+    quote_currency,
+    rate,
+    source,
+    sampled_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: RollupRateCandles :execrows
+INSERT INTO rate_candles (
+    base_currency,
+    quote_currency,
+    interval,
+    bucket_start,
+    open,
+    high,
+    low,
+    close,
+    sample_count
+)
+SELECT
+    s.base_currency,
+    s.quote_currency,
+    sqlc.arg(interval)::VARCHAR,
+    to_timestamp(floor(extract(epoch FROM s.sampled_at) / sqlc.arg(bucket_seconds)::BIGINT) * sqlc.arg(bucket_seconds)::BIGINT) AS bucket_start,
+    (array_agg(s.rate ORDER BY s.sampled_at ASC))[1],
+    MAX(s.rate),
+    MIN(s.rate),
+    (array_agg(s.rate ORDER BY s.sampled_at DESC))[1],
+    COUNT(*)
+FROM rate_samples s
+WHERE s.sampled_at >= sqlc.arg(since)
+GROUP BY s.base_currency, s.quote_currency, bucket_start
+ON CONFLICT (base_currency, quote_currency, interval, bucket_start) DO UPDATE
+SET open = EXCLUDED.open,
+    high = EXCLUDED.high,
+    low = EXCLUDED.low,
+    close = EXCLUDED.close,
+    sample_count = EXCLUDED.sample_count,
+    updated_at = NOW();
+
+-- name: ListRateCandles :many
+SELECT * FROM rate_candles
+WHERE base_currency = $1
+  AND quote_currency = $2
+  AND interval = $3
+  AND bucket_start >= sqlc.arg(start_time)
+  AND bucket_start <= sqlc.arg(end_time)
+ORDER BY bucket_start DESC
+LIMIT sqlc.arg(max_candles);
+
+-- name: GetRateSampleAt :one
+SELECT * FROM rate_samples
+WHERE base_currency = $1
+  AND quote_currency = $2
+  AND sampled_at <= sqlc.arg(at)
+ORDER BY sampled_at DESC
+LIMIT 1;
+
+-- name: GetRateCandleAt :one
+SELECT * FROM rate_candles
+WHERE base_currency = $1
+  AND quote_currency = $2
+  AND interval = $3
+  AND bucket_start <= sqlc.arg(at)
+ORDER BY bucket_start DESC
+LIMIT 1;
+
+-- name: DeleteRateSamplesBefore :execrows
+DELETE FROM rate_samples
+WHERE sampled_at < $1;
+
+-- name: DeleteRateCandlesBefore :execrows
+DELETE FROM rate_candles
+WHERE interval = $1 AND bucket_start < $2;
@@ -0,0 +1,30 @@
+-- name: InsertPublishedRate :one
+INSERT INTO published_rates (
+    base_currency,
+    quote_currency,
+    rate,
+    method,
+    source_count,
+    accepted_count,
+    sources,
+    published_at,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetLatestPublishedRate :one
+SELECT * FROM published_rates
+WHERE base_currency = $1 AND quote_currency = $2
+ORDER BY published_at DESC
+LIMIT 1;
+
+-- name: ListPublishedRates :many
+SELECT * FROM published_rates
+WHERE base_currency = $1 AND quote_currency = $2
+ORDER BY published_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: DeletePublishedRatesBefore :execrows
+DELETE FROM published_rates
+WHERE published_at < $1;
@@ -0,0 +1,83 @@
+-- name: CreateRateChangeRequest :one
+INSERT INTO rate_change_requests (
+    change_type,
+    target_id,
+    source_currency,
+    target_currency,
+    payload,
+    preview,
+    summary,
+    requested_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetRateChangeRequest :one
+SELECT * FROM rate_change_requests
+WHERE id = $1;
+
+-- name: GetRateChangeRequestForUpdate :one
+SELECT * FROM rate_change_requests
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListRateChangeRequests :many
+SELECT * FROM rate_change_requests
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY created_at DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountRateChangeRequests :one
+SELECT COUNT(*) FROM rate_change_requests
+WHERE (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status));
+
+-- name: ApproveRateChangeRequest :one
+UPDATE rate_change_requests
+SET status = 'approved', reviewed_by = $2, review_note = $3, reviewed_at = NOW(), applied_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: RejectRateChangeRequest :one
+UPDATE rate_change_requests
+SET status = 'rejected', reviewed_by = $2, review_note = $3, reviewed_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: CancelRateChangeRequest :one
+UPDATE rate_change_requests
+SET status = 'cancelled', updated_at = NOW()
+WHERE id = $1 AND requested_by = $2 AND status = 'pending'
+RETURNING *;
+
+-- name: FailRateChangeRequest :exec
+UPDATE rate_change_requests
+SET status = 'failed', reviewed_by = $2, failure_reason = $3, reviewed_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- name: RecordApprovedRateChange :one
+INSERT INTO rate_change_history (
+    source_currency,
+    target_currency,
+    base_rate,
+    adjusted_rate,
+    adjustment_amount,
+    rule_id,
+    rule_name,
+    rate_provider,
+    change_reason,
+    changed_by,
+    approved_by,
+    change_request_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: GetPairConversionVolume :one
+SELECT
+    COUNT(*)::bigint AS conversion_count,
+    COALESCE(SUM(source_amount), 0)::numeric AS source_volume
+FROM conversion_history
+WHERE source_currency = $1
+  AND target_currency = $2
+  AND status = 'success'
+  AND created_at >= $3;
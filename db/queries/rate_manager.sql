@@ -124,9 +124,13 @@ INSERT INTO rate_adjustment_rules (
     valid_until,
     is_active,
     created_by,
-    updated_by
+    updated_by,
+    channels,
+    time_windows,
+    schedule_timezone,
+    exclude_holidays
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
 ) RETURNING *;
 
 -- name: GetRateAdjustmentRuleByID :one
@@ -175,6 +179,10 @@ WHERE is_global_rule = TRUE
   AND target_currency = $2
   AND (valid_from IS NULL OR valid_from <= NOW())
   AND (valid_until IS NULL OR valid_until > NOW())
+  AND cardinality(channels) = 0
+  AND (time_windows IS NULL OR time_windows = '[]'::jsonb)
+  AND min_conversion_amount IS NULL
+  AND max_conversion_amount IS NULL
 LIMIT 1;
 
 -- name: GetActiveRuleForVIPLevel :one
@@ -202,10 +210,6 @@ WHERE r.deleted_at IS NULL
   AND r.is_active = TRUE
   AND r.source_currency = $1
   AND r.target_currency = $2
-  AND (r.valid_from IS NULL OR r.valid_from <= NOW())
-  AND (r.valid_until IS NULL OR r.valid_until > NOW())
-  AND (r.min_conversion_amount IS NULL OR r.min_conversion_amount <= $3)
-  AND (r.max_conversion_amount IS NULL OR r.max_conversion_amount >= $3)
   AND (
     r.is_global_rule = TRUE 
     OR r.vip_level_id = (
@@ -213,8 +217,7 @@ WHERE r.deleted_at IS NULL
         WHERE users.id = sqlc.arg('user_id') AND users.deleted_at IS NULL
     )
   )
-ORDER BY r.priority DESC, v.level_rank DESC NULLS LAST
-LIMIT 1;
+ORDER BY r.priority DESC, v.level_rank DESC NULLS LAST, r.created_at ASC, r.id ASC;
 
 -- name: UpdateRateAdjustmentRule :one
 UPDATE rate_adjustment_rules
@@ -230,6 +233,10 @@ SET
     valid_from = COALESCE(sqlc.narg('valid_from'), valid_from),
     valid_until = COALESCE(sqlc.narg('valid_until'), valid_until),
     is_active = COALESCE(sqlc.narg('is_active'), is_active),
+    channels = COALESCE(sqlc.narg('channels')::text[], channels),
+    time_windows = COALESCE(sqlc.narg('time_windows'), time_windows),
+    schedule_timezone = COALESCE(sqlc.narg('schedule_timezone'), schedule_timezone),
+    exclude_holidays = COALESCE(sqlc.narg('exclude_holidays'), exclude_holidays),
     updated_by = $2
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
@@ -576,4 +583,26 @@ SET total_conversion_volume = $2,
     total_transaction_volume = $3,
     current_vip_level_id = $4,
     updated_at = NOW()
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+-- =====================================================
+-- PUBLIC HOLIDAYS QUERIES
+-- =====================================================
+
+-- name: CreatePublicHoliday :one
+INSERT INTO public_holidays (
+    holiday_date,
+    name,
+    created_by
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ListPublicHolidays :many
+SELECT * FROM public_holidays
+WHERE holiday_date >= sqlc.arg(start_date)
+  AND holiday_date <= sqlc.arg(end_date)
+ORDER BY holiday_date ASC;
+
+-- name: DeletePublicHoliday :execrows
+DELETE FROM public_holidays
+WHERE id = $1;
@@ -0,0 +1,48 @@
+-- name: CreateRateQuote :one
+INSERT INTO rate_quotes (
+    id,
+    user_id,
+    purpose,
+    source_currency,
+    target_currency,
+    source_amount,
+    rate,
+    fees,
+    target_amount,
+    net_amount,
+    rate_provider,
+    signature,
+    expires_at,
+    mid_rate,
+    rule_id,
+    rule_name,
+    vip_level_id,
+    vip_level_name
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+) RETURNING *;
+
+-- name: GetRateQuote :one
+SELECT * FROM rate_quotes
+WHERE id = $1;
+
+-- name: GetActiveRateQuoteForQRCode :one
+SELECT * FROM rate_quotes
+WHERE qr_code_id = $1 AND status = 'active'
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: BindRateQuoteToQRCode :execrows
+UPDATE rate_quotes
+SET qr_code_id = $2
+WHERE id = $1 AND status = 'active' AND qr_code_id IS NULL;
+
+-- name: MarkRateQuoteUsed :execrows
+UPDATE rate_quotes
+SET status = 'used', used_at = NOW(), consumed_reference = $2
+WHERE id = $1 AND status = 'active' AND expires_at >= sqlc.arg(valid_at);
+
+-- name: ExpireRateQuote :exec
+UPDATE rate_quotes
+SET status = 'expired'
+WHERE id = $1 AND status = 'active';
@@ -171,6 +171,9 @@ SELECT * FROM conversion_history
 WHERE conversion_rule_id = $1
 ORDER BY executed_at DESC;
 
+-- name: GetConversionHistoryByTransactionID :one
+SELECT * FROM conversion_history WHERE transaction_id = $1;
+
 -- name: GetConversionHistoryStats :one
 SELECT 
     COUNT(*) as total_conversions,
@@ -2,9 +2,9 @@
 INSERT INTO card_plans (
     name, description, creation_fee, monthly_maintenance_fee,
     monthly_spending_limit, transaction_limit, daily_spending_limit,
-    max_cards_per_user, failed_tx_count_before_block, card_limit
+    max_cards_per_user, failed_tx_count_before_block, card_limit, usage_type
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 ) RETURNING *;
 
 -- name: GetCardPlan :one
@@ -37,6 +37,7 @@ SET
     is_active = COALESCE(sqlc.narg('is_active'), is_active),
     card_limit = COALESCE(sqlc.narg('card_limit'), card_limit),
     failed_tx_count_before_block = COALESCE(sqlc.narg('failed_tx_count_before_block'), failed_tx_count_before_block),
+    usage_type = COALESCE(sqlc.narg('usage_type'), usage_type),
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
@@ -0,0 +1,155 @@
+-- name: CreateAutoSaveRule :one
+INSERT INTO vault_auto_save_rules (
+    user_id,
+    vault_id,
+    rule_type,
+    triggers,
+    round_to,
+    percentage,
+    max_per_transaction
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetAutoSaveRule :one
+SELECT * FROM vault_auto_save_rules
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListUserAutoSaveRules :many
+SELECT
+    r.id,
+    r.user_id,
+    r.vault_id,
+    r.rule_type,
+    r.triggers,
+    r.round_to,
+    r.percentage,
+    r.max_per_transaction,
+    r.is_active,
+    r.created_at,
+    r.updated_at,
+    v.vault_name,
+    v.currency,
+    COALESCE(SUM(a.amount) FILTER (WHERE a.status = 'deposited'), 0)::text AS total_saved,
+    COALESCE(SUM(a.amount) FILTER (WHERE a.status IN ('pending', 'processing')), 0)::text AS pending_amount
+FROM vault_auto_save_rules r
+JOIN vault_savings v ON v.id = r.vault_id
+LEFT JOIN vault_auto_save_accruals a ON a.rule_id = r.id
+WHERE r.user_id = $1
+GROUP BY r.id, v.vault_name, v.currency
+ORDER BY r.created_at DESC;
+
+-- Active rules of the user that fire on trigger, for vaults in the currency the money moved in
+-- name: GetAutoSaveRulesForTrigger :many
+SELECT r.id, r.user_id, r.vault_id, r.rule_type, r.triggers, r.round_to, r.percentage, r.max_per_transaction, r.is_active, r.created_at, r.updated_at
+FROM vault_auto_save_rules r
+JOIN vault_savings v ON v.id = r.vault_id
+WHERE r.user_id = $1
+  AND r.is_active = TRUE
+  AND v.status = 'active'
+  AND v.vault_type = 'flexible'
+  AND v.currency = $2
+  AND sqlc.arg(trigger)::text = ANY(r.triggers);
+
+-- name: UpdateAutoSaveRule :one
+UPDATE vault_auto_save_rules
+SET triggers = $3,
+    round_to = $4,
+    percentage = $5,
+    max_per_transaction = $6,
+    is_active = $7,
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteAutoSaveRule :execrows
+DELETE FROM vault_auto_save_rules
+WHERE id = $1 AND user_id = $2;
+
+-- Returns no row when the rule already saved from this transaction
+-- name: CreateAutoSaveAccrual :one
+INSERT INTO vault_auto_save_accruals (
+    rule_id,
+    user_id,
+    vault_id,
+    source,
+    source_transaction_id,
+    source_amount,
+    amount,
+    currency
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (rule_id, source_transaction_id) DO NOTHING
+RETURNING *;
+
+-- Rules with accruals from before the cutoff still waiting to be deposited
+-- name: GetDueAutoSaveBatches :many
+SELECT rule_id, user_id, vault_id, currency
+FROM vault_auto_save_accruals
+WHERE status = 'pending' AND created_at < $1
+GROUP BY rule_id, user_id, vault_id, currency
+LIMIT $2;
+
+-- Takes the rule's pending accruals from before the cutoff for one deposit
+-- name: ClaimAutoSaveAccruals :many
+UPDATE vault_auto_save_accruals
+SET status = 'processing',
+    batch_reference = $3,
+    processed_at = NOW()
+WHERE rule_id = $1 AND status = 'pending' AND created_at < $2
+RETURNING *;
+
+-- name: CompleteAutoSaveBatch :exec
+UPDATE vault_auto_save_accruals
+SET status = 'deposited',
+    processed_at = NOW()
+WHERE batch_reference = $1 AND status = 'processing';
+
+-- name: FailAutoSaveBatch :exec
+UPDATE vault_auto_save_accruals
+SET status = 'failed',
+    failure_reason = $2,
+    processed_at = NOW()
+WHERE batch_reference = $1 AND status = 'processing';
+
+-- Puts a batch back in the queue when its deposit never happened
+-- name: ReleaseAutoSaveBatch :exec
+UPDATE vault_auto_save_accruals
+SET status = 'pending',
+    batch_reference = NULL
+WHERE batch_reference = $1 AND status = 'processing';
+
+-- Batches left in processing by a run that died before finishing
+-- name: GetStaleAutoSaveBatches :many
+SELECT DISTINCT batch_reference, rule_id
+FROM vault_auto_save_accruals
+WHERE status = 'processing' AND processed_at < $1;
+
+-- What each user auto-saved in the period, per currency, for users not yet sent that month's summary
+-- name: GetAutoSaveMonthlyTotals :many
+SELECT
+    a.user_id,
+    a.currency,
+    COALESCE(SUM(a.amount), 0)::text AS total_saved,
+    COUNT(*) AS transactions
+FROM vault_auto_save_accruals a
+WHERE a.status = 'deposited'
+  AND a.processed_at >= sqlc.arg(period_start)::timestamptz
+  AND a.processed_at < sqlc.arg(period_end)::timestamptz
+  AND NOT EXISTS (
+      SELECT 1 FROM vault_auto_save_summaries s
+      WHERE s.user_id = a.user_id AND s.period_start = (sqlc.arg(period_start)::timestamptz)::date
+  )
+GROUP BY a.user_id, a.currency
+ORDER BY a.user_id, a.currency;
+
+-- Returns 0 when the summary was already sent
+-- name: MarkAutoSaveSummarySent :execrows
+INSERT INTO vault_auto_save_summaries (
+    user_id,
+    period_start
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id, period_start) DO NOTHING;
@@ -0,0 +1,225 @@
+-- name: CreateVaultGroup :one
+INSERT INTO vault_groups (
+    vault_id,
+    owner_id,
+    withdrawal_rule,
+    contribution_amount,
+    rotation_interval_days,
+    next_payout_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetVaultGroup :one
+SELECT * FROM vault_groups
+WHERE vault_id = $1;
+
+-- name: LockVaultGroupForUpdate :one
+SELECT * FROM vault_groups
+WHERE vault_id = $1
+FOR UPDATE;
+
+-- name: GetDueGroupRotations :many
+SELECT * FROM vault_groups
+WHERE withdrawal_rule = 'rotating'
+  AND next_payout_at <= NOW()
+ORDER BY next_payout_at ASC
+LIMIT $1;
+
+-- name: AdvanceGroupRotation :exec
+UPDATE vault_groups
+SET next_payout_position = $2,
+    next_payout_at = $3,
+    updated_at = NOW()
+WHERE vault_id = $1;
+
+-- Invites a user, or re-invites one who declined, left or was removed. Returns no row when the
+-- user is already invited or active.
+-- name: AddVaultGroupMember :one
+INSERT INTO vault_group_members (
+    vault_id,
+    user_id,
+    role,
+    status,
+    invited_by,
+    payout_position,
+    joined_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (vault_id, user_id) DO UPDATE
+SET status = EXCLUDED.status,
+    invited_by = EXCLUDED.invited_by,
+    updated_at = NOW()
+WHERE vault_group_members.status IN ('declined', 'left', 'removed')
+RETURNING *;
+
+-- name: GetVaultGroupMember :one
+SELECT * FROM vault_group_members
+WHERE vault_id = $1 AND user_id = $2;
+
+-- name: ActivateVaultGroupMember :one
+UPDATE vault_group_members
+SET status = 'active',
+    payout_position = $3,
+    joined_at = NOW(),
+    updated_at = NOW()
+WHERE vault_id = $1 AND user_id = $2 AND status = 'invited'
+RETURNING *;
+
+-- name: UpdateVaultGroupMemberStatus :execrows
+UPDATE vault_group_members
+SET status = sqlc.arg(new_status),
+    updated_at = NOW()
+WHERE vault_id = sqlc.arg(vault_id)
+  AND user_id = sqlc.arg(user_id)
+  AND status = sqlc.arg(old_status);
+
+-- name: NextVaultGroupPayoutPosition :one
+SELECT (COALESCE(MAX(payout_position), 0) + 1)::int AS next_position
+FROM vault_group_members
+WHERE vault_id = $1;
+
+-- name: CountActiveVaultGroupMembers :one
+SELECT COUNT(*) FROM vault_group_members
+WHERE vault_id = $1 AND status = 'active';
+
+-- name: ListVaultGroupMembers :many
+SELECT
+    m.id,
+    m.vault_id,
+    m.user_id,
+    m.role,
+    m.status,
+    m.payout_position,
+    m.total_contributed,
+    m.total_received,
+    m.joined_at,
+    m.created_at,
+    u.user_tag,
+    u.first_name,
+    u.last_name
+FROM vault_group_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.vault_id = $1
+ORDER BY m.payout_position ASC NULLS LAST, m.created_at ASC;
+
+-- name: AddVaultGroupContribution :exec
+UPDATE vault_group_members
+SET total_contributed = total_contributed + $3,
+    updated_at = NOW()
+WHERE vault_id = $1 AND user_id = $2;
+
+-- name: AddVaultGroupPayout :exec
+UPDATE vault_group_members
+SET total_received = total_received + $3,
+    updated_at = NOW()
+WHERE vault_id = $1 AND user_id = $2;
+
+-- Group vaults the user is an active member of, including the ones they own
+-- name: ListUserGroupVaults :many
+SELECT * FROM vault_savings
+WHERE id IN (
+    SELECT vault_id FROM vault_group_members
+    WHERE user_id = $1 AND status = 'active'
+)
+ORDER BY created_at DESC;
+
+-- name: ListUserGroupInvites :many
+SELECT
+    m.vault_id,
+    vs.vault_name,
+    vs.currency,
+    vs.goal_amount,
+    g.withdrawal_rule,
+    g.contribution_amount,
+    inviter.user_tag AS invited_by_tag,
+    m.created_at
+FROM vault_group_members m
+JOIN vault_groups g ON g.vault_id = m.vault_id
+JOIN vault_savings vs ON vs.id = m.vault_id
+LEFT JOIN users inviter ON inviter.id = m.invited_by
+WHERE m.user_id = $1 AND m.status = 'invited' AND vs.status = 'active'
+ORDER BY m.created_at DESC;
+
+-- name: CreateGroupWithdrawalRequest :one
+INSERT INTO vault_group_withdrawal_requests (
+    vault_id,
+    requested_by,
+    amount,
+    reason,
+    approvals_required,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetGroupWithdrawalRequestForUpdate :one
+SELECT * FROM vault_group_withdrawal_requests
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetPendingGroupWithdrawalRequest :one
+SELECT * FROM vault_group_withdrawal_requests
+WHERE vault_id = $1 AND status = 'pending';
+
+-- name: ResolveGroupWithdrawalRequest :exec
+UPDATE vault_group_withdrawal_requests
+SET status = $2,
+    vault_transaction_id = $3,
+    resolved_at = NOW()
+WHERE id = $1 AND status = 'pending';
+
+-- name: ExpireGroupWithdrawalRequests :many
+UPDATE vault_group_withdrawal_requests
+SET status = 'expired',
+    resolved_at = NOW()
+WHERE status = 'pending' AND expires_at <= NOW()
+RETURNING *;
+
+-- Returns 0 when the member has already voted
+-- name: CastGroupWithdrawalVote :execrows
+INSERT INTO vault_group_withdrawal_votes (
+    request_id,
+    user_id,
+    approve
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (request_id, user_id) DO NOTHING;
+
+-- name: CountGroupWithdrawalVotes :one
+SELECT
+    COUNT(*) FILTER (WHERE approve) AS approvals,
+    COUNT(*) FILTER (WHERE NOT approve) AS rejections
+FROM vault_group_withdrawal_votes
+WHERE request_id = $1;
+
+-- name: CreateVaultGroupActivity :exec
+INSERT INTO vault_group_activity (
+    vault_id,
+    actor_id,
+    activity_type,
+    amount,
+    message,
+    metadata
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+);
+
+-- name: ListVaultGroupActivity :many
+SELECT
+    a.id,
+    a.vault_id,
+    a.actor_id,
+    a.activity_type,
+    a.amount,
+    a.message,
+    a.metadata,
+    a.created_at,
+    u.user_tag AS actor_tag
+FROM vault_group_activity a
+LEFT JOIN users u ON u.id = a.actor_id
+WHERE a.vault_id = $1
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT $2 OFFSET $3;
@@ -18,9 +18,10 @@ INSERT INTO vault_savings (
     status,
     vault_type,
     category,
+    funding_currency,
     next_yield_calculation
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW() + INTERVAL '1 day'
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NOW() + INTERVAL '1 day'
 ) RETURNING *;
 
 -- name: GetVaultGoalByID :one
@@ -46,6 +47,15 @@ SELECT * FROM vault_savings
 WHERE user_id = $1 AND currency = $2 AND status != 'cancelled'
 ORDER BY created_at DESC;
 
+-- name: VaultNameTaken :one
+SELECT EXISTS (
+    SELECT 1 FROM vault_savings
+    WHERE user_id = $1
+      AND LOWER(vault_name) = LOWER(sqlc.arg(vault_name))
+      AND status != 'cancelled'
+      AND id != sqlc.arg(exclude_id)
+);
+
 -- name: UpdateVaultBalance :exec
 UPDATE vault_savings
 SET current_balance = $2,
@@ -125,7 +135,10 @@ ORDER BY next_auto_save ASC;
 -- name: GetVaultsDueForYieldCalculation :many
 SELECT * FROM vault_savings
 WHERE status = 'active'
-  AND current_balance > 0
+  AND (current_balance > 0 OR EXISTS (
+      SELECT 1 FROM vault_yield_accruals a
+      WHERE a.vault_id = vault_savings.id AND a.yield_id IS NULL
+  ))
   AND (next_yield_calculation IS NULL OR next_yield_calculation <= NOW())
 ORDER BY next_yield_calculation ASC NULLS FIRST
 LIMIT $1;
@@ -169,6 +182,19 @@ SELECT
 FROM vault_savings
 WHERE user_id = $1 AND status != 'cancelled';
 
+-- name: GetUserVaultBalancesByCurrency :many
+SELECT
+    currency,
+    COUNT(*) as vault_count,
+    COUNT(*) FILTER (WHERE status = 'active') as active_vaults,
+    COUNT(*) FILTER (WHERE status = 'completed') as completed_vaults,
+    COALESCE(SUM(current_balance), 0)::text as total_balance,
+    COALESCE(SUM(total_yield_earned), 0)::text as total_yield_earned
+FROM vault_savings
+WHERE user_id = $1 AND status != 'cancelled'
+GROUP BY currency
+ORDER BY currency;
+
 -- name: DeleteVaultGoal :exec
 UPDATE vault_savings
 SET status = 'cancelled',
@@ -367,9 +393,15 @@ INSERT INTO vault_yield_configs (
     is_active,
     effective_from,
     effective_until,
-    notes
+    notes,
+    product_type,
+    lock_term_days,
+    early_withdrawal_policy,
+    early_withdrawal_penalty_rate,
+    apy_tiers,
+    withholding_tax_rate
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 ) RETURNING *;
 
 -- name: GetYieldConfigByID :one
@@ -379,6 +411,7 @@ WHERE id = $1;
 -- name: GetActiveYieldConfigByCurrency :one
 SELECT * FROM vault_yield_configs
 WHERE currency = $1
+  AND product_type = 'flexible'
   AND is_active = TRUE
   AND effective_from <= NOW()
   AND (effective_until IS NULL OR effective_until > NOW())
@@ -402,6 +435,10 @@ SET apy_rate = COALESCE(sqlc.narg('apy_rate'), apy_rate),
     is_active = COALESCE(sqlc.narg('is_active'), is_active),
     effective_until = COALESCE(sqlc.narg('effective_until'), effective_until),
     notes = COALESCE(sqlc.narg('notes'), notes),
+    early_withdrawal_policy = COALESCE(sqlc.narg('early_withdrawal_policy'), early_withdrawal_policy),
+    early_withdrawal_penalty_rate = COALESCE(sqlc.narg('early_withdrawal_penalty_rate'), early_withdrawal_penalty_rate),
+    apy_tiers = COALESCE(sqlc.narg('apy_tiers'), apy_tiers),
+    withholding_tax_rate = COALESCE(sqlc.narg('withholding_tax_rate'), withholding_tax_rate),
     updated_at = NOW()
 WHERE id = $1;
 
@@ -0,0 +1,96 @@
+-- name: CreateSweepRule :one
+INSERT INTO vault_sweep_rules (
+    user_id,
+    vault_id,
+    currency,
+    rule_type,
+    target_balance,
+    min_amount,
+    run_time,
+    next_run_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetSweepRule :one
+SELECT * FROM vault_sweep_rules
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListUserSweepRules :many
+SELECT
+    r.id,
+    r.user_id,
+    r.vault_id,
+    r.currency,
+    r.rule_type,
+    r.target_balance,
+    r.min_amount,
+    r.run_time,
+    r.is_active,
+    r.next_run_at,
+    r.last_run_at,
+    r.created_at,
+    r.updated_at,
+    v.vault_name
+FROM vault_sweep_rules r
+JOIN vault_savings v ON v.id = r.vault_id
+WHERE r.user_id = $1
+ORDER BY r.created_at DESC;
+
+-- Active rules on the user's wallet in this currency
+-- name: GetActiveSweepRulesByCurrency :many
+SELECT * FROM vault_sweep_rules
+WHERE user_id = $1 AND currency = $2 AND is_active = TRUE;
+
+-- name: UpdateSweepRule :one
+UPDATE vault_sweep_rules
+SET target_balance = $3,
+    min_amount = $4,
+    run_time = $5,
+    is_active = $6,
+    next_run_at = $7,
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteSweepRule :execrows
+DELETE FROM vault_sweep_rules
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetDueSweepRules :many
+SELECT * FROM vault_sweep_rules
+WHERE is_active = TRUE AND next_run_at <= $1
+ORDER BY next_run_at ASC
+LIMIT $2;
+
+-- Moves the rule on to its next run. Returns 0 when another replica already took this run.
+-- name: ClaimSweepRule :execrows
+UPDATE vault_sweep_rules
+SET next_run_at = $3,
+    last_run_at = NOW()
+WHERE id = $1 AND next_run_at = $2 AND is_active = TRUE;
+
+-- name: CreateSweepRun :one
+INSERT INTO vault_sweep_runs (
+    rule_id,
+    user_id,
+    vault_id,
+    rule_type,
+    currency,
+    wallet_balance_before,
+    vault_balance_before,
+    target_balance,
+    amount,
+    status,
+    vault_transaction_id,
+    reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: ListUserSweepRuns :many
+SELECT * FROM vault_sweep_runs
+WHERE user_id = $1
+  AND (sqlc.narg(rule_id)::uuid IS NULL OR rule_id = sqlc.narg(rule_id))
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
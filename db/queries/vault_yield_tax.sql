@@ -0,0 +1,99 @@
+-- ============================================================================
+-- VAULT YIELD ACCRUALS
+-- ============================================================================
+
+-- A day already accrued is left alone, so a rerun never earns twice
+-- name: CreateYieldAccrual :exec
+INSERT INTO vault_yield_accruals (
+    vault_id,
+    user_id,
+    accrual_date,
+    period_start,
+    period_end,
+    balance,
+    apy_rate,
+    amount
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (vault_id, accrual_date) DO NOTHING;
+
+-- name: ListPendingYieldAccruals :many
+SELECT * FROM vault_yield_accruals
+WHERE vault_id = $1 AND yield_id IS NULL
+ORDER BY accrual_date ASC;
+
+-- name: CapitaliseYieldAccruals :exec
+UPDATE vault_yield_accruals
+SET yield_id = $2
+WHERE vault_id = $1 AND yield_id IS NULL;
+
+-- ============================================================================
+-- WITHHOLDING TAX
+-- ============================================================================
+
+-- name: CreateWithholdingTaxEntry :one
+INSERT INTO vault_withholding_tax_entries (
+    yield_id,
+    user_id,
+    vault_id,
+    currency,
+    gross_yield,
+    tax_rate,
+    tax_amount,
+    net_yield,
+    tax_year
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- Balance of the tax liability account per currency and tax year, split by whether it has been remitted
+-- name: GetWithholdingTaxLiability :many
+SELECT
+    currency,
+    tax_year,
+    status,
+    COUNT(*) AS entries,
+    COALESCE(SUM(gross_yield), 0)::text AS gross_yield,
+    COALESCE(SUM(tax_amount), 0)::text AS tax_amount
+FROM vault_withholding_tax_entries
+WHERE created_at >= $1 AND created_at < $2
+GROUP BY currency, tax_year, status
+ORDER BY tax_year DESC, currency, status;
+
+-- Marks tax withheld before the cutoff as paid over to the tax authority
+-- name: RemitWithholdingTax :execrows
+UPDATE vault_withholding_tax_entries
+SET status = 'remitted',
+    remittance_reference = $4,
+    remitted_at = NOW()
+WHERE status = 'accrued'
+  AND currency = $1
+  AND tax_year = $2
+  AND created_at < $3;
+
+-- ============================================================================
+-- YIELD STATEMENTS
+-- ============================================================================
+
+-- Every yield credited to the vault in the tax year, gross, tax withheld and net.
+-- Yields credited before withholding existed have no entry and were paid gross.
+-- name: GetVaultYieldStatement :many
+SELECT
+    vy.id,
+    vy.calculation_period_start,
+    vy.calculation_period_end,
+    vy.vault_balance_snapshot,
+    vy.yield_rate,
+    vy.yield_amount AS gross_yield,
+    COALESCE(w.tax_rate, 0)::text AS tax_rate,
+    COALESCE(w.tax_amount, 0)::text AS tax_amount,
+    (vy.yield_amount - COALESCE(w.tax_amount, 0))::text AS net_yield,
+    vy.credited_at
+FROM vault_yields vy
+LEFT JOIN vault_withholding_tax_entries w ON w.yield_id = vy.id
+WHERE vy.vault_id = $1
+  AND vy.status = 'credited'
+  AND vy.credited_at >= make_date(sqlc.arg(tax_year)::int, 1, 1)
+  AND vy.credited_at < make_date(sqlc.arg(tax_year)::int + 1, 1, 1)
+ORDER BY vy.credited_at ASC;
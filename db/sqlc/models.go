@@ -152,6 +152,52 @@ type AgentMetric struct {
 	CreatedAt                 time.Time      `json:"created_at"`
 }
 
+type AlertConversionAction struct {
+	AlertID        uuid.UUID `json:"alert_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	SourceCurrency string    `json:"source_currency"`
+	TargetCurrency string    `json:"target_currency"`
+	SourceWalletID uuid.UUID `json:"source_wallet_id"`
+	TargetWalletID uuid.UUID `json:"target_wallet_id"`
+	Amount         string    `json:"amount"`
+	AuthorizedAt   time.Time `json:"authorized_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type AlertConversionRun struct {
+	ID                  uuid.UUID      `json:"id"`
+	TriggerID           int64          `json:"trigger_id"`
+	AlertID             uuid.UUID      `json:"alert_id"`
+	UserID              uuid.UUID      `json:"user_id"`
+	Status              string         `json:"status"`
+	Reason              sql.NullString `json:"reason"`
+	ConversionHistoryID uuid.NullUUID  `json:"conversion_history_id"`
+	TransactionID       uuid.NullUUID  `json:"transaction_id"`
+	SourceCurrency      string         `json:"source_currency"`
+	TargetCurrency      string         `json:"target_currency"`
+	SourceAmount        string         `json:"source_amount"`
+	AmountUsd           sql.NullString `json:"amount_usd"`
+	TargetAmount        sql.NullString `json:"target_amount"`
+	ExecutedRate        sql.NullString `json:"executed_rate"`
+	CreatedAt           time.Time      `json:"created_at"`
+}
+
+type AlertTriggerDelivery struct {
+	ID            int64          `json:"id"`
+	TriggerID     int64          `json:"trigger_id"`
+	AlertID       uuid.UUID      `json:"alert_id"`
+	UserID        uuid.UUID      `json:"user_id"`
+	Channel       string         `json:"channel"`
+	Status        string         `json:"status"`
+	Attempts      int32          `json:"attempts"`
+	LastError     sql.NullString `json:"last_error"`
+	NextAttemptAt sql.NullTime   `json:"next_attempt_at"`
+	DeliveredAt   sql.NullTime   `json:"delivered_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
 // Historical log of when alerts were triggered
 type AlertTriggerHistory struct {
 	ID                    int64          `json:"id"`
@@ -167,6 +213,8 @@ type AlertTriggerHistory struct {
 	InAppNotificationSent sql.NullBool   `json:"in_app_notification_sent"`
 	NotificationError     sql.NullString `json:"notification_error"`
 	CreatedAt             time.Time      `json:"created_at"`
+	Title                 sql.NullString `json:"title"`
+	Body                  sql.NullString `json:"body"`
 }
 
 type Attachment struct {
@@ -330,6 +378,21 @@ type Badge struct {
 	UpdatedAt          time.Time      `json:"updated_at"`
 }
 
+type BalanceHold struct {
+	ID          uuid.UUID    `json:"id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	WalletID    uuid.UUID    `json:"wallet_id"`
+	Currency    string       `json:"currency"`
+	Amount      string       `json:"amount"`
+	Remaining   string       `json:"remaining"`
+	Purpose     string       `json:"purpose"`
+	ReferenceID uuid.UUID    `json:"reference_id"`
+	Status      string       `json:"status"`
+	ReleasedAt  sql.NullTime `json:"released_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
 // User bank accounts for withdrawals and QR payments - users can have multiple accounts with one default
 type BankAccount struct {
 	ID                    uuid.UUID      `json:"id"`
@@ -410,11 +473,85 @@ type CardBillingHistory struct {
 	Currency           string         `json:"currency"`
 	BillingPeriodStart time.Time      `json:"billing_period_start"`
 	BillingPeriodEnd   time.Time      `json:"billing_period_end"`
-	SourceWalletID     uuid.UUID      `json:"source_wallet_id"`
+	SourceWalletID     uuid.NullUUID  `json:"source_wallet_id"`
 	Status             string         `json:"status"`
 	FailureReason      sql.NullString `json:"failure_reason"`
 	CreatedAt          time.Time      `json:"created_at"`
 	ProcessedAt        sql.NullTime   `json:"processed_at"`
+	ChargeSource       sql.NullString `json:"charge_source"`
+	AttemptCount       int32          `json:"attempt_count"`
+	NextRetryAt        sql.NullTime   `json:"next_retry_at"`
+	ProviderReference  sql.NullString `json:"provider_reference"`
+	BillingRunID       uuid.NullUUID  `json:"billing_run_id"`
+}
+
+type CardBillingRun struct {
+	ID            uuid.UUID      `json:"id"`
+	TriggerType   string         `json:"trigger_type"`
+	TriggeredBy   uuid.NullUUID  `json:"triggered_by"`
+	Status        string         `json:"status"`
+	CardsDue      int32          `json:"cards_due"`
+	ChargedCount  int32          `json:"charged_count"`
+	ChargedAmount string         `json:"charged_amount"`
+	PendingCount  int32          `json:"pending_count"`
+	FailedCount   int32          `json:"failed_count"`
+	FrozenCount   int32          `json:"frozen_count"`
+	WaivedCount   int32          `json:"waived_count"`
+	SkippedCount  int32          `json:"skipped_count"`
+	RemindersSent int32          `json:"reminders_sent"`
+	Error         sql.NullString `json:"error"`
+	StartedAt     time.Time      `json:"started_at"`
+	FinishedAt    sql.NullTime   `json:"finished_at"`
+}
+
+type CardDispute struct {
+	ID                        uuid.UUID      `json:"id"`
+	CardTransactionID         uuid.UUID      `json:"card_transaction_id"`
+	CardID                    uuid.UUID      `json:"card_id"`
+	UserID                    uuid.UUID      `json:"user_id"`
+	Reason                    string         `json:"reason"`
+	Description               string         `json:"description"`
+	Amount                    string         `json:"amount"`
+	Currency                  string         `json:"currency"`
+	Status                    string         `json:"status"`
+	TicketID                  sql.NullInt64  `json:"ticket_id"`
+	AssignedTo                uuid.NullUUID  `json:"assigned_to"`
+	ProviderReference         sql.NullString `json:"provider_reference"`
+	ReviewDueAt               time.Time      `json:"review_due_at"`
+	ResolutionDueAt           time.Time      `json:"resolution_due_at"`
+	ReviewBreachAlertedAt     sql.NullTime   `json:"review_breach_alerted_at"`
+	ResolutionBreachAlertedAt sql.NullTime   `json:"resolution_breach_alerted_at"`
+	CreditWalletID            uuid.NullUUID  `json:"credit_wallet_id"`
+	CreditTransactionID       uuid.NullUUID  `json:"credit_transaction_id"`
+	CreditedAt                sql.NullTime   `json:"credited_at"`
+	ReversalTransactionID     uuid.NullUUID  `json:"reversal_transaction_id"`
+	ReversalShortfall         string         `json:"reversal_shortfall"`
+	ResolutionNote            sql.NullString `json:"resolution_note"`
+	ResolvedBy                uuid.NullUUID  `json:"resolved_by"`
+	ResolvedAt                sql.NullTime   `json:"resolved_at"`
+	CreatedAt                 time.Time      `json:"created_at"`
+	UpdatedAt                 time.Time      `json:"updated_at"`
+}
+
+type CardDisputeEvent struct {
+	ID         int64          `json:"id"`
+	DisputeID  uuid.UUID      `json:"dispute_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	ActorID    uuid.NullUUID  `json:"actor_id"`
+	Note       sql.NullString `json:"note"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type CardDisputeEvidence struct {
+	ID         int64     `json:"id"`
+	DisputeID  uuid.UUID `json:"dispute_id"`
+	UploadedBy uuid.UUID `json:"uploaded_by"`
+	FileUrl    string    `json:"file_url"`
+	FileName   string    `json:"file_name"`
+	FileSize   int32     `json:"file_size"`
+	MimeType   string    `json:"mime_type"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type CardFundingHistory struct {
@@ -452,6 +589,23 @@ type CardPlan struct {
 	CreatedAt                time.Time      `json:"created_at"`
 	UpdatedAt                time.Time      `json:"updated_at"`
 	DeletedAt                sql.NullTime   `json:"deleted_at"`
+	UsageType                string         `json:"usage_type"`
+}
+
+type CardSpendingControl struct {
+	CardID              uuid.UUID      `json:"card_id"`
+	UserID              uuid.UUID      `json:"user_id"`
+	BlockedCategories   []string       `json:"blocked_categories"`
+	OnlineOnly          bool           `json:"online_only"`
+	AtmEnabled          bool           `json:"atm_enabled"`
+	TransactionLimit    sql.NullString `json:"transaction_limit"`
+	DailyLimit          sql.NullString `json:"daily_limit"`
+	MonthlyLimit        sql.NullString `json:"monthly_limit"`
+	ConsecutiveDeclines int32          `json:"consecutive_declines"`
+	LastViolationReason sql.NullString `json:"last_violation_reason"`
+	LastViolationAt     sql.NullTime   `json:"last_violation_at"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
 }
 
 type CardSpendingSummary struct {
@@ -492,6 +646,22 @@ type CardTransaction struct {
 	CreatedAt               time.Time             `json:"created_at"`
 }
 
+type CardUsageLock struct {
+	CardID                  uuid.UUID      `json:"card_id"`
+	UserID                  uuid.UUID      `json:"user_id"`
+	UsageType               string         `json:"usage_type"`
+	LockedMerchant          sql.NullString `json:"locked_merchant"`
+	LockedMerchantDisplay   sql.NullString `json:"locked_merchant_display"`
+	LockedAt                sql.NullTime   `json:"locked_at"`
+	UsedByCardTransactionID uuid.NullUUID  `json:"used_by_card_transaction_id"`
+	TerminationStatus       sql.NullString `json:"termination_status"`
+	UnloadReference         sql.NullString `json:"unload_reference"`
+	UnloadAmount            sql.NullString `json:"unload_amount"`
+	FailureReason           sql.NullString `json:"failure_reason"`
+	CreatedAt               time.Time      `json:"created_at"`
+	UpdatedAt               time.Time      `json:"updated_at"`
+}
+
 type Category struct {
 	ID         int32  `json:"id"`
 	CategoryID int64  `json:"category_id"`
@@ -541,6 +711,45 @@ type ConversionHistory struct {
 	CreatedAt                time.Time      `json:"created_at"`
 }
 
+type ConversionOrder struct {
+	ID             uuid.UUID      `json:"id"`
+	UserID         uuid.UUID      `json:"user_id"`
+	SourceCurrency string         `json:"source_currency"`
+	TargetCurrency string         `json:"target_currency"`
+	SourceWalletID uuid.UUID      `json:"source_wallet_id"`
+	TargetWalletID uuid.UUID      `json:"target_wallet_id"`
+	LimitRate      string         `json:"limit_rate"`
+	SourceAmount   string         `json:"source_amount"`
+	FilledAmount   string         `json:"filled_amount"`
+	ReceivedAmount string         `json:"received_amount"`
+	FeesPaid       string         `json:"fees_paid"`
+	TimeInForce    string         `json:"time_in_force"`
+	ExpiresAt      sql.NullTime   `json:"expires_at"`
+	HoldID         uuid.UUID      `json:"hold_id"`
+	Status         string         `json:"status"`
+	Label          sql.NullString `json:"label"`
+	LastFilledAt   sql.NullTime   `json:"last_filled_at"`
+	ClosedAt       sql.NullTime   `json:"closed_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+type ConversionOrderFill struct {
+	ID                  uuid.UUID     `json:"id"`
+	OrderID             uuid.UUID     `json:"order_id"`
+	ConversionHistoryID uuid.NullUUID `json:"conversion_history_id"`
+	TransactionID       uuid.NullUUID `json:"transaction_id"`
+	SourceCurrency      string        `json:"source_currency"`
+	TargetCurrency      string        `json:"target_currency"`
+	SourceAmount        string        `json:"source_amount"`
+	ExecutedRate        string        `json:"executed_rate"`
+	TargetAmount        string        `json:"target_amount"`
+	Fees                string        `json:"fees"`
+	NetAmount           string        `json:"net_amount"`
+	SourceAmountUsd     string        `json:"source_amount_usd"`
+	CreatedAt           time.Time     `json:"created_at"`
+}
+
 // User-defined automated conversion rules with rate-based and scheduled triggers
 type ConversionRule struct {
 	ID                  uuid.UUID      `json:"id"`
@@ -595,6 +804,29 @@ type CryptoAddress struct {
 	UpdatedAt  time.Time      `json:"updated_at"`
 }
 
+type CryptoTagTransfer struct {
+	ID                  uuid.UUID      `json:"id"`
+	Reference           string         `json:"reference"`
+	SenderID            uuid.UUID      `json:"sender_id"`
+	RecipientID         uuid.UUID      `json:"recipient_id"`
+	SenderWalletID      uuid.UUID      `json:"sender_wallet_id"`
+	RecipientWalletID   uuid.UUID      `json:"recipient_wallet_id"`
+	SenderTag           string         `json:"sender_tag"`
+	RecipientTag        string         `json:"recipient_tag"`
+	Currency            string         `json:"currency"`
+	Amount              string         `json:"amount"`
+	Fee                 string         `json:"fee"`
+	AmountUsd           string         `json:"amount_usd"`
+	DebitTransactionID  uuid.NullUUID  `json:"debit_transaction_id"`
+	CreditTransactionID uuid.NullUUID  `json:"credit_transaction_id"`
+	RiskScore           int32          `json:"risk_score"`
+	RiskFlags           []string       `json:"risk_flags"`
+	Description         sql.NullString `json:"description"`
+	Status              string         `json:"status"`
+	FailureReason       sql.NullString `json:"failure_reason"`
+	CreatedAt           time.Time      `json:"created_at"`
+}
+
 // Metadata for cryptocurrency transactions
 type CryptoTransactionMetadatum struct {
 	ID                   uuid.UUID      `json:"id"`
@@ -680,6 +912,48 @@ type DataAirtimePurchaseMetadatum struct {
 	Date          time.Time      `json:"date"`
 }
 
+type DcaPlan struct {
+	ID                      uuid.UUID      `json:"id"`
+	UserID                  uuid.UUID      `json:"user_id"`
+	SourceCurrency          string         `json:"source_currency"`
+	TargetCurrency          string         `json:"target_currency"`
+	SourceWalletID          uuid.UUID      `json:"source_wallet_id"`
+	TargetWalletID          uuid.UUID      `json:"target_wallet_id"`
+	Amount                  string         `json:"amount"`
+	Frequency               string         `json:"frequency"`
+	ScheduleDayOfWeek       sql.NullInt32  `json:"schedule_day_of_week"`
+	ScheduleDayOfMonth      sql.NullInt32  `json:"schedule_day_of_month"`
+	ScheduleTime            time.Time      `json:"schedule_time"`
+	Timezone                string         `json:"timezone"`
+	RateCeiling             sql.NullString `json:"rate_ceiling"`
+	InsufficientFundsPolicy string         `json:"insufficient_funds_policy"`
+	Status                  string         `json:"status"`
+	NextExecutionAt         time.Time      `json:"next_execution_at"`
+	LastExecutedAt          sql.NullTime   `json:"last_executed_at"`
+	ExecutionCount          int32          `json:"execution_count"`
+	SkippedCount            int32          `json:"skipped_count"`
+	TotalInvested           string         `json:"total_invested"`
+	TotalAccumulated        string         `json:"total_accumulated"`
+	Label                   sql.NullString `json:"label"`
+	CancelledAt             sql.NullTime   `json:"cancelled_at"`
+	CreatedAt               time.Time      `json:"created_at"`
+	UpdatedAt               time.Time      `json:"updated_at"`
+}
+
+type DcaPlanExecution struct {
+	ID                  uuid.UUID      `json:"id"`
+	PlanID              uuid.UUID      `json:"plan_id"`
+	Status              string         `json:"status"`
+	Reason              sql.NullString `json:"reason"`
+	ConversionHistoryID uuid.NullUUID  `json:"conversion_history_id"`
+	TransactionID       uuid.NullUUID  `json:"transaction_id"`
+	SourceAmount        string         `json:"source_amount"`
+	TargetAmount        sql.NullString `json:"target_amount"`
+	ExecutedRate        sql.NullString `json:"executed_rate"`
+	ScheduledFor        time.Time      `json:"scheduled_for"`
+	CreatedAt           time.Time      `json:"created_at"`
+}
+
 type ElectricityPurchaseMetadatum struct {
 	ID              uuid.UUID      `json:"id"`
 	TransactionID   uuid.UUID      `json:"transaction_id"`
@@ -728,6 +1002,31 @@ type FaqDocument struct {
 	Tsv          interface{}    `json:"tsv"`
 }
 
+type FxRevenueEntry struct {
+	ID             uuid.UUID      `json:"id"`
+	Channel        string         `json:"channel"`
+	ReferenceID    uuid.UUID      `json:"reference_id"`
+	TransactionID  uuid.NullUUID  `json:"transaction_id"`
+	UserID         uuid.UUID      `json:"user_id"`
+	SourceCurrency string         `json:"source_currency"`
+	TargetCurrency string         `json:"target_currency"`
+	SourceAmount   string         `json:"source_amount"`
+	MidRate        string         `json:"mid_rate"`
+	MidRateSource  string         `json:"mid_rate_source"`
+	AppliedRate    string         `json:"applied_rate"`
+	SpreadRevenue  string         `json:"spread_revenue"`
+	FeeRevenue     string         `json:"fee_revenue"`
+	TotalRevenue   string         `json:"total_revenue"`
+	RevenueUsd     sql.NullString `json:"revenue_usd"`
+	RuleID         uuid.NullUUID  `json:"rule_id"`
+	RuleName       sql.NullString `json:"rule_name"`
+	VipLevelID     uuid.NullUUID  `json:"vip_level_id"`
+	VipLevelName   sql.NullString `json:"vip_level_name"`
+	RateProvider   sql.NullString `json:"rate_provider"`
+	OccurredAt     time.Time      `json:"occurred_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
 type GiftCard struct {
 	ID                       int32                 `json:"id"`
 	ProductID                int64                 `json:"product_id"`
@@ -856,13 +1155,26 @@ type Otp struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type PortfolioSnapshot struct {
+	ID           uuid.UUID       `json:"id"`
+	UserID       uuid.UUID       `json:"user_id"`
+	SnapshotDate time.Time       `json:"snapshot_date"`
+	TotalUsd     string          `json:"total_usd"`
+	WalletsUsd   string          `json:"wallets_usd"`
+	VaultsUsd    string          `json:"vaults_usd"`
+	CardsUsd     string          `json:"cards_usd"`
+	UsdRates     json.RawMessage `json:"usd_rates"`
+	Holdings     json.RawMessage `json:"holdings"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
 // Stores user-configured price alerts for cryptocurrency-to-fiat rate monitoring
 type PriceAlert struct {
 	ID             uuid.UUID `json:"id"`
 	UserID         uuid.UUID `json:"user_id"`
 	SourceCurrency string    `json:"source_currency"`
 	TargetCurrency string    `json:"target_currency"`
-	// Type of price condition: above, below, equals, percent_up, percent_down, range, breakout
+	// Type of price condition: above, below, equals, percent_up, percent_down, range, breakout, or an indicator condition (sma/ema crossovers, rsi, bollinger, period high/low)
 	AlertCondition string `json:"alert_condition"`
 	// Alert behavior: one_time (trigger once), recurring (trigger multiple times), trailing (dynamic stop-loss/buy)
 	AlertType        string         `json:"alert_type"`
@@ -873,21 +1185,46 @@ type PriceAlert struct {
 	RangeMax         sql.NullString `json:"range_max"`
 	BaselineRate     sql.NullString `json:"baseline_rate"`
 	// For trailing alerts: percentage distance from peak/trough (e.g., 5 for 5%)
-	TrailingDistance sql.NullString `json:"trailing_distance"`
-	MaxTrailingRate  sql.NullString `json:"max_trailing_rate"`
-	MinTrailingRate  sql.NullString `json:"min_trailing_rate"`
-	Description      sql.NullString `json:"description"`
-	Label            sql.NullString `json:"label"`
-	IsActive         bool           `json:"is_active"`
-	TriggeredCount   int32          `json:"triggered_count"`
-	LastTriggeredAt  sql.NullTime   `json:"last_triggered_at"`
-	LastCheckedAt    sql.NullTime   `json:"last_checked_at"`
-	ExpiresAt        sql.NullTime   `json:"expires_at"`
-	NotifyPush       bool           `json:"notify_push"`
-	NotifyInApp      bool           `json:"notify_in_app"`
-	CreatedAt        time.Time      `json:"created_at"`
-	UpdatedAt        time.Time      `json:"updated_at"`
-	DeletedAt        sql.NullTime   `json:"deleted_at"`
+	TrailingDistance    sql.NullString `json:"trailing_distance"`
+	MaxTrailingRate     sql.NullString `json:"max_trailing_rate"`
+	MinTrailingRate     sql.NullString `json:"min_trailing_rate"`
+	Description         sql.NullString `json:"description"`
+	Label               sql.NullString `json:"label"`
+	IsActive            bool           `json:"is_active"`
+	TriggeredCount      int32          `json:"triggered_count"`
+	LastTriggeredAt     sql.NullTime   `json:"last_triggered_at"`
+	LastCheckedAt       sql.NullTime   `json:"last_checked_at"`
+	ExpiresAt           sql.NullTime   `json:"expires_at"`
+	NotifyPush          bool           `json:"notify_push"`
+	NotifyInApp         bool           `json:"notify_in_app"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           sql.NullTime   `json:"deleted_at"`
+	DeliveryChannels    []string       `json:"delivery_channels"`
+	WebhookUrl          sql.NullString `json:"webhook_url"`
+	WebhookSecret       sql.NullString `json:"webhook_secret"`
+	IndicatorInterval   sql.NullString `json:"indicator_interval"`
+	IndicatorPeriod     sql.NullInt32  `json:"indicator_period"`
+	IndicatorFastPeriod sql.NullInt32  `json:"indicator_fast_period"`
+	IndicatorThreshold  sql.NullString `json:"indicator_threshold"`
+}
+
+type PriceAlertEvaluator struct {
+	NodeID            string    `json:"node_id"`
+	StartedAt         time.Time `json:"started_at"`
+	HeartbeatAt       time.Time `json:"heartbeat_at"`
+	AlertsEvaluated   int64     `json:"alerts_evaluated"`
+	AlertsTriggered   int64     `json:"alerts_triggered"`
+	DuplicateTriggers int64     `json:"duplicate_triggers"`
+	LastCycleMs       int32     `json:"last_cycle_ms"`
+	MaxLagMs          int32     `json:"max_lag_ms"`
+}
+
+type PriceAlertShardLease struct {
+	Shard      int32     `json:"shard"`
+	NodeID     string    `json:"node_id"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
 }
 
 type ProofOfAddressImage struct {
@@ -902,6 +1239,27 @@ type ProofOfAddressImage struct {
 	VerifiedAt sql.NullTime `json:"verified_at"`
 }
 
+type PublicHoliday struct {
+	ID          uuid.UUID     `json:"id"`
+	HolidayDate time.Time     `json:"holiday_date"`
+	Name        string        `json:"name"`
+	CreatedBy   uuid.NullUUID `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type PublishedRate struct {
+	ID            int64           `json:"id"`
+	BaseCurrency  string          `json:"base_currency"`
+	QuoteCurrency string          `json:"quote_currency"`
+	Rate          string          `json:"rate"`
+	Method        string          `json:"method"`
+	SourceCount   int32           `json:"source_count"`
+	AcceptedCount int32           `json:"accepted_count"`
+	Sources       json.RawMessage `json:"sources"`
+	PublishedAt   time.Time       `json:"published_at"`
+	ExpiresAt     time.Time       `json:"expires_at"`
+}
+
 // Generated QR codes for receiving crypto via Cryptomus with auto-conversion to fiat
 type QrCode struct {
 	ID                  uuid.UUID      `json:"id"`
@@ -980,27 +1338,31 @@ type QrTransaction struct {
 
 // Automatic rate mark-up rules for different VIP levels
 type RateAdjustmentRule struct {
-	ID                  uuid.UUID      `json:"id"`
-	RuleName            string         `json:"rule_name"`
-	RuleDescription     sql.NullString `json:"rule_description"`
-	VipLevelID          uuid.NullUUID  `json:"vip_level_id"`
-	IsGlobalRule        bool           `json:"is_global_rule"`
-	SourceCurrency      string         `json:"source_currency"`
-	TargetCurrency      string         `json:"target_currency"`
-	AdjustmentType      string         `json:"adjustment_type"`
-	AdjustmentValue     string         `json:"adjustment_value"`
-	AdjustmentDirection string         `json:"adjustment_direction"`
-	Priority            int32          `json:"priority"`
-	MinConversionAmount sql.NullString `json:"min_conversion_amount"`
-	MaxConversionAmount sql.NullString `json:"max_conversion_amount"`
-	ValidFrom           sql.NullTime   `json:"valid_from"`
-	ValidUntil          sql.NullTime   `json:"valid_until"`
-	IsActive            bool           `json:"is_active"`
-	CreatedBy           uuid.NullUUID  `json:"created_by"`
-	UpdatedBy           uuid.NullUUID  `json:"updated_by"`
-	CreatedAt           time.Time      `json:"created_at"`
-	UpdatedAt           time.Time      `json:"updated_at"`
-	DeletedAt           sql.NullTime   `json:"deleted_at"`
+	ID                  uuid.UUID             `json:"id"`
+	RuleName            string                `json:"rule_name"`
+	RuleDescription     sql.NullString        `json:"rule_description"`
+	VipLevelID          uuid.NullUUID         `json:"vip_level_id"`
+	IsGlobalRule        bool                  `json:"is_global_rule"`
+	SourceCurrency      string                `json:"source_currency"`
+	TargetCurrency      string                `json:"target_currency"`
+	AdjustmentType      string                `json:"adjustment_type"`
+	AdjustmentValue     string                `json:"adjustment_value"`
+	AdjustmentDirection string                `json:"adjustment_direction"`
+	Priority            int32                 `json:"priority"`
+	MinConversionAmount sql.NullString        `json:"min_conversion_amount"`
+	MaxConversionAmount sql.NullString        `json:"max_conversion_amount"`
+	ValidFrom           sql.NullTime          `json:"valid_from"`
+	ValidUntil          sql.NullTime          `json:"valid_until"`
+	IsActive            bool                  `json:"is_active"`
+	CreatedBy           uuid.NullUUID         `json:"created_by"`
+	UpdatedBy           uuid.NullUUID         `json:"updated_by"`
+	CreatedAt           time.Time             `json:"created_at"`
+	UpdatedAt           time.Time             `json:"updated_at"`
+	DeletedAt           sql.NullTime          `json:"deleted_at"`
+	Channels            []string              `json:"channels"`
+	TimeWindows         pqtype.NullRawMessage `json:"time_windows"`
+	ScheduleTimezone    string                `json:"schedule_timezone"`
+	ExcludeHolidays     bool                  `json:"exclude_holidays"`
 }
 
 // Admin notifications for rate-related events
@@ -1019,6 +1381,21 @@ type RateAdminNotification struct {
 	CreatedAt         time.Time             `json:"created_at"`
 }
 
+type RateCandle struct {
+	ID            int64     `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Interval      string    `json:"interval"`
+	BucketStart   time.Time `json:"bucket_start"`
+	Open          string    `json:"open"`
+	High          string    `json:"high"`
+	Low           string    `json:"low"`
+	Close         string    `json:"close"`
+	SampleCount   int32     `json:"sample_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
 // Audit trail for all rate adjustments and applications
 type RateChangeHistory struct {
 	ID               uuid.UUID      `json:"id"`
@@ -1037,6 +1414,63 @@ type RateChangeHistory struct {
 	ChangeReason     sql.NullString `json:"change_reason"`
 	ChangedBy        uuid.NullUUID  `json:"changed_by"`
 	CreatedAt        time.Time      `json:"created_at"`
+	ApprovedBy       uuid.NullUUID  `json:"approved_by"`
+	ChangeRequestID  uuid.NullUUID  `json:"change_request_id"`
+}
+
+type RateChangeRequest struct {
+	ID             uuid.UUID             `json:"id"`
+	ChangeType     string                `json:"change_type"`
+	TargetID       uuid.NullUUID         `json:"target_id"`
+	SourceCurrency string                `json:"source_currency"`
+	TargetCurrency string                `json:"target_currency"`
+	Payload        json.RawMessage       `json:"payload"`
+	Preview        pqtype.NullRawMessage `json:"preview"`
+	Summary        sql.NullString        `json:"summary"`
+	Status         string                `json:"status"`
+	RequestedBy    uuid.UUID             `json:"requested_by"`
+	ReviewedBy     uuid.NullUUID         `json:"reviewed_by"`
+	ReviewNote     sql.NullString        `json:"review_note"`
+	FailureReason  sql.NullString        `json:"failure_reason"`
+	ReviewedAt     sql.NullTime          `json:"reviewed_at"`
+	AppliedAt      sql.NullTime          `json:"applied_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+type RateQuote struct {
+	ID                uuid.UUID      `json:"id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	Purpose           string         `json:"purpose"`
+	SourceCurrency    string         `json:"source_currency"`
+	TargetCurrency    string         `json:"target_currency"`
+	SourceAmount      string         `json:"source_amount"`
+	Rate              string         `json:"rate"`
+	Fees              string         `json:"fees"`
+	TargetAmount      string         `json:"target_amount"`
+	NetAmount         string         `json:"net_amount"`
+	RateProvider      string         `json:"rate_provider"`
+	Signature         string         `json:"signature"`
+	Status            string         `json:"status"`
+	QrCodeID          uuid.NullUUID  `json:"qr_code_id"`
+	ExpiresAt         time.Time      `json:"expires_at"`
+	UsedAt            sql.NullTime   `json:"used_at"`
+	ConsumedReference sql.NullString `json:"consumed_reference"`
+	CreatedAt         time.Time      `json:"created_at"`
+	MidRate           sql.NullString `json:"mid_rate"`
+	RuleID            uuid.NullUUID  `json:"rule_id"`
+	RuleName          sql.NullString `json:"rule_name"`
+	VipLevelID        uuid.NullUUID  `json:"vip_level_id"`
+	VipLevelName      sql.NullString `json:"vip_level_name"`
+}
+
+type RateSample struct {
+	ID            int64     `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          string    `json:"rate"`
+	Source        string    `json:"source"`
+	SampledAt     time.Time `json:"sampled_at"`
 }
 
 type RecentCriticalEvent struct {
@@ -1595,28 +2029,169 @@ type UserVipMetricsCache struct {
 	UpdatedAt              time.Time      `json:"updated_at"`
 }
 
+type VaultAutoSaveAccrual struct {
+	ID                  uuid.UUID      `json:"id"`
+	RuleID              uuid.UUID      `json:"rule_id"`
+	UserID              uuid.UUID      `json:"user_id"`
+	VaultID             uuid.UUID      `json:"vault_id"`
+	Source              string         `json:"source"`
+	SourceTransactionID uuid.UUID      `json:"source_transaction_id"`
+	SourceAmount        string         `json:"source_amount"`
+	Amount              string         `json:"amount"`
+	Currency            string         `json:"currency"`
+	Status              string         `json:"status"`
+	BatchReference      sql.NullString `json:"batch_reference"`
+	FailureReason       sql.NullString `json:"failure_reason"`
+	CreatedAt           time.Time      `json:"created_at"`
+	ProcessedAt         sql.NullTime   `json:"processed_at"`
+}
+
+type VaultAutoSaveRule struct {
+	ID                uuid.UUID      `json:"id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	VaultID           uuid.UUID      `json:"vault_id"`
+	RuleType          string         `json:"rule_type"`
+	Triggers          []string       `json:"triggers"`
+	RoundTo           sql.NullString `json:"round_to"`
+	Percentage        sql.NullString `json:"percentage"`
+	MaxPerTransaction sql.NullString `json:"max_per_transaction"`
+	IsActive          bool           `json:"is_active"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+type VaultAutoSaveSummary struct {
+	UserID      uuid.UUID `json:"user_id"`
+	PeriodStart time.Time `json:"period_start"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type VaultGroup struct {
+	VaultID              uuid.UUID      `json:"vault_id"`
+	OwnerID              uuid.UUID      `json:"owner_id"`
+	WithdrawalRule       string         `json:"withdrawal_rule"`
+	ContributionAmount   sql.NullString `json:"contribution_amount"`
+	RotationIntervalDays sql.NullInt32  `json:"rotation_interval_days"`
+	NextPayoutAt         sql.NullTime   `json:"next_payout_at"`
+	NextPayoutPosition   int32          `json:"next_payout_position"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+}
+
+type VaultGroupActivity struct {
+	ID           int64                 `json:"id"`
+	VaultID      uuid.UUID             `json:"vault_id"`
+	ActorID      uuid.NullUUID         `json:"actor_id"`
+	ActivityType string                `json:"activity_type"`
+	Amount       sql.NullString        `json:"amount"`
+	Message      string                `json:"message"`
+	Metadata     pqtype.NullRawMessage `json:"metadata"`
+	CreatedAt    time.Time             `json:"created_at"`
+}
+
+type VaultGroupMember struct {
+	ID               uuid.UUID     `json:"id"`
+	VaultID          uuid.UUID     `json:"vault_id"`
+	UserID           uuid.UUID     `json:"user_id"`
+	Role             string        `json:"role"`
+	Status           string        `json:"status"`
+	InvitedBy        uuid.NullUUID `json:"invited_by"`
+	PayoutPosition   sql.NullInt32 `json:"payout_position"`
+	TotalContributed string        `json:"total_contributed"`
+	TotalReceived    string        `json:"total_received"`
+	JoinedAt         sql.NullTime  `json:"joined_at"`
+	CreatedAt        time.Time     `json:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+}
+
+type VaultGroupWithdrawalRequest struct {
+	ID                 uuid.UUID      `json:"id"`
+	VaultID            uuid.UUID      `json:"vault_id"`
+	RequestedBy        uuid.UUID      `json:"requested_by"`
+	Amount             string         `json:"amount"`
+	Reason             sql.NullString `json:"reason"`
+	Status             string         `json:"status"`
+	ApprovalsRequired  int32          `json:"approvals_required"`
+	VaultTransactionID uuid.NullUUID  `json:"vault_transaction_id"`
+	ExpiresAt          time.Time      `json:"expires_at"`
+	ResolvedAt         sql.NullTime   `json:"resolved_at"`
+	CreatedAt          time.Time      `json:"created_at"`
+}
+
+type VaultGroupWithdrawalVote struct {
+	RequestID uuid.UUID `json:"request_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Approve   bool      `json:"approve"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type VaultSaving struct {
-	ID                   uuid.UUID             `json:"id"`
-	UserID               uuid.UUID             `json:"user_id"`
-	VaultName            string                `json:"vault_name"`
-	Description          sql.NullString        `json:"description"`
-	GoalAmount           sql.NullString        `json:"goal_amount"`
-	CurrentBalance       sql.NullString        `json:"current_balance"`
-	Category             string                `json:"category"`
-	Currency             string                `json:"currency"`
-	AutoSaveEnabled      bool                  `json:"auto_save_enabled"`
-	AutoSaveFrequency    sql.NullString        `json:"auto_save_frequency"`
-	AutoSaveAmount       sql.NullString        `json:"auto_save_amount"`
-	NextAutoSave         sql.NullTime          `json:"next_auto_save"`
-	RecurringRule        pqtype.NullRawMessage `json:"recurring_rule"`
-	TotalYieldEarned     sql.NullString        `json:"total_yield_earned"`
-	NextYieldCalculation sql.NullTime          `json:"next_yield_calculation"`
-	LastYieldCalculation sql.NullTime          `json:"last_yield_calculation"`
-	Status               string                `json:"status"`
-	VaultType            string                `json:"vault_type"`
-	CreatedAt            time.Time             `json:"created_at"`
-	UpdatedAt            time.Time             `json:"updated_at"`
-	CompletedAt          sql.NullTime          `json:"completed_at"`
+	ID                         uuid.UUID             `json:"id"`
+	UserID                     uuid.UUID             `json:"user_id"`
+	VaultName                  string                `json:"vault_name"`
+	Description                sql.NullString        `json:"description"`
+	GoalAmount                 sql.NullString        `json:"goal_amount"`
+	CurrentBalance             sql.NullString        `json:"current_balance"`
+	Category                   string                `json:"category"`
+	Currency                   string                `json:"currency"`
+	AutoSaveEnabled            bool                  `json:"auto_save_enabled"`
+	AutoSaveFrequency          sql.NullString        `json:"auto_save_frequency"`
+	AutoSaveAmount             sql.NullString        `json:"auto_save_amount"`
+	NextAutoSave               sql.NullTime          `json:"next_auto_save"`
+	RecurringRule              pqtype.NullRawMessage `json:"recurring_rule"`
+	TotalYieldEarned           sql.NullString        `json:"total_yield_earned"`
+	NextYieldCalculation       sql.NullTime          `json:"next_yield_calculation"`
+	LastYieldCalculation       sql.NullTime          `json:"last_yield_calculation"`
+	Status                     string                `json:"status"`
+	VaultType                  string                `json:"vault_type"`
+	CreatedAt                  time.Time             `json:"created_at"`
+	UpdatedAt                  time.Time             `json:"updated_at"`
+	CompletedAt                sql.NullTime          `json:"completed_at"`
+	YieldConfigID              uuid.NullUUID         `json:"yield_config_id"`
+	LockTermDays               sql.NullInt32         `json:"lock_term_days"`
+	LockedApy                  sql.NullString        `json:"locked_apy"`
+	LockedPrincipal            sql.NullString        `json:"locked_principal"`
+	LockedAt                   sql.NullTime          `json:"locked_at"`
+	MaturesAt                  sql.NullTime          `json:"matures_at"`
+	MaturityAction             sql.NullString        `json:"maturity_action"`
+	EarlyWithdrawalPolicy      sql.NullString        `json:"early_withdrawal_policy"`
+	EarlyWithdrawalPenaltyRate sql.NullString        `json:"early_withdrawal_penalty_rate"`
+	RolloverCount              int32                 `json:"rollover_count"`
+	// wallet currency recurring deposits are converted from; null funds from the vault currency
+	FundingCurrency sql.NullString `json:"funding_currency"`
+}
+
+type VaultSweepRule struct {
+	ID            uuid.UUID    `json:"id"`
+	UserID        uuid.UUID    `json:"user_id"`
+	VaultID       uuid.UUID    `json:"vault_id"`
+	Currency      string       `json:"currency"`
+	RuleType      string       `json:"rule_type"`
+	TargetBalance string       `json:"target_balance"`
+	MinAmount     string       `json:"min_amount"`
+	RunTime       string       `json:"run_time"`
+	IsActive      bool         `json:"is_active"`
+	NextRunAt     time.Time    `json:"next_run_at"`
+	LastRunAt     sql.NullTime `json:"last_run_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+type VaultSweepRun struct {
+	ID                  uuid.UUID      `json:"id"`
+	RuleID              uuid.UUID      `json:"rule_id"`
+	UserID              uuid.UUID      `json:"user_id"`
+	VaultID             uuid.UUID      `json:"vault_id"`
+	RuleType            string         `json:"rule_type"`
+	Currency            string         `json:"currency"`
+	WalletBalanceBefore string         `json:"wallet_balance_before"`
+	VaultBalanceBefore  string         `json:"vault_balance_before"`
+	TargetBalance       string         `json:"target_balance"`
+	Amount              string         `json:"amount"`
+	Status              string         `json:"status"`
+	VaultTransactionID  uuid.NullUUID  `json:"vault_transaction_id"`
+	Reason              sql.NullString `json:"reason"`
+	CreatedAt           time.Time      `json:"created_at"`
 }
 
 type VaultTransaction struct {
@@ -1641,6 +2216,23 @@ type VaultTransaction struct {
 	CreatedAt         time.Time             `json:"created_at"`
 }
 
+type VaultWithholdingTaxEntry struct {
+	ID                  uuid.UUID      `json:"id"`
+	YieldID             uuid.NullUUID  `json:"yield_id"`
+	UserID              uuid.UUID      `json:"user_id"`
+	VaultID             uuid.UUID      `json:"vault_id"`
+	Currency            string         `json:"currency"`
+	GrossYield          string         `json:"gross_yield"`
+	TaxRate             string         `json:"tax_rate"`
+	TaxAmount           string         `json:"tax_amount"`
+	NetYield            string         `json:"net_yield"`
+	TaxYear             int32          `json:"tax_year"`
+	Status              string         `json:"status"`
+	RemittanceReference sql.NullString `json:"remittance_reference"`
+	RemittedAt          sql.NullTime   `json:"remitted_at"`
+	CreatedAt           time.Time      `json:"created_at"`
+}
+
 type VaultYield struct {
 	ID                     uuid.UUID      `json:"id"`
 	UserID                 uuid.UUID      `json:"user_id"`
@@ -1655,18 +2247,39 @@ type VaultYield struct {
 	CreatedAt              time.Time      `json:"created_at"`
 }
 
+type VaultYieldAccrual struct {
+	ID          uuid.UUID     `json:"id"`
+	VaultID     uuid.UUID     `json:"vault_id"`
+	UserID      uuid.UUID     `json:"user_id"`
+	AccrualDate time.Time     `json:"accrual_date"`
+	PeriodStart time.Time     `json:"period_start"`
+	PeriodEnd   time.Time     `json:"period_end"`
+	Balance     string        `json:"balance"`
+	ApyRate     string        `json:"apy_rate"`
+	Amount      string        `json:"amount"`
+	YieldID     uuid.NullUUID `json:"yield_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
 type VaultYieldConfig struct {
-	ID                 uuid.UUID      `json:"id"`
-	Currency           string         `json:"currency"`
-	ApyRate            string         `json:"apy_rate"`
-	MinBalanceForYield string         `json:"min_balance_for_yield"`
-	CompoundFrequency  sql.NullString `json:"compound_frequency"`
-	IsActive           bool           `json:"is_active"`
-	EffectiveFrom      time.Time      `json:"effective_from"`
-	EffectiveUntil     sql.NullTime   `json:"effective_until"`
-	Notes              sql.NullString `json:"notes"`
-	CreatedAt          time.Time      `json:"created_at"`
-	UpdatedAt          time.Time      `json:"updated_at"`
+	ID                         uuid.UUID      `json:"id"`
+	Currency                   string         `json:"currency"`
+	ApyRate                    string         `json:"apy_rate"`
+	MinBalanceForYield         string         `json:"min_balance_for_yield"`
+	CompoundFrequency          sql.NullString `json:"compound_frequency"`
+	IsActive                   bool           `json:"is_active"`
+	EffectiveFrom              time.Time      `json:"effective_from"`
+	EffectiveUntil             sql.NullTime   `json:"effective_until"`
+	Notes                      sql.NullString `json:"notes"`
+	CreatedAt                  time.Time      `json:"created_at"`
+	UpdatedAt                  time.Time      `json:"updated_at"`
+	ProductType                string         `json:"product_type"`
+	LockTermDays               sql.NullInt32  `json:"lock_term_days"`
+	EarlyWithdrawalPolicy      sql.NullString `json:"early_withdrawal_policy"`
+	EarlyWithdrawalPenaltyRate string         `json:"early_withdrawal_penalty_rate"`
+	// Balance bands with their own APY; each band's rate applies to the part of the balance inside it
+	ApyTiers           pqtype.NullRawMessage `json:"apy_tiers"`
+	WithholdingTaxRate string                `json:"withholding_tax_rate"`
 }
 
 // VIP tier definitions with transaction volume thresholds
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createAlertTriggerHistory = `-- name: CreateAlertTriggerHistory :one
@@ -23,10 +24,12 @@ INSERT INTO alert_trigger_history (
     alert_condition,
     target_rate,
     push_notification_sent,
-    in_app_notification_sent
+    in_app_notification_sent,
+    title,
+    body
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING id, alert_id, user_id, triggered_at, current_rate, previous_rate, change_percent, alert_condition, target_rate, push_notification_sent, in_app_notification_sent, notification_error, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING id, alert_id, user_id, triggered_at, current_rate, previous_rate, change_percent, alert_condition, target_rate, push_notification_sent, in_app_notification_sent, notification_error, created_at, title, body
 `
 
 type CreateAlertTriggerHistoryParams struct {
@@ -39,6 +42,8 @@ type CreateAlertTriggerHistoryParams struct {
 	TargetRate            sql.NullString `json:"target_rate"`
 	PushNotificationSent  sql.NullBool   `json:"push_notification_sent"`
 	InAppNotificationSent sql.NullBool   `json:"in_app_notification_sent"`
+	Title                 sql.NullString `json:"title"`
+	Body                  sql.NullString `json:"body"`
 }
 
 func (q *Queries) CreateAlertTriggerHistory(ctx context.Context, arg CreateAlertTriggerHistoryParams) (AlertTriggerHistory, error) {
@@ -52,6 +57,8 @@ func (q *Queries) CreateAlertTriggerHistory(ctx context.Context, arg CreateAlert
 		arg.TargetRate,
 		arg.PushNotificationSent,
 		arg.InAppNotificationSent,
+		arg.Title,
+		arg.Body,
 	)
 	var i AlertTriggerHistory
 	err := row.Scan(
@@ -68,6 +75,8 @@ func (q *Queries) CreateAlertTriggerHistory(ctx context.Context, arg CreateAlert
 		&i.InAppNotificationSent,
 		&i.NotificationError,
 		&i.CreatedAt,
+		&i.Title,
+		&i.Body,
 	)
 	return i, err
 }
@@ -92,33 +101,48 @@ INSERT INTO price_alerts (
     label,
     expires_at,
     notify_push,
-    notify_in_app
+    notify_in_app,
+    delivery_channels,
+    webhook_url,
+    webhook_secret,
+    indicator_interval,
+    indicator_period,
+    indicator_fast_period,
+    indicator_threshold
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-    $11, $12, $13, $14, $15, $16, $17, $18, $19
-) RETURNING id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26
+) RETURNING id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at, delivery_channels, webhook_url, webhook_secret, indicator_interval, indicator_period, indicator_fast_period, indicator_threshold
 `
 
 type CreatePriceAlertParams struct {
-	UserID           uuid.UUID      `json:"user_id"`
-	SourceCurrency   string         `json:"source_currency"`
-	TargetCurrency   string         `json:"target_currency"`
-	AlertCondition   string         `json:"alert_condition"`
-	AlertType        string         `json:"alert_type"`
-	Priority         string         `json:"priority"`
-	TargetRate       sql.NullString `json:"target_rate"`
-	PercentageChange sql.NullString `json:"percentage_change"`
-	RangeMin         sql.NullString `json:"range_min"`
-	RangeMax         sql.NullString `json:"range_max"`
-	BaselineRate     sql.NullString `json:"baseline_rate"`
-	TrailingDistance sql.NullString `json:"trailing_distance"`
-	MaxTrailingRate  sql.NullString `json:"max_trailing_rate"`
-	MinTrailingRate  sql.NullString `json:"min_trailing_rate"`
-	Description      sql.NullString `json:"description"`
-	Label            sql.NullString `json:"label"`
-	ExpiresAt        sql.NullTime   `json:"expires_at"`
-	NotifyPush       bool           `json:"notify_push"`
-	NotifyInApp      bool           `json:"notify_in_app"`
+	UserID              uuid.UUID      `json:"user_id"`
+	SourceCurrency      string         `json:"source_currency"`
+	TargetCurrency      string         `json:"target_currency"`
+	AlertCondition      string         `json:"alert_condition"`
+	AlertType           string         `json:"alert_type"`
+	Priority            string         `json:"priority"`
+	TargetRate          sql.NullString `json:"target_rate"`
+	PercentageChange    sql.NullString `json:"percentage_change"`
+	RangeMin            sql.NullString `json:"range_min"`
+	RangeMax            sql.NullString `json:"range_max"`
+	BaselineRate        sql.NullString `json:"baseline_rate"`
+	TrailingDistance    sql.NullString `json:"trailing_distance"`
+	MaxTrailingRate     sql.NullString `json:"max_trailing_rate"`
+	MinTrailingRate     sql.NullString `json:"min_trailing_rate"`
+	Description         sql.NullString `json:"description"`
+	Label               sql.NullString `json:"label"`
+	ExpiresAt           sql.NullTime   `json:"expires_at"`
+	NotifyPush          bool           `json:"notify_push"`
+	NotifyInApp         bool           `json:"notify_in_app"`
+	DeliveryChannels    []string       `json:"delivery_channels"`
+	WebhookUrl          sql.NullString `json:"webhook_url"`
+	WebhookSecret       sql.NullString `json:"webhook_secret"`
+	IndicatorInterval   sql.NullString `json:"indicator_interval"`
+	IndicatorPeriod     sql.NullInt32  `json:"indicator_period"`
+	IndicatorFastPeriod sql.NullInt32  `json:"indicator_fast_period"`
+	IndicatorThreshold  sql.NullString `json:"indicator_threshold"`
 }
 
 func (q *Queries) CreatePriceAlert(ctx context.Context, arg CreatePriceAlertParams) (PriceAlert, error) {
@@ -142,6 +166,13 @@ func (q *Queries) CreatePriceAlert(ctx context.Context, arg CreatePriceAlertPara
 		arg.ExpiresAt,
 		arg.NotifyPush,
 		arg.NotifyInApp,
+		pq.Array(arg.DeliveryChannels),
+		arg.WebhookUrl,
+		arg.WebhookSecret,
+		arg.IndicatorInterval,
+		arg.IndicatorPeriod,
+		arg.IndicatorFastPeriod,
+		arg.IndicatorThreshold,
 	)
 	var i PriceAlert
 	err := row.Scan(
@@ -172,6 +203,13 @@ func (q *Queries) CreatePriceAlert(ctx context.Context, arg CreatePriceAlertPara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		pq.Array(&i.DeliveryChannels),
+		&i.WebhookUrl,
+		&i.WebhookSecret,
+		&i.IndicatorInterval,
+		&i.IndicatorPeriod,
+		&i.IndicatorFastPeriod,
+		&i.IndicatorThreshold,
 	)
 	return i, err
 }
@@ -225,7 +263,7 @@ func (q *Queries) GetActiveAlertCount(ctx context.Context) (int64, error) {
 }
 
 const getActivePriceAlerts = `-- name: GetActivePriceAlerts :many
-SELECT id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at FROM price_alerts
+SELECT id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at, delivery_channels, webhook_url, webhook_secret, indicator_interval, indicator_period, indicator_fast_period, indicator_threshold FROM price_alerts
 WHERE is_active = true 
 AND deleted_at IS NULL
 AND (expires_at IS NULL OR expires_at > NOW())
@@ -269,6 +307,13 @@ func (q *Queries) GetActivePriceAlerts(ctx context.Context) ([]PriceAlert, error
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			pq.Array(&i.DeliveryChannels),
+			&i.WebhookUrl,
+			&i.WebhookSecret,
+			&i.IndicatorInterval,
+			&i.IndicatorPeriod,
+			&i.IndicatorFastPeriod,
+			&i.IndicatorThreshold,
 		); err != nil {
 			return nil, err
 		}
@@ -284,7 +329,7 @@ func (q *Queries) GetActivePriceAlerts(ctx context.Context) ([]PriceAlert, error
 }
 
 const getAlertTriggerHistory = `-- name: GetAlertTriggerHistory :many
-SELECT id, alert_id, user_id, triggered_at, current_rate, previous_rate, change_percent, alert_condition, target_rate, push_notification_sent, in_app_notification_sent, notification_error, created_at FROM alert_trigger_history
+SELECT id, alert_id, user_id, triggered_at, current_rate, previous_rate, change_percent, alert_condition, target_rate, push_notification_sent, in_app_notification_sent, notification_error, created_at, title, body FROM alert_trigger_history
 WHERE alert_id = $1
 ORDER BY triggered_at DESC
 LIMIT $2 OFFSET $3
@@ -319,6 +364,8 @@ func (q *Queries) GetAlertTriggerHistory(ctx context.Context, arg GetAlertTrigge
 			&i.InAppNotificationSent,
 			&i.NotificationError,
 			&i.CreatedAt,
+			&i.Title,
+			&i.Body,
 		); err != nil {
 			return nil, err
 		}
@@ -334,7 +381,7 @@ func (q *Queries) GetAlertTriggerHistory(ctx context.Context, arg GetAlertTrigge
 }
 
 const getAllPriceAlerts = `-- name: GetAllPriceAlerts :many
-SELECT id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at FROM price_alerts
+SELECT id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at, delivery_channels, webhook_url, webhook_secret, indicator_interval, indicator_period, indicator_fast_period, indicator_threshold FROM price_alerts
 WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -382,6 +429,13 @@ func (q *Queries) GetAllPriceAlerts(ctx context.Context, arg GetAllPriceAlertsPa
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			pq.Array(&i.DeliveryChannels),
+			&i.WebhookUrl,
+			&i.WebhookSecret,
+			&i.IndicatorInterval,
+			&i.IndicatorPeriod,
+			&i.IndicatorFastPeriod,
+			&i.IndicatorThreshold,
 		); err != nil {
 			return nil, err
 		}
@@ -397,7 +451,7 @@ func (q *Queries) GetAllPriceAlerts(ctx context.Context, arg GetAllPriceAlertsPa
 }
 
 const getPriceAlert = `-- name: GetPriceAlert :one
-SELECT id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at FROM price_alerts
+SELECT id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at, delivery_channels, webhook_url, webhook_secret, indicator_interval, indicator_period, indicator_fast_period, indicator_threshold FROM price_alerts
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -432,12 +486,19 @@ func (q *Queries) GetPriceAlert(ctx context.Context, id uuid.UUID) (PriceAlert,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		pq.Array(&i.DeliveryChannels),
+		&i.WebhookUrl,
+		&i.WebhookSecret,
+		&i.IndicatorInterval,
+		&i.IndicatorPeriod,
+		&i.IndicatorFastPeriod,
+		&i.IndicatorThreshold,
 	)
 	return i, err
 }
 
 const getUserActiveAlerts = `-- name: GetUserActiveAlerts :many
-SELECT id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at FROM price_alerts
+SELECT id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at, delivery_channels, webhook_url, webhook_secret, indicator_interval, indicator_period, indicator_fast_period, indicator_threshold FROM price_alerts
 WHERE user_id = $1 AND is_active = true AND deleted_at IS NULL
 ORDER BY created_at DESC
 `
@@ -479,6 +540,13 @@ func (q *Queries) GetUserActiveAlerts(ctx context.Context, userID uuid.UUID) ([]
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			pq.Array(&i.DeliveryChannels),
+			&i.WebhookUrl,
+			&i.WebhookSecret,
+			&i.IndicatorInterval,
+			&i.IndicatorPeriod,
+			&i.IndicatorFastPeriod,
+			&i.IndicatorThreshold,
 		); err != nil {
 			return nil, err
 		}
@@ -533,7 +601,7 @@ func (q *Queries) GetUserAlertStats(ctx context.Context, userID uuid.UUID) (GetU
 }
 
 const getUserAlerts = `-- name: GetUserAlerts :many
-SELECT id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at FROM price_alerts
+SELECT id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at, delivery_channels, webhook_url, webhook_secret, indicator_interval, indicator_period, indicator_fast_period, indicator_threshold FROM price_alerts
 WHERE user_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
 `
@@ -575,6 +643,13 @@ func (q *Queries) GetUserAlerts(ctx context.Context, userID uuid.UUID) ([]PriceA
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			pq.Array(&i.DeliveryChannels),
+			&i.WebhookUrl,
+			&i.WebhookSecret,
+			&i.IndicatorInterval,
+			&i.IndicatorPeriod,
+			&i.IndicatorFastPeriod,
+			&i.IndicatorThreshold,
 		); err != nil {
 			return nil, err
 		}
@@ -675,22 +750,34 @@ SET
     label = COALESCE($7, label),
     expires_at = COALESCE($8, expires_at),
     notify_push = COALESCE($9, notify_push),
-    notify_in_app = COALESCE($10, notify_in_app)
+    notify_in_app = COALESCE($10, notify_in_app),
+    delivery_channels = COALESCE($11::text[], delivery_channels),
+    webhook_url = COALESCE($12, webhook_url),
+    webhook_secret = COALESCE($13, webhook_secret),
+    indicator_period = COALESCE($14, indicator_period),
+    indicator_fast_period = COALESCE($15, indicator_fast_period),
+    indicator_threshold = COALESCE($16, indicator_threshold)
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at
+RETURNING id, user_id, source_currency, target_currency, alert_condition, alert_type, priority, target_rate, percentage_change, range_min, range_max, baseline_rate, trailing_distance, max_trailing_rate, min_trailing_rate, description, label, is_active, triggered_count, last_triggered_at, last_checked_at, expires_at, notify_push, notify_in_app, created_at, updated_at, deleted_at, delivery_channels, webhook_url, webhook_secret, indicator_interval, indicator_period, indicator_fast_period, indicator_threshold
 `
 
 type UpdatePriceAlertParams struct {
-	ID               uuid.UUID      `json:"id"`
-	TargetRate       sql.NullString `json:"target_rate"`
-	PercentageChange sql.NullString `json:"percentage_change"`
-	RangeMin         sql.NullString `json:"range_min"`
-	RangeMax         sql.NullString `json:"range_max"`
-	Description      sql.NullString `json:"description"`
-	Label            sql.NullString `json:"label"`
-	ExpiresAt        sql.NullTime   `json:"expires_at"`
-	NotifyPush       sql.NullBool   `json:"notify_push"`
-	NotifyInApp      sql.NullBool   `json:"notify_in_app"`
+	ID                  uuid.UUID      `json:"id"`
+	TargetRate          sql.NullString `json:"target_rate"`
+	PercentageChange    sql.NullString `json:"percentage_change"`
+	RangeMin            sql.NullString `json:"range_min"`
+	RangeMax            sql.NullString `json:"range_max"`
+	Description         sql.NullString `json:"description"`
+	Label               sql.NullString `json:"label"`
+	ExpiresAt           sql.NullTime   `json:"expires_at"`
+	NotifyPush          sql.NullBool   `json:"notify_push"`
+	NotifyInApp         sql.NullBool   `json:"notify_in_app"`
+	DeliveryChannels    []string       `json:"delivery_channels"`
+	WebhookUrl          sql.NullString `json:"webhook_url"`
+	WebhookSecret       sql.NullString `json:"webhook_secret"`
+	IndicatorPeriod     sql.NullInt32  `json:"indicator_period"`
+	IndicatorFastPeriod sql.NullInt32  `json:"indicator_fast_period"`
+	IndicatorThreshold  sql.NullString `json:"indicator_threshold"`
 }
 
 func (q *Queries) UpdatePriceAlert(ctx context.Context, arg UpdatePriceAlertParams) (PriceAlert, error) {
@@ -705,6 +792,12 @@ func (q *Queries) UpdatePriceAlert(ctx context.Context, arg UpdatePriceAlertPara
 		arg.ExpiresAt,
 		arg.NotifyPush,
 		arg.NotifyInApp,
+		pq.Array(arg.DeliveryChannels),
+		arg.WebhookUrl,
+		arg.WebhookSecret,
+		arg.IndicatorPeriod,
+		arg.IndicatorFastPeriod,
+		arg.IndicatorThreshold,
 	)
 	var i PriceAlert
 	err := row.Scan(
@@ -735,6 +828,13 @@ func (q *Queries) UpdatePriceAlert(ctx context.Context, arg UpdatePriceAlertPara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		pq.Array(&i.DeliveryChannels),
+		&i.WebhookUrl,
+		&i.WebhookSecret,
+		&i.IndicatorInterval,
+		&i.IndicatorPeriod,
+		&i.IndicatorFastPeriod,
+		&i.IndicatorThreshold,
 	)
 	return i, err
 }
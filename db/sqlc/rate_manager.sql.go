@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sqlc-dev/pqtype"
 )
 
@@ -249,6 +250,39 @@ func (q *Queries) CountVIPLevelUsers(ctx context.Context, currentVipLevelID uuid
 	return count, err
 }
 
+const createPublicHoliday = `-- name: CreatePublicHoliday :one
+
+INSERT INTO public_holidays (
+    holiday_date,
+    name,
+    created_by
+) VALUES (
+    $1, $2, $3
+) RETURNING id, holiday_date, name, created_by, created_at
+`
+
+type CreatePublicHolidayParams struct {
+	HolidayDate time.Time     `json:"holiday_date"`
+	Name        string        `json:"name"`
+	CreatedBy   uuid.NullUUID `json:"created_by"`
+}
+
+// =====================================================
+// PUBLIC HOLIDAYS QUERIES
+// =====================================================
+func (q *Queries) CreatePublicHoliday(ctx context.Context, arg CreatePublicHolidayParams) (PublicHoliday, error) {
+	row := q.db.QueryRowContext(ctx, createPublicHoliday, arg.HolidayDate, arg.Name, arg.CreatedBy)
+	var i PublicHoliday
+	err := row.Scan(
+		&i.ID,
+		&i.HolidayDate,
+		&i.Name,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createRateAdjustmentRule = `-- name: CreateRateAdjustmentRule :one
 
 INSERT INTO rate_adjustment_rules (
@@ -268,30 +302,38 @@ INSERT INTO rate_adjustment_rules (
     valid_until,
     is_active,
     created_by,
-    updated_by
+    updated_by,
+    channels,
+    time_windows,
+    schedule_timezone,
+    exclude_holidays
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
-) RETURNING id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
+) RETURNING id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at, channels, time_windows, schedule_timezone, exclude_holidays
 `
 
 type CreateRateAdjustmentRuleParams struct {
-	RuleName            string         `json:"rule_name"`
-	RuleDescription     sql.NullString `json:"rule_description"`
-	VipLevelID          uuid.NullUUID  `json:"vip_level_id"`
-	IsGlobalRule        bool           `json:"is_global_rule"`
-	SourceCurrency      string         `json:"source_currency"`
-	TargetCurrency      string         `json:"target_currency"`
-	AdjustmentType      string         `json:"adjustment_type"`
-	AdjustmentValue     string         `json:"adjustment_value"`
-	AdjustmentDirection string         `json:"adjustment_direction"`
-	Priority            int32          `json:"priority"`
-	MinConversionAmount sql.NullString `json:"min_conversion_amount"`
-	MaxConversionAmount sql.NullString `json:"max_conversion_amount"`
-	ValidFrom           sql.NullTime   `json:"valid_from"`
-	ValidUntil          sql.NullTime   `json:"valid_until"`
-	IsActive            bool           `json:"is_active"`
-	CreatedBy           uuid.NullUUID  `json:"created_by"`
-	UpdatedBy           uuid.NullUUID  `json:"updated_by"`
+	RuleName            string                `json:"rule_name"`
+	RuleDescription     sql.NullString        `json:"rule_description"`
+	VipLevelID          uuid.NullUUID         `json:"vip_level_id"`
+	IsGlobalRule        bool                  `json:"is_global_rule"`
+	SourceCurrency      string                `json:"source_currency"`
+	TargetCurrency      string                `json:"target_currency"`
+	AdjustmentType      string                `json:"adjustment_type"`
+	AdjustmentValue     string                `json:"adjustment_value"`
+	AdjustmentDirection string                `json:"adjustment_direction"`
+	Priority            int32                 `json:"priority"`
+	MinConversionAmount sql.NullString        `json:"min_conversion_amount"`
+	MaxConversionAmount sql.NullString        `json:"max_conversion_amount"`
+	ValidFrom           sql.NullTime          `json:"valid_from"`
+	ValidUntil          sql.NullTime          `json:"valid_until"`
+	IsActive            bool                  `json:"is_active"`
+	CreatedBy           uuid.NullUUID         `json:"created_by"`
+	UpdatedBy           uuid.NullUUID         `json:"updated_by"`
+	Channels            []string              `json:"channels"`
+	TimeWindows         pqtype.NullRawMessage `json:"time_windows"`
+	ScheduleTimezone    string                `json:"schedule_timezone"`
+	ExcludeHolidays     bool                  `json:"exclude_holidays"`
 }
 
 // =====================================================
@@ -316,6 +358,10 @@ func (q *Queries) CreateRateAdjustmentRule(ctx context.Context, arg CreateRateAd
 		arg.IsActive,
 		arg.CreatedBy,
 		arg.UpdatedBy,
+		pq.Array(arg.Channels),
+		arg.TimeWindows,
+		arg.ScheduleTimezone,
+		arg.ExcludeHolidays,
 	)
 	var i RateAdjustmentRule
 	err := row.Scan(
@@ -340,6 +386,10 @@ func (q *Queries) CreateRateAdjustmentRule(ctx context.Context, arg CreateRateAd
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		pq.Array(&i.Channels),
+		&i.TimeWindows,
+		&i.ScheduleTimezone,
+		&i.ExcludeHolidays,
 	)
 	return i, err
 }
@@ -528,6 +578,19 @@ func (q *Queries) DeactivateVIPAssignment(ctx context.Context, id uuid.UUID) (Us
 	return i, err
 }
 
+const deletePublicHoliday = `-- name: DeletePublicHoliday :execrows
+DELETE FROM public_holidays
+WHERE id = $1
+`
+
+func (q *Queries) DeletePublicHoliday(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deletePublicHoliday, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteRateAdjustmentRule = `-- name: DeleteRateAdjustmentRule :exec
 DELETE FROM rate_adjustment_rules
 WHERE id = $1
@@ -585,7 +648,7 @@ func (q *Queries) DeleteVIPLevel(ctx context.Context, arg DeleteVIPLevelParams)
 }
 
 const getActiveGlobalRule = `-- name: GetActiveGlobalRule :one
-SELECT id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at FROM rate_adjustment_rules
+SELECT id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at, channels, time_windows, schedule_timezone, exclude_holidays FROM rate_adjustment_rules
 WHERE is_global_rule = TRUE 
   AND is_active = TRUE 
   AND deleted_at IS NULL
@@ -593,6 +656,10 @@ WHERE is_global_rule = TRUE
   AND target_currency = $2
   AND (valid_from IS NULL OR valid_from <= NOW())
   AND (valid_until IS NULL OR valid_until > NOW())
+  AND cardinality(channels) = 0
+  AND (time_windows IS NULL OR time_windows = '[]'::jsonb)
+  AND min_conversion_amount IS NULL
+  AND max_conversion_amount IS NULL
 LIMIT 1
 `
 
@@ -626,12 +693,16 @@ func (q *Queries) GetActiveGlobalRule(ctx context.Context, arg GetActiveGlobalRu
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		pq.Array(&i.Channels),
+		&i.TimeWindows,
+		&i.ScheduleTimezone,
+		&i.ExcludeHolidays,
 	)
 	return i, err
 }
 
 const getActiveRuleForVIPLevel = `-- name: GetActiveRuleForVIPLevel :one
-SELECT id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at FROM rate_adjustment_rules
+SELECT id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at, channels, time_windows, schedule_timezone, exclude_holidays FROM rate_adjustment_rules
 WHERE vip_level_id = $1
   AND is_active = TRUE
   AND deleted_at IS NULL
@@ -682,6 +753,10 @@ func (q *Queries) GetActiveRuleForVIPLevel(ctx context.Context, arg GetActiveRul
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		pq.Array(&i.Channels),
+		&i.TimeWindows,
+		&i.ScheduleTimezone,
+		&i.ExcludeHolidays,
 	)
 	return i, err
 }
@@ -770,7 +845,7 @@ func (q *Queries) GetActiveVIPAssignment(ctx context.Context, userID uuid.UUID)
 
 const getApplicableRulesForUser = `-- name: GetApplicableRulesForUser :many
 SELECT 
-    r.id, r.rule_name, r.rule_description, r.vip_level_id, r.is_global_rule, r.source_currency, r.target_currency, r.adjustment_type, r.adjustment_value, r.adjustment_direction, r.priority, r.min_conversion_amount, r.max_conversion_amount, r.valid_from, r.valid_until, r.is_active, r.created_by, r.updated_by, r.created_at, r.updated_at, r.deleted_at,
+    r.id, r.rule_name, r.rule_description, r.vip_level_id, r.is_global_rule, r.source_currency, r.target_currency, r.adjustment_type, r.adjustment_value, r.adjustment_direction, r.priority, r.min_conversion_amount, r.max_conversion_amount, r.valid_from, r.valid_until, r.is_active, r.created_by, r.updated_by, r.created_at, r.updated_at, r.deleted_at, r.channels, r.time_windows, r.schedule_timezone, r.exclude_holidays,
     v.level_name as vip_level_name,
     v.level_rank as vip_level_rank
 FROM rate_adjustment_rules r
@@ -779,61 +854,54 @@ WHERE r.deleted_at IS NULL
   AND r.is_active = TRUE
   AND r.source_currency = $1
   AND r.target_currency = $2
-  AND (r.valid_from IS NULL OR r.valid_from <= NOW())
-  AND (r.valid_until IS NULL OR r.valid_until > NOW())
-  AND (r.min_conversion_amount IS NULL OR r.min_conversion_amount <= $3)
-  AND (r.max_conversion_amount IS NULL OR r.max_conversion_amount >= $3)
   AND (
     r.is_global_rule = TRUE 
     OR r.vip_level_id = (
         SELECT current_vip_level_id FROM users 
-        WHERE users.id = $4 AND users.deleted_at IS NULL
+        WHERE users.id = $3 AND users.deleted_at IS NULL
     )
   )
-ORDER BY r.priority DESC, v.level_rank DESC NULLS LAST
-LIMIT 1
+ORDER BY r.priority DESC, v.level_rank DESC NULLS LAST, r.created_at ASC, r.id ASC
 `
 
 type GetApplicableRulesForUserParams struct {
-	SourceCurrency      string         `json:"source_currency"`
-	TargetCurrency      string         `json:"target_currency"`
-	MinConversionAmount sql.NullString `json:"min_conversion_amount"`
-	UserID              uuid.UUID      `json:"user_id"`
+	SourceCurrency string    `json:"source_currency"`
+	TargetCurrency string    `json:"target_currency"`
+	UserID         uuid.UUID `json:"user_id"`
 }
 
 type GetApplicableRulesForUserRow struct {
-	ID                  uuid.UUID      `json:"id"`
-	RuleName            string         `json:"rule_name"`
-	RuleDescription     sql.NullString `json:"rule_description"`
-	VipLevelID          uuid.NullUUID  `json:"vip_level_id"`
-	IsGlobalRule        bool           `json:"is_global_rule"`
-	SourceCurrency      string         `json:"source_currency"`
-	TargetCurrency      string         `json:"target_currency"`
-	AdjustmentType      string         `json:"adjustment_type"`
-	AdjustmentValue     string         `json:"adjustment_value"`
-	AdjustmentDirection string         `json:"adjustment_direction"`
-	Priority            int32          `json:"priority"`
-	MinConversionAmount sql.NullString `json:"min_conversion_amount"`
-	MaxConversionAmount sql.NullString `json:"max_conversion_amount"`
-	ValidFrom           sql.NullTime   `json:"valid_from"`
-	ValidUntil          sql.NullTime   `json:"valid_until"`
-	IsActive            bool           `json:"is_active"`
-	CreatedBy           uuid.NullUUID  `json:"created_by"`
-	UpdatedBy           uuid.NullUUID  `json:"updated_by"`
-	CreatedAt           time.Time      `json:"created_at"`
-	UpdatedAt           time.Time      `json:"updated_at"`
-	DeletedAt           sql.NullTime   `json:"deleted_at"`
-	VipLevelName        sql.NullString `json:"vip_level_name"`
-	VipLevelRank        sql.NullInt32  `json:"vip_level_rank"`
+	ID                  uuid.UUID             `json:"id"`
+	RuleName            string                `json:"rule_name"`
+	RuleDescription     sql.NullString        `json:"rule_description"`
+	VipLevelID          uuid.NullUUID         `json:"vip_level_id"`
+	IsGlobalRule        bool                  `json:"is_global_rule"`
+	SourceCurrency      string                `json:"source_currency"`
+	TargetCurrency      string                `json:"target_currency"`
+	AdjustmentType      string                `json:"adjustment_type"`
+	AdjustmentValue     string                `json:"adjustment_value"`
+	AdjustmentDirection string                `json:"adjustment_direction"`
+	Priority            int32                 `json:"priority"`
+	MinConversionAmount sql.NullString        `json:"min_conversion_amount"`
+	MaxConversionAmount sql.NullString        `json:"max_conversion_amount"`
+	ValidFrom           sql.NullTime          `json:"valid_from"`
+	ValidUntil          sql.NullTime          `json:"valid_until"`
+	IsActive            bool                  `json:"is_active"`
+	CreatedBy           uuid.NullUUID         `json:"created_by"`
+	UpdatedBy           uuid.NullUUID         `json:"updated_by"`
+	CreatedAt           time.Time             `json:"created_at"`
+	UpdatedAt           time.Time             `json:"updated_at"`
+	DeletedAt           sql.NullTime          `json:"deleted_at"`
+	Channels            []string              `json:"channels"`
+	TimeWindows         pqtype.NullRawMessage `json:"time_windows"`
+	ScheduleTimezone    string                `json:"schedule_timezone"`
+	ExcludeHolidays     bool                  `json:"exclude_holidays"`
+	VipLevelName        sql.NullString        `json:"vip_level_name"`
+	VipLevelRank        sql.NullInt32         `json:"vip_level_rank"`
 }
 
 func (q *Queries) GetApplicableRulesForUser(ctx context.Context, arg GetApplicableRulesForUserParams) ([]GetApplicableRulesForUserRow, error) {
-	rows, err := q.db.QueryContext(ctx, getApplicableRulesForUser,
-		arg.SourceCurrency,
-		arg.TargetCurrency,
-		arg.MinConversionAmount,
-		arg.UserID,
-	)
+	rows, err := q.db.QueryContext(ctx, getApplicableRulesForUser, arg.SourceCurrency, arg.TargetCurrency, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -863,6 +931,10 @@ func (q *Queries) GetApplicableRulesForUser(ctx context.Context, arg GetApplicab
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			pq.Array(&i.Channels),
+			&i.TimeWindows,
+			&i.ScheduleTimezone,
+			&i.ExcludeHolidays,
 			&i.VipLevelName,
 			&i.VipLevelRank,
 		); err != nil {
@@ -980,7 +1052,7 @@ func (q *Queries) GetRateAdjustmentImpact(ctx context.Context, arg GetRateAdjust
 }
 
 const getRateAdjustmentRuleByID = `-- name: GetRateAdjustmentRuleByID :one
-SELECT id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at FROM rate_adjustment_rules
+SELECT id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at, channels, time_windows, schedule_timezone, exclude_holidays FROM rate_adjustment_rules
 WHERE id = $1
 `
 
@@ -1009,6 +1081,10 @@ func (q *Queries) GetRateAdjustmentRuleByID(ctx context.Context, id uuid.UUID) (
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		pq.Array(&i.Channels),
+		&i.TimeWindows,
+		&i.ScheduleTimezone,
+		&i.ExcludeHolidays,
 	)
 	return i, err
 }
@@ -1039,7 +1115,7 @@ func (q *Queries) GetRateAdminNotificationByID(ctx context.Context, id uuid.UUID
 }
 
 const getRateChangeByID = `-- name: GetRateChangeByID :one
-SELECT id, source_currency, target_currency, base_rate, adjusted_rate, adjustment_amount, rule_id, rule_name, vip_level_id, vip_level_name, rate_provider, applied_to_user_id, conversion_id, change_reason, changed_by, created_at FROM rate_change_history
+SELECT id, source_currency, target_currency, base_rate, adjusted_rate, adjustment_amount, rule_id, rule_name, vip_level_id, vip_level_name, rate_provider, applied_to_user_id, conversion_id, change_reason, changed_by, created_at, approved_by, change_request_id FROM rate_change_history
 WHERE id = $1
 `
 
@@ -1063,12 +1139,14 @@ func (q *Queries) GetRateChangeByID(ctx context.Context, id uuid.UUID) (RateChan
 		&i.ChangeReason,
 		&i.ChangedBy,
 		&i.CreatedAt,
+		&i.ApprovedBy,
+		&i.ChangeRequestID,
 	)
 	return i, err
 }
 
 const getRateChangesForRule = `-- name: GetRateChangesForRule :many
-SELECT id, source_currency, target_currency, base_rate, adjusted_rate, adjustment_amount, rule_id, rule_name, vip_level_id, vip_level_name, rate_provider, applied_to_user_id, conversion_id, change_reason, changed_by, created_at FROM rate_change_history
+SELECT id, source_currency, target_currency, base_rate, adjusted_rate, adjustment_amount, rule_id, rule_name, vip_level_id, vip_level_name, rate_provider, applied_to_user_id, conversion_id, change_reason, changed_by, created_at, approved_by, change_request_id FROM rate_change_history
 WHERE rule_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -1106,6 +1184,8 @@ func (q *Queries) GetRateChangesForRule(ctx context.Context, arg GetRateChangesF
 			&i.ChangeReason,
 			&i.ChangedBy,
 			&i.CreatedAt,
+			&i.ApprovedBy,
+			&i.ChangeRequestID,
 		); err != nil {
 			return nil, err
 		}
@@ -1121,7 +1201,7 @@ func (q *Queries) GetRateChangesForRule(ctx context.Context, arg GetRateChangesF
 }
 
 const getRateChangesForUser = `-- name: GetRateChangesForUser :many
-SELECT id, source_currency, target_currency, base_rate, adjusted_rate, adjustment_amount, rule_id, rule_name, vip_level_id, vip_level_name, rate_provider, applied_to_user_id, conversion_id, change_reason, changed_by, created_at FROM rate_change_history
+SELECT id, source_currency, target_currency, base_rate, adjusted_rate, adjustment_amount, rule_id, rule_name, vip_level_id, vip_level_name, rate_provider, applied_to_user_id, conversion_id, change_reason, changed_by, created_at, approved_by, change_request_id FROM rate_change_history
 WHERE applied_to_user_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -1159,6 +1239,8 @@ func (q *Queries) GetRateChangesForUser(ctx context.Context, arg GetRateChangesF
 			&i.ChangeReason,
 			&i.ChangedBy,
 			&i.CreatedAt,
+			&i.ApprovedBy,
+			&i.ChangeRequestID,
 		); err != nil {
 			return nil, err
 		}
@@ -1682,7 +1764,7 @@ func (q *Queries) IncrementUserConversionVolume(ctx context.Context, arg Increme
 
 const listActiveRateAdjustmentRules = `-- name: ListActiveRateAdjustmentRules :many
 SELECT 
-    r.id, r.rule_name, r.rule_description, r.vip_level_id, r.is_global_rule, r.source_currency, r.target_currency, r.adjustment_type, r.adjustment_value, r.adjustment_direction, r.priority, r.min_conversion_amount, r.max_conversion_amount, r.valid_from, r.valid_until, r.is_active, r.created_by, r.updated_by, r.created_at, r.updated_at, r.deleted_at,
+    r.id, r.rule_name, r.rule_description, r.vip_level_id, r.is_global_rule, r.source_currency, r.target_currency, r.adjustment_type, r.adjustment_value, r.adjustment_direction, r.priority, r.min_conversion_amount, r.max_conversion_amount, r.valid_from, r.valid_until, r.is_active, r.created_by, r.updated_by, r.created_at, r.updated_at, r.deleted_at, r.channels, r.time_windows, r.schedule_timezone, r.exclude_holidays,
     v.level_name as vip_level_name,
     v.level_code as vip_level_code
 FROM rate_adjustment_rules r
@@ -1695,29 +1777,33 @@ ORDER BY r.priority DESC, r.created_at DESC
 `
 
 type ListActiveRateAdjustmentRulesRow struct {
-	ID                  uuid.UUID      `json:"id"`
-	RuleName            string         `json:"rule_name"`
-	RuleDescription     sql.NullString `json:"rule_description"`
-	VipLevelID          uuid.NullUUID  `json:"vip_level_id"`
-	IsGlobalRule        bool           `json:"is_global_rule"`
-	SourceCurrency      string         `json:"source_currency"`
-	TargetCurrency      string         `json:"target_currency"`
-	AdjustmentType      string         `json:"adjustment_type"`
-	AdjustmentValue     string         `json:"adjustment_value"`
-	AdjustmentDirection string         `json:"adjustment_direction"`
-	Priority            int32          `json:"priority"`
-	MinConversionAmount sql.NullString `json:"min_conversion_amount"`
-	MaxConversionAmount sql.NullString `json:"max_conversion_amount"`
-	ValidFrom           sql.NullTime   `json:"valid_from"`
-	ValidUntil          sql.NullTime   `json:"valid_until"`
-	IsActive            bool           `json:"is_active"`
-	CreatedBy           uuid.NullUUID  `json:"created_by"`
-	UpdatedBy           uuid.NullUUID  `json:"updated_by"`
-	CreatedAt           time.Time      `json:"created_at"`
-	UpdatedAt           time.Time      `json:"updated_at"`
-	DeletedAt           sql.NullTime   `json:"deleted_at"`
-	VipLevelName        sql.NullString `json:"vip_level_name"`
-	VipLevelCode        sql.NullString `json:"vip_level_code"`
+	ID                  uuid.UUID             `json:"id"`
+	RuleName            string                `json:"rule_name"`
+	RuleDescription     sql.NullString        `json:"rule_description"`
+	VipLevelID          uuid.NullUUID         `json:"vip_level_id"`
+	IsGlobalRule        bool                  `json:"is_global_rule"`
+	SourceCurrency      string                `json:"source_currency"`
+	TargetCurrency      string                `json:"target_currency"`
+	AdjustmentType      string                `json:"adjustment_type"`
+	AdjustmentValue     string                `json:"adjustment_value"`
+	AdjustmentDirection string                `json:"adjustment_direction"`
+	Priority            int32                 `json:"priority"`
+	MinConversionAmount sql.NullString        `json:"min_conversion_amount"`
+	MaxConversionAmount sql.NullString        `json:"max_conversion_amount"`
+	ValidFrom           sql.NullTime          `json:"valid_from"`
+	ValidUntil          sql.NullTime          `json:"valid_until"`
+	IsActive            bool                  `json:"is_active"`
+	CreatedBy           uuid.NullUUID         `json:"created_by"`
+	UpdatedBy           uuid.NullUUID         `json:"updated_by"`
+	CreatedAt           time.Time             `json:"created_at"`
+	UpdatedAt           time.Time             `json:"updated_at"`
+	DeletedAt           sql.NullTime          `json:"deleted_at"`
+	Channels            []string              `json:"channels"`
+	TimeWindows         pqtype.NullRawMessage `json:"time_windows"`
+	ScheduleTimezone    string                `json:"schedule_timezone"`
+	ExcludeHolidays     bool                  `json:"exclude_holidays"`
+	VipLevelName        sql.NullString        `json:"vip_level_name"`
+	VipLevelCode        sql.NullString        `json:"vip_level_code"`
 }
 
 func (q *Queries) ListActiveRateAdjustmentRules(ctx context.Context) ([]ListActiveRateAdjustmentRulesRow, error) {
@@ -1751,6 +1837,10 @@ func (q *Queries) ListActiveRateAdjustmentRules(ctx context.Context) ([]ListActi
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			pq.Array(&i.Channels),
+			&i.TimeWindows,
+			&i.ScheduleTimezone,
+			&i.ExcludeHolidays,
 			&i.VipLevelName,
 			&i.VipLevelCode,
 		); err != nil {
@@ -1813,9 +1903,50 @@ func (q *Queries) ListActiveVIPLevels(ctx context.Context) ([]VipLevel, error) {
 	return items, nil
 }
 
+const listPublicHolidays = `-- name: ListPublicHolidays :many
+SELECT id, holiday_date, name, created_by, created_at FROM public_holidays
+WHERE holiday_date >= $1
+  AND holiday_date <= $2
+ORDER BY holiday_date ASC
+`
+
+type ListPublicHolidaysParams struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+func (q *Queries) ListPublicHolidays(ctx context.Context, arg ListPublicHolidaysParams) ([]PublicHoliday, error) {
+	rows, err := q.db.QueryContext(ctx, listPublicHolidays, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PublicHoliday{}
+	for rows.Next() {
+		var i PublicHoliday
+		if err := rows.Scan(
+			&i.ID,
+			&i.HolidayDate,
+			&i.Name,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRateAdjustmentRules = `-- name: ListRateAdjustmentRules :many
 SELECT 
-    r.id, r.rule_name, r.rule_description, r.vip_level_id, r.is_global_rule, r.source_currency, r.target_currency, r.adjustment_type, r.adjustment_value, r.adjustment_direction, r.priority, r.min_conversion_amount, r.max_conversion_amount, r.valid_from, r.valid_until, r.is_active, r.created_by, r.updated_by, r.created_at, r.updated_at, r.deleted_at,
+    r.id, r.rule_name, r.rule_description, r.vip_level_id, r.is_global_rule, r.source_currency, r.target_currency, r.adjustment_type, r.adjustment_value, r.adjustment_direction, r.priority, r.min_conversion_amount, r.max_conversion_amount, r.valid_from, r.valid_until, r.is_active, r.created_by, r.updated_by, r.created_at, r.updated_at, r.deleted_at, r.channels, r.time_windows, r.schedule_timezone, r.exclude_holidays,
     v.level_name as vip_level_name,
     v.level_code as vip_level_code
 FROM rate_adjustment_rules r
@@ -1831,29 +1962,33 @@ type ListRateAdjustmentRulesParams struct {
 }
 
 type ListRateAdjustmentRulesRow struct {
-	ID                  uuid.UUID      `json:"id"`
-	RuleName            string         `json:"rule_name"`
-	RuleDescription     sql.NullString `json:"rule_description"`
-	VipLevelID          uuid.NullUUID  `json:"vip_level_id"`
-	IsGlobalRule        bool           `json:"is_global_rule"`
-	SourceCurrency      string         `json:"source_currency"`
-	TargetCurrency      string         `json:"target_currency"`
-	AdjustmentType      string         `json:"adjustment_type"`
-	AdjustmentValue     string         `json:"adjustment_value"`
-	AdjustmentDirection string         `json:"adjustment_direction"`
-	Priority            int32          `json:"priority"`
-	MinConversionAmount sql.NullString `json:"min_conversion_amount"`
-	MaxConversionAmount sql.NullString `json:"max_conversion_amount"`
-	ValidFrom           sql.NullTime   `json:"valid_from"`
-	ValidUntil          sql.NullTime   `json:"valid_until"`
-	IsActive            bool           `json:"is_active"`
-	CreatedBy           uuid.NullUUID  `json:"created_by"`
-	UpdatedBy           uuid.NullUUID  `json:"updated_by"`
-	CreatedAt           time.Time      `json:"created_at"`
-	UpdatedAt           time.Time      `json:"updated_at"`
-	DeletedAt           sql.NullTime   `json:"deleted_at"`
-	VipLevelName        sql.NullString `json:"vip_level_name"`
-	VipLevelCode        sql.NullString `json:"vip_level_code"`
+	ID                  uuid.UUID             `json:"id"`
+	RuleName            string                `json:"rule_name"`
+	RuleDescription     sql.NullString        `json:"rule_description"`
+	VipLevelID          uuid.NullUUID         `json:"vip_level_id"`
+	IsGlobalRule        bool                  `json:"is_global_rule"`
+	SourceCurrency      string                `json:"source_currency"`
+	TargetCurrency      string                `json:"target_currency"`
+	AdjustmentType      string                `json:"adjustment_type"`
+	AdjustmentValue     string                `json:"adjustment_value"`
+	AdjustmentDirection string                `json:"adjustment_direction"`
+	Priority            int32                 `json:"priority"`
+	MinConversionAmount sql.NullString        `json:"min_conversion_amount"`
+	MaxConversionAmount sql.NullString        `json:"max_conversion_amount"`
+	ValidFrom           sql.NullTime          `json:"valid_from"`
+	ValidUntil          sql.NullTime          `json:"valid_until"`
+	IsActive            bool                  `json:"is_active"`
+	CreatedBy           uuid.NullUUID         `json:"created_by"`
+	UpdatedBy           uuid.NullUUID         `json:"updated_by"`
+	CreatedAt           time.Time             `json:"created_at"`
+	UpdatedAt           time.Time             `json:"updated_at"`
+	DeletedAt           sql.NullTime          `json:"deleted_at"`
+	Channels            []string              `json:"channels"`
+	TimeWindows         pqtype.NullRawMessage `json:"time_windows"`
+	ScheduleTimezone    string                `json:"schedule_timezone"`
+	ExcludeHolidays     bool                  `json:"exclude_holidays"`
+	VipLevelName        sql.NullString        `json:"vip_level_name"`
+	VipLevelCode        sql.NullString        `json:"vip_level_code"`
 }
 
 func (q *Queries) ListRateAdjustmentRules(ctx context.Context, arg ListRateAdjustmentRulesParams) ([]ListRateAdjustmentRulesRow, error) {
@@ -1887,6 +2022,10 @@ func (q *Queries) ListRateAdjustmentRules(ctx context.Context, arg ListRateAdjus
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			pq.Array(&i.Channels),
+			&i.TimeWindows,
+			&i.ScheduleTimezone,
+			&i.ExcludeHolidays,
 			&i.VipLevelName,
 			&i.VipLevelCode,
 		); err != nil {
@@ -1961,7 +2100,7 @@ func (q *Queries) ListRateAdminNotifications(ctx context.Context, arg ListRateAd
 
 const listRateChanges = `-- name: ListRateChanges :many
 SELECT 
-    rch.id, rch.source_currency, rch.target_currency, rch.base_rate, rch.adjusted_rate, rch.adjustment_amount, rch.rule_id, rch.rule_name, rch.vip_level_id, rch.vip_level_name, rch.rate_provider, rch.applied_to_user_id, rch.conversion_id, rch.change_reason, rch.changed_by, rch.created_at,
+    rch.id, rch.source_currency, rch.target_currency, rch.base_rate, rch.adjusted_rate, rch.adjustment_amount, rch.rule_id, rch.rule_name, rch.vip_level_id, rch.vip_level_name, rch.rate_provider, rch.applied_to_user_id, rch.conversion_id, rch.change_reason, rch.changed_by, rch.created_at, rch.approved_by, rch.change_request_id,
     u.email as user_email
 FROM rate_change_history rch
 LEFT JOIN users u ON rch.applied_to_user_id = u.id
@@ -1999,6 +2138,8 @@ type ListRateChangesRow struct {
 	ChangeReason     sql.NullString `json:"change_reason"`
 	ChangedBy        uuid.NullUUID  `json:"changed_by"`
 	CreatedAt        time.Time      `json:"created_at"`
+	ApprovedBy       uuid.NullUUID  `json:"approved_by"`
+	ChangeRequestID  uuid.NullUUID  `json:"change_request_id"`
 	UserEmail        sql.NullString `json:"user_email"`
 }
 
@@ -2035,6 +2176,8 @@ func (q *Queries) ListRateChanges(ctx context.Context, arg ListRateChangesParams
 			&i.ChangeReason,
 			&i.ChangedBy,
 			&i.CreatedAt,
+			&i.ApprovedBy,
+			&i.ChangeRequestID,
 			&i.UserEmail,
 		); err != nil {
 			return nil, err
@@ -2306,7 +2449,7 @@ INSERT INTO rate_change_history (
     changed_by
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
-) RETURNING id, source_currency, target_currency, base_rate, adjusted_rate, adjustment_amount, rule_id, rule_name, vip_level_id, vip_level_name, rate_provider, applied_to_user_id, conversion_id, change_reason, changed_by, created_at
+) RETURNING id, source_currency, target_currency, base_rate, adjusted_rate, adjustment_amount, rule_id, rule_name, vip_level_id, vip_level_name, rate_provider, applied_to_user_id, conversion_id, change_reason, changed_by, created_at, approved_by, change_request_id
 `
 
 type RecordRateChangeParams struct {
@@ -2364,6 +2507,8 @@ func (q *Queries) RecordRateChange(ctx context.Context, arg RecordRateChangePara
 		&i.ChangeReason,
 		&i.ChangedBy,
 		&i.CreatedAt,
+		&i.ApprovedBy,
+		&i.ChangeRequestID,
 	)
 	return i, err
 }
@@ -2372,7 +2517,7 @@ const toggleRateAdjustmentRule = `-- name: ToggleRateAdjustmentRule :one
 UPDATE rate_adjustment_rules
 SET is_active = $2, updated_by = $3
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at
+RETURNING id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at, channels, time_windows, schedule_timezone, exclude_holidays
 `
 
 type ToggleRateAdjustmentRuleParams struct {
@@ -2406,6 +2551,10 @@ func (q *Queries) ToggleRateAdjustmentRule(ctx context.Context, arg ToggleRateAd
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		pq.Array(&i.Channels),
+		&i.TimeWindows,
+		&i.ScheduleTimezone,
+		&i.ExcludeHolidays,
 	)
 	return i, err
 }
@@ -2424,25 +2573,33 @@ SET
     valid_from = COALESCE($11, valid_from),
     valid_until = COALESCE($12, valid_until),
     is_active = COALESCE($13, is_active),
+    channels = COALESCE($14::text[], channels),
+    time_windows = COALESCE($15, time_windows),
+    schedule_timezone = COALESCE($16, schedule_timezone),
+    exclude_holidays = COALESCE($17, exclude_holidays),
     updated_by = $2
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at
+RETURNING id, rule_name, rule_description, vip_level_id, is_global_rule, source_currency, target_currency, adjustment_type, adjustment_value, adjustment_direction, priority, min_conversion_amount, max_conversion_amount, valid_from, valid_until, is_active, created_by, updated_by, created_at, updated_at, deleted_at, channels, time_windows, schedule_timezone, exclude_holidays
 `
 
 type UpdateRateAdjustmentRuleParams struct {
-	ID                  uuid.UUID      `json:"id"`
-	UpdatedBy           uuid.NullUUID  `json:"updated_by"`
-	RuleName            sql.NullString `json:"rule_name"`
-	RuleDescription     sql.NullString `json:"rule_description"`
-	AdjustmentType      sql.NullString `json:"adjustment_type"`
-	AdjustmentValue     sql.NullString `json:"adjustment_value"`
-	AdjustmentDirection sql.NullString `json:"adjustment_direction"`
-	Priority            sql.NullInt32  `json:"priority"`
-	MinConversionAmount sql.NullString `json:"min_conversion_amount"`
-	MaxConversionAmount sql.NullString `json:"max_conversion_amount"`
-	ValidFrom           sql.NullTime   `json:"valid_from"`
-	ValidUntil          sql.NullTime   `json:"valid_until"`
-	IsActive            sql.NullBool   `json:"is_active"`
+	ID                  uuid.UUID             `json:"id"`
+	UpdatedBy           uuid.NullUUID         `json:"updated_by"`
+	RuleName            sql.NullString        `json:"rule_name"`
+	RuleDescription     sql.NullString        `json:"rule_description"`
+	AdjustmentType      sql.NullString        `json:"adjustment_type"`
+	AdjustmentValue     sql.NullString        `json:"adjustment_value"`
+	AdjustmentDirection sql.NullString        `json:"adjustment_direction"`
+	Priority            sql.NullInt32         `json:"priority"`
+	MinConversionAmount sql.NullString        `json:"min_conversion_amount"`
+	MaxConversionAmount sql.NullString        `json:"max_conversion_amount"`
+	ValidFrom           sql.NullTime          `json:"valid_from"`
+	ValidUntil          sql.NullTime          `json:"valid_until"`
+	IsActive            sql.NullBool          `json:"is_active"`
+	Channels            []string              `json:"channels"`
+	TimeWindows         pqtype.NullRawMessage `json:"time_windows"`
+	ScheduleTimezone    sql.NullString        `json:"schedule_timezone"`
+	ExcludeHolidays     sql.NullBool          `json:"exclude_holidays"`
 }
 
 func (q *Queries) UpdateRateAdjustmentRule(ctx context.Context, arg UpdateRateAdjustmentRuleParams) (RateAdjustmentRule, error) {
@@ -2460,6 +2617,10 @@ func (q *Queries) UpdateRateAdjustmentRule(ctx context.Context, arg UpdateRateAd
 		arg.ValidFrom,
 		arg.ValidUntil,
 		arg.IsActive,
+		pq.Array(arg.Channels),
+		arg.TimeWindows,
+		arg.ScheduleTimezone,
+		arg.ExcludeHolidays,
 	)
 	var i RateAdjustmentRule
 	err := row.Scan(
@@ -2484,6 +2645,10 @@ func (q *Queries) UpdateRateAdjustmentRule(ctx context.Context, arg UpdateRateAd
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		pq.Array(&i.Channels),
+		&i.TimeWindows,
+		&i.ScheduleTimezone,
+		&i.ExcludeHolidays,
 	)
 	return i, err
 }
@@ -1126,6 +1126,44 @@ func (q *Queries) GetConversionHistoryByRule(ctx context.Context, conversionRule
 	return items, nil
 }
 
+const getConversionHistoryByTransactionID = `-- name: GetConversionHistoryByTransactionID :one
+SELECT id, conversion_rule_id, user_id, transaction_id, source_currency, target_currency, source_wallet_id, target_wallet_id, trigger_rate, executed_rate, rate_difference_percentage, rate_provider, source_amount, target_amount, fees, net_amount, source_balance_before, source_balance_after, target_balance_before, target_balance_after, execution_type, trigger_type, status, failure_reason, executed_at, created_at FROM conversion_history WHERE transaction_id = $1
+`
+
+func (q *Queries) GetConversionHistoryByTransactionID(ctx context.Context, transactionID uuid.NullUUID) (ConversionHistory, error) {
+	row := q.db.QueryRowContext(ctx, getConversionHistoryByTransactionID, transactionID)
+	var i ConversionHistory
+	err := row.Scan(
+		&i.ID,
+		&i.ConversionRuleID,
+		&i.UserID,
+		&i.TransactionID,
+		&i.SourceCurrency,
+		&i.TargetCurrency,
+		&i.SourceWalletID,
+		&i.TargetWalletID,
+		&i.TriggerRate,
+		&i.ExecutedRate,
+		&i.RateDifferencePercentage,
+		&i.RateProvider,
+		&i.SourceAmount,
+		&i.TargetAmount,
+		&i.Fees,
+		&i.NetAmount,
+		&i.SourceBalanceBefore,
+		&i.SourceBalanceAfter,
+		&i.TargetBalanceBefore,
+		&i.TargetBalanceAfter,
+		&i.ExecutionType,
+		&i.TriggerType,
+		&i.Status,
+		&i.FailureReason,
+		&i.ExecutedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getConversionHistoryByUser = `-- name: GetConversionHistoryByUser :many
 SELECT id, conversion_rule_id, user_id, transaction_id, source_currency, target_currency, source_wallet_id, target_wallet_id, trigger_rate, executed_rate, rate_difference_percentage, rate_provider, source_amount, target_amount, fees, net_amount, source_balance_before, source_balance_after, target_balance_before, target_balance_after, execution_type, trigger_type, status, failure_reason, executed_at, created_at FROM conversion_history
 WHERE user_id = $1
@@ -176,21 +176,21 @@ INSERT INTO card_billing_history (
     currency, billing_period_start, billing_period_end, source_wallet_id, status, transaction_id
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
-) RETURNING id, transaction_id, card_id, user_id, card_plan_id, billing_type, amount, currency, billing_period_start, billing_period_end, source_wallet_id, status, failure_reason, created_at, processed_at
+) RETURNING id, transaction_id, card_id, user_id, card_plan_id, billing_type, amount, currency, billing_period_start, billing_period_end, source_wallet_id, status, failure_reason, created_at, processed_at, charge_source, attempt_count, next_retry_at, provider_reference, billing_run_id
 `
 
 type CreateCardBillingParams struct {
-	CardID             uuid.UUID `json:"card_id"`
-	UserID             uuid.UUID `json:"user_id"`
-	CardPlanID         int64     `json:"card_plan_id"`
-	BillingType        string    `json:"billing_type"`
-	Amount             string    `json:"amount"`
-	Currency           string    `json:"currency"`
-	BillingPeriodStart time.Time `json:"billing_period_start"`
-	BillingPeriodEnd   time.Time `json:"billing_period_end"`
-	SourceWalletID     uuid.UUID `json:"source_wallet_id"`
-	Status             string    `json:"status"`
-	TransactionID      uuid.UUID `json:"transaction_id"`
+	CardID             uuid.UUID     `json:"card_id"`
+	UserID             uuid.UUID     `json:"user_id"`
+	CardPlanID         int64         `json:"card_plan_id"`
+	BillingType        string        `json:"billing_type"`
+	Amount             string        `json:"amount"`
+	Currency           string        `json:"currency"`
+	BillingPeriodStart time.Time     `json:"billing_period_start"`
+	BillingPeriodEnd   time.Time     `json:"billing_period_end"`
+	SourceWalletID     uuid.NullUUID `json:"source_wallet_id"`
+	Status             string        `json:"status"`
+	TransactionID      uuid.UUID     `json:"transaction_id"`
 }
 
 // ============================================================================
@@ -227,6 +227,11 @@ func (q *Queries) CreateCardBilling(ctx context.Context, arg CreateCardBillingPa
 		&i.FailureReason,
 		&i.CreatedAt,
 		&i.ProcessedAt,
+		&i.ChargeSource,
+		&i.AttemptCount,
+		&i.NextRetryAt,
+		&i.ProviderReference,
+		&i.BillingRunID,
 	)
 	return i, err
 }
@@ -298,10 +303,10 @@ const createCardPlan = `-- name: CreateCardPlan :one
 INSERT INTO card_plans (
     name, description, creation_fee, monthly_maintenance_fee,
     monthly_spending_limit, transaction_limit, daily_spending_limit,
-    max_cards_per_user, failed_tx_count_before_block, card_limit
+    max_cards_per_user, failed_tx_count_before_block, card_limit, usage_type
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING id, name, description, is_active, creation_fee, monthly_maintenance_fee, monthly_spending_limit, transaction_limit, daily_spending_limit, card_limit, max_cards_per_user, failed_tx_count_before_block, created_at, updated_at, deleted_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING id, name, description, is_active, creation_fee, monthly_maintenance_fee, monthly_spending_limit, transaction_limit, daily_spending_limit, card_limit, max_cards_per_user, failed_tx_count_before_block, created_at, updated_at, deleted_at, usage_type
 `
 
 type CreateCardPlanParams struct {
@@ -315,6 +320,7 @@ type CreateCardPlanParams struct {
 	MaxCardsPerUser          int32          `json:"max_cards_per_user"`
 	FailedTxCountBeforeBlock sql.NullInt32  `json:"failed_tx_count_before_block"`
 	CardLimit                sql.NullString `json:"card_limit"`
+	UsageType                string         `json:"usage_type"`
 }
 
 func (q *Queries) CreateCardPlan(ctx context.Context, arg CreateCardPlanParams) (CardPlan, error) {
@@ -329,6 +335,7 @@ func (q *Queries) CreateCardPlan(ctx context.Context, arg CreateCardPlanParams)
 		arg.MaxCardsPerUser,
 		arg.FailedTxCountBeforeBlock,
 		arg.CardLimit,
+		arg.UsageType,
 	)
 	var i CardPlan
 	err := row.Scan(
@@ -347,6 +354,7 @@ func (q *Queries) CreateCardPlan(ctx context.Context, arg CreateCardPlanParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.UsageType,
 	)
 	return i, err
 }
@@ -1107,7 +1115,7 @@ func (q *Queries) GetAutoTopupSuccessRate(ctx context.Context, userID uuid.UUID)
 }
 
 const getCardBillingHistory = `-- name: GetCardBillingHistory :many
-SELECT id, transaction_id, card_id, user_id, card_plan_id, billing_type, amount, currency, billing_period_start, billing_period_end, source_wallet_id, status, failure_reason, created_at, processed_at FROM card_billing_history
+SELECT id, transaction_id, card_id, user_id, card_plan_id, billing_type, amount, currency, billing_period_start, billing_period_end, source_wallet_id, status, failure_reason, created_at, processed_at, charge_source, attempt_count, next_retry_at, provider_reference, billing_run_id FROM card_billing_history
 WHERE card_id = $1
 ORDER BY billing_period_start DESC
 LIMIT $2 OFFSET $3
@@ -1144,6 +1152,11 @@ func (q *Queries) GetCardBillingHistory(ctx context.Context, arg GetCardBillingH
 			&i.FailureReason,
 			&i.CreatedAt,
 			&i.ProcessedAt,
+			&i.ChargeSource,
+			&i.AttemptCount,
+			&i.NextRetryAt,
+			&i.ProviderReference,
+			&i.BillingRunID,
 		); err != nil {
 			return nil, err
 		}
@@ -1240,7 +1253,7 @@ func (q *Queries) GetCardFundingHistory(ctx context.Context, arg GetCardFundingH
 }
 
 const getCardPlan = `-- name: GetCardPlan :one
-SELECT id, name, description, is_active, creation_fee, monthly_maintenance_fee, monthly_spending_limit, transaction_limit, daily_spending_limit, card_limit, max_cards_per_user, failed_tx_count_before_block, created_at, updated_at, deleted_at FROM card_plans
+SELECT id, name, description, is_active, creation_fee, monthly_maintenance_fee, monthly_spending_limit, transaction_limit, daily_spending_limit, card_limit, max_cards_per_user, failed_tx_count_before_block, created_at, updated_at, deleted_at, usage_type FROM card_plans
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -1263,12 +1276,13 @@ func (q *Queries) GetCardPlan(ctx context.Context, id int64) (CardPlan, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.UsageType,
 	)
 	return i, err
 }
 
 const getCardPlanByName = `-- name: GetCardPlanByName :one
-SELECT id, name, description, is_active, creation_fee, monthly_maintenance_fee, monthly_spending_limit, transaction_limit, daily_spending_limit, card_limit, max_cards_per_user, failed_tx_count_before_block, created_at, updated_at, deleted_at FROM card_plans
+SELECT id, name, description, is_active, creation_fee, monthly_maintenance_fee, monthly_spending_limit, transaction_limit, daily_spending_limit, card_limit, max_cards_per_user, failed_tx_count_before_block, created_at, updated_at, deleted_at, usage_type FROM card_plans
 WHERE name = $1 AND deleted_at IS NULL
 `
 
@@ -1291,6 +1305,7 @@ func (q *Queries) GetCardPlanByName(ctx context.Context, name string) (CardPlan,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.UsageType,
 	)
 	return i, err
 }
@@ -2264,7 +2279,7 @@ func (q *Queries) GetUserActiveCardsCount(ctx context.Context, userID uuid.UUID)
 }
 
 const getUserBillingHistory = `-- name: GetUserBillingHistory :many
-SELECT cbh.id, cbh.transaction_id, cbh.card_id, cbh.user_id, cbh.card_plan_id, cbh.billing_type, cbh.amount, cbh.currency, cbh.billing_period_start, cbh.billing_period_end, cbh.source_wallet_id, cbh.status, cbh.failure_reason, cbh.created_at, cbh.processed_at, vc.card_name, cp.name as plan_name
+SELECT cbh.id, cbh.transaction_id, cbh.card_id, cbh.user_id, cbh.card_plan_id, cbh.billing_type, cbh.amount, cbh.currency, cbh.billing_period_start, cbh.billing_period_end, cbh.source_wallet_id, cbh.status, cbh.failure_reason, cbh.created_at, cbh.processed_at, cbh.charge_source, cbh.attempt_count, cbh.next_retry_at, cbh.provider_reference, cbh.billing_run_id, vc.card_name, cp.name as plan_name
 FROM card_billing_history cbh
 JOIN virtual_cards vc ON cbh.card_id = vc.id
 JOIN card_plans cp ON cbh.card_plan_id = cp.id
@@ -2290,11 +2305,16 @@ type GetUserBillingHistoryRow struct {
 	Currency           string         `json:"currency"`
 	BillingPeriodStart time.Time      `json:"billing_period_start"`
 	BillingPeriodEnd   time.Time      `json:"billing_period_end"`
-	SourceWalletID     uuid.UUID      `json:"source_wallet_id"`
+	SourceWalletID     uuid.NullUUID  `json:"source_wallet_id"`
 	Status             string         `json:"status"`
 	FailureReason      sql.NullString `json:"failure_reason"`
 	CreatedAt          time.Time      `json:"created_at"`
 	ProcessedAt        sql.NullTime   `json:"processed_at"`
+	ChargeSource       sql.NullString `json:"charge_source"`
+	AttemptCount       int32          `json:"attempt_count"`
+	NextRetryAt        sql.NullTime   `json:"next_retry_at"`
+	ProviderReference  sql.NullString `json:"provider_reference"`
+	BillingRunID       uuid.NullUUID  `json:"billing_run_id"`
 	CardName           string         `json:"card_name"`
 	PlanName           string         `json:"plan_name"`
 }
@@ -2324,6 +2344,11 @@ func (q *Queries) GetUserBillingHistory(ctx context.Context, arg GetUserBillingH
 			&i.FailureReason,
 			&i.CreatedAt,
 			&i.ProcessedAt,
+			&i.ChargeSource,
+			&i.AttemptCount,
+			&i.NextRetryAt,
+			&i.ProviderReference,
+			&i.BillingRunID,
 			&i.CardName,
 			&i.PlanName,
 		); err != nil {
@@ -3391,7 +3416,7 @@ func (q *Queries) ListAllSubscriptions(ctx context.Context) ([]ListAllSubscripti
 }
 
 const listCardPlans = `-- name: ListCardPlans :many
-SELECT id, name, description, is_active, creation_fee, monthly_maintenance_fee, monthly_spending_limit, transaction_limit, daily_spending_limit, card_limit, max_cards_per_user, failed_tx_count_before_block, created_at, updated_at, deleted_at FROM card_plans
+SELECT id, name, description, is_active, creation_fee, monthly_maintenance_fee, monthly_spending_limit, transaction_limit, daily_spending_limit, card_limit, max_cards_per_user, failed_tx_count_before_block, created_at, updated_at, deleted_at, usage_type FROM card_plans
 WHERE deleted_at IS NULL
 ORDER BY creation_fee ASC
 `
@@ -3421,6 +3446,7 @@ func (q *Queries) ListCardPlans(ctx context.Context) ([]CardPlan, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.UsageType,
 		); err != nil {
 			return nil, err
 		}
@@ -3864,7 +3890,7 @@ SET
     failure_reason = $2,
     processed_at = NOW()
 WHERE id = $3
-RETURNING id, transaction_id, card_id, user_id, card_plan_id, billing_type, amount, currency, billing_period_start, billing_period_end, source_wallet_id, status, failure_reason, created_at, processed_at
+RETURNING id, transaction_id, card_id, user_id, card_plan_id, billing_type, amount, currency, billing_period_start, billing_period_end, source_wallet_id, status, failure_reason, created_at, processed_at, charge_source, attempt_count, next_retry_at, provider_reference, billing_run_id
 `
 
 type UpdateCardBillingStatusParams struct {
@@ -3892,6 +3918,11 @@ func (q *Queries) UpdateCardBillingStatus(ctx context.Context, arg UpdateCardBil
 		&i.FailureReason,
 		&i.CreatedAt,
 		&i.ProcessedAt,
+		&i.ChargeSource,
+		&i.AttemptCount,
+		&i.NextRetryAt,
+		&i.ProviderReference,
+		&i.BillingRunID,
 	)
 	return i, err
 }
@@ -4003,9 +4034,10 @@ SET
     is_active = COALESCE($9, is_active),
     card_limit = COALESCE($10, card_limit),
     failed_tx_count_before_block = COALESCE($11, failed_tx_count_before_block),
+    usage_type = COALESCE($12, usage_type),
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, name, description, is_active, creation_fee, monthly_maintenance_fee, monthly_spending_limit, transaction_limit, daily_spending_limit, card_limit, max_cards_per_user, failed_tx_count_before_block, created_at, updated_at, deleted_at
+RETURNING id, name, description, is_active, creation_fee, monthly_maintenance_fee, monthly_spending_limit, transaction_limit, daily_spending_limit, card_limit, max_cards_per_user, failed_tx_count_before_block, created_at, updated_at, deleted_at, usage_type
 `
 
 type UpdateCardPlanParams struct {
@@ -4020,6 +4052,7 @@ type UpdateCardPlanParams struct {
 	IsActive                 sql.NullBool   `json:"is_active"`
 	CardLimit                sql.NullString `json:"card_limit"`
 	FailedTxCountBeforeBlock sql.NullInt32  `json:"failed_tx_count_before_block"`
+	UsageType                sql.NullString `json:"usage_type"`
 }
 
 func (q *Queries) UpdateCardPlan(ctx context.Context, arg UpdateCardPlanParams) (CardPlan, error) {
@@ -4035,6 +4068,7 @@ func (q *Queries) UpdateCardPlan(ctx context.Context, arg UpdateCardPlanParams)
 		arg.IsActive,
 		arg.CardLimit,
 		arg.FailedTxCountBeforeBlock,
+		arg.UsageType,
 	)
 	var i CardPlan
 	err := row.Scan(
@@ -4053,6 +4087,7 @@ func (q *Queries) UpdateCardPlan(ctx context.Context, arg UpdateCardPlanParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.UsageType,
 	)
 	return i, err
 }
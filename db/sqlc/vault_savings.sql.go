@@ -73,10 +73,11 @@ INSERT INTO vault_savings (
     status,
     vault_type,
     category,
+    funding_currency,
     next_yield_calculation
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW() + INTERVAL '1 day'
-) RETURNING id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NOW() + INTERVAL '1 day'
+) RETURNING id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency
 `
 
 type CreateVaultGoalParams struct {
@@ -94,6 +95,7 @@ type CreateVaultGoalParams struct {
 	Status            string                `json:"status"`
 	VaultType         string                `json:"vault_type"`
 	Category          string                `json:"category"`
+	FundingCurrency   sql.NullString        `json:"funding_currency"`
 }
 
 // ============================================================================
@@ -115,6 +117,7 @@ func (q *Queries) CreateVaultGoal(ctx context.Context, arg CreateVaultGoalParams
 		arg.Status,
 		arg.VaultType,
 		arg.Category,
+		arg.FundingCurrency,
 	)
 	var i VaultSaving
 	err := row.Scan(
@@ -139,6 +142,17 @@ func (q *Queries) CreateVaultGoal(ctx context.Context, arg CreateVaultGoalParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.YieldConfigID,
+		&i.LockTermDays,
+		&i.LockedApy,
+		&i.LockedPrincipal,
+		&i.LockedAt,
+		&i.MaturesAt,
+		&i.MaturityAction,
+		&i.EarlyWithdrawalPolicy,
+		&i.EarlyWithdrawalPenaltyRate,
+		&i.RolloverCount,
+		&i.FundingCurrency,
 	)
 	return i, err
 }
@@ -298,21 +312,33 @@ INSERT INTO vault_yield_configs (
     is_active,
     effective_from,
     effective_until,
-    notes
+    notes,
+    product_type,
+    lock_term_days,
+    early_withdrawal_policy,
+    early_withdrawal_penalty_rate,
+    apy_tiers,
+    withholding_tax_rate
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at, product_type, lock_term_days, early_withdrawal_policy, early_withdrawal_penalty_rate, apy_tiers, withholding_tax_rate
 `
 
 type CreateYieldConfigParams struct {
-	Currency           string         `json:"currency"`
-	ApyRate            string         `json:"apy_rate"`
-	MinBalanceForYield string         `json:"min_balance_for_yield"`
-	CompoundFrequency  sql.NullString `json:"compound_frequency"`
-	IsActive           bool           `json:"is_active"`
-	EffectiveFrom      time.Time      `json:"effective_from"`
-	EffectiveUntil     sql.NullTime   `json:"effective_until"`
-	Notes              sql.NullString `json:"notes"`
+	Currency                   string                `json:"currency"`
+	ApyRate                    string                `json:"apy_rate"`
+	MinBalanceForYield         string                `json:"min_balance_for_yield"`
+	CompoundFrequency          sql.NullString        `json:"compound_frequency"`
+	IsActive                   bool                  `json:"is_active"`
+	EffectiveFrom              time.Time             `json:"effective_from"`
+	EffectiveUntil             sql.NullTime          `json:"effective_until"`
+	Notes                      sql.NullString        `json:"notes"`
+	ProductType                string                `json:"product_type"`
+	LockTermDays               sql.NullInt32         `json:"lock_term_days"`
+	EarlyWithdrawalPolicy      sql.NullString        `json:"early_withdrawal_policy"`
+	EarlyWithdrawalPenaltyRate string                `json:"early_withdrawal_penalty_rate"`
+	ApyTiers                   pqtype.NullRawMessage `json:"apy_tiers"`
+	WithholdingTaxRate         string                `json:"withholding_tax_rate"`
 }
 
 // ============================================================================
@@ -328,6 +354,12 @@ func (q *Queries) CreateYieldConfig(ctx context.Context, arg CreateYieldConfigPa
 		arg.EffectiveFrom,
 		arg.EffectiveUntil,
 		arg.Notes,
+		arg.ProductType,
+		arg.LockTermDays,
+		arg.EarlyWithdrawalPolicy,
+		arg.EarlyWithdrawalPenaltyRate,
+		arg.ApyTiers,
+		arg.WithholdingTaxRate,
 	)
 	var i VaultYieldConfig
 	err := row.Scan(
@@ -342,6 +374,12 @@ func (q *Queries) CreateYieldConfig(ctx context.Context, arg CreateYieldConfigPa
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProductType,
+		&i.LockTermDays,
+		&i.EarlyWithdrawalPolicy,
+		&i.EarlyWithdrawalPenaltyRate,
+		&i.ApyTiers,
+		&i.WithholdingTaxRate,
 	)
 	return i, err
 }
@@ -426,7 +464,7 @@ func (q *Queries) EnableAutoSave(ctx context.Context, arg EnableAutoSaveParams)
 }
 
 const filterVaultsByStatus = `-- name: FilterVaultsByStatus :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE user_id = $1 
   AND status = ANY($2::text[])
 ORDER BY created_at DESC
@@ -468,6 +506,17 @@ func (q *Queries) FilterVaultsByStatus(ctx context.Context, arg FilterVaultsBySt
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -483,7 +532,7 @@ func (q *Queries) FilterVaultsByStatus(ctx context.Context, arg FilterVaultsBySt
 }
 
 const getActiveVaultGoalsByUserID = `-- name: GetActiveVaultGoalsByUserID :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE user_id = $1 AND status = 'active'
 ORDER BY created_at DESC
 `
@@ -519,6 +568,17 @@ func (q *Queries) GetActiveVaultGoalsByUserID(ctx context.Context, userID uuid.U
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -534,8 +594,9 @@ func (q *Queries) GetActiveVaultGoalsByUserID(ctx context.Context, userID uuid.U
 }
 
 const getActiveYieldConfigByCurrency = `-- name: GetActiveYieldConfigByCurrency :one
-SELECT id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at FROM vault_yield_configs
+SELECT id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at, product_type, lock_term_days, early_withdrawal_policy, early_withdrawal_penalty_rate, apy_tiers, withholding_tax_rate FROM vault_yield_configs
 WHERE currency = $1
+  AND product_type = 'flexible'
   AND is_active = TRUE
   AND effective_from <= NOW()
   AND (effective_until IS NULL OR effective_until > NOW())
@@ -558,12 +619,18 @@ func (q *Queries) GetActiveYieldConfigByCurrency(ctx context.Context, currency s
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProductType,
+		&i.LockTermDays,
+		&i.EarlyWithdrawalPolicy,
+		&i.EarlyWithdrawalPenaltyRate,
+		&i.ApyTiers,
+		&i.WithholdingTaxRate,
 	)
 	return i, err
 }
 
 const getAllActiveYieldConfigs = `-- name: GetAllActiveYieldConfigs :many
-SELECT id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at FROM vault_yield_configs
+SELECT id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at, product_type, lock_term_days, early_withdrawal_policy, early_withdrawal_penalty_rate, apy_tiers, withholding_tax_rate FROM vault_yield_configs
 ORDER BY currency
 `
 
@@ -588,6 +655,12 @@ func (q *Queries) GetAllActiveYieldConfigs(ctx context.Context) ([]VaultYieldCon
 			&i.Notes,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ProductType,
+			&i.LockTermDays,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.ApyTiers,
+			&i.WithholdingTaxRate,
 		); err != nil {
 			return nil, err
 		}
@@ -603,7 +676,7 @@ func (q *Queries) GetAllActiveYieldConfigs(ctx context.Context) ([]VaultYieldCon
 }
 
 const getAllVaultGoals = `-- name: GetAllVaultGoals :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 ORDER BY created_at DESC
 `
 
@@ -638,6 +711,17 @@ func (q *Queries) GetAllVaultGoals(ctx context.Context) ([]VaultSaving, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -653,7 +737,7 @@ func (q *Queries) GetAllVaultGoals(ctx context.Context) ([]VaultSaving, error) {
 }
 
 const getDueVaultsWithRecurringRules = `-- name: GetDueVaultsWithRecurringRules :many
-SELECT vs.id, vs.user_id, vs.vault_name, vs.description, vs.goal_amount, vs.current_balance, vs.category, vs.currency, vs.auto_save_enabled, vs.auto_save_frequency, vs.auto_save_amount, vs.next_auto_save, vs.recurring_rule, vs.total_yield_earned, vs.next_yield_calculation, vs.last_yield_calculation, vs.status, vs.vault_type, vs.created_at, vs.updated_at, vs.completed_at FROM vault_savings vs
+SELECT vs.id, vs.user_id, vs.vault_name, vs.description, vs.goal_amount, vs.current_balance, vs.category, vs.currency, vs.auto_save_enabled, vs.auto_save_frequency, vs.auto_save_amount, vs.next_auto_save, vs.recurring_rule, vs.total_yield_earned, vs.next_yield_calculation, vs.last_yield_calculation, vs.status, vs.vault_type, vs.created_at, vs.updated_at, vs.completed_at, vs.yield_config_id, vs.lock_term_days, vs.locked_apy, vs.locked_principal, vs.locked_at, vs.matures_at, vs.maturity_action, vs.early_withdrawal_policy, vs.early_withdrawal_penalty_rate, vs.rollover_count, vs.funding_currency FROM vault_savings vs
 WHERE vs.auto_save_enabled = true
 AND vs.recurring_rule IS NOT NULL
 AND vs.status = 'active'
@@ -702,6 +786,17 @@ func (q *Queries) GetDueVaultsWithRecurringRules(ctx context.Context, limit int3
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -1081,6 +1176,59 @@ func (q *Queries) GetUserRetentionMetrics(ctx context.Context, arg GetUserRetent
 	return items, nil
 }
 
+const getUserVaultBalancesByCurrency = `-- name: GetUserVaultBalancesByCurrency :many
+SELECT
+    currency,
+    COUNT(*) as vault_count,
+    COUNT(*) FILTER (WHERE status = 'active') as active_vaults,
+    COUNT(*) FILTER (WHERE status = 'completed') as completed_vaults,
+    COALESCE(SUM(current_balance), 0)::text as total_balance,
+    COALESCE(SUM(total_yield_earned), 0)::text as total_yield_earned
+FROM vault_savings
+WHERE user_id = $1 AND status != 'cancelled'
+GROUP BY currency
+ORDER BY currency
+`
+
+type GetUserVaultBalancesByCurrencyRow struct {
+	Currency         string `json:"currency"`
+	VaultCount       int64  `json:"vault_count"`
+	ActiveVaults     int64  `json:"active_vaults"`
+	CompletedVaults  int64  `json:"completed_vaults"`
+	TotalBalance     string `json:"total_balance"`
+	TotalYieldEarned string `json:"total_yield_earned"`
+}
+
+func (q *Queries) GetUserVaultBalancesByCurrency(ctx context.Context, userID uuid.UUID) ([]GetUserVaultBalancesByCurrencyRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserVaultBalancesByCurrency, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUserVaultBalancesByCurrencyRow{}
+	for rows.Next() {
+		var i GetUserVaultBalancesByCurrencyRow
+		if err := rows.Scan(
+			&i.Currency,
+			&i.VaultCount,
+			&i.ActiveVaults,
+			&i.CompletedVaults,
+			&i.TotalBalance,
+			&i.TotalYieldEarned,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserVaultsSummary = `-- name: GetUserVaultsSummary :one
 SELECT 
     COUNT(*) as total_vaults,
@@ -1172,7 +1320,7 @@ func (q *Queries) GetVaultCompletionStats(ctx context.Context, createdAt time.Ti
 }
 
 const getVaultGoalByID = `-- name: GetVaultGoalByID :one
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE id = $1 AND status != 'cancelled'
 `
 
@@ -1201,6 +1349,17 @@ func (q *Queries) GetVaultGoalByID(ctx context.Context, id uuid.UUID) (VaultSavi
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.YieldConfigID,
+		&i.LockTermDays,
+		&i.LockedApy,
+		&i.LockedPrincipal,
+		&i.LockedAt,
+		&i.MaturesAt,
+		&i.MaturityAction,
+		&i.EarlyWithdrawalPolicy,
+		&i.EarlyWithdrawalPenaltyRate,
+		&i.RolloverCount,
+		&i.FundingCurrency,
 	)
 	return i, err
 }
@@ -1247,7 +1406,7 @@ func (q *Queries) GetVaultGoalProgress(ctx context.Context, id uuid.UUID) (GetVa
 }
 
 const getVaultGoalsByUserID = `-- name: GetVaultGoalsByUserID :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE user_id = $1 AND status != 'cancelled'
 ORDER BY created_at DESC
 `
@@ -1283,6 +1442,17 @@ func (q *Queries) GetVaultGoalsByUserID(ctx context.Context, userID uuid.UUID) (
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -1298,7 +1468,7 @@ func (q *Queries) GetVaultGoalsByUserID(ctx context.Context, userID uuid.UUID) (
 }
 
 const getVaultGoalsByUserIDAndCurrency = `-- name: GetVaultGoalsByUserIDAndCurrency :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE user_id = $1 AND currency = $2 AND status != 'cancelled'
 ORDER BY created_at DESC
 `
@@ -1339,6 +1509,17 @@ func (q *Queries) GetVaultGoalsByUserIDAndCurrency(ctx context.Context, arg GetV
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -1987,7 +2168,7 @@ func (q *Queries) GetVaultsDashboardMetrics(ctx context.Context) (GetVaultsDashb
 }
 
 const getVaultsDueForAutoSave = `-- name: GetVaultsDueForAutoSave :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE auto_save_enabled = TRUE
   AND status = 'active'
   AND next_auto_save <= NOW()
@@ -2025,6 +2206,17 @@ func (q *Queries) GetVaultsDueForAutoSave(ctx context.Context) ([]VaultSaving, e
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -2040,9 +2232,12 @@ func (q *Queries) GetVaultsDueForAutoSave(ctx context.Context) ([]VaultSaving, e
 }
 
 const getVaultsDueForYieldCalculation = `-- name: GetVaultsDueForYieldCalculation :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE status = 'active'
-  AND current_balance > 0
+  AND (current_balance > 0 OR EXISTS (
+      SELECT 1 FROM vault_yield_accruals a
+      WHERE a.vault_id = vault_savings.id AND a.yield_id IS NULL
+  ))
   AND (next_yield_calculation IS NULL OR next_yield_calculation <= NOW())
 ORDER BY next_yield_calculation ASC NULLS FIRST
 LIMIT $1
@@ -2079,6 +2274,17 @@ func (q *Queries) GetVaultsDueForYieldCalculation(ctx context.Context, limit int
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -2094,7 +2300,7 @@ func (q *Queries) GetVaultsDueForYieldCalculation(ctx context.Context, limit int
 }
 
 const getVaultsWithActiveRecurringRules = `-- name: GetVaultsWithActiveRecurringRules :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency
 FROM vault_savings
 WHERE recurring_rule IS NOT NULL
   AND recurring_rule->>'enabled' = 'true'
@@ -2133,6 +2339,17 @@ func (q *Queries) GetVaultsWithActiveRecurringRules(ctx context.Context) ([]Vaul
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -2148,7 +2365,7 @@ func (q *Queries) GetVaultsWithActiveRecurringRules(ctx context.Context) ([]Vaul
 }
 
 const getVaultsWithDueRecurringDeposits = `-- name: GetVaultsWithDueRecurringDeposits :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency
 FROM vault_savings
 WHERE recurring_rule IS NOT NULL 
   AND recurring_rule->>'enabled' = 'true'
@@ -2189,6 +2406,17 @@ func (q *Queries) GetVaultsWithDueRecurringDeposits(ctx context.Context, dollar_
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -2204,7 +2432,7 @@ func (q *Queries) GetVaultsWithDueRecurringDeposits(ctx context.Context, dollar_
 }
 
 const getVaultsWithLowBalance = `-- name: GetVaultsWithLowBalance :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE status = 'active'
   AND current_balance > 0
   AND current_balance < goal_amount * 0.1  -- Less than 10% of goal
@@ -2243,6 +2471,17 @@ func (q *Queries) GetVaultsWithLowBalance(ctx context.Context, limit int32) ([]V
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -2258,7 +2497,7 @@ func (q *Queries) GetVaultsWithLowBalance(ctx context.Context, limit int32) ([]V
 }
 
 const getVaultsWithRecurringRules = `-- name: GetVaultsWithRecurringRules :many
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE recurring_rule IS NOT NULL
   AND status = 'active'
   AND (recurring_rule->>'enabled')::boolean = true
@@ -2298,6 +2537,17 @@ func (q *Queries) GetVaultsWithRecurringRules(ctx context.Context, limit int32)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -2313,7 +2563,7 @@ func (q *Queries) GetVaultsWithRecurringRules(ctx context.Context, limit int32)
 }
 
 const getYieldConfigByID = `-- name: GetYieldConfigByID :one
-SELECT id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at FROM vault_yield_configs
+SELECT id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at, product_type, lock_term_days, early_withdrawal_policy, early_withdrawal_penalty_rate, apy_tiers, withholding_tax_rate FROM vault_yield_configs
 WHERE id = $1
 `
 
@@ -2332,12 +2582,18 @@ func (q *Queries) GetYieldConfigByID(ctx context.Context, id uuid.UUID) (VaultYi
 		&i.Notes,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProductType,
+		&i.LockTermDays,
+		&i.EarlyWithdrawalPolicy,
+		&i.EarlyWithdrawalPenaltyRate,
+		&i.ApyTiers,
+		&i.WithholdingTaxRate,
 	)
 	return i, err
 }
 
 const getYieldConfigHistory = `-- name: GetYieldConfigHistory :many
-SELECT id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at FROM vault_yield_configs
+SELECT id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at, product_type, lock_term_days, early_withdrawal_policy, early_withdrawal_penalty_rate, apy_tiers, withholding_tax_rate FROM vault_yield_configs
 WHERE currency = $1
 ORDER BY effective_from DESC
 LIMIT $2 OFFSET $3
@@ -2370,6 +2626,12 @@ func (q *Queries) GetYieldConfigHistory(ctx context.Context, arg GetYieldConfigH
 			&i.Notes,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ProductType,
+			&i.LockTermDays,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.ApyTiers,
+			&i.WithholdingTaxRate,
 		); err != nil {
 			return nil, err
 		}
@@ -2385,7 +2647,7 @@ func (q *Queries) GetYieldConfigHistory(ctx context.Context, arg GetYieldConfigH
 }
 
 const getYieldConfigsByCurrency = `-- name: GetYieldConfigsByCurrency :many
-SELECT id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at FROM vault_yield_configs
+SELECT id, currency, apy_rate, min_balance_for_yield, compound_frequency, is_active, effective_from, effective_until, notes, created_at, updated_at, product_type, lock_term_days, early_withdrawal_policy, early_withdrawal_penalty_rate, apy_tiers, withholding_tax_rate FROM vault_yield_configs
 WHERE currency = $1
 ORDER BY effective_from DESC
 `
@@ -2411,6 +2673,12 @@ func (q *Queries) GetYieldConfigsByCurrency(ctx context.Context, currency string
 			&i.Notes,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ProductType,
+			&i.LockTermDays,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.ApyTiers,
+			&i.WithholdingTaxRate,
 		); err != nil {
 			return nil, err
 		}
@@ -2493,7 +2761,7 @@ func (q *Queries) IncrementVaultBalance(ctx context.Context, arg IncrementVaultB
 
 const lockVaultForUpdate = `-- name: LockVaultForUpdate :one
 
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE id = $1
 FOR UPDATE
 `
@@ -2524,6 +2792,17 @@ func (q *Queries) LockVaultForUpdate(ctx context.Context, id uuid.UUID) (VaultSa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.YieldConfigID,
+		&i.LockTermDays,
+		&i.LockedApy,
+		&i.LockedPrincipal,
+		&i.LockedAt,
+		&i.MaturesAt,
+		&i.MaturityAction,
+		&i.EarlyWithdrawalPolicy,
+		&i.EarlyWithdrawalPenaltyRate,
+		&i.RolloverCount,
+		&i.FundingCurrency,
 	)
 	return i, err
 }
@@ -2584,7 +2863,7 @@ func (q *Queries) ProcessVaultWithdrawal(ctx context.Context, arg ProcessVaultWi
 const searchVaultsByName = `-- name: SearchVaultsByName :many
 
 
-SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at FROM vault_savings
+SELECT id, user_id, vault_name, description, goal_amount, current_balance, category, currency, auto_save_enabled, auto_save_frequency, auto_save_amount, next_auto_save, recurring_rule, total_yield_earned, next_yield_calculation, last_yield_calculation, status, vault_type, created_at, updated_at, completed_at, yield_config_id, lock_term_days, locked_apy, locked_principal, locked_at, matures_at, maturity_action, early_withdrawal_policy, early_withdrawal_penalty_rate, rollover_count, funding_currency FROM vault_savings
 WHERE user_id = $1 
   AND vault_name ILIKE '%' || $2 || '%'
   AND status != 'cancelled'
@@ -2645,6 +2924,17 @@ func (q *Queries) SearchVaultsByName(ctx context.Context, arg SearchVaultsByName
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.YieldConfigID,
+			&i.LockTermDays,
+			&i.LockedApy,
+			&i.LockedPrincipal,
+			&i.LockedAt,
+			&i.MaturesAt,
+			&i.MaturityAction,
+			&i.EarlyWithdrawalPolicy,
+			&i.EarlyWithdrawalPenaltyRate,
+			&i.RolloverCount,
+			&i.FundingCurrency,
 		); err != nil {
 			return nil, err
 		}
@@ -2801,18 +3091,26 @@ SET apy_rate = COALESCE($2, apy_rate),
     is_active = COALESCE($5, is_active),
     effective_until = COALESCE($6, effective_until),
     notes = COALESCE($7, notes),
+    early_withdrawal_policy = COALESCE($8, early_withdrawal_policy),
+    early_withdrawal_penalty_rate = COALESCE($9, early_withdrawal_penalty_rate),
+    apy_tiers = COALESCE($10, apy_tiers),
+    withholding_tax_rate = COALESCE($11, withholding_tax_rate),
     updated_at = NOW()
 WHERE id = $1
 `
 
 type UpdateYieldConfigParams struct {
-	ID                 uuid.UUID      `json:"id"`
-	ApyRate            sql.NullString `json:"apy_rate"`
-	MinBalanceForYield sql.NullString `json:"min_balance_for_yield"`
-	CompoundFrequency  sql.NullString `json:"compound_frequency"`
-	IsActive           sql.NullBool   `json:"is_active"`
-	EffectiveUntil     sql.NullTime   `json:"effective_until"`
-	Notes              sql.NullString `json:"notes"`
+	ID                         uuid.UUID             `json:"id"`
+	ApyRate                    sql.NullString        `json:"apy_rate"`
+	MinBalanceForYield         sql.NullString        `json:"min_balance_for_yield"`
+	CompoundFrequency          sql.NullString        `json:"compound_frequency"`
+	IsActive                   sql.NullBool          `json:"is_active"`
+	EffectiveUntil             sql.NullTime          `json:"effective_until"`
+	Notes                      sql.NullString        `json:"notes"`
+	EarlyWithdrawalPolicy      sql.NullString        `json:"early_withdrawal_policy"`
+	EarlyWithdrawalPenaltyRate sql.NullString        `json:"early_withdrawal_penalty_rate"`
+	ApyTiers                   pqtype.NullRawMessage `json:"apy_tiers"`
+	WithholdingTaxRate         sql.NullString        `json:"withholding_tax_rate"`
 }
 
 func (q *Queries) UpdateYieldConfig(ctx context.Context, arg UpdateYieldConfigParams) error {
@@ -2824,6 +3122,10 @@ func (q *Queries) UpdateYieldConfig(ctx context.Context, arg UpdateYieldConfigPa
 		arg.IsActive,
 		arg.EffectiveUntil,
 		arg.Notes,
+		arg.EarlyWithdrawalPolicy,
+		arg.EarlyWithdrawalPenaltyRate,
+		arg.ApyTiers,
+		arg.WithholdingTaxRate,
 	)
 	return err
 }
@@ -2870,3 +3172,26 @@ func (q *Queries) UpdateYieldTracking(ctx context.Context, arg UpdateYieldTracki
 	)
 	return err
 }
+
+const vaultNameTaken = `-- name: VaultNameTaken :one
+SELECT EXISTS (
+    SELECT 1 FROM vault_savings
+    WHERE user_id = $1
+      AND LOWER(vault_name) = LOWER($2)
+      AND status != 'cancelled'
+      AND id != $3
+)
+`
+
+type VaultNameTakenParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	VaultName string    `json:"vault_name"`
+	ExcludeID uuid.UUID `json:"exclude_id"`
+}
+
+func (q *Queries) VaultNameTaken(ctx context.Context, arg VaultNameTakenParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, vaultNameTaken, arg.UserID, arg.VaultName, arg.ExcludeID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
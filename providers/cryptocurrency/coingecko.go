@@ -67,6 +67,12 @@ func NewRatesProvider() *CoinGeckoProvider {
 	}
 }
 
+// SupportsCoin reports whether the coin symbol maps to a CoinGecko coin ID
+func (c *CoinGeckoProvider) SupportsCoin(coin string) bool {
+	_, ok := supportedCoins[coin]
+	return ok
+}
+
 func (c *CoinGeckoProvider) GetUSDRate(coin *string) (string, error) {
 
 	base, err := url.Parse(c.BaseURL)
@@ -152,6 +152,7 @@ const (
 	EventYieldConfigDeleted        = "yield.config.deleted"
 	EventYieldConfigDeactivated    = "yield.config.deactivated"
 	EventYieldConfigActivated      = "yield.config.activated"
+	EventWithholdingTaxRemitted    = "yield.withholding_tax.remitted"
 	EventInterestPaid              = "interest.paid"
 	EventVaultSuspended            = "vault.suspended"
 	EventVaultReactivated          = "vault.reactivated"
@@ -174,6 +175,13 @@ const (
 	EventResumeConversionRule = "smart-convert.rule.resumed"
 	EventDeleteConversionRule = "smart-convert.rule.deleted"
 	EventManualConversion     = "smart-convert.manual"
+	EventPlaceLimitOrder      = "smart-convert.order.placed"
+	EventCancelLimitOrder     = "smart-convert.order.cancelled"
+	EventCreateDCAPlan        = "smart-convert.dca.created"
+	EventUpdateDCAPlan        = "smart-convert.dca.updated"
+	EventPauseDCAPlan         = "smart-convert.dca.paused"
+	EventResumeDCAPlan        = "smart-convert.dca.resumed"
+	EventCancelDCAPlan        = "smart-convert.dca.cancelled"
 
 	// Reward events
 	EventCreateRewardConfig     = "rewards.config.created"
@@ -202,6 +210,10 @@ const (
 	EventUnfreezeCard       = "card.unfrozen"
 	EventDeleteCard         = "card.deleted"
 	EventCreateCardPlan     = "card.plan.created"
+	EventUpdateCardControls = "card.controls.updated"
+	EventRunCardBilling     = "card.billing.run"
+	EventOpenCardDispute    = "card.dispute.opened"
+	EventUpdateCardDispute  = "card.dispute.updated"
 
 	EventUpdateAutoTopup                 = "subscriptions.auto_topup.updated"
 	EventUpdateSubscriptionStatus        = "subscriptions.status.updated"
@@ -0,0 +1,374 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	aggregationMethod  = "median_filtered_weighted_mean"
+	sourceFetchTimeout = 8 * time.Second
+	// an outage keeps failing on every request, so admins are paged at most once per pair per window
+	pageThrottle = 15 * time.Minute
+)
+
+// AggregatorConfig tunes consensus. Values are read from the environment and fall back to defaults.
+type AggregatorConfig struct {
+	// quotes further than this from the median are rejected as outliers
+	MaxDeviationPct float64 `mapstructure:"RATE_MAX_DEVIATION_PCT"`
+	// a published rate is stale after this many seconds
+	TTLSeconds int `mapstructure:"RATE_TTL_SECONDS"`
+	// accepted quotes required for consensus, capped at the number of sources covering the pair
+	MinSources int `mapstructure:"RATE_MIN_SOURCES"`
+}
+
+func LoadAggregatorConfig() AggregatorConfig {
+	var c AggregatorConfig
+	if err := utils.LoadCustomConfig(utils.EnvPath, &c); err != nil {
+		c = AggregatorConfig{}
+	}
+	if c.MaxDeviationPct <= 0 {
+		c.MaxDeviationPct = 2
+	}
+	if c.TTLSeconds <= 0 {
+		c.TTLSeconds = 120
+	}
+	if c.MinSources <= 0 {
+		c.MinSources = 2
+	}
+	return c
+}
+
+// AdminPager raises an alert for the on-call admins
+type AdminPager interface {
+	CreateAdminAlert(ctx context.Context, severity, title, message, source string) (*db.AdminAlert, error)
+}
+
+// SourceQuote is one source's contribution to a published rate
+type SourceQuote struct {
+	Source    string          `json:"source"`
+	Rate      decimal.Decimal `json:"rate"`
+	Weight    decimal.Decimal `json:"weight"`
+	FetchedAt time.Time       `json:"fetched_at,omitempty"`
+	Inverted  bool            `json:"inverted,omitempty"` // quoted by the source as to->from
+	Accepted  bool            `json:"accepted"`
+	Reason    string          `json:"reason,omitempty"` // why the quote was rejected
+}
+
+type pairKey struct{ from, to string }
+
+// RateAggregator queries every source covering a pair, filters stale quotes and outliers,
+// and publishes a weighted consensus rate that stays fresh for the configured TTL.
+type RateAggregator struct {
+	store   *db.Store
+	sources []SourceConfig
+	config  AggregatorConfig
+	pager   AdminPager
+	logger  *logging.Logger
+
+	mu        sync.Mutex
+	published map[pairKey]*ExchangeRate
+	pairLocks map[pairKey]*sync.Mutex
+	lastPaged map[pairKey]time.Time
+}
+
+func NewRateAggregator(store *db.Store, sources []SourceConfig, config AggregatorConfig, pager AdminPager, logger *logging.Logger) *RateAggregator {
+	return &RateAggregator{
+		store:     store,
+		sources:   sources,
+		config:    config,
+		pager:     pager,
+		logger:    logger,
+		published: make(map[pairKey]*ExchangeRate),
+		pairLocks: make(map[pairKey]*sync.Mutex),
+		lastPaged: make(map[pairKey]time.Time),
+	}
+}
+
+// covers reports whether the source quotes the pair directly or inverted
+func covers(src RateSource, from, to string) (ok, inverted bool) {
+	if src.Supports(from, to) {
+		return true, false
+	}
+	if src.Supports(to, from) {
+		return true, true
+	}
+	return false, false
+}
+
+// requiredSources returns how many non-optional sources cover the pair
+func (a *RateAggregator) requiredSources(from, to string) int {
+	n := 0
+	for _, sc := range a.sources {
+		if ok, _ := covers(sc.Source, from, to); ok && !sc.Optional {
+			n++
+		}
+	}
+	return n
+}
+
+// Supports reports whether at least one non-optional source covers the pair
+func (a *RateAggregator) Supports(from, to string) bool {
+	return a.requiredSources(from, to) > 0
+}
+
+// GetRate returns the published consensus rate for the pair, re-aggregating once it is stale
+func (a *RateAggregator) GetRate(ctx context.Context, from, to string) (*ExchangeRate, error) {
+	key := pairKey{from, to}
+
+	if rate := a.cached(key); rate != nil {
+		return rate, nil
+	}
+
+	// one aggregation per pair at a time; concurrent callers reuse its result
+	lock := a.pairLock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if rate := a.cached(key); rate != nil {
+		return rate, nil
+	}
+
+	return a.aggregate(ctx, from, to)
+}
+
+func (a *RateAggregator) cached(key pairKey) *ExchangeRate {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if rate, ok := a.published[key]; ok && !rate.IsStale() {
+		return rate
+	}
+	return nil
+}
+
+func (a *RateAggregator) pairLock(key pairKey) *sync.Mutex {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if l, ok := a.pairLocks[key]; ok {
+		return l
+	}
+	l := &sync.Mutex{}
+	a.pairLocks[key] = l
+	return l
+}
+
+func (a *RateAggregator) aggregate(ctx context.Context, from, to string) (*ExchangeRate, error) {
+	quotes := a.collectQuotes(ctx, from, to)
+
+	accepted := filterQuotes(quotes, decimal.NewFromFloat(a.config.MaxDeviationPct))
+
+	quorum := a.config.MinSources
+	if required := a.requiredSources(from, to); required < quorum {
+		quorum = required
+	}
+	if quorum < 1 {
+		quorum = 1
+	}
+
+	if len(accepted) < quorum {
+		a.pageNoConsensus(from, to, quorum, quotes)
+		return nil, fmt.Errorf("%w: %s/%s has %d of %d required fresh quotes", ErrNoConsensusRate, from, to, len(accepted), quorum)
+	}
+
+	var weighted, totalWeight decimal.Decimal
+	names := make([]string, 0, len(accepted))
+	for _, q := range accepted {
+		weighted = weighted.Add(q.Rate.Mul(q.Weight))
+		totalWeight = totalWeight.Add(q.Weight)
+		names = append(names, q.Source)
+	}
+	consensus := weighted.Div(totalWeight)
+
+	now := time.Now()
+	rate := &ExchangeRate{
+		From:      from,
+		To:        to,
+		Rate:      consensus,
+		Provider:  fmt.Sprintf("Consensus: %s", strings.Join(names, ",")),
+		Time:      now,
+		ExpiresAt: now.Add(time.Duration(a.config.TTLSeconds) * time.Second),
+		Sources:   quotes,
+	}
+
+	a.persist(ctx, rate, len(accepted))
+
+	a.mu.Lock()
+	a.published[pairKey{from, to}] = rate
+	a.mu.Unlock()
+
+	return rate, nil
+}
+
+// collectQuotes queries every covering source concurrently
+func (a *RateAggregator) collectQuotes(ctx context.Context, from, to string) []SourceQuote {
+	quotes := make([]SourceQuote, 0, len(a.sources))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, sc := range a.sources {
+		ok, inverted := covers(sc.Source, from, to)
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(sc SourceConfig, inverted bool) {
+			defer wg.Done()
+
+			fetchCtx, cancel := context.WithTimeout(ctx, sourceFetchTimeout)
+			defer cancel()
+
+			q := SourceQuote{Source: sc.Source.Name(), Weight: sc.Weight, Inverted: inverted}
+
+			var rate decimal.Decimal
+			var fetchedAt time.Time
+			var err error
+			if inverted {
+				rate, fetchedAt, err = sc.Source.Fetch(fetchCtx, to, from)
+				if err == nil && rate.IsPositive() {
+					rate = decimal.NewFromInt(1).Div(rate)
+				}
+			} else {
+				rate, fetchedAt, err = sc.Source.Fetch(fetchCtx, from, to)
+			}
+
+			switch {
+			case err != nil:
+				q.Reason = fmt.Sprintf("error: %v", err)
+			case !rate.IsPositive():
+				q.Reason = "non-positive rate"
+			case sc.MaxAge > 0 && time.Since(fetchedAt) > sc.MaxAge:
+				q.Rate, q.FetchedAt = rate, fetchedAt
+				q.Reason = fmt.Sprintf("stale: last updated %s ago", time.Since(fetchedAt).Round(time.Second))
+			default:
+				q.Rate, q.FetchedAt, q.Accepted = rate, fetchedAt, true
+			}
+
+			if q.Reason != "" && a.logger != nil {
+				a.logger.Warn(fmt.Sprintf("Rate source %s rejected for %s/%s: %s", q.Source, from, to, q.Reason))
+			}
+
+			mu.Lock()
+			quotes = append(quotes, q)
+			mu.Unlock()
+		}(sc, inverted)
+	}
+	wg.Wait()
+
+	sort.Slice(quotes, func(i, j int) bool { return quotes[i].Source < quotes[j].Source })
+	return quotes
+}
+
+// filterQuotes rejects quotes further than maxDeviationPct from the median of the fresh quotes
+// and returns the survivors. Rejections are recorded on the quotes in place.
+func filterQuotes(quotes []SourceQuote, maxDeviationPct decimal.Decimal) []SourceQuote {
+	fresh := make([]decimal.Decimal, 0, len(quotes))
+	for _, q := range quotes {
+		if q.Accepted {
+			fresh = append(fresh, q.Rate)
+		}
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	median := medianOf(fresh)
+	accepted := make([]SourceQuote, 0, len(fresh))
+	for i := range quotes {
+		if !quotes[i].Accepted {
+			continue
+		}
+		deviation := quotes[i].Rate.Sub(median).Abs().Div(median).Mul(decimal.NewFromInt(100))
+		if deviation.GreaterThan(maxDeviationPct) {
+			quotes[i].Accepted = false
+			quotes[i].Reason = fmt.Sprintf("outlier: %s%% from median %s", deviation.Round(2), median)
+			continue
+		}
+		accepted = append(accepted, quotes[i])
+	}
+	return accepted
+}
+
+func medianOf(values []decimal.Decimal) decimal.Decimal {
+	sorted := append([]decimal.Decimal(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return sorted[mid-1].Add(sorted[mid]).Div(decimal.NewFromInt(2))
+}
+
+// persist stores the published rate with every contributing and rejected quote
+func (a *RateAggregator) persist(ctx context.Context, rate *ExchangeRate, acceptedCount int) {
+	if a.store == nil {
+		return
+	}
+
+	sources, err := json.Marshal(rate.Sources)
+	if err != nil {
+		a.logger.Error(fmt.Sprintf("Failed to encode sources for %s/%s: %v", rate.From, rate.To, err))
+		return
+	}
+
+	_, err = a.store.InsertPublishedRate(ctx, db.InsertPublishedRateParams{
+		BaseCurrency:  rate.From,
+		QuoteCurrency: rate.To,
+		Rate:          rate.Rate.String(),
+		Method:        aggregationMethod,
+		SourceCount:   int32(len(rate.Sources)),
+		AcceptedCount: int32(acceptedCount),
+		Sources:       sources,
+		PublishedAt:   rate.Time,
+		ExpiresAt:     rate.ExpiresAt,
+	})
+	if err != nil {
+		a.logger.Error(fmt.Sprintf("Failed to store published rate for %s/%s: %v", rate.From, rate.To, err))
+	}
+}
+
+// pageNoConsensus alerts admins that conversions on the pair are halted
+func (a *RateAggregator) pageNoConsensus(from, to string, quorum int, quotes []SourceQuote) {
+	key := pairKey{from, to}
+
+	a.mu.Lock()
+	if time.Since(a.lastPaged[key]) < pageThrottle {
+		a.mu.Unlock()
+		return
+	}
+	a.lastPaged[key] = time.Now()
+	a.mu.Unlock()
+
+	details := make([]string, 0, len(quotes))
+	for _, q := range quotes {
+		if q.Accepted {
+			details = append(details, fmt.Sprintf("%s=%s", q.Source, q.Rate))
+		} else {
+			details = append(details, fmt.Sprintf("%s (%s)", q.Source, q.Reason))
+		}
+	}
+	message := fmt.Sprintf("No fresh consensus rate for %s/%s: needed %d agreeing sources. Conversions on this pair are halted. Sources: %s",
+		from, to, quorum, strings.Join(details, "; "))
+
+	a.logger.Error(message)
+	if a.pager == nil {
+		return
+	}
+
+	go func() {
+		if _, err := a.pager.CreateAdminAlert(context.Background(), "critical", fmt.Sprintf("Rate consensus lost for %s/%s", from, to), message, "rate-aggregator"); err != nil {
+			a.logger.Error(fmt.Sprintf("Failed to page admins about %s/%s consensus: %v", from, to, err))
+		}
+	}()
+}
@@ -22,14 +22,22 @@ func (e *ExchangeRateError) Error() string {
 var (
 	ErrInvalidCurrencyPair = &ExchangeRateError{Code: "INVALID_CURRENCY_PAIR", Message: "Invalid currency pair"}
 	ErrRateNotAvailable    = &ExchangeRateError{Code: "RATE_NOT_AVAILABLE", Message: "Exchange rate not available"}
+	ErrNoConsensusRate     = &ExchangeRateError{Code: "NO_CONSENSUS_RATE", Message: "No fresh consensus exchange rate"}
 )
 
 type ExchangeRate struct {
-	From     string          `json:"from"`
-	To       string          `json:"to"`
-	Rate     decimal.Decimal `json:"rate"`
-	Provider string          `json:"provider"`
-	Time     time.Time       `json:"time"`
+	From      string          `json:"from"`
+	To        string          `json:"to"`
+	Rate      decimal.Decimal `json:"rate"`
+	Provider  string          `json:"provider"`
+	Time      time.Time       `json:"time"`
+	ExpiresAt time.Time       `json:"expires_at,omitempty"`
+	Sources   []SourceQuote   `json:"sources,omitempty"`
+}
+
+// IsStale reports whether a published rate has outlived its TTL
+func (r *ExchangeRate) IsStale() bool {
+	return !r.ExpiresAt.IsZero() && time.Now().After(r.ExpiresAt)
 }
 
 type GetRateRequest struct {
@@ -0,0 +1,74 @@
+package exchangerate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/tasks"
+)
+
+const (
+	publishedRateRetentionTaskID = "published-rate-retention"
+	publishedRateRetention       = 90 * 24 * time.Hour
+)
+
+// PublishedRateScheduler prunes published consensus rates past their retention once per interval
+type PublishedRateScheduler struct {
+	taskScheduler *tasks.TaskScheduler
+	service       *ExchangeRateService
+	logger        *logging.Logger
+	interval      time.Duration
+}
+
+func NewPublishedRateScheduler(
+	taskScheduler *tasks.TaskScheduler,
+	service *ExchangeRateService,
+	logger *logging.Logger,
+	interval time.Duration,
+) *PublishedRateScheduler {
+	if interval == 0 {
+		interval = 24 * time.Hour
+	}
+
+	return &PublishedRateScheduler{
+		taskScheduler: taskScheduler,
+		service:       service,
+		logger:        logger,
+		interval:      interval,
+	}
+}
+
+// Start registers and schedules the retention task
+func (s *PublishedRateScheduler) Start() error {
+	s.logger.Info("Starting published rate scheduler...")
+
+	if _, err := s.taskScheduler.AddTask(publishedRateRetentionTaskID, "Prune Published Rates", s.prune, s.interval); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to register %s task: %v", publishedRateRetentionTaskID, err))
+		return err
+	}
+	s.taskScheduler.ScheduleTask(publishedRateRetentionTaskID, 10*time.Minute)
+
+	s.logger.Info(fmt.Sprintf("Published rate scheduler started. Interval: %s", s.interval))
+	return nil
+}
+
+// Stop halts the scheduler
+func (s *PublishedRateScheduler) Stop() error {
+	s.logger.Info("Stopping published rate scheduler...")
+	s.taskScheduler.StopTask(publishedRateRetentionTaskID)
+	s.logger.Info("Published rate scheduler stopped")
+	return nil
+}
+
+// Errors are logged rather than returned so a failed run does not stall the recurring task.
+func (s *PublishedRateScheduler) prune(ctx context.Context) error {
+	pruned, err := s.service.PrunePublishedRates(ctx, time.Now().UTC().Add(-publishedRateRetention))
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Published rate pruning failed: %v", err))
+		return nil
+	}
+	s.logger.Info(fmt.Sprintf("Pruned %d published rates", pruned))
+	return nil
+}
@@ -2,31 +2,41 @@ package exchangerate
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
 	"github.com/SwiftFiat/SwiftFiat-Backend/providers/cryptocurrency"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
 	"github.com/shopspring/decimal"
 )
 
-// ExchangeRateService handles real-time exchange rate fetching
+// ExchangeRateService handles real-time exchange rate fetching. Direct rates come from the
+// multi-source aggregator; other pairs are triangulated through USD.
 type ExchangeRateService struct {
-	cryptomusProvider *cryptocurrency.CryptomusProvider
-	logger            *logging.Logger
-	httpClient        *http.Client
+	aggregator *RateAggregator
+	store      *db.Store
+	logger     *logging.Logger
 }
 
-func NewExchangeRateService(cryptomusProvider *cryptocurrency.CryptomusProvider, logger *logging.Logger) *ExchangeRateService {
+func NewExchangeRateService(
+	store *db.Store,
+	cryptomusProvider *cryptocurrency.CryptomusProvider,
+	coinGeckoProvider *cryptocurrency.CoinGeckoProvider,
+	pager AdminPager,
+	logger *logging.Logger,
+) *ExchangeRateService {
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	sources := defaultSources(store, cryptomusProvider, coinGeckoProvider, httpClient)
+
 	return &ExchangeRateService{
-		cryptomusProvider: cryptomusProvider,
-		logger:            logger,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		aggregator: NewRateAggregator(store, sources, LoadAggregatorConfig(), pager, logger),
+		store:      store,
+		logger:     logger,
 	}
 }
 
@@ -39,31 +49,31 @@ func (s *ExchangeRateService) GetExchangeRate(ctx context.Context, from, to stri
 	if err == nil {
 		return rate, nil
 	}
+	// sources cover the pair but disagree or are stale: halt rather than route around them
+	if errors.Is(err, ErrNoConsensusRate) {
+		return nil, err
+	}
 
 	// If direct rate fails, try triangular arbitrage through USD
 	s.logger.Info(fmt.Sprintf("Direct rate failed, trying triangular: %v", err))
 	rate, err = s.getTriangularRate(ctx, from, to)
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("Triangular rate failed: %v", err))
+		if errors.Is(err, ErrNoConsensusRate) {
+			return nil, err
+		}
 		return nil, ErrRateNotAvailable
 	}
 
 	return rate, nil
 }
 
-// getDirectRate attempts to get rate directly between two currencies
+// getDirectRate returns the aggregated consensus rate for pairs covered by at least one source
 func (s *ExchangeRateService) getDirectRate(ctx context.Context, from, to string) (*ExchangeRate, error) {
-	// For crypto pairs (USDT, USDC), use Cryptomus
-	if s.isCrypto(from) {
-		return s.getCryptoRate(ctx, from, to)
-	}
-
-	// For fiat pairs, use external API
-	if from == "NGN" || to == "NGN" {
-		return s.getFiatRate(ctx, from, to)
+	if !s.aggregator.Supports(from, to) {
+		return nil, fmt.Errorf("no direct rate available")
 	}
-
-	return nil, fmt.Errorf("no direct rate available")
+	return s.aggregator.GetRate(ctx, from, to)
 }
 
 // getTriangularRate calculates rate through USD intermediary
@@ -83,145 +93,40 @@ func (s *ExchangeRateService) getTriangularRate(ctx context.Context, from, to st
 	// Calculate final rate: from -> to
 	finalRate := fromToUSD.Rate.Mul(usdToTarget.Rate)
 
-	return &ExchangeRate{
-		From:     from,
-		To:       to,
-		Rate:     finalRate,
-		Provider: fmt.Sprintf("Triangular: %s,%s", fromToUSD.Provider, usdToTarget.Provider),
-		Time:     time.Now(),
-	}, nil
-}
-
-// getCryptoRate gets rate for crypto currencies using Cryptomus
-func (s *ExchangeRateService) getCryptoRate(ctx context.Context, from, to string) (*ExchangeRate, error) {
-	// Cryptomus provides rates to USD
-	if to == "USD" {
-		rateStr, err := s.cryptomusProvider.GetUSDRate(from)
-		if err != nil {
-			return nil, fmt.Errorf("cryptomus rate fetch failed: %w", err)
-		}
-
-		rate, err := decimal.NewFromString(rateStr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid rate format: %w", err)
-		}
-
-		return &ExchangeRate{
-			From:     from,
-			To:       "USD",
-			Rate:     rate,
-			Provider: "Cryptomus",
-			Time:     time.Now(),
-		}, nil
-	}
-
-	// For other conversions, go through USD
-	return nil, fmt.Errorf("crypto rate only available to USD")
-}
-
-// getFiatRate gets fiat currency rates
-func (s *ExchangeRateService) getFiatRate(ctx context.Context, from, to string) (*ExchangeRate, error) {
-	// For NGN pairs, use a reliable Nigerian forex API
-	if from == "NGN" || to == "NGN" {
-		return s.getNGNRate(ctx, from, to)
-	}
-
-	// For other fiat pairs, use exchangerate-api.com or similar
-	return s.getGenericFiatRate(ctx, from, to)
-}
-
-// getNGNRate gets NGN exchange rates from reliable Nigerian source
-func (s *ExchangeRateService) getNGNRate(ctx context.Context, from, to string) (*ExchangeRate, error) {
-	// Use exchangerate-api.com for NGN rates (free tier: 1,500 requests/month)
-	// Or use: https://api.exchangerate-api.com/v4/latest/USD
-
-	baseCurrency := from
-	if to == "USD" || to == "NGN" {
-		baseCurrency = "USD"
-	}
-
-	url := fmt.Sprintf("https://api.exchangerate-api.com/v4/latest/%s", baseCurrency)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch rate: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		s.logger.Error(fmt.Sprintf("Rate API error: %d - %s", resp.StatusCode, string(body)))
-		return nil, fmt.Errorf("rate API returned status %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Base  string             `json:"base"`
-		Rates map[string]float64 `json:"rates"`
-		Date  string             `json:"date"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	// Calculate the rate
-	var rate decimal.Decimal
-	if from == baseCurrency {
-		// Direct conversion
-		targetRate, exists := result.Rates[to]
-		if !exists {
-			return nil, fmt.Errorf("rate for %s not found", to)
-		}
-		rate = decimal.NewFromFloat(targetRate)
-	} else {
-		// Inverse conversion
-		fromRate, exists := result.Rates[from]
-		if !exists {
-			return nil, fmt.Errorf("rate for %s not found", from)
-		}
-		toRate, exists := result.Rates[to]
-		if !exists {
-			return nil, fmt.Errorf("rate for %s not found", to)
-		}
-		// Calculate: from -> base -> to
-		rate = decimal.NewFromFloat(toRate / fromRate)
+	// the derived rate goes stale with whichever leg expires first
+	expiresAt := fromToUSD.ExpiresAt
+	if expiresAt.IsZero() || (!usdToTarget.ExpiresAt.IsZero() && usdToTarget.ExpiresAt.Before(expiresAt)) {
+		expiresAt = usdToTarget.ExpiresAt
 	}
 
 	return &ExchangeRate{
-		From:     from,
-		To:       to,
-		Rate:     rate,
-		Provider: "ExchangeRate-API",
-		Time:     time.Now(),
+		From:      from,
+		To:        to,
+		Rate:      finalRate,
+		Provider:  fmt.Sprintf("Triangular: %s,%s", fromToUSD.Provider, usdToTarget.Provider),
+		Time:      time.Now(),
+		ExpiresAt: expiresAt,
 	}, nil
 }
 
-// getGenericFiatRate gets generic fiat rates
-func (s *ExchangeRateService) getGenericFiatRate(ctx context.Context, from, to string) (*ExchangeRate, error) {
-	// For now, delegate to NGN rate function which handles multiple currencies
-	return s.getNGNRate(ctx, from, to)
+// ListPublishedRates returns the consensus rates published for a pair, newest first, with their sources
+func (s *ExchangeRateService) ListPublishedRates(ctx context.Context, base, quote string, limit, offset int32) ([]db.PublishedRate, error) {
+	return s.store.ListPublishedRates(ctx, db.ListPublishedRatesParams{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Limit:         limit,
+		Offset:        offset,
+	})
+}
+
+// PrunePublishedRates deletes published rates older than the cutoff
+func (s *ExchangeRateService) PrunePublishedRates(ctx context.Context, before time.Time) (int64, error) {
+	return s.store.DeletePublishedRatesBefore(ctx, before)
 }
 
 // isCrypto checks if currency is a cryptocurrency
 func (s *ExchangeRateService) isCrypto(currency string) bool {
-	cryptos := map[string]bool{
-		"USDT": true,
-		"USDC": true,
-		"BTC":  true,
-		"ETH":  true,
-		"DOGE": true,
-		"LTC":  true,
-		"BNB":  true,
-		"TRX":  true,
-		"SOL":  true,
-		"TON":  true,
-	}
-	return cryptos[currency]
+	return isCryptoCurrency(currency)
 }
 
 // CalculateConversionAmount calculates the target amount based on source amount and rate
@@ -0,0 +1,283 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/providers/cryptocurrency"
+	"github.com/shopspring/decimal"
+)
+
+// RateSource is one upstream feed the aggregator can query for a currency pair
+type RateSource interface {
+	Name() string
+	Supports(from, to string) bool
+	// Fetch returns the rate for from->to and the time the source last updated it
+	Fetch(ctx context.Context, from, to string) (decimal.Decimal, time.Time, error)
+}
+
+// SourceConfig registers a source with the aggregator
+type SourceConfig struct {
+	Source RateSource
+	Weight decimal.Decimal
+	// quotes older than MaxAge are rejected as stale
+	MaxAge time.Duration
+	// optional sources contribute when they have a quote but never count towards the quorum
+	Optional bool
+}
+
+var cryptoCurrencies = map[string]bool{
+	"USDT": true,
+	"USDC": true,
+	"BTC":  true,
+	"ETH":  true,
+	"DOGE": true,
+	"LTC":  true,
+	"BNB":  true,
+	"TRX":  true,
+	"SOL":  true,
+	"TON":  true,
+}
+
+func isCryptoCurrency(currency string) bool {
+	return cryptoCurrencies[currency]
+}
+
+// ── Cryptomus ────────────────────────────────────────────────────────────────
+
+type cryptomusSource struct {
+	provider *cryptocurrency.CryptomusProvider
+}
+
+func (s *cryptomusSource) Name() string { return "cryptomus" }
+
+func (s *cryptomusSource) Supports(from, to string) bool {
+	return s.provider != nil && isCryptoCurrency(from) && to == "USD"
+}
+
+func (s *cryptomusSource) Fetch(ctx context.Context, from, to string) (decimal.Decimal, time.Time, error) {
+	rateStr, err := s.provider.GetUSDRate(from)
+	if err != nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("cryptomus rate fetch failed: %w", err)
+	}
+	rate, err := decimal.NewFromString(rateStr)
+	if err != nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("invalid rate format: %w", err)
+	}
+	return rate, time.Now(), nil
+}
+
+// ── CoinGecko ────────────────────────────────────────────────────────────────
+
+type coinGeckoSource struct {
+	provider *cryptocurrency.CoinGeckoProvider
+}
+
+func (s *coinGeckoSource) Name() string { return "coingecko" }
+
+func (s *coinGeckoSource) Supports(from, to string) bool {
+	return s.provider != nil && isCryptoCurrency(from) && to == "USD" && s.provider.SupportsCoin(strings.ToLower(from))
+}
+
+func (s *coinGeckoSource) Fetch(ctx context.Context, from, to string) (decimal.Decimal, time.Time, error) {
+	coin := strings.ToLower(from)
+	rateStr, err := s.provider.GetUSDRate(&coin)
+	if err != nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("coingecko rate fetch failed: %w", err)
+	}
+	rate, err := decimal.NewFromString(rateStr)
+	if err != nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("invalid rate format: %w", err)
+	}
+	return rate, time.Now(), nil
+}
+
+// ── Manual rates (exchange_rates table) ──────────────────────────────────────
+
+type manualSource struct {
+	store *db.Store
+}
+
+func (s *manualSource) Name() string { return "manual" }
+
+func (s *manualSource) Supports(from, to string) bool { return s.store != nil }
+
+func (s *manualSource) Fetch(ctx context.Context, from, to string) (decimal.Decimal, time.Time, error) {
+	rate, err := s.store.GetLatestManualExchangeRate(ctx, db.GetLatestManualExchangeRateParams{
+		BaseCurrency:  from,
+		QuoteCurrency: to,
+	})
+	if err != nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("manual rate not found for %s/%s: %w", from, to, err)
+	}
+	value, err := decimal.NewFromString(rate.Rate)
+	if err != nil {
+		return decimal.Zero, time.Time{}, fmt.Errorf("invalid manual rate: %w", err)
+	}
+	return value, rate.EffectiveTime, nil
+}
+
+// ── Fiat feeds ───────────────────────────────────────────────────────────────
+
+// fiatTable is a USD-based rate table as published by a fiat feed
+type fiatTable struct {
+	rates     map[string]decimal.Decimal
+	updatedAt time.Time
+	fetchedAt time.Time
+}
+
+// fiatFeedSource reads a USD-based rate table from a public fiat feed. Free tiers have tight
+// quotas and only update daily, so the table is cached between aggregator runs.
+type fiatFeedSource struct {
+	name       string
+	url        string
+	parse      func(body []byte) (*fiatTable, error)
+	httpClient *http.Client
+	refresh    time.Duration
+
+	mu    sync.Mutex
+	table *fiatTable
+}
+
+func (s *fiatFeedSource) Name() string { return s.name }
+
+func (s *fiatFeedSource) Supports(from, to string) bool {
+	return !isCryptoCurrency(from) && !isCryptoCurrency(to)
+}
+
+func (s *fiatFeedSource) Fetch(ctx context.Context, from, to string) (decimal.Decimal, time.Time, error) {
+	table, err := s.getTable(ctx)
+	if err != nil {
+		return decimal.Zero, time.Time{}, err
+	}
+
+	fromRate, ok := table.rates[from]
+	if !ok || !fromRate.IsPositive() {
+		return decimal.Zero, time.Time{}, fmt.Errorf("rate for %s not found", from)
+	}
+	toRate, ok := table.rates[to]
+	if !ok {
+		return decimal.Zero, time.Time{}, fmt.Errorf("rate for %s not found", to)
+	}
+
+	return toRate.Div(fromRate), table.updatedAt, nil
+}
+
+func (s *fiatFeedSource) getTable(ctx context.Context) (*fiatTable, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.table != nil && time.Since(s.table.fetchedAt) < s.refresh {
+		return s.table, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rate API returned status %d", resp.StatusCode)
+	}
+
+	table, err := s.parse(body)
+	if err != nil {
+		return nil, err
+	}
+	table.rates["USD"] = decimal.NewFromInt(1)
+	table.fetchedAt = time.Now()
+
+	s.table = table
+	return table, nil
+}
+
+// parseExchangeRateAPI parses https://api.exchangerate-api.com/v4/latest/USD
+func parseExchangeRateAPI(body []byte) (*fiatTable, error) {
+	var result struct {
+		Rates           map[string]float64 `json:"rates"`
+		TimeLastUpdated int64              `json:"time_last_updated"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return newFiatTable(result.Rates, time.Unix(result.TimeLastUpdated, 0), false), nil
+}
+
+// parseOpenERAPI parses https://open.er-api.com/v6/latest/USD
+func parseOpenERAPI(body []byte) (*fiatTable, error) {
+	var result struct {
+		Result             string             `json:"result"`
+		Rates              map[string]float64 `json:"rates"`
+		TimeLastUpdateUnix int64              `json:"time_last_update_unix"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.Result != "success" {
+		return nil, fmt.Errorf("rate API returned result %q", result.Result)
+	}
+	return newFiatTable(result.Rates, time.Unix(result.TimeLastUpdateUnix, 0), false), nil
+}
+
+// parseCurrencyAPI parses the jsDelivr-hosted currency-api USD table (lowercase codes, daily date)
+func parseCurrencyAPI(body []byte) (*fiatTable, error) {
+	var result struct {
+		Date string             `json:"date"`
+		USD  map[string]float64 `json:"usd"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	updatedAt, err := time.Parse("2006-01-02", result.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", result.Date, err)
+	}
+	return newFiatTable(result.USD, updatedAt, true), nil
+}
+
+func newFiatTable(rates map[string]float64, updatedAt time.Time, upperKeys bool) *fiatTable {
+	table := &fiatTable{rates: make(map[string]decimal.Decimal, len(rates)), updatedAt: updatedAt}
+	for code, rate := range rates {
+		if upperKeys {
+			code = strings.ToUpper(code)
+		}
+		table.rates[code] = decimal.NewFromFloat(rate)
+	}
+	return table
+}
+
+// defaultSources returns the sources queried by the aggregator
+func defaultSources(store *db.Store, cryptomus *cryptocurrency.CryptomusProvider, coinGecko *cryptocurrency.CoinGeckoProvider, httpClient *http.Client) []SourceConfig {
+	one := decimal.NewFromInt(1)
+	fiatFeed := func(name, url string, parse func([]byte) (*fiatTable, error)) *fiatFeedSource {
+		return &fiatFeedSource{name: name, url: url, parse: parse, httpClient: httpClient, refresh: 15 * time.Minute}
+	}
+
+	return []SourceConfig{
+		{Source: &cryptomusSource{provider: cryptomus}, Weight: one, MaxAge: 5 * time.Minute},
+		{Source: &coinGeckoSource{provider: coinGecko}, Weight: one, MaxAge: 5 * time.Minute},
+		// the free fiat feeds publish once a day
+		{Source: fiatFeed("exchangerate-api", "https://api.exchangerate-api.com/v4/latest/USD", parseExchangeRateAPI), Weight: one, MaxAge: 26 * time.Hour},
+		{Source: fiatFeed("open-er-api", "https://open.er-api.com/v6/latest/USD", parseOpenERAPI), Weight: one, MaxAge: 26 * time.Hour},
+		{Source: fiatFeed("currency-api", "https://cdn.jsdelivr.net/npm/@fawazahmed0/currency-api@latest/v1/currencies/usd.json", parseCurrencyAPI), Weight: one, MaxAge: 50 * time.Hour},
+		// manual rates are weighted higher since an admin set them deliberately
+		{Source: &manualSource{store: store}, Weight: decimal.NewFromInt(2), MaxAge: 24 * time.Hour, Optional: true},
+	}
+}
@@ -0,0 +1,130 @@
+package fxrevenue
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	ChannelSmartConversion = "smart_conversion"
+	ChannelRapidRamp       = "rapid_ramp"
+	ChannelCardFunding     = "card_funding"
+
+	// where an entry's mid-market rate came from
+	MidRateSourceRateManager     = "rate_manager"
+	MidRateSourceExchangeService = "exchange_service"
+	MidRateSourceApplied         = "applied"
+
+	PeriodDaily   = "daily"
+	PeriodWeekly  = "weekly"
+	PeriodMonthly = "monthly"
+
+	GroupByPair     = "pair"
+	GroupByRule     = "rule"
+	GroupByVIPLevel = "vip_level"
+	GroupByChannel  = "channel"
+
+	// MaxReportDays bounds the window of a single summary, export or reconciliation
+	MaxReportDays = 366
+	// unattributedSampleSize caps the records listed by a reconciliation
+	unattributedSampleSize = 100
+)
+
+var (
+	ErrInvalidPeriod  = errors.New("period must be daily, weekly or monthly")
+	ErrInvalidGroupBy = errors.New("group_by must be pair, rule, vip_level or channel")
+	ErrInvalidRange   = errors.New("from must be before to and the range at most 366 days")
+)
+
+// periodBuckets maps a report period to its date_trunc field
+var periodBuckets = map[string]string{
+	PeriodDaily:   "day",
+	PeriodWeekly:  "week",
+	PeriodMonthly: "month",
+}
+
+// Entry describes one revenue-generating FX event. MidRate may be left zero for
+// the service to look up; Fees are in the target currency.
+type Entry struct {
+	Channel        string
+	ReferenceID    uuid.UUID
+	TransactionID  *uuid.UUID
+	UserID         uuid.UUID
+	SourceCurrency string
+	TargetCurrency string
+	SourceAmount   decimal.Decimal
+	MidRate        decimal.Decimal
+	MidRateSource  string
+	AppliedRate    decimal.Decimal
+	Fees           decimal.Decimal
+	RuleID         *uuid.UUID
+	RuleName       *string
+	VIPLevelID     *uuid.UUID
+	VIPLevelName   *string
+	RateProvider   string
+	OccurredAt     time.Time
+}
+
+// SummaryLine is the revenue of one group in one period. Spread, fee and total
+// revenue are only set when the group has a single target currency; RevenueUSD
+// is always comparable across groups.
+type SummaryLine struct {
+	PeriodStart    time.Time        `json:"period_start"`
+	Key            string           `json:"key"`
+	Label          string           `json:"label"`
+	TargetCurrency string           `json:"target_currency,omitempty"`
+	EntryCount     int64            `json:"entry_count"`
+	SpreadRevenue  *decimal.Decimal `json:"spread_revenue,omitempty"`
+	FeeRevenue     *decimal.Decimal `json:"fee_revenue,omitempty"`
+	TotalRevenue   *decimal.Decimal `json:"total_revenue,omitempty"`
+	RevenueUSD     decimal.Decimal  `json:"revenue_usd"`
+	UnpricedCount  int64            `json:"unpriced_count,omitempty"` // entries with no USD valuation
+}
+
+// Summary is the revenue report for a window
+type Summary struct {
+	Period          string          `json:"period"`
+	GroupBy         string          `json:"group_by"`
+	From            time.Time       `json:"from"`
+	To              time.Time       `json:"to"`
+	TotalRevenueUSD decimal.Decimal `json:"total_revenue_usd"`
+	EntryCount      int64           `json:"entry_count"`
+	Lines           []SummaryLine   `json:"lines"`
+}
+
+// ReconciliationLine compares the channel's own records for a pair with the
+// revenue entries attributed to them
+type ReconciliationLine struct {
+	Channel          string          `json:"channel"`
+	SourceCurrency   string          `json:"source_currency"`
+	TargetCurrency   string          `json:"target_currency"`
+	SourceCount      int64           `json:"source_count"`
+	SourceVolume     decimal.Decimal `json:"source_volume"`
+	SourceFees       decimal.Decimal `json:"source_fees"`
+	AttributedCount  int64           `json:"attributed_count"`
+	AttributedVolume decimal.Decimal `json:"attributed_volume"`
+	AttributedFees   decimal.Decimal `json:"attributed_fees"`
+	Reconciled       bool            `json:"reconciled"`
+}
+
+// UnattributedRecord is a completed channel record with no revenue entry
+type UnattributedRecord struct {
+	Channel        string          `json:"channel"`
+	ReferenceID    uuid.UUID       `json:"reference_id"`
+	SourceCurrency string          `json:"source_currency"`
+	TargetCurrency string          `json:"target_currency"`
+	SourceAmount   decimal.Decimal `json:"source_amount"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+}
+
+// Reconciliation is the result of checking revenue entries against fee and ledger data
+type Reconciliation struct {
+	From         time.Time            `json:"from"`
+	To           time.Time            `json:"to"`
+	Reconciled   bool                 `json:"reconciled"`
+	Lines        []ReconciliationLine `json:"lines"`
+	Unattributed []UnattributedRecord `json:"unattributed,omitempty"`
+}
@@ -0,0 +1,395 @@
+package fxrevenue
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Service attributes what the platform earns on every FX event - the spread
+// between the mid-market and applied rate plus fees - and reports on it.
+type Service struct {
+	store               *db.Store
+	exchangeRateService *exchangerate.ExchangeRateService
+	logger              *logging.Logger
+}
+
+func NewService(store *db.Store, exchangeRateService *exchangerate.ExchangeRateService, logger *logging.Logger) *Service {
+	return &Service{
+		store:               store,
+		exchangeRateService: exchangeRateService,
+		logger:              logger,
+	}
+}
+
+// Record stores the revenue of one FX event. Pass the transaction's queries to
+// record atomically with the event itself, or nil to use the store. Recording
+// the same channel reference twice is a no-op.
+func (s *Service) Record(ctx context.Context, q *db.Queries, e Entry) error {
+	if q == nil {
+		q = s.store.Queries
+	}
+
+	midRate, midRateSource := e.MidRate, e.MidRateSource
+	if !midRate.IsPositive() {
+		midRate, midRateSource = s.lookupMidRate(ctx, e)
+	} else if midRateSource == "" {
+		midRateSource = MidRateSourceRateManager
+	}
+
+	spread := e.SourceAmount.Mul(midRate.Sub(e.AppliedRate)).Round(8)
+	fees := e.Fees.Round(8)
+	total := spread.Add(fees)
+
+	revenueUSD := sql.NullString{}
+	if usd, err := s.toUSD(ctx, total, e.TargetCurrency); err != nil {
+		s.logger.Warn(fmt.Sprintf("fx revenue: could not value %s %s in USD for %s %s: %v", total, e.TargetCurrency, e.Channel, e.ReferenceID, err))
+	} else {
+		revenueUSD = sql.NullString{String: usd.Round(8).String(), Valid: true}
+	}
+
+	occurredAt := e.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	return q.CreateFxRevenueEntry(ctx, db.CreateFxRevenueEntryParams{
+		Channel:        e.Channel,
+		ReferenceID:    e.ReferenceID,
+		TransactionID:  toNullUUID(e.TransactionID),
+		UserID:         e.UserID,
+		SourceCurrency: e.SourceCurrency,
+		TargetCurrency: e.TargetCurrency,
+		SourceAmount:   e.SourceAmount.Round(8).String(),
+		MidRate:        midRate.Round(10).String(),
+		MidRateSource:  midRateSource,
+		AppliedRate:    e.AppliedRate.Round(10).String(),
+		SpreadRevenue:  spread.String(),
+		FeeRevenue:     fees.String(),
+		TotalRevenue:   total.String(),
+		RevenueUsd:     revenueUSD,
+		RuleID:         toNullUUID(e.RuleID),
+		RuleName:       toNullString(e.RuleName),
+		VipLevelID:     toNullUUID(e.VIPLevelID),
+		VipLevelName:   toNullString(e.VIPLevelName),
+		RateProvider:   sql.NullString{String: e.RateProvider, Valid: e.RateProvider != ""},
+		OccurredAt:     occurredAt,
+	})
+}
+
+// lookupMidRate prices the pair from the exchange service. Without a reference
+// rate the applied rate is used, so the entry carries fee revenue only.
+func (s *Service) lookupMidRate(ctx context.Context, e Entry) (decimal.Decimal, string) {
+	if e.SourceCurrency == e.TargetCurrency {
+		return decimal.NewFromInt(1), MidRateSourceExchangeService
+	}
+	rate, err := s.exchangeRateService.GetExchangeRate(ctx, e.SourceCurrency, e.TargetCurrency)
+	if err != nil || !rate.Rate.IsPositive() {
+		s.logger.Warn(fmt.Sprintf("fx revenue: no mid rate for %s/%s on %s %s, using applied rate: %v", e.SourceCurrency, e.TargetCurrency, e.Channel, e.ReferenceID, err))
+		return e.AppliedRate, MidRateSourceApplied
+	}
+	return rate.Rate, MidRateSourceExchangeService
+}
+
+func (s *Service) toUSD(ctx context.Context, amount decimal.Decimal, cur string) (decimal.Decimal, error) {
+	if cur == "USD" || amount.IsZero() {
+		return amount, nil
+	}
+	rate, err := s.exchangeRateService.GetExchangeRate(ctx, cur, "USD")
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return amount.Mul(rate.Rate), nil
+}
+
+// summaryGroup accumulates the rows of one period and group key
+type summaryGroup struct {
+	line      SummaryLine
+	spread    decimal.Decimal
+	fees      decimal.Decimal
+	total     decimal.Decimal
+	currency  string
+	mixedCurr bool
+}
+
+// Summary reports revenue per period grouped by pair, rule, VIP level or channel
+func (s *Service) Summary(ctx context.Context, period, groupBy string, from, to time.Time) (*Summary, error) {
+	bucket, ok := periodBuckets[period]
+	if !ok {
+		return nil, ErrInvalidPeriod
+	}
+	if groupBy != GroupByPair && groupBy != GroupByRule && groupBy != GroupByVIPLevel && groupBy != GroupByChannel {
+		return nil, ErrInvalidGroupBy
+	}
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.store.SummarizeFxRevenue(ctx, db.SummarizeFxRevenueParams{
+		Bucket:    bucket,
+		StartTime: from,
+		EndTime:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize fx revenue: %w", err)
+	}
+
+	summary := &Summary{
+		Period:          period,
+		GroupBy:         groupBy,
+		From:            from,
+		To:              to,
+		TotalRevenueUSD: decimal.Zero,
+		Lines:           []SummaryLine{},
+	}
+
+	groups := map[string]*summaryGroup{}
+	var order []string
+	for _, row := range rows {
+		key, label := groupKey(groupBy, row)
+		id := row.PeriodStart.Format(time.RFC3339) + "|" + key
+
+		g, ok := groups[id]
+		if !ok {
+			g = &summaryGroup{
+				line: SummaryLine{
+					PeriodStart: row.PeriodStart,
+					Key:         key,
+					Label:       label,
+					RevenueUSD:  decimal.Zero,
+				},
+				currency: row.TargetCurrency,
+			}
+			groups[id] = g
+			order = append(order, id)
+		}
+		if g.currency != row.TargetCurrency {
+			g.mixedCurr = true
+		}
+
+		revenueUSD, _ := decimal.NewFromString(row.RevenueUsd)
+		spread, _ := decimal.NewFromString(row.SpreadRevenue)
+		fees, _ := decimal.NewFromString(row.FeeRevenue)
+		total, _ := decimal.NewFromString(row.TotalRevenue)
+
+		g.line.EntryCount += row.EntryCount
+		g.line.UnpricedCount += row.UnpricedCount
+		g.line.RevenueUSD = g.line.RevenueUSD.Add(revenueUSD)
+		g.spread = g.spread.Add(spread)
+		g.fees = g.fees.Add(fees)
+		g.total = g.total.Add(total)
+
+		summary.EntryCount += row.EntryCount
+		summary.TotalRevenueUSD = summary.TotalRevenueUSD.Add(revenueUSD)
+	}
+
+	for _, id := range order {
+		g := groups[id]
+		// native-currency figures only add up within a single target currency
+		if !g.mixedCurr {
+			g.line.TargetCurrency = g.currency
+			g.line.SpreadRevenue = &g.spread
+			g.line.FeeRevenue = &g.fees
+			g.line.TotalRevenue = &g.total
+		}
+		summary.Lines = append(summary.Lines, g.line)
+	}
+
+	sort.SliceStable(summary.Lines, func(i, j int) bool {
+		a, b := summary.Lines[i], summary.Lines[j]
+		if !a.PeriodStart.Equal(b.PeriodStart) {
+			return a.PeriodStart.Before(b.PeriodStart)
+		}
+		return a.RevenueUSD.GreaterThan(b.RevenueUSD)
+	})
+
+	return summary, nil
+}
+
+func groupKey(groupBy string, row db.SummarizeFxRevenueRow) (key, label string) {
+	switch groupBy {
+	case GroupByRule:
+		if !row.RuleID.Valid {
+			return "none", "No rule"
+		}
+		return row.RuleID.UUID.String(), row.RuleName.String
+	case GroupByVIPLevel:
+		if !row.VipLevelID.Valid {
+			return "none", "No VIP level"
+		}
+		return row.VipLevelID.UUID.String(), row.VipLevelName.String
+	case GroupByChannel:
+		return row.Channel, row.Channel
+	default:
+		pair := row.SourceCurrency + "/" + row.TargetCurrency
+		return pair, pair
+	}
+}
+
+var exportHeader = []string{
+	"id", "occurred_at", "channel", "reference_id", "transaction_id", "user_id",
+	"source_currency", "target_currency", "source_amount", "mid_rate", "mid_rate_source",
+	"applied_rate", "spread_revenue", "fee_revenue", "total_revenue", "revenue_usd",
+	"rule_id", "rule_name", "vip_level_id", "vip_level_name", "rate_provider",
+}
+
+// ExportCSV writes every revenue entry in the window to w
+func (s *Service) ExportCSV(ctx context.Context, w io.Writer, from, to time.Time) error {
+	if err := validateRange(from, to); err != nil {
+		return err
+	}
+
+	entries, err := s.store.ListFxRevenueEntries(ctx, db.ListFxRevenueEntriesParams{
+		StartTime: from,
+		EndTime:   to,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list fx revenue entries: %w", err)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		record := []string{
+			e.ID.String(),
+			e.OccurredAt.UTC().Format(time.RFC3339),
+			e.Channel,
+			e.ReferenceID.String(),
+			nullUUIDString(e.TransactionID),
+			e.UserID.String(),
+			e.SourceCurrency,
+			e.TargetCurrency,
+			e.SourceAmount,
+			e.MidRate,
+			e.MidRateSource,
+			e.AppliedRate,
+			e.SpreadRevenue,
+			e.FeeRevenue,
+			e.TotalRevenue,
+			e.RevenueUsd.String,
+			nullUUIDString(e.RuleID),
+			e.RuleName.String,
+			nullUUIDString(e.VipLevelID),
+			e.VipLevelName.String,
+			e.RateProvider.String,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Reconcile checks revenue entries against the channels' own records: every
+// completed conversion, ramp payout and card funding should have exactly one
+// entry with the same volume and fees.
+func (s *Service) Reconcile(ctx context.Context, from, to time.Time) (*Reconciliation, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.store.ReconcileFxRevenue(ctx, db.ReconcileFxRevenueParams{
+		StartTime: from,
+		EndTime:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile fx revenue: %w", err)
+	}
+
+	result := &Reconciliation{
+		From:       from,
+		To:         to,
+		Reconciled: true,
+		Lines:      make([]ReconciliationLine, 0, len(rows)),
+	}
+
+	// entries store amounts at 8 decimal places, so allow that much rounding per record
+	perRecordTolerance := decimal.New(1, -8)
+	for _, row := range rows {
+		line := ReconciliationLine{
+			Channel:         row.Channel,
+			SourceCurrency:  row.SourceCurrency,
+			TargetCurrency:  row.TargetCurrency,
+			SourceCount:     row.SourceCount,
+			AttributedCount: row.AttributedCount,
+		}
+		line.SourceVolume, _ = decimal.NewFromString(row.SourceVolume)
+		line.SourceFees, _ = decimal.NewFromString(row.SourceFees)
+		line.AttributedVolume, _ = decimal.NewFromString(row.AttributedVolume)
+		line.AttributedFees, _ = decimal.NewFromString(row.AttributedFees)
+
+		tolerance := perRecordTolerance.Mul(decimal.NewFromInt(row.SourceCount))
+		line.Reconciled = row.SourceCount == row.AttributedCount &&
+			line.SourceVolume.Sub(line.AttributedVolume).Abs().LessThanOrEqual(tolerance) &&
+			line.SourceFees.Sub(line.AttributedFees).Abs().LessThanOrEqual(tolerance)
+		if !line.Reconciled {
+			result.Reconciled = false
+		}
+		result.Lines = append(result.Lines, line)
+	}
+
+	if !result.Reconciled {
+		missing, err := s.store.ListUnattributedFxRecords(ctx, db.ListUnattributedFxRecordsParams{
+			StartTime: from,
+			EndTime:   to,
+			RowLimit:  unattributedSampleSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list unattributed records: %w", err)
+		}
+		for _, m := range missing {
+			amount, _ := decimal.NewFromString(m.SourceAmount)
+			result.Unattributed = append(result.Unattributed, UnattributedRecord{
+				Channel:        m.Channel,
+				ReferenceID:    m.ReferenceID,
+				SourceCurrency: m.SourceCurrency,
+				TargetCurrency: m.TargetCurrency,
+				SourceAmount:   amount,
+				OccurredAt:     m.OccurredAt,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func validateRange(from, to time.Time) error {
+	if !from.Before(to) || to.Sub(from) > MaxReportDays*24*time.Hour {
+		return ErrInvalidRange
+	}
+	return nil
+}
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func toNullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func nullUUIDString(id uuid.NullUUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.UUID.String()
+}
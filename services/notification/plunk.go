@@ -493,6 +493,93 @@ func (s *Plunk) SendYieldCredited(ctx context.Context, user *db.User, name, amou
 	return nil
 }
 
+func (s *Plunk) SendLockedVaultMaturedEmail(ctx context.Context, user *db.User, name, amount, currency, yieldEarned, reference string) error {
+	tplData := map[string]any{
+		"FirstName":   user.FirstName.String,
+		"VaultName":   name,
+		"Currency":    currency,
+		"Amount":      amount,
+		"YieldEarned": yieldEarned,
+		"Reference":   reference,
+		"Date":        time.Now().Format("02 Jan 2006 15:04 MST"),
+		"Year":        time.Now().Year(),
+	}
+
+	body, err := utils.RenderEmailTemplate("templates/vault_locked_matured.html", tplData)
+	if err != nil {
+		return fmt.Errorf("failed to render locked vault matured email: %v", err)
+	}
+
+	emailService := Plunk{
+		Config:     s.Config,
+		HttpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	subject := "SwiftFiat - Locked Vault Matured"
+	if err := emailService.SendEmail(user.Email, subject, body); err != nil {
+		return fmt.Errorf("failed to send locked vault matured email: %v", err)
+	}
+
+	return nil
+}
+
+func (s *Plunk) SendLockedVaultRolledOverEmail(ctx context.Context, user *db.User, name, principal, currency, yieldEarned, apy string, termDays int32, maturesAt time.Time) error {
+	tplData := map[string]any{
+		"FirstName":   user.FirstName.String,
+		"VaultName":   name,
+		"Currency":    currency,
+		"Principal":   principal,
+		"YieldEarned": yieldEarned,
+		"APY":         apy,
+		"TermDays":    termDays,
+		"MaturesAt":   maturesAt.Format("02 Jan 2006"),
+		"Year":        time.Now().Year(),
+	}
+
+	body, err := utils.RenderEmailTemplate("templates/vault_locked_rollover.html", tplData)
+	if err != nil {
+		return fmt.Errorf("failed to render locked vault rollover email: %v", err)
+	}
+
+	emailService := Plunk{
+		Config:     s.Config,
+		HttpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	subject := "SwiftFiat - Locked Vault Renewed"
+	if err := emailService.SendEmail(user.Email, subject, body); err != nil {
+		return fmt.Errorf("failed to send locked vault rollover email: %v", err)
+	}
+
+	return nil
+}
+
+func (s *Plunk) SendAutoSaveMonthlySummaryEmail(ctx context.Context, user *db.User, month time.Time, totals []db.GetAutoSaveMonthlyTotalsRow) error {
+	tplData := map[string]any{
+		"FirstName": user.FirstName.String,
+		"Month":     month.Format("January 2006"),
+		"Totals":    totals,
+		"Year":      time.Now().Year(),
+	}
+
+	body, err := utils.RenderEmailTemplate("templates/vault_autosave_summary.html", tplData)
+	if err != nil {
+		return fmt.Errorf("failed to render vault auto-save summary email: %v", err)
+	}
+
+	emailService := Plunk{
+		Config:     s.Config,
+		HttpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	subject := "SwiftFiat - Your Auto-Save Summary for " + month.Format("January")
+	if err := emailService.SendEmail(user.Email, subject, body); err != nil {
+		return fmt.Errorf("failed to send vault auto-save summary email: %v", err)
+	}
+
+	return nil
+}
+
 func (s *Plunk) KycVerified(ctx context.Context, firstName, email string) error {
 	tplData := map[string]any{
 		"FirstName":     firstName,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
 	user_service "github.com/SwiftFiat/SwiftFiat-Backend/services/user"
@@ -603,6 +604,21 @@ func (p *PushNotificationService) SendYieldCredited(ctx context.Context, userID
 	return nil
 }
 
+func (p *PushNotificationService) SendLockedVaultMaturedPush(ctx context.Context, userID uuid.UUID, name, amount, currency string) error {
+	return p.SendPushNotification(ctx, userID, "Locked Vault Matured",
+		fmt.Sprintf("Your locked vault '%s' has matured and %s %s has been paid to your wallet.", name, amount, currency))
+}
+
+func (p *PushNotificationService) SendLockedVaultRolledOverPush(ctx context.Context, userID uuid.UUID, name, apy string, maturesAt time.Time) error {
+	return p.SendPushNotification(ctx, userID, "Locked Vault Renewed",
+		fmt.Sprintf("Your locked vault '%s' has been locked for another term at %s%% APY until %s.", name, apy, maturesAt.Format("02 Jan 2006")))
+}
+
+func (p *PushNotificationService) SendAutoSaveMonthlySummaryPush(ctx context.Context, userID uuid.UUID, month, saved string) error {
+	return p.SendPushNotification(ctx, userID, "Your Auto-Save Summary",
+		fmt.Sprintf("Your auto-save rules put away %s in %s. Small change, adding up.", saved, month))
+}
+
 func (p *PushNotificationService) SendRewardNotification(ctx context.Context, userID uuid.UUID, message, txType string, pointEarned int64) error {
 	tokens, err := p.getUserPushTokens(userID)
 	if err != nil {
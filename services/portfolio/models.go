@@ -0,0 +1,132 @@
+package portfolio
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// default and maximum windows for the net-worth series, in days
+	DefaultHistoryDays = 30
+	MaxHistoryDays     = 365
+)
+
+var (
+	ErrUnsupportedDisplayCurrency = errors.New("unsupported display currency")
+	ErrInvalidHistoryRange        = errors.New("history range must be between 1 and 365 days")
+)
+
+// Holding is one currency position across wallet, vault and card balances.
+// Cost basis and P&L are tracked in USD with the average-cost method and
+// expressed in the display currency at the current rate.
+type Holding struct {
+	Currency             string          `json:"currency"`
+	WalletBalance        decimal.Decimal `json:"wallet_balance"`
+	VaultBalance         decimal.Decimal `json:"vault_balance"`
+	CardBalance          decimal.Decimal `json:"card_balance"`
+	TotalBalance         decimal.Decimal `json:"total_balance"`
+	Price                decimal.Decimal `json:"price"`
+	Value                decimal.Decimal `json:"value"`
+	CostBasis            decimal.Decimal `json:"cost_basis"`
+	UnrealisedPnL        decimal.Decimal `json:"unrealised_pnl"`
+	UnrealisedPnLPercent decimal.Decimal `json:"unrealised_pnl_percent"`
+	RealisedPnL          decimal.Decimal `json:"realised_pnl"`
+	Allocation           decimal.Decimal `json:"allocation_percent"`
+}
+
+// Portfolio is a user's consolidated net worth in their display currency
+type Portfolio struct {
+	DisplayCurrency  string          `json:"display_currency"`
+	TotalValue       decimal.Decimal `json:"total_value"`
+	WalletsValue     decimal.Decimal `json:"wallets_value"`
+	VaultsValue      decimal.Decimal `json:"vaults_value"`
+	CardsValue       decimal.Decimal `json:"cards_value"`
+	TotalCostBasis   decimal.Decimal `json:"total_cost_basis"`
+	UnrealisedPnL    decimal.Decimal `json:"unrealised_pnl"`
+	RealisedPnL      decimal.Decimal `json:"realised_pnl"`
+	Holdings         []Holding       `json:"holdings"`
+	CardsUnavailable bool            `json:"cards_unavailable,omitempty"` // card balances could not be fetched from the issuer
+	ValuedAt         time.Time       `json:"valued_at"`
+}
+
+// NetWorthPoint is one daily snapshot re-expressed in the display currency
+type NetWorthPoint struct {
+	Date         string          `json:"date"`
+	TotalValue   decimal.Decimal `json:"total_value"`
+	WalletsValue decimal.Decimal `json:"wallets_value"`
+	VaultsValue  decimal.Decimal `json:"vaults_value"`
+	CardsValue   decimal.Decimal `json:"cards_value"`
+}
+
+// NetWorthHistory is the daily net-worth series for a user
+type NetWorthHistory struct {
+	DisplayCurrency string          `json:"display_currency"`
+	Points          []NetWorthPoint `json:"points"`
+	Change          decimal.Decimal `json:"change"`
+	ChangePercent   decimal.Decimal `json:"change_percent"`
+}
+
+// snapshotHolding is the per-currency breakdown persisted with each snapshot
+type snapshotHolding struct {
+	Currency string          `json:"currency"`
+	Balance  decimal.Decimal `json:"balance"`
+	ValueUSD decimal.Decimal `json:"value_usd"`
+}
+
+// balances holds the quantity of one currency held in each product
+type balances struct {
+	wallet decimal.Decimal
+	vault  decimal.Decimal
+	card   decimal.Decimal
+}
+
+func (b *balances) total() decimal.Decimal {
+	return b.wallet.Add(b.vault).Add(b.card)
+}
+
+// costPosition tracks the USD cost of the units of a currency acquired through tracked events
+type costPosition struct {
+	quantity decimal.Decimal
+	costUSD  decimal.Decimal
+	realised decimal.Decimal
+}
+
+func (p *costPosition) acquire(quantity, costUSD decimal.Decimal) {
+	p.quantity = p.quantity.Add(quantity)
+	p.costUSD = p.costUSD.Add(costUSD)
+}
+
+// dispose realises P&L on the tracked part of a disposal. Units beyond the tracked
+// quantity came from untracked inflows (deposits, transfers) and carry no P&L.
+func (p *costPosition) dispose(quantity, proceedsUSD decimal.Decimal) {
+	if !p.quantity.IsPositive() || !quantity.IsPositive() {
+		return
+	}
+
+	tracked := decimal.Min(quantity, p.quantity)
+	share := tracked.Div(p.quantity)
+	basis := p.costUSD.Mul(share)
+	proceeds := proceedsUSD.Mul(tracked).Div(quantity)
+
+	p.realised = p.realised.Add(proceeds.Sub(basis))
+	p.costUSD = p.costUSD.Sub(basis)
+	p.quantity = p.quantity.Sub(tracked)
+}
+
+// basisFor returns the USD cost of holding `balance` units. Units covered by tracked
+// acquisitions use the average cost; any excess is carried at current value.
+func (p *costPosition) basisFor(balance, priceUSD decimal.Decimal) decimal.Decimal {
+	if !balance.IsPositive() {
+		return decimal.Zero
+	}
+	if !p.quantity.IsPositive() {
+		return balance.Mul(priceUSD)
+	}
+
+	if balance.LessThanOrEqual(p.quantity) {
+		return p.costUSD.Div(p.quantity).Mul(balance)
+	}
+	return p.costUSD.Add(balance.Sub(p.quantity).Mul(priceUSD))
+}
@@ -0,0 +1,512 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/currency"
+	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	virtualcard "github.com/SwiftFiat/SwiftFiat-Backend/services/virtual_card"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// PortfolioService values a user's wallet, vault and card balances in a display
+// currency, tracks cost basis and P&L, and records daily net-worth snapshots.
+type PortfolioService struct {
+	store               *db.Store
+	exchangeRateService *exchangerate.ExchangeRateService
+	cardService         *virtualcard.Service
+	logger              *logging.Logger
+}
+
+func NewPortfolioService(
+	store *db.Store,
+	exchangeRateService *exchangerate.ExchangeRateService,
+	cardService *virtualcard.Service,
+	logger *logging.Logger,
+) *PortfolioService {
+	return &PortfolioService{
+		store:               store,
+		exchangeRateService: exchangeRateService,
+		cardService:         cardService,
+		logger:              logger,
+	}
+}
+
+// usdPrices caches the USD price of each currency for the duration of one valuation
+type usdPrices map[string]decimal.Decimal
+
+func (s *PortfolioService) usdPrice(ctx context.Context, prices usdPrices, cur string) (decimal.Decimal, error) {
+	if cur == "USD" {
+		return decimal.NewFromInt(1), nil
+	}
+	if p, ok := prices[cur]; ok {
+		return p, nil
+	}
+
+	rate, err := s.exchangeRateService.GetExchangeRate(ctx, cur, "USD")
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to price %s in USD: %w", cur, err)
+	}
+	prices[cur] = rate.Rate
+	return rate.Rate, nil
+}
+
+// GetPortfolio values every holding of the user in displayCurrency
+func (s *PortfolioService) GetPortfolio(ctx context.Context, userID uuid.UUID, displayCurrency string) (*Portfolio, error) {
+	displayCurrency = strings.ToUpper(displayCurrency)
+	if !currency.IsCurrencyValid(displayCurrency) {
+		return nil, ErrUnsupportedDisplayCurrency
+	}
+
+	prices := usdPrices{}
+	displayPrice, err := s.usdPrice(ctx, prices, displayCurrency)
+	if err != nil {
+		return nil, err
+	}
+	if !displayPrice.IsPositive() {
+		return nil, fmt.Errorf("invalid USD price for %s", displayCurrency)
+	}
+
+	holdings, cardsUnavailable, err := s.collectBalances(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.buildCostPositions(ctx, userID, prices)
+	if err != nil {
+		return nil, err
+	}
+
+	// USD amounts are converted to the display currency at the current rate
+	toDisplay := func(usd decimal.Decimal) decimal.Decimal {
+		return usd.Div(displayPrice).Round(2)
+	}
+
+	portfolio := &Portfolio{
+		DisplayCurrency:  displayCurrency,
+		Holdings:         []Holding{},
+		CardsUnavailable: cardsUnavailable,
+		ValuedAt:         time.Now().UTC(),
+	}
+
+	var totalUSD decimal.Decimal
+	for _, cur := range sortedCurrencies(holdings, positions) {
+		bal := holdings[cur]
+		if bal == nil {
+			bal = &balances{}
+		}
+		pos := positions[cur]
+		if pos == nil {
+			pos = &costPosition{}
+		}
+
+		priceUSD, err := s.usdPrice(ctx, prices, cur)
+		if err != nil {
+			return nil, err
+		}
+
+		total := bal.total()
+		valueUSD := total.Mul(priceUSD)
+		basisUSD := pos.basisFor(total, priceUSD)
+		unrealisedUSD := valueUSD.Sub(basisUSD)
+
+		h := Holding{
+			Currency:      cur,
+			WalletBalance: bal.wallet,
+			VaultBalance:  bal.vault,
+			CardBalance:   bal.card,
+			TotalBalance:  total,
+			Price:         priceUSD.Div(displayPrice).Round(8),
+			Value:         toDisplay(valueUSD),
+			CostBasis:     toDisplay(basisUSD),
+			UnrealisedPnL: toDisplay(unrealisedUSD),
+			RealisedPnL:   toDisplay(pos.realised),
+		}
+		if basisUSD.IsPositive() {
+			h.UnrealisedPnLPercent = unrealisedUSD.Div(basisUSD).Mul(hundred).Round(2)
+		}
+
+		portfolio.WalletsValue = portfolio.WalletsValue.Add(toDisplay(bal.wallet.Mul(priceUSD)))
+		portfolio.VaultsValue = portfolio.VaultsValue.Add(toDisplay(bal.vault.Mul(priceUSD)))
+		portfolio.CardsValue = portfolio.CardsValue.Add(toDisplay(bal.card.Mul(priceUSD)))
+		portfolio.TotalCostBasis = portfolio.TotalCostBasis.Add(h.CostBasis)
+		portfolio.UnrealisedPnL = portfolio.UnrealisedPnL.Add(h.UnrealisedPnL)
+		portfolio.RealisedPnL = portfolio.RealisedPnL.Add(h.RealisedPnL)
+		totalUSD = totalUSD.Add(valueUSD)
+
+		portfolio.Holdings = append(portfolio.Holdings, h)
+	}
+
+	portfolio.TotalValue = toDisplay(totalUSD)
+	if portfolio.TotalValue.IsPositive() {
+		for i := range portfolio.Holdings {
+			portfolio.Holdings[i].Allocation = portfolio.Holdings[i].Value.Div(portfolio.TotalValue).Mul(hundred).Round(2)
+		}
+	}
+
+	return portfolio, nil
+}
+
+// GetNetWorthHistory returns the daily snapshot series for the last `days` days in displayCurrency.
+// Each point is converted with the rates recorded in its own snapshot.
+func (s *PortfolioService) GetNetWorthHistory(ctx context.Context, userID uuid.UUID, displayCurrency string, days int) (*NetWorthHistory, error) {
+	displayCurrency = strings.ToUpper(displayCurrency)
+	if !currency.IsCurrencyValid(displayCurrency) {
+		return nil, ErrUnsupportedDisplayCurrency
+	}
+	if days < 1 || days > MaxHistoryDays {
+		return nil, ErrInvalidHistoryRange
+	}
+
+	end := truncateToDate(time.Now().UTC())
+	start := end.AddDate(0, 0, -(days - 1))
+
+	snapshots, err := s.store.ListPortfolioSnapshots(ctx, db.ListPortfolioSnapshotsParams{
+		UserID:    userID,
+		StartDate: start,
+		EndDate:   end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list portfolio snapshots: %w", err)
+	}
+
+	history := &NetWorthHistory{
+		DisplayCurrency: displayCurrency,
+		Points:          make([]NetWorthPoint, 0, len(snapshots)),
+	}
+
+	prices := usdPrices{}
+	for _, snap := range snapshots {
+		displayPrice, err := s.snapshotDisplayPrice(ctx, prices, snap, displayCurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		convert := func(v string) decimal.Decimal {
+			usd, _ := decimal.NewFromString(v)
+			return usd.Div(displayPrice).Round(2)
+		}
+
+		history.Points = append(history.Points, NetWorthPoint{
+			Date:         snap.SnapshotDate.Format("2006-01-02"),
+			TotalValue:   convert(snap.TotalUsd),
+			WalletsValue: convert(snap.WalletsUsd),
+			VaultsValue:  convert(snap.VaultsUsd),
+			CardsValue:   convert(snap.CardsUsd),
+		})
+	}
+
+	if n := len(history.Points); n > 1 {
+		first, last := history.Points[0].TotalValue, history.Points[n-1].TotalValue
+		history.Change = last.Sub(first)
+		if first.IsPositive() {
+			history.ChangePercent = history.Change.Div(first).Mul(hundred).Round(2)
+		}
+	}
+
+	return history, nil
+}
+
+// snapshotDisplayPrice returns the USD price of the display currency recorded in the
+// snapshot, falling back to the current rate for snapshots that did not record it.
+func (s *PortfolioService) snapshotDisplayPrice(ctx context.Context, prices usdPrices, snap db.PortfolioSnapshot, displayCurrency string) (decimal.Decimal, error) {
+	var recorded map[string]decimal.Decimal
+	if err := json.Unmarshal(snap.UsdRates, &recorded); err == nil {
+		if p, ok := recorded[displayCurrency]; ok && p.IsPositive() {
+			return p, nil
+		}
+	}
+
+	p, err := s.usdPrice(ctx, prices, displayCurrency)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if !p.IsPositive() {
+		return decimal.Zero, fmt.Errorf("invalid USD price for %s", displayCurrency)
+	}
+	return p, nil
+}
+
+// SnapshotAll records today's net-worth snapshot for every user holding a wallet.
+// Rates are fetched once and shared across users; a currency that cannot be priced
+// only skips the users holding a balance in it.
+func (s *PortfolioService) SnapshotAll(ctx context.Context) (int, error) {
+	userIDs, err := s.store.ListPortfolioUserIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list portfolio users: %w", err)
+	}
+
+	prices := usdPrices{}
+	unpriced := map[string]error{}
+	for _, cur := range currency.SupportedCurrencies {
+		if _, err := s.usdPrice(ctx, prices, cur); err != nil {
+			s.logger.Error(fmt.Sprintf("Skipping portfolio snapshots holding %s: %v", cur, err))
+			unpriced[cur] = err
+		}
+	}
+
+	date := truncateToDate(time.Now().UTC())
+	taken := 0
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return taken, ctx.Err()
+		}
+		if err := s.snapshotUser(ctx, userID, date, prices, unpriced); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to snapshot portfolio for user %s: %v", userID, err))
+			continue
+		}
+		taken++
+	}
+
+	return taken, nil
+}
+
+// snapshotUser records the user's snapshot for date. It is skipped rather than saved with
+// missing value when a card balance could not be fetched or a held currency could not be priced.
+func (s *PortfolioService) snapshotUser(ctx context.Context, userID uuid.UUID, date time.Time, prices usdPrices, unpriced map[string]error) error {
+	holdings, cardsUnavailable, err := s.collectBalances(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if cardsUnavailable {
+		return errors.New("card balances unavailable")
+	}
+
+	var walletsUSD, vaultsUSD, cardsUSD decimal.Decimal
+	breakdown := make([]snapshotHolding, 0, len(holdings))
+	for _, cur := range sortedCurrencies(holdings, nil) {
+		bal := holdings[cur]
+		if err, ok := unpriced[cur]; ok {
+			if bal.total().IsZero() {
+				continue
+			}
+			return err
+		}
+		priceUSD, err := s.usdPrice(ctx, prices, cur)
+		if err != nil {
+			return err
+		}
+
+		walletsUSD = walletsUSD.Add(bal.wallet.Mul(priceUSD))
+		vaultsUSD = vaultsUSD.Add(bal.vault.Mul(priceUSD))
+		cardsUSD = cardsUSD.Add(bal.card.Mul(priceUSD))
+		breakdown = append(breakdown, snapshotHolding{
+			Currency: cur,
+			Balance:  bal.total(),
+			ValueUSD: bal.total().Mul(priceUSD).Round(8),
+		})
+	}
+
+	rates := map[string]decimal.Decimal{"USD": decimal.NewFromInt(1)}
+	for cur, p := range prices {
+		rates[cur] = p
+	}
+	ratesJSON, err := json.Marshal(rates)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot rates: %w", err)
+	}
+	holdingsJSON, err := json.Marshal(breakdown)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot holdings: %w", err)
+	}
+
+	_, err = s.store.UpsertPortfolioSnapshot(ctx, db.UpsertPortfolioSnapshotParams{
+		UserID:       userID,
+		SnapshotDate: date,
+		TotalUsd:     walletsUSD.Add(vaultsUSD).Add(cardsUSD).Round(8).String(),
+		WalletsUsd:   walletsUSD.Round(8).String(),
+		VaultsUsd:    vaultsUSD.Round(8).String(),
+		CardsUsd:     cardsUSD.Round(8).String(),
+		UsdRates:     ratesJSON,
+		Holdings:     holdingsJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save portfolio snapshot: %w", err)
+	}
+	return nil
+}
+
+// collectBalances sums wallet, vault and card balances per currency. Card balances come
+// from the issuer; if any lookup fails the rest are still returned and the flag is set.
+func (s *PortfolioService) collectBalances(ctx context.Context, userID uuid.UUID) (map[string]*balances, bool, error) {
+	holdings := make(map[string]*balances)
+	get := func(cur string) *balances {
+		cur = strings.ToUpper(cur)
+		if holdings[cur] == nil {
+			holdings[cur] = &balances{}
+		}
+		return holdings[cur]
+	}
+
+	wallets, err := s.store.GetWalletByCustomerID(ctx, userID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch wallets: %w", err)
+	}
+	for _, w := range wallets {
+		amount, _ := decimal.NewFromString(w.Balance.String)
+		b := get(w.Currency)
+		b.wallet = b.wallet.Add(amount)
+	}
+
+	vaults, err := s.store.GetVaultGoalsByUserID(ctx, userID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch vaults: %w", err)
+	}
+	for _, v := range vaults {
+		amount, _ := decimal.NewFromString(v.CurrentBalance.String)
+		b := get(v.Currency)
+		b.vault = b.vault.Add(amount)
+	}
+
+	cards, err := s.store.GetUserCards(ctx, userID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch cards: %w", err)
+	}
+	cardsUnavailable := false
+	for _, c := range cards {
+		resp, err := s.cardService.GetCardBalance(ctx, c.BridgecardCardID)
+		if err != nil {
+			s.logger.Warn(fmt.Sprintf("Failed to fetch balance for card %s: %v", c.ID, err))
+			cardsUnavailable = true
+			continue
+		}
+
+		// issuer balances are returned in cents
+		cents, err := strconv.ParseInt(resp.Data.Balance, 10, 64)
+		if err != nil {
+			s.logger.Warn(fmt.Sprintf("Failed to parse balance for card %s: %v", c.ID, err))
+			cardsUnavailable = true
+			continue
+		}
+		b := get(c.Currency)
+		b.card = b.card.Add(decimal.New(cents, -2))
+	}
+
+	return holdings, cardsUnavailable, nil
+}
+
+// buildCostPositions replays successful conversions and crypto inflows in order to
+// derive the average USD cost and realised P&L of each currency.
+func (s *PortfolioService) buildCostPositions(ctx context.Context, userID uuid.UUID, prices usdPrices) (map[string]*costPosition, error) {
+	conversions, err := s.store.ListUserConversionCosts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch conversion history: %w", err)
+	}
+	inflows, err := s.store.ListUserCryptoInflowCosts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch crypto inflows: %w", err)
+	}
+
+	type costEvent struct {
+		at    time.Time
+		apply func() error
+	}
+
+	positions := make(map[string]*costPosition)
+	get := func(cur string) *costPosition {
+		cur = strings.ToUpper(cur)
+		if positions[cur] == nil {
+			positions[cur] = &costPosition{}
+		}
+		return positions[cur]
+	}
+
+	events := make([]costEvent, 0, len(conversions)+len(inflows))
+
+	for _, c := range conversions {
+		c := c
+		events = append(events, costEvent{at: c.ExecutedAt, apply: func() error {
+			sourceAmount, _ := decimal.NewFromString(c.SourceAmount)
+			netAmount, _ := decimal.NewFromString(c.NetAmount)
+
+			// the swap transaction records the USD value of the source leg at execution time
+			valueUSD, _ := decimal.NewFromString(c.AmountUsd.String)
+			if !valueUSD.IsPositive() {
+				switch {
+				case strings.EqualFold(c.SourceCurrency, "USD"):
+					valueUSD = sourceAmount
+				case strings.EqualFold(c.TargetCurrency, "USD"):
+					valueUSD = netAmount
+				default:
+					price, err := s.usdPrice(ctx, prices, strings.ToUpper(c.SourceCurrency))
+					if err != nil {
+						return err
+					}
+					valueUSD = sourceAmount.Mul(price)
+				}
+			}
+
+			get(c.SourceCurrency).dispose(sourceAmount, valueUSD)
+			get(c.TargetCurrency).acquire(netAmount, valueUSD)
+			return nil
+		}})
+	}
+
+	for _, in := range inflows {
+		in := in
+		events = append(events, costEvent{at: in.CreatedAt, apply: func() error {
+			quantity, _ := decimal.NewFromString(in.ReceivedAmount.String)
+			costUSD, _ := decimal.NewFromString(in.AmountUsd)
+
+			// coin amount x coin/USD rate at receipt is the acquisition cost
+			if in.SentAmount.Valid && in.Rate.Valid {
+				sent, _ := decimal.NewFromString(in.SentAmount.String)
+				rate, _ := decimal.NewFromString(in.Rate.String)
+				if sent.Mul(rate).IsPositive() {
+					costUSD = sent.Mul(rate)
+				}
+			}
+			if !quantity.IsPositive() {
+				return nil
+			}
+
+			get(in.Currency).acquire(quantity, costUSD)
+			return nil
+		}})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+	for _, e := range events {
+		if err := e.apply(); err != nil {
+			return nil, err
+		}
+	}
+
+	return positions, nil
+}
+
+// sortedCurrencies lists every currency that has a balance or a cost position
+func sortedCurrencies(holdings map[string]*balances, positions map[string]*costPosition) []string {
+	seen := make(map[string]bool)
+	for cur := range holdings {
+		seen[cur] = true
+	}
+	for cur, pos := range positions {
+		if pos.quantity.IsPositive() || !pos.realised.IsZero() {
+			seen[cur] = true
+		}
+	}
+
+	out := make([]string, 0, len(seen))
+	for cur := range seen {
+		out = append(out, cur)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,93 @@
+package pricealert
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	smartconversion "github.com/SwiftFiat/SwiftFiat-Backend/services/smart_conversion"
+	"github.com/shopspring/decimal"
+)
+
+// ConvertAction converts between two of the user's wallets each time the alert triggers
+type ConvertAction struct {
+	SourceCurrency string `json:"source_currency" binding:"required"`
+	TargetCurrency string `json:"target_currency" binding:"required"`
+	Amount         string `json:"amount" binding:"required"` // source amount converted per trigger
+	// set when the user confirms the action with their transaction PIN
+	AuthorizedAt *time.Time `json:"authorized_at,omitempty"`
+}
+
+// createAlertWithAction stores the alert and its conversion action together so an alert is
+// never left without the action the user authorised
+func (s *PriceAlertService) createAlertWithAction(ctx context.Context, params db.CreatePriceAlertParams, action *ConvertAction, terms *smartconversion.AlertConversionTerms) (db.PriceAlert, error) {
+	var alert db.PriceAlert
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		alert, err = q.CreatePriceAlert(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateAlertConversionAction(ctx, db.CreateAlertConversionActionParams{
+			AlertID:        alert.ID,
+			UserID:         alert.UserID,
+			SourceCurrency: action.SourceCurrency,
+			TargetCurrency: action.TargetCurrency,
+			SourceWalletID: terms.SourceWalletID,
+			TargetWalletID: terms.TargetWalletID,
+			Amount:         terms.Amount.String(),
+			AuthorizedAt:   time.Now(),
+		})
+		return err
+	})
+	return alert, err
+}
+
+// withConvertAction loads the alert's conversion action, if it has one
+func (s *PriceAlertService) withConvertAction(ctx context.Context, alert *PriceAlert) *PriceAlert {
+	action, err := s.store.GetAlertConversionAction(ctx, alert.ID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			s.logger.Error(fmt.Sprintf("Failed to load conversion action for alert %s: %v", alert.ID, err))
+		}
+		return alert
+	}
+
+	alert.ConvertAction = &ConvertAction{
+		SourceCurrency: action.SourceCurrency,
+		TargetCurrency: action.TargetCurrency,
+		Amount:         action.Amount,
+		AuthorizedAt:   &action.AuthorizedAt,
+	}
+	return alert
+}
+
+// runConvertAction executes the alert's conversion action for a trigger. The conversion
+// service records the outcome against the trigger and notifies the user.
+func (s *PriceAlertService) runConvertAction(ctx context.Context, alert *PriceAlert, trigger *db.AlertTriggerHistory, currentRate decimal.Decimal) {
+	action, err := s.store.GetAlertConversionAction(ctx, alert.ID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			s.logger.Error(fmt.Sprintf("Failed to load conversion action for alert %s: %v", alert.ID, err))
+		}
+		return
+	}
+
+	label := fmt.Sprintf("%s/%s price alert", alert.SourceCurrency, alert.TargetCurrency)
+	if alert.Label != nil && *alert.Label != "" {
+		label = *alert.Label + " alert"
+	}
+
+	err = s.conversion.ExecuteAlertConversion(ctx, &smartconversion.AlertConversion{
+		Action:      action,
+		TriggerID:   trigger.ID,
+		TriggerRate: currentRate,
+		Label:       label,
+	})
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Conversion for alert %s trigger %d failed: %v", alert.ID, trigger.ID, err))
+	}
+}
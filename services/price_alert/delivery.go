@@ -0,0 +1,639 @@
+package pricealert
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	service "github.com/SwiftFiat/SwiftFiat-Backend/services/notification"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+)
+
+// DeliveryChannel is a way a triggered alert reaches the user
+type DeliveryChannel string
+
+const (
+	ChannelPush      DeliveryChannel = "push"
+	ChannelEmail     DeliveryChannel = "email"
+	ChannelSMS       DeliveryChannel = "sms"
+	ChannelInApp     DeliveryChannel = "in_app"
+	ChannelWebsocket DeliveryChannel = "websocket" // realtime message on the user's open websocket connections
+	ChannelWebhook   DeliveryChannel = "webhook"   // signed HTTPS POST to the alert's webhook_url
+)
+
+const (
+	DeliveryPending    = "pending"
+	DeliverySent       = "sent"
+	DeliveryFailed     = "failed"
+	DeliverySuppressed = "suppressed" // over the user's daily cap for the channel
+
+	// WebsocketEventAlertTriggered is the websocket message type for triggered alerts
+	WebsocketEventAlertTriggered = "price_alert:triggered"
+
+	// WebhookSignatureHeader carries "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">"
+	WebhookSignatureHeader = "X-SwiftFiat-Signature"
+
+	deliveryRetryBatch = 100
+	deliveryRetryLease = 5 * time.Minute // how long a claimed retry stays hidden from other replicas
+)
+
+var validChannels = map[DeliveryChannel]bool{
+	ChannelPush:      true,
+	ChannelEmail:     true,
+	ChannelSMS:       true,
+	ChannelInApp:     true,
+	ChannelWebsocket: true,
+	ChannelWebhook:   true,
+}
+
+// errUndeliverable marks failures that retrying cannot fix, such as a user without a phone number
+var errUndeliverable = errors.New("undeliverable")
+
+// RealtimePublisher sends an event to a user's open websocket connections. It returns an error
+// when the user has none, so the delivery is retried.
+type RealtimePublisher interface {
+	PublishToUser(userID uuid.UUID, eventType string, data any) error
+}
+
+// AlertDeliveryConfig sets retries and per-user daily caps for alert deliveries. Values are read
+// from the environment and fall back to defaults.
+type AlertDeliveryConfig struct {
+	// total tries per channel, including the first
+	MaxAttempts int `mapstructure:"ALERT_DELIVERY_MAX_ATTEMPTS"`
+	// wait before the first retry; each later retry waits four times longer
+	RetryBaseSeconds int `mapstructure:"ALERT_DELIVERY_RETRY_BASE_SECONDS"`
+	// deliveries per user and channel in any 24 hours; triggers beyond it are suppressed
+	DailyCapPush      int `mapstructure:"ALERT_DAILY_CAP_PUSH"`
+	DailyCapEmail     int `mapstructure:"ALERT_DAILY_CAP_EMAIL"`
+	DailyCapSMS       int `mapstructure:"ALERT_DAILY_CAP_SMS"`
+	DailyCapInApp     int `mapstructure:"ALERT_DAILY_CAP_IN_APP"`
+	DailyCapWebsocket int `mapstructure:"ALERT_DAILY_CAP_WEBSOCKET"`
+	DailyCapWebhook   int `mapstructure:"ALERT_DAILY_CAP_WEBHOOK"`
+}
+
+func LoadAlertDeliveryConfig() AlertDeliveryConfig {
+	var c AlertDeliveryConfig
+	if err := utils.LoadCustomConfig(utils.EnvPath, &c); err != nil {
+		c = AlertDeliveryConfig{}
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 4
+	}
+	if c.RetryBaseSeconds <= 0 {
+		c.RetryBaseSeconds = 60
+	}
+	if c.DailyCapPush <= 0 {
+		c.DailyCapPush = 50
+	}
+	if c.DailyCapEmail <= 0 {
+		c.DailyCapEmail = 10
+	}
+	if c.DailyCapSMS <= 0 {
+		c.DailyCapSMS = 5
+	}
+	if c.DailyCapInApp <= 0 {
+		c.DailyCapInApp = 100
+	}
+	if c.DailyCapWebsocket <= 0 {
+		c.DailyCapWebsocket = 200
+	}
+	if c.DailyCapWebhook <= 0 {
+		c.DailyCapWebhook = 200
+	}
+	return c
+}
+
+func (c AlertDeliveryConfig) dailyCap(channel DeliveryChannel) int64 {
+	switch channel {
+	case ChannelPush:
+		return int64(c.DailyCapPush)
+	case ChannelEmail:
+		return int64(c.DailyCapEmail)
+	case ChannelSMS:
+		return int64(c.DailyCapSMS)
+	case ChannelInApp:
+		return int64(c.DailyCapInApp)
+	case ChannelWebsocket:
+		return int64(c.DailyCapWebsocket)
+	default:
+		return int64(c.DailyCapWebhook)
+	}
+}
+
+// retryDelay is the wait after the given number of failed attempts: base, 4x base, 16x base...
+func (c AlertDeliveryConfig) retryDelay(attempts int32) time.Duration {
+	delay := time.Duration(c.RetryBaseSeconds) * time.Second
+	for i := int32(1); i < attempts; i++ {
+		delay *= 4
+	}
+	return delay
+}
+
+// AlertDelivery is the outcome of one triggered alert on one channel
+type AlertDelivery struct {
+	Channel       DeliveryChannel `json:"channel"`
+	Status        string          `json:"status"` // pending, sent, failed, suppressed
+	Attempts      int32           `json:"attempts"`
+	LastError     *string         `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at,omitempty"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// AlertTrigger is one firing of an alert with its delivery on each channel and, for alerts
+// with a conversion action, the conversion it ran
+type AlertTrigger struct {
+	db.AlertTriggerHistory
+	Deliveries []AlertDelivery        `json:"deliveries"`
+	Conversion *db.AlertConversionRun `json:"conversion,omitempty"`
+}
+
+// AlertWebhookPayload is the JSON body sent to webhook and websocket channels
+type AlertWebhookPayload struct {
+	Event          string    `json:"event"`
+	AlertID        uuid.UUID `json:"alert_id"`
+	TriggerID      int64     `json:"trigger_id"`
+	SourceCurrency string    `json:"source_currency"`
+	TargetCurrency string    `json:"target_currency"`
+	AlertCondition string    `json:"alert_condition"`
+	CurrentRate    string    `json:"current_rate"`
+	TargetRate     *string   `json:"target_rate,omitempty"`
+	ChangePercent  *string   `json:"change_percent,omitempty"`
+	Title          string    `json:"title"`
+	Body           string    `json:"body"`
+	TriggeredAt    time.Time `json:"triggered_at"`
+}
+
+// SetRealtimePublisher wires the websocket hub, which is created after the service
+func (s *PriceAlertService) SetRealtimePublisher(p RealtimePublisher) {
+	s.realtime = p
+}
+
+// resolveDeliveryChannels picks the alert's channels. Requests without delivery_channels fall
+// back to the notify_push and notify_in_app switches.
+func resolveDeliveryChannels(req *CreateAlertRequest) []DeliveryChannel {
+	if len(req.DeliveryChannels) > 0 {
+		seen := make(map[DeliveryChannel]bool)
+		channels := make([]DeliveryChannel, 0, len(req.DeliveryChannels))
+		for _, channel := range req.DeliveryChannels {
+			if !seen[channel] {
+				seen[channel] = true
+				channels = append(channels, channel)
+			}
+		}
+		return channels
+	}
+
+	var channels []DeliveryChannel
+	if req.NotifyInApp == nil || *req.NotifyInApp {
+		channels = append(channels, ChannelInApp)
+	}
+	if req.NotifyPush != nil && *req.NotifyPush {
+		channels = append(channels, ChannelPush)
+	}
+	return channels
+}
+
+// validateDeliveryChannels checks channel names and, when webhook is chosen, the webhook URL
+func validateDeliveryChannels(channels []DeliveryChannel, webhookURL *string) error {
+	if len(channels) == 0 {
+		return fmt.Errorf("at least one delivery channel is required")
+	}
+	for _, channel := range channels {
+		if !validChannels[channel] {
+			return fmt.Errorf("unsupported delivery channel %q", channel)
+		}
+		if channel == ChannelWebhook {
+			if webhookURL == nil || *webhookURL == "" {
+				return fmt.Errorf("webhook_url is required for the webhook channel")
+			}
+		}
+	}
+	if webhookURL != nil && *webhookURL != "" {
+		return validateWebhookURL(*webhookURL)
+	}
+	return nil
+}
+
+// validateWebhookURL accepts public HTTPS endpoints only. Hostnames are checked again
+// after DNS resolution when the webhook client dials them.
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("webhook_url is not a valid URL")
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("webhook_url must use https")
+	}
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".local") {
+		return fmt.Errorf("webhook_url must be a public host")
+	}
+	if ip := net.ParseIP(host); ip != nil && !isPublicIP(ip) {
+		return fmt.Errorf("webhook_url must be a public host")
+	}
+	return nil
+}
+
+// isPublicIP reports whether a webhook may be delivered to ip
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// newWebhookClient returns the client webhooks are delivered with. It refuses to connect to
+// non-public addresses, so a hostname that resolves to a loopback, private, link-local or
+// metadata address is rejected after DNS resolution, not just when the URL is saved.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("webhook host %s is not a public address", host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			// no proxy: the dial check must see the webhook host itself
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		// a redirect could point the request at a host validateWebhookURL would reject
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func newWebhookSecret() (string, error) {
+	secret, err := utils.SecureRandomString(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + secret, nil
+}
+
+func hasChannel(channels []DeliveryChannel, channel DeliveryChannel) bool {
+	for _, c := range channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+func channelsToStrings(channels []DeliveryChannel) []string {
+	out := make([]string, len(channels))
+	for i, c := range channels {
+		out[i] = string(c)
+	}
+	return out
+}
+
+// triggerHistoryParams builds the record of a trigger, including the notification it sends
+func (s *PriceAlertService) triggerHistoryParams(event *AlertTriggerEvent, message string) db.CreateAlertTriggerHistoryParams {
+	alert := event.Alert
+
+	// Prepare notification content
+	title := s.formatAlertTitle(alert)
+	body := s.formatAlertBody(alert, event, message)
+
+	var changePercent sql.NullString
+	if event.ChangePercent != nil {
+		changePercent = sql.NullString{String: event.ChangePercent.StringFixed(4), Valid: true}
+	}
+
+	return db.CreateAlertTriggerHistoryParams{
+		AlertID:               alert.ID,
+		UserID:                alert.UserID,
+		CurrentRate:           event.CurrentRate.String(),
+		PreviousRate:          s.decimalToNullString(event.PreviousRate),
+		ChangePercent:         changePercent,
+		AlertCondition:        string(alert.AlertCondition),
+		TargetRate:            s.decimalToNullString(alert.TargetRate),
+		PushNotificationSent:  sql.NullBool{Bool: false, Valid: true},
+		InAppNotificationSent: sql.NullBool{Bool: false, Valid: true},
+		Title:                 sql.NullString{String: title, Valid: true},
+		Body:                  sql.NullString{String: body, Valid: true},
+	}
+}
+
+// sendAlertNotifications delivers a recorded trigger on each of the alert's channels.
+// Channels over the user's daily cap are suppressed; failures are left for RetryFailedDeliveries.
+func (s *PriceAlertService) sendAlertNotifications(ctx context.Context, alert *PriceAlert, trigger *db.AlertTriggerHistory) {
+	since := time.Now().Add(-24 * time.Hour)
+	sent := make(map[DeliveryChannel]bool)
+	var failures []string
+
+	for _, channel := range alert.DeliveryChannels {
+		status := DeliveryPending
+		count, err := s.store.CountUserAlertDeliveriesSince(ctx, db.CountUserAlertDeliveriesSinceParams{
+			UserID:    alert.UserID,
+			Channel:   string(channel),
+			CreatedAt: since,
+		})
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to count %s deliveries for user %s: %v", channel, alert.UserID, err))
+		} else if count >= s.delivery.dailyCap(channel) {
+			status = DeliverySuppressed
+		}
+
+		delivery, err := s.store.CreateAlertTriggerDelivery(ctx, db.CreateAlertTriggerDeliveryParams{
+			TriggerID: trigger.ID,
+			AlertID:   alert.ID,
+			UserID:    alert.UserID,
+			Channel:   string(channel),
+			Status:    status,
+		})
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to record %s delivery for alert %s: %v", channel, alert.ID, err))
+			continue
+		}
+
+		if status == DeliverySuppressed {
+			s.logger.Info(fmt.Sprintf("Suppressed %s delivery for alert %s: daily cap reached", channel, alert.ID))
+			failures = append(failures, fmt.Sprintf("%s: daily cap reached", channel))
+			continue
+		}
+
+		if err := s.attemptDelivery(ctx, alert, trigger, delivery); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", channel, err))
+			continue
+		}
+		sent[channel] = true
+	}
+
+	var notificationError sql.NullString
+	if len(failures) > 0 {
+		notificationError = sql.NullString{String: strings.Join(failures, "; "), Valid: true}
+	}
+	if err := s.store.UpdateAlertTriggerNotificationStatus(ctx, db.UpdateAlertTriggerNotificationStatusParams{
+		ID:                    trigger.ID,
+		PushNotificationSent:  sql.NullBool{Bool: sent[ChannelPush], Valid: true},
+		InAppNotificationSent: sql.NullBool{Bool: sent[ChannelInApp], Valid: true},
+		NotificationError:     notificationError,
+	}); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to update trigger %d notification status: %v", trigger.ID, err))
+	}
+}
+
+// attemptDelivery sends one delivery and records the outcome. Failures are scheduled for a
+// retry with backoff until the attempts run out.
+func (s *PriceAlertService) attemptDelivery(ctx context.Context, alert *PriceAlert, trigger *db.AlertTriggerHistory, delivery db.AlertTriggerDelivery) error {
+	err := s.deliver(ctx, DeliveryChannel(delivery.Channel), alert, trigger)
+	if err == nil {
+		if _, err := s.store.MarkAlertDeliverySent(ctx, delivery.ID); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to mark delivery %d sent: %v", delivery.ID, err))
+		}
+		return nil
+	}
+
+	attempts := delivery.Attempts + 1
+	var next sql.NullTime
+	if attempts < int32(s.delivery.MaxAttempts) && !errors.Is(err, errUndeliverable) {
+		next = sql.NullTime{Time: time.Now().Add(s.delivery.retryDelay(attempts)), Valid: true}
+	}
+
+	s.logger.Error(fmt.Sprintf("Failed %s delivery for alert %s (attempt %d): %v", delivery.Channel, alert.ID, attempts, err))
+	if _, markErr := s.store.MarkAlertDeliveryFailed(ctx, db.MarkAlertDeliveryFailedParams{
+		ID:            delivery.ID,
+		LastError:     sql.NullString{String: err.Error(), Valid: true},
+		NextAttemptAt: next,
+	}); markErr != nil {
+		s.logger.Error(fmt.Sprintf("Failed to mark delivery %d failed: %v", delivery.ID, markErr))
+	}
+	return err
+}
+
+// deliver sends the trigger's title and body on a single channel
+func (s *PriceAlertService) deliver(ctx context.Context, channel DeliveryChannel, alert *PriceAlert, trigger *db.AlertTriggerHistory) error {
+	title := trigger.Title.String
+	body := trigger.Body.String
+
+	switch channel {
+	case ChannelPush:
+		if s.pushService == nil {
+			return fmt.Errorf("%w: push service unavailable", errUndeliverable)
+		}
+		return s.pushService.SendPushNotification(ctx, alert.UserID, title, body)
+
+	case ChannelInApp:
+		source := fmt.Sprintf("price_alert:%s", alert.ID)
+		n, err := s.notificationService.Create(ctx, nil, title, body, source)
+		if err != nil {
+			return fmt.Errorf("failed to create in-app notification: %w", err)
+		}
+		return s.notificationService.AddRecipent(ctx, alert.UserID, n.ID)
+
+	case ChannelEmail:
+		if s.email == nil {
+			return fmt.Errorf("%w: email service unavailable", errUndeliverable)
+		}
+		user, err := s.store.GetUserByID(ctx, alert.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch user: %w", err)
+		}
+		return s.email.SendEmail(user.Email, title, strings.ReplaceAll(body, "\n", "<br>"))
+
+	case ChannelSMS:
+		if s.config == nil {
+			return fmt.Errorf("%w: sms service unavailable", errUndeliverable)
+		}
+		user, err := s.store.GetUserByID(ctx, alert.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch user: %w", err)
+		}
+		if !user.PhoneNumber.Valid || user.PhoneNumber.String == "" {
+			return fmt.Errorf("%w: no phone number on file", errUndeliverable)
+		}
+		sms := service.SmsNotification{
+			Message:     fmt.Sprintf("%s: %s", title, body),
+			PhoneNumber: user.PhoneNumber.String,
+			Config:      s.config,
+		}
+		return sms.SendSMS()
+
+	case ChannelWebsocket:
+		if s.realtime == nil {
+			return fmt.Errorf("%w: websocket hub unavailable", errUndeliverable)
+		}
+		return s.realtime.PublishToUser(alert.UserID, WebsocketEventAlertTriggered, s.alertPayload(alert, trigger))
+
+	case ChannelWebhook:
+		return s.sendWebhook(ctx, alert, s.alertPayload(alert, trigger))
+	}
+
+	return fmt.Errorf("%w: unsupported channel %q", errUndeliverable, channel)
+}
+
+func (s *PriceAlertService) alertPayload(alert *PriceAlert, trigger *db.AlertTriggerHistory) AlertWebhookPayload {
+	return AlertWebhookPayload{
+		Event:          WebsocketEventAlertTriggered,
+		AlertID:        alert.ID,
+		TriggerID:      trigger.ID,
+		SourceCurrency: alert.SourceCurrency,
+		TargetCurrency: alert.TargetCurrency,
+		AlertCondition: trigger.AlertCondition,
+		CurrentRate:    trigger.CurrentRate,
+		TargetRate:     s.nullStringToString(trigger.TargetRate),
+		ChangePercent:  s.nullStringToString(trigger.ChangePercent),
+		Title:          trigger.Title.String,
+		Body:           trigger.Body.String,
+		TriggeredAt:    trigger.TriggeredAt,
+	}
+}
+
+// sendWebhook posts the payload to the alert's webhook URL, signed with its webhook secret
+func (s *PriceAlertService) sendWebhook(ctx context.Context, alert *PriceAlert, payload AlertWebhookPayload) error {
+	if alert.WebhookURL == nil || alert.WebhookSecret == nil {
+		return fmt.Errorf("%w: alert has no webhook configured", errUndeliverable)
+	}
+	if err := validateWebhookURL(*alert.WebhookURL); err != nil {
+		return fmt.Errorf("%w: %v", errUndeliverable, err)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	mac := hmac.New(sha256.New, []byte(*alert.WebhookSecret))
+	fmt.Fprintf(mac, "%d.%s", timestamp, body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *alert.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SwiftFiat-Webhooks/1.0")
+	req.Header.Set(WebhookSignatureHeader, fmt.Sprintf("t=%d,v1=%s", timestamp, signature))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// RetryFailedDeliveries re-sends failed deliveries whose next attempt is due. Each delivery is
+// claimed for deliveryRetryLease first, so only one replica resends it.
+func (s *PriceAlertService) RetryFailedDeliveries(ctx context.Context) error {
+	due, err := s.store.ClaimDueAlertDeliveries(ctx, db.ClaimDueAlertDeliveriesParams{
+		LeaseSeconds: int32(deliveryRetryLease / time.Second),
+		BatchSize:    deliveryRetryBatch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to claim due alert deliveries: %w", err)
+	}
+
+	for _, delivery := range due {
+		trigger, err := s.store.GetAlertTriggerHistoryEntry(ctx, delivery.TriggerID)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to load trigger %d for delivery %d: %v", delivery.TriggerID, delivery.ID, err))
+			continue
+		}
+
+		dbAlert, err := s.store.GetPriceAlert(ctx, delivery.AlertID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// the alert was deleted; stop retrying
+				_, _ = s.store.MarkAlertDeliveryFailed(ctx, db.MarkAlertDeliveryFailedParams{
+					ID:        delivery.ID,
+					LastError: sql.NullString{String: "alert deleted", Valid: true},
+				})
+				continue
+			}
+			s.logger.Error(fmt.Sprintf("Failed to load alert %s for delivery %d: %v", delivery.AlertID, delivery.ID, err))
+			continue
+		}
+
+		_ = s.attemptDelivery(ctx, s.dbAlertToModel(&dbAlert), &trigger, delivery)
+	}
+
+	return nil
+}
+
+// GetAlertHistory returns the alert's triggers, newest first, with the delivery on each channel
+func (s *PriceAlertService) GetAlertHistory(ctx context.Context, alertID, userID uuid.UUID, limit, offset int32) ([]AlertTrigger, error) {
+	if _, err := s.GetAlert(ctx, alertID, userID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.store.GetAlertTriggerHistory(ctx, db.GetAlertTriggerHistoryParams{
+		AlertID: alertID,
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	triggerIDs := make([]int64, len(history))
+	for i, h := range history {
+		triggerIDs[i] = h.ID
+	}
+	deliveries, err := s.store.ListAlertTriggerDeliveries(ctx, triggerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byTrigger := make(map[int64][]AlertDelivery)
+	for _, d := range deliveries {
+		byTrigger[d.TriggerID] = append(byTrigger[d.TriggerID], AlertDelivery{
+			Channel:       DeliveryChannel(d.Channel),
+			Status:        d.Status,
+			Attempts:      d.Attempts,
+			LastError:     s.nullStringToString(d.LastError),
+			NextAttemptAt: s.nullTimeToTime(d.NextAttemptAt),
+			DeliveredAt:   s.nullTimeToTime(d.DeliveredAt),
+		})
+	}
+
+	runs, err := s.store.ListAlertConversionRuns(ctx, triggerIDs)
+	if err != nil {
+		return nil, err
+	}
+	conversions := make(map[int64]*db.AlertConversionRun, len(runs))
+	for i := range runs {
+		conversions[runs[i].TriggerID] = &runs[i]
+	}
+
+	triggers := make([]AlertTrigger, len(history))
+	for i, h := range history {
+		triggers[i] = AlertTrigger{AlertTriggerHistory: h, Deliveries: byTrigger[h.ID], Conversion: conversions[h.ID]}
+		if triggers[i].Deliveries == nil {
+			triggers[i].Deliveries = []AlertDelivery{}
+		}
+	}
+	return triggers, nil
+}
@@ -0,0 +1,251 @@
+package pricealert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+)
+
+const (
+	// throughput is averaged over the cycles in this window
+	throughputWindow = 5 * time.Minute
+	// changed alerts are re-read with this overlap, covering transactions that committed after
+	// the previous read began and clock drift between replicas and the database
+	refreshOverlap = time.Minute
+	// last_checked_at is written at most this often per pair
+	checkedSaveInterval = time.Minute
+)
+
+// errTriggerClaimed means another evaluator claimed the alert's trigger first
+var errTriggerClaimed = errors.New("alert trigger already claimed")
+
+// alertEvaluator runs this replica's share of alert evaluation: the pairs in the shards it
+// leases, matched against an in-memory index of their alerts
+type alertEvaluator struct {
+	config      AlertEvaluationConfig
+	coordinator *shardCoordinator
+
+	cycle       sync.Mutex // held for a whole evaluation cycle; guards the index
+	index       *alertIndex
+	builtAt     time.Time // last full load of the index
+	refreshedAt time.Time // last load of changed alerts
+
+	mu          sync.RWMutex // guards the figures below, which metrics read during a cycle
+	evaluated   int64
+	triggered   int64
+	duplicates  int64
+	lastCycleAt time.Time
+	lastCycle   time.Duration
+	samples     []cycleSample
+	sampled     int64 // triggered count at the last sample
+	pairs       int
+	indexed     int
+	scanned     int
+	indexBuilt  time.Time
+	oldestCheck time.Time // stalest pair evaluation at the end of the last cycle
+}
+
+type cycleSample struct {
+	at        time.Time
+	evaluated int64
+	triggered int64
+}
+
+func newAlertEvaluator(store *db.Store, logger *logging.Logger, config AlertEvaluationConfig) *alertEvaluator {
+	return &alertEvaluator{
+		config:      config,
+		coordinator: newShardCoordinator(store, logger, config),
+		index:       newAlertIndex(),
+	}
+}
+
+// EvaluationMetrics reports this replica's share of alert evaluation and that of every live
+// replica
+type EvaluationMetrics struct {
+	NodeID      string  `json:"node_id"`
+	ShardCount  int     `json:"shard_count"`
+	OwnedShards []int32 `json:"owned_shards"`
+	Pairs       int     `json:"pairs"`
+	// alerts matched through the threshold index, and alerts evaluated on every cycle
+	IndexedAlerts     int               `json:"indexed_alerts"`
+	ScannedAlerts     int               `json:"scanned_alerts"`
+	IndexBuiltAt      time.Time         `json:"index_built_at"`
+	LastCycleAt       time.Time         `json:"last_cycle_at"`
+	LastCycleMs       int64             `json:"last_cycle_ms"`
+	MaxLagMs          int64             `json:"max_lag_ms"` // age of the stalest pair evaluation
+	AlertsEvaluated   int64             `json:"alerts_evaluated"`
+	AlertsTriggered   int64             `json:"alerts_triggered"`
+	DuplicateTriggers int64             `json:"duplicate_triggers"` // triggers another replica had already claimed
+	EvaluatedPerMin   float64           `json:"evaluated_per_minute"`
+	TriggeredPerMin   float64           `json:"triggered_per_minute"`
+	UnownedShards     []int32           `json:"unowned_shards"` // shards no live replica holds
+	Evaluators        []EvaluatorStatus `json:"evaluators"`
+}
+
+// EvaluatorStatus is a replica's last heartbeat
+type EvaluatorStatus struct {
+	NodeID            string    `json:"node_id"`
+	Shards            int       `json:"shards"`
+	HeartbeatAt       time.Time `json:"heartbeat_at"`
+	StartedAt         time.Time `json:"started_at"`
+	AlertsEvaluated   int64     `json:"alerts_evaluated"`
+	AlertsTriggered   int64     `json:"alerts_triggered"`
+	DuplicateTriggers int64     `json:"duplicate_triggers"`
+	LastCycleMs       int32     `json:"last_cycle_ms"`
+	MaxLagMs          int32     `json:"max_lag_ms"`
+}
+
+// heartbeat carries the replica's counters into its evaluator row
+func (e *alertEvaluator) heartbeat() db.UpsertAlertEvaluatorParams {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return db.UpsertAlertEvaluatorParams{
+		AlertsEvaluated:   e.evaluated,
+		AlertsTriggered:   e.triggered,
+		DuplicateTriggers: e.duplicates,
+		LastCycleMs:       int32(e.lastCycle.Milliseconds()),
+		MaxLagMs:          int32(e.maxLag(time.Now()).Milliseconds()),
+	}
+}
+
+// maxLag is the age of the stalest pair evaluation; callers hold mu
+func (e *alertEvaluator) maxLag(now time.Time) time.Duration {
+	if e.oldestCheck.IsZero() {
+		return 0
+	}
+	return now.Sub(e.oldestCheck)
+}
+
+func (e *alertEvaluator) recordTrigger() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.triggered++
+}
+
+func (e *alertEvaluator) recordDuplicate() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.duplicates++
+}
+
+// recordCycle stores the figures of a finished cycle; callers hold cycle
+func (e *alertEvaluator) recordCycle(start time.Time, evaluated int64) {
+	now := time.Now()
+	indexed, scanned := e.index.alertCounts()
+
+	var oldest time.Time
+	for _, pair := range e.index.pairs {
+		if oldest.IsZero() || pair.checkedAt.Before(oldest) {
+			oldest = pair.checkedAt
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.evaluated += evaluated
+	e.lastCycleAt = now
+	e.lastCycle = now.Sub(start)
+	e.pairs = len(e.index.pairs)
+	e.indexed = indexed
+	e.scanned = scanned
+	e.indexBuilt = e.builtAt
+	e.oldestCheck = oldest
+
+	e.samples = append(e.samples, cycleSample{at: now, evaluated: evaluated, triggered: e.triggered - e.sampled})
+	e.sampled = e.triggered
+	for len(e.samples) > 0 && now.Sub(e.samples[0].at) > throughputWindow {
+		e.samples = e.samples[1:]
+	}
+}
+
+// EvaluationMetrics returns lag and throughput for this replica, with the shard ownership and
+// heartbeat of every live replica
+func (s *PriceAlertService) EvaluationMetrics(ctx context.Context) (*EvaluationMetrics, error) {
+	e := s.evaluator
+	now := time.Now()
+
+	e.mu.RLock()
+	metrics := &EvaluationMetrics{
+		NodeID:            e.coordinator.nodeID,
+		ShardCount:        e.config.ShardCount,
+		OwnedShards:       e.coordinator.ownedShards(),
+		Pairs:             e.pairs,
+		IndexedAlerts:     e.indexed,
+		ScannedAlerts:     e.scanned,
+		IndexBuiltAt:      e.indexBuilt,
+		LastCycleAt:       e.lastCycleAt,
+		LastCycleMs:       e.lastCycle.Milliseconds(),
+		MaxLagMs:          e.maxLag(now).Milliseconds(),
+		AlertsEvaluated:   e.evaluated,
+		AlertsTriggered:   e.triggered,
+		DuplicateTriggers: e.duplicates,
+	}
+	if len(e.samples) > 0 {
+		var evaluated, triggered int64
+		for _, sample := range e.samples {
+			evaluated += sample.evaluated
+			triggered += sample.triggered
+		}
+		minutes := max(now.Sub(e.samples[0].at).Minutes(), s.checkInterval.Minutes())
+		metrics.EvaluatedPerMin = float64(evaluated) / minutes
+		metrics.TriggeredPerMin = float64(triggered) / minutes
+	}
+	e.mu.RUnlock()
+
+	nodes, err := s.store.ListLiveAlertEvaluators(ctx, e.coordinator.ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list evaluators: %w", err)
+	}
+	leases, err := s.store.ListAlertShardLeases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shard leases: %w", err)
+	}
+
+	held := make(map[int32]bool, len(leases))
+	shardsByNode := make(map[string]int)
+	for _, lease := range leases {
+		if lease.ExpiresAt.After(now) {
+			held[lease.Shard] = true
+			shardsByNode[lease.NodeID]++
+		}
+	}
+	metrics.UnownedShards = []int32{}
+	for shard := int32(0); shard < int32(e.config.ShardCount); shard++ {
+		if !held[shard] {
+			metrics.UnownedShards = append(metrics.UnownedShards, shard)
+		}
+	}
+
+	metrics.Evaluators = make([]EvaluatorStatus, 0, len(nodes))
+	for _, node := range nodes {
+		metrics.Evaluators = append(metrics.Evaluators, EvaluatorStatus{
+			NodeID:            node.NodeID,
+			Shards:            shardsByNode[node.NodeID],
+			HeartbeatAt:       node.HeartbeatAt,
+			StartedAt:         node.StartedAt,
+			AlertsEvaluated:   node.AlertsEvaluated,
+			AlertsTriggered:   node.AlertsTriggered,
+			DuplicateTriggers: node.DuplicateTriggers,
+			LastCycleMs:       node.LastCycleMs,
+			MaxLagMs:          node.MaxLagMs,
+		})
+	}
+
+	return metrics, nil
+}
+
+// StopEvaluation hands this replica's shards back once any running cycle finishes, so the
+// other replicas pick them up on their next cycle
+func (s *PriceAlertService) StopEvaluation(ctx context.Context) {
+	s.evaluator.cycle.Lock()
+	defer s.evaluator.cycle.Unlock()
+
+	s.evaluator.coordinator.release(ctx)
+}
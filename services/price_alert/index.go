@@ -0,0 +1,137 @@
+package pricealert
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// pairIndex holds the active alerts of one currency pair. Plain above and below alerts are
+// kept in target order, so a rate finds the alerts it crosses by binary search; every other
+// condition depends on more than the current rate and is evaluated each cycle.
+type pairIndex struct {
+	sourceCurrency string
+	targetCurrency string
+	above          []*PriceAlert // by target rate, ascending
+	below          []*PriceAlert // by target rate, ascending
+	scan           []*PriceAlert
+	checkedAt      time.Time // last time the pair's rate was evaluated
+	checkedSavedAt time.Time // last time last_checked_at was written for the pair
+}
+
+// indexable reports whether the alert triggers on the current rate crossing a fixed target.
+// Trailing alerts move their target as the rate moves, so they are evaluated each cycle.
+func indexable(alert *PriceAlert) bool {
+	return alert.AlertType != AlertTypeTrailing && alert.TargetRate != nil &&
+		(alert.AlertCondition == ConditionAbove || alert.AlertCondition == ConditionBelow)
+}
+
+// match returns the alerts to evaluate at rate: indexed alerts whose target the rate has
+// crossed, followed by every alert that is evaluated each cycle
+func (p *pairIndex) match(rate decimal.Decimal) []*PriceAlert {
+	// above triggers when the rate is greater than the target: a prefix of the list
+	aboveEnd := sort.Search(len(p.above), func(i int) bool {
+		return p.above[i].TargetRate.GreaterThanOrEqual(rate)
+	})
+	// below triggers when the rate is less than the target: a suffix of the list
+	belowStart := sort.Search(len(p.below), func(i int) bool {
+		return p.below[i].TargetRate.GreaterThan(rate)
+	})
+
+	matched := make([]*PriceAlert, 0, aboveEnd+len(p.below)-belowStart+len(p.scan))
+	matched = append(matched, p.above[:aboveEnd]...)
+	matched = append(matched, p.below[belowStart:]...)
+	matched = append(matched, p.scan...)
+	return matched
+}
+
+func (p *pairIndex) size() int {
+	return len(p.above) + len(p.below) + len(p.scan)
+}
+
+// alertIndex is a replica's in-memory view of the active alerts in the shards it holds
+type alertIndex struct {
+	pairs map[string]*pairIndex
+	byID  map[uuid.UUID]*PriceAlert
+}
+
+func newAlertIndex() *alertIndex {
+	return &alertIndex{
+		pairs: make(map[string]*pairIndex),
+		byID:  make(map[uuid.UUID]*PriceAlert),
+	}
+}
+
+func pairKey(sourceCurrency, targetCurrency string) string {
+	return sourceCurrency + "/" + targetCurrency
+}
+
+// put adds the alert, replacing any earlier version of it
+func (x *alertIndex) put(alert *PriceAlert) {
+	x.remove(alert.ID)
+
+	key := pairKey(alert.SourceCurrency, alert.TargetCurrency)
+	pair, ok := x.pairs[key]
+	if !ok {
+		pair = &pairIndex{sourceCurrency: alert.SourceCurrency, targetCurrency: alert.TargetCurrency}
+		x.pairs[key] = pair
+	}
+
+	switch {
+	case !indexable(alert):
+		pair.scan = append(pair.scan, alert)
+	case alert.AlertCondition == ConditionAbove:
+		pair.above = insertByTarget(pair.above, alert)
+	default:
+		pair.below = insertByTarget(pair.below, alert)
+	}
+	x.byID[alert.ID] = alert
+}
+
+// remove drops the alert if the index holds it
+func (x *alertIndex) remove(id uuid.UUID) {
+	alert, ok := x.byID[id]
+	if !ok {
+		return
+	}
+	delete(x.byID, id)
+
+	key := pairKey(alert.SourceCurrency, alert.TargetCurrency)
+	pair := x.pairs[key]
+	pair.above = removeAlert(pair.above, id)
+	pair.below = removeAlert(pair.below, id)
+	pair.scan = removeAlert(pair.scan, id)
+	if pair.size() == 0 {
+		delete(x.pairs, key)
+	}
+}
+
+// alertCounts returns the number of indexed alerts and of alerts evaluated each cycle
+func (x *alertIndex) alertCounts() (indexed, scanned int) {
+	for _, pair := range x.pairs {
+		indexed += len(pair.above) + len(pair.below)
+		scanned += len(pair.scan)
+	}
+	return indexed, scanned
+}
+
+func insertByTarget(alerts []*PriceAlert, alert *PriceAlert) []*PriceAlert {
+	i := sort.Search(len(alerts), func(i int) bool {
+		return alerts[i].TargetRate.GreaterThan(*alert.TargetRate)
+	})
+	alerts = append(alerts, nil)
+	copy(alerts[i+1:], alerts[i:])
+	alerts[i] = alert
+	return alerts
+}
+
+func removeAlert(alerts []*PriceAlert, id uuid.UUID) []*PriceAlert {
+	for i, alert := range alerts {
+		if alert.ID == id {
+			return append(alerts[:i], alerts[i+1:]...)
+		}
+	}
+	return alerts
+}
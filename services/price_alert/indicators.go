@@ -0,0 +1,307 @@
+package pricealert
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	pricehistory "github.com/SwiftFiat/SwiftFiat-Backend/services/price_history"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	minIndicatorPeriod = 2
+	maxIndicatorPeriod = 200
+
+	// EMA and RSI are seeded with a simple average, so they read extra candles
+	// until the seed no longer dominates the value
+	smoothingWarmup = 3
+
+	defaultBollingerWidth = 2
+)
+
+// indicatorSeries holds the closed candles of one pair and interval for a check cycle.
+// Closes are parsed once and shared by every alert reading the series.
+type indicatorSeries struct {
+	interval pricehistory.CandleInterval
+	candles  []pricehistory.Candle
+	closes   []float64
+}
+
+// IsIndicator reports whether the condition is evaluated over candle history
+// rather than against the current rate alone
+func (c AlertCondition) IsIndicator() bool {
+	switch c {
+	case ConditionSMACrossUp, ConditionSMACrossDown, ConditionEMACrossUp, ConditionEMACrossDown,
+		ConditionRSIAbove, ConditionRSIBelow, ConditionBollingerUpper, ConditionBollingerLower,
+		ConditionPeriodHigh, ConditionPeriodLow:
+		return true
+	}
+	return false
+}
+
+func (c AlertCondition) isCrossover() bool {
+	switch c {
+	case ConditionSMACrossUp, ConditionSMACrossDown, ConditionEMACrossUp, ConditionEMACrossDown:
+		return true
+	}
+	return false
+}
+
+// validateIndicatorConfig checks the windows and threshold of an indicator condition
+func (s *PriceAlertService) validateIndicatorConfig(req *CreateAlertRequest) error {
+	if req.IndicatorInterval == nil || !pricehistory.IsCandleIntervalValid(*req.IndicatorInterval) {
+		return fmt.Errorf("indicator_interval must be one of 1m, 1h, 1d for %s condition", req.AlertCondition)
+	}
+	if req.IndicatorPeriod == nil || *req.IndicatorPeriod < minIndicatorPeriod || *req.IndicatorPeriod > maxIndicatorPeriod {
+		return fmt.Errorf("indicator_period must be between %d and %d for %s condition", minIndicatorPeriod, maxIndicatorPeriod, req.AlertCondition)
+	}
+	if !s.priceHistory.IsTracked(strings.ToUpper(req.SourceCurrency), strings.ToUpper(req.TargetCurrency)) {
+		return fmt.Errorf("%s/%s has no rate history for indicator alerts", req.SourceCurrency, req.TargetCurrency)
+	}
+
+	switch {
+	case req.AlertCondition.isCrossover():
+		if req.IndicatorFastPeriod == nil || *req.IndicatorFastPeriod < 1 || *req.IndicatorFastPeriod >= *req.IndicatorPeriod {
+			return fmt.Errorf("indicator_fast_period is required and must be less than indicator_period for %s condition", req.AlertCondition)
+		}
+
+	case req.AlertCondition == ConditionRSIAbove || req.AlertCondition == ConditionRSIBelow:
+		if req.IndicatorThreshold == nil || !req.IndicatorThreshold.IsPositive() || req.IndicatorThreshold.GreaterThanOrEqual(decimal.NewFromInt(100)) {
+			return fmt.Errorf("indicator_threshold is required and must be between 0 and 100 for %s condition", req.AlertCondition)
+		}
+
+	case req.AlertCondition == ConditionBollingerUpper || req.AlertCondition == ConditionBollingerLower:
+		if req.IndicatorThreshold != nil && !req.IndicatorThreshold.IsPositive() {
+			return fmt.Errorf("indicator_threshold must be a positive number of standard deviations for %s condition", req.AlertCondition)
+		}
+	}
+
+	return nil
+}
+
+// candlesRequired is the number of closed candles the alert's indicator reads
+func candlesRequired(alert *PriceAlert) int {
+	if alert.IndicatorPeriod == nil {
+		return 0
+	}
+	period := *alert.IndicatorPeriod
+
+	switch alert.AlertCondition {
+	case ConditionEMACrossUp, ConditionEMACrossDown, ConditionRSIAbove, ConditionRSIBelow:
+		return smoothingWarmup*period + 1
+	default:
+		return period + 1
+	}
+}
+
+// loadIndicatorSeries fetches candles once per interval used by the pair's indicator alerts,
+// deep enough for the longest window among them
+func (s *PriceAlertService) loadIndicatorSeries(ctx context.Context, base, quote string, alerts []*PriceAlert) map[pricehistory.CandleInterval]*indicatorSeries {
+	depth := make(map[pricehistory.CandleInterval]int)
+	for _, alert := range alerts {
+		if !alert.AlertCondition.IsIndicator() || alert.IndicatorInterval == nil {
+			continue
+		}
+		interval := pricehistory.CandleInterval(*alert.IndicatorInterval)
+		if n := candlesRequired(alert); n > depth[interval] {
+			depth[interval] = n
+		}
+	}
+
+	series := make(map[pricehistory.CandleInterval]*indicatorSeries, len(depth))
+	for interval, count := range depth {
+		candles, err := s.priceHistory.ClosedCandles(ctx, base, quote, interval, count)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to load %s candles for %s/%s: %v", interval, base, quote, err))
+			continue
+		}
+
+		closes := make([]float64, len(candles))
+		for i, c := range candles {
+			closes[i] = c.Close.InexactFloat64()
+		}
+		series[interval] = &indicatorSeries{interval: interval, candles: candles, closes: closes}
+	}
+
+	return series
+}
+
+// evaluateIndicator checks an indicator condition on the latest closed candle. Conditions
+// fire on the candle where they become true, and at most once per candle, so a recurring
+// alert does not re-trigger on every tick while the bucket stays open.
+func evaluateIndicator(alert *PriceAlert, series *indicatorSeries) (bool, string) {
+	if series == nil || alert.IndicatorPeriod == nil || len(series.candles) < candlesRequired(alert) {
+		return false, ""
+	}
+
+	last := series.candles[len(series.candles)-1]
+	closedAt := last.Time.Add(series.interval.Duration())
+	if alert.LastTriggeredAt != nil && !alert.LastTriggeredAt.Before(closedAt) {
+		return false, ""
+	}
+
+	period := *alert.IndicatorPeriod
+	closes := series.closes
+	n := len(closes)
+
+	switch alert.AlertCondition {
+	case ConditionSMACrossUp, ConditionSMACrossDown, ConditionEMACrossUp, ConditionEMACrossDown:
+		if alert.IndicatorFastPeriod == nil {
+			return false, ""
+		}
+		fast := *alert.IndicatorFastPeriod
+
+		name := "SMA"
+		var fastPrev, fastCur, slowPrev, slowCur float64
+		if alert.AlertCondition == ConditionEMACrossUp || alert.AlertCondition == ConditionEMACrossDown {
+			name = "EMA"
+			fastPrev, fastCur = ema(closes, fast)
+			slowPrev, slowCur = ema(closes, period)
+		} else {
+			fastPrev, fastCur = sma(closes, fast, n-2), sma(closes, fast, n-1)
+			slowPrev, slowCur = sma(closes, period, n-2), sma(closes, period, n-1)
+		}
+
+		up := alert.AlertCondition == ConditionSMACrossUp || alert.AlertCondition == ConditionEMACrossUp
+		if up && fastPrev <= slowPrev && fastCur > slowCur {
+			return true, fmt.Sprintf("%s(%d) crossed above %s(%d) on %s candles (%.6f > %.6f)",
+				name, fast, name, period, series.interval, fastCur, slowCur)
+		}
+		if !up && fastPrev >= slowPrev && fastCur < slowCur {
+			return true, fmt.Sprintf("%s(%d) crossed below %s(%d) on %s candles (%.6f < %.6f)",
+				name, fast, name, period, series.interval, fastCur, slowCur)
+		}
+
+	case ConditionRSIAbove, ConditionRSIBelow:
+		if alert.IndicatorThreshold == nil {
+			return false, ""
+		}
+		level := alert.IndicatorThreshold.InexactFloat64()
+		prev, cur := rsi(closes, period)
+
+		if alert.AlertCondition == ConditionRSIAbove && prev <= level && cur > level {
+			return true, fmt.Sprintf("RSI(%d) rose above %.2f on %s candles (%.2f)", period, level, series.interval, cur)
+		}
+		if alert.AlertCondition == ConditionRSIBelow && prev >= level && cur < level {
+			return true, fmt.Sprintf("RSI(%d) fell below %.2f on %s candles (%.2f)", period, level, series.interval, cur)
+		}
+
+	case ConditionBollingerUpper, ConditionBollingerLower:
+		width := float64(defaultBollingerWidth)
+		if alert.IndicatorThreshold != nil {
+			width = alert.IndicatorThreshold.InexactFloat64()
+		}
+		prevUpper, prevLower := bollinger(closes, period, width, n-2)
+		upper, lower := bollinger(closes, period, width, n-1)
+
+		if alert.AlertCondition == ConditionBollingerUpper && closes[n-2] <= prevUpper && closes[n-1] > upper {
+			return true, fmt.Sprintf("Price closed above the upper Bollinger band (%.6f) on %s candles", upper, series.interval)
+		}
+		if alert.AlertCondition == ConditionBollingerLower && closes[n-2] >= prevLower && closes[n-1] < lower {
+			return true, fmt.Sprintf("Price closed below the lower Bollinger band (%.6f) on %s candles", lower, series.interval)
+		}
+
+	case ConditionPeriodHigh:
+		prior := series.candles[n-1-period : n-1]
+		high := prior[0].High
+		for _, c := range prior[1:] {
+			high = decimal.Max(high, c.High)
+		}
+		if last.Close.GreaterThan(high) {
+			return true, fmt.Sprintf("Price closed above the %d-candle high of %s on %s candles",
+				period, high.StringFixed(6), series.interval)
+		}
+
+	case ConditionPeriodLow:
+		prior := series.candles[n-1-period : n-1]
+		low := prior[0].Low
+		for _, c := range prior[1:] {
+			low = decimal.Min(low, c.Low)
+		}
+		if last.Close.LessThan(low) {
+			return true, fmt.Sprintf("Price closed below the %d-candle low of %s on %s candles",
+				period, low.StringFixed(6), series.interval)
+		}
+	}
+
+	return false, ""
+}
+
+// sma is the simple moving average of the period values ending at index end
+func sma(values []float64, period, end int) float64 {
+	var sum float64
+	for _, v := range values[end-period+1 : end+1] {
+		sum += v
+	}
+	return sum / float64(period)
+}
+
+// ema returns the exponential moving average at the second-to-last and last value,
+// seeded with the simple average of the first period values
+func ema(values []float64, period int) (prev, cur float64) {
+	alpha := 2 / float64(period+1)
+
+	cur = sma(values, period, period-1)
+	prev = cur
+	for _, v := range values[period:] {
+		prev = cur
+		cur = alpha*v + (1-alpha)*cur
+	}
+	return prev, cur
+}
+
+// rsi returns Wilder's relative strength index at the second-to-last and last value
+func rsi(values []float64, period int) (prev, cur float64) {
+	var avgGain, avgLoss float64
+	for i := 1; i <= period; i++ {
+		gain, loss := change(values[i-1], values[i])
+		avgGain += gain
+		avgLoss += loss
+	}
+	avgGain /= float64(period)
+	avgLoss /= float64(period)
+
+	cur = rsiValue(avgGain, avgLoss)
+	prev = cur
+	for i := period + 1; i < len(values); i++ {
+		gain, loss := change(values[i-1], values[i])
+		avgGain = (avgGain*float64(period-1) + gain) / float64(period)
+		avgLoss = (avgLoss*float64(period-1) + loss) / float64(period)
+		prev = cur
+		cur = rsiValue(avgGain, avgLoss)
+	}
+	return prev, cur
+}
+
+func change(from, to float64) (gain, loss float64) {
+	if to > from {
+		return to - from, 0
+	}
+	return 0, from - to
+}
+
+func rsiValue(avgGain, avgLoss float64) float64 {
+	if avgLoss == 0 {
+		if avgGain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+avgGain/avgLoss)
+}
+
+// bollinger returns the bands width standard deviations either side of the
+// period simple moving average ending at index end
+func bollinger(values []float64, period int, width float64, end int) (upper, lower float64) {
+	mean := sma(values, period, end)
+
+	var variance float64
+	for _, v := range values[end-period+1 : end+1] {
+		variance += (v - mean) * (v - mean)
+	}
+	stddev := math.Sqrt(variance / float64(period))
+
+	return mean + width*stddev, mean - width*stddev
+}
@@ -100,10 +100,23 @@ func (s *AlertScheduler) Start() error {
 		return err
 	}
 
+	// Register retry task for failed alert deliveries
+	_, err = s.taskScheduler.AddTask(
+		"price-alert-delivery-retry",
+		"Retry Failed Alert Deliveries",
+		s.retryDeliveries,
+		1*time.Minute,
+	)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to register delivery retry task: %v", err))
+		return err
+	}
+
 	// Start all tasks with initial delay
 	s.taskScheduler.ScheduleTask("price-alert-checker", 5*time.Second)
 	s.taskScheduler.ScheduleTask("price-alert-metrics", 1*time.Minute)
 	s.taskScheduler.ScheduleTask("price-alert-cleanup", 10*time.Second)
+	s.taskScheduler.ScheduleTask("price-alert-delivery-retry", 30*time.Second)
 
 	s.logger.Info(fmt.Sprintf("Price alert scheduler started. Check interval: %s", s.checkInterval))
 	return nil
@@ -116,6 +129,12 @@ func (s *AlertScheduler) Stop() error {
 	s.taskScheduler.StopTask("price-alert-checker")
 	s.taskScheduler.StopTask("price-alert-metrics")
 	s.taskScheduler.StopTask("price-alert-cleanup")
+	s.taskScheduler.StopTask("price-alert-delivery-retry")
+
+	// Hand this replica's alert shards to the others straight away
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.alertService.StopEvaluation(ctx)
 	
 	s.logger.Info("Price alert scheduler stopped")
 	return nil
@@ -203,6 +222,15 @@ func (s *AlertScheduler) cleanupExpiredAlerts(ctx context.Context) error {
 	return nil
 }
 
+// retryDeliveries re-sends alert deliveries that failed and are due another attempt
+func (s *AlertScheduler) retryDeliveries(ctx context.Context) error {
+	if err := s.alertService.RetryFailedDeliveries(ctx); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to retry alert deliveries: %v", err))
+		return err
+	}
+	return nil
+}
+
 // recordSuccessfulCheck updates metrics for successful check
 func (s *AlertScheduler) recordSuccessfulCheck(duration time.Duration) {
 	s.metrics.mu.Lock()
@@ -259,7 +287,7 @@ func (s *AlertScheduler) GetStats(ctx context.Context) map[string]interface{} {
 	}
 
 	// Add task info
-	tasks := []string{"price-alert-checker", "price-alert-metrics", "price-alert-cleanup"}
+	tasks := []string{"price-alert-checker", "price-alert-metrics", "price-alert-cleanup", "price-alert-delivery-retry"}
 	taskStats := make(map[string]interface{})
 
 	for _, taskID := range tasks {
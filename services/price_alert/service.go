@@ -3,13 +3,18 @@ package pricealert
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
 	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
 	service "github.com/SwiftFiat/SwiftFiat-Backend/services/notification"
+	pricehistory "github.com/SwiftFiat/SwiftFiat-Backend/services/price_history"
+	smartconversion "github.com/SwiftFiat/SwiftFiat-Backend/services/smart_conversion"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -25,6 +30,18 @@ const (
 	ConditionPercentDown AlertCondition = "percent_down" // Price decreases by X%
 	ConditionRange       AlertCondition = "range"        // Price enters a specific range
 	ConditionBreakout    AlertCondition = "breakout"     // Price breaks resistance/support
+
+	// Indicator conditions are evaluated on closed candles of indicator_interval
+	ConditionSMACrossUp     AlertCondition = "sma_cross_up"    // Fast SMA crosses above slow SMA
+	ConditionSMACrossDown   AlertCondition = "sma_cross_down"  // Fast SMA crosses below slow SMA
+	ConditionEMACrossUp     AlertCondition = "ema_cross_up"    // Fast EMA crosses above slow EMA
+	ConditionEMACrossDown   AlertCondition = "ema_cross_down"  // Fast EMA crosses below slow EMA
+	ConditionRSIAbove       AlertCondition = "rsi_above"       // RSI rises above threshold
+	ConditionRSIBelow       AlertCondition = "rsi_below"       // RSI falls below threshold
+	ConditionBollingerUpper AlertCondition = "bollinger_upper" // Close breaks above the upper band
+	ConditionBollingerLower AlertCondition = "bollinger_lower" // Close breaks below the lower band
+	ConditionPeriodHigh     AlertCondition = "period_high"     // Close breaks the N-candle high
+	ConditionPeriodLow      AlertCondition = "period_low"      // Close breaks the N-candle low
 )
 
 // AlertType defines the behavior of the alert
@@ -53,6 +70,14 @@ type PriceAlertService struct {
 	exchangeRateService *exchangerate.ExchangeRateService
 	notificationService *service.Notification
 	pushService         *service.PushNotificationService
+	email               *service.Plunk
+	config              *utils.Config
+	priceHistory        *pricehistory.PriceHistoryService
+	conversion          *smartconversion.ConversionService
+	realtime            RealtimePublisher
+	httpClient          *http.Client
+	delivery            AlertDeliveryConfig
+	evaluator           *alertEvaluator
 	// taskScheduler       *tasks.TaskScheduler
 	checkInterval time.Duration
 }
@@ -83,8 +108,17 @@ type PriceAlert struct {
 	ExpiresAt        *time.Time
 	NotifyPush       bool
 	NotifyInApp      bool
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	DeliveryChannels []DeliveryChannel
+	WebhookURL       *string
+	WebhookSecret    *string // signs webhook deliveries; see WebhookSignatureHeader
+	// Indicator conditions only
+	IndicatorInterval   *string
+	IndicatorPeriod     *int // slow window for crossovers
+	IndicatorFastPeriod *int
+	IndicatorThreshold  *decimal.Decimal // RSI level, or Bollinger width in standard deviations
+	ConvertAction       *ConvertAction
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
 }
 
 // CreateAlertRequest encapsulates parameters for creating a price alert
@@ -104,6 +138,18 @@ type CreateAlertRequest struct {
 	ExpiresAt        *time.Time       `json:"expires_at"`
 	NotifyPush       *bool            `json:"notify_push"`
 	NotifyInApp      *bool            `json:"notify_in_app"`
+	// push, email, sms, in_app, websocket, webhook; defaults to notify_push/notify_in_app when empty
+	DeliveryChannels []DeliveryChannel `json:"delivery_channels"`
+	WebhookURL       *string           `json:"webhook_url"` // HTTPS endpoint, required for the webhook channel
+	// Indicator conditions: candle width (1m, 1h, 1d) and windows; indicator_fast_period is
+	// the fast window for crossovers and indicator_threshold the RSI level or Bollinger width
+	IndicatorInterval   *string          `json:"indicator_interval"`
+	IndicatorPeriod     *int             `json:"indicator_period"`
+	IndicatorFastPeriod *int             `json:"indicator_fast_period"`
+	IndicatorThreshold  *decimal.Decimal `json:"indicator_threshold"`
+	// Converts between the user's wallets each time the alert triggers; needs pin
+	ConvertAction *ConvertAction `json:"convert_action"`
+	Pin           string         `json:"pin"` // transaction PIN, checked by the handler
 }
 
 // AlertTriggerEvent contains information about a triggered alert
@@ -121,6 +167,10 @@ func NewPriceAlertService(
 	exchangeRateService *exchangerate.ExchangeRateService,
 	notificationService *service.Notification,
 	pushService *service.PushNotificationService,
+	email *service.Plunk,
+	config *utils.Config,
+	priceHistory *pricehistory.PriceHistoryService,
+	conversion *smartconversion.ConversionService,
 	// taskScheduler *tasks.TaskScheduler,
 	checkInterval time.Duration,
 ) *PriceAlertService {
@@ -133,6 +183,13 @@ func NewPriceAlertService(
 		exchangeRateService: exchangeRateService,
 		notificationService: notificationService,
 		pushService:         pushService,
+		email:               email,
+		config:              config,
+		priceHistory:        priceHistory,
+		conversion:          conversion,
+		httpClient:          newWebhookClient(),
+		delivery:            LoadAlertDeliveryConfig(),
+		evaluator:           newAlertEvaluator(store, logger, LoadAlertEvaluationConfig()),
 		// taskScheduler:       taskScheduler,
 		checkInterval: checkInterval,
 	}
@@ -174,6 +231,15 @@ func (s *PriceAlertService) CreateAlert(ctx context.Context, userID uuid.UUID, r
 		return nil, err
 	}
 
+	var terms *smartconversion.AlertConversionTerms
+	if req.ConvertAction != nil {
+		terms, err = s.conversion.AuthorizeAlertConversion(ctx, userID,
+			req.ConvertAction.SourceCurrency, req.ConvertAction.TargetCurrency, req.ConvertAction.Amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Get current rate for baseline (especially important for percentage and trailing alerts)
 	currentRate, err := s.exchangeRateService.GetExchangeRate(ctx, req.SourceCurrency, req.TargetCurrency)
 	if err != nil {
@@ -186,10 +252,18 @@ func (s *PriceAlertService) CreateAlert(ctx context.Context, userID uuid.UUID, r
 	// }
 
 	// Set defaults
-	notifyPush := req.NotifyPush != nil && *req.NotifyPush
-	notifyInApp := true
-	if req.NotifyInApp != nil {
-		notifyInApp = *req.NotifyInApp
+	channels := resolveDeliveryChannels(req)
+	if err := validateDeliveryChannels(channels, req.WebhookURL); err != nil {
+		return nil, err
+	}
+
+	var webhookSecret *string
+	if req.WebhookURL != nil && *req.WebhookURL != "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhookSecret = &secret
 	}
 
 	priority := req.Priority
@@ -197,6 +271,13 @@ func (s *PriceAlertService) CreateAlert(ctx context.Context, userID uuid.UUID, r
 		priority = PriorityMedium
 	}
 
+	indicatorThreshold := req.IndicatorThreshold
+	if indicatorThreshold == nil &&
+		(req.AlertCondition == ConditionBollingerUpper || req.AlertCondition == ConditionBollingerLower) {
+		width := decimal.NewFromInt(defaultBollingerWidth)
+		indicatorThreshold = &width
+	}
+
 	// Initialize trailing alert baselines
 	var maxTrailingRate, minTrailingRate *decimal.Decimal
 	if req.AlertType == AlertTypeTrailing {
@@ -206,34 +287,46 @@ func (s *PriceAlertService) CreateAlert(ctx context.Context, userID uuid.UUID, r
 
 	// Create the alert in database
 	params := db.CreatePriceAlertParams{
-		UserID:           userID,
-		SourceCurrency:   req.SourceCurrency,
-		TargetCurrency:   req.TargetCurrency,
-		AlertCondition:   string(req.AlertCondition),
-		AlertType:        string(req.AlertType),
-		Priority:         string(priority),
-		TargetRate:       s.decimalToNullString(req.TargetRate),
-		PercentageChange: s.decimalToNullString(req.PercentageChange),
-		RangeMin:         s.decimalToNullString(req.RangeMin),
-		RangeMax:         s.decimalToNullString(req.RangeMax),
-		BaselineRate:     s.decimalToNullString(&currentRate.Rate),
-		TrailingDistance: s.decimalToNullString(req.TrailingDistance),
-		MaxTrailingRate:  s.decimalToNullString(maxTrailingRate),
-		MinTrailingRate:  s.decimalToNullString(minTrailingRate),
-		Description:      s.stringToNullString(req.Description),
-		Label:            s.stringToNullString(req.Label),
-		ExpiresAt:        s.timeToNullTime(req.ExpiresAt),
-		NotifyPush:       notifyPush,
-		NotifyInApp:      notifyInApp,
-	}
-
-	alert, err := s.store.CreatePriceAlert(ctx, params)
+		UserID:              userID,
+		SourceCurrency:      req.SourceCurrency,
+		TargetCurrency:      req.TargetCurrency,
+		AlertCondition:      string(req.AlertCondition),
+		AlertType:           string(req.AlertType),
+		Priority:            string(priority),
+		TargetRate:          s.decimalToNullString(req.TargetRate),
+		PercentageChange:    s.decimalToNullString(req.PercentageChange),
+		RangeMin:            s.decimalToNullString(req.RangeMin),
+		RangeMax:            s.decimalToNullString(req.RangeMax),
+		BaselineRate:        s.decimalToNullString(&currentRate.Rate),
+		TrailingDistance:    s.decimalToNullString(req.TrailingDistance),
+		MaxTrailingRate:     s.decimalToNullString(maxTrailingRate),
+		MinTrailingRate:     s.decimalToNullString(minTrailingRate),
+		Description:         s.stringToNullString(req.Description),
+		Label:               s.stringToNullString(req.Label),
+		ExpiresAt:           s.timeToNullTime(req.ExpiresAt),
+		NotifyPush:          hasChannel(channels, ChannelPush),
+		NotifyInApp:         hasChannel(channels, ChannelInApp),
+		DeliveryChannels:    channelsToStrings(channels),
+		WebhookUrl:          s.stringToNullString(req.WebhookURL),
+		WebhookSecret:       s.stringToNullString(webhookSecret),
+		IndicatorInterval:   s.stringToNullString(req.IndicatorInterval),
+		IndicatorPeriod:     s.intToNullInt32(req.IndicatorPeriod),
+		IndicatorFastPeriod: s.intToNullInt32(req.IndicatorFastPeriod),
+		IndicatorThreshold:  s.decimalToNullString(indicatorThreshold),
+	}
+
+	var alert db.PriceAlert
+	if req.ConvertAction != nil {
+		alert, err = s.createAlertWithAction(ctx, params, req.ConvertAction, terms)
+	} else {
+		alert, err = s.store.CreatePriceAlert(ctx, params)
+	}
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("Failed to create price alert: %v", err))
 		return nil, fmt.Errorf("failed to create price alert: %w", err)
 	}
 
-	return s.dbAlertToModel(&alert), nil
+	return s.withConvertAction(ctx, s.dbAlertToModel(&alert)), nil
 }
 
 // validateAlertConfig validates alert configuration based on condition type
@@ -261,6 +354,14 @@ func (s *PriceAlertService) validateAlertConfig(req *CreateAlertRequest) error {
 		if req.TargetRate == nil {
 			return fmt.Errorf("target_rate is required for breakout condition")
 		}
+
+	default:
+		if !req.AlertCondition.IsIndicator() {
+			return fmt.Errorf("unsupported alert condition: %s", req.AlertCondition)
+		}
+		if err := s.validateIndicatorConfig(req); err != nil {
+			return err
+		}
 	}
 
 	// Validate trailing alert
@@ -277,52 +378,134 @@ func (s *PriceAlertService) validateAlertConfig(req *CreateAlertRequest) error {
 	return nil
 }
 
-// CheckAlerts processes all active alerts and triggers notifications
+// CheckAlerts evaluates the alerts of the currency pairs in the shards this replica leases.
+// Replicas split the shards between them, so each pair is evaluated by one replica at a time.
 func (s *PriceAlertService) CheckAlerts(ctx context.Context) error {
-	s.logger.Debug("Checking active price alerts...")
+	e := s.evaluator
+	e.cycle.Lock()
+	defer e.cycle.Unlock()
 
-	// Get all active alerts grouped by currency pair to optimize rate fetching
-	alerts, err := s.store.GetActivePriceAlerts(ctx)
+	startTime := time.Now()
+
+	shardsChanged, err := e.coordinator.rebalance(ctx, e.heartbeat())
 	if err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to fetch active alerts: %v", err))
+		// without renewed leases the replica cannot tell which pairs are its own
+		s.logger.Error(fmt.Sprintf("Failed to rebalance alert shards: %v", err))
 		return err
 	}
-
-	if len(alerts) == 0 {
-		s.logger.Debug("No active price alerts to check")
-		return nil
+	if shardsChanged {
+		s.logger.Info(fmt.Sprintf("Evaluator %s now holds alert shards %v", e.coordinator.nodeID, e.coordinator.ownedShards()))
 	}
 
-	s.logger.Info(fmt.Sprintf("Checking %d active price alerts", len(alerts)))
+	if err := s.refreshIndex(ctx, shardsChanged); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to refresh alert index: %v", err))
+		return err
+	}
 
-	// Group alerts by currency pair to minimize API calls
-	alertsByCurrencyPair := make(map[string][]*PriceAlert)
-	for _, alert := range alerts {
-		model := s.dbAlertToModel(&alert)
-		key := fmt.Sprintf("%s/%s", model.SourceCurrency, model.TargetCurrency)
-		alertsByCurrencyPair[key] = append(alertsByCurrencyPair[key], model)
+	if len(e.index.pairs) == 0 {
+		s.logger.Debug("No active price alerts in this evaluator's shards")
+		e.recordCycle(startTime, 0)
+		return nil
 	}
 
 	// Process each currency pair
-	for pair, pairAlerts := range alertsByCurrencyPair {
-		if err := s.checkCurrencyPairAlerts(ctx, pairAlerts); err != nil {
-			s.logger.Error(fmt.Sprintf("Error checking alerts for %s: %v", pair, err))
+	var evaluated int64
+	for key, pair := range e.index.pairs {
+		count := pair.size()
+		if err := s.checkCurrencyPairAlerts(ctx, pair); err != nil {
+			s.logger.Error(fmt.Sprintf("Error checking alerts for %s: %v", key, err))
 			// Continue processing other pairs even if one fails
+			continue
 		}
+		evaluated += int64(count)
 	}
 
+	e.recordCycle(startTime, evaluated)
 	return nil
 }
 
-// checkCurrencyPairAlerts checks all alerts for a specific currency pair
-func (s *PriceAlertService) checkCurrencyPairAlerts(ctx context.Context, alerts []*PriceAlert) error {
-	if len(alerts) == 0 {
-		return nil
+// refreshIndex brings the evaluator's index up to date. The index is reloaded in full when the
+// held shards change or the rebuild interval passes; in between, only alerts changed since the
+// last refresh are read.
+func (s *PriceAlertService) refreshIndex(ctx context.Context, shardsChanged bool) error {
+	e := s.evaluator
+	now := time.Now()
+
+	if shardsChanged || e.builtAt.IsZero() || now.Sub(e.builtAt) >= time.Duration(e.config.IndexRebuildSeconds)*time.Second {
+		return s.rebuildIndex(ctx, now)
+	}
+
+	changed, err := s.store.ListPriceAlertsChangedSince(ctx, e.refreshedAt.Add(-refreshOverlap))
+	if err != nil {
+		return fmt.Errorf("failed to load changed alerts: %w", err)
+	}
+
+	for i := range changed {
+		dbAlert := &changed[i]
+		if !e.coordinator.owns(dbAlert.SourceCurrency, dbAlert.TargetCurrency) {
+			continue
+		}
+		if !dbAlert.IsActive || dbAlert.DeletedAt.Valid || (dbAlert.ExpiresAt.Valid && !dbAlert.ExpiresAt.Time.After(now)) {
+			e.index.remove(dbAlert.ID)
+			continue
+		}
+		e.index.put(s.dbAlertToModel(dbAlert))
 	}
 
-	// All alerts in this group have the same currency pair
-	sourceCurrency := alerts[0].SourceCurrency
-	targetCurrency := alerts[0].TargetCurrency
+	e.refreshedAt = now
+	return nil
+}
+
+// rebuildIndex loads every active alert of the held shards
+func (s *PriceAlertService) rebuildIndex(ctx context.Context, now time.Time) error {
+	e := s.evaluator
+
+	pairs, err := s.store.ListActivePriceAlertPairs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list alert pairs: %w", err)
+	}
+
+	var sources, targets []string
+	for _, pair := range pairs {
+		if e.coordinator.owns(pair.SourceCurrency, pair.TargetCurrency) {
+			sources = append(sources, pair.SourceCurrency)
+			targets = append(targets, pair.TargetCurrency)
+		}
+	}
+
+	index := newAlertIndex()
+	if len(sources) > 0 {
+		alerts, err := s.store.GetActivePriceAlertsByPairs(ctx, db.GetActivePriceAlertsByPairsParams{
+			SourceCurrencies: sources,
+			TargetCurrencies: targets,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load alerts: %w", err)
+		}
+		for i := range alerts {
+			index.put(s.dbAlertToModel(&alerts[i]))
+		}
+	}
+
+	// Pairs kept across the rebuild keep their evaluation times, so lag reads true
+	for key, pair := range index.pairs {
+		if previous, ok := e.index.pairs[key]; ok {
+			pair.checkedAt = previous.checkedAt
+			pair.checkedSavedAt = previous.checkedSavedAt
+		}
+	}
+
+	e.index = index
+	e.builtAt = now
+	e.refreshedAt = now
+	s.logger.Info(fmt.Sprintf("Loaded %d price alerts across %d pairs", len(index.byID), len(index.pairs)))
+	return nil
+}
+
+// checkCurrencyPairAlerts checks the alerts of one currency pair against its current rate
+func (s *PriceAlertService) checkCurrencyPairAlerts(ctx context.Context, pair *pairIndex) error {
+	sourceCurrency := pair.sourceCurrency
+	targetCurrency := pair.targetCurrency
 
 	// Fetch current rate once for all alerts in this pair
 	currentRate, err := s.exchangeRateService.GetExchangeRate(ctx, sourceCurrency, targetCurrency)
@@ -335,22 +518,45 @@ func (s *PriceAlertService) checkCurrencyPairAlerts(ctx context.Context, alerts
 	// 	return fmt.Errorf("failed to parse rate: %w", err)
 	// }
 
+	// Only alerts whose target the rate has crossed, and those evaluated every cycle
+	alerts := pair.match(currentRate.Rate)
+
+	// Indicator alerts share one candle read per interval
+	series := s.loadIndicatorSeries(ctx, sourceCurrency, targetCurrency, pair.scan)
+
 	// Check each alert
 	for _, alert := range alerts {
-		if err := s.evaluateAlert(ctx, alert, currentRate.Rate); err != nil {
+		if err := s.evaluateAlert(ctx, alert, currentRate.Rate, series); err != nil {
 			s.logger.Error(fmt.Sprintf("Error evaluating alert %s: %v", alert.ID, err))
 			// Continue processing other alerts
 		}
+		if !alert.IsActive {
+			s.evaluator.index.remove(alert.ID)
+		}
+	}
+
+	now := time.Now()
+	pair.checkedAt = now
+	if now.Sub(pair.checkedSavedAt) >= checkedSaveInterval {
+		if err := s.store.MarkPairAlertsChecked(ctx, db.MarkPairAlertsCheckedParams{
+			SourceCurrency: sourceCurrency,
+			TargetCurrency: targetCurrency,
+		}); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to update last checked time for %s/%s: %v", sourceCurrency, targetCurrency, err))
+		} else {
+			pair.checkedSavedAt = now
+		}
 	}
 
 	return nil
 }
 
 // evaluateAlert checks if an alert condition is met and triggers notification
-func (s *PriceAlertService) evaluateAlert(ctx context.Context, alert *PriceAlert, currentRate decimal.Decimal) error {
+func (s *PriceAlertService) evaluateAlert(ctx context.Context, alert *PriceAlert, currentRate decimal.Decimal, series map[pricehistory.CandleInterval]*indicatorSeries) error {
 	// Check if alert has expired
 	if alert.ExpiresAt != nil && time.Now().After(*alert.ExpiresAt) {
 		s.logger.Info(fmt.Sprintf("Alert %s has expired, deactivating", alert.ID))
+		alert.IsActive = false
 		return s.DeactivateAlert(ctx, alert.ID, alert.UserID)
 	}
 
@@ -360,16 +566,17 @@ func (s *PriceAlertService) evaluateAlert(ctx context.Context, alert *PriceAlert
 	}
 
 	// Evaluate condition
-	triggered, message := s.evaluateCondition(alert, currentRate)
+	var triggered bool
+	var message string
+	if alert.AlertCondition.IsIndicator() && alert.IndicatorInterval != nil {
+		triggered, message = evaluateIndicator(alert, series[pricehistory.CandleInterval(*alert.IndicatorInterval)])
+	} else {
+		triggered, message = s.evaluateCondition(alert, currentRate)
+	}
 	if !triggered {
-		// Update last checked time
-		_ = s.store.UpdateAlertLastChecked(ctx, alert.ID)
 		return nil
 	}
 
-	// Alert is triggered!
-	s.logger.Info(fmt.Sprintf("Alert %s triggered: %s", alert.ID, message))
-
 	// Create trigger event
 	event := &AlertTriggerEvent{
 		Alert:       alert,
@@ -386,13 +593,28 @@ func (s *PriceAlertService) evaluateAlert(ctx context.Context, alert *PriceAlert
 		event.PreviousRate = alert.BaselineRate
 	}
 
-	// Send notifications
-	if err := s.sendAlertNotifications(ctx, event, message); err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to send notifications for alert %s: %v", alert.ID, err))
+	// Claim the trigger so no other evaluator fires it too
+	trigger, err := s.handleAlertTrigger(ctx, event, message)
+	if errors.Is(err, errTriggerClaimed) {
+		s.logger.Info(fmt.Sprintf("Alert %s was already triggered by another evaluator", alert.ID))
+		s.evaluator.recordDuplicate()
+		return nil
 	}
+	if err != nil {
+		return err
+	}
+
+	// Alert is triggered!
+	s.logger.Info(fmt.Sprintf("Alert %s triggered: %s", alert.ID, message))
+	s.evaluator.recordTrigger()
 
-	// Update alert state
-	return s.handleAlertTrigger(ctx, alert, currentRate)
+	// Send notifications
+	s.sendAlertNotifications(ctx, alert, trigger)
+
+	// Run the conversion the user attached to the alert
+	s.runConvertAction(ctx, alert, trigger, currentRate)
+
+	return nil
 }
 
 // evaluateCondition checks if alert condition is met
@@ -513,51 +735,6 @@ func (s *PriceAlertService) updateTrailingAlert(ctx context.Context, alert *Pric
 	}
 }
 
-// sendAlertNotifications sends push and in-app notifications
-func (s *PriceAlertService) sendAlertNotifications(ctx context.Context, event *AlertTriggerEvent, message string) error {
-	alert := event.Alert
-
-	// Prepare notification content
-	title := s.formatAlertTitle(alert)
-	body := s.formatAlertBody(alert, event, message)
-
-	// Send in-app notification
-	if alert.NotifyInApp {
-		source := fmt.Sprintf("price_alert:%s", alert.ID)
-		n, err := s.notificationService.Create(ctx, nil, title, body, source)
-		if err != nil {
-			s.logger.Error(fmt.Sprintf("Failed to create in-app notification: %v", err))
-		} else {
-			// Add recipient
-			_ = s.notificationService.AddRecipent(ctx, alert.UserID, n.ID)
-		}
-	}
-
-	// Send push notification
-	if alert.NotifyPush && s.pushService != nil {
-		if err := s.sendPushNotification(ctx, alert, title, body); err != nil {
-			s.logger.Error(fmt.Sprintf("Failed to send push notification: %v", err))
-		}
-	}
-
-	return nil
-}
-
-// sendPushNotification sends push notification for alert
-func (s *PriceAlertService) sendPushNotification(ctx context.Context, alert *PriceAlert, title, body string) error {
-	// Get user's push tokens (assuming similar pattern to existing code)
-	// This would need integration with user service to get tokens
-
-	// For now, log the intention
-	s.logger.Info(fmt.Sprintf("Would send push notification to user %d: %s", alert.UserID, title))
-
-	// TODO: Implement actual push notification sending
-	// tokens, err := s.pushService.getUserPushTokens(ctx, alert.UserID)
-	// ... send to FCM/Expo
-
-	return nil
-}
-
 // formatAlertTitle creates a notification title
 func (s *PriceAlertService) formatAlertTitle(alert *PriceAlert) string {
 	label := "Price Alert"
@@ -598,15 +775,16 @@ func (s *PriceAlertService) formatAlertBody(alert *PriceAlert, event *AlertTrigg
 	return body
 }
 
-// handleAlertTrigger updates alert state after triggering
-func (s *PriceAlertService) handleAlertTrigger(ctx context.Context, alert *PriceAlert, currentRate decimal.Decimal) error {
-	now := time.Now()
+// handleAlertTrigger claims the trigger and records it in one transaction, so an alert fires
+// once however many evaluators see its condition. The alert takes the state it was claimed
+// with; errTriggerClaimed means another evaluator claimed it first.
+func (s *PriceAlertService) handleAlertTrigger(ctx context.Context, event *AlertTriggerEvent, message string) (*db.AlertTriggerHistory, error) {
+	alert := event.Alert
 
-	params := db.UpdateAlertTriggerParams{
+	params := db.ClaimAlertTriggerParams{
 		ID:              alert.ID,
-		TriggeredCount:  int32(alert.TriggeredCount + 1),
-		LastTriggeredAt: sql.NullTime{Time: now, Valid: true},
-		LastCheckedAt:   sql.NullTime{Time: now, Valid: true},
+		TriggeredCount:  int32(alert.TriggeredCount),
+		LastTriggeredAt: sql.NullTime{Time: event.TriggeredAt, Valid: true},
 	}
 
 	// Handle different alert types
@@ -620,7 +798,7 @@ func (s *PriceAlertService) handleAlertTrigger(ctx context.Context, alert *Price
 		// Keep recurring alerts active but update baseline for percentage-based alerts
 		if alert.AlertCondition == ConditionPercentUp ||
 			alert.AlertCondition == ConditionPercentDown {
-			params.BaselineRate = s.decimalToNullString(&currentRate)
+			params.BaselineRate = s.decimalToNullString(&event.CurrentRate)
 		}
 
 	case AlertTypeTrailing:
@@ -628,7 +806,27 @@ func (s *PriceAlertService) handleAlertTrigger(ctx context.Context, alert *Price
 		// No special action needed here as tracking is handled elsewhere
 	}
 
-	return s.store.UpdateAlertTrigger(ctx, params)
+	var trigger db.AlertTriggerHistory
+	var claimed db.PriceAlert
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		claimed, err = q.ClaimAlertTrigger(ctx, params)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errTriggerClaimed
+			}
+			return err
+		}
+
+		trigger, err = q.CreateAlertTriggerHistory(ctx, s.triggerHistoryParams(event, message))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	*alert = *s.dbAlertToModel(&claimed)
+	return &trigger, nil
 }
 
 // GetUserAlerts retrieves all alerts for a user
@@ -648,7 +846,7 @@ func (s *PriceAlertService) GetUserAlerts(ctx context.Context, userID uuid.UUID,
 
 	alerts := make([]*PriceAlert, len(dbAlerts))
 	for i, dbAlert := range dbAlerts {
-		alerts[i] = s.dbAlertToModel(&dbAlert)
+		alerts[i] = s.withConvertAction(ctx, s.dbAlertToModel(&dbAlert))
 	}
 
 	return alerts, nil
@@ -665,13 +863,13 @@ func (s *PriceAlertService) GetAlert(ctx context.Context, alertID uuid.UUID, use
 		return nil, fmt.Errorf("unauthorized access to alert")
 	}
 
-	return s.dbAlertToModel(&dbAlert), nil
+	return s.withConvertAction(ctx, s.dbAlertToModel(&dbAlert)), nil
 }
 
 // UpdateAlert updates alert configuration
 func (s *PriceAlertService) UpdateAlert(ctx context.Context, alertID uuid.UUID, userID uuid.UUID, req *CreateAlertRequest) (*PriceAlert, error) {
 	// Verify ownership
-	_, err := s.GetAlert(ctx, alertID, userID)
+	existing, err := s.GetAlert(ctx, alertID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -681,14 +879,50 @@ func (s *PriceAlertService) UpdateAlert(ctx context.Context, alertID uuid.UUID,
 		return nil, err
 	}
 
+	// A conversion action is authorised once, at creation
+	if req.ConvertAction != nil {
+		return nil, fmt.Errorf("convert_action cannot be changed on an existing alert; create a new alert instead")
+	}
+
+	// Channels change only when the request names them or flips a notify switch
+	channels := existing.DeliveryChannels
+	if len(req.DeliveryChannels) > 0 || req.NotifyPush != nil || req.NotifyInApp != nil {
+		channels = resolveDeliveryChannels(req)
+	}
+	webhookURL := req.WebhookURL
+	if webhookURL == nil {
+		webhookURL = existing.WebhookURL
+	}
+	if err := validateDeliveryChannels(channels, webhookURL); err != nil {
+		return nil, err
+	}
+
+	// a webhook URL set for the first time gets a signing secret
+	var webhookSecret *string
+	if webhookURL != nil && *webhookURL != "" && existing.WebhookSecret == nil {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		webhookSecret = &secret
+	}
+
 	// Update logic similar to create
 	// This is a simplified version - full implementation would update specific fields
 	params := db.UpdatePriceAlertParams{
-		ID:               alertID,
-		TargetRate:       s.decimalToNullString(req.TargetRate),
-		PercentageChange: s.decimalToNullString(req.PercentageChange),
-		Description:      s.stringToNullString(req.Description),
-		Label:            s.stringToNullString(req.Label),
+		ID:                  alertID,
+		TargetRate:          s.decimalToNullString(req.TargetRate),
+		PercentageChange:    s.decimalToNullString(req.PercentageChange),
+		Description:         s.stringToNullString(req.Description),
+		Label:               s.stringToNullString(req.Label),
+		NotifyPush:          sql.NullBool{Bool: hasChannel(channels, ChannelPush), Valid: true},
+		NotifyInApp:         sql.NullBool{Bool: hasChannel(channels, ChannelInApp), Valid: true},
+		DeliveryChannels:    channelsToStrings(channels),
+		WebhookUrl:          s.stringToNullString(req.WebhookURL),
+		WebhookSecret:       s.stringToNullString(webhookSecret),
+		IndicatorPeriod:     s.intToNullInt32(req.IndicatorPeriod),
+		IndicatorFastPeriod: s.intToNullInt32(req.IndicatorFastPeriod),
+		IndicatorThreshold:  s.decimalToNullString(req.IndicatorThreshold),
 	}
 
 	updatedAlert, err := s.store.UpdatePriceAlert(ctx, params)
@@ -793,33 +1027,48 @@ func (s *PriceAlertService) GetAlertStats(ctx context.Context, userID uuid.UUID)
 // Helper conversion functions
 func (s *PriceAlertService) dbAlertToModel(dbAlert *db.PriceAlert) *PriceAlert {
 	return &PriceAlert{
-		ID:               dbAlert.ID,
-		UserID:           dbAlert.UserID,
-		SourceCurrency:   dbAlert.SourceCurrency,
-		TargetCurrency:   dbAlert.TargetCurrency,
-		AlertCondition:   AlertCondition(dbAlert.AlertCondition),
-		AlertType:        AlertType(dbAlert.AlertType),
-		Priority:         AlertPriority(dbAlert.Priority),
-		TargetRate:       s.nullStringToDecimal(dbAlert.TargetRate),
-		PercentageChange: s.nullStringToDecimal(dbAlert.PercentageChange),
-		RangeMin:         s.nullStringToDecimal(dbAlert.RangeMin),
-		RangeMax:         s.nullStringToDecimal(dbAlert.RangeMax),
-		BaselineRate:     s.nullStringToDecimal(dbAlert.BaselineRate),
-		TrailingDistance: s.nullStringToDecimal(dbAlert.TrailingDistance),
-		MaxTrailingRate:  s.nullStringToDecimal(dbAlert.MaxTrailingRate),
-		MinTrailingRate:  s.nullStringToDecimal(dbAlert.MinTrailingRate),
-		Description:      s.nullStringToString(dbAlert.Description),
-		Label:            s.nullStringToString(dbAlert.Label),
-		IsActive:         dbAlert.IsActive,
-		TriggeredCount:   int(dbAlert.TriggeredCount),
-		LastTriggeredAt:  s.nullTimeToTime(dbAlert.LastTriggeredAt),
-		LastCheckedAt:    s.nullTimeToTime(dbAlert.LastCheckedAt),
-		ExpiresAt:        s.nullTimeToTime(dbAlert.ExpiresAt),
-		NotifyPush:       dbAlert.NotifyPush,
-		NotifyInApp:      dbAlert.NotifyInApp,
-		CreatedAt:        dbAlert.CreatedAt,
-		UpdatedAt:        dbAlert.UpdatedAt,
+		ID:                  dbAlert.ID,
+		UserID:              dbAlert.UserID,
+		SourceCurrency:      dbAlert.SourceCurrency,
+		TargetCurrency:      dbAlert.TargetCurrency,
+		AlertCondition:      AlertCondition(dbAlert.AlertCondition),
+		AlertType:           AlertType(dbAlert.AlertType),
+		Priority:            AlertPriority(dbAlert.Priority),
+		TargetRate:          s.nullStringToDecimal(dbAlert.TargetRate),
+		PercentageChange:    s.nullStringToDecimal(dbAlert.PercentageChange),
+		RangeMin:            s.nullStringToDecimal(dbAlert.RangeMin),
+		RangeMax:            s.nullStringToDecimal(dbAlert.RangeMax),
+		BaselineRate:        s.nullStringToDecimal(dbAlert.BaselineRate),
+		TrailingDistance:    s.nullStringToDecimal(dbAlert.TrailingDistance),
+		MaxTrailingRate:     s.nullStringToDecimal(dbAlert.MaxTrailingRate),
+		MinTrailingRate:     s.nullStringToDecimal(dbAlert.MinTrailingRate),
+		Description:         s.nullStringToString(dbAlert.Description),
+		Label:               s.nullStringToString(dbAlert.Label),
+		IsActive:            dbAlert.IsActive,
+		TriggeredCount:      int(dbAlert.TriggeredCount),
+		LastTriggeredAt:     s.nullTimeToTime(dbAlert.LastTriggeredAt),
+		LastCheckedAt:       s.nullTimeToTime(dbAlert.LastCheckedAt),
+		ExpiresAt:           s.nullTimeToTime(dbAlert.ExpiresAt),
+		NotifyPush:          dbAlert.NotifyPush,
+		NotifyInApp:         dbAlert.NotifyInApp,
+		DeliveryChannels:    s.stringsToChannels(dbAlert.DeliveryChannels),
+		WebhookURL:          s.nullStringToString(dbAlert.WebhookUrl),
+		WebhookSecret:       s.nullStringToString(dbAlert.WebhookSecret),
+		IndicatorInterval:   s.nullStringToString(dbAlert.IndicatorInterval),
+		IndicatorPeriod:     s.nullInt32ToInt(dbAlert.IndicatorPeriod),
+		IndicatorFastPeriod: s.nullInt32ToInt(dbAlert.IndicatorFastPeriod),
+		IndicatorThreshold:  s.nullStringToDecimal(dbAlert.IndicatorThreshold),
+		CreatedAt:           dbAlert.CreatedAt,
+		UpdatedAt:           dbAlert.UpdatedAt,
+	}
+}
+
+func (s *PriceAlertService) stringsToChannels(channels []string) []DeliveryChannel {
+	out := make([]DeliveryChannel, len(channels))
+	for i, c := range channels {
+		out[i] = DeliveryChannel(c)
 	}
+	return out
 }
 
 func (s *PriceAlertService) decimalToNullString(d *decimal.Decimal) sql.NullString {
@@ -836,6 +1085,13 @@ func (s *PriceAlertService) stringToNullString(str *string) sql.NullString {
 	return sql.NullString{String: *str, Valid: true}
 }
 
+func (s *PriceAlertService) intToNullInt32(i *int) sql.NullInt32 {
+	if i == nil {
+		return sql.NullInt32{Valid: false}
+	}
+	return sql.NullInt32{Int32: int32(*i), Valid: true}
+}
+
 func (s *PriceAlertService) timeToNullTime(t *time.Time) sql.NullTime {
 	if t == nil {
 		return sql.NullTime{Valid: false}
@@ -851,6 +1107,14 @@ func (s *PriceAlertService) nullStringToDecimal(ns sql.NullString) *decimal.Deci
 	return &d
 }
 
+func (s *PriceAlertService) nullInt32ToInt(ni sql.NullInt32) *int {
+	if !ni.Valid {
+		return nil
+	}
+	i := int(ni.Int32)
+	return &i
+}
+
 func (s *PriceAlertService) nullStringToString(ns sql.NullString) *string {
 	if !ns.Valid {
 		return nil
@@ -0,0 +1,238 @@
+package pricealert
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+)
+
+// AlertEvaluationConfig sets how replicas share alert evaluation. Values are read from the
+// environment and fall back to defaults.
+type AlertEvaluationConfig struct {
+	// currency pairs hash into this many shards; every replica must use the same value
+	ShardCount int `mapstructure:"PRICE_ALERT_SHARD_COUNT"`
+	// shards of a replica that stops renewing are taken over after this long
+	LeaseTTLSeconds int `mapstructure:"PRICE_ALERT_LEASE_TTL_SECONDS"`
+	// full reload of the threshold index; changed alerts are picked up every cycle in between
+	IndexRebuildSeconds int `mapstructure:"PRICE_ALERT_INDEX_REBUILD_SECONDS"`
+	// names the replica in leases and metrics; defaults to the hostname with a random suffix
+	NodeID string `mapstructure:"PRICE_ALERT_NODE_ID"`
+}
+
+func LoadAlertEvaluationConfig() AlertEvaluationConfig {
+	var c AlertEvaluationConfig
+	if err := utils.LoadCustomConfig(utils.EnvPath, &c); err != nil {
+		c = AlertEvaluationConfig{}
+	}
+	if c.ShardCount <= 0 {
+		c.ShardCount = 64
+	}
+	if c.LeaseTTLSeconds <= 0 {
+		c.LeaseTTLSeconds = 90
+	}
+	if c.IndexRebuildSeconds <= 0 {
+		c.IndexRebuildSeconds = 600
+	}
+	if c.NodeID == "" {
+		host, err := os.Hostname()
+		if err != nil || host == "" {
+			host = "evaluator"
+		}
+		c.NodeID = fmt.Sprintf("%s-%s", host, uuid.NewString()[:8])
+	}
+	return c
+}
+
+// shardOf maps a currency pair to its shard
+func shardOf(sourceCurrency, targetCurrency string, shards int) int32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(sourceCurrency + "/" + targetCurrency))
+	return int32(h.Sum32() % uint32(shards))
+}
+
+// shardCoordinator keeps this replica's shard leases. Each cycle it renews what it holds and
+// moves toward an even share: shards above the share are released for other replicas, and
+// free or expired shards are taken while below it.
+type shardCoordinator struct {
+	store  *db.Store
+	logger *logging.Logger
+	nodeID string
+	shards int
+	ttl    int32 // seconds
+
+	mu    sync.RWMutex
+	owned map[int32]bool
+}
+
+func newShardCoordinator(store *db.Store, logger *logging.Logger, config AlertEvaluationConfig) *shardCoordinator {
+	return &shardCoordinator{
+		store:  store,
+		logger: logger,
+		nodeID: config.NodeID,
+		shards: config.ShardCount,
+		ttl:    int32(config.LeaseTTLSeconds),
+		owned:  make(map[int32]bool),
+	}
+}
+
+// owns reports whether the replica currently holds the pair's shard
+func (c *shardCoordinator) owns(sourceCurrency, targetCurrency string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.owned[shardOf(sourceCurrency, targetCurrency, c.shards)]
+}
+
+// ownedShards returns the held shards in order
+func (c *shardCoordinator) ownedShards() []int32 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	shards := make([]int32, 0, len(c.owned))
+	for shard := range c.owned {
+		shards = append(shards, shard)
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+	return shards
+}
+
+// rebalance heartbeats the replica and adjusts its leases. It reports whether the set of held
+// shards changed, in which case the caller reloads its index.
+func (c *shardCoordinator) rebalance(ctx context.Context, heartbeat db.UpsertAlertEvaluatorParams) (bool, error) {
+	heartbeat.NodeID = c.nodeID
+	if err := c.store.UpsertAlertEvaluator(ctx, heartbeat); err != nil {
+		return false, fmt.Errorf("failed to record evaluator heartbeat: %w", err)
+	}
+
+	nodes, err := c.store.ListLiveAlertEvaluators(ctx, c.ttl)
+	if err != nil {
+		return false, fmt.Errorf("failed to list live evaluators: %w", err)
+	}
+	share := (c.shards + len(nodes) - 1) / max(len(nodes), 1)
+
+	renewed, err := c.store.RenewAlertShardLeases(ctx, db.RenewAlertShardLeasesParams{
+		NodeID:     c.nodeID,
+		TtlSeconds: c.ttl,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to renew shard leases: %w", err)
+	}
+
+	held := make(map[int32]bool, len(renewed))
+	for _, lease := range renewed {
+		held[lease.Shard] = true
+	}
+
+	// Hand back the highest shards above the share
+	if len(held) > share {
+		shards := make([]int32, 0, len(held))
+		for shard := range held {
+			shards = append(shards, shard)
+		}
+		sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+
+		for _, shard := range shards[share:] {
+			if err := c.store.ReleaseAlertShardLease(ctx, db.ReleaseAlertShardLeaseParams{
+				Shard:  shard,
+				NodeID: c.nodeID,
+			}); err != nil {
+				c.logger.Error(fmt.Sprintf("Failed to release alert shard %d: %v", shard, err))
+				continue
+			}
+			delete(held, shard)
+		}
+	}
+
+	if len(held) < share {
+		if err := c.acquire(ctx, held, share); err != nil {
+			c.logger.Error(fmt.Sprintf("Failed to acquire alert shards: %v", err))
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	changed := len(held) != len(c.owned)
+	for shard := range held {
+		if !c.owned[shard] {
+			changed = true
+		}
+	}
+	c.owned = held
+	return changed, nil
+}
+
+// acquire takes free and expired shards into held until it reaches the share. Replicas start
+// their search at different shards so they rarely race for the same one.
+func (c *shardCoordinator) acquire(ctx context.Context, held map[int32]bool, share int) error {
+	// replicas that stopped without releasing are long past their leases by now
+	if err := c.store.DeleteStaleAlertEvaluators(ctx, 10*c.ttl); err != nil {
+		c.logger.Error(fmt.Sprintf("Failed to remove stale evaluators: %v", err))
+	}
+
+	leases, err := c.store.ListAlertShardLeases(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	taken := make(map[int32]bool, len(leases))
+	for _, lease := range leases {
+		if lease.NodeID != c.nodeID && lease.ExpiresAt.After(now) {
+			taken[lease.Shard] = true
+		}
+	}
+
+	start := int(shardOf(c.nodeID, "", c.shards))
+	for i := 0; i < c.shards && len(held) < share; i++ {
+		shard := int32((start + i) % c.shards)
+		if held[shard] || taken[shard] {
+			continue
+		}
+
+		_, err := c.store.AcquireAlertShardLease(ctx, db.AcquireAlertShardLeaseParams{
+			Shard:      shard,
+			NodeID:     c.nodeID,
+			TtlSeconds: c.ttl,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			// another replica got there first
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		held[shard] = true
+	}
+	return nil
+}
+
+// release gives up every lease so other replicas take the shards over without waiting for
+// them to expire
+func (c *shardCoordinator) release(ctx context.Context) {
+	for _, shard := range c.ownedShards() {
+		if err := c.store.ReleaseAlertShardLease(ctx, db.ReleaseAlertShardLeaseParams{
+			Shard:  shard,
+			NodeID: c.nodeID,
+		}); err != nil {
+			c.logger.Error(fmt.Sprintf("Failed to release alert shard %d: %v", shard, err))
+		}
+	}
+	if err := c.store.DeleteAlertEvaluator(ctx, c.nodeID); err != nil {
+		c.logger.Error(fmt.Sprintf("Failed to remove evaluator %s: %v", c.nodeID, err))
+	}
+
+	c.mu.Lock()
+	c.owned = make(map[int32]bool)
+	c.mu.Unlock()
+}
@@ -0,0 +1,111 @@
+package pricehistory
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CandleInterval is the width of an OHLC bucket
+type CandleInterval string
+
+const (
+	Interval1m CandleInterval = "1m"
+	Interval1h CandleInterval = "1h"
+	Interval1d CandleInterval = "1d"
+)
+
+// Duration returns the bucket width of the interval
+func (i CandleInterval) Duration() time.Duration {
+	switch i {
+	case Interval1m:
+		return time.Minute
+	case Interval1h:
+		return time.Hour
+	case Interval1d:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+func IsCandleIntervalValid(i string) bool {
+	return CandleInterval(i).Duration() > 0
+}
+
+var (
+	ErrUnsupportedPair     = errors.New("currency pair is not tracked by the price history collector")
+	ErrInvalidInterval     = errors.New("interval must be one of 1m, 1h, 1d")
+	ErrInvalidTimeRange    = errors.New("start time must be before end time")
+	ErrNoHistoricalRate    = errors.New("no rate recorded at or before the requested time")
+	ErrUnsupportedTimespan = errors.New("unsupported time period")
+)
+
+// TrackedPair is a currency pair sampled by the collector
+type TrackedPair struct {
+	Base  string `json:"base"`
+	Quote string `json:"quote"`
+}
+
+// DefaultTrackedPairs are the pairs sampled on every collector tick
+var DefaultTrackedPairs = []TrackedPair{
+	{Base: "USDT", Quote: "USD"},
+	{Base: "USDC", Quote: "USD"},
+	{Base: "USD", Quote: "NGN"},
+	{Base: "USDT", Quote: "NGN"},
+	{Base: "USDC", Quote: "NGN"},
+	{Base: "BTC", Quote: "USD"},
+	{Base: "ETH", Quote: "USD"},
+	{Base: "SOL", Quote: "USD"},
+	{Base: "BNB", Quote: "USD"},
+	{Base: "TRX", Quote: "USD"},
+	{Base: "TON", Quote: "USD"},
+	{Base: "LTC", Quote: "USD"},
+	{Base: "DOGE", Quote: "USD"},
+}
+
+// Candle is an OHLC bucket for a currency pair
+type Candle struct {
+	Time        time.Time       `json:"time"`
+	Open        decimal.Decimal `json:"open"`
+	High        decimal.Decimal `json:"high"`
+	Low         decimal.Decimal `json:"low"`
+	Close       decimal.Decimal `json:"close"`
+	SampleCount int32           `json:"sample_count"`
+}
+
+// ChartResponse is the payload served to charting clients
+type ChartResponse struct {
+	Base     string         `json:"base"`
+	Quote    string         `json:"quote"`
+	Interval CandleInterval `json:"interval"`
+	Start    time.Time      `json:"start"`
+	End      time.Time      `json:"end"`
+	Change   string         `json:"change"`
+	Candles  []Candle       `json:"candles"`
+}
+
+// HistoricalRate is the rate in effect for a pair at a past moment
+type HistoricalRate struct {
+	Base       string          `json:"base"`
+	Quote      string          `json:"quote"`
+	At         time.Time       `json:"at"`
+	Rate       decimal.Decimal `json:"rate"`
+	Source     string          `json:"source"`
+	SampledAt  time.Time       `json:"sampled_at"`
+	Resolution string          `json:"resolution"` // "sample" or the candle interval it was resolved from
+}
+
+// PricePoint mirrors a CoinRanking history entry so cached history is a drop-in replacement
+type PricePoint struct {
+	Price     string `json:"price"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// CoinHistory mirrors cryptocurrency.CoinHistoryData, the CoinRanking history payload served by
+// GET /coin-price-data, so the response has the same shape whichever source serves it
+type CoinHistory struct {
+	Change  string       `json:"change"`
+	History []PricePoint `json:"history"`
+}
@@ -0,0 +1,115 @@
+package pricehistory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/tasks"
+)
+
+const (
+	sampleRetention       = 30 * 24 * time.Hour
+	minuteCandleRetention = 30 * 24 * time.Hour
+)
+
+// PriceHistoryScheduler runs the rate collector and candle roll-ups in the background
+type PriceHistoryScheduler struct {
+	taskScheduler   *tasks.TaskScheduler
+	service         *PriceHistoryService
+	logger          *logging.Logger
+	collectInterval time.Duration
+}
+
+func NewPriceHistoryScheduler(
+	taskScheduler *tasks.TaskScheduler,
+	service *PriceHistoryService,
+	logger *logging.Logger,
+	collectInterval time.Duration,
+) *PriceHistoryScheduler {
+	if collectInterval == 0 {
+		collectInterval = 1 * time.Minute // Default: one sample per pair per minute
+	}
+
+	return &PriceHistoryScheduler{
+		taskScheduler:   taskScheduler,
+		service:         service,
+		logger:          logger,
+		collectInterval: collectInterval,
+	}
+}
+
+// Start registers and schedules the collector, roll-up and retention tasks
+func (s *PriceHistoryScheduler) Start() error {
+	s.logger.Info("Starting price history scheduler...")
+
+	taskDefs := []struct {
+		id       string
+		name     string
+		fn       func(context.Context) error
+		interval time.Duration
+		delay    time.Duration
+	}{
+		{"price-history-collector", "Collect Rate Samples", s.collect, s.collectInterval, 5 * time.Second},
+		{"price-history-rollup-1m", "Roll Up 1m Candles", s.rollup(Interval1m), 1 * time.Minute, 20 * time.Second},
+		{"price-history-rollup-1h", "Roll Up 1h Candles", s.rollup(Interval1h), 5 * time.Minute, 30 * time.Second},
+		{"price-history-rollup-1d", "Roll Up 1d Candles", s.rollup(Interval1d), 1 * time.Hour, 40 * time.Second},
+		{"price-history-retention", "Prune Price History", s.prune, 24 * time.Hour, 10 * time.Minute},
+	}
+
+	for _, t := range taskDefs {
+		if _, err := s.taskScheduler.AddTask(t.id, t.name, t.fn, t.interval); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to register %s task: %v", t.id, err))
+			return err
+		}
+	}
+
+	for _, t := range taskDefs {
+		s.taskScheduler.ScheduleTask(t.id, t.delay)
+	}
+
+	s.logger.Info(fmt.Sprintf("Price history scheduler started. Collect interval: %s", s.collectInterval))
+	return nil
+}
+
+// Stop halts the scheduler
+func (s *PriceHistoryScheduler) Stop() error {
+	s.logger.Info("Stopping price history scheduler...")
+
+	s.taskScheduler.StopTask("price-history-collector")
+	s.taskScheduler.StopTask("price-history-rollup-1m")
+	s.taskScheduler.StopTask("price-history-rollup-1h")
+	s.taskScheduler.StopTask("price-history-rollup-1d")
+	s.taskScheduler.StopTask("price-history-retention")
+
+	s.logger.Info("Price history scheduler stopped")
+	return nil
+}
+
+// Task errors are logged rather than returned: a failed provider call must not stall the recurring task.
+func (s *PriceHistoryScheduler) collect(ctx context.Context) error {
+	if err := s.service.CollectSamples(ctx); err != nil {
+		s.logger.Error(fmt.Sprintf("Price history collection failed: %v", err))
+	}
+	return nil
+}
+
+func (s *PriceHistoryScheduler) rollup(interval CandleInterval) func(context.Context) error {
+	return func(ctx context.Context) error {
+		rows, err := s.service.RollupCandles(ctx, interval)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Price history %s roll-up failed: %v", interval, err))
+			return nil
+		}
+		s.logger.Debug(fmt.Sprintf("Price history %s roll-up updated %d candles", interval, rows))
+		return nil
+	}
+}
+
+func (s *PriceHistoryScheduler) prune(ctx context.Context) error {
+	if err := s.service.PruneHistory(ctx, sampleRetention, minuteCandleRetention); err != nil {
+		s.logger.Error(fmt.Sprintf("Price history pruning failed: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,434 @@
+package pricehistory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// fiat feeds update slowly and have tight free-tier quotas, so their legs are cached between ticks
+	defaultFiatRefresh = 15 * time.Minute
+	maxChartCandles    = 1000
+)
+
+// PriceHistoryService samples exchange rates into a local time-series store and serves
+// chart data and point-in-time rate lookups from it.
+type PriceHistoryService struct {
+	store               *db.Store
+	exchangeRateService *exchangerate.ExchangeRateService
+	logger              *logging.Logger
+	pairs               []TrackedPair
+	fiatRefresh         time.Duration
+
+	mu        sync.Mutex
+	fiatCache map[string]*exchangerate.ExchangeRate
+}
+
+func NewPriceHistoryService(
+	store *db.Store,
+	exchangeRateService *exchangerate.ExchangeRateService,
+	logger *logging.Logger,
+	pairs []TrackedPair,
+) *PriceHistoryService {
+	if len(pairs) == 0 {
+		pairs = DefaultTrackedPairs
+	}
+
+	return &PriceHistoryService{
+		store:               store,
+		exchangeRateService: exchangeRateService,
+		logger:              logger,
+		pairs:               pairs,
+		fiatRefresh:         defaultFiatRefresh,
+		fiatCache:           make(map[string]*exchangerate.ExchangeRate),
+	}
+}
+
+// TrackedPairs returns the pairs sampled by the collector
+func (s *PriceHistoryService) TrackedPairs() []TrackedPair {
+	return s.pairs
+}
+
+// IsTracked reports whether base/quote is sampled by the collector
+func (s *PriceHistoryService) IsTracked(base, quote string) bool {
+	for _, p := range s.pairs {
+		if p.Base == base && p.Quote == quote {
+			return true
+		}
+	}
+	return false
+}
+
+// CollectSamples records one sample per tracked pair. Each upstream leg (crypto/USD, USD/fiat)
+// is fetched once per tick and cross rates are derived from them, so adding a pair does not add
+// a provider call.
+func (s *PriceHistoryService) CollectSamples(ctx context.Context) error {
+	sampledAt := time.Now().UTC()
+
+	usdLegs := make(map[string]*exchangerate.ExchangeRate)
+	for _, p := range s.pairs {
+		if p.Base == "USD" {
+			continue
+		}
+		if _, done := usdLegs[p.Base]; done {
+			continue
+		}
+
+		rate, err := s.exchangeRateService.GetExchangeRate(ctx, p.Base, "USD")
+		if err != nil {
+			s.logger.Warn(fmt.Sprintf("price history: failed to fetch %s/USD: %v", p.Base, err))
+			usdLegs[p.Base] = nil
+			continue
+		}
+		usdLegs[p.Base] = rate
+	}
+
+	recorded := 0
+	for _, p := range s.pairs {
+		rate, source, err := s.resolvePairRate(ctx, p, usdLegs)
+		if err != nil {
+			s.logger.Warn(fmt.Sprintf("price history: skipping %s/%s: %v", p.Base, p.Quote, err))
+			continue
+		}
+
+		if _, err := s.store.InsertRateSample(ctx, db.InsertRateSampleParams{
+			BaseCurrency:  p.Base,
+			QuoteCurrency: p.Quote,
+			Rate:          rate.String(),
+			Source:        source,
+			SampledAt:     sampledAt,
+		}); err != nil {
+			s.logger.Error(fmt.Sprintf("price history: failed to store %s/%s sample: %v", p.Base, p.Quote, err))
+			continue
+		}
+		recorded++
+	}
+
+	if recorded == 0 && len(s.pairs) > 0 {
+		return fmt.Errorf("price history: no rates could be sampled")
+	}
+
+	s.logger.Debug(fmt.Sprintf("price history: recorded %d/%d samples", recorded, len(s.pairs)))
+	return nil
+}
+
+func (s *PriceHistoryService) resolvePairRate(ctx context.Context, p TrackedPair, usdLegs map[string]*exchangerate.ExchangeRate) (decimal.Decimal, string, error) {
+	if p.Quote == "USD" {
+		leg := usdLegs[p.Base]
+		if leg == nil {
+			return decimal.Zero, "", fmt.Errorf("%s/USD leg unavailable", p.Base)
+		}
+		return leg.Rate, leg.Provider, nil
+	}
+
+	fiatLeg, err := s.getFiatLeg(ctx, p.Quote)
+	if err != nil {
+		return decimal.Zero, "", err
+	}
+
+	if p.Base == "USD" {
+		return fiatLeg.Rate, fiatLeg.Provider, nil
+	}
+
+	leg := usdLegs[p.Base]
+	if leg == nil {
+		return decimal.Zero, "", fmt.Errorf("%s/USD leg unavailable", p.Base)
+	}
+
+	return leg.Rate.Mul(fiatLeg.Rate), fmt.Sprintf("%s,%s", leg.Provider, fiatLeg.Provider), nil
+}
+
+// getFiatLeg returns USD/quote, refreshing it at most once per fiatRefresh
+func (s *PriceHistoryService) getFiatLeg(ctx context.Context, quote string) (*exchangerate.ExchangeRate, error) {
+	s.mu.Lock()
+	cached, ok := s.fiatCache[quote]
+	s.mu.Unlock()
+
+	if ok && time.Since(cached.Time) < s.fiatRefresh {
+		return cached, nil
+	}
+
+	rate, err := s.exchangeRateService.GetExchangeRate(ctx, "USD", quote)
+	if err != nil {
+		if ok {
+			// serve the last known leg rather than leave a gap in the series
+			return cached, nil
+		}
+		return nil, fmt.Errorf("USD/%s leg unavailable: %w", quote, err)
+	}
+
+	s.mu.Lock()
+	s.fiatCache[quote] = rate
+	s.mu.Unlock()
+
+	return rate, nil
+}
+
+// RollupCandles rebuilds the candles of the given interval covering the current and previous bucket
+func (s *PriceHistoryService) RollupCandles(ctx context.Context, interval CandleInterval) (int64, error) {
+	width := interval.Duration()
+	if width == 0 {
+		return 0, ErrInvalidInterval
+	}
+
+	since := time.Now().UTC().Add(-width).Truncate(width)
+
+	rows, err := s.store.RollupRateCandles(ctx, db.RollupRateCandlesParams{
+		Interval:      string(interval),
+		BucketSeconds: int64(width / time.Second),
+		Since:         since,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll up %s candles: %w", interval, err)
+	}
+
+	return rows, nil
+}
+
+// PruneHistory removes raw samples and fine-grained candles past their retention
+func (s *PriceHistoryService) PruneHistory(ctx context.Context, sampleRetention, minuteCandleRetention time.Duration) error {
+	now := time.Now().UTC()
+
+	samples, err := s.store.DeleteRateSamplesBefore(ctx, now.Add(-sampleRetention))
+	if err != nil {
+		return fmt.Errorf("failed to prune rate samples: %w", err)
+	}
+
+	candles, err := s.store.DeleteRateCandlesBefore(ctx, db.DeleteRateCandlesBeforeParams{
+		Interval:    string(Interval1m),
+		BucketStart: now.Add(-minuteCandleRetention),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to prune 1m candles: %w", err)
+	}
+
+	s.logger.Info(fmt.Sprintf("price history: pruned %d samples and %d 1m candles", samples, candles))
+	return nil
+}
+
+// GetChart returns OHLC candles for base/quote between start and end in chronological order
+func (s *PriceHistoryService) GetChart(ctx context.Context, base, quote string, interval CandleInterval, start, end time.Time) (*ChartResponse, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+
+	if interval.Duration() == 0 {
+		return nil, ErrInvalidInterval
+	}
+	if !start.Before(end) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	rows, err := s.store.ListRateCandles(ctx, db.ListRateCandlesParams{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Interval:      string(interval),
+		StartTime:     start,
+		EndTime:       end,
+		MaxCandles:    maxChartCandles,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list candles: %w", err)
+	}
+
+	candles := make([]Candle, 0, len(rows))
+	for i := len(rows) - 1; i >= 0; i-- {
+		candles = append(candles, toCandle(rows[i]))
+	}
+
+	return &ChartResponse{
+		Base:     base,
+		Quote:    quote,
+		Interval: interval,
+		Start:    start,
+		End:      end,
+		Change:   percentChange(candles),
+		Candles:  candles,
+	}, nil
+}
+
+// ClosedCandles returns up to count of the most recent completed candles for base/quote in
+// chronological order. The bucket still being rolled up is left out so indicator values
+// computed from the result only change when a bucket closes.
+func (s *PriceHistoryService) ClosedCandles(ctx context.Context, base, quote string, interval CandleInterval, count int) ([]Candle, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+
+	width := interval.Duration()
+	if width == 0 {
+		return nil, ErrInvalidInterval
+	}
+	if !s.IsTracked(base, quote) {
+		return nil, ErrUnsupportedPair
+	}
+
+	current := time.Now().UTC().Truncate(width)
+
+	rows, err := s.store.ListRateCandles(ctx, db.ListRateCandlesParams{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Interval:      string(interval),
+		StartTime:     current.Add(-time.Duration(count) * width),
+		EndTime:       current.Add(-width),
+		MaxCandles:    int32(count),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list candles: %w", err)
+	}
+
+	candles := make([]Candle, 0, len(rows))
+	for i := len(rows) - 1; i >= 0; i-- {
+		candles = append(candles, toCandle(rows[i]))
+	}
+
+	return candles, nil
+}
+
+// GetRateAt returns the rate that was in effect for base/quote at the given moment.
+// Raw samples are used while retained; older moments resolve to the hourly, then daily, candle.
+func (s *PriceHistoryService) GetRateAt(ctx context.Context, base, quote string, at time.Time) (*HistoricalRate, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+
+	sample, err := s.store.GetRateSampleAt(ctx, db.GetRateSampleAtParams{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		At:            at,
+	})
+	if err == nil && at.Sub(sample.SampledAt) <= Interval1h.Duration() {
+		rate, _ := decimal.NewFromString(sample.Rate)
+		return &HistoricalRate{
+			Base:       base,
+			Quote:      quote,
+			At:         at,
+			Rate:       rate,
+			Source:     sample.Source,
+			SampledAt:  sample.SampledAt,
+			Resolution: "sample",
+		}, nil
+	} else if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to look up rate sample: %w", err)
+	}
+
+	for _, interval := range []CandleInterval{Interval1h, Interval1d} {
+		candle, err := s.store.GetRateCandleAt(ctx, db.GetRateCandleAtParams{
+			BaseCurrency:  base,
+			QuoteCurrency: quote,
+			Interval:      string(interval),
+			At:            at,
+		})
+		if err == sql.ErrNoRows {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to look up %s candle: %w", interval, err)
+		}
+
+		if at.Sub(candle.BucketStart) > interval.Duration() {
+			continue
+		}
+
+		rate, _ := decimal.NewFromString(candle.Open)
+		return &HistoricalRate{
+			Base:       base,
+			Quote:      quote,
+			At:         at,
+			Rate:       rate,
+			Source:     "candle",
+			SampledAt:  candle.BucketStart,
+			Resolution: string(interval),
+		}, nil
+	}
+
+	return nil, ErrNoHistoricalRate
+}
+
+// coinHistoryPeriods maps CoinRanking time periods to a lookback window and candle interval
+var coinHistoryPeriods = map[string]struct {
+	window   time.Duration
+	interval CandleInterval
+}{
+	"1h":  {time.Hour, Interval1m},
+	"3h":  {3 * time.Hour, Interval1m},
+	"12h": {12 * time.Hour, Interval1m},
+	"24h": {24 * time.Hour, Interval1h},
+	"7d":  {7 * 24 * time.Hour, Interval1h},
+	"30d": {30 * 24 * time.Hour, Interval1h},
+	"3m":  {90 * 24 * time.Hour, Interval1d},
+	"1y":  {365 * 24 * time.Hour, Interval1d},
+	"3y":  {3 * 365 * 24 * time.Hour, Interval1d},
+	"5y":  {5 * 365 * 24 * time.Hour, Interval1d},
+}
+
+// GetCoinHistory serves a CoinRanking-shaped USD price history for coin from local candles.
+// It returns an error when the coin is not tracked or the store does not yet cover the period,
+// in which case callers should fall back to the upstream provider.
+func (s *PriceHistoryService) GetCoinHistory(ctx context.Context, coin, timePeriod string) (*CoinHistory, error) {
+	coin = strings.ToUpper(coin)
+	if !s.IsTracked(coin, "USD") {
+		return nil, ErrUnsupportedPair
+	}
+
+	period, ok := coinHistoryPeriods[timePeriod]
+	if !ok {
+		return nil, ErrUnsupportedTimespan
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-period.window)
+
+	chart, err := s.GetChart(ctx, coin, "USD", period.interval, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// require the stored series to cover at least 90% of the window
+	if len(chart.Candles) == 0 || chart.Candles[0].Time.After(start.Add(period.window/10)) {
+		return nil, ErrNoHistoricalRate
+	}
+
+	history := make([]PricePoint, 0, len(chart.Candles))
+	for i := len(chart.Candles) - 1; i >= 0; i-- {
+		c := chart.Candles[i]
+		history = append(history, PricePoint{
+			Price:     c.Close.String(),
+			Timestamp: c.Time.Unix(),
+		})
+	}
+
+	return &CoinHistory{
+		Change:  chart.Change,
+		History: history,
+	}, nil
+}
+
+func toCandle(c db.RateCandle) Candle {
+	open, _ := decimal.NewFromString(c.Open)
+	high, _ := decimal.NewFromString(c.High)
+	low, _ := decimal.NewFromString(c.Low)
+	closing, _ := decimal.NewFromString(c.Close)
+
+	return Candle{
+		Time:        c.BucketStart,
+		Open:        open,
+		High:        high,
+		Low:         low,
+		Close:       closing,
+		SampleCount: c.SampleCount,
+	}
+}
+
+// percentChange is the change from the first candle's open to the last candle's close
+func percentChange(candles []Candle) string {
+	if len(candles) == 0 || candles[0].Open.IsZero() {
+		return "0"
+	}
+
+	first := candles[0].Open
+	last := candles[len(candles)-1].Close
+	return last.Sub(first).Div(first).Mul(decimal.NewFromInt(100)).StringFixed(2)
+}
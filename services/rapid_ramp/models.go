@@ -51,6 +51,13 @@ type CreateQRCodeRequest struct {
 	Amount             string           `json:"amount" binding:"required"`
 	UsageLimit         *int             `json:"usage_limit"`
 	ExpiresAt          *time.Time       `json:"expires_at"`
+	// QuoteID locks the payout for the first payment, see POST /qr-codes/quotes
+	QuoteID            *string          `json:"quote_id"`
+}
+
+type RampQuoteRequest struct {
+	CryptoCurrency string `json:"crypto_currency" binding:"required"`
+	Amount         string `json:"amount" binding:"required"`
 }
 
 type QRCodeResponse struct {
@@ -71,6 +78,7 @@ type QRCodeResponse struct {
 	Label              *string          `json:"label,omitempty"`
 	BankAccount        *BankAccountInfo `json:"bank_account,omitempty"`
 	ExpiresAt          *time.Time       `json:"expires_at,omitempty"`
+	QuoteID            *string          `json:"quote_id,omitempty"`
 	LastUsedAt         *time.Time       `json:"last_used_at,omitempty"`
 	CreatedAt          time.Time        `json:"created_at"`
 }
@@ -11,8 +11,10 @@ import (
 	"github.com/SwiftFiat/SwiftFiat-Backend/providers"
 	"github.com/SwiftFiat/SwiftFiat-Backend/providers/cryptocurrency"
 	"github.com/SwiftFiat/SwiftFiat-Backend/providers/fiat"
+	fxrevenue "github.com/SwiftFiat/SwiftFiat-Backend/services/fx_revenue"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
 	ratemanager "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_manager"
+	ratequote "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_quote"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/transaction"
 	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
 	"github.com/google/uuid"
@@ -27,6 +29,8 @@ type QRCodeService struct {
 	providerService    *providers.ProviderService
 	config             *utils.Config
 	rateManagerService *ratemanager.Service
+	quoteService       *ratequote.Service
+	revenueService     *fxrevenue.Service
 }
 
 func NewQRCodeService(
@@ -36,6 +40,8 @@ func NewQRCodeService(
 	providerService *providers.ProviderService,
 	config *utils.Config,
 	rateManagerService *ratemanager.Service,
+	quoteService *ratequote.Service,
+	revenueService *fxrevenue.Service,
 ) *QRCodeService {
 	return &QRCodeService{
 		store:              store,
@@ -44,6 +50,8 @@ func NewQRCodeService(
 		providerService:    providerService,
 		config:             config,
 		rateManagerService: rateManagerService,
+		quoteService:       quoteService,
+		revenueService:     revenueService,
 	}
 }
 
@@ -67,6 +75,20 @@ const (
 func (s *QRCodeService) CreateQRCode(ctx context.Context, userID uuid.UUID, req *CreateQRCodeRequest) (*QRCodeResponse, error) {
 	s.logger.Info(fmt.Sprintf("Creating QR code for user %d", userID))
 
+	// a ramp quote locks the payout for the first payment made to this QR code
+	var quote *ratequote.Terms
+	if req.QuoteID != nil && *req.QuoteID != "" {
+		terms, err := s.quoteService.Resolve(ctx, *req.QuoteID, userID, ratequote.PurposeRamp)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := decimal.NewFromString(req.Amount)
+		if err != nil || terms.SourceCurrency != req.CryptoCurrency || !terms.SourceAmount.Equal(amount) {
+			return nil, ratequote.ErrQuoteMismatch
+		}
+		quote = terms
+	}
+
 	// Get or create Cryptomus static wallet address
 	cryptomusAddress, err := s.getOrCreateCryptomusAddress(ctx, userID, req.Network, req.CryptoCurrency)
 	if err != nil {
@@ -115,6 +137,14 @@ func (s *QRCodeService) CreateQRCode(ctx context.Context, userID uuid.UUID, req
 		return nil, fmt.Errorf("failed to create QR code: %w", err)
 	}
 
+	var quoteID *string
+	if quote != nil {
+		if err := s.quoteService.BindToQRCode(ctx, quote.ID, qrCode.ID); err != nil {
+			return nil, err
+		}
+		quoteID = &quote.QuoteID
+	}
+
 	// Get bank account info if in auto mode
 	var bankInfo *BankAccountInfo
 	if req.BankAccountID != nil {
@@ -145,10 +175,46 @@ func (s *QRCodeService) CreateQRCode(ctx context.Context, userID uuid.UUID, req
 		Label:              s.nullStringToStringPtr(qrCode.Label),
 		BankAccount:        bankInfo,
 		ExpiresAt:          s.nullTimeToTimePtr(qrCode.ExpiresAt),
+		QuoteID:            quoteID,
 		CreatedAt:          qrCode.CreatedAt,
 	}, nil
 }
 
+// QuoteRamp prices a crypto payment to a QR code and locks the payout in a single-use quote.
+// The quote is honoured if the exact quoted amount arrives before it expires.
+func (s *QRCodeService) QuoteRamp(ctx context.Context, userID uuid.UUID, req *RampQuoteRequest) (*ratequote.Quote, error) {
+	cryptoAmount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !cryptoAmount.IsPositive() {
+		return nil, fmt.Errorf("invalid amount: %s", req.Amount)
+	}
+
+	// QR codes always pay out in NGN
+	fiatCurrency := "NGN"
+	rate, err := s.getConversionRate(req.CryptoCurrency, fiatCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversion rate: %w", err)
+	}
+
+	fiatAmount := cryptoAmount.Mul(rate)
+	_, _, _, totalFees := s.calculateConversionFees(fiatAmount)
+
+	return s.quoteService.Issue(ctx, ratequote.IssueParams{
+		UserID:         userID,
+		Purpose:        ratequote.PurposeRamp,
+		SourceCurrency: req.CryptoCurrency,
+		TargetCurrency: fiatCurrency,
+		SourceAmount:   cryptoAmount,
+		Rate:           rate,
+		Fees:           totalFees,
+		TargetAmount:   fiatAmount,
+		NetAmount:      fiatAmount.Sub(totalFees),
+		RateProvider:   "cryptomus",
+		TTL:            ratequote.RampQuoteTTL,
+		// ramps apply no spread, so the quoted rate is the market rate
+		MidRate: rate,
+	})
+}
+
 // GetQRCodes retrieves all QR codes for a user
 func (s *QRCodeService) GetQRCodes(ctx context.Context, userID uuid.UUID) ([]*QRCodeResponse, error) {
 	qrCodes, err := s.store.GetQRCodesByUser(ctx, userID)
@@ -585,25 +651,38 @@ func (s *QRCodeService) convertQRTransaction(ctx context.Context, tx *db.QrTrans
 		return fmt.Errorf("failed to get QR code: %w", err)
 	}
 
-	// Get conversion rate
-	rate, err := s.getConversionRate(tx.CryptoCurrency, qrCode.CurrencyPreference)
-	if err != nil {
-		return fmt.Errorf("failed to get conversion rate: %w", err)
-	}
-
 	// Parse crypto amount
 	cryptoAmount, _ := decimal.NewFromString(tx.CryptoAmount)
 
-	// Calculate fiat amount
-	fiatAmount := cryptoAmount.Mul(rate)
+	var rate, fiatAmount, conversionFee, platformFee, networkFee, totalFees, netAmount decimal.Decimal
+	var rateProvider string
+	var attribution *ratequote.Terms
+
+	if quote := s.quoteForTransaction(ctx, qrCode, tx, cryptoAmount); quote != nil {
+		// honour the locked quote exactly
+		rate = quote.Rate
+		fiatAmount = quote.TargetAmount
+		conversionFee = quote.Fees
+		platformFee = decimal.Zero
+		networkFee = decimal.Zero
+		totalFees = quote.Fees
+		netAmount = quote.NetAmount
+		rateProvider = quote.RateProvider
+		attribution = quote
+	} else {
+		// Get conversion rate
+		rate, err = s.getConversionRate(tx.CryptoCurrency, qrCode.CurrencyPreference)
+		if err != nil {
+			return fmt.Errorf("failed to get conversion rate: %w", err)
+		}
 
-	// Calculate fees
-	// TODO: change fees to vip rates
-	conversionFee := fiatAmount.Mul(decimal.NewFromFloat(0.00)) // 1% conversion fee
-	platformFee := fiatAmount.Mul(decimal.NewFromFloat(0.000))  // 0.5% platform fee
-	networkFee := decimal.NewFromFloat(0)                       // Fixed NGN 100 network fee
-	totalFees := conversionFee.Add(platformFee).Add(networkFee)
-	netAmount := fiatAmount.Sub(totalFees)
+		// Calculate fiat amount
+		fiatAmount = cryptoAmount.Mul(rate)
+
+		conversionFee, platformFee, networkFee, totalFees = s.calculateConversionFees(fiatAmount)
+		netAmount = fiatAmount.Sub(totalFees)
+		rateProvider = "cryptomus"
+	}
 
 	// Update transaction with conversion details
 	_, err = s.store.UpdateQRTransactionToConverting(ctx, db.UpdateQRTransactionToConvertingParams{
@@ -615,7 +694,7 @@ func (s *QRCodeService) convertQRTransaction(ctx context.Context, tx *db.QrTrans
 	}
 
 	// Mark conversion as complete
-	_, err = s.store.UpdateQRTransactionConversionComplete(ctx, db.UpdateQRTransactionConversionCompleteParams{
+	completed, err := s.store.UpdateQRTransactionConversionComplete(ctx, db.UpdateQRTransactionConversionCompleteParams{
 		ID:             tx.ID,
 		FiatCurrency:   sql.NullString{String: qrCode.CurrencyPreference, Valid: true},
 		FiatAmount:     sql.NullString{String: fiatAmount.String(), Valid: true},
@@ -630,12 +709,83 @@ func (s *QRCodeService) convertQRTransaction(ctx context.Context, tx *db.QrTrans
 		return fmt.Errorf("failed to complete conversion: %w", err)
 	}
 
+	entry := fxrevenue.Entry{
+		Channel:        fxrevenue.ChannelRapidRamp,
+		ReferenceID:    tx.ID,
+		TransactionID:  s.nullUUIDToUUIDPtr(tx.TransactionID),
+		UserID:         tx.UserID,
+		SourceCurrency: tx.CryptoCurrency,
+		TargetCurrency: qrCode.CurrencyPreference,
+		SourceAmount:   cryptoAmount,
+		AppliedRate:    rate,
+		Fees:           totalFees,
+		RateProvider:   rateProvider,
+		OccurredAt:     completed.ConversionCompletedAt.Time,
+	}
+	// a quoted ramp is measured against the pricing the user was shown, not today's rate.
+	// Ramps apply no spread, so that mid rate is the applied rate.
+	if attribution != nil {
+		entry.MidRate, entry.MidRateSource = attribution.MidRate, fxrevenue.MidRateSourceApplied
+		entry.RuleID, entry.RuleName = attribution.RuleID, attribution.RuleName
+		entry.VIPLevelID, entry.VIPLevelName = attribution.VIPLevelID, attribution.VIPLevelName
+	}
+
+	// a missing entry is reported by revenue reconciliation, so it does not hold up the payout
+	if err := s.revenueService.Record(ctx, nil, entry); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to record revenue for transaction %s: %v", tx.ID, err))
+	}
+
 	s.logger.Info(fmt.Sprintf("Transaction %s converted: %s %s -> %s %s",
 		tx.ID, cryptoAmount, tx.CryptoCurrency, netAmount, qrCode.CurrencyPreference))
 
 	return nil
 }
 
+// quoteForTransaction consumes the ramp quote bound to a QR code if it covers this payment: same
+// currency, exactly the quoted amount and received before the quote expired. A payment the quote
+// does not cover cannot be rejected once it is on-chain, so it converts at the live rate and the
+// quote is retired.
+func (s *QRCodeService) quoteForTransaction(ctx context.Context, qrCode db.QrCode, tx *db.QrTransaction, cryptoAmount decimal.Decimal) *ratequote.Terms {
+	quote, err := s.quoteService.ActiveQuoteForQRCode(ctx, qrCode.ID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to get quote for QR code %s: %v", qrCode.ID, err))
+		return nil
+	}
+	if quote == nil {
+		return nil
+	}
+
+	receivedAt := tx.CreatedAt
+	if tx.PaymentReceivedAt.Valid {
+		receivedAt = tx.PaymentReceivedAt.Time
+	}
+
+	if quote.SourceCurrency != tx.CryptoCurrency || !quote.SourceAmount.Equal(cryptoAmount) || receivedAt.After(quote.ExpiresAt) {
+		s.logger.Info(fmt.Sprintf("Quote %s does not cover transaction %s, converting at live rate", quote.ID, tx.ID))
+		if err := s.quoteService.Expire(ctx, quote.ID); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to expire quote %s: %v", quote.ID, err))
+		}
+		return nil
+	}
+
+	if err := s.quoteService.Consume(ctx, nil, quote.ID, tx.ID.String(), receivedAt); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to consume quote %s for transaction %s: %v", quote.ID, tx.ID, err))
+		return nil
+	}
+
+	return quote
+}
+
+// calculateConversionFees returns the conversion, platform and network fees on a fiat amount
+func (s *QRCodeService) calculateConversionFees(fiatAmount decimal.Decimal) (conversionFee, platformFee, networkFee, totalFees decimal.Decimal) {
+	// TODO: change fees to vip rates
+	conversionFee = fiatAmount.Mul(decimal.NewFromFloat(0.00)) // 1% conversion fee
+	platformFee = fiatAmount.Mul(decimal.NewFromFloat(0.000))  // 0.5% platform fee
+	networkFee = decimal.NewFromFloat(0)                       // Fixed NGN 100 network fee
+	totalFees = conversionFee.Add(platformFee).Add(networkFee)
+	return conversionFee, platformFee, networkFee, totalFees
+}
+
 // getConversionRate gets crypto to fiat conversion rate
 func (s *QRCodeService) getConversionRate(cryptoCurrency, fiatCurrency string) (decimal.Decimal, error) {
 	// First get crypto to USD rate via Cryptomus
@@ -760,6 +910,13 @@ func (s *QRCodeService) uuidPtrToNullUUID(id *uuid.UUID) uuid.NullUUID {
 	return uuid.NullUUID{UUID: *id, Valid: true}
 }
 
+func (s *QRCodeService) nullUUIDToUUIDPtr(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &id.UUID
+}
+
 func (s *QRCodeService) stringPtrToNullString(str *string) sql.NullString {
 	if str == nil {
 		return sql.NullString{Valid: false}
@@ -0,0 +1,764 @@
+package ratemanager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/audit"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// =====================================================
+// RATE CHANGE APPROVALS (MAKER-CHECKER)
+// =====================================================
+
+const (
+	// revenueImpactWindow is the conversion history the projected impact of a change is priced against
+	revenueImpactWindow = 30 * 24 * time.Hour
+	// defaultPreviewAmount is simulated when a pair has no recent conversions to average
+	defaultPreviewAmount = "1000"
+)
+
+// SubmitRuleCreation queues a new rate adjustment rule for approval
+func (s *Service) SubmitRuleCreation(ctx context.Context, req *CreateRateAdjustmentRuleRequest, user *db.User) (*RateChangeRequest, error) {
+	if err := validateRuleCreation(ctx, s.store.Queries, req); err != nil {
+		return nil, err
+	}
+
+	summary := fmt.Sprintf("Create rule %q on %s/%s: %s %s %s",
+		req.RuleName, req.SourceCurrency, req.TargetCurrency, req.AdjustmentDirection, req.AdjustmentValue, req.AdjustmentType)
+
+	return s.submitChange(ctx, RateChangeCreateRule, uuid.NullUUID{}, req.SourceCurrency, req.TargetCurrency, req, summary, user)
+}
+
+// SubmitRuleUpdate queues an update to an existing rate adjustment rule for approval
+func (s *Service) SubmitRuleUpdate(ctx context.Context, id uuid.UUID, req *UpdateRateAdjustmentRuleRequest, user *db.User) (*RateChangeRequest, error) {
+	rule, err := s.store.GetRateAdjustmentRuleByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get rate adjustment rule: %w", err)
+	}
+	if err := validateRuleUpdate(&rule, req); err != nil {
+		return nil, err
+	}
+
+	summary := fmt.Sprintf("Update rule %q on %s/%s", rule.RuleName, rule.SourceCurrency, rule.TargetCurrency)
+
+	return s.submitChange(ctx, RateChangeUpdateRule, uuid.NullUUID{UUID: id, Valid: true}, rule.SourceCurrency, rule.TargetCurrency, req, summary, user)
+}
+
+// SubmitRuleToggle queues enabling or disabling a rate adjustment rule for approval
+func (s *Service) SubmitRuleToggle(ctx context.Context, id uuid.UUID, enabled bool, user *db.User) (*RateChangeRequest, error) {
+	rule, err := s.store.GetRateAdjustmentRuleByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get rate adjustment rule: %w", err)
+	}
+
+	action := "Disable"
+	if enabled {
+		action = "Enable"
+	}
+	summary := fmt.Sprintf("%s rule %q on %s/%s", action, rule.RuleName, rule.SourceCurrency, rule.TargetCurrency)
+
+	return s.submitChange(ctx, RateChangeToggleRule, uuid.NullUUID{UUID: id, Valid: true}, rule.SourceCurrency, rule.TargetCurrency,
+		ruleToggleChange{Enabled: enabled}, summary, user)
+}
+
+// SubmitRateSourcePreference queues switching a currency pair between manual and exchange service rates
+func (s *Service) SubmitRateSourcePreference(ctx context.Context, currencyPair string, preference string, user *db.User) (*RateChangeRequest, error) {
+	if preference != string(RateSourceExchangeService) && preference != string(RateSourceManual) {
+		return nil, &RateManagerError{Code: "INVALID_RATE_SOURCE", Message: fmt.Sprintf("invalid rate source preference: %s", preference)}
+	}
+
+	from, to, ok := strings.Cut(currencyPair, "/")
+	if !ok || from == "" || to == "" || from == to {
+		return nil, ErrInvalidCurrencyPair
+	}
+
+	summary := fmt.Sprintf("Use %s rates for %s", preference, currencyPair)
+
+	return s.submitChange(ctx, RateChangeRateSource, uuid.NullUUID{}, from, to,
+		rateSourceChange{CurrencyPair: currencyPair, Preference: preference}, summary, user)
+}
+
+// SubmitManualRate queues a manually set exchange rate for approval
+func (s *Service) SubmitManualRate(ctx context.Context, base, quote string, rate decimal.Decimal, user *db.User) (*RateChangeRequest, error) {
+	if base == "" || quote == "" || base == quote {
+		return nil, ErrInvalidCurrencyPair
+	}
+	if !rate.IsPositive() {
+		return nil, &RateManagerError{Code: "INVALID_RATE", Message: "Rate must be greater than zero"}
+	}
+
+	summary := fmt.Sprintf("Set manual %s/%s rate to %s", base, quote, rate.String())
+
+	return s.submitChange(ctx, RateChangeManualRate, uuid.NullUUID{}, base, quote,
+		manualRateChange{BaseCurrency: base, QuoteCurrency: quote, Rate: rate.String()}, summary, user)
+}
+
+// submitChange stores a pending change together with the preview the approver will review
+func (s *Service) submitChange(ctx context.Context, changeType RateChangeType, targetID uuid.NullUUID, source, target string, payload any, summary string, user *db.User) (*RateChangeRequest, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode rate change: %w", err)
+	}
+
+	preview := s.previewChange(ctx, &db.RateChangeRequest{
+		ChangeType:     string(changeType),
+		TargetID:       targetID,
+		SourceCurrency: source,
+		TargetCurrency: target,
+		Payload:        raw,
+	})
+	rawPreview, err := json.Marshal(preview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode rate change preview: %w", err)
+	}
+
+	change, err := s.store.CreateRateChangeRequest(ctx, db.CreateRateChangeRequestParams{
+		ChangeType:     string(changeType),
+		TargetID:       targetID,
+		SourceCurrency: source,
+		TargetCurrency: target,
+		Payload:        raw,
+		Preview:        pqtype.NullRawMessage{RawMessage: rawPreview, Valid: true},
+		Summary:        sql.NullString{String: summary, Valid: summary != ""},
+		RequestedBy:    user.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rate change request: %w", err)
+	}
+
+	s.auditService.Log(&audit.LogEntry{
+		EventCategory: audit.CategoryRateManager,
+		EventType:     "rate_change_requested",
+		Severity:      audit.SeverityInfo,
+		ActorType:     user.Role,
+		ActorID:       &user.ID,
+		ActorEmail:    &user.Email,
+		EntityType:    "rate_change_request",
+		EntityID:      change.ID.String(),
+		Action:        audit.ActionCreate,
+		Description:   summary,
+		NewValues: map[string]any{
+			"change_type":   changeType,
+			"currency_pair": fmt.Sprintf("%s/%s", source, target),
+			"payload":       string(raw),
+		},
+		Success: true,
+	})
+
+	return toRateChangeRequestModel(&change), nil
+}
+
+// ApproveRateChange applies a pending change and records it with both the maker and the approver.
+// The change, its history entry and the request's status are committed together.
+func (s *Service) ApproveRateChange(ctx context.Context, id uuid.UUID, approver *db.User, note *string) (*RateChangeRequest, error) {
+	change, err := s.getChangeRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if change.Status != ChangeStatusPending {
+		return nil, ErrChangeRequestNotPending
+	}
+	if change.RequestedBy == approver.ID {
+		return nil, ErrSelfApproval
+	}
+
+	maker, err := s.store.GetUserByID(ctx, change.RequestedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get change requester: %w", err)
+	}
+
+	// price the change against live rates at the moment it goes out, not when it was submitted
+	preview := s.previewChange(ctx, change)
+
+	var approved db.RateChangeRequest
+	var undo func()
+	err = s.store.ExecTx(ctx, func(q *db.Queries) error {
+		locked, err := q.GetRateChangeRequestForUpdate(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to lock rate change request: %w", err)
+		}
+		if locked.Status != ChangeStatusPending {
+			return ErrChangeRequestNotPending
+		}
+
+		var ruleID uuid.NullUUID
+		var ruleName sql.NullString
+		ruleID, ruleName, undo, err = s.applyChange(ctx, q, &locked, &maker)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.RecordApprovedRateChange(ctx, db.RecordApprovedRateChangeParams{
+			SourceCurrency:   locked.SourceCurrency,
+			TargetCurrency:   locked.TargetCurrency,
+			BaseRate:         previewValue(preview.After, func(r *RateSimulationResponse) string { return r.BaseRate }),
+			AdjustedRate:     previewValue(preview.After, func(r *RateSimulationResponse) string { return r.AdjustedRate }),
+			AdjustmentAmount: previewValue(preview.After, func(r *RateSimulationResponse) string { return r.AdjustmentAmount }),
+			RuleID:           ruleID,
+			RuleName:         ruleName,
+			RateProvider:     sql.NullString{String: previewValue(preview.After, func(r *RateSimulationResponse) string { return r.RateProvider }), Valid: preview.After != nil},
+			ChangeReason:     locked.Summary,
+			ChangedBy:        uuid.NullUUID{UUID: maker.ID, Valid: true},
+			ApprovedBy:       uuid.NullUUID{UUID: approver.ID, Valid: true},
+			ChangeRequestID:  uuid.NullUUID{UUID: locked.ID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record rate change: %w", err)
+		}
+
+		approved, err = q.ApproveRateChangeRequest(ctx, db.ApproveRateChangeRequestParams{
+			ID:         locked.ID,
+			ReviewedBy: uuid.NullUUID{UUID: approver.ID, Valid: true},
+			ReviewNote: toNullString(note),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to approve rate change request: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if undo != nil {
+			undo()
+		}
+		if errors.Is(err, ErrChangeRequestNotPending) {
+			return nil, err
+		}
+
+		// leave a trail on the request so the maker can see why it did not go out
+		if failErr := s.store.FailRateChangeRequest(ctx, db.FailRateChangeRequestParams{
+			ID:            id,
+			ReviewedBy:    uuid.NullUUID{UUID: approver.ID, Valid: true},
+			FailureReason: sql.NullString{String: err.Error(), Valid: true},
+		}); failErr != nil {
+			s.logger.Error(fmt.Sprintf("Failed to mark rate change request %s failed: %v", id, failErr))
+		}
+
+		s.auditService.Log(&audit.LogEntry{
+			EventCategory: audit.CategoryRateManager,
+			EventType:     "rate_change_failed",
+			Severity:      audit.SeverityWarning,
+			ActorType:     approver.Role,
+			ActorID:       &approver.ID,
+			ActorEmail:    &approver.Email,
+			EntityType:    "rate_change_request",
+			EntityID:      id.String(),
+			Action:        audit.ActionUpdate,
+			Description:   fmt.Sprintf("Approved rate change could not be applied: %v", err),
+			Success:       false,
+		})
+		return nil, err
+	}
+
+	s.auditService.Log(&audit.LogEntry{
+		EventCategory: audit.CategoryRateManager,
+		EventType:     "rate_change_approved",
+		Severity:      audit.SeverityWarning,
+		ActorType:     approver.Role,
+		ActorID:       &approver.ID,
+		ActorEmail:    &approver.Email,
+		EntityType:    "rate_change_request",
+		EntityID:      id.String(),
+		Action:        audit.ActionUpdate,
+		Description:   fmt.Sprintf("Approved rate change requested by %s: %s", maker.Email, approved.Summary.String),
+		OldValues:     map[string]any{"status": ChangeStatusPending},
+		NewValues: map[string]any{
+			"status":                   approved.Status,
+			"requested_by":             maker.ID,
+			"projected_revenue_impact": preview.ProjectedRevenueImpact,
+		},
+		Success: true,
+	})
+
+	model := toRateChangeRequestModel(&approved)
+	model.CurrentPreview = preview
+	return model, nil
+}
+
+// RejectRateChange closes a pending change without applying it
+func (s *Service) RejectRateChange(ctx context.Context, id uuid.UUID, reviewer *db.User, note *string) (*RateChangeRequest, error) {
+	change, err := s.getChangeRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if change.RequestedBy == reviewer.ID {
+		return nil, ErrSelfApproval
+	}
+
+	rejected, err := s.store.RejectRateChangeRequest(ctx, db.RejectRateChangeRequestParams{
+		ID:         id,
+		ReviewedBy: uuid.NullUUID{UUID: reviewer.ID, Valid: true},
+		ReviewNote: toNullString(note),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChangeRequestNotPending
+		}
+		return nil, fmt.Errorf("failed to reject rate change request: %w", err)
+	}
+
+	s.auditService.Log(&audit.LogEntry{
+		EventCategory: audit.CategoryRateManager,
+		EventType:     "rate_change_rejected",
+		Severity:      audit.SeverityInfo,
+		ActorType:     reviewer.Role,
+		ActorID:       &reviewer.ID,
+		ActorEmail:    &reviewer.Email,
+		EntityType:    "rate_change_request",
+		EntityID:      id.String(),
+		Action:        audit.ActionUpdate,
+		Description:   fmt.Sprintf("Rejected rate change: %s", rejected.Summary.String),
+		OldValues:     map[string]any{"status": ChangeStatusPending},
+		NewValues:     map[string]any{"status": rejected.Status},
+		Success:       true,
+	})
+
+	return toRateChangeRequestModel(&rejected), nil
+}
+
+// CancelRateChange lets the maker withdraw a change that has not been reviewed yet
+func (s *Service) CancelRateChange(ctx context.Context, id uuid.UUID, user *db.User) (*RateChangeRequest, error) {
+	change, err := s.getChangeRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if change.RequestedBy != user.ID {
+		return nil, ErrChangeRequestNotFound
+	}
+
+	cancelled, err := s.store.CancelRateChangeRequest(ctx, db.CancelRateChangeRequestParams{
+		ID:          id,
+		RequestedBy: user.ID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChangeRequestNotPending
+		}
+		return nil, fmt.Errorf("failed to cancel rate change request: %w", err)
+	}
+
+	s.auditService.Log(&audit.LogEntry{
+		EventCategory: audit.CategoryRateManager,
+		EventType:     "rate_change_cancelled",
+		Severity:      audit.SeverityInfo,
+		ActorType:     user.Role,
+		ActorID:       &user.ID,
+		ActorEmail:    &user.Email,
+		EntityType:    "rate_change_request",
+		EntityID:      id.String(),
+		Action:        audit.ActionUpdate,
+		Description:   fmt.Sprintf("Cancelled rate change: %s", cancelled.Summary.String),
+		Success:       true,
+	})
+
+	return toRateChangeRequestModel(&cancelled), nil
+}
+
+// GetRateChangeRequest returns a change request; pending requests also carry a preview priced at current rates
+func (s *Service) GetRateChangeRequest(ctx context.Context, id uuid.UUID) (*RateChangeRequest, error) {
+	change, err := s.getChangeRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	model := toRateChangeRequestModel(change)
+	if change.Status == ChangeStatusPending {
+		model.CurrentPreview = s.previewChange(ctx, change)
+	}
+	return model, nil
+}
+
+// ListRateChangeRequests lists change requests, newest first, optionally filtered by status
+func (s *Service) ListRateChangeRequests(ctx context.Context, status string, params *PaginationParams) (*PaginatedResponse, error) {
+	statusFilter := sql.NullString{String: status, Valid: status != ""}
+
+	changes, err := s.store.ListRateChangeRequests(ctx, db.ListRateChangeRequestsParams{
+		Status:    statusFilter,
+		RowLimit:  params.GetLimit(),
+		RowOffset: params.GetOffset(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rate change requests: %w", err)
+	}
+
+	totalCount, err := s.store.CountRateChangeRequests(ctx, statusFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count rate change requests: %w", err)
+	}
+
+	data := make([]*RateChangeRequest, len(changes))
+	for i := range changes {
+		data[i] = toRateChangeRequestModel(&changes[i])
+	}
+
+	totalPages := int32((totalCount + int64(params.GetLimit()) - 1) / int64(params.GetLimit()))
+
+	return &PaginatedResponse{
+		Data:       data,
+		Page:       params.Page,
+		PageSize:   params.GetLimit(),
+		TotalCount: totalCount,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (s *Service) getChangeRequest(ctx context.Context, id uuid.UUID) (*db.RateChangeRequest, error) {
+	change, err := s.store.GetRateChangeRequest(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrChangeRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to get rate change request: %w", err)
+	}
+	return &change, nil
+}
+
+// applyChange performs the change on q on behalf of its maker. Rate source preferences live in
+// Redis rather than the database, so for those undo restores the previous value if the
+// surrounding transaction does not commit.
+func (s *Service) applyChange(ctx context.Context, q *db.Queries, change *db.RateChangeRequest, maker *db.User) (uuid.NullUUID, sql.NullString, func(), error) {
+	switch RateChangeType(change.ChangeType) {
+	case RateChangeCreateRule:
+		var req CreateRateAdjustmentRuleRequest
+		if err := json.Unmarshal(change.Payload, &req); err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, fmt.Errorf("invalid rate change payload: %w", err)
+		}
+		rule, err := s.createRateAdjustmentRule(ctx, q, &req, maker)
+		if err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, err
+		}
+		return uuid.NullUUID{UUID: rule.ID, Valid: true}, sql.NullString{String: rule.RuleName, Valid: true}, nil, nil
+
+	case RateChangeUpdateRule:
+		var req UpdateRateAdjustmentRuleRequest
+		if err := json.Unmarshal(change.Payload, &req); err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, fmt.Errorf("invalid rate change payload: %w", err)
+		}
+		rule, err := s.updateRateAdjustmentRule(ctx, q, change.TargetID.UUID, &req, maker)
+		if err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, err
+		}
+		return uuid.NullUUID{UUID: rule.ID, Valid: true}, sql.NullString{String: rule.RuleName, Valid: true}, nil, nil
+
+	case RateChangeToggleRule:
+		var toggle ruleToggleChange
+		if err := json.Unmarshal(change.Payload, &toggle); err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, fmt.Errorf("invalid rate change payload: %w", err)
+		}
+		if err := s.toggleRateAdjustmentRule(ctx, q, change.TargetID.UUID, toggle.Enabled, maker); err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, err
+		}
+		rule, err := q.GetRateAdjustmentRuleByID(ctx, change.TargetID.UUID)
+		if err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, fmt.Errorf("failed to get rate adjustment rule: %w", err)
+		}
+		return change.TargetID, sql.NullString{String: rule.RuleName, Valid: true}, nil, nil
+
+	case RateChangeManualRate:
+		var manual manualRateChange
+		if err := json.Unmarshal(change.Payload, &manual); err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, fmt.Errorf("invalid rate change payload: %w", err)
+		}
+		_, err := q.CreateExchangeRate(ctx, db.CreateExchangeRateParams{
+			BaseCurrency:  manual.BaseCurrency,
+			QuoteCurrency: manual.QuoteCurrency,
+			Rate:          manual.Rate,
+			Source:        "manual",
+			EffectiveTime: time.Now(),
+		})
+		if err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, fmt.Errorf("failed to set manual rate: %w", err)
+		}
+		return uuid.NullUUID{}, sql.NullString{}, nil, nil
+
+	case RateChangeRateSource:
+		var source rateSourceChange
+		if err := json.Unmarshal(change.Payload, &source); err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, fmt.Errorf("invalid rate change payload: %w", err)
+		}
+		previous := s.GetRateSourcePreference(ctx, source.CurrencyPair)
+		if err := s.setRateSourcePreference(ctx, source.CurrencyPair, source.Preference); err != nil {
+			return uuid.NullUUID{}, sql.NullString{}, nil, fmt.Errorf("failed to set rate source preference: %w", err)
+		}
+		undo := func() {
+			if err := s.setRateSourcePreference(context.Background(), source.CurrencyPair, string(previous)); err != nil {
+				s.logger.Error(fmt.Sprintf("Failed to restore rate source preference for %s: %v", source.CurrencyPair, err))
+			}
+		}
+		return uuid.NullUUID{}, sql.NullString{}, undo, nil
+	}
+
+	return uuid.NullUUID{}, sql.NullString{}, nil, fmt.Errorf("unknown rate change type: %s", change.ChangeType)
+}
+
+// previewChange simulates a typical conversion on the pair before and after the change and
+// projects the difference over the last 30 days of volume. A failed simulation is reported on
+// the preview rather than blocking the request.
+func (s *Service) previewChange(ctx context.Context, change *db.RateChangeRequest) *RateChangePreview {
+	preview := &RateChangePreview{
+		SourceCurrency:         change.SourceCurrency,
+		TargetCurrency:         change.TargetCurrency,
+		SampleAmount:           defaultPreviewAmount,
+		RateChangePercent:      "0",
+		SourceVolume:           "0",
+		ProjectedRevenueImpact: "0",
+		GeneratedAt:            time.Now(),
+	}
+
+	volume, err := s.store.GetPairConversionVolume(ctx, db.GetPairConversionVolumeParams{
+		SourceCurrency: change.SourceCurrency,
+		TargetCurrency: change.TargetCurrency,
+		CreatedAt:      time.Now().Add(-revenueImpactWindow),
+	})
+	sourceVolume := decimal.Zero
+	if err != nil {
+		s.logger.Warn(fmt.Sprintf("Failed to get conversion volume for %s/%s: %v", change.SourceCurrency, change.TargetCurrency, err))
+	} else {
+		sourceVolume, _ = decimal.NewFromString(volume.SourceVolume)
+		preview.ConversionCount = volume.ConversionCount
+		preview.SourceVolume = sourceVolume.String()
+		if volume.ConversionCount > 0 && sourceVolume.IsPositive() {
+			preview.SampleAmount = sourceVolume.Div(decimal.NewFromInt(volume.ConversionCount)).Round(2).String()
+		}
+	}
+
+	before, after, note, err := s.simulateChange(ctx, change, preview.SampleAmount)
+	if err != nil {
+		preview.Error = err.Error()
+		return preview
+	}
+	preview.Before = before
+	preview.After = after
+	preview.Note = note
+
+	beforeRate, _ := decimal.NewFromString(before.AdjustedRate)
+	afterRate, _ := decimal.NewFromString(after.AdjustedRate)
+	if !beforeRate.IsZero() {
+		preview.RateChangePercent = afterRate.Sub(beforeRate).Div(beforeRate).Mul(decimal.NewFromInt(100)).Round(4).String()
+	}
+
+	// users receive source * rate, so every unit the rate drops is kept by the platform
+	preview.ProjectedRevenueImpact = sourceVolume.Mul(beforeRate.Sub(afterRate)).Round(2).String()
+
+	return preview
+}
+
+// withTargetingNote extends a preview note for rules limited by amount band, channel or time window
+func withTargetingNote(note string) string {
+	if note == "" {
+		return "Impact ignores the rule's amount band, channel and time window targeting, so it is an upper bound"
+	}
+	return note + "; amount band, channel and time window targeting is ignored, so it is an upper bound"
+}
+
+// simulateChange prices the sample amount as the pair is configured today and as it would be with the change
+func (s *Service) simulateChange(ctx context.Context, change *db.RateChangeRequest, amount string) (before, after *RateSimulationResponse, note string, err error) {
+	unadjusted := &RateSimulationRequest{
+		SourceCurrency: change.SourceCurrency,
+		TargetCurrency: change.TargetCurrency,
+		Amount:         amount,
+	}
+
+	switch RateChangeType(change.ChangeType) {
+	case RateChangeCreateRule:
+		var req CreateRateAdjustmentRuleRequest
+		if err := json.Unmarshal(change.Payload, &req); err != nil {
+			return nil, nil, "", fmt.Errorf("invalid rate change payload: %w", err)
+		}
+
+		// a new global rule replaces the unadjusted rate; a VIP rule is compared against the global one it would override
+		beforeReq := unadjusted
+		if global, err := s.store.GetActiveGlobalRule(ctx, db.GetActiveGlobalRuleParams{
+			SourceCurrency: change.SourceCurrency,
+			TargetCurrency: change.TargetCurrency,
+		}); err == nil {
+			beforeReq = ruleSimulation(unadjusted, global.AdjustmentType, global.AdjustmentValue, global.AdjustmentDirection)
+		}
+		if !req.IsGlobalRule {
+			note = "Impact assumes all volume on the pair is converted at the VIP level's rate"
+		}
+		if req.MinConversionAmount != nil || req.MaxConversionAmount != nil || len(req.Channels) > 0 || len(req.TimeWindows) > 0 {
+			note = withTargetingNote(note)
+		}
+
+		before, err = s.SimulateRateAdjustment(ctx, beforeReq)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		after, err = s.SimulateRateAdjustment(ctx, ruleSimulation(unadjusted, string(req.AdjustmentType), req.AdjustmentValue, string(req.AdjustmentDirection)))
+		if err != nil {
+			return nil, nil, "", err
+		}
+		return before, after, note, nil
+
+	case RateChangeUpdateRule, RateChangeToggleRule:
+		rule, err := s.store.GetRateAdjustmentRuleByID(ctx, change.TargetID.UUID)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to get rate adjustment rule: %w", err)
+		}
+
+		current := ruleSimulation(unadjusted, rule.AdjustmentType, rule.AdjustmentValue, rule.AdjustmentDirection)
+		beforeReq, afterReq := current, current
+		if !rule.IsActive {
+			beforeReq = unadjusted
+		}
+
+		if RateChangeType(change.ChangeType) == RateChangeToggleRule {
+			var toggle ruleToggleChange
+			if err := json.Unmarshal(change.Payload, &toggle); err != nil {
+				return nil, nil, "", fmt.Errorf("invalid rate change payload: %w", err)
+			}
+			if !toggle.Enabled {
+				afterReq = unadjusted
+			}
+		} else {
+			var req UpdateRateAdjustmentRuleRequest
+			if err := json.Unmarshal(change.Payload, &req); err != nil {
+				return nil, nil, "", fmt.Errorf("invalid rate change payload: %w", err)
+			}
+			adjustmentType, value, direction := rule.AdjustmentType, rule.AdjustmentValue, rule.AdjustmentDirection
+			if req.AdjustmentType != nil {
+				adjustmentType = string(*req.AdjustmentType)
+			}
+			if req.AdjustmentValue != nil {
+				value = *req.AdjustmentValue
+			}
+			if req.AdjustmentDirection != nil {
+				direction = string(*req.AdjustmentDirection)
+			}
+			afterReq = ruleSimulation(unadjusted, adjustmentType, value, direction)
+			if (req.IsActive != nil && !*req.IsActive) || (req.IsActive == nil && !rule.IsActive) {
+				afterReq = unadjusted
+			}
+		}
+		if rule.VipLevelID.Valid {
+			note = "Impact assumes all volume on the pair is converted at the VIP level's rate"
+		}
+		if rule.MinConversionAmount.Valid || rule.MaxConversionAmount.Valid || len(rule.Channels) > 0 || len(decodeTimeWindows(rule.TimeWindows)) > 0 {
+			note = withTargetingNote(note)
+		}
+
+		before, err = s.SimulateRateAdjustment(ctx, beforeReq)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		after, err = s.SimulateRateAdjustment(ctx, afterReq)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		return before, after, note, nil
+
+	case RateChangeManualRate:
+		var manual manualRateChange
+		if err := json.Unmarshal(change.Payload, &manual); err != nil {
+			return nil, nil, "", fmt.Errorf("invalid rate change payload: %w", err)
+		}
+		pair := fmt.Sprintf("%s/%s", manual.BaseCurrency, manual.QuoteCurrency)
+
+		before, err = s.simulateBaseRate(ctx, change.SourceCurrency, change.TargetCurrency, amount, s.GetRateSourcePreference(ctx, pair))
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if s.GetRateSourcePreference(ctx, pair) != RateSourceManual {
+			note = "The pair is priced from the exchange service, so this rate has no effect until manual rates are enabled for it"
+			return before, before, note, nil
+		}
+
+		rate, _ := decimal.NewFromString(manual.Rate)
+		after = s.simulateAtRate(change.SourceCurrency, change.TargetCurrency, amount, rate, "manual")
+		return before, after, note, nil
+
+	case RateChangeRateSource:
+		var source rateSourceChange
+		if err := json.Unmarshal(change.Payload, &source); err != nil {
+			return nil, nil, "", fmt.Errorf("invalid rate change payload: %w", err)
+		}
+
+		before, err = s.simulateBaseRate(ctx, change.SourceCurrency, change.TargetCurrency, amount, s.GetRateSourcePreference(ctx, source.CurrencyPair))
+		if err != nil {
+			return nil, nil, "", err
+		}
+		after, err = s.simulateBaseRate(ctx, change.SourceCurrency, change.TargetCurrency, amount, RateSourcePreference(source.Preference))
+		if err != nil {
+			return nil, nil, "", err
+		}
+		note = "Rate adjustment rules are applied on top of either source and are not shown"
+		return before, after, note, nil
+	}
+
+	return nil, nil, "", fmt.Errorf("unknown rate change type: %s", change.ChangeType)
+}
+
+// simulateBaseRate prices an unadjusted conversion from the given rate source
+func (s *Service) simulateBaseRate(ctx context.Context, from, to, amount string, source RateSourcePreference) (*RateSimulationResponse, error) {
+	if source == RateSourceManual {
+		manual, err := s.getManualRate(ctx, from, to)
+		if err == nil {
+			return s.simulateAtRate(from, to, amount, manual.Rate, "manual"), nil
+		}
+	}
+
+	return s.SimulateRateAdjustment(ctx, &RateSimulationRequest{
+		SourceCurrency: from,
+		TargetCurrency: to,
+		Amount:         amount,
+	})
+}
+
+// simulateAtRate prices an unadjusted conversion at a fixed rate
+func (s *Service) simulateAtRate(from, to, amount string, rate decimal.Decimal, provider string) *RateSimulationResponse {
+	amt, _ := decimal.NewFromString(amount)
+	feePercentage := s.exchangeRateService.GetFeePercentage(from, to)
+	targetAmount, fees, netAmount := s.exchangeRateService.CalculateConversionAmount(amt, rate, feePercentage)
+
+	return &RateSimulationResponse{
+		BaseRate:            rate.String(),
+		AdjustedRate:        rate.String(),
+		AdjustmentAmount:    "0",
+		SourceAmount:        amount,
+		TargetAmount:        targetAmount.String(),
+		Fees:                fees.String(),
+		NetAmount:           netAmount.String(),
+		RateProvider:        provider,
+		SimulationTimestamp: time.Now(),
+	}
+}
+
+func ruleSimulation(base *RateSimulationRequest, adjustmentType, value, direction string) *RateSimulationRequest {
+	req := *base
+	t := AdjustmentType(adjustmentType)
+	d := AdjustmentDirection(direction)
+	req.AdjustmentType = &t
+	req.AdjustmentValue = &value
+	req.AdjustmentDirection = &d
+	return &req
+}
+
+func previewValue(sim *RateSimulationResponse, field func(*RateSimulationResponse) string) string {
+	if sim == nil {
+		return "0"
+	}
+	return field(sim)
+}
+
+func toNullString(value *string) sql.NullString {
+	if value == nil || *value == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
@@ -1,6 +1,7 @@
 package ratemanager
 
 import (
+	"encoding/json"
 	"time"
 
 	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
@@ -115,6 +116,10 @@ type RateAdjustmentRule struct {
 	MaxConversionAmount *string             `json:"max_conversion_amount,omitempty"`
 	ValidFrom           *time.Time          `json:"valid_from,omitempty"`
 	ValidUntil          *time.Time          `json:"valid_until,omitempty"`
+	Channels            []string            `json:"channels"`
+	TimeWindows         []TimeWindow        `json:"time_windows,omitempty"`
+	ScheduleTimezone    string              `json:"schedule_timezone"`
+	ExcludeHolidays     bool                `json:"exclude_holidays"`
 	IsActive            bool                `json:"is_active"`
 	CreatedBy           *int64              `json:"created_by,omitempty"`
 	UpdatedBy           *int64              `json:"updated_by,omitempty"`
@@ -138,6 +143,10 @@ type CreateRateAdjustmentRuleRequest struct {
 	MaxConversionAmount *string             `json:"max_conversion_amount,omitempty" binding:"omitempty,gte=0"`
 	ValidFrom           *time.Time          `json:"valid_from,omitempty"`
 	ValidUntil          *time.Time          `json:"valid_until,omitempty"`
+	Channels            []string            `json:"channels,omitempty" binding:"omitempty,dive,oneof=app rapid_ramp smart_conversion"`
+	TimeWindows         []TimeWindow        `json:"time_windows,omitempty"`
+	ScheduleTimezone    *string             `json:"schedule_timezone,omitempty"`
+	ExcludeHolidays     *bool               `json:"exclude_holidays,omitempty"`
 	IsActive            *bool               `json:"is_active,omitempty"`
 }
 
@@ -152,6 +161,10 @@ type UpdateRateAdjustmentRuleRequest struct {
 	MaxConversionAmount *string              `json:"max_conversion_amount,omitempty" binding:"omitempty,gte=0"`
 	ValidFrom           *time.Time           `json:"valid_from,omitempty"`
 	ValidUntil          *time.Time           `json:"valid_until,omitempty"`
+	Channels            []string             `json:"channels" binding:"omitempty,dive,oneof=app rapid_ramp smart_conversion"` // null keeps, [] clears
+	TimeWindows         []TimeWindow         `json:"time_windows"`                                                            // null keeps, [] clears
+	ScheduleTimezone    *string              `json:"schedule_timezone,omitempty"`
+	ExcludeHolidays     *bool                `json:"exclude_holidays,omitempty"`
 	IsActive            *bool                `json:"is_active,omitempty"`
 }
 
@@ -173,6 +186,10 @@ type RateAdjustmentRuleResponse struct {
 	MaxConversionAmount *string             `json:"max_conversion_amount,omitempty"`
 	ValidFrom           *time.Time          `json:"valid_from,omitempty"`
 	ValidUntil          *time.Time          `json:"valid_until,omitempty"`
+	Channels            []string            `json:"channels"`
+	TimeWindows         []TimeWindow        `json:"time_windows,omitempty"`
+	ScheduleTimezone    string              `json:"schedule_timezone"`
+	ExcludeHolidays     bool                `json:"exclude_holidays"`
 	IsActive            bool                `json:"is_active"`
 	ApplicationCount    int64               `json:"application_count"`
 	TotalImpact         string              `json:"total_impact"`
@@ -195,17 +212,47 @@ type RateSimulationRequest struct {
 }
 
 type RateSimulationResponse struct {
-	BaseRate            string    `json:"base_rate"`
-	AdjustedRate        string    `json:"adjusted_rate"`
-	AdjustmentAmount    string    `json:"adjustment_amount"`
-	SourceAmount        string    `json:"source_amount"`
-	TargetAmount        string    `json:"target_amount"`
-	Fees                string    `json:"fees"`
-	NetAmount           string    `json:"net_amount"`
-	RateProvider        string    `json:"rate_provider"`
-	VIPLevelApplied     *string   `json:"vip_level_applied,omitempty"`
-	RuleApplied         *string   `json:"rule_applied,omitempty"`
-	SimulationTimestamp time.Time `json:"simulation_timestamp"`
+	BaseRate            string           `json:"base_rate"`
+	AdjustedRate        string           `json:"adjusted_rate"`
+	AdjustmentAmount    string           `json:"adjustment_amount"`
+	SourceAmount        string           `json:"source_amount"`
+	TargetAmount        string           `json:"target_amount"`
+	Fees                string           `json:"fees"`
+	NetAmount           string           `json:"net_amount"`
+	RateProvider        string           `json:"rate_provider"`
+	VIPLevelApplied     *string          `json:"vip_level_applied,omitempty"`
+	VIPLevelID          *uuid.UUID       `json:"vip_level_id,omitempty"`
+	RuleApplied         *string          `json:"rule_applied,omitempty"`
+	RuleID              *uuid.UUID       `json:"rule_id,omitempty"`
+	Explanation         *RuleExplanation `json:"explanation,omitempty"`
+	SimulationTimestamp time.Time        `json:"simulation_timestamp"`
+}
+
+// ExplainRateRequest asks which rule would price a user's quote, optionally at another time
+type ExplainRateRequest struct {
+	UserID         uuid.UUID  `json:"user_id" binding:"required"`
+	SourceCurrency string     `json:"source_currency" binding:"required,oneof=USD NGN USDT USDC"`
+	TargetCurrency string     `json:"target_currency" binding:"required,oneof=USD NGN USDT USDC"`
+	Amount         string     `json:"amount" binding:"required"`
+	Channel        string     `json:"channel,omitempty" binding:"omitempty,oneof=app rapid_ramp smart_conversion"`
+	At             *time.Time `json:"at,omitempty"`
+}
+
+// =====================================================
+// PUBLIC HOLIDAY MODELS
+// =====================================================
+
+type PublicHoliday struct {
+	ID          uuid.UUID  `json:"id"`
+	HolidayDate string     `json:"holiday_date"` // YYYY-MM-DD
+	Name        string     `json:"name"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type CreatePublicHolidayRequest struct {
+	HolidayDate string `json:"holiday_date" binding:"required,datetime=2006-01-02"`
+	Name        string `json:"name" binding:"required,min=2,max=100"`
 }
 
 // =====================================================
@@ -382,6 +429,91 @@ type RateAdminNotification struct {
 	CreatedAt         time.Time            `json:"created_at"`
 }
 
+// =====================================================
+// RATE CHANGE APPROVAL MODELS
+// =====================================================
+
+type RateChangeType string
+
+const (
+	RateChangeCreateRule RateChangeType = "create_rule"
+	RateChangeUpdateRule RateChangeType = "update_rule"
+	RateChangeToggleRule RateChangeType = "toggle_rule"
+	RateChangeRateSource RateChangeType = "set_rate_source"
+	RateChangeManualRate RateChangeType = "set_manual_rate"
+)
+
+const (
+	ChangeStatusPending   = "pending"
+	ChangeStatusApproved  = "approved"
+	ChangeStatusRejected  = "rejected"
+	ChangeStatusCancelled = "cancelled"
+	ChangeStatusFailed    = "failed"
+)
+
+type ToggleRateAdjustmentRuleRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+type ReviewRateChangeRequest struct {
+	Note *string `json:"note"`
+}
+
+type rateSourceChange struct {
+	CurrencyPair string `json:"currency_pair"`
+	Preference   string `json:"preference"`
+}
+
+type manualRateChange struct {
+	BaseCurrency  string `json:"base_currency"`
+	QuoteCurrency string `json:"quote_currency"`
+	Rate          string `json:"rate"`
+}
+
+type ruleToggleChange struct {
+	Enabled bool `json:"enabled"`
+}
+
+// RateChangePreview is what the approver sees: the rate a typical conversion gets today and
+// after the change, and what the difference would have been worth over the last 30 days
+type RateChangePreview struct {
+	SourceCurrency    string                  `json:"source_currency"`
+	TargetCurrency    string                  `json:"target_currency"`
+	SampleAmount      string                  `json:"sample_amount"`
+	Before            *RateSimulationResponse `json:"before,omitempty"`
+	After             *RateSimulationResponse `json:"after,omitempty"`
+	RateChangePercent string                  `json:"rate_change_percent"`
+	ConversionCount   int64                   `json:"conversion_count_30d"`
+	SourceVolume      string                  `json:"source_volume_30d"`
+	// ProjectedRevenueImpact is in the target currency; positive means the platform keeps more
+	ProjectedRevenueImpact string    `json:"projected_revenue_impact"`
+	Note                   string    `json:"note,omitempty"`
+	Error                  string    `json:"error,omitempty"`
+	GeneratedAt            time.Time `json:"generated_at"`
+}
+
+type RateChangeRequest struct {
+	ID             uuid.UUID          `json:"id"`
+	ChangeType     string             `json:"change_type"`
+	TargetID       *uuid.UUID         `json:"target_id,omitempty"`
+	SourceCurrency string             `json:"source_currency"`
+	TargetCurrency string             `json:"target_currency"`
+	Payload        json.RawMessage    `json:"payload"`
+	Summary        *string            `json:"summary,omitempty"`
+	Status         string             `json:"status"`
+	RequestedBy    uuid.UUID          `json:"requested_by"`
+	ReviewedBy     *uuid.UUID         `json:"reviewed_by,omitempty"`
+	ReviewNote     *string            `json:"review_note,omitempty"`
+	FailureReason  *string            `json:"failure_reason,omitempty"`
+	ReviewedAt     *time.Time         `json:"reviewed_at,omitempty"`
+	AppliedAt      *time.Time         `json:"applied_at,omitempty"`
+	Preview        *RateChangePreview `json:"preview,omitempty"`
+	// CurrentPreview is recomputed against live rates when a single request is fetched
+	CurrentPreview *RateChangePreview `json:"current_preview,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
 // =====================================================
 // ERROR TYPES
 // =====================================================
@@ -410,6 +542,11 @@ var (
 	ErrInvalidRuleConfiguration = &RateManagerError{Code: "INVALID_RULE_CONFIG", Message: "Invalid rule configuration"}
 	ErrUserAssignmentNotFound   = &RateManagerError{Code: "USER_ASSIGNMENT_NOT_FOUND", Message: "User VIP assignment not found"}
 	ErrInvalidCurrencyPair      = &RateManagerError{Code: "INVALID_CURRENCY_PAIR", Message: "Invalid currency pair"}
+	ErrChangeRequestNotFound    = &RateManagerError{Code: "CHANGE_REQUEST_NOT_FOUND", Message: "Rate change request not found"}
+	ErrChangeRequestNotPending  = &RateManagerError{Code: "CHANGE_REQUEST_NOT_PENDING", Message: "Rate change request is no longer pending"}
+	ErrSelfApproval             = &RateManagerError{Code: "SELF_APPROVAL", Message: "A rate change must be reviewed by a different admin"}
+	ErrHolidayNotFound          = &RateManagerError{Code: "HOLIDAY_NOT_FOUND", Message: "Public holiday not found"}
+	ErrHolidayExists            = &RateManagerError{Code: "HOLIDAY_EXISTS", Message: "A public holiday is already set for this date"}
 )
 
 // =====================================================
@@ -512,6 +649,10 @@ func toRateAdjustmentRuleModel(rule *db.RateAdjustmentRule) *RateAdjustmentRule
 		AdjustmentValue:     rule.AdjustmentValue,
 		AdjustmentDirection: AdjustmentDirection(rule.AdjustmentDirection),
 		Priority:            rule.Priority,
+		Channels:            rule.Channels,
+		TimeWindows:         decodeTimeWindows(rule.TimeWindows),
+		ScheduleTimezone:    rule.ScheduleTimezone,
+		ExcludeHolidays:     rule.ExcludeHolidays,
 		IsActive:            rule.IsActive,
 		CreatedAt:           rule.CreatedAt,
 		UpdatedAt:           rule.UpdatedAt,
@@ -520,6 +661,12 @@ func toRateAdjustmentRuleModel(rule *db.RateAdjustmentRule) *RateAdjustmentRule
 	if rule.VipLevelID.Valid {
 		model.VIPLevelID = &rule.VipLevelID.UUID
 	}
+	if rule.MinConversionAmount.Valid {
+		model.MinConversionAmount = &rule.MinConversionAmount.String
+	}
+	if rule.MaxConversionAmount.Valid {
+		model.MaxConversionAmount = &rule.MaxConversionAmount.String
+	}
 
 	return model
 }
@@ -535,6 +682,10 @@ func toRateAdjustmentRuleResponse(rule *db.RateAdjustmentRule, appCount int64, t
 		AdjustmentValue:     rule.AdjustmentValue,
 		AdjustmentDirection: AdjustmentDirection(rule.AdjustmentDirection),
 		Priority:            rule.Priority,
+		Channels:            rule.Channels,
+		TimeWindows:         decodeTimeWindows(rule.TimeWindows),
+		ScheduleTimezone:    rule.ScheduleTimezone,
+		ExcludeHolidays:     rule.ExcludeHolidays,
 		IsActive:            rule.IsActive,
 		ApplicationCount:    appCount,
 		TotalImpact:         totalImpact,
@@ -545,6 +696,69 @@ func toRateAdjustmentRuleResponse(rule *db.RateAdjustmentRule, appCount int64, t
 	if rule.VipLevelID.Valid {
 		resp.VIPLevelID = &rule.VipLevelID.UUID
 	}
+	if rule.MinConversionAmount.Valid {
+		resp.MinConversionAmount = &rule.MinConversionAmount.String
+	}
+	if rule.MaxConversionAmount.Valid {
+		resp.MaxConversionAmount = &rule.MaxConversionAmount.String
+	}
 
 	return resp
 }
+
+func toRateChangeRequestModel(change *db.RateChangeRequest) *RateChangeRequest {
+	model := &RateChangeRequest{
+		ID:             change.ID,
+		ChangeType:     change.ChangeType,
+		SourceCurrency: change.SourceCurrency,
+		TargetCurrency: change.TargetCurrency,
+		Payload:        change.Payload,
+		Status:         change.Status,
+		RequestedBy:    change.RequestedBy,
+		CreatedAt:      change.CreatedAt,
+		UpdatedAt:      change.UpdatedAt,
+	}
+
+	if change.TargetID.Valid {
+		model.TargetID = &change.TargetID.UUID
+	}
+	if change.Summary.Valid {
+		model.Summary = &change.Summary.String
+	}
+	if change.ReviewedBy.Valid {
+		model.ReviewedBy = &change.ReviewedBy.UUID
+	}
+	if change.ReviewNote.Valid {
+		model.ReviewNote = &change.ReviewNote.String
+	}
+	if change.FailureReason.Valid {
+		model.FailureReason = &change.FailureReason.String
+	}
+	if change.ReviewedAt.Valid {
+		model.ReviewedAt = &change.ReviewedAt.Time
+	}
+	if change.AppliedAt.Valid {
+		model.AppliedAt = &change.AppliedAt.Time
+	}
+	if change.Preview.Valid {
+		var preview RateChangePreview
+		if err := json.Unmarshal(change.Preview.RawMessage, &preview); err == nil {
+			model.Preview = &preview
+		}
+	}
+
+	return model
+}
+
+func toPublicHolidayModel(h *db.PublicHoliday) *PublicHoliday {
+	model := &PublicHoliday{
+		ID:          h.ID,
+		HolidayDate: h.HolidayDate.Format("2006-01-02"),
+		Name:        h.Name,
+		CreatedAt:   h.CreatedAt,
+	}
+	if h.CreatedBy.Valid {
+		model.CreatedBy = &h.CreatedBy.UUID
+	}
+	return model
+}
@@ -373,39 +373,12 @@ func (s *Service) DeleteVIPLevel(ctx context.Context, id uuid.UUID, user *db.Use
 // RATE ADJUSTMENT RULES MANAGEMENT
 // =====================================================
 
-// CreateRateAdjustmentRule creates a new rate adjustment rule
-func (s *Service) CreateRateAdjustmentRule(ctx context.Context, req *CreateRateAdjustmentRuleRequest, user *db.User) (*RateAdjustmentRule, error) {
+// createRateAdjustmentRule creates a rule on q. It is only reached through an approved change request.
+func (s *Service) createRateAdjustmentRule(ctx context.Context, q *db.Queries, req *CreateRateAdjustmentRuleRequest, user *db.User) (*RateAdjustmentRule, error) {
 	s.logger.Info(fmt.Sprintf("Creating rate adjustment rule: %s", req.RuleName))
 
-	// Validate currency pair
-	if req.SourceCurrency == req.TargetCurrency {
-		return nil, ErrInvalidCurrencyPair
-	}
-
-	// Validate global rule constraints
-	if req.IsGlobalRule && req.VIPLevelID != nil {
-		return nil, &RateManagerError{
-			Code:    "INVALID_RULE_CONFIG",
-			Message: "Global rules cannot be associated with a VIP level",
-		}
-	}
-
-	if !req.IsGlobalRule && req.VIPLevelID == nil {
-		return nil, &RateManagerError{
-			Code:    "INVALID_RULE_CONFIG",
-			Message: "Non-global rules must be associated with a VIP level",
-		}
-	}
-
-	// Check for duplicate global rule
-	if req.IsGlobalRule {
-		existing, err := s.store.GetActiveGlobalRule(ctx, db.GetActiveGlobalRuleParams{
-			SourceCurrency: req.SourceCurrency,
-			TargetCurrency: req.TargetCurrency,
-		})
-		if err == nil && existing.ID != uuid.Nil {
-			return nil, ErrDuplicateGlobalRule
-		}
+	if err := validateRuleCreation(ctx, q, req); err != nil {
+		return nil, err
 	}
 
 	isActive := true
@@ -428,6 +401,8 @@ func (s *Service) CreateRateAdjustmentRule(ctx context.Context, req *CreateRateA
 		AdjustmentDirection: string(req.AdjustmentDirection),
 		Priority:            priority,
 		IsActive:            isActive,
+		Channels:            []string{},
+		ScheduleTimezone:    DefaultScheduleTimezone,
 		CreatedBy:           uuid.NullUUID{UUID: user.ID, Valid: true},
 		UpdatedBy:           uuid.NullUUID{UUID: user.ID, Valid: true},
 	}
@@ -450,8 +425,24 @@ func (s *Service) CreateRateAdjustmentRule(ctx context.Context, req *CreateRateA
 	if req.ValidUntil != nil {
 		params.ValidUntil = sql.NullTime{Time: *req.ValidUntil, Valid: true}
 	}
+	if req.Channels != nil {
+		params.Channels = req.Channels
+	}
+	if len(req.TimeWindows) > 0 {
+		windows, err := encodeTimeWindows(req.TimeWindows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode time windows: %w", err)
+		}
+		params.TimeWindows = windows
+	}
+	if req.ScheduleTimezone != nil {
+		params.ScheduleTimezone = *req.ScheduleTimezone
+	}
+	if req.ExcludeHolidays != nil {
+		params.ExcludeHolidays = *req.ExcludeHolidays
+	}
 
-	rule, err := s.store.CreateRateAdjustmentRule(ctx, params)
+	rule, err := q.CreateRateAdjustmentRule(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rate adjustment rule: %w", err)
 	}
@@ -474,6 +465,8 @@ func (s *Service) CreateRateAdjustmentRule(ctx context.Context, req *CreateRateA
 			"currency_pair":    fmt.Sprintf("%s/%s", req.SourceCurrency, req.TargetCurrency),
 			"adjustment_type":  req.AdjustmentType,
 			"adjustment_value": req.AdjustmentValue,
+			"channels":         params.Channels,
+			"time_windows":     req.TimeWindows,
 		},
 		Success: true,
 	})
@@ -484,6 +477,66 @@ func (s *Service) CreateRateAdjustmentRule(ctx context.Context, req *CreateRateA
 	return toRateAdjustmentRuleModel(&rule), nil
 }
 
+// validateRuleCreation rejects rule configurations that can never be applied
+func validateRuleCreation(ctx context.Context, q *db.Queries, req *CreateRateAdjustmentRuleRequest) error {
+	// Validate currency pair
+	if req.SourceCurrency == req.TargetCurrency {
+		return ErrInvalidCurrencyPair
+	}
+
+	// Validate global rule constraints
+	if req.IsGlobalRule && req.VIPLevelID != nil {
+		return &RateManagerError{
+			Code:    "INVALID_RULE_CONFIG",
+			Message: "Global rules cannot be associated with a VIP level",
+		}
+	}
+
+	if !req.IsGlobalRule && req.VIPLevelID == nil {
+		return &RateManagerError{
+			Code:    "INVALID_RULE_CONFIG",
+			Message: "Non-global rules must be associated with a VIP level",
+		}
+	}
+
+	if err := validateAmountBand(req.MinConversionAmount, req.MaxConversionAmount); err != nil {
+		return err
+	}
+	if err := validateRuleTargeting(req.Channels, req.TimeWindows, req.ScheduleTimezone); err != nil {
+		return err
+	}
+
+	// Check for duplicate global rule. Targeted global rules (by amount band, channel or
+	// time window) may overlap; the selection order decides between them.
+	untargeted := req.MinConversionAmount == nil && req.MaxConversionAmount == nil && len(req.Channels) == 0 && len(req.TimeWindows) == 0
+	if req.IsGlobalRule && untargeted {
+		existing, err := q.GetActiveGlobalRule(ctx, db.GetActiveGlobalRuleParams{
+			SourceCurrency: req.SourceCurrency,
+			TargetCurrency: req.TargetCurrency,
+		})
+		if err == nil && existing.ID != uuid.Nil {
+			return ErrDuplicateGlobalRule
+		}
+	}
+
+	return nil
+}
+
+// validateRuleUpdate rejects updates that would leave a rule with targeting that can never match
+func validateRuleUpdate(existing *db.RateAdjustmentRule, req *UpdateRateAdjustmentRuleRequest) error {
+	minAmount, maxAmount := req.MinConversionAmount, req.MaxConversionAmount
+	if minAmount == nil && existing.MinConversionAmount.Valid {
+		minAmount = &existing.MinConversionAmount.String
+	}
+	if maxAmount == nil && existing.MaxConversionAmount.Valid {
+		maxAmount = &existing.MaxConversionAmount.String
+	}
+	if err := validateAmountBand(minAmount, maxAmount); err != nil {
+		return err
+	}
+	return validateRuleTargeting(req.Channels, req.TimeWindows, req.ScheduleTimezone)
+}
+
 // GetRateAdjustmentRule retrieves a rate adjustment rule by ID
 func (s *Service) GetRateAdjustmentRule(ctx context.Context, id uuid.UUID) (*RateAdjustmentRuleResponse, error) {
 	rule, err := s.store.GetRateAdjustmentRuleByID(ctx, id)
@@ -504,12 +557,12 @@ func (s *Service) GetRateAdjustmentRule(ctx context.Context, id uuid.UUID) (*Rat
 	return toRateAdjustmentRuleResponse(&rule, impact.TotalAdjustments, fmt.Sprintf("%d", impact.TotalAdjustmentValue)), nil
 }
 
-// UpdateRateAdjustmentRule updates an existing rate adjustment rule
-func (s *Service) UpdateRateAdjustmentRule(ctx context.Context, id uuid.UUID, req *UpdateRateAdjustmentRuleRequest, user *db.User) (*RateAdjustmentRule, error) {
+// updateRateAdjustmentRule updates an existing rate adjustment rule on q. It is only reached through an approved change request.
+func (s *Service) updateRateAdjustmentRule(ctx context.Context, q *db.Queries, id uuid.UUID, req *UpdateRateAdjustmentRuleRequest, user *db.User) (*RateAdjustmentRule, error) {
 	s.logger.Info(fmt.Sprintf("Updating rate adjustment rule: %s", id))
 
 	// Get existing rule for audit
-	existing, err := s.store.GetRateAdjustmentRuleByID(ctx, id)
+	existing, err := q.GetRateAdjustmentRuleByID(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrRuleNotFound
@@ -517,6 +570,10 @@ func (s *Service) UpdateRateAdjustmentRule(ctx context.Context, id uuid.UUID, re
 		return nil, fmt.Errorf("failed to get rate adjustment rule: %w", err)
 	}
 
+	if err := validateRuleUpdate(&existing, req); err != nil {
+		return nil, err
+	}
+
 	// Build update params
 	params := db.UpdateRateAdjustmentRuleParams{
 		ID:        id,
@@ -580,6 +637,32 @@ func (s *Service) UpdateRateAdjustmentRule(ctx context.Context, id uuid.UUID, re
 		newValues["valid_until"] = *req.ValidUntil
 	}
 
+	if req.Channels != nil {
+		params.Channels = req.Channels
+		oldValues["channels"] = existing.Channels
+		newValues["channels"] = req.Channels
+	}
+
+	if req.TimeWindows != nil {
+		if params.TimeWindows, err = encodeTimeWindows(req.TimeWindows); err != nil {
+			return nil, fmt.Errorf("failed to encode time windows: %w", err)
+		}
+		oldValues["time_windows"] = decodeTimeWindows(existing.TimeWindows)
+		newValues["time_windows"] = req.TimeWindows
+	}
+
+	if req.ScheduleTimezone != nil {
+		params.ScheduleTimezone = sql.NullString{String: *req.ScheduleTimezone, Valid: true}
+		oldValues["schedule_timezone"] = existing.ScheduleTimezone
+		newValues["schedule_timezone"] = *req.ScheduleTimezone
+	}
+
+	if req.ExcludeHolidays != nil {
+		params.ExcludeHolidays = sql.NullBool{Bool: *req.ExcludeHolidays, Valid: true}
+		oldValues["exclude_holidays"] = existing.ExcludeHolidays
+		newValues["exclude_holidays"] = *req.ExcludeHolidays
+	}
+
 	if req.IsActive != nil {
 		params.IsActive = sql.NullBool{Bool: *req.IsActive, Valid: true}
 		oldValues["is_active"] = existing.IsActive
@@ -587,7 +670,7 @@ func (s *Service) UpdateRateAdjustmentRule(ctx context.Context, id uuid.UUID, re
 	}
 
 	// Update the rule
-	updated, err := s.store.UpdateRateAdjustmentRule(ctx, params)
+	updated, err := q.UpdateRateAdjustmentRule(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update rate adjustment rule: %w", err)
 	}
@@ -654,12 +737,12 @@ func (s *Service) DeleteRateAdjustmentRule(ctx context.Context, id uuid.UUID, us
 	return nil
 }
 
-// ToggleRateAdjustmentRule enables or disables a rate adjustment rule
-func (s *Service) ToggleRateAdjustmentRule(ctx context.Context, id uuid.UUID, enabled bool, user *db.User) error {
+// toggleRateAdjustmentRule enables or disables a rate adjustment rule on q. It is only reached through an approved change request.
+func (s *Service) toggleRateAdjustmentRule(ctx context.Context, q *db.Queries, id uuid.UUID, enabled bool, user *db.User) error {
 	s.logger.Info(fmt.Sprintf("Toggling rate adjustment rule: %s to %v", id, enabled))
 
 	// Get existing rule
-	existing, err := s.store.GetRateAdjustmentRuleByID(ctx, id)
+	existing, err := q.GetRateAdjustmentRuleByID(ctx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return ErrRuleNotFound
@@ -668,7 +751,7 @@ func (s *Service) ToggleRateAdjustmentRule(ctx context.Context, id uuid.UUID, en
 	}
 
 	// Update status
-	_, err = s.store.ToggleRateAdjustmentRule(ctx, db.ToggleRateAdjustmentRuleParams{
+	_, err = q.ToggleRateAdjustmentRule(ctx, db.ToggleRateAdjustmentRuleParams{
 		ID:        id,
 		IsActive:  enabled,
 		UpdatedBy: uuid.NullUUID{UUID: user.ID, Valid: true},
@@ -943,8 +1026,9 @@ const (
 	RateSourceManual          RateSourcePreference = "manual"
 )
 
-// SetRateSourcePreference sets whether to use manual or exchange service rates for a currency pair
-func (s *Service) SetRateSourcePreference(ctx context.Context, currencyPair string, preference string) error {
+// setRateSourcePreference sets whether to use manual or exchange service rates for a currency pair.
+// It is only reached through an approved change request.
+func (s *Service) setRateSourcePreference(ctx context.Context, currencyPair string, preference string) error {
 	// Validate preference
 	if preference != string(RateSourceExchangeService) && preference != string(RateSourceManual) {
 		return fmt.Errorf("invalid rate source preference: %s", preference)
@@ -981,9 +1065,35 @@ func (s *Service) GetRateSourcePreference(ctx context.Context, currencyPair stri
 // RATE CALCULATION WITH ADJUSTMENTS (UPDATED)
 // =====================================================
 
-// GetAdjustedRateForUser calculates the adjusted rate for a specific user
-func (s *Service) GetAdjustedRateForUser(ctx context.Context, userID uuid.UUID, from, to string, amount string) (*RateSimulationResponse, error) {
-	s.logger.Info(fmt.Sprintf("Calculating adjusted rate for user %s: %s to %s, amount: %s", userID, from, to, amount))
+// GetAdjustedRateForUser calculates the adjusted rate for a specific user quoting on channel.
+// The response explains which rule was applied and why the others were not.
+func (s *Service) GetAdjustedRateForUser(ctx context.Context, userID uuid.UUID, from, to string, amount string, channel RateChannel) (*RateSimulationResponse, error) {
+	if !channel.IsValid() {
+		channel = ChannelApp
+	}
+	return s.adjustedRateForUser(ctx, userID, from, to, RateContext{Amount: amount, Channel: channel, At: time.Now()}, true)
+}
+
+// ExplainRateForUser evaluates the user's rules for a hypothetical quote, optionally at another
+// time, without recording a rate change
+func (s *Service) ExplainRateForUser(ctx context.Context, req ExplainRateRequest) (*RateSimulationResponse, error) {
+	channel := RateChannel(req.Channel)
+	if req.Channel == "" {
+		channel = ChannelApp
+	}
+	if !channel.IsValid() {
+		return nil, &RateManagerError{Code: "INVALID_CHANNEL", Message: fmt.Sprintf("Unknown channel %q: use app, rapid_ramp or smart_conversion", req.Channel)}
+	}
+	at := time.Now()
+	if req.At != nil {
+		at = *req.At
+	}
+	return s.adjustedRateForUser(ctx, req.UserID, req.SourceCurrency, req.TargetCurrency, RateContext{Amount: req.Amount, Channel: channel, At: at}, false)
+}
+
+func (s *Service) adjustedRateForUser(ctx context.Context, userID uuid.UUID, from, to string, rc RateContext, record bool) (*RateSimulationResponse, error) {
+	amount := rc.Amount
+	s.logger.Info(fmt.Sprintf("Calculating adjusted rate for user %s: %s to %s, amount: %s, channel: %s", userID, from, to, amount, rc.Channel))
 
 	currencyPair := fmt.Sprintf("%s/%s", from, to)
 	rateSourcePref := s.GetRateSourcePreference(ctx, currencyPair)
@@ -1009,36 +1119,46 @@ func (s *Service) GetAdjustedRateForUser(ctx context.Context, userID uuid.UUID,
 		return nil, fmt.Errorf("failed to get base rate: %w", err)
 	}
 
-	// Get applicable rule for user
-	applicableRule, err := s.store.GetApplicableRulesForUser(ctx, db.GetApplicableRulesForUserParams{
-		SourceCurrency:      from,
-		TargetCurrency:      to,
-		MinConversionAmount: sql.NullString{String: amount, Valid: true},
-		UserID:              userID,
+	// Get the user's candidate rules and pick the one that applies
+	candidates, err := s.store.GetApplicableRulesForUser(ctx, db.GetApplicableRulesForUserParams{
+		SourceCurrency: from,
+		TargetCurrency: to,
+		UserID:         userID,
 	})
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to load rate rules for user %s: %v", userID, err))
+	}
+	rule, explanation := s.selectRule(ctx, candidates, rc)
 
-	s.logger.Infof("Applicable rule for user %s: %s -> %s, amount: %s", userID, from, to, amount)
-	s.logger.Infof("Applicable rule name : %v", applicableRule)
+	s.logger.Infof("Rate rule selection for user %s: %s -> %s, amount: %s: %s", userID, from, to, amount, explanation.Reason)
 
 	var adjustedRate decimal.Decimal
 	var adjustmentAmount decimal.Decimal
 	var vipLevelApplied *string
 	var ruleApplied *string
+	var vipLevelID *uuid.UUID
+	var ruleID *uuid.UUID
 
-	if err == nil && len(applicableRule) > 0 {
+	if rule != nil {
 		// Apply the rule
-		rule := applicableRule[0]
 		adjustedRate, adjustmentAmount = s.applyRateAdjustment(baseRate.Rate, rule.AdjustmentType, rule.AdjustmentValue, rule.AdjustmentDirection)
 
 		if rule.VipLevelName.Valid {
 			levelName := rule.VipLevelName.String
 			vipLevelApplied = &levelName
 		}
+		if rule.VipLevelID.Valid {
+			levelID := rule.VipLevelID.UUID
+			vipLevelID = &levelID
+		}
 		ruleName := rule.RuleName
 		ruleApplied = &ruleName
+		ruleID = &rule.ID
 
 		// Record rate change
-		go s.recordRateChange(context.Background(), baseRate, adjustedRate, adjustmentAmount, &rule, &userID, nil)
+		if record {
+			go s.recordRateChange(context.Background(), baseRate, adjustedRate, adjustmentAmount, rule, &userID, nil)
+		}
 	} else {
 		// No adjustment - use base rate
 		adjustedRate = baseRate.Rate
@@ -1067,7 +1187,10 @@ func (s *Service) GetAdjustedRateForUser(ctx context.Context, userID uuid.UUID,
 		NetAmount:           netAmount.String(),
 		RateProvider:        rateSource,
 		VIPLevelApplied:     vipLevelApplied,
+		VIPLevelID:          vipLevelID,
 		RuleApplied:         ruleApplied,
+		RuleID:              ruleID,
+		Explanation:         explanation,
 		SimulationTimestamp: time.Now(),
 	}, nil
 }
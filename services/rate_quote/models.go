@@ -0,0 +1,105 @@
+package ratequote
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	PurposeConversion = "conversion"
+	PurposeRamp       = "ramp"
+
+	StatusActive  = "active"
+	StatusUsed    = "used"
+	StatusExpired = "expired"
+
+	// ConversionQuoteTTL is how long a wallet-to-wallet conversion quote can be executed
+	ConversionQuoteTTL = 30 * time.Second
+	// RampQuoteTTL is the window in which the crypto payment for a ramp quote must arrive
+	RampQuoteTTL = 15 * time.Minute
+)
+
+// Quote is the client-facing view of a locked rate quote
+type Quote struct {
+	QuoteID        string          `json:"quote_id"`
+	UserID         uuid.UUID       `json:"user_id"`
+	Purpose        string          `json:"purpose"`
+	SourceCurrency string          `json:"source_currency"`
+	TargetCurrency string          `json:"target_currency"`
+	SourceAmount   decimal.Decimal `json:"source_amount"`
+	Rate           decimal.Decimal `json:"rate"`
+	Fees           decimal.Decimal `json:"fees"`
+	TargetAmount   decimal.Decimal `json:"target_amount"`
+	NetAmount      decimal.Decimal `json:"net_amount"`
+	RateProvider   string          `json:"rate_provider,omitempty"`
+	Status         string          `json:"status"`
+	QRCodeID       *uuid.UUID      `json:"qr_code_id,omitempty"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+	TTLSeconds     int64           `json:"ttl_seconds"`
+	UsedAt         *time.Time      `json:"used_at,omitempty"`
+	Reference      *string         `json:"reference,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// IssueParams are the priced terms a quote locks in
+type IssueParams struct {
+	UserID         uuid.UUID
+	Purpose        string
+	SourceCurrency string
+	TargetCurrency string
+	SourceAmount   decimal.Decimal
+	Rate           decimal.Decimal
+	Fees           decimal.Decimal
+	TargetAmount   decimal.Decimal
+	NetAmount      decimal.Decimal
+	RateProvider   string
+	TTL            time.Duration
+	// MidRate is the market rate Rate was derived from; with the rule and VIP level that priced
+	// the quote it is kept for revenue attribution. Zero MidRate means unknown.
+	MidRate      decimal.Decimal
+	RuleID       *uuid.UUID
+	RuleName     *string
+	VIPLevelID   *uuid.UUID
+	VIPLevelName *string
+}
+
+// Terms are the locked values of a verified quote, parsed for execution
+type Terms struct {
+	ID             uuid.UUID
+	QuoteID        string
+	SourceCurrency string
+	TargetCurrency string
+	SourceAmount   decimal.Decimal
+	Rate           decimal.Decimal
+	Fees           decimal.Decimal
+	TargetAmount   decimal.Decimal
+	NetAmount      decimal.Decimal
+	RateProvider   string
+	ExpiresAt      time.Time
+	// pricing the quote was issued with, for revenue attribution; MidRate is zero when unknown
+	MidRate      decimal.Decimal
+	RuleID       *uuid.UUID
+	RuleName     *string
+	VIPLevelID   *uuid.UUID
+	VIPLevelName *string
+}
+
+type QuoteError struct {
+	Code    string
+	Message string
+}
+
+func (e *QuoteError) Error() string {
+	return e.Message
+}
+
+var (
+	ErrQuoteNotFound    = &QuoteError{Code: "QUOTE_NOT_FOUND", Message: "Quote not found"}
+	ErrQuoteInvalid     = &QuoteError{Code: "QUOTE_INVALID", Message: "Quote ID is invalid"}
+	ErrQuoteExpired     = &QuoteError{Code: "QUOTE_EXPIRED", Message: "Quote has expired, request a new quote"}
+	ErrQuoteUsed        = &QuoteError{Code: "QUOTE_USED", Message: "Quote has already been used"}
+	ErrQuoteMismatch    = &QuoteError{Code: "QUOTE_MISMATCH", Message: "Request does not match the quoted terms"}
+	ErrQuoteUnavailable = &QuoteError{Code: "QUOTE_UNAVAILABLE", Message: "Quote has expired or was already used"}
+)
@@ -0,0 +1,350 @@
+package ratequote
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Service issues signed, single-use quotes that lock a rate, fee and net amount for a short
+// window. Quotes are persisted so the terms a user accepted can be produced in a dispute.
+type Service struct {
+	store      *db.Store
+	signingKey []byte
+	logger     *logging.Logger
+}
+
+func NewService(store *db.Store, signingKey string, logger *logging.Logger) *Service {
+	return &Service{
+		store:      store,
+		signingKey: []byte(signingKey),
+		logger:     logger,
+	}
+}
+
+// Issue stores a new quote and returns it with its signed quote ID
+func (s *Service) Issue(ctx context.Context, params IssueParams) (*Quote, error) {
+	if params.TTL <= 0 {
+		params.TTL = ConversionQuoteTTL
+	}
+
+	// round to the column scales up front so the signed values are exactly what is stored
+	quote := db.RateQuote{
+		ID:             uuid.New(),
+		UserID:         params.UserID,
+		Purpose:        params.Purpose,
+		SourceCurrency: params.SourceCurrency,
+		TargetCurrency: params.TargetCurrency,
+		SourceAmount:   params.SourceAmount.Round(8).String(),
+		Rate:           params.Rate.Round(10).String(),
+		Fees:           params.Fees.Round(8).String(),
+		TargetAmount:   params.TargetAmount.Round(8).String(),
+		NetAmount:      params.NetAmount.Round(8).String(),
+		RateProvider:   params.RateProvider,
+		ExpiresAt:      time.Now().Add(params.TTL).Truncate(time.Microsecond),
+	}
+	quote.Signature = s.sign(&quote)
+
+	stored, err := s.store.CreateRateQuote(ctx, db.CreateRateQuoteParams{
+		ID:             quote.ID,
+		UserID:         quote.UserID,
+		Purpose:        quote.Purpose,
+		SourceCurrency: quote.SourceCurrency,
+		TargetCurrency: quote.TargetCurrency,
+		SourceAmount:   quote.SourceAmount,
+		Rate:           quote.Rate,
+		Fees:           quote.Fees,
+		TargetAmount:   quote.TargetAmount,
+		NetAmount:      quote.NetAmount,
+		RateProvider:   quote.RateProvider,
+		Signature:      quote.Signature,
+		ExpiresAt:      quote.ExpiresAt,
+		MidRate:        sql.NullString{String: params.MidRate.Round(10).String(), Valid: params.MidRate.IsPositive()},
+		RuleID:         nullUUID(params.RuleID),
+		RuleName:       nullString(params.RuleName),
+		VipLevelID:     nullUUID(params.VIPLevelID),
+		VipLevelName:   nullString(params.VIPLevelName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store quote: %w", err)
+	}
+
+	s.logger.Infof("issued %s quote %s for user %s: %s %s -> %s at %s",
+		stored.Purpose, stored.ID, stored.UserID, stored.SourceAmount, stored.SourceCurrency, stored.TargetCurrency, stored.Rate)
+
+	return s.toQuote(&stored), nil
+}
+
+// Resolve verifies a client-supplied quote ID and returns its locked terms. It does not consume
+// the quote; callers consume it with Consume inside the transaction that executes it.
+func (s *Service) Resolve(ctx context.Context, quoteID string, userID uuid.UUID, purpose string) (*Terms, error) {
+	quote, err := s.lookup(ctx, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.UserID != userID {
+		return nil, ErrQuoteNotFound
+	}
+	if quote.Purpose != purpose {
+		return nil, ErrQuoteMismatch
+	}
+
+	switch quote.Status {
+	case StatusUsed:
+		return nil, ErrQuoteUsed
+	case StatusExpired:
+		return nil, ErrQuoteExpired
+	}
+
+	if time.Now().After(quote.ExpiresAt) {
+		if err := s.Expire(ctx, quote.ID); err != nil {
+			s.logger.Warnf("failed to mark quote %s expired: %v", quote.ID, err)
+		}
+		return nil, ErrQuoteExpired
+	}
+
+	return s.toTerms(quote)
+}
+
+// ActiveQuoteForQRCode returns the unused ramp quote bound to a QR code, or nil when there is none
+func (s *Service) ActiveQuoteForQRCode(ctx context.Context, qrCodeID uuid.UUID) (*Terms, error) {
+	quote, err := s.store.GetActiveRateQuoteForQRCode(ctx, uuid.NullUUID{UUID: qrCodeID, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get quote for QR code: %w", err)
+	}
+	if !hmac.Equal([]byte(s.sign(&quote)), []byte(quote.Signature)) {
+		return nil, ErrQuoteInvalid
+	}
+	return s.toTerms(&quote)
+}
+
+// Consume marks a quote used by the given transaction reference. validAt is the moment the
+// quote had to be honoured by (now for conversions, payment receipt for ramps). Pass the
+// queries of the executing transaction so the quote is only spent if the execution commits.
+func (s *Service) Consume(ctx context.Context, q *db.Queries, id uuid.UUID, reference string, validAt time.Time) error {
+	if q == nil {
+		q = s.store.Queries
+	}
+	rows, err := q.MarkRateQuoteUsed(ctx, db.MarkRateQuoteUsedParams{
+		ID:                id,
+		ConsumedReference: sql.NullString{String: reference, Valid: reference != ""},
+		ValidAt:           validAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to consume quote: %w", err)
+	}
+	if rows == 0 {
+		return ErrQuoteUnavailable
+	}
+	return nil
+}
+
+// Expire retires an active quote that can no longer be honoured
+func (s *Service) Expire(ctx context.Context, id uuid.UUID) error {
+	return s.store.ExpireRateQuote(ctx, id)
+}
+
+// BindToQRCode attaches an active ramp quote to the QR code whose payment it will price
+func (s *Service) BindToQRCode(ctx context.Context, id uuid.UUID, qrCodeID uuid.UUID) error {
+	rows, err := s.store.BindRateQuoteToQRCode(ctx, db.BindRateQuoteToQRCodeParams{
+		ID:       id,
+		QrCodeID: uuid.NullUUID{UUID: qrCodeID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to bind quote: %w", err)
+	}
+	if rows == 0 {
+		return ErrQuoteUnavailable
+	}
+	return nil
+}
+
+// Get returns a stored quote by its quote ID or bare UUID, whatever its status
+func (s *Service) Get(ctx context.Context, quoteID string) (*Quote, error) {
+	if !strings.Contains(quoteID, ".") {
+		id, err := uuid.Parse(quoteID)
+		if err != nil {
+			return nil, ErrQuoteInvalid
+		}
+		quote, err := s.store.GetRateQuote(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrQuoteNotFound
+			}
+			return nil, fmt.Errorf("failed to get quote: %w", err)
+		}
+		return s.toQuote(&quote), nil
+	}
+
+	quote, err := s.lookup(ctx, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toQuote(quote), nil
+}
+
+// lookup loads the quote behind a signed quote ID and checks the signature
+func (s *Service) lookup(ctx context.Context, quoteID string) (*db.RateQuote, error) {
+	idPart, signature, ok := strings.Cut(quoteID, ".")
+	if !ok {
+		return nil, ErrQuoteInvalid
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return nil, ErrQuoteInvalid
+	}
+
+	quote, err := s.store.GetRateQuote(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrQuoteNotFound
+		}
+		return nil, fmt.Errorf("failed to get quote: %w", err)
+	}
+
+	// the presented signature must match the stored one, and the stored one must still match
+	// the stored terms so a tampered row is never honoured
+	if !hmac.Equal([]byte(signature), []byte(quote.Signature)) ||
+		!hmac.Equal([]byte(s.sign(&quote)), []byte(quote.Signature)) {
+		return nil, ErrQuoteInvalid
+	}
+
+	return &quote, nil
+}
+
+// sign computes the HMAC over every locked term of a quote
+func (s *Service) sign(quote *db.RateQuote) string {
+	payload := strings.Join([]string{
+		"rate-quote",
+		quote.ID.String(),
+		quote.UserID.String(),
+		quote.Purpose,
+		quote.SourceCurrency,
+		quote.TargetCurrency,
+		normalize(quote.SourceAmount),
+		normalize(quote.Rate),
+		normalize(quote.Fees),
+		normalize(quote.TargetAmount),
+		normalize(quote.NetAmount),
+		fmt.Sprint(quote.ExpiresAt.UnixMicro()),
+	}, "|")
+
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// normalize drops the trailing zeros NUMERIC columns pad values with
+func normalize(value string) string {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return value
+	}
+	return d.String()
+}
+
+func (s *Service) toTerms(quote *db.RateQuote) (*Terms, error) {
+	values := make([]decimal.Decimal, 5)
+	for i, raw := range []string{quote.SourceAmount, quote.Rate, quote.Fees, quote.TargetAmount, quote.NetAmount} {
+		d, err := decimal.NewFromString(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quote value %q: %w", raw, err)
+		}
+		values[i] = d
+	}
+
+	terms := &Terms{
+		ID:             quote.ID,
+		QuoteID:        quote.ID.String() + "." + quote.Signature,
+		SourceCurrency: quote.SourceCurrency,
+		TargetCurrency: quote.TargetCurrency,
+		SourceAmount:   values[0],
+		Rate:           values[1],
+		Fees:           values[2],
+		TargetAmount:   values[3],
+		NetAmount:      values[4],
+		RateProvider:   quote.RateProvider,
+		ExpiresAt:      quote.ExpiresAt,
+	}
+	if quote.MidRate.Valid {
+		terms.MidRate, _ = decimal.NewFromString(quote.MidRate.String)
+	}
+	if quote.RuleID.Valid {
+		terms.RuleID = &quote.RuleID.UUID
+	}
+	if quote.RuleName.Valid {
+		terms.RuleName = &quote.RuleName.String
+	}
+	if quote.VipLevelID.Valid {
+		terms.VIPLevelID = &quote.VipLevelID.UUID
+	}
+	if quote.VipLevelName.Valid {
+		terms.VIPLevelName = &quote.VipLevelName.String
+	}
+
+	return terms, nil
+}
+
+func (s *Service) toQuote(quote *db.RateQuote) *Quote {
+	result := &Quote{
+		QuoteID:        quote.ID.String() + "." + quote.Signature,
+		UserID:         quote.UserID,
+		Purpose:        quote.Purpose,
+		SourceCurrency: quote.SourceCurrency,
+		TargetCurrency: quote.TargetCurrency,
+		SourceAmount:   decimal.RequireFromString(quote.SourceAmount),
+		Rate:           decimal.RequireFromString(quote.Rate),
+		Fees:           decimal.RequireFromString(quote.Fees),
+		TargetAmount:   decimal.RequireFromString(quote.TargetAmount),
+		NetAmount:      decimal.RequireFromString(quote.NetAmount),
+		RateProvider:   quote.RateProvider,
+		Status:         quote.Status,
+		ExpiresAt:      quote.ExpiresAt,
+		CreatedAt:      quote.CreatedAt,
+	}
+
+	if quote.Status == StatusActive {
+		if remaining := time.Until(quote.ExpiresAt); remaining > 0 {
+			result.TTLSeconds = int64(remaining.Seconds())
+		}
+	}
+	if quote.QrCodeID.Valid {
+		result.QRCodeID = &quote.QrCodeID.UUID
+	}
+	if quote.UsedAt.Valid {
+		result.UsedAt = &quote.UsedAt.Time
+	}
+	if quote.ConsumedReference.Valid {
+		result.Reference = &quote.ConsumedReference.String
+	}
+
+	return result
+}
+
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
@@ -0,0 +1,149 @@
+package recurrence
+
+import (
+	"sync"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Calendar reports the non-working days a schedule can skip
+type Calendar interface {
+	// HolidayOn returns the holiday name when the calendar date of day is a holiday
+	HolidayOn(day time.Time) (string, bool)
+}
+
+// HolidayCalendar combines holidays that can be computed for any year with dates
+// declared at runtime, such as the Islamic holidays the government announces
+// once the moon is sighted.
+type HolidayCalendar struct {
+	mu       sync.RWMutex
+	rules    func(year int) map[string]string
+	years    map[int]map[string]string
+	declared map[string]string
+}
+
+// NewHolidayCalendar creates a calendar from a function that lists a year's
+// computable holidays keyed by YYYY-MM-DD. rules may be nil.
+func NewHolidayCalendar(rules func(year int) map[string]string) *HolidayCalendar {
+	return &HolidayCalendar{
+		rules:    rules,
+		years:    map[int]map[string]string{},
+		declared: map[string]string{},
+	}
+}
+
+// NigerianHolidays is the federal public holiday calendar of Nigeria. Declared
+// holidays are loaded from the public_holidays table by the services that use it.
+var NigerianHolidays = NewHolidayCalendar(nigerianHolidays)
+
+// HolidayOn implements Calendar
+func (c *HolidayCalendar) HolidayOn(day time.Time) (string, bool) {
+	key := day.Format(dateLayout)
+
+	c.mu.RLock()
+	if name, ok := c.declared[key]; ok {
+		c.mu.RUnlock()
+		return name, true
+	}
+	year, cached := c.years[day.Year()]
+	c.mu.RUnlock()
+
+	if !cached {
+		if c.rules == nil {
+			return "", false
+		}
+		year = c.rules(day.Year())
+		c.mu.Lock()
+		c.years[day.Year()] = year
+		c.mu.Unlock()
+	}
+
+	name, ok := year[key]
+	return name, ok
+}
+
+// SetDeclared replaces the runtime-declared holidays, keyed by YYYY-MM-DD
+func (c *HolidayCalendar) SetDeclared(holidays map[string]string) {
+	declared := make(map[string]string, len(holidays))
+	for k, v := range holidays {
+		declared[k] = v
+	}
+
+	c.mu.Lock()
+	c.declared = declared
+	c.mu.Unlock()
+}
+
+// IsBusinessDay reports whether day is a weekday and, when cal is set, not a holiday
+func IsBusinessDay(day time.Time, cal Calendar) bool {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	if cal != nil {
+		if _, ok := cal.HolidayOn(day); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// nigerianHolidays lists the fixed-date and Easter holidays of the Public
+// Holidays Act. A holiday on a weekend is observed on the next free working day.
+func nigerianHolidays(year int) map[string]string {
+	easter := easterSunday(year)
+	fixed := []struct {
+		date time.Time
+		name string
+	}{
+		{time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), "New Year's Day"},
+		{easter.AddDate(0, 0, -2), "Good Friday"},
+		{easter.AddDate(0, 0, 1), "Easter Monday"},
+		{time.Date(year, time.May, 1, 0, 0, 0, 0, time.UTC), "Workers' Day"},
+		{time.Date(year, time.June, 12, 0, 0, 0, 0, time.UTC), "Democracy Day"},
+		{time.Date(year, time.October, 1, 0, 0, 0, 0, time.UTC), "Independence Day"},
+		{time.Date(year, time.December, 25, 0, 0, 0, 0, time.UTC), "Christmas Day"},
+		{time.Date(year, time.December, 26, 0, 0, 0, 0, time.UTC), "Boxing Day"},
+	}
+
+	holidays := make(map[string]string, len(fixed)+2)
+	for _, h := range fixed {
+		holidays[h.date.Format(dateLayout)] = h.name
+	}
+
+	for _, h := range fixed {
+		if h.date.Weekday() != time.Saturday && h.date.Weekday() != time.Sunday {
+			continue
+		}
+		observed := h.date.AddDate(0, 0, 1)
+		for {
+			_, taken := holidays[observed.Format(dateLayout)]
+			if !taken && observed.Weekday() != time.Saturday && observed.Weekday() != time.Sunday {
+				break
+			}
+			observed = observed.AddDate(0, 0, 1)
+		}
+		holidays[observed.Format(dateLayout)] = h.name + " (observed)"
+	}
+
+	return holidays
+}
+
+// easterSunday computes Western Easter with the anonymous Gregorian algorithm
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,270 @@
+// Package recurrence computes execution times for recurring schedules: daily,
+// weekly and monthly intervals, standard cron expressions, the last business day
+// of a month, and business-day shifting around weekends and public holidays, all
+// evaluated in an IANA timezone.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Frequency is how often a schedule recurs
+type Frequency string
+
+const (
+	Daily   Frequency = "daily"
+	Weekly  Frequency = "weekly"
+	Monthly Frequency = "monthly"
+	Cron    Frequency = "cron"
+)
+
+// MaxPreview caps how many upcoming executions Preview returns
+const MaxPreview = 24
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule describes when something recurs.
+//
+// Daily, weekly and monthly occurrences that land on a skipped day move forward to
+// the next business day, the way payroll does, so "monthly on the 1st, skipping
+// holidays" pays on 2 January. Cron occurrences on a skipped day are dropped
+// instead, since a cron expression already names the exact days it wants.
+type Schedule struct {
+	Frequency Frequency
+
+	// Cron is a five-field cron expression, required when Frequency is Cron
+	Cron string
+
+	// DayOfWeek is 0 (Sunday) to 6 (Saturday) for weekly schedules. When nil the
+	// schedule repeats on the weekday it is evaluated from.
+	DayOfWeek *int
+
+	// DayOfMonth is 1-31, or -1 for the last day, for monthly schedules. Days past
+	// the end of a short month fall on its last day. Nil means the 1st.
+	DayOfMonth *int
+
+	// LastBusinessDay runs a monthly schedule on the last weekday of the month that
+	// is not a holiday, ignoring DayOfMonth
+	LastBusinessDay bool
+
+	// TimeOfDay is HH:MM in 24-hour format; empty means midnight. Cron schedules
+	// carry their own time and ignore it.
+	TimeOfDay string
+
+	// Timezone is an IANA zone such as Africa/Lagos; empty means server time
+	Timezone string
+
+	SkipWeekends bool
+	SkipHolidays bool
+
+	// Calendar supplies holidays; nil means NigerianHolidays
+	Calendar Calendar
+}
+
+// Validate checks the schedule can be evaluated
+func (s Schedule) Validate() error {
+	switch s.Frequency {
+	case Daily, Weekly, Monthly:
+		if s.Cron != "" {
+			return fmt.Errorf("%w: cron expression is only used with the cron frequency", ErrInvalidSchedule)
+		}
+	case Cron:
+		if s.Cron == "" {
+			return fmt.Errorf("%w: cron expression is required", ErrInvalidSchedule)
+		}
+		if _, err := ParseCron(s.Cron); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unknown frequency %q", ErrInvalidSchedule, s.Frequency)
+	}
+
+	if s.DayOfWeek != nil && (*s.DayOfWeek < 0 || *s.DayOfWeek > 6) {
+		return fmt.Errorf("%w: day of week must be 0-6", ErrInvalidSchedule)
+	}
+	if s.DayOfMonth != nil && *s.DayOfMonth != -1 && (*s.DayOfMonth < 1 || *s.DayOfMonth > 31) {
+		return fmt.Errorf("%w: day of month must be 1-31 or -1", ErrInvalidSchedule)
+	}
+	if s.LastBusinessDay && s.Frequency != Monthly {
+		return fmt.Errorf("%w: last business day only applies to monthly schedules", ErrInvalidSchedule)
+	}
+	if _, _, err := s.clock(); err != nil {
+		return err
+	}
+	if _, err := s.Location(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Location resolves Timezone
+func (s Schedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidSchedule, s.Timezone)
+	}
+	return loc, nil
+}
+
+// Next returns the first execution strictly after the given time
+func (s Schedule) Next(after time.Time) (time.Time, error) {
+	if err := s.Validate(); err != nil {
+		return time.Time{}, err
+	}
+	loc, _ := s.Location()
+
+	if s.Frequency == Cron {
+		expr, _ := ParseCron(s.Cron)
+		t := after
+		// Bounded so an expression that only matches skipped days cannot spin forever
+		for i := 0; i < 1000; i++ {
+			t = expr.Next(t, loc)
+			if t.IsZero() {
+				break
+			}
+			if !s.skipped(t) {
+				return t, nil
+			}
+			// every occurrence that day is skipped too
+			t = time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, loc)
+		}
+		return time.Time{}, fmt.Errorf("%w: cron expression %q has no upcoming executions", ErrInvalidSchedule, s.Cron)
+	}
+
+	// Start far enough back that an occurrence shifted past a run of weekends and
+	// holidays (Christmas, Boxing Day and their observed days) is still seen
+	t := after.In(loc).AddDate(0, 0, -10)
+	anchor := after.In(loc).Weekday()
+	for i := 0; i < 1000; i++ {
+		t = s.nextRaw(t, loc, anchor)
+		shifted := s.shift(t)
+		if shifted.After(after) {
+			return shifted, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: no upcoming executions", ErrInvalidSchedule)
+}
+
+// Preview returns the next n executions after the given time
+func (s Schedule) Preview(after time.Time, n int) ([]time.Time, error) {
+	if n <= 0 {
+		n = 1
+	}
+	if n > MaxPreview {
+		n = MaxPreview
+	}
+
+	times := make([]time.Time, 0, n)
+	t := after
+	for len(times) < n {
+		next, err := s.Next(t)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, next)
+		t = next
+	}
+	return times, nil
+}
+
+func (s Schedule) calendar() Calendar {
+	if s.Calendar != nil {
+		return s.Calendar
+	}
+	return NigerianHolidays
+}
+
+func (s Schedule) clock() (int, int, error) {
+	if s.TimeOfDay == "" {
+		return 0, 0, nil
+	}
+	t, err := time.Parse("15:04", s.TimeOfDay)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: time of day must be HH:MM", ErrInvalidSchedule)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// nextRaw returns the first unshifted occurrence after t
+func (s Schedule) nextRaw(t time.Time, loc *time.Location, anchor time.Weekday) time.Time {
+	hour, minute, _ := s.clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, minute, 0, 0, loc)
+	}
+
+	switch s.Frequency {
+	case Weekly:
+		target := anchor
+		if s.DayOfWeek != nil {
+			target = time.Weekday(*s.DayOfWeek)
+		}
+		days := (int(target) - int(t.Weekday()) + 7) % 7
+		candidate := at(t.Year(), t.Month(), t.Day()+days)
+		if !candidate.After(t) {
+			candidate = at(t.Year(), t.Month(), t.Day()+days+7)
+		}
+		return candidate
+
+	case Monthly:
+		for offset := 0; ; offset++ {
+			first := time.Date(t.Year(), t.Month()+time.Month(offset), 1, 0, 0, 0, 0, loc)
+			candidate := at(first.Year(), first.Month(), s.monthDay(first))
+			if candidate.After(t) {
+				return candidate
+			}
+		}
+
+	default:
+		candidate := at(t.Year(), t.Month(), t.Day())
+		if !candidate.After(t) {
+			candidate = at(t.Year(), t.Month(), t.Day()+1)
+		}
+		return candidate
+	}
+}
+
+// monthDay picks the execution day in the month that starts on first
+func (s Schedule) monthDay(first time.Time) int {
+	last := first.AddDate(0, 1, -1)
+	if s.LastBusinessDay {
+		day := last
+		for !IsBusinessDay(day, s.calendar()) && day.Day() > 1 {
+			day = day.AddDate(0, 0, -1)
+		}
+		return day.Day()
+	}
+
+	if s.DayOfMonth == nil {
+		return 1
+	}
+	if *s.DayOfMonth == -1 || *s.DayOfMonth > last.Day() {
+		return last.Day()
+	}
+	return *s.DayOfMonth
+}
+
+// skipped reports whether t falls on a day the schedule avoids
+func (s Schedule) skipped(t time.Time) bool {
+	if s.SkipWeekends && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+		return true
+	}
+	if s.SkipHolidays {
+		if _, ok := s.calendar().HolidayOn(t); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// shift moves t forward a day at a time, keeping its clock time, until it is not skipped
+func (s Schedule) shift(t time.Time) time.Time {
+	for i := 0; i < 31 && s.skipped(t); i++ {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, t.Hour(), t.Minute(), 0, 0, t.Location())
+	}
+	return t
+}
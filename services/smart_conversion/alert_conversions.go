@@ -0,0 +1,339 @@
+package smartconversion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
+	ratemanager "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_manager"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	alertTriggerType = "price_alert"
+
+	AlertRunExecuted = "executed"
+	AlertRunFailed   = "failed"
+
+	alertFailInsufficientFunds = "insufficient_balance"
+	alertFailAmountLimit       = "amount_limit_exceeded"
+	alertFailDailyLimit        = "daily_limit_exceeded"
+	alertFailRateUnavailable   = "rate_unavailable"
+	alertFailAccountInactive   = "account_inactive"
+	alertFailKYCTier           = "kyc_tier_2_required"
+	alertFailConversion        = "conversion_failed"
+)
+
+// AlertConversionConfig limits the conversions price alerts run on the user's behalf. Values are
+// read from the environment and fall back to defaults.
+type AlertConversionConfig struct {
+	// USD value allowed in a single alert conversion
+	MaxAmountUSD float64 `mapstructure:"ALERT_CONVERSION_MAX_USD"`
+	// USD value of a user's executed alert conversions allowed over 24 hours
+	DailyLimitUSD float64 `mapstructure:"ALERT_CONVERSION_DAILY_LIMIT_USD"`
+}
+
+func LoadAlertConversionConfig() AlertConversionConfig {
+	var c AlertConversionConfig
+	if err := utils.LoadCustomConfig(utils.EnvPath, &c); err != nil {
+		c = AlertConversionConfig{}
+	}
+	if c.MaxAmountUSD <= 0 {
+		c.MaxAmountUSD = 5000
+	}
+	if c.DailyLimitUSD <= 0 {
+		c.DailyLimitUSD = 10000
+	}
+	return c
+}
+
+// AlertConversionTerms are the wallets and amount of a validated conversion action
+type AlertConversionTerms struct {
+	SourceWalletID uuid.UUID
+	TargetWalletID uuid.UUID
+	Amount         decimal.Decimal
+}
+
+// AlertConversion is a triggered alert's conversion action
+type AlertConversion struct {
+	Action      db.AlertConversionAction
+	TriggerID   int64
+	TriggerRate decimal.Decimal
+	// alert name used in notifications
+	Label string
+}
+
+// alertRun ties a conversion to the alert trigger it runs for
+type alertRun struct {
+	conversion *AlertConversion
+	amountUsd  decimal.Decimal
+}
+
+// errAlertDailyLimit is returned from inside the conversion transaction when concurrent alert
+// conversions have used up the daily limit since it was first checked
+var errAlertDailyLimit = &ConversionError{Code: "ALERT_DAILY_LIMIT", Message: "Alert conversion daily limit reached"}
+
+func invalidAlertAction(message string) error {
+	return &ConversionError{Code: "INVALID_ALERT_ACTION", Message: message}
+}
+
+// AuthorizeAlertConversion checks a conversion action before it is attached to an alert and
+// resolves the user's wallets for it
+func (s *ConversionService) AuthorizeAlertConversion(ctx context.Context, userID uuid.UUID, sourceCurrency, targetCurrency, amount string) (*AlertConversionTerms, error) {
+	if sourceCurrency == targetCurrency {
+		return nil, invalidAlertAction("source and target currencies must be different")
+	}
+
+	kyc, err := s.store.Queries.GetKYCByUserID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("Err_KYC_NOT_FOUND")
+		}
+		return nil, fmt.Errorf("failed to fetch KYC: %w", err)
+	}
+	if kyc.Tier == "tier_1" {
+		return nil, fmt.Errorf("Err_KYC_NEED_TIER_2")
+	}
+	if err := s.exchangeRateService.ValidateCurrencyPair(sourceCurrency, targetCurrency); err != nil {
+		return nil, exchangerate.ErrInvalidCurrencyPair
+	}
+
+	value, err := utils.ToDecimal(amount)
+	if err != nil || !value.IsPositive() {
+		return nil, invalidAlertAction("amount must be a positive number")
+	}
+	if !value.Equal(value.Round(4)) {
+		return nil, invalidAlertAction("amount supports at most 4 decimal places")
+	}
+
+	amountUsd, err := utils.ConvertToUSD(ctx, value, sourceCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount to USD: %w", err)
+	}
+	if amountUsd.GreaterThan(decimal.NewFromFloat(s.alertConversions.MaxAmountUSD)) {
+		return nil, invalidAlertAction(fmt.Sprintf("amount is above the %s USD limit for a single alert conversion",
+			decimal.NewFromFloat(s.alertConversions.MaxAmountUSD).String()))
+	}
+
+	sourceWallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: userID,
+		Currency:   sourceCurrency,
+	})
+	if err != nil {
+		return nil, ErrWalletNotFound
+	}
+	targetWallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: userID,
+		Currency:   targetCurrency,
+	})
+	if err != nil {
+		return nil, ErrWalletNotFound
+	}
+
+	return &AlertConversionTerms{
+		SourceWalletID: sourceWallet.ID,
+		TargetWalletID: targetWallet.ID,
+		Amount:         value,
+	}, nil
+}
+
+// ExecuteAlertConversion runs the conversion action of a triggered alert within the alert
+// conversion limits. Every run is recorded against the trigger, and the user is notified of
+// the result; a failed run leaves the wallets untouched.
+func (s *ConversionService) ExecuteAlertConversion(ctx context.Context, conv *AlertConversion) error {
+	action := conv.Action
+	amount := s.stringToDecimal(action.Amount)
+
+	user, err := s.store.GetUserByID(ctx, action.UserID)
+	if err != nil {
+		s.failAlertConversion(ctx, conv, nil, alertFailConversion)
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive {
+		s.failAlertConversion(ctx, conv, nil, alertFailAccountInactive)
+		return nil
+	}
+
+	// the user's tier can change between attaching the action and the alert triggering
+	kyc, err := s.store.Queries.GetKYCByUserID(ctx, action.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		s.failAlertConversion(ctx, conv, nil, alertFailConversion)
+		return fmt.Errorf("failed to fetch KYC: %w", err)
+	}
+	if err == sql.ErrNoRows || kyc.Tier == "tier_1" {
+		s.failAlertConversion(ctx, conv, nil, alertFailKYCTier)
+		return nil
+	}
+
+	amountUsd, err := utils.ConvertToUSD(ctx, amount, action.SourceCurrency)
+	if err != nil {
+		s.failAlertConversion(ctx, conv, nil, alertFailRateUnavailable)
+		return fmt.Errorf("failed to convert amount to USD: %w", err)
+	}
+	if amountUsd.GreaterThan(decimal.NewFromFloat(s.alertConversions.MaxAmountUSD)) {
+		s.failAlertConversion(ctx, conv, &amountUsd, alertFailAmountLimit)
+		return nil
+	}
+
+	spent, err := s.store.SumUserAlertConversionsSince(ctx, db.SumUserAlertConversionsSinceParams{
+		UserID:    action.UserID,
+		CreatedAt: time.Now().Add(-24 * time.Hour),
+	})
+	if err != nil {
+		s.failAlertConversion(ctx, conv, &amountUsd, alertFailConversion)
+		return fmt.Errorf("failed to sum alert conversions: %w", err)
+	}
+	if s.stringToDecimal(spent).Add(amountUsd).GreaterThan(decimal.NewFromFloat(s.alertConversions.DailyLimitUSD)) {
+		s.failAlertConversion(ctx, conv, &amountUsd, alertFailDailyLimit)
+		return nil
+	}
+
+	rate, err := s.rateManagerService.GetAdjustedRateForUser(ctx, action.UserID, action.SourceCurrency, action.TargetCurrency, amount.String(), ratemanager.ChannelSmartConversion)
+	if err != nil {
+		s.failAlertConversion(ctx, conv, &amountUsd, alertFailRateUnavailable)
+		if errors.Is(err, exchangerate.ErrNoConsensusRate) {
+			return nil
+		}
+		return fmt.Errorf("failed to get rate: %w", err)
+	}
+	executedRate := s.stringToDecimal(rate.AdjustedRate)
+	if !executedRate.IsPositive() {
+		s.failAlertConversion(ctx, conv, &amountUsd, alertFailRateUnavailable)
+		return nil
+	}
+
+	feePercentage := s.exchangeRateService.GetFeePercentage(action.SourceCurrency, action.TargetCurrency)
+	targetAmount, fees, netAmount := s.exchangeRateService.CalculateConversionAmount(amount, executedRate, feePercentage)
+
+	triggerType := alertTriggerType
+	_, err = s.executeConversion(ctx, &conversionExecutionParams{
+		userID:         action.UserID,
+		sourceWalletID: action.SourceWalletID,
+		targetWalletID: action.TargetWalletID,
+		sourceCurrency: action.SourceCurrency,
+		targetCurrency: action.TargetCurrency,
+		sourceAmount:   amount,
+		targetAmount:   targetAmount,
+		fees:           fees,
+		netAmount:      netAmount,
+		executedRate:   executedRate,
+		midRate:        s.stringToDecimal(rate.BaseRate),
+		triggerRate:    &conv.TriggerRate,
+		executionType:  "automatic",
+		triggerType:    &triggerType,
+		rateProvider:   rate.RateProvider,
+		rateRuleID:     rate.RuleID,
+		rateRuleName:   rate.RuleApplied,
+		vipLevelID:     rate.VIPLevelID,
+		vipLevelName:   rate.VIPLevelApplied,
+		alertRun:       &alertRun{conversion: conv, amountUsd: amountUsd},
+	})
+	if err != nil {
+		if err == ErrInsufficientBalance {
+			s.failAlertConversion(ctx, conv, &amountUsd, alertFailInsufficientFunds)
+			return nil
+		}
+		if err == errAlertDailyLimit {
+			s.failAlertConversion(ctx, conv, &amountUsd, alertFailDailyLimit)
+			return nil
+		}
+		s.failAlertConversion(ctx, conv, &amountUsd, alertFailConversion)
+		return err
+	}
+	return nil
+}
+
+// recordAlertRun links the conversion to the alert trigger that ran it. The daily limit is checked
+// again here: alerts on different pairs run on different evaluators, and the serializable
+// conversion transaction lets only one of two concurrent runs count against the same total.
+func (s *ConversionService) recordAlertRun(ctx context.Context, q *db.Queries, run *alertRun, params *conversionExecutionParams, historyID, transactionID uuid.UUID) error {
+	action := run.conversion.Action
+	spent, err := q.SumUserAlertConversionsSince(ctx, db.SumUserAlertConversionsSinceParams{
+		UserID:    action.UserID,
+		CreatedAt: time.Now().Add(-24 * time.Hour),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sum alert conversions: %w", err)
+	}
+	if s.stringToDecimal(spent).Add(run.amountUsd).GreaterThan(decimal.NewFromFloat(s.alertConversions.DailyLimitUSD)) {
+		return errAlertDailyLimit
+	}
+
+	_, err = q.CreateAlertConversionRun(ctx, db.CreateAlertConversionRunParams{
+		TriggerID:           run.conversion.TriggerID,
+		AlertID:             action.AlertID,
+		UserID:              action.UserID,
+		Status:              AlertRunExecuted,
+		ConversionHistoryID: uuid.NullUUID{UUID: historyID, Valid: true},
+		TransactionID:       uuid.NullUUID{UUID: transactionID, Valid: true},
+		SourceCurrency:      action.SourceCurrency,
+		TargetCurrency:      action.TargetCurrency,
+		SourceAmount:        params.sourceAmount.String(),
+		AmountUsd:           sql.NullString{String: run.amountUsd.String(), Valid: true},
+		TargetAmount:        sql.NullString{String: params.netAmount.String(), Valid: true},
+		ExecutedRate:        sql.NullString{String: params.executedRate.String(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record alert conversion: %w", err)
+	}
+	return nil
+}
+
+// failAlertConversion records a run that did not convert and tells the user why
+func (s *ConversionService) failAlertConversion(ctx context.Context, conv *AlertConversion, amountUsd *decimal.Decimal, reason string) {
+	action := conv.Action
+
+	_, err := s.store.CreateAlertConversionRun(ctx, db.CreateAlertConversionRunParams{
+		TriggerID:      conv.TriggerID,
+		AlertID:        action.AlertID,
+		UserID:         action.UserID,
+		Status:         AlertRunFailed,
+		Reason:         sql.NullString{String: reason, Valid: true},
+		SourceCurrency: action.SourceCurrency,
+		TargetCurrency: action.TargetCurrency,
+		SourceAmount:   action.Amount,
+		AmountUsd:      s.decimalToNullString(amountUsd),
+	})
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to record failed conversion for alert %s: %v", action.AlertID, err))
+	}
+
+	s.notifyUser(ctx, action.UserID, "Alert Conversion Failed",
+		fmt.Sprintf("Your %s triggered but the conversion of %s %s to %s did not go through because %s. No funds were taken",
+			conv.Label, action.Amount, action.SourceCurrency, action.TargetCurrency, s.alertFailureReason(conv, reason)))
+}
+
+func (s *ConversionService) alertFailureReason(conv *AlertConversion, reason string) string {
+	switch reason {
+	case alertFailInsufficientFunds:
+		return fmt.Sprintf("your %s wallet balance was too low", conv.Action.SourceCurrency)
+	case alertFailAmountLimit:
+		return fmt.Sprintf("the amount is above the %s USD limit for a single alert conversion",
+			decimal.NewFromFloat(s.alertConversions.MaxAmountUSD).String())
+	case alertFailDailyLimit:
+		return fmt.Sprintf("it would take your alert conversions over the %s USD daily limit",
+			decimal.NewFromFloat(s.alertConversions.DailyLimitUSD).String())
+	case alertFailRateUnavailable:
+		return "no exchange rate was available"
+	case alertFailAccountInactive:
+		return "your account is inactive"
+	case alertFailKYCTier:
+		return "alert conversions require Tier 2 verification"
+	default:
+		return "the conversion could not be completed"
+	}
+}
+
+// notifyAlertConversion tells the user what the alert's conversion bought
+func (s *ConversionService) notifyAlertConversion(ctx context.Context, run *alertRun, params *conversionExecutionParams) {
+	s.notifyUser(ctx, params.userID, "Alert Conversion Completed",
+		fmt.Sprintf("Your %s triggered and converted %s %s to %s %s at %s",
+			run.conversion.Label, params.sourceAmount.String(), params.sourceCurrency,
+			params.netAmount.StringFixed(4), params.targetCurrency, params.executedRate.String()))
+}
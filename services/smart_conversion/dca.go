@@ -0,0 +1,701 @@
+package smartconversion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
+	ratemanager "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_manager"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	PlanStatusActive    = "active"
+	PlanStatusPaused    = "paused"
+	PlanStatusCancelled = "cancelled"
+
+	dcaSourceCurrency   = "NGN"
+	dcaDefaultTime      = "09:00"
+	dcaDefaultTimezone  = "Africa/Lagos"
+	dcaTriggerType      = "dca"
+	dcaExecutionHistory = 50
+
+	dcaSkipRateAboveCeiling  = "rate_above_ceiling"
+	dcaSkipInsufficientFunds = "insufficient_balance"
+	dcaFailRateUnavailable   = "rate_unavailable"
+	dcaFailConversion        = "conversion_failed"
+)
+
+// dcaRun ties a conversion to the DCA plan run it executes
+type dcaRun struct {
+	plan db.DcaPlan
+	next time.Time
+}
+
+func invalidPlan(message string) error {
+	return &ConversionError{Code: "INVALID_PLAN", Message: message}
+}
+
+// dcaSchedule is a plan's validated schedule
+type dcaSchedule struct {
+	frequency  string
+	dayOfWeek  *int
+	dayOfMonth *int
+	at         string
+	timezone   string
+}
+
+func (d dcaSchedule) validate() error {
+	switch d.frequency {
+	case "weekly":
+		if d.dayOfWeek == nil {
+			return invalidPlan("weekly plans need schedule_day_of_week")
+		}
+	case "monthly":
+		if d.dayOfMonth == nil {
+			return invalidPlan("monthly plans need schedule_day_of_month")
+		}
+	}
+	if _, err := time.Parse("15:04", d.at); err != nil {
+		return invalidPlan("schedule_time must be HH:MM")
+	}
+	if _, err := time.LoadLocation(d.timezone); err != nil {
+		return invalidPlan(fmt.Sprintf("unknown timezone %q", d.timezone))
+	}
+	return nil
+}
+
+// timeOfDay is the schedule time as stored in a TIME column
+func (d dcaSchedule) timeOfDay() time.Time {
+	t, _ := time.Parse("15:04", d.at)
+	return time.Date(2000, 1, 1, t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+func (s *ConversionService) nextDCAExecution(d dcaSchedule) time.Time {
+	return s.calculateNextExecution(&d.frequency, d.dayOfWeek, d.dayOfMonth, &d.at, &d.timezone)
+}
+
+func planSchedule(plan db.DcaPlan) dcaSchedule {
+	d := dcaSchedule{
+		frequency: plan.Frequency,
+		at:        plan.ScheduleTime.Format("15:04"),
+		timezone:  plan.Timezone,
+	}
+	if plan.ScheduleDayOfWeek.Valid {
+		dow := int(plan.ScheduleDayOfWeek.Int32)
+		d.dayOfWeek = &dow
+	}
+	if plan.ScheduleDayOfMonth.Valid {
+		dom := int(plan.ScheduleDayOfMonth.Int32)
+		d.dayOfMonth = &dom
+	}
+	return d
+}
+
+func parsePlanAmount(raw string) (decimal.Decimal, error) {
+	amount, err := utils.ToDecimal(raw)
+	if err != nil || !amount.IsPositive() {
+		return decimal.Zero, invalidPlan("amount must be a positive number")
+	}
+	if !amount.Equal(amount.Round(4)) {
+		return decimal.Zero, invalidPlan("amount supports at most 4 decimal places")
+	}
+	return amount, nil
+}
+
+func parseRateCeiling(raw *string) (sql.NullString, error) {
+	if raw == nil || *raw == "" {
+		return sql.NullString{}, nil
+	}
+	ceiling, err := utils.ToDecimal(*raw)
+	if err != nil || !ceiling.IsPositive() {
+		return sql.NullString{}, invalidPlan("rate_ceiling must be a positive number")
+	}
+	return sql.NullString{String: ceiling.String(), Valid: true}, nil
+}
+
+func intPtrToNullInt32(i *int) sql.NullInt32 {
+	if i == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*i), Valid: true}
+}
+
+// ============================================================
+// PLAN LIFECYCLE
+// ============================================================
+
+// CreateDCAPlan starts a plan that converts a fixed NGN amount into a stablecoin on a schedule
+func (s *ConversionService) CreateDCAPlan(ctx context.Context, user *db.User, req *CreateDCAPlanRequest) (*DCAPlan, error) {
+	s.logger.Info(fmt.Sprintf("Creating DCA plan for user %s", user.ID))
+
+	kyc, err := s.store.Queries.GetKYCByUserID(ctx, user.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("Err_KYC_NOT_FOUND")
+		}
+		return nil, fmt.Errorf("failed to fetch KYC: %w", err)
+	}
+	if kyc.Tier == "tier_1" {
+		s.push.SendPushNotification(ctx, user.ID, "Verification required.", "This feature requires Tier 2 verification. Complete identity verification to continue")
+		return nil, fmt.Errorf("Err_KYC_NEED_TIER_2")
+	}
+
+	amount, err := parsePlanAmount(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	ceiling, err := parseRateCeiling(req.RateCeiling)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := dcaSchedule{
+		frequency:  req.Frequency,
+		dayOfWeek:  req.ScheduleDayOfWeek,
+		dayOfMonth: req.ScheduleDayOfMonth,
+		at:         s.stringOrDefault(req.ScheduleTime, dcaDefaultTime),
+		timezone:   s.stringOrDefault(req.Timezone, dcaDefaultTimezone),
+	}
+	if err := schedule.validate(); err != nil {
+		return nil, err
+	}
+
+	policy := req.InsufficientFundsPolicy
+	if policy == "" {
+		policy = "skip"
+	}
+
+	sourceWallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: user.ID,
+		Currency:   dcaSourceCurrency,
+	})
+	if err != nil {
+		return nil, ErrWalletNotFound
+	}
+	targetWallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: user.ID,
+		Currency:   req.TargetCurrency,
+	})
+	if err != nil {
+		return nil, ErrWalletNotFound
+	}
+
+	plan, err := s.store.CreateDCAPlan(ctx, db.CreateDCAPlanParams{
+		UserID:                  user.ID,
+		SourceCurrency:          dcaSourceCurrency,
+		TargetCurrency:          req.TargetCurrency,
+		SourceWalletID:          sourceWallet.ID,
+		TargetWalletID:          targetWallet.ID,
+		Amount:                  amount.String(),
+		Frequency:               schedule.frequency,
+		ScheduleDayOfWeek:       intPtrToNullInt32(schedule.dayOfWeek),
+		ScheduleDayOfMonth:      intPtrToNullInt32(schedule.dayOfMonth),
+		ScheduleTime:            schedule.timeOfDay(),
+		Timezone:                schedule.timezone,
+		RateCeiling:             ceiling,
+		InsufficientFundsPolicy: policy,
+		NextExecutionAt:         s.nextDCAExecution(schedule),
+		Label:                   s.stringToNullString(req.Label),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DCA plan: %w", err)
+	}
+
+	return s.toDCAPlan(ctx, plan, nil), nil
+}
+
+// UpdateDCAPlan changes an active or paused plan. Schedule changes move the next run.
+func (s *ConversionService) UpdateDCAPlan(ctx context.Context, userID, planID uuid.UUID, req *UpdateDCAPlanRequest) (*DCAPlan, error) {
+	plan, err := s.userDCAPlan(ctx, userID, planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Status == PlanStatusCancelled {
+		return nil, ErrPlanStatus
+	}
+
+	params := db.UpdateDCAPlanParams{
+		ID:                      plan.ID,
+		Amount:                  plan.Amount,
+		Frequency:               plan.Frequency,
+		ScheduleDayOfWeek:       plan.ScheduleDayOfWeek,
+		ScheduleDayOfMonth:      plan.ScheduleDayOfMonth,
+		ScheduleTime:            plan.ScheduleTime,
+		Timezone:                plan.Timezone,
+		RateCeiling:             plan.RateCeiling,
+		InsufficientFundsPolicy: plan.InsufficientFundsPolicy,
+		Label:                   plan.Label,
+		NextExecutionAt:         plan.NextExecutionAt,
+	}
+
+	if req.Amount != nil {
+		amount, err := parsePlanAmount(*req.Amount)
+		if err != nil {
+			return nil, err
+		}
+		params.Amount = amount.String()
+	}
+	if req.RateCeiling != nil {
+		if params.RateCeiling, err = parseRateCeiling(req.RateCeiling); err != nil {
+			return nil, err
+		}
+	}
+	if req.InsufficientFundsPolicy != nil {
+		params.InsufficientFundsPolicy = *req.InsufficientFundsPolicy
+	}
+	if req.Label != nil {
+		params.Label = s.stringToNullString(req.Label)
+	}
+
+	if req.Frequency != nil || req.ScheduleDayOfWeek != nil || req.ScheduleDayOfMonth != nil || req.ScheduleTime != nil || req.Timezone != nil {
+		schedule := planSchedule(plan)
+		if req.Frequency != nil {
+			schedule.frequency = *req.Frequency
+		}
+		if req.ScheduleDayOfWeek != nil {
+			schedule.dayOfWeek = req.ScheduleDayOfWeek
+		}
+		if req.ScheduleDayOfMonth != nil {
+			schedule.dayOfMonth = req.ScheduleDayOfMonth
+		}
+		if req.ScheduleTime != nil {
+			schedule.at = *req.ScheduleTime
+		}
+		if req.Timezone != nil {
+			schedule.timezone = *req.Timezone
+		}
+		if err := schedule.validate(); err != nil {
+			return nil, err
+		}
+
+		params.Frequency = schedule.frequency
+		params.ScheduleDayOfWeek = intPtrToNullInt32(schedule.dayOfWeek)
+		params.ScheduleDayOfMonth = intPtrToNullInt32(schedule.dayOfMonth)
+		params.ScheduleTime = schedule.timeOfDay()
+		params.Timezone = schedule.timezone
+		params.NextExecutionAt = s.nextDCAExecution(schedule)
+	}
+
+	updated, err := s.store.UpdateDCAPlan(ctx, params)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPlanStatus
+		}
+		return nil, fmt.Errorf("failed to update DCA plan: %w", err)
+	}
+	return s.toDCAPlan(ctx, updated, nil), nil
+}
+
+// PauseDCAPlan stops an active plan's runs until it is resumed
+func (s *ConversionService) PauseDCAPlan(ctx context.Context, userID, planID uuid.UUID) (*DCAPlan, error) {
+	if _, err := s.userDCAPlan(ctx, userID, planID); err != nil {
+		return nil, err
+	}
+	plan, err := s.store.PauseDCAPlan(ctx, planID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPlanStatus
+		}
+		return nil, fmt.Errorf("failed to pause DCA plan: %w", err)
+	}
+	return s.toDCAPlan(ctx, plan, nil), nil
+}
+
+// ResumeDCAPlan reactivates a paused plan from its next scheduled time; missed runs are not made up
+func (s *ConversionService) ResumeDCAPlan(ctx context.Context, userID, planID uuid.UUID) (*DCAPlan, error) {
+	plan, err := s.userDCAPlan(ctx, userID, planID)
+	if err != nil {
+		return nil, err
+	}
+	resumed, err := s.store.ResumeDCAPlan(ctx, db.ResumeDCAPlanParams{
+		ID:              plan.ID,
+		NextExecutionAt: s.nextDCAExecution(planSchedule(plan)),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPlanStatus
+		}
+		return nil, fmt.Errorf("failed to resume DCA plan: %w", err)
+	}
+	return s.toDCAPlan(ctx, resumed, nil), nil
+}
+
+// CancelDCAPlan ends a plan for good. Its history and summary stay available.
+func (s *ConversionService) CancelDCAPlan(ctx context.Context, userID, planID uuid.UUID) (*DCAPlan, error) {
+	if _, err := s.userDCAPlan(ctx, userID, planID); err != nil {
+		return nil, err
+	}
+	plan, err := s.store.CancelDCAPlan(ctx, planID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPlanStatus
+		}
+		return nil, fmt.Errorf("failed to cancel DCA plan: %w", err)
+	}
+	return s.toDCAPlan(ctx, plan, nil), nil
+}
+
+// ListDCAPlans returns the user's active and paused plans with their summaries
+func (s *ConversionService) ListDCAPlans(ctx context.Context, userID uuid.UUID) ([]*DCAPlan, error) {
+	plans, err := s.store.ListDCAPlansByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DCA plans: %w", err)
+	}
+
+	prices := make(map[string]*decimal.Decimal)
+	result := make([]*DCAPlan, 0, len(plans))
+	for _, plan := range plans {
+		result = append(result, s.toDCAPlan(ctx, plan, prices))
+	}
+	return result, nil
+}
+
+// GetDCAPlan returns one of the user's plans with its summary and run history, newest first
+func (s *ConversionService) GetDCAPlan(ctx context.Context, userID, planID uuid.UUID, limit, offset int32) (*DCAPlanDetail, error) {
+	plan, err := s.userDCAPlan(ctx, userID, planID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > dcaExecutionHistory {
+		limit = dcaExecutionHistory
+	}
+
+	executions, err := s.store.ListDCAPlanExecutions(ctx, db.ListDCAPlanExecutionsParams{
+		PlanID: plan.ID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DCA plan runs: %w", err)
+	}
+
+	detail := &DCAPlanDetail{
+		DCAPlan:    s.toDCAPlan(ctx, plan, nil),
+		Executions: make([]DCAPlanExecution, 0, len(executions)),
+	}
+	for _, e := range executions {
+		detail.Executions = append(detail.Executions, s.toDCAPlanExecution(e))
+	}
+	return detail, nil
+}
+
+func (s *ConversionService) userDCAPlan(ctx context.Context, userID, planID uuid.UUID) (db.DcaPlan, error) {
+	plan, err := s.store.GetDCAPlan(ctx, planID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.DcaPlan{}, ErrPlanNotFound
+		}
+		return db.DcaPlan{}, fmt.Errorf("failed to get DCA plan: %w", err)
+	}
+	if plan.UserID != userID {
+		return db.DcaPlan{}, ErrPlanNotFound
+	}
+	return plan, nil
+}
+
+// ============================================================
+// RUNS
+// ============================================================
+
+// ExecuteDCAPlans runs every active plan that is due. A run is skipped when the price is above
+// the plan's ceiling or the wallet cannot cover it; either way the plan moves to its next run.
+func (s *ConversionService) ExecuteDCAPlans(ctx context.Context) error {
+	s.logger.Info("Executing due DCA plans")
+
+	plans, err := s.store.ListDueDCAPlans(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to fetch due DCA plans: %w", err)
+	}
+
+	for _, plan := range plans {
+		s.runDCAPlan(ctx, plan)
+	}
+	return nil
+}
+
+func (s *ConversionService) runDCAPlan(ctx context.Context, plan db.DcaPlan) {
+	next := s.nextDCAExecution(planSchedule(plan))
+	amount := s.stringToDecimal(plan.Amount)
+
+	rate, err := s.rateManagerService.GetAdjustedRateForUser(ctx, plan.UserID, plan.SourceCurrency, plan.TargetCurrency, amount.String(), ratemanager.ChannelSmartConversion)
+	if err != nil {
+		if !errors.Is(err, exchangerate.ErrNoConsensusRate) {
+			s.logger.Error(fmt.Sprintf("Failed to get rate for DCA plan %s: %v", plan.ID, err))
+		}
+		s.missDCARun(ctx, plan, next, "failed", dcaFailRateUnavailable, PlanStatusActive)
+		return
+	}
+
+	executedRate := s.stringToDecimal(rate.AdjustedRate)
+	if !executedRate.IsPositive() {
+		s.missDCARun(ctx, plan, next, "failed", dcaFailRateUnavailable, PlanStatusActive)
+		return
+	}
+	price := decimal.NewFromInt(1).Div(executedRate)
+	if ceiling := s.nullStringToDecimal(plan.RateCeiling); ceiling != nil && price.GreaterThan(*ceiling) {
+		s.missDCARun(ctx, plan, next, "skipped", dcaSkipRateAboveCeiling, PlanStatusActive)
+		return
+	}
+
+	feePercentage := s.exchangeRateService.GetFeePercentage(plan.SourceCurrency, plan.TargetCurrency)
+	targetAmount, fees, netAmount := s.exchangeRateService.CalculateConversionAmount(amount, executedRate, feePercentage)
+
+	triggerType := dcaTriggerType
+	_, err = s.executeConversion(ctx, &conversionExecutionParams{
+		userID:         plan.UserID,
+		sourceWalletID: plan.SourceWalletID,
+		targetWalletID: plan.TargetWalletID,
+		sourceCurrency: plan.SourceCurrency,
+		targetCurrency: plan.TargetCurrency,
+		sourceAmount:   amount,
+		targetAmount:   targetAmount,
+		fees:           fees,
+		netAmount:      netAmount,
+		executedRate:   executedRate,
+		midRate:        s.stringToDecimal(rate.BaseRate),
+		executionType:  "scheduled",
+		triggerType:    &triggerType,
+		rateProvider:   rate.RateProvider,
+		rateRuleID:     rate.RuleID,
+		rateRuleName:   rate.RuleApplied,
+		vipLevelID:     rate.VIPLevelID,
+		vipLevelName:   rate.VIPLevelApplied,
+		dcaRun:         &dcaRun{plan: plan, next: next},
+	})
+	if err != nil {
+		if err == ErrInsufficientBalance {
+			status := PlanStatusActive
+			if plan.InsufficientFundsPolicy == "pause" {
+				status = PlanStatusPaused
+			}
+			s.missDCARun(ctx, plan, next, "skipped", dcaSkipInsufficientFunds, status)
+			return
+		}
+		if err == ErrPlanStatus {
+			// the plan was paused, cancelled or already run since it was listed
+			return
+		}
+		s.logger.Error(fmt.Sprintf("Failed to execute DCA plan %s: %v", plan.ID, err))
+		s.missDCARun(ctx, plan, next, "failed", dcaFailConversion, PlanStatusActive)
+	}
+}
+
+// recordDCARun adds a completed run to the plan's totals and history
+func (s *ConversionService) recordDCARun(ctx context.Context, q *db.Queries, run *dcaRun, params *conversionExecutionParams, historyID, transactionID uuid.UUID) (db.DcaPlan, error) {
+	plan, err := q.RecordDCAPlanRun(ctx, db.RecordDCAPlanRunParams{
+		Invested:        params.sourceAmount.String(),
+		Accumulated:     params.netAmount.String(),
+		NextExecutionAt: run.next,
+		ID:              run.plan.ID,
+		ScheduledFor:    run.plan.NextExecutionAt,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.DcaPlan{}, ErrPlanStatus
+		}
+		return db.DcaPlan{}, fmt.Errorf("failed to record DCA run: %w", err)
+	}
+
+	_, err = q.CreateDCAPlanExecution(ctx, db.CreateDCAPlanExecutionParams{
+		PlanID:              run.plan.ID,
+		Status:              "executed",
+		ConversionHistoryID: uuid.NullUUID{UUID: historyID, Valid: true},
+		TransactionID:       uuid.NullUUID{UUID: transactionID, Valid: true},
+		SourceAmount:        params.sourceAmount.String(),
+		TargetAmount:        sql.NullString{String: params.netAmount.String(), Valid: true},
+		ExecutedRate:        sql.NullString{String: params.executedRate.String(), Valid: true},
+		ScheduledFor:        run.plan.NextExecutionAt,
+	})
+	if err != nil {
+		return db.DcaPlan{}, fmt.Errorf("failed to record DCA run: %w", err)
+	}
+	return plan, nil
+}
+
+// missDCARun records a skipped or failed run and moves the plan to its next run, leaving it in
+// planStatus
+func (s *ConversionService) missDCARun(ctx context.Context, plan db.DcaPlan, next time.Time, runStatus, reason, planStatus string) {
+	var updated db.DcaPlan
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		updated, err = q.RecordDCAPlanSkip(ctx, db.RecordDCAPlanSkipParams{
+			Status:          planStatus,
+			NextExecutionAt: next,
+			ID:              plan.ID,
+			ScheduledFor:    plan.NextExecutionAt,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.CreateDCAPlanExecution(ctx, db.CreateDCAPlanExecutionParams{
+			PlanID:       plan.ID,
+			Status:       runStatus,
+			Reason:       sql.NullString{String: reason, Valid: true},
+			SourceAmount: plan.Amount,
+			ScheduledFor: plan.NextExecutionAt,
+		})
+		return err
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			s.logger.Error(fmt.Sprintf("Failed to record missed run for DCA plan %s: %v", plan.ID, err))
+		}
+		return
+	}
+
+	label := s.dcaPlanName(updated)
+	switch {
+	case reason == dcaSkipRateAboveCeiling:
+		s.notifyUser(ctx, plan.UserID, "DCA Purchase Skipped",
+			fmt.Sprintf("Your %s run was skipped because the %s price is above your ceiling of %s %s. The next run is on %s",
+				label, plan.TargetCurrency, plan.RateCeiling.String, plan.SourceCurrency, s.dcaRunDate(updated)))
+	case reason == dcaSkipInsufficientFunds && updated.Status == PlanStatusPaused:
+		s.notifyUser(ctx, plan.UserID, "DCA Plan Paused",
+			fmt.Sprintf("Your %s has been paused because your %s wallet could not cover %s %s. Top up and resume the plan to continue",
+				label, plan.SourceCurrency, plan.Amount, plan.SourceCurrency))
+	case reason == dcaSkipInsufficientFunds:
+		s.notifyUser(ctx, plan.UserID, "DCA Purchase Skipped",
+			fmt.Sprintf("Your %s run was skipped because your %s wallet could not cover %s %s. The next run is on %s",
+				label, plan.SourceCurrency, plan.Amount, plan.SourceCurrency, s.dcaRunDate(updated)))
+	default:
+		s.notifyUser(ctx, plan.UserID, "DCA Purchase Failed",
+			fmt.Sprintf("We could not complete your %s run. No funds were taken and the next run is on %s",
+				label, s.dcaRunDate(updated)))
+	}
+}
+
+// notifyDCARun tells the user what a run bought and where the plan stands
+func (s *ConversionService) notifyDCARun(ctx context.Context, plan db.DcaPlan, params *conversionExecutionParams) {
+	msg := fmt.Sprintf("Your %s bought %s %s for %s %s. You have accumulated %s %s",
+		s.dcaPlanName(plan), params.netAmount.StringFixed(4), plan.TargetCurrency, params.sourceAmount.String(), plan.SourceCurrency,
+		plan.TotalAccumulated, plan.TargetCurrency)
+	if average := s.dcaAverageCost(plan); average != nil {
+		msg += fmt.Sprintf(" at an average cost of %s %s", average.StringFixed(2), plan.SourceCurrency)
+	}
+	s.notifyUser(ctx, plan.UserID, "DCA Purchase Completed", msg)
+}
+
+func (s *ConversionService) dcaPlanName(plan db.DcaPlan) string {
+	if plan.Label.Valid && plan.Label.String != "" {
+		return plan.Label.String + " plan"
+	}
+	return fmt.Sprintf("%s %s plan", plan.Frequency, plan.TargetCurrency)
+}
+
+func (s *ConversionService) dcaRunDate(plan db.DcaPlan) string {
+	loc, err := time.LoadLocation(plan.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return plan.NextExecutionAt.In(loc).Format("Jan 2, 15:04")
+}
+
+// dcaAverageCost is the source currency paid per unit of target across all runs
+func (s *ConversionService) dcaAverageCost(plan db.DcaPlan) *decimal.Decimal {
+	accumulated := s.stringToDecimal(plan.TotalAccumulated)
+	if !accumulated.IsPositive() {
+		return nil
+	}
+	average := s.stringToDecimal(plan.TotalInvested).Div(accumulated).Round(4)
+	return &average
+}
+
+// currentDCAPrice is the market price in source currency per unit of target. prices caches
+// lookups across plans and may be nil.
+func (s *ConversionService) currentDCAPrice(ctx context.Context, source, target string, prices map[string]*decimal.Decimal) *decimal.Decimal {
+	pair := source + "/" + target
+	if price, ok := prices[pair]; ok {
+		return price
+	}
+
+	var price *decimal.Decimal
+	rate, err := s.exchangeRateService.GetExchangeRate(ctx, source, target)
+	if err == nil && rate.Rate.IsPositive() {
+		p := decimal.NewFromInt(1).Div(rate.Rate).Round(4)
+		price = &p
+	}
+	if prices != nil {
+		prices[pair] = price
+	}
+	return price
+}
+
+func (s *ConversionService) toDCAPlan(ctx context.Context, plan db.DcaPlan, prices map[string]*decimal.Decimal) *DCAPlan {
+	invested := s.stringToDecimal(plan.TotalInvested)
+	accumulated := s.stringToDecimal(plan.TotalAccumulated)
+
+	result := &DCAPlan{
+		ID:                      plan.ID,
+		SourceCurrency:          plan.SourceCurrency,
+		TargetCurrency:          plan.TargetCurrency,
+		Amount:                  s.stringToDecimal(plan.Amount),
+		Frequency:               plan.Frequency,
+		ScheduleTime:            plan.ScheduleTime.Format("15:04"),
+		Timezone:                plan.Timezone,
+		RateCeiling:             s.nullStringToDecimal(plan.RateCeiling),
+		InsufficientFundsPolicy: plan.InsufficientFundsPolicy,
+		Status:                  plan.Status,
+		LastExecutedAt:          s.nullTimeToTime(plan.LastExecutedAt),
+		ExecutionCount:          plan.ExecutionCount,
+		SkippedCount:            plan.SkippedCount,
+		Label:                   s.nullStringToString(plan.Label),
+		CreatedAt:               plan.CreatedAt,
+		Summary: DCASummary{
+			TotalInvested:    invested,
+			TotalAccumulated: accumulated,
+			AverageCost:      s.dcaAverageCost(plan),
+		},
+	}
+	if plan.ScheduleDayOfWeek.Valid {
+		result.ScheduleDayOfWeek = &plan.ScheduleDayOfWeek.Int32
+	}
+	if plan.ScheduleDayOfMonth.Valid {
+		result.ScheduleDayOfMonth = &plan.ScheduleDayOfMonth.Int32
+	}
+	if plan.Status == PlanStatusActive {
+		next := plan.NextExecutionAt
+		result.NextExecutionAt = &next
+	}
+
+	if accumulated.IsPositive() {
+		if price := s.currentDCAPrice(ctx, plan.SourceCurrency, plan.TargetCurrency, prices); price != nil {
+			value := accumulated.Mul(*price).Round(4)
+			result.Summary.CurrentPrice = price
+			result.Summary.CurrentValue = &value
+			if invested.IsPositive() {
+				ret := value.Sub(invested).Div(invested).Mul(decimal.NewFromInt(100)).Round(2)
+				result.Summary.ReturnPercentage = &ret
+			}
+		}
+	}
+	return result
+}
+
+func (s *ConversionService) toDCAPlanExecution(e db.DcaPlanExecution) DCAPlanExecution {
+	result := DCAPlanExecution{
+		ID:           e.ID,
+		Status:       e.Status,
+		Reason:       s.nullStringToString(e.Reason),
+		SourceAmount: s.stringToDecimal(e.SourceAmount),
+		TargetAmount: s.nullStringToDecimal(e.TargetAmount),
+		ExecutedRate: s.nullStringToDecimal(e.ExecutedRate),
+		ScheduledFor: e.ScheduledFor,
+		CreatedAt:    e.CreatedAt,
+	}
+	if e.TransactionID.Valid {
+		txID := e.TransactionID.UUID
+		result.TransactionID = &txID
+	}
+	if result.ExecutedRate != nil && result.ExecutedRate.IsPositive() {
+		price := decimal.NewFromInt(1).Div(*result.ExecutedRate).Round(4)
+		result.Price = &price
+	}
+	return result
+}
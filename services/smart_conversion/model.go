@@ -153,6 +153,14 @@ type ManualConversionRequest struct {
 	Amount         string `json:"amount" binding:"required,gt=0"` // Amount to convert
 	Reference      string `json:"reference" binding:"required"`
 	Pin            string `json:"pin" binding:"required"`
+	// QuoteID executes at the locked terms of a quote from POST /smart-convert/quotes
+	QuoteID        string `json:"quote_id"`
+}
+
+type ConversionQuoteRequest struct {
+	SourceCurrency string `json:"source_currency" binding:"required,oneof=USD NGN USDT USDC"`
+	TargetCurrency string `json:"target_currency" binding:"required,oneof=USD NGN USDT USDC"`
+	Amount         string `json:"amount" binding:"required"`
 }
 
 type ManualConversionResponse struct {
@@ -163,6 +171,140 @@ type ManualConversionResponse struct {
 	Fees         float64 `json:"fees"`
 	NetAmount    float64 `json:"net_amount"`
 	Status       string  `json:"status"`
+	QuoteID      string  `json:"quote_id,omitempty"`
+}
+
+// ============================================================
+// LIMIT ORDERS
+// ============================================================
+
+type PlaceLimitOrderRequest struct {
+	SourceCurrency string `json:"source_currency" binding:"required,oneof=USD NGN USDT USDC"`
+	TargetCurrency string `json:"target_currency" binding:"required,oneof=USD NGN USDT USDC"`
+	Amount         string `json:"amount" binding:"required"`     // source amount, held from the wallet until filled, cancelled or expired
+	LimitRate      string `json:"limit_rate" binding:"required"` // worst acceptable rate, in target per unit of source
+	TimeInForce    string `json:"time_in_force" binding:"required,oneof=gtc gtd"`
+	// ExpiresAt is required for gtd orders
+	ExpiresAt *time.Time `json:"expires_at"`
+	Label     *string    `json:"label"`
+	Pin       string     `json:"pin" binding:"required"`
+}
+
+type LimitOrder struct {
+	ID              uuid.UUID        `json:"id"`
+	SourceCurrency  string           `json:"source_currency"`
+	TargetCurrency  string           `json:"target_currency"`
+	LimitRate       decimal.Decimal  `json:"limit_rate"`
+	SourceAmount    decimal.Decimal  `json:"source_amount"`
+	FilledAmount    decimal.Decimal  `json:"filled_amount"`
+	RemainingAmount decimal.Decimal  `json:"remaining_amount"`
+	ReceivedAmount  decimal.Decimal  `json:"received_amount"` // net of fees
+	FeesPaid        decimal.Decimal  `json:"fees_paid"`
+	AverageRate     *decimal.Decimal `json:"average_rate,omitempty"`
+	TimeInForce     string           `json:"time_in_force"` // gtc, gtd
+	ExpiresAt       *time.Time       `json:"expires_at,omitempty"`
+	Status          string           `json:"status"` // open, partially_filled, filled, cancelled, expired
+	Label           *string          `json:"label,omitempty"`
+	LastFilledAt    *time.Time       `json:"last_filled_at,omitempty"`
+	ClosedAt        *time.Time       `json:"closed_at,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	Fills           []LimitOrderFill `json:"fills"`
+}
+
+type LimitOrderFill struct {
+	ID            uuid.UUID       `json:"id"`
+	TransactionID *uuid.UUID      `json:"transaction_id,omitempty"`
+	SourceAmount  decimal.Decimal `json:"source_amount"`
+	ExecutedRate  decimal.Decimal `json:"executed_rate"`
+	TargetAmount  decimal.Decimal `json:"target_amount"`
+	Fees          decimal.Decimal `json:"fees"`
+	NetAmount     decimal.Decimal `json:"net_amount"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// ============================================================
+// DCA PLANS
+// ============================================================
+
+type CreateDCAPlanRequest struct {
+	TargetCurrency string `json:"target_currency" binding:"required,oneof=USDT USDC"`
+	Amount         string `json:"amount" binding:"required"` // NGN converted on every run
+	Frequency      string `json:"frequency" binding:"required,oneof=daily weekly monthly"`
+	// ScheduleDayOfWeek is required for weekly plans: 0 = Sunday ... 6 = Saturday
+	ScheduleDayOfWeek *int `json:"schedule_day_of_week" binding:"omitempty,min=0,max=6"`
+	// ScheduleDayOfMonth is required for monthly plans; shorter months run on their last day
+	ScheduleDayOfMonth *int    `json:"schedule_day_of_month" binding:"omitempty,min=1,max=31"`
+	ScheduleTime       *string `json:"schedule_time"` // HH:MM, defaults to 09:00
+	Timezone           *string `json:"timezone"`      // defaults to Africa/Lagos
+	// RateCeiling is the most NGN to pay per unit of target; runs priced above it are skipped
+	RateCeiling             *string `json:"rate_ceiling"`
+	InsufficientFundsPolicy string  `json:"insufficient_funds_policy" binding:"omitempty,oneof=skip pause"` // defaults to skip
+	Label                   *string `json:"label"`
+	Pin                     string  `json:"pin" binding:"required"`
+}
+
+// UpdateDCAPlanRequest changes the fields that are set. An empty rate_ceiling removes the ceiling.
+type UpdateDCAPlanRequest struct {
+	Amount                  *string `json:"amount"`
+	Frequency               *string `json:"frequency" binding:"omitempty,oneof=daily weekly monthly"`
+	ScheduleDayOfWeek       *int    `json:"schedule_day_of_week" binding:"omitempty,min=0,max=6"`
+	ScheduleDayOfMonth      *int    `json:"schedule_day_of_month" binding:"omitempty,min=1,max=31"`
+	ScheduleTime            *string `json:"schedule_time"`
+	Timezone                *string `json:"timezone"`
+	RateCeiling             *string `json:"rate_ceiling"`
+	InsufficientFundsPolicy *string `json:"insufficient_funds_policy" binding:"omitempty,oneof=skip pause"`
+	Label                   *string `json:"label"`
+}
+
+type DCAPlan struct {
+	ID                      uuid.UUID        `json:"id"`
+	SourceCurrency          string           `json:"source_currency"`
+	TargetCurrency          string           `json:"target_currency"`
+	Amount                  decimal.Decimal  `json:"amount"`
+	Frequency               string           `json:"frequency"` // daily, weekly, monthly
+	ScheduleDayOfWeek       *int32           `json:"schedule_day_of_week,omitempty"`
+	ScheduleDayOfMonth      *int32           `json:"schedule_day_of_month,omitempty"`
+	ScheduleTime            string           `json:"schedule_time"`
+	Timezone                string           `json:"timezone"`
+	RateCeiling             *decimal.Decimal `json:"rate_ceiling,omitempty"`
+	InsufficientFundsPolicy string           `json:"insufficient_funds_policy"` // skip, pause
+	Status                  string           `json:"status"`                    // active, paused, cancelled
+	NextExecutionAt         *time.Time       `json:"next_execution_at,omitempty"`
+	LastExecutedAt          *time.Time       `json:"last_executed_at,omitempty"`
+	ExecutionCount          int32            `json:"execution_count"`
+	SkippedCount            int32            `json:"skipped_count"`
+	Label                   *string          `json:"label,omitempty"`
+	CreatedAt               time.Time        `json:"created_at"`
+	Summary                 DCASummary       `json:"summary"`
+}
+
+// DCASummary prices are in source currency per unit of target
+type DCASummary struct {
+	TotalInvested    decimal.Decimal  `json:"total_invested"`
+	TotalAccumulated decimal.Decimal  `json:"total_accumulated"`
+	AverageCost      *decimal.Decimal `json:"average_cost,omitempty"`
+	CurrentPrice     *decimal.Decimal `json:"current_price,omitempty"`
+	// CurrentValue is the accumulated amount valued at the current price
+	CurrentValue     *decimal.Decimal `json:"current_value,omitempty"`
+	ReturnPercentage *decimal.Decimal `json:"return_percentage,omitempty"`
+}
+
+type DCAPlanExecution struct {
+	ID            uuid.UUID        `json:"id"`
+	Status        string           `json:"status"`           // executed, skipped, failed
+	Reason        *string          `json:"reason,omitempty"` // rate_above_ceiling, insufficient_balance, rate_unavailable, conversion_failed
+	TransactionID *uuid.UUID       `json:"transaction_id,omitempty"`
+	SourceAmount  decimal.Decimal  `json:"source_amount"`
+	TargetAmount  *decimal.Decimal `json:"target_amount,omitempty"`
+	ExecutedRate  *decimal.Decimal `json:"executed_rate,omitempty"`
+	Price         *decimal.Decimal `json:"price,omitempty"`
+	ScheduledFor  time.Time        `json:"scheduled_for"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+type DCAPlanDetail struct {
+	*DCAPlan
+	Executions []DCAPlanExecution `json:"executions"`
 }
 
 // ============================================================
@@ -188,4 +330,8 @@ var (
 	ErrWalletNotFound      = &ConversionError{Code: "WALLET_NOT_FOUND", Message: "Wallet not found"}
 	ErrInsufficientBalance = &ConversionError{Code: "INSUFFICIENT_BALANCE", Message: "Insufficient wallet balance"}
 	ErrConversionFailed    = &ConversionError{Code: "CONVERSION_FAILED", Message: "Conversion execution failed"}
+	ErrOrderNotFound       = &ConversionError{Code: "ORDER_NOT_FOUND", Message: "Limit order not found"}
+	ErrOrderNotOpen        = &ConversionError{Code: "ORDER_NOT_OPEN", Message: "Limit order is no longer open"}
+	ErrPlanNotFound        = &ConversionError{Code: "PLAN_NOT_FOUND", Message: "DCA plan not found"}
+	ErrPlanStatus          = &ConversionError{Code: "PLAN_STATUS", Message: "DCA plan cannot be changed in its current status"}
 )
@@ -0,0 +1,573 @@
+package smartconversion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
+	ratemanager "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_manager"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	OrderStatusOpen            = "open"
+	OrderStatusPartiallyFilled = "partially_filled"
+	OrderStatusFilled          = "filled"
+	OrderStatusCancelled       = "cancelled"
+	OrderStatusExpired         = "expired"
+
+	holdPurposeLimitOrder = "limit_order"
+	limitOrderTriggerType = "limit_order"
+)
+
+// LimitOrderConfig caps how much limit order volume is filled per currency pair. Values are read
+// from the environment and fall back to defaults.
+type LimitOrderConfig struct {
+	// USD value of fills allowed per currency pair in each interval; orders beyond it fill partially
+	LiquidityCapUSD float64 `mapstructure:"LIMIT_ORDER_LIQUIDITY_CAP_USD"`
+	IntervalSeconds int     `mapstructure:"LIMIT_ORDER_INTERVAL_SECONDS"`
+	// partial fills smaller than this wait for the next interval
+	MinFillUSD float64 `mapstructure:"LIMIT_ORDER_MIN_FILL_USD"`
+}
+
+func LoadLimitOrderConfig() LimitOrderConfig {
+	var c LimitOrderConfig
+	if err := utils.LoadCustomConfig(utils.EnvPath, &c); err != nil {
+		c = LimitOrderConfig{}
+	}
+	if c.LiquidityCapUSD <= 0 {
+		c.LiquidityCapUSD = 50000
+	}
+	if c.IntervalSeconds <= 0 {
+		c.IntervalSeconds = 300
+	}
+	if c.MinFillUSD <= 0 {
+		c.MinFillUSD = 1
+	}
+	return c
+}
+
+func (c LimitOrderConfig) interval() time.Duration {
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+// orderFill ties a conversion to the limit order it fills. The source funds come out of the
+// order's balance hold rather than the wallet, which was debited when the order was placed.
+type orderFill struct {
+	order     db.ConversionOrder
+	amountUsd decimal.Decimal
+}
+
+func invalidOrder(message string) error {
+	return &ConversionError{Code: "INVALID_ORDER", Message: message}
+}
+
+// ============================================================
+// PLACING AND CANCELLING
+// ============================================================
+
+// PlaceLimitOrder reserves the source amount in a balance hold and opens an order that converts
+// it once the user's rate reaches the limit rate
+func (s *ConversionService) PlaceLimitOrder(ctx context.Context, user *db.User, req *PlaceLimitOrderRequest) (*LimitOrder, error) {
+	s.logger.Info(fmt.Sprintf("Placing limit order for user %s", user.ID))
+
+	kyc, err := s.store.Queries.GetKYCByUserID(ctx, user.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("Err_KYC_NOT_FOUND")
+		}
+		return nil, fmt.Errorf("failed to fetch KYC: %w", err)
+	}
+	if kyc.Tier == "tier_1" {
+		s.push.SendPushNotification(ctx, user.ID, "Verification required.", "This feature requires Tier 2 verification. Complete identity verification to continue")
+		return nil, fmt.Errorf("Err_KYC_NEED_TIER_2")
+	}
+
+	if req.SourceCurrency == req.TargetCurrency {
+		return nil, invalidOrder("source and target currencies must be different")
+	}
+	if err := s.exchangeRateService.ValidateCurrencyPair(req.SourceCurrency, req.TargetCurrency); err != nil {
+		return nil, exchangerate.ErrInvalidCurrencyPair
+	}
+
+	amount, err := utils.ToDecimal(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		return nil, invalidOrder("amount must be a positive number")
+	}
+	if !amount.Equal(amount.Round(4)) {
+		return nil, invalidOrder("amount supports at most 4 decimal places")
+	}
+	limitRate, err := utils.ToDecimal(req.LimitRate)
+	if err != nil || !limitRate.IsPositive() {
+		return nil, invalidOrder("limit_rate must be a positive number")
+	}
+
+	var expiresAt sql.NullTime
+	switch req.TimeInForce {
+	case "gtd":
+		if req.ExpiresAt == nil || !req.ExpiresAt.After(time.Now()) {
+			return nil, invalidOrder("good-till-date orders need an expires_at in the future")
+		}
+		expiresAt = sql.NullTime{Time: *req.ExpiresAt, Valid: true}
+	case "gtc":
+		if req.ExpiresAt != nil {
+			return nil, invalidOrder("good-till-cancelled orders do not expire; use time_in_force gtd to set expires_at")
+		}
+	}
+
+	sourceWallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: user.ID,
+		Currency:   req.SourceCurrency,
+	})
+	if err != nil {
+		return nil, ErrWalletNotFound
+	}
+	targetWallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: user.ID,
+		Currency:   req.TargetCurrency,
+	})
+	if err != nil {
+		return nil, ErrWalletNotFound
+	}
+
+	orderID := uuid.New()
+	var order db.ConversionOrder
+	err = s.store.ExecTx(ctx, func(q *db.Queries) error {
+		wallet, err := q.GetWalletForUpdate(ctx, sourceWallet.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get source wallet: %w", err)
+		}
+		balance, _ := decimal.NewFromString(wallet.Balance.String)
+		if amount.GreaterThan(balance) {
+			return ErrInsufficientBalance
+		}
+
+		// held funds leave the wallet so no other debit can spend them
+		_, err = q.DecrementWalletBalance(ctx, db.DecrementWalletBalanceParams{
+			Balance: sql.NullString{String: amount.String(), Valid: true},
+			ID:      wallet.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to debit source wallet: %w", err)
+		}
+
+		hold, err := q.CreateBalanceHold(ctx, db.CreateBalanceHoldParams{
+			UserID:      user.ID,
+			WalletID:    wallet.ID,
+			Currency:    req.SourceCurrency,
+			Amount:      amount.String(),
+			Purpose:     holdPurposeLimitOrder,
+			ReferenceID: orderID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to hold funds: %w", err)
+		}
+
+		order, err = q.CreateConversionOrder(ctx, db.CreateConversionOrderParams{
+			ID:             orderID,
+			UserID:         user.ID,
+			SourceCurrency: req.SourceCurrency,
+			TargetCurrency: req.TargetCurrency,
+			SourceWalletID: wallet.ID,
+			TargetWalletID: targetWallet.ID,
+			LimitRate:      limitRate.String(),
+			SourceAmount:   amount.String(),
+			TimeInForce:    req.TimeInForce,
+			ExpiresAt:      expiresAt,
+			HoldID:         hold.ID,
+			Label:          s.stringToNullString(req.Label),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create limit order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toLimitOrder(order, nil), nil
+}
+
+// CancelLimitOrder closes one of the user's open orders and returns the unfilled amount to the wallet
+func (s *ConversionService) CancelLimitOrder(ctx context.Context, userID, orderID uuid.UUID) (*LimitOrder, error) {
+	order, _, err := s.closeLimitOrder(ctx, orderID, &userID, OrderStatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+	return s.toLimitOrder(*order, s.orderFills(ctx, order.ID)), nil
+}
+
+// closeLimitOrder moves an open order to status and releases what is left of its hold back to
+// the source wallet. userID, when set, must own the order.
+func (s *ConversionService) closeLimitOrder(ctx context.Context, orderID uuid.UUID, userID *uuid.UUID, status string) (*db.ConversionOrder, decimal.Decimal, error) {
+	var closed db.ConversionOrder
+	var refunded decimal.Decimal
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		order, err := q.GetConversionOrderForUpdate(ctx, orderID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrOrderNotFound
+			}
+			return fmt.Errorf("failed to get limit order: %w", err)
+		}
+		if userID != nil && order.UserID != *userID {
+			return ErrOrderNotFound
+		}
+
+		closed, err = q.CloseConversionOrder(ctx, db.CloseConversionOrderParams{
+			ID:     order.ID,
+			Status: status,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrOrderNotOpen
+			}
+			return fmt.Errorf("failed to close limit order: %w", err)
+		}
+
+		hold, err := q.ReleaseBalanceHold(ctx, order.HoldID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				// a hold fully consumed by fills has nothing left to release
+				return nil
+			}
+			return fmt.Errorf("failed to release hold: %w", err)
+		}
+
+		refunded, _ = decimal.NewFromString(hold.Remaining)
+		if refunded.IsPositive() {
+			_, err = q.IncrementWalletBalance(ctx, db.IncrementWalletBalanceParams{
+				Balance: sql.NullString{String: refunded.String(), Valid: true},
+				ID:      hold.WalletID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to return held funds: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, decimal.Zero, err
+	}
+	return &closed, refunded, nil
+}
+
+// ============================================================
+// ORDER BOOK
+// ============================================================
+
+// ListLimitOrders returns the user's orders with their fills, newest first
+func (s *ConversionService) ListLimitOrders(ctx context.Context, userID uuid.UUID, openOnly bool, limit, offset int32) ([]*LimitOrder, error) {
+	orders, err := s.store.ListConversionOrdersByUser(ctx, db.ListConversionOrdersByUserParams{
+		UserID:    userID,
+		OpenOnly:  openOnly,
+		RowLimit:  limit,
+		RowOffset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit orders: %w", err)
+	}
+
+	result := make([]*LimitOrder, 0, len(orders))
+	for _, order := range orders {
+		result = append(result, s.toLimitOrder(order, s.orderFills(ctx, order.ID)))
+	}
+	return result, nil
+}
+
+// GetLimitOrder returns one of the user's orders with its fill history
+func (s *ConversionService) GetLimitOrder(ctx context.Context, userID, orderID uuid.UUID) (*LimitOrder, error) {
+	order, err := s.store.GetConversionOrder(ctx, orderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get limit order: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, ErrOrderNotFound
+	}
+	return s.toLimitOrder(order, s.orderFills(ctx, order.ID)), nil
+}
+
+func (s *ConversionService) orderFills(ctx context.Context, orderID uuid.UUID) []db.ConversionOrderFill {
+	fills, err := s.store.ListConversionOrderFills(ctx, orderID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to list fills for order %s: %v", orderID, err))
+		return nil
+	}
+	return fills
+}
+
+// ============================================================
+// MATCHING
+// ============================================================
+
+// ProcessLimitOrders expires lapsed orders, then fills open orders whose limit rate the user's
+// current rate meets. Fills per currency pair are capped each interval; orders are filled
+// oldest first and the one reaching the cap fills partially.
+func (s *ConversionService) ProcessLimitOrders(ctx context.Context) error {
+	now := time.Now()
+	s.expireLimitOrders(ctx, now)
+
+	orders, err := s.store.ListOpenConversionOrders(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list open limit orders: %w", err)
+	}
+
+	windowStart := now.Truncate(s.limitOrders.interval())
+	liquidityCap := decimal.NewFromFloat(s.limitOrders.LiquidityCapUSD)
+	minFill := decimal.NewFromFloat(s.limitOrders.MinFillUSD)
+	capacity := make(map[string]decimal.Decimal)
+
+	for _, order := range orders {
+		pair := order.SourceCurrency + "/" + order.TargetCurrency
+		available, ok := capacity[pair]
+		if !ok {
+			used, err := s.store.SumConversionOrderFillsSince(ctx, db.SumConversionOrderFillsSinceParams{
+				SourceCurrency: order.SourceCurrency,
+				TargetCurrency: order.TargetCurrency,
+				CreatedAt:      windowStart,
+			})
+			if err != nil {
+				s.logger.Error(fmt.Sprintf("Failed to sum limit order fills for %s: %v", pair, err))
+				continue
+			}
+			available = liquidityCap.Sub(s.stringToDecimal(used))
+			capacity[pair] = available
+		}
+		if available.LessThan(minFill) {
+			continue
+		}
+
+		filledUsd, err := s.fillLimitOrder(ctx, order, available, minFill)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to fill limit order %s: %v", order.ID, err))
+			continue
+		}
+		capacity[pair] = available.Sub(filledUsd)
+	}
+
+	return nil
+}
+
+// fillLimitOrder converts as much of the order's remaining amount as availableUsd allows, if the
+// user's rate meets the limit. It returns the USD value filled.
+func (s *ConversionService) fillLimitOrder(ctx context.Context, order db.ConversionOrder, availableUsd, minFillUsd decimal.Decimal) (decimal.Decimal, error) {
+	remaining := s.stringToDecimal(order.SourceAmount).Sub(s.stringToDecimal(order.FilledAmount))
+	if !remaining.IsPositive() {
+		return decimal.Zero, nil
+	}
+
+	remainingUsd, err := utils.ConvertToUSD(ctx, remaining, order.SourceCurrency)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to convert amount to USD: %w", err)
+	}
+
+	fillAmount, fillUsd := remaining, remainingUsd
+	if remainingUsd.GreaterThan(availableUsd) {
+		fillAmount = remaining.Mul(availableUsd).Div(remainingUsd).RoundDown(4)
+		fillUsd = availableUsd
+		if !fillAmount.IsPositive() || fillUsd.LessThan(minFillUsd) {
+			return decimal.Zero, nil
+		}
+	}
+
+	rate, err := s.rateManagerService.GetAdjustedRateForUser(ctx, order.UserID, order.SourceCurrency, order.TargetCurrency, fillAmount.String(), ratemanager.ChannelSmartConversion)
+	if err != nil {
+		if errors.Is(err, exchangerate.ErrNoConsensusRate) {
+			// conversions are halted for the pair; the order waits for the next run
+			return decimal.Zero, nil
+		}
+		return decimal.Zero, fmt.Errorf("failed to get rate: %w", err)
+	}
+
+	executedRate := s.stringToDecimal(rate.AdjustedRate)
+	limitRate := s.stringToDecimal(order.LimitRate)
+	if executedRate.LessThan(limitRate) {
+		return decimal.Zero, nil
+	}
+
+	feePercentage := s.exchangeRateService.GetFeePercentage(order.SourceCurrency, order.TargetCurrency)
+	targetAmount, fees, netAmount := s.exchangeRateService.CalculateConversionAmount(fillAmount, executedRate, feePercentage)
+
+	s.logger.Info(fmt.Sprintf("Filling limit order %s: %s %s at %s (limit %s)", order.ID, fillAmount, order.SourceCurrency, executedRate, limitRate))
+
+	triggerType := limitOrderTriggerType
+	_, err = s.executeConversion(ctx, &conversionExecutionParams{
+		userID:         order.UserID,
+		sourceWalletID: order.SourceWalletID,
+		targetWalletID: order.TargetWalletID,
+		sourceCurrency: order.SourceCurrency,
+		targetCurrency: order.TargetCurrency,
+		sourceAmount:   fillAmount,
+		targetAmount:   targetAmount,
+		fees:           fees,
+		netAmount:      netAmount,
+		executedRate:   executedRate,
+		midRate:        s.stringToDecimal(rate.BaseRate),
+		triggerRate:    &limitRate,
+		executionType:  "automatic",
+		triggerType:    &triggerType,
+		rateProvider:   rate.RateProvider,
+		rateRuleID:     rate.RuleID,
+		rateRuleName:   rate.RuleApplied,
+		vipLevelID:     rate.VIPLevelID,
+		vipLevelName:   rate.VIPLevelApplied,
+		orderFill:      &orderFill{order: order, amountUsd: fillUsd},
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return fillUsd, nil
+}
+
+// recordOrderFill draws the fill from the order's hold and adds it to the order's fill history
+func (s *ConversionService) recordOrderFill(ctx context.Context, q *db.Queries, fill *orderFill, params *conversionExecutionParams, historyID, transactionID uuid.UUID) (db.ConversionOrder, error) {
+	_, err := q.ConsumeBalanceHold(ctx, db.ConsumeBalanceHoldParams{
+		Amount: params.sourceAmount.String(),
+		ID:     fill.order.HoldID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.ConversionOrder{}, ErrOrderNotOpen
+		}
+		return db.ConversionOrder{}, fmt.Errorf("failed to consume hold: %w", err)
+	}
+
+	order, err := q.RecordConversionOrderFill(ctx, db.RecordConversionOrderFillParams{
+		FillAmount:     params.sourceAmount.String(),
+		ReceivedAmount: params.netAmount.String(),
+		Fees:           params.fees.String(),
+		ID:             fill.order.ID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.ConversionOrder{}, ErrOrderNotOpen
+		}
+		return db.ConversionOrder{}, fmt.Errorf("failed to record order fill: %w", err)
+	}
+
+	_, err = q.CreateConversionOrderFill(ctx, db.CreateConversionOrderFillParams{
+		OrderID:             fill.order.ID,
+		ConversionHistoryID: uuid.NullUUID{UUID: historyID, Valid: true},
+		TransactionID:       uuid.NullUUID{UUID: transactionID, Valid: true},
+		SourceCurrency:      params.sourceCurrency,
+		TargetCurrency:      params.targetCurrency,
+		SourceAmount:        params.sourceAmount.String(),
+		ExecutedRate:        params.executedRate.String(),
+		TargetAmount:        params.targetAmount.String(),
+		Fees:                params.fees.String(),
+		NetAmount:           params.netAmount.String(),
+		SourceAmountUsd:     fill.amountUsd.String(),
+	})
+	if err != nil {
+		return db.ConversionOrder{}, fmt.Errorf("failed to create order fill: %w", err)
+	}
+	return order, nil
+}
+
+// expireLimitOrders closes good-till-date orders past their expiry and returns the held funds
+func (s *ConversionService) expireLimitOrders(ctx context.Context, now time.Time) {
+	orders, err := s.store.ListExpiredConversionOrders(ctx, sql.NullTime{Time: now, Valid: true})
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to list expired limit orders: %v", err))
+		return
+	}
+
+	for _, order := range orders {
+		closed, refunded, err := s.closeLimitOrder(ctx, order.ID, nil, OrderStatusExpired)
+		if err != nil {
+			if err != ErrOrderNotOpen {
+				s.logger.Error(fmt.Sprintf("Failed to expire limit order %s: %v", order.ID, err))
+			}
+			continue
+		}
+
+		msg := fmt.Sprintf("Your limit order to convert %s to %s has expired", closed.SourceCurrency, closed.TargetCurrency)
+		if refunded.IsPositive() {
+			msg += fmt.Sprintf(" and %s %s has been returned to your wallet", refunded.String(), closed.SourceCurrency)
+		}
+		s.notifyUser(ctx, closed.UserID, "Limit Order Expired", msg)
+	}
+}
+
+// notifyOrderFill tells the user a fill happened and whether the order is complete
+func (s *ConversionService) notifyOrderFill(ctx context.Context, order db.ConversionOrder, params *conversionExecutionParams) {
+	if order.Status == OrderStatusFilled {
+		s.notifyUser(ctx, order.UserID, "Limit Order Filled",
+			fmt.Sprintf("Your limit order to convert %s %s to %s has been filled. You received %s %s in total",
+				order.SourceAmount, order.SourceCurrency, order.TargetCurrency, order.ReceivedAmount, order.TargetCurrency))
+		return
+	}
+
+	remaining := s.stringToDecimal(order.SourceAmount).Sub(s.stringToDecimal(order.FilledAmount))
+	s.notifyUser(ctx, order.UserID, "Limit Order Partially Filled",
+		fmt.Sprintf("%s %s of your limit order was converted to %s %s at %s. The remaining %s %s stays open",
+			params.sourceAmount.String(), order.SourceCurrency, params.netAmount.String(), order.TargetCurrency,
+			params.executedRate.String(), remaining.String(), order.SourceCurrency))
+}
+
+func (s *ConversionService) notifyUser(ctx context.Context, userID uuid.UUID, title, msg string) {
+	if s.push != nil {
+		s.push.SendPushNotification(ctx, userID, title, msg)
+	}
+	s.notifyr.CreateWithRecipients(ctx, nil, title, msg, "system", []uuid.UUID{userID})
+}
+
+func (s *ConversionService) toLimitOrder(order db.ConversionOrder, fills []db.ConversionOrderFill) *LimitOrder {
+	sourceAmount := s.stringToDecimal(order.SourceAmount)
+	filled := s.stringToDecimal(order.FilledAmount)
+	received := s.stringToDecimal(order.ReceivedAmount)
+	fees := s.stringToDecimal(order.FeesPaid)
+
+	result := &LimitOrder{
+		ID:              order.ID,
+		SourceCurrency:  order.SourceCurrency,
+		TargetCurrency:  order.TargetCurrency,
+		LimitRate:       s.stringToDecimal(order.LimitRate),
+		SourceAmount:    sourceAmount,
+		FilledAmount:    filled,
+		RemainingAmount: sourceAmount.Sub(filled),
+		ReceivedAmount:  received,
+		FeesPaid:        fees,
+		TimeInForce:     order.TimeInForce,
+		ExpiresAt:       s.nullTimeToTime(order.ExpiresAt),
+		Status:          order.Status,
+		Label:           s.nullStringToString(order.Label),
+		LastFilledAt:    s.nullTimeToTime(order.LastFilledAt),
+		ClosedAt:        s.nullTimeToTime(order.ClosedAt),
+		CreatedAt:       order.CreatedAt,
+		Fills:           make([]LimitOrderFill, 0, len(fills)),
+	}
+	if filled.IsPositive() {
+		// gross target per unit of source across all fills
+		average := received.Add(fees).Div(filled).Round(10)
+		result.AverageRate = &average
+	}
+
+	for _, f := range fills {
+		fill := LimitOrderFill{
+			ID:           f.ID,
+			SourceAmount: s.stringToDecimal(f.SourceAmount),
+			ExecutedRate: s.stringToDecimal(f.ExecutedRate),
+			TargetAmount: s.stringToDecimal(f.TargetAmount),
+			Fees:         s.stringToDecimal(f.Fees),
+			NetAmount:    s.stringToDecimal(f.NetAmount),
+			CreatedAt:    f.CreatedAt,
+		}
+		if f.TransactionID.Valid {
+			txID := f.TransactionID.UUID
+			fill.TransactionID = &txID
+		}
+		result.Fills = append(result.Fills, fill)
+	}
+	return result
+}
@@ -64,8 +64,34 @@ func (s *Scheduler) Start() error {
 		return err
 	}
 
+	// Register task for expiring and filling limit orders
+	_, err = s.taskScheduler.AddTask(
+		"limit-order-smart-conversion-process",
+		"Process Smart Conversion Limit Orders",
+		s.processLimitOrders,
+		s.checkInterval,
+	)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to register limit order task: %v", err))
+		return err
+	}
+
+	// Register task for running due DCA plans
+	_, err = s.taskScheduler.AddTask(
+		"dca-plan-smart-conversion-process",
+		"Process DCA Plans",
+		s.processDCAPlans,
+		s.checkInterval,
+	)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to register DCA plan task: %v", err))
+		return err
+	}
+
 	// Start all tasks with 10 second initial delay
 	s.taskScheduler.ScheduleTask("scheduled-smart-conversion-process", 10*time.Second)
+	s.taskScheduler.ScheduleTask("limit-order-smart-conversion-process", 10*time.Second)
+	s.taskScheduler.ScheduleTask("dca-plan-smart-conversion-process", 10*time.Second)
 	s.logger.Info(fmt.Sprintf("Smart conversion scheduler started. Checking every %s", s.checkInterval))
 	return nil
 }
@@ -73,6 +99,8 @@ func (s *Scheduler) Start() error {
 func (s *Scheduler) Stop() error {
 	s.logger.Info("Stopping smart conversion scheduler...")
 	s.taskScheduler.StopTask("scheduled-smart-conversion-process")
+	s.taskScheduler.StopTask("limit-order-smart-conversion-process")
+	s.taskScheduler.StopTask("dca-plan-smart-conversion-process")
 	s.logger.Info("Smart conversion scheduler stopped")
 	return nil
 }
@@ -85,6 +113,14 @@ func (s *Scheduler) processRateBasedSmartConversions(ctx context.Context) error
 	return s.smartConversionService.CheckAndExecuteRateBasedRules(ctx)
 }
 
+func (s *Scheduler) processLimitOrders(ctx context.Context) error {
+	return s.smartConversionService.ProcessLimitOrders(ctx)
+}
+
+func (s *Scheduler) processDCAPlans(ctx context.Context) error {
+	return s.smartConversionService.ExecuteDCAPlans(ctx)
+}
+
 // TriggerScheduledConversions manually triggers the processing of scheduled smart conversions.
 func (s *Scheduler) TriggerScheduledConversions(ctx context.Context) error {
 	s.logger.Info("Manually triggering scheduled smart conversions processing")
@@ -102,7 +138,7 @@ func (s *Scheduler) GetStats(ctx context.Context) map[string]interface{} {
 	stats := make(map[string]interface{})
 	stats["check_interval"] = s.checkInterval.String()
 
-	tasks := []string{"scheduled-smart-conversion-process", "rate-based-smart-conversion-process"}
+	tasks := []string{"scheduled-smart-conversion-process", "rate-based-smart-conversion-process", "limit-order-smart-conversion-process", "dca-plan-smart-conversion-process"}
 	taskStats := make(map[string]interface{})
 
 	for _, taskID := range tasks {
@@ -3,14 +3,18 @@ package smartconversion
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
 	exchangerate "github.com/SwiftFiat/SwiftFiat-Backend/services/exchange_rate"
+	fxrevenue "github.com/SwiftFiat/SwiftFiat-Backend/services/fx_revenue"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
 	service "github.com/SwiftFiat/SwiftFiat-Backend/services/notification"
 	ratemanager "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_manager"
+	ratequote "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_quote"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/recurrence"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/streaks"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/transaction"
 	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
@@ -23,10 +27,14 @@ type ConversionService struct {
 	logger              *logging.Logger
 	rateManagerService  *ratemanager.Service
 	exchangeRateService *exchangerate.ExchangeRateService
+	quoteService        *ratequote.Service
+	revenueService      *fxrevenue.Service
 	transactionService  *transaction.TransactionService
 	streakScheduler     *streaks.StreakScheduler
 	notifyr             *service.Notification
 	push                *service.PushNotificationService
+	limitOrders         LimitOrderConfig
+	alertConversions    AlertConversionConfig
 }
 
 func NewConversionService(
@@ -34,6 +42,8 @@ func NewConversionService(
 	logger *logging.Logger,
 	rateManagerService *ratemanager.Service,
 	exchangeRateService *exchangerate.ExchangeRateService,
+	quoteService *ratequote.Service,
+	revenueService *fxrevenue.Service,
 	transactionService *transaction.TransactionService,
 	streakScheduler *streaks.StreakScheduler,
 	notifyr *service.Notification,
@@ -44,10 +54,14 @@ func NewConversionService(
 		logger:              logger,
 		rateManagerService:  rateManagerService,
 		exchangeRateService: exchangeRateService,
+		quoteService:        quoteService,
+		revenueService:      revenueService,
 		transactionService:  transactionService,
 		streakScheduler:     streakScheduler,
 		notifyr:             notifyr,
 		push:                push,
+		limitOrders:         LoadLimitOrderConfig(),
+		alertConversions:    LoadAlertConversionConfig(),
 	}
 }
 
@@ -242,9 +256,18 @@ func (s *ConversionService) ExecuteManualConversion(ctx context.Context, req *Ma
 		return nil, fmt.Errorf("Err_KYC_NEED_TIER_2")
 	}
 
+	// a quote fixes the rate, fees and net amount the user was shown
+	if req.QuoteID != "" {
+		return s.executeQuotedConversion(ctx, req, user.ID, amount, sourceWallet.ID, targetWallet.ID)
+	}
+
 	// Get vip adjusted rate
-	rate, err := s.rateManagerService.GetAdjustedRateForUser(ctx, user.ID, req.SourceCurrency, req.TargetCurrency, req.Amount)
+	rate, err := s.rateManagerService.GetAdjustedRateForUser(ctx, user.ID, req.SourceCurrency, req.TargetCurrency, req.Amount, ratemanager.ChannelSmartConversion)
 	if err != nil {
+		// no fresh consensus rate: conversions are halted until sources agree again
+		if errors.Is(err, exchangerate.ErrNoConsensusRate) {
+			return nil, err
+		}
 		s.logger.Warnf("%s", fmt.Sprintf("Failed to get VIP-adjusted rate: %v", err))
 		// fallback to base rate if vip rate fails
 		s.logger.Warnf("%s", fmt.Sprintf("Falling back to base rate for user %d", user.ID))
@@ -255,6 +278,11 @@ func (s *ConversionService) ExecuteManualConversion(ctx context.Context, req *Ma
 		s.logger.Infof("VIP adjusted rate for %s to %s is %s", req.SourceCurrency, req.TargetCurrency, rate.AdjustedRate)
 	}
 
+	baseRate, err := utils.ToDecimal(rate.BaseRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert base rate to decimal: %w", err)
+	}
+
 	// Calculate amounts
 	fees, err := utils.ToDecimal(rate.Fees)
 	if err != nil {
@@ -290,10 +318,16 @@ func (s *ConversionService) ExecuteManualConversion(ctx context.Context, req *Ma
 		fees:           fees,
 		netAmount:      netAmount,
 		executedRate:   adjustedRate,
+		midRate:        baseRate,
 		triggerRate:    nil,
 		executionType:  "manual",
 		triggerType:    nil,
 		rateProvider:   rate.RateProvider,
+		reference:      req.Reference,
+		rateRuleID:     rate.RuleID,
+		rateRuleName:   rate.RuleApplied,
+		vipLevelID:     rate.VIPLevelID,
+		vipLevelName:   rate.VIPLevelApplied,
 	})
 	if err != nil {
 		return nil, err
@@ -302,10 +336,157 @@ func (s *ConversionService) ExecuteManualConversion(ctx context.Context, req *Ma
 	return history, nil
 }
 
+// QuoteConversion prices a manual conversion and locks the result in a short-lived, single-use quote
+func (s *ConversionService) QuoteConversion(ctx context.Context, req *ConversionQuoteRequest, user *db.User) (*ratequote.Quote, error) {
+	if err := s.exchangeRateService.ValidateCurrencyPair(req.SourceCurrency, req.TargetCurrency); err != nil {
+		return nil, err
+	}
+
+	amount, err := utils.ToDecimal(req.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount to decimal: %w", err)
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("amount must be greater than zero")
+	}
+
+	kyc, err := s.store.Queries.GetKYCByUserID(ctx, user.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("Err_KYC_NOT_FOUND")
+		}
+		return nil, fmt.Errorf("failed to fetch KYC: %w", err)
+	}
+	if kyc.Tier == "tier_1" {
+		return nil, fmt.Errorf("Err_KYC_NEED_TIER_2")
+	}
+
+	// price exactly as ExecuteManualConversion would, including the base-rate fallback
+	var executedRate, feeInput, midRate decimal.Decimal
+	var rateProvider string
+	var rateRuleID, vipLevelID *uuid.UUID
+	var rateRuleName, vipLevelName *string
+
+	rate, err := s.rateManagerService.GetAdjustedRateForUser(ctx, user.ID, req.SourceCurrency, req.TargetCurrency, req.Amount, ratemanager.ChannelSmartConversion)
+	if err != nil {
+		if errors.Is(err, exchangerate.ErrNoConsensusRate) {
+			return nil, err
+		}
+		s.logger.Warnf("%s", fmt.Sprintf("Failed to get VIP-adjusted rate for quote, using base rate: %v", err))
+
+		baseRate, err := s.exchangeRateService.GetExchangeRate(ctx, req.SourceCurrency, req.TargetCurrency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get base rate: %w", err)
+		}
+		executedRate = baseRate.Rate
+		midRate = baseRate.Rate
+		feeInput = s.exchangeRateService.GetFeePercentage(req.SourceCurrency, req.TargetCurrency)
+		rateProvider = baseRate.Provider
+	} else {
+		if executedRate, err = utils.ToDecimal(rate.AdjustedRate); err != nil {
+			return nil, fmt.Errorf("failed to convert adjusted rate to decimal: %w", err)
+		}
+		if feeInput, err = utils.ToDecimal(rate.Fees); err != nil {
+			return nil, fmt.Errorf("failed to convert fees to decimal: %w", err)
+		}
+		if midRate, err = utils.ToDecimal(rate.BaseRate); err != nil {
+			return nil, fmt.Errorf("failed to convert base rate to decimal: %w", err)
+		}
+		rateProvider = rate.RateProvider
+		rateRuleID, rateRuleName = rate.RuleID, rate.RuleApplied
+		vipLevelID, vipLevelName = rate.VIPLevelID, rate.VIPLevelApplied
+	}
+
+	targetAmount, fees, netAmount := s.exchangeRateService.CalculateConversionAmount(amount, executedRate, feeInput)
+
+	return s.quoteService.Issue(ctx, ratequote.IssueParams{
+		UserID:         user.ID,
+		Purpose:        ratequote.PurposeConversion,
+		SourceCurrency: req.SourceCurrency,
+		TargetCurrency: req.TargetCurrency,
+		SourceAmount:   amount,
+		Rate:           executedRate,
+		Fees:           fees,
+		TargetAmount:   targetAmount,
+		NetAmount:      netAmount,
+		RateProvider:   rateProvider,
+		TTL:            ratequote.ConversionQuoteTTL,
+		MidRate:        midRate,
+		RuleID:         rateRuleID,
+		RuleName:       rateRuleName,
+		VIPLevelID:     vipLevelID,
+		VIPLevelName:   vipLevelName,
+	})
+}
+
+// GetQuote returns one of the user's conversion quotes, whatever its status
+func (s *ConversionService) GetQuote(ctx context.Context, quoteID string, userID uuid.UUID) (*ratequote.Quote, error) {
+	quote, err := s.quoteService.Get(ctx, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.UserID != userID || quote.Purpose != ratequote.PurposeConversion {
+		return nil, ratequote.ErrQuoteNotFound
+	}
+	return quote, nil
+}
+
 // ============================================================
 // HELPER FUNCTIONS
 // ============================================================
 
+// executeQuotedConversion executes a manual conversion at the exact terms of a quote
+func (s *ConversionService) executeQuotedConversion(
+	ctx context.Context,
+	req *ManualConversionRequest,
+	userID uuid.UUID,
+	amount decimal.Decimal,
+	sourceWalletID uuid.UUID,
+	targetWalletID uuid.UUID,
+) (*ManualConversionResponse, error) {
+	terms, err := s.quoteService.Resolve(ctx, req.QuoteID, userID, ratequote.PurposeConversion)
+	if err != nil {
+		return nil, err
+	}
+
+	if terms.SourceCurrency != req.SourceCurrency || terms.TargetCurrency != req.TargetCurrency || !terms.SourceAmount.Equal(amount) {
+		return nil, ratequote.ErrQuoteMismatch
+	}
+
+	s.logger.Infof("executing quote %s: %s %s -> %s at %s", terms.ID, terms.SourceAmount, terms.SourceCurrency, terms.TargetCurrency, terms.Rate)
+
+	history, err := s.executeConversion(ctx, &conversionExecutionParams{
+		userID:         userID,
+		ruleID:         nil,
+		sourceWalletID: sourceWalletID,
+		targetWalletID: targetWalletID,
+		sourceCurrency: terms.SourceCurrency,
+		targetCurrency: terms.TargetCurrency,
+		sourceAmount:   terms.SourceAmount,
+		targetAmount:   terms.TargetAmount,
+		fees:           terms.Fees,
+		netAmount:      terms.NetAmount,
+		executedRate:   terms.Rate,
+		midRate:        terms.MidRate,
+		triggerRate:    nil,
+		executionType:  "manual",
+		triggerType:    nil,
+		rateProvider:   terms.RateProvider,
+		quoteID:        &terms.ID,
+		reference:      req.Reference,
+		rateRuleID:     terms.RuleID,
+		rateRuleName:   terms.RuleName,
+		vipLevelID:     terms.VIPLevelID,
+		vipLevelName:   terms.VIPLevelName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history.QuoteID = terms.QuoteID
+	return history, nil
+}
+
 // executeWithBaseRate is a fallback when VIP rate calculation fails
 func (s *ConversionService) executeWithBaseRate(
 	ctx context.Context,
@@ -358,10 +539,12 @@ func (s *ConversionService) executeWithBaseRate(
 		fees:           fees,
 		netAmount:      netAmount,
 		executedRate:   baseRate.Rate,
+		midRate:        baseRate.Rate,
 		triggerRate:    nil,
 		executionType:  "manual",
 		triggerType:    nil,
 		rateProvider:   baseRate.Provider,
+		reference:      req.Reference,
 	})
 	if err != nil {
 		return nil, err
@@ -382,49 +565,63 @@ type conversionExecutionParams struct {
 	fees           decimal.Decimal
 	netAmount      decimal.Decimal
 	executedRate   decimal.Decimal
-	triggerRate    *decimal.Decimal
-	executionType  string
-	triggerType    *string
-	rateProvider   string
+	// midRate is the unadjusted market rate; zero when unknown
+	midRate       decimal.Decimal
+	triggerRate   *decimal.Decimal
+	executionType string
+	triggerType   *string
+	rateProvider  string
+	// quoteID is consumed in the same transaction as the conversion
+	quoteID *uuid.UUID
+	// reference is the caller's idempotency key for the conversion; empty generates one
+	reference string
+	// rate manager rule and VIP level that priced the conversion, for revenue attribution
+	rateRuleID   *uuid.UUID
+	rateRuleName *string
+	vipLevelID   *uuid.UUID
+	vipLevelName *string
+	// orderFill is set when the conversion fills a limit order
+	orderFill *orderFill
+	// dcaRun is set when the conversion is a DCA plan's scheduled run
+	dcaRun *dcaRun
+	// alertRun is set when the conversion is a triggered price alert's action
+	alertRun *alertRun
 }
 
-// executeConversion performs the actual conversion in a database transaction
+// executeConversion performs the actual conversion in a database transaction. Both wallets are
+// locked inside the transaction and moved by delta, and nothing is announced until it commits.
 func (s *ConversionService) executeConversion(ctx context.Context, params *conversionExecutionParams) (*ManualConversionResponse, error) {
-
-	sourceWallet, err := s.store.GetWalletForUpdate(ctx, params.sourceWalletID)
+	dbTx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get source wallet: %w", err)
+		return nil, fmt.Errorf("begin transaction: %w", err)
 	}
+	defer dbTx.Rollback()
+
+	qtx := s.store.WithTx(dbTx)
 
-	targetWallet, err := s.store.GetWalletForUpdate(ctx, params.targetWalletID)
+	sourceWallet, targetWallet, err := lockConversionWallets(ctx, qtx, params.sourceWalletID, params.targetWalletID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get target wallet: %w", err)
+		return nil, err
 	}
 
 	sourceBalance, _ := decimal.NewFromString(sourceWallet.Balance.String)
-	if params.sourceAmount.GreaterThan(sourceBalance) {
-		return nil, ErrInsufficientBalance
+	// a limit order's funds were taken out of the wallet into a hold when it was placed
+	newSourceBalance := sourceBalance
+	if params.orderFill == nil {
+		if params.sourceAmount.GreaterThan(sourceBalance) {
+			return nil, ErrInsufficientBalance
+		}
+		newSourceBalance = sourceBalance.Sub(params.sourceAmount)
 	}
 
 	// calculate new balances
-	newSourceBalance := sourceBalance.Sub(params.sourceAmount)
 	targetBalance, _ := decimal.NewFromString(targetWallet.Balance.String)
 	newTargetBalance := targetBalance.Add(params.netAmount)
 
-	dbTx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
-	if err != nil {
-		return nil, fmt.Errorf("begin transaction: %w", err)
+	reference := params.reference
+	if reference == "" {
+		reference = utils.WatRequestID()
 	}
-	defer dbTx.Rollback()
-
-	// Commit at the end before returning
-	defer func() {
-		if err == nil {
-			err = dbTx.Commit()
-		}
-	}()
-
-	qtx := s.store.WithTx(dbTx)
 
 	amountUsd, err := utils.ConvertToUSD(ctx, params.sourceAmount, params.sourceCurrency)
 	if err != nil {
@@ -440,7 +637,7 @@ func (s *ConversionService) executeConversion(ctx context.Context, params *conve
 		Currency:        params.sourceCurrency,
 		AmountUsd:       amountUsd.String(),
 		Status:          string(transaction.Pending),
-		IdempotencyKey:  utils.WatRequestID(),
+		IdempotencyKey:  reference,
 		TFrom:           params.sourceCurrency,
 		TTo:             params.targetCurrency,
 		Direction:       "conversion",
@@ -449,6 +646,12 @@ func (s *ConversionService) executeConversion(ctx context.Context, params *conve
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
+	if params.quoteID != nil {
+		if err = s.quoteService.Consume(ctx, qtx, *params.quoteID, mainTx.IdempotencyKey, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create conversion history
 	history, err := qtx.CreateConversionHistory(ctx, db.CreateConversionHistoryParams{
 		ConversionRuleID:    s.uuidToNullUUID(params.ruleID),
@@ -478,19 +681,41 @@ func (s *ConversionService) executeConversion(ctx context.Context, params *conve
 		return nil, fmt.Errorf("failed to create conversion history: %w", err)
 	}
 
-	// update source wallet
-	_, err = qtx.UpdateWalletBalance(ctx, db.UpdateWalletBalanceParams{
-		Amount: sql.NullString{String: newSourceBalance.String(), Valid: true},
-		ID:     params.sourceWalletID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to update source wallet: %w", err)
+	// update source wallet, or draw a limit order fill from its hold
+	var filledOrder db.ConversionOrder
+	var dcaPlan db.DcaPlan
+	if params.orderFill != nil {
+		filledOrder, err = s.recordOrderFill(ctx, qtx, params.orderFill, params, history.ID, mainTx.ID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		_, err = qtx.DecrementWalletBalance(ctx, db.DecrementWalletBalanceParams{
+			Balance: sql.NullString{String: params.sourceAmount.String(), Valid: true},
+			ID:      params.sourceWalletID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update source wallet: %w", err)
+		}
+	}
+
+	if params.dcaRun != nil {
+		dcaPlan, err = s.recordDCARun(ctx, qtx, params.dcaRun, params, history.ID, mainTx.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if params.alertRun != nil {
+		if err = s.recordAlertRun(ctx, qtx, params.alertRun, params, history.ID, mainTx.ID); err != nil {
+			return nil, err
+		}
 	}
 
 	// update target wallet
-	_, err = qtx.UpdateWalletBalance(ctx, db.UpdateWalletBalanceParams{
-		ID:     params.targetWalletID,
-		Amount: sql.NullString{String: newTargetBalance.String(), Valid: true},
+	_, err = qtx.IncrementWalletBalance(ctx, db.IncrementWalletBalanceParams{
+		Balance: sql.NullString{String: params.netAmount.String(), Valid: true},
+		ID:      params.targetWalletID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to update target wallet: %w", err)
@@ -513,16 +738,27 @@ func (s *ConversionService) executeConversion(ctx context.Context, params *conve
 		return nil, fmt.Errorf("failed to update conversion history: %v", err)
 	}
 
-	// Update user streak
-	if err := s.streakScheduler.UpdateStreakOnTransaction(ctx, params.userID, history.ID, "conversion"); err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to update user streak: %v", err))
-		// Don't fail the conversion for this
-	}
-
-	// Increment user's conversion volume for VIP tracking
-	if err := s.rateManagerService.IncrementUserConversionVolume(ctx, params.userID, params.targetAmount); err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to increment conversion volume for user %d: %v", params.userID, err))
-		// Don't fail the conversion for this
+	// attribute spread and fee revenue in the same transaction so every conversion has an entry
+	err = s.revenueService.Record(ctx, qtx, fxrevenue.Entry{
+		Channel:        fxrevenue.ChannelSmartConversion,
+		ReferenceID:    history.ID,
+		TransactionID:  &mainTx.ID,
+		UserID:         params.userID,
+		SourceCurrency: params.sourceCurrency,
+		TargetCurrency: params.targetCurrency,
+		SourceAmount:   params.sourceAmount,
+		MidRate:        params.midRate,
+		AppliedRate:    params.executedRate,
+		Fees:           params.fees,
+		RuleID:         params.rateRuleID,
+		RuleName:       params.rateRuleName,
+		VIPLevelID:     params.vipLevelID,
+		VIPLevelName:   params.vipLevelName,
+		RateProvider:   params.rateProvider,
+		OccurredAt:     history.CreatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record conversion revenue: %w", err)
 	}
 
 	user, userErr := s.store.GetUserByID(ctx, params.userID)
@@ -530,19 +766,20 @@ func (s *ConversionService) executeConversion(ctx context.Context, params *conve
 		s.logger.Error("failed to get user")
 	}
 
+	// referral credits post in the conversion transaction; their notifications wait for the commit
+	var referralNotices []func()
 	if userErr == nil && !user.HasCompletedFirstConversion.Bool {
 		referrerID, referralBonus, refErr := transaction.CheckFirstConersionAndDisburseReferralBonus(ctx, s.store, dbTx, params.userID, mainTx.ID)
 		if refErr != nil {
 			s.logger.Errorf("Failed to disburse referral bonus: %v", refErr)
 		}
 		if referrerID != nil && referralBonus != nil {
-			// go func() {
-			// bgCtx := context.Background()
-			s.notifyr.CreateWithRecipients(ctx, nil, "Referral Bonus Credit",
-				fmt.Sprintf("You have received a referral bonus of %s", referralBonus.String()),
-				"system", []uuid.UUID{*referrerID})
-			s.push.ReferralBonusEarned(ctx, *referrerID, referralBonus.String())
-			// }()
+			referralNotices = append(referralNotices, func() {
+				s.notifyr.CreateWithRecipients(ctx, nil, "Referral Bonus Credit",
+					fmt.Sprintf("You have received a referral bonus of %s", referralBonus.String()),
+					"system", []uuid.UUID{*referrerID})
+				s.push.ReferralBonusEarned(ctx, *referrerID, referralBonus.String())
+			})
 		}
 	}
 
@@ -550,26 +787,54 @@ func (s *ConversionService) executeConversion(ctx context.Context, params *conve
 	if creditErr != nil {
 		s.logger.Errorf("failed to credit referrer for conversion: %v", creditErr)
 	} else {
-		// go func() {
-		// bgCtx := context.Background()
-		s.notifyr.CreateWithRecipients(ctx, nil, "Referral conversion Bonus Earned",
-			fmt.Sprintf("You have earned %s from a referral conversion", amountEarned.String()),
-			"system", []uuid.UUID{*referrerID})
-		s.push.SendPushNotification(ctx, *referrerID, "Referral conversion Bonus Earned",
-			fmt.Sprintf("You have earned %s from a referral conversion", amountEarned.String()))
-		// }()
+		referralNotices = append(referralNotices, func() {
+			s.notifyr.CreateWithRecipients(ctx, nil, "Referral conversion Bonus Earned",
+				fmt.Sprintf("You have earned %s from a referral conversion", amountEarned.String()),
+				"system", []uuid.UUID{*referrerID})
+			s.push.SendPushNotification(ctx, *referrerID, "Referral conversion Bonus Earned",
+				fmt.Sprintf("You have earned %s from a referral conversion", amountEarned.String()))
+		})
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit conversion: %w", err)
+	}
+
+	// Update user streak
+	if err := s.streakScheduler.UpdateStreakOnTransaction(ctx, params.userID, history.ID, "conversion"); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to update user streak: %v", err))
+		// Don't fail the conversion for this
+	}
+
+	// Increment user's conversion volume for VIP tracking
+	if err := s.rateManagerService.IncrementUserConversionVolume(ctx, params.userID, params.targetAmount); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to increment conversion volume for user %d: %v", params.userID, err))
+		// Don't fail the conversion for this
+	}
+
+	for _, notice := range referralNotices {
+		notice()
 	}
 
 	// send notification
 	// go func() {
 	// bgCtx := context.Background()
-	if s.push != nil {
-		s.push.SendPushNotification(ctx, params.userID, "Conversion Successful",
-			fmt.Sprintf("Your conversion of %s %s to %s %s is successful", params.sourceAmount.String(), params.sourceCurrency, params.targetAmount.String(), params.targetCurrency))
+	switch {
+	case params.orderFill != nil:
+		s.notifyOrderFill(ctx, filledOrder, params)
+	case params.dcaRun != nil:
+		s.notifyDCARun(ctx, dcaPlan, params)
+	case params.alertRun != nil:
+		s.notifyAlertConversion(ctx, params.alertRun, params)
+	default:
+		if s.push != nil {
+			s.push.SendPushNotification(ctx, params.userID, "Conversion Successful",
+				fmt.Sprintf("Your conversion of %s %s to %s %s is successful", params.sourceAmount.String(), params.sourceCurrency, params.targetAmount.String(), params.targetCurrency))
+		}
+		s.notifyr.CreateWithRecipients(ctx, nil, "Conversion Completed",
+			fmt.Sprintf("You have converted %s %s to %s %s", params.sourceAmount.String(), params.sourceCurrency, params.targetAmount.String(), params.targetCurrency),
+			"system", []uuid.UUID{params.userID})
 	}
-	s.notifyr.CreateWithRecipients(ctx, nil, "Conversion Completed",
-		fmt.Sprintf("You have converted %s %s to %s %s", params.sourceAmount.String(), params.sourceCurrency, params.targetAmount.String(), params.targetCurrency),
-		"system", []uuid.UUID{params.userID})
 	// }()
 
 	return &ManualConversionResponse{
@@ -583,6 +848,31 @@ func (s *ConversionService) executeConversion(ctx context.Context, params *conve
 	}, nil
 }
 
+// lockConversionWallets locks both wallets of a conversion in a fixed order, so two conversions
+// between the same pair of wallets in opposite directions cannot deadlock
+func lockConversionWallets(ctx context.Context, qtx *db.Queries, sourceID, targetID uuid.UUID) (source, target db.SwiftWallet, err error) {
+	lock := func(id uuid.UUID, name string) (db.SwiftWallet, error) {
+		wallet, err := qtx.GetWalletForUpdate(ctx, id)
+		if err != nil {
+			return wallet, fmt.Errorf("failed to get %s wallet: %w", name, err)
+		}
+		return wallet, nil
+	}
+
+	if sourceID.String() < targetID.String() {
+		if source, err = lock(sourceID, "source"); err != nil {
+			return
+		}
+		target, err = lock(targetID, "target")
+		return
+	}
+	if target, err = lock(targetID, "target"); err != nil {
+		return
+	}
+	source, err = lock(sourceID, "source")
+	return
+}
+
 // CheckAndExecuteRateBasedRules checks rate-based rules and executes if triggered
 func (s *ConversionService) CheckAndExecuteRateBasedRules(ctx context.Context) error {
 	s.logger.Info("Checking rate-based conversion rules")
@@ -739,6 +1029,7 @@ func (s *ConversionService) executeRuleConversion(ctx context.Context, rule *db.
 		fees:           fees,
 		netAmount:      netAmount,
 		executedRate:   rate.Rate,
+		midRate:        rate.Rate,
 		triggerRate:    s.nullStringToDecimal(rule.TriggerRate),
 		executionType:  "automatic",
 		triggerType:    &triggerType,
@@ -847,103 +1138,61 @@ func (s *ConversionService) dbRuleToModel(rule *db.ConversionRule) *ConversionRu
 	}
 }
 
-// ...existing code...
+// calculateNextExecution returns the next scheduled run using the shared recurrence
+// schedule. Missing or unknown timezones fall back to UTC and an unknown frequency
+// runs tomorrow at the scheduled time.
 func (s *ConversionService) calculateNextExecution(frequency *string, dayOfWeek, dayOfMonth *int, scheduleTime *string, timezone *string) time.Time {
-	// Determine location
-	loc := time.UTC
+	schedule := recurrence.Schedule{Frequency: recurrence.Daily, Timezone: "UTC"}
 	if timezone != nil && *timezone != "" {
-		if l, err := time.LoadLocation(*timezone); err == nil {
-			loc = l
+		if _, err := time.LoadLocation(*timezone); err == nil {
+			schedule.Timezone = *timezone
 		}
 	}
+	loc, _ := schedule.Location()
 	now := time.Now().In(loc)
 
-	// parse scheduled time (HH:MM) if provided
-	hour, min := 0, 0
 	if scheduleTime != nil && *scheduleTime != "" {
-		if t, err := time.ParseInLocation("15:04", *scheduleTime, loc); err == nil {
-			hour, min = t.Hour(), t.Minute()
+		if _, err := time.Parse("15:04", *scheduleTime); err == nil {
+			schedule.TimeOfDay = *scheduleTime
 		}
 	}
 
-	// helper to create a time at given date with scheduled hour/min
-	at := func(y int, m time.Month, d int) time.Time {
-		return time.Date(y, m, d, hour, min, 0, 0, loc)
-	}
-
-	// default to next day same time if no frequency
-	if frequency == nil || *frequency == "" {
-		cand := at(now.Year(), now.Month(), now.Day()).Add(24 * time.Hour)
-		if cand.After(now) {
-			return cand
-		}
-		return cand.Add(24 * time.Hour)
+	from := now
+	freq := ""
+	if frequency != nil {
+		freq = *frequency
 	}
-
-	switch *frequency {
+	switch freq {
 	case "daily":
-		today := at(now.Year(), now.Month(), now.Day())
-		if today.After(now) {
-			return today
-		}
-		return today.Add(24 * time.Hour)
-
 	case "weekly":
-		// Expect dayOfWeek: 0 = Sunday ... 6 = Saturday (fallback to next day)
-		var targetWeekday time.Weekday
+		// 0 = Sunday ... 6 = Saturday; nil repeats on today's weekday
 		if dayOfWeek != nil {
-			targetWeekday = time.Weekday(*dayOfWeek % 7)
-		} else {
-			// default to same weekday next week
-			targetWeekday = now.Weekday()
-		}
-		daysUntil := (int(targetWeekday) - int(now.Weekday()) + 7) % 7
-		candidate := at(now.Year(), now.Month(), now.Day()).AddDate(0, 0, daysUntil)
-		// if same day but time already passed, schedule for next week
-		if daysUntil == 0 && !candidate.After(now) {
-			candidate = candidate.AddDate(0, 0, 7)
-		}
-		if candidate.After(now) {
-			return candidate
+			dow := ((*dayOfWeek % 7) + 7) % 7
+			schedule.DayOfWeek = &dow
 		}
-		return candidate.AddDate(0, 0, 7)
-
+		schedule.Frequency = recurrence.Weekly
 	case "monthly":
-		// Expect dayOfMonth: 1..31
-		var dom int
+		// 1..31, clamped to the month's last day; nil repeats on today's date
+		dom := now.Day()
 		if dayOfMonth != nil && *dayOfMonth > 0 {
-			dom = *dayOfMonth
-		} else {
-			dom = now.Day()
-		}
-
-		// try this month
-		maxDay := daysInMonth(now.Year(), now.Month())
-		day := dom
-		if day > maxDay {
-			day = maxDay
+			dom = min(*dayOfMonth, 31)
 		}
-		candidate := at(now.Year(), now.Month(), day)
-		if candidate.After(now) {
-			return candidate
-		}
-		// next month
-		nextMonth := now.AddDate(0, 1, 0)
-		maxDayNext := daysInMonth(nextMonth.Year(), nextMonth.Month())
-		day = dom
-		if day > maxDayNext {
-			day = maxDayNext
-		}
-		return at(nextMonth.Year(), nextMonth.Month(), day)
-
+		schedule.DayOfMonth = &dom
+		schedule.Frequency = recurrence.Monthly
 	default:
-		// custom or unknown -> fallback to next day at scheduled time
-		cand := at(now.Year(), now.Month(), now.Day()).Add(24 * time.Hour)
-		if cand.After(now) {
-			return cand
+		// custom or unknown -> next day at scheduled time
+		hour, minute := 0, 0
+		if t, err := time.Parse("15:04", schedule.TimeOfDay); err == nil {
+			hour, minute = t.Hour(), t.Minute()
 		}
-		return cand.Add(24 * time.Hour)
+		from = time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, loc)
 	}
+
+	next, err := schedule.Next(from)
+	if err != nil {
+		return now.Add(24 * time.Hour)
+	}
+	return next
 }
 
 func (s *ConversionService) calculateNextExecutionForRule(rule *db.ConversionRule) time.Time {
@@ -981,12 +1230,6 @@ func (s *ConversionService) calculateNextExecutionForRule(rule *db.ConversionRul
 	return s.calculateNextExecution(freqPtr, dowPtr, domPtr, scheduleTimePtr, tzPtr)
 }
 
-func daysInMonth(year int, month time.Month) int {
-	// day 0 of next month is last day of current month
-	t := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
-	return t.Day()
-}
-
 func (s *ConversionService) parseScheduleTime(timeStr *string) sql.NullTime {
 	if timeStr == nil {
 		return sql.NullTime{Valid: false}
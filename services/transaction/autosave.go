@@ -0,0 +1,68 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// MovementSource identifies the kind of completed money movement auto-save rules react to
+type MovementSource string
+
+const (
+	MovementCardDebit    MovementSource = "card_debit"
+	MovementBillPayment  MovementSource = "bill_payment"
+	MovementTransferOut  MovementSource = "transfer_out"
+	MovementTransferIn   MovementSource = "transfer_in"
+	MovementCryptoInflow MovementSource = "crypto_inflow"
+)
+
+// IsInflow reports whether the movement credited the user
+func (m MovementSource) IsInflow() bool {
+	return m == MovementTransferIn || m == MovementCryptoInflow
+}
+
+// MoneyMovement is a committed debit or credit of a user's money
+type MoneyMovement struct {
+	UserID        uuid.UUID
+	TransactionID uuid.UUID
+	Source        MovementSource
+	Amount        decimal.Decimal
+	Currency      string
+}
+
+// AutoSaver interface defines methods for saving into vaults when money moves
+// This allows loose coupling between transaction and vault services
+type AutoSaver interface {
+	OnMoneyMovement(ctx context.Context, movement MoneyMovement) error
+}
+
+// SetAutoSaver sets the auto saver for the transaction service
+// Call this during service initialization to enable vault auto-save rules
+func (s *TransactionService) SetAutoSaver(saver AutoSaver) {
+	s.autoSaver = saver
+	s.logger.Info("Auto saver integrated with transaction service")
+}
+
+// recordMoneyMovement hands a committed movement to the auto saver
+// Must only be called after the transaction that moved the money has committed
+func (s *TransactionService) recordMoneyMovement(movement MoneyMovement) {
+	if s.autoSaver == nil {
+		return
+	}
+
+	// Run in the background so saving never holds up the response
+	go func() {
+		if err := s.autoSaver.OnMoneyMovement(context.Background(), movement); err != nil {
+			s.logger.Error(fmt.Sprintf(
+				"Failed to apply auto-save rules for user %s after %s %s: %v",
+				movement.UserID,
+				movement.Source,
+				movement.TransactionID,
+				err,
+			))
+		}
+	}()
+}
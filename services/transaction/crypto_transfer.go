@@ -0,0 +1,421 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/wallet"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Off-chain stablecoin transfers between SwiftFiat users.
+// Funds move between internal USDT/USDC wallets only; Cryptomus and the chain are never touched.
+
+var (
+	ErrUnsupportedTagTransferCurrency = errors.New("only USDT and USDC can be sent by tag")
+	ErrTagTransferToSelf              = errors.New("you cannot send crypto to yourself")
+	ErrTagTransferAmountPrecision     = errors.New("amount supports at most 2 decimal places")
+	ErrTagTransferBelowMinimum        = errors.New("amount is below the minimum transfer amount")
+	ErrTagTransferAboveMaximum        = errors.New("amount exceeds the maximum amount per transfer")
+	ErrTagTransferDailyLimit          = errors.New("daily crypto transfer limit exceeded")
+	ErrTagTransferBlocked             = errors.New("transfer blocked by risk checks, please contact support")
+	ErrTagTransferDuplicate           = errors.New("a transfer with this idempotency key already exists")
+)
+
+var (
+	cryptoTagTransferMinAmount  = decimal.NewFromInt(1)
+	cryptoTagTransferMaxAmount  = decimal.NewFromInt(5000)
+	cryptoTagTransferDailyLimit = decimal.NewFromInt(10000)
+
+	// transfers scoring at or above this are refused and raised to admins
+	cryptoTagTransferBlockScore int32 = 70
+)
+
+// SupportedTagTransferCurrencies lists the stablecoins that can be sent off-chain by tag
+var SupportedTagTransferCurrencies = []string{"USDT", "USDC"}
+
+func IsTagTransferCurrencySupported(currency string) bool {
+	for _, c := range SupportedTagTransferCurrencies {
+		if c == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleCryptoTagTransfer moves a stablecoin amount from the user's wallet to the wallet
+// of the user owning DestinationUserTag. Both legs, their ledger entries and the receipt are
+// written in a single serializable transaction.
+func (s *TransactionService) HandleCryptoTagTransfer(ctx context.Context, user *db.User, req CryptoTagTransferRequest) (*CryptoTagTransferReceipt, error) {
+	req.Currency = strings.ToUpper(req.Currency)
+	if !IsTagTransferCurrencySupported(req.Currency) {
+		return nil, ErrUnsupportedTagTransferCurrency
+	}
+
+	amount := decimal.NewFromFloat(req.Amount)
+	if !amount.Equal(amount.Truncate(2)) {
+		return nil, ErrTagTransferAmountPrecision
+	}
+	if amount.LessThan(cryptoTagTransferMinAmount) {
+		return nil, ErrTagTransferBelowMinimum
+	}
+	if amount.GreaterThan(cryptoTagTransferMaxAmount) {
+		return nil, ErrTagTransferAboveMaximum
+	}
+
+	if _, err := s.store.GetTransactionByIdempotencyKey(ctx, req.IdempotencyKey); err == nil {
+		return nil, ErrTagTransferDuplicate
+	}
+	// a blocked transfer keeps its key on the receipt without creating a transaction
+	if used, err := s.store.CheckCryptoTagTransferReference(ctx, req.IdempotencyKey); err != nil {
+		return nil, fmt.Errorf("failed to check transfer reference: %w", err)
+	} else if used {
+		return nil, ErrTagTransferDuplicate
+	}
+
+	kyc, err := s.store.GetKYCByUserID(ctx, user.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("Err_KYC_NOT_FOUND")
+		}
+		return nil, fmt.Errorf("failed to fetch KYC: %w", err)
+	}
+
+	if kyc.Tier == "tier_1" {
+		s.sendTransactionPushNotification(ctx, user.ID, "Verification required.", "This feature requires Tier 2 verification. Complete identity verification to continue", "kyc_tier2_required")
+		return nil, fmt.Errorf("Err_KYC_NEED_TIER_2")
+	}
+
+	recipient, err := s.walletClient.ResolveTag(ctx, req.DestinationUserTag, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipient.ID_2 == user.ID {
+		return nil, ErrTagTransferToSelf
+	}
+
+	recipientUser, err := s.store.GetUserByID(ctx, recipient.ID_2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recipient user: %w", err)
+	}
+
+	senderWallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: user.ID,
+		Currency:   req.Currency,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, wallet.NewWalletError(wallet.ErrWalletNotFound, "", err)
+		}
+		return nil, fmt.Errorf("failed to get sending wallet: %w", err)
+	}
+
+	// stablecoins are pegged 1:1 to USD
+	amountUsd, err := utils.ConvertToUSD(ctx, amount, req.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount to USD: %w", err)
+	}
+
+	description := req.Description
+	if description == "" {
+		description = fmt.Sprintf("%s transfer via SWIIFT", req.Currency)
+	}
+
+	dbTx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	q := s.store.WithTx(dbTx)
+
+	sendingWallet, err := q.GetWalletForUpdate(ctx, senderWallet.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock sending wallet: %w", err)
+	}
+
+	if _, err := q.GetWalletForUpdate(ctx, recipient.ID); err != nil {
+		return nil, fmt.Errorf("failed to lock recipient wallet: %w", err)
+	}
+
+	// the daily limit and risk checks read the sender's transfers under the wallet lock,
+	// so concurrent transfers cannot both pass against the same volume
+	dayStart := time.Now().Truncate(24 * time.Hour)
+	volume, err := q.GetUserCryptoTagTransferVolumeSince(ctx, db.GetUserCryptoTagTransferVolumeSinceParams{
+		SenderID:  user.ID,
+		CreatedAt: dayStart,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch daily transfer volume: %w", err)
+	}
+
+	sentToday, _ := utils.ToDecimal(volume.TotalUsd)
+	if sentToday.Add(amountUsd).GreaterThan(cryptoTagTransferDailyLimit) {
+		return nil, ErrTagTransferDailyLimit
+	}
+
+	riskScore, riskFlags := s.scoreCryptoTagTransfer(ctx, q, user.ID, recipientUser, amountUsd)
+
+	if riskScore >= cryptoTagTransferBlockScore {
+		if _, err := q.CreateCryptoTagTransfer(ctx, db.CreateCryptoTagTransferParams{
+			Reference:         req.IdempotencyKey,
+			SenderID:          user.ID,
+			RecipientID:       recipientUser.ID,
+			SenderWalletID:    senderWallet.ID,
+			RecipientWalletID: recipient.ID,
+			SenderTag:         user.UserTag.String,
+			RecipientTag:      recipientUser.UserTag.String,
+			Currency:          req.Currency,
+			Amount:            amount.String(),
+			Fee:               "0",
+			AmountUsd:         amountUsd.String(),
+			RiskScore:         riskScore,
+			RiskFlags:         riskFlags,
+			Description:       sql.NullString{String: description, Valid: true},
+			Status:            "blocked",
+			FailureReason:     sql.NullString{String: strings.Join(riskFlags, ","), Valid: true},
+		}); err != nil {
+			s.logger.Error(fmt.Sprintf("failed to record blocked crypto tag transfer: %v", err))
+		} else if err := dbTx.Commit(); err != nil {
+			s.logger.Error(fmt.Sprintf("failed to commit blocked crypto tag transfer: %v", err))
+		}
+
+		s.createAdminAlert(ctx, db.CreateAdminAlertParams{
+			Severity: WARNINGALERT,
+			Title:    "Crypto tag transfer blocked",
+			Message:  fmt.Sprintf("%s %s from %s to %s blocked (score %d: %s)", amount.String(), req.Currency, user.UserTag.String, recipientUser.UserTag.String, riskScore, strings.Join(riskFlags, ", ")),
+			Source:   sql.NullString{String: "crypto-tag-transfer", Valid: true},
+		})
+		return nil, ErrTagTransferBlocked
+	}
+
+	sendingBalance, _ := utils.ToDecimal(sendingWallet.Balance.String)
+	if amount.GreaterThan(sendingBalance) {
+		return nil, wallet.ErrInsufficientFunds
+	}
+
+	debitTx, err := q.CreateTransaction(ctx, db.CreateTransactionParams{
+		UserID:          user.ID,
+		TransactionFlow: string(InPlatform),
+		Type:            string(Transfer),
+		Description:     sql.NullString{String: description, Valid: true},
+		Amount:          amount.String(),
+		AmountUsd:       amountUsd.String(),
+		Currency:        req.Currency,
+		IdempotencyKey:  req.IdempotencyKey,
+		TFrom:           string(Wallet),
+		TTo:             string(Wallet),
+		Direction:       string(Debit),
+		Status:          string(Success),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create debit tx record [HandleCryptoTagTransfer]: %v", err)
+	}
+
+	creditTx, err := q.CreateTransaction(ctx, db.CreateTransactionParams{
+		UserID:          recipientUser.ID,
+		TransactionFlow: string(InPlatform),
+		Type:            string(Transfer),
+		Description:     sql.NullString{String: description, Valid: true},
+		Amount:          amount.String(),
+		AmountUsd:       amountUsd.String(),
+		Currency:        req.Currency,
+		IdempotencyKey:  uuid.NewString(),
+		TFrom:           string(Wallet),
+		TTo:             string(Wallet),
+		Direction:       string(Credit),
+		Status:          string(Success),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credit tx record [HandleCryptoTagTransfer]: %v", err)
+	}
+
+	if _, err = q.DecrementWalletBalance(ctx, db.DecrementWalletBalanceParams{
+		Balance: sql.NullString{String: amount.String(), Valid: true},
+		ID:      sendingWallet.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to debit sending wallet [HandleCryptoTagTransfer]: %v", err)
+	}
+
+	if _, err = q.IncrementWalletBalance(ctx, db.IncrementWalletBalanceParams{
+		Balance: sql.NullString{String: amount.String(), Valid: true},
+		ID:      recipient.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to credit recipient wallet [HandleCryptoTagTransfer]: %v", err)
+	}
+
+	if _, err = q.InsertLedgerEntry(ctx, db.InsertLedgerEntryParams{
+		TransactionID:   uuid.NullUUID{UUID: debitTx.ID, Valid: true},
+		WalletID:        uuid.NullUUID{UUID: sendingWallet.ID, Valid: true},
+		EntryType:       "debit",
+		Amount:          amount.String(),
+		SourceType:      string(OnPlatform),
+		DestinationType: string(OnPlatform),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create debit ledger entry [HandleCryptoTagTransfer]: %w", err)
+	}
+
+	if _, err = q.InsertLedgerEntry(ctx, db.InsertLedgerEntryParams{
+		TransactionID:   uuid.NullUUID{UUID: creditTx.ID, Valid: true},
+		WalletID:        uuid.NullUUID{UUID: recipient.ID, Valid: true},
+		EntryType:       "credit",
+		Amount:          amount.String(),
+		SourceType:      string(OnPlatform),
+		DestinationType: string(OnPlatform),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create credit ledger entry [HandleCryptoTagTransfer]: %w", err)
+	}
+
+	for _, leg := range []struct {
+		txID      uuid.UUID
+		direction TransactionDirection
+		reference string
+	}{
+		{debitTx.ID, Debit, req.IdempotencyKey},
+		{creditTx.ID, Credit, uuid.NewString()},
+	} {
+		if _, err = q.CreateWalletTransferMetadata(ctx, db.CreateWalletTransferMetadataParams{
+			Currency:      req.Currency,
+			TransactionID: leg.txID,
+			Sender:        user.UserTag.String,
+			Type:          string(leg.direction),
+			Recipient:     recipientUser.UserTag.String,
+			ServiceCharge: sql.NullString{String: "0", Valid: true},
+			Amount:        amount.String(),
+			AmountPaid:    sql.NullString{String: amount.String(), Valid: true},
+			BonusEarned:   sql.NullString{String: "0", Valid: true},
+			Reference:     leg.reference,
+			Status:        string(Success),
+			Description:   description,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create wallet %s metadata record [HandleCryptoTagTransfer]: %v", leg.direction, err)
+		}
+	}
+
+	transfer, err := q.CreateCryptoTagTransfer(ctx, db.CreateCryptoTagTransferParams{
+		Reference:           req.IdempotencyKey,
+		SenderID:            user.ID,
+		RecipientID:         recipientUser.ID,
+		SenderWalletID:      sendingWallet.ID,
+		RecipientWalletID:   recipient.ID,
+		SenderTag:           user.UserTag.String,
+		RecipientTag:        recipientUser.UserTag.String,
+		Currency:            req.Currency,
+		Amount:              amount.String(),
+		Fee:                 "0",
+		AmountUsd:           amountUsd.String(),
+		DebitTransactionID:  uuid.NullUUID{UUID: debitTx.ID, Valid: true},
+		CreditTransactionID: uuid.NullUUID{UUID: creditTx.ID, Valid: true},
+		RiskScore:           riskScore,
+		RiskFlags:           riskFlags,
+		Description:         sql.NullString{String: description, Valid: true},
+		Status:              string(Success),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transfer receipt [HandleCryptoTagTransfer]: %w", err)
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit crypto tag transfer: %w", err)
+	}
+
+	if err = s.streakUpdater.UpdateStreakOnTransaction(ctx, user.ID, debitTx.ID, debitTx.Type); err != nil {
+		s.logger.Error("Failed to update streak:", err)
+	}
+
+	s.recordMoneyMovement(MoneyMovement{
+		UserID: user.ID, TransactionID: debitTx.ID, Source: MovementTransferOut,
+		Amount: amount, Currency: req.Currency,
+	})
+	s.recordMoneyMovement(MoneyMovement{
+		UserID: recipientUser.ID, TransactionID: creditTx.ID, Source: MovementTransferIn,
+		Amount: amount, Currency: req.Currency,
+	})
+
+	debitMessage := fmt.Sprintf("%s %s has been sent to %s. If this was not initiated by you, please contact SWIIFT immediately", amount.StringFixed(2), req.Currency, recipientUser.UserTag.String)
+	creditMessage := fmt.Sprintf("%s %s has been credited to your wallet from %s", amount.StringFixed(2), req.Currency, user.UserTag.String)
+
+	s.notifyr.CreateWithRecipients(ctx, nil, "Crypto Sent", debitMessage, "system", []uuid.UUID{user.ID})
+	s.notifyr.CreateWithRecipients(ctx, nil, "Crypto Received", creditMessage, "system", []uuid.UUID{recipientUser.ID})
+
+	s.push.DebitAlert(ctx, user.ID, amount.InexactFloat64(), req.Currency)
+	s.push.CreditAlert(ctx, recipientUser.ID, amount.InexactFloat64(), req.Currency)
+
+	return ToCryptoTagTransferReceipt(transfer, user.ID), nil
+}
+
+// GetCryptoTagTransferReceipt returns the receipt for a transfer the user sent or received
+func (s *TransactionService) GetCryptoTagTransferReceipt(ctx context.Context, userID uuid.UUID, reference string) (*CryptoTagTransferReceipt, error) {
+	transfer, err := s.store.GetCryptoTagTransferByReference(ctx, db.GetCryptoTagTransferByReferenceParams{
+		Reference: reference,
+		SenderID:  userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToCryptoTagTransferReceipt(transfer, userID), nil
+}
+
+// ListCryptoTagTransfers returns the user's sent and received stablecoin transfers, newest first
+func (s *TransactionService) ListCryptoTagTransfers(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*CryptoTagTransferReceipt, error) {
+	transfers, err := s.store.ListUserCryptoTagTransfers(ctx, db.ListUserCryptoTagTransfersParams{
+		SenderID: userID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*CryptoTagTransferReceipt, 0, len(transfers))
+	for _, t := range transfers {
+		receipts = append(receipts, ToCryptoTagTransferReceipt(t, userID))
+	}
+	return receipts, nil
+}
+
+// scoreCryptoTagTransfer computes a simple additive risk score for a transfer, reading the
+// sender's history through q so it sees the same snapshot as the transfer.
+// Individual checks fail open so a lookup error never blocks a legitimate payment.
+func (s *TransactionService) scoreCryptoTagTransfer(ctx context.Context, q *db.Queries, senderID uuid.UUID, recipient db.User, amountUsd decimal.Decimal) (int32, []string) {
+	var score int32
+	flags := []string{}
+
+	prior, err := q.CountCryptoTagTransfersBetween(ctx, db.CountCryptoTagTransfersBetweenParams{
+		SenderID:    senderID,
+		RecipientID: recipient.ID,
+	})
+	if err == nil && prior == 0 {
+		score += 20
+		flags = append(flags, "new_recipient")
+	}
+
+	lastHour, err := q.GetUserCryptoTagTransferVolumeSince(ctx, db.GetUserCryptoTagTransferVolumeSinceParams{
+		SenderID:  senderID,
+		CreatedAt: time.Now().Add(-1 * time.Hour),
+	})
+	if err == nil && lastHour.TransferCount >= 10 {
+		score += 40
+		flags = append(flags, "high_velocity")
+	}
+
+	if amountUsd.GreaterThanOrEqual(cryptoTagTransferMaxAmount.Div(decimal.NewFromInt(2))) {
+		score += 20
+		flags = append(flags, "large_amount")
+	}
+
+	if time.Since(recipient.CreatedAt) < 24*time.Hour {
+		score += 30
+		flags = append(flags, "new_recipient_account")
+	}
+
+	return score, flags
+}
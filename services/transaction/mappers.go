@@ -2,6 +2,7 @@ package transaction
 
 import (
 	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/google/uuid"
 )
 
 func ToTransactionModelResponse(tx *db.Transaction, userTag *string) *IntraTransaction {
@@ -12,3 +13,31 @@ func ToTransactionModelResponse(tx *db.Transaction, userTag *string) *IntraTrans
 		UserTag:     *userTag,
 	}
 }
+
+// ToCryptoTagTransferReceipt renders a transfer from the point of view of viewerID
+func ToCryptoTagTransferReceipt(t db.CryptoTagTransfer, viewerID uuid.UUID) *CryptoTagTransferReceipt {
+	receipt := &CryptoTagTransferReceipt{
+		Reference:    t.Reference,
+		Direction:    string(Debit),
+		SenderTag:    t.SenderTag,
+		RecipientTag: t.RecipientTag,
+		Currency:     t.Currency,
+		Amount:       t.Amount,
+		Fee:          t.Fee,
+		NetworkFee:   "0",
+		Description:  t.Description.String,
+		Status:       t.Status,
+		Date:         t.CreatedAt,
+	}
+
+	txID := t.DebitTransactionID
+	if t.RecipientID == viewerID {
+		receipt.Direction = string(Credit)
+		txID = t.CreditTransactionID
+	}
+	if txID.Valid {
+		receipt.TransactionID = txID.UUID
+	}
+
+	return receipt
+}
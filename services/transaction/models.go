@@ -453,6 +453,31 @@ type WalletTransferResponse struct {
 	Reference  string    `json:"reference"`
 }
 
+type CryptoTagTransferRequest struct {
+	Currency           string  `json:"currency" binding:"required,oneof=USDT USDC usdt usdc"`
+	Amount             float64 `json:"amount" binding:"required,gt=0"`
+	DestinationUserTag string  `json:"target_user_tag" binding:"required"`
+	Description        string  `json:"description"`
+	Pin                string  `json:"pin" binding:"required"`
+	IdempotencyKey     string  `json:"idempotency_key" binding:"required"`
+}
+
+// CryptoTagTransferReceipt is the user-facing receipt of an off-chain stablecoin transfer
+type CryptoTagTransferReceipt struct {
+	Reference     string    `json:"reference"`
+	Direction     string    `json:"direction"`
+	SenderTag     string    `json:"sender_tag"`
+	RecipientTag  string    `json:"recipient_tag"`
+	Currency      string    `json:"currency"`
+	Amount        string    `json:"amount"`
+	Fee           string    `json:"fee"`
+	NetworkFee    string    `json:"network_fee"`
+	Description   string    `json:"description"`
+	Status        string    `json:"status"`
+	TransactionID uuid.UUID `json:"transaction_id"`
+	Date          time.Time `json:"date"`
+}
+
 type BankTransferRequest struct {
 	Name            string  `json:"name" binding:"required"`
 	AccountNumber   string  `json:"account_number" binding:"required"`
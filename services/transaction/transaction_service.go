@@ -37,6 +37,7 @@ type TransactionService struct {
 	notifyr        *service.Notification
 	push           *service.PushNotificationService
 	streakUpdater  StreakUpdater
+	autoSaver      AutoSaver
 	billProvider   *bills.VTPassProvider
 	rewardSvc      *rewards.RewardService
 	audit          *audit.Service
@@ -325,6 +326,8 @@ func (s *TransactionService) CreateAllCryptoINflowTXs(
 		notifyUser   *db.User
 		notifyAmount decimal.Decimal
 		notifyCoin   string
+		// set when the inflow landed in the user's USD wallet, for auto-save rules
+		walletCredited bool
 	)
 
 	coinSym := strings.ToUpper(tx.Coin)
@@ -362,6 +365,7 @@ func (s *TransactionService) CreateAllCryptoINflowTXs(
 				notifyAmount = finalAmount
 				notifyCoin = coinSym
 			}
+			walletCredited = true
 		} else {
 			// Path B: trail but no pending tx → create a fresh successful tx.
 			s.logger.Warn("Trail exists but no pending tx found; creating new successful tx")
@@ -403,6 +407,14 @@ func (s *TransactionService) CreateAllCryptoINflowTXs(
 		s.sendCryptoSuccessNotifications(ctx, *notifyUser, notifyAmount, notifyCoin, tx.TransactionID)
 	}
 
+	// Rapid ramp inflows are paid out to a bank account, so only wallet credits count
+	if notifyUser != nil && tObj != nil && (walletCredited || notifyCoin == string(USD)) {
+		s.recordMoneyMovement(MoneyMovement{
+			UserID: notifyUser.ID, TransactionID: tObj.ID, Source: MovementCryptoInflow,
+			Amount: notifyAmount, Currency: string(USD),
+		})
+	}
+
 	s.logger.Info("Crypto inflow completed successfully")
 	return tObj, nil
 }
@@ -454,7 +466,7 @@ func (s *TransactionService) createNewSuccessfulCryptoTransaction(
 		return tObj, &user, coinAmount, coinSym, nil
 	}
 
-	vip_rate, err := s.rateManager.GetAdjustedRateForUser(ctx, user.ID, coinSym, string(USD), coinAmount.String())
+	vip_rate, err := s.rateManager.GetAdjustedRateForUser(ctx, user.ID, coinSym, string(USD), coinAmount.String(), ratemanager.ChannelApp)
 	if err != nil {
 		s.createAdminAlert(ctx, db.CreateAdminAlertParams{
 			Severity: WARNINGALERT,
@@ -715,7 +727,7 @@ func (s *TransactionService) processRapidRampInflow(
 		return nil, fmt.Errorf("parsing USD rate: %w", err)
 	}
 
-	vipRate, err := s.rateManager.GetAdjustedRateForUser(ctx, userID, "USD", "NGN", coinToUSDDecimal.String())
+	vipRate, err := s.rateManager.GetAdjustedRateForUser(ctx, userID, "USD", "NGN", coinToUSDDecimal.String(), ratemanager.ChannelRapidRamp)
 	if err != nil {
 		return nil, fmt.Errorf("to decimal error: %v", err)
 	}
@@ -2159,6 +2171,10 @@ func (s *TransactionService) HandleAirtime(ctx context.Context, user *db.User, r
 		if err = dbTx.Commit(); err != nil {
 			return nil, fmt.Errorf("airtime purchase commit failed: %w", err)
 		}
+		s.recordMoneyMovement(MoneyMovement{
+			UserID: user.ID, TransactionID: txx.ID, Source: MovementBillPayment,
+			Amount: finalAmount, Currency: string(NGN),
+		})
 
 		// FIX [B5]: Notification built AFTER confirmed delivery.
 		notificationMsg := fmt.Sprintf("You have received airtime of ₦%d to %s", req.Amount, req.Phone)
@@ -2413,6 +2429,10 @@ func (s *TransactionService) HandleData(ctx context.Context, user *db.User, req
 		if err = dbTx.Commit(); err != nil {
 			return nil, fmt.Errorf("data purchase commit failed: %w", err)
 		}
+		s.recordMoneyMovement(MoneyMovement{
+			UserID: user.ID, TransactionID: txx.ID, Source: MovementBillPayment,
+			Amount: finalAmount, Currency: string(NGN),
+		})
 
 		// FIX [B5]: Build notification post-commit, after outcome is known.
 		notificationMsg := fmt.Sprintf("You have received %s data on %s", selectedVariation.VariationCode, req.Phone)
@@ -2683,6 +2703,10 @@ func (s *TransactionService) HandleTvSubscription(ctx context.Context, user *db.
 		if err = dbTx.Commit(); err != nil {
 			return nil, fmt.Errorf("TV subscription commit failed: %w", err)
 		}
+		s.recordMoneyMovement(MoneyMovement{
+			UserID: user.ID, TransactionID: txx.ID, Source: MovementBillPayment,
+			Amount: finalAmount, Currency: string(NGN),
+		})
 
 		// FIX [B5]: Notification built post-commit.
 		notificationMsg := fmt.Sprintf("Your %s TV subscription is active", selectedVariation.VariationCode)
@@ -3100,7 +3124,22 @@ func (s *TransactionService) reconcileFinalizeBillSuccess(ctx context.Context, m
 		return fmt.Errorf("unknown bill type for success finalization: %s", billType)
 	}
 
-	return dbTx.Commit()
+	if err = dbTx.Commit(); err != nil {
+		return err
+	}
+
+	// Bills that only succeed on reconciliation still count toward round-ups
+	if billType != "BankTransfer" {
+		if txx, err := s.store.GetTransactionByID(ctx, meta.GetTransactionID()); err == nil {
+			if amount, err := decimal.NewFromString(txx.Amount); err == nil {
+				s.recordMoneyMovement(MoneyMovement{
+					UserID: txx.UserID, TransactionID: txx.ID, Source: MovementBillPayment,
+					Amount: amount, Currency: txx.Currency,
+				})
+			}
+		}
+	}
+	return nil
 }
 
 // reconcileFinalizeBillFailure refunds the debited amount and updates metadata status to failed.
@@ -3411,6 +3450,10 @@ func (s *TransactionService) HandleBuyElectricity(ctx context.Context, user *db.
 		if err = dbTx.Commit(); err != nil {
 			return nil, fmt.Errorf("electricity purchase commit failed: %w", err)
 		}
+		s.recordMoneyMovement(MoneyMovement{
+			UserID: user.ID, TransactionID: txx.ID, Source: MovementBillPayment,
+			Amount: finalAmount, Currency: string(NGN),
+		})
 
 		// FIX [B5]: Notification built post-commit with actual token.
 		notificationMsg := fmt.Sprintf("Your electricity purchase is successful. Token: %s", btx.Token)
@@ -3645,6 +3688,15 @@ func (s TransactionService) HandleWalletTransfer(ctx context.Context, user *db.U
 		s.logger.Error("Failed to update streak:", err)
 	}
 
+	s.recordMoneyMovement(MoneyMovement{
+		UserID: user.ID, TransactionID: tx.ID, Source: MovementTransferOut,
+		Amount: amount, Currency: req.Currency,
+	})
+	s.recordMoneyMovement(MoneyMovement{
+		UserID: recipientUser.ID, TransactionID: t.ID, Source: MovementTransferIn,
+		Amount: amount, Currency: req.Currency,
+	})
+
 	// err = s.store.UpdateUserTransactionVolume(ctx, db.UpdateUserTransactionVolumeParams{
 	// 	TotalTransactionVolume: sql.NullString{String: amount.String(), Valid: true},
 	// 	ID:                     user.ID,
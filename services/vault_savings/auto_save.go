@@ -0,0 +1,661 @@
+package vaultsavings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/transaction"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ============================================================================
+// AUTO-SAVE TYPES
+// ============================================================================
+
+type AutoSaveRuleType string
+
+const (
+	// AutoSaveRoundUp rounds every matching debit up to the next multiple of round_to and
+	// saves the difference in the next daily batch
+	AutoSaveRoundUp AutoSaveRuleType = "round_up"
+	// AutoSavePercentOfInflow saves a percentage of every matching credit straight away
+	AutoSavePercentOfInflow AutoSaveRuleType = "percent_of_inflow"
+)
+
+type AutoSaveAccrualStatus string
+
+const (
+	AutoSaveAccrualPending    AutoSaveAccrualStatus = "pending"
+	AutoSaveAccrualProcessing AutoSaveAccrualStatus = "processing"
+	AutoSaveAccrualDeposited  AutoSaveAccrualStatus = "deposited"
+	AutoSaveAccrualFailed     AutoSaveAccrualStatus = "failed"
+)
+
+// maxAutoSavePercentage matches the check on vault_auto_save_rules.percentage
+var maxAutoSavePercentage = decimal.NewFromInt(50)
+
+// autoSaveStaleAfter is how long a batch may sit in processing before a later run picks it up
+const autoSaveStaleAfter = 30 * time.Minute
+
+// autoSaveTriggers lists the movements each rule type can react to
+var autoSaveTriggers = map[AutoSaveRuleType][]transaction.MovementSource{
+	AutoSaveRoundUp: {
+		transaction.MovementCardDebit,
+		transaction.MovementBillPayment,
+		transaction.MovementTransferOut,
+	},
+	AutoSavePercentOfInflow: {
+		transaction.MovementTransferIn,
+		transaction.MovementCryptoInflow,
+	},
+}
+
+// ============================================================================
+// AUTO-SAVE MODELS
+// ============================================================================
+
+type CreateAutoSaveRuleRequest struct {
+	VaultID  uuid.UUID `json:"vault_id" binding:"required"`
+	RuleType string    `json:"rule_type" binding:"required" enums:"round_up,percent_of_inflow"`
+	Triggers []string  `json:"triggers" binding:"required" example:"card_debit,bill_payment,transfer_out"`
+	// required for round_up rules, e.g. 100 rounds ₦1,250 up to ₦1,300
+	RoundTo *string `json:"round_to" example:"100"`
+	// required for percent_of_inflow rules, up to 50
+	Percentage        *string `json:"percentage" example:"10"`
+	MaxPerTransaction *string `json:"max_per_transaction" example:"5000"`
+}
+
+type UpdateAutoSaveRuleRequest struct {
+	Triggers          []string `json:"triggers,omitempty"`
+	RoundTo           *string  `json:"round_to,omitempty"`
+	Percentage        *string  `json:"percentage,omitempty"`
+	MaxPerTransaction *string  `json:"max_per_transaction,omitempty"`
+	IsActive          *bool    `json:"is_active,omitempty"`
+}
+
+type AutoSaveRuleResponse struct {
+	ID                uuid.UUID `json:"id"`
+	VaultID           uuid.UUID `json:"vault_id"`
+	VaultName         string    `json:"vault_name,omitempty"`
+	Currency          string    `json:"currency,omitempty"`
+	RuleType          string    `json:"rule_type"`
+	Triggers          []string  `json:"triggers"`
+	RoundTo           string    `json:"round_to,omitempty"`
+	Percentage        string    `json:"percentage,omitempty"`
+	MaxPerTransaction string    `json:"max_per_transaction,omitempty"`
+	IsActive          bool      `json:"is_active"`
+	TotalSaved        string    `json:"total_saved"`
+	// saved from transactions but not yet moved into the vault
+	PendingAmount string    `json:"pending_amount"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func MapAutoSaveRuleToResponse(r *db.VaultAutoSaveRule) *AutoSaveRuleResponse {
+	return &AutoSaveRuleResponse{
+		ID:                r.ID,
+		VaultID:           r.VaultID,
+		RuleType:          r.RuleType,
+		Triggers:          r.Triggers,
+		RoundTo:           r.RoundTo.String,
+		Percentage:        r.Percentage.String,
+		MaxPerTransaction: r.MaxPerTransaction.String,
+		IsActive:          r.IsActive,
+		TotalSaved:        "0",
+		PendingAmount:     "0",
+		CreatedAt:         r.CreatedAt,
+		UpdatedAt:         r.UpdatedAt,
+	}
+}
+
+func MapListUserAutoSaveRulesRowToResponse(r *db.ListUserAutoSaveRulesRow) *AutoSaveRuleResponse {
+	return &AutoSaveRuleResponse{
+		ID:                r.ID,
+		VaultID:           r.VaultID,
+		VaultName:         r.VaultName,
+		Currency:          r.Currency,
+		RuleType:          r.RuleType,
+		Triggers:          r.Triggers,
+		RoundTo:           r.RoundTo.String,
+		Percentage:        r.Percentage.String,
+		MaxPerTransaction: r.MaxPerTransaction.String,
+		IsActive:          r.IsActive,
+		TotalSaved:        r.TotalSaved,
+		PendingAmount:     r.PendingAmount,
+		CreatedAt:         r.CreatedAt,
+		UpdatedAt:         r.UpdatedAt,
+	}
+}
+
+// ============================================================================
+// RULE MANAGEMENT
+// ============================================================================
+
+// CreateAutoSaveRule adds a rule that saves into one of the user's own flexible vaults
+func (s *VaultService) CreateAutoSaveRule(ctx context.Context, userID uuid.UUID, req CreateAutoSaveRuleRequest) (*AutoSaveRuleResponse, error) {
+	ruleType := AutoSaveRuleType(req.RuleType)
+	triggers, err := validateAutoSaveTriggers(ruleType, req.Triggers)
+	if err != nil {
+		return nil, err
+	}
+
+	roundTo, percentage, maxPerTx, err := validateAutoSaveAmounts(ruleType, req.RoundTo, req.Percentage, req.MaxPerTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	vault, err := s.store.GetVaultGoalByID(ctx, req.VaultID)
+	if err != nil || vault.UserID != userID {
+		return nil, ErrVaultNotFound
+	}
+	if vault.VaultType == string(SavingsTypeLocked) {
+		return nil, ErrVaultLocked
+	}
+	if vault.Status != string(SavingsStatusActive) {
+		return nil, fmt.Errorf("%w: vault is %s", ErrInvalidAutoSaveRule, vault.Status)
+	}
+
+	rule, err := s.store.CreateAutoSaveRule(ctx, db.CreateAutoSaveRuleParams{
+		UserID:            userID,
+		VaultID:           vault.ID,
+		RuleType:          string(ruleType),
+		Triggers:          triggers,
+		RoundTo:           roundTo,
+		Percentage:        percentage,
+		MaxPerTransaction: maxPerTx,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auto-save rule: %w", err)
+	}
+
+	s.logger.Info(fmt.Sprintf("Auto-save rule %s (%s) created for vault %s", rule.ID, rule.RuleType, vault.ID))
+
+	resp := MapAutoSaveRuleToResponse(&rule)
+	resp.VaultName = vault.VaultName
+	resp.Currency = vault.Currency
+	return resp, nil
+}
+
+func (s *VaultService) ListAutoSaveRules(ctx context.Context, userID uuid.UUID) ([]*AutoSaveRuleResponse, error) {
+	rows, err := s.store.ListUserAutoSaveRules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auto-save rules: %w", err)
+	}
+
+	rules := make([]*AutoSaveRuleResponse, 0, len(rows))
+	for i := range rows {
+		rules = append(rules, MapListUserAutoSaveRulesRowToResponse(&rows[i]))
+	}
+	return rules, nil
+}
+
+// UpdateAutoSaveRule changes a rule's triggers, amounts or pauses it. The rule type and vault are fixed;
+// create a new rule to change either.
+func (s *VaultService) UpdateAutoSaveRule(ctx context.Context, userID, ruleID uuid.UUID, req UpdateAutoSaveRuleRequest) (*AutoSaveRuleResponse, error) {
+	rule, err := s.store.GetAutoSaveRule(ctx, db.GetAutoSaveRuleParams{ID: ruleID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAutoSaveRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get auto-save rule: %w", err)
+	}
+
+	ruleType := AutoSaveRuleType(rule.RuleType)
+	triggers := rule.Triggers
+	if req.Triggers != nil {
+		if triggers, err = validateAutoSaveTriggers(ruleType, req.Triggers); err != nil {
+			return nil, err
+		}
+	}
+
+	roundTo, percentage, maxPerTx := rule.RoundTo, rule.Percentage, rule.MaxPerTransaction
+	if req.RoundTo != nil || req.Percentage != nil || req.MaxPerTransaction != nil {
+		current := func(v sql.NullString, update *string) *string {
+			if update != nil {
+				return update
+			}
+			if v.Valid {
+				return &v.String
+			}
+			return nil
+		}
+		roundTo, percentage, maxPerTx, err = validateAutoSaveAmounts(ruleType,
+			current(rule.RoundTo, req.RoundTo),
+			current(rule.Percentage, req.Percentage),
+			current(rule.MaxPerTransaction, req.MaxPerTransaction),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	isActive := rule.IsActive
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	updated, err := s.store.UpdateAutoSaveRule(ctx, db.UpdateAutoSaveRuleParams{
+		ID:                rule.ID,
+		UserID:            userID,
+		Triggers:          triggers,
+		RoundTo:           roundTo,
+		Percentage:        percentage,
+		MaxPerTransaction: maxPerTx,
+		IsActive:          isActive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update auto-save rule: %w", err)
+	}
+
+	return MapAutoSaveRuleToResponse(&updated), nil
+}
+
+// DeleteAutoSaveRule removes a rule. Round-ups it has not deposited yet are dropped with it.
+func (s *VaultService) DeleteAutoSaveRule(ctx context.Context, userID, ruleID uuid.UUID) error {
+	n, err := s.store.DeleteAutoSaveRule(ctx, db.DeleteAutoSaveRuleParams{ID: ruleID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete auto-save rule: %w", err)
+	}
+	if n == 0 {
+		return ErrAutoSaveRuleNotFound
+	}
+	return nil
+}
+
+// validateAutoSaveTriggers checks every trigger suits the rule type and drops duplicates
+func validateAutoSaveTriggers(ruleType AutoSaveRuleType, triggers []string) ([]string, error) {
+	allowed, ok := autoSaveTriggers[ruleType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown rule type %q", ErrInvalidAutoSaveRule, ruleType)
+	}
+	if len(triggers) == 0 {
+		return nil, fmt.Errorf("%w: at least one trigger is required", ErrInvalidAutoSaveRule)
+	}
+
+	seen := make(map[string]bool, len(triggers))
+	out := make([]string, 0, len(triggers))
+	for _, t := range triggers {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if seen[t] {
+			continue
+		}
+		valid := false
+		for _, a := range allowed {
+			if string(a) == t {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("%w: %q is not a %s trigger", ErrInvalidAutoSaveRule, t, ruleType)
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// validateAutoSaveAmounts checks round_to or percentage is set as the rule type needs, and the optional cap
+func validateAutoSaveAmounts(ruleType AutoSaveRuleType, roundTo, percentage, maxPerTx *string) (sql.NullString, sql.NullString, sql.NullString, error) {
+	var rt, pct, limit sql.NullString
+
+	positive := func(field string, v *string) (decimal.Decimal, error) {
+		d, err := decimal.NewFromString(*v)
+		if err != nil || d.LessThanOrEqual(decimal.Zero) {
+			return decimal.Zero, fmt.Errorf("%w: %s must be a positive amount", ErrInvalidAutoSaveRule, field)
+		}
+		return d, nil
+	}
+
+	switch ruleType {
+	case AutoSaveRoundUp:
+		if roundTo == nil || percentage != nil {
+			return rt, pct, limit, fmt.Errorf("%w: round_up rules need round_to and no percentage", ErrInvalidAutoSaveRule)
+		}
+		d, err := positive("round_to", roundTo)
+		if err != nil {
+			return rt, pct, limit, err
+		}
+		rt = nullString(d.String())
+	case AutoSavePercentOfInflow:
+		if percentage == nil || roundTo != nil {
+			return rt, pct, limit, fmt.Errorf("%w: percent_of_inflow rules need percentage and no round_to", ErrInvalidAutoSaveRule)
+		}
+		d, err := positive("percentage", percentage)
+		if err != nil {
+			return rt, pct, limit, err
+		}
+		if d.GreaterThan(maxAutoSavePercentage) {
+			return rt, pct, limit, fmt.Errorf("%w: percentage cannot exceed %s", ErrInvalidAutoSaveRule, maxAutoSavePercentage)
+		}
+		pct = nullString(d.String())
+	default:
+		return rt, pct, limit, fmt.Errorf("%w: unknown rule type %q", ErrInvalidAutoSaveRule, ruleType)
+	}
+
+	if maxPerTx != nil {
+		d, err := positive("max_per_transaction", maxPerTx)
+		if err != nil {
+			return rt, pct, limit, err
+		}
+		limit = nullString(d.String())
+	}
+
+	return rt, pct, limit, nil
+}
+
+// ============================================================================
+// SAVING ON MONEY MOVEMENT
+// ============================================================================
+
+// OnMoneyMovement implements transaction.AutoSaver. Every active rule of the user that fires on
+// the movement records what it saves; round-ups wait for the daily batch while inflow percentages
+// are deposited at once, since the money has only just landed in the wallet.
+func (s *VaultService) OnMoneyMovement(ctx context.Context, movement transaction.MoneyMovement) error {
+	if movement.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil
+	}
+
+	rules, err := s.store.GetAutoSaveRulesForTrigger(ctx, db.GetAutoSaveRulesForTriggerParams{
+		UserID:   movement.UserID,
+		Currency: movement.Currency,
+		Trigger:  string(movement.Source),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get auto-save rules: %w", err)
+	}
+
+	var firstErr error
+	for i := range rules {
+		if err := s.applyAutoSaveRule(ctx, &rules[i], movement); err != nil {
+			s.logger.Error(fmt.Sprintf("Auto-save rule %s failed on %s %s: %v", rules[i].ID, movement.Source, movement.TransactionID, err))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (s *VaultService) applyAutoSaveRule(ctx context.Context, rule *db.VaultAutoSaveRule, movement transaction.MoneyMovement) error {
+	amount := autoSaveAmount(rule, movement.Amount)
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil
+	}
+
+	accrual, err := s.store.CreateAutoSaveAccrual(ctx, db.CreateAutoSaveAccrualParams{
+		RuleID:              rule.ID,
+		UserID:              rule.UserID,
+		VaultID:             rule.VaultID,
+		Source:              string(movement.Source),
+		SourceTransactionID: movement.TransactionID,
+		SourceAmount:        movement.Amount.String(),
+		Amount:              amount.String(),
+		Currency:            movement.Currency,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Already saved from this transaction
+			return nil
+		}
+		return fmt.Errorf("failed to record auto-save: %w", err)
+	}
+
+	if AutoSaveRuleType(rule.RuleType) != AutoSavePercentOfInflow {
+		return nil
+	}
+
+	// Claim up to and including the accrual just written
+	_, err = s.depositAutoSaveBatch(ctx, db.GetDueAutoSaveBatchesRow{
+		RuleID:   rule.ID,
+		UserID:   rule.UserID,
+		VaultID:  rule.VaultID,
+		Currency: accrual.Currency,
+	}, accrual.CreatedAt.Add(time.Second), "Auto-save from incoming funds")
+	return err
+}
+
+// autoSaveAmount is what a rule saves from a movement of amount, after the per-transaction cap
+func autoSaveAmount(rule *db.VaultAutoSaveRule, amount decimal.Decimal) decimal.Decimal {
+	var save decimal.Decimal
+
+	switch AutoSaveRuleType(rule.RuleType) {
+	case AutoSaveRoundUp:
+		roundTo, err := decimal.NewFromString(rule.RoundTo.String)
+		if err != nil || roundTo.LessThanOrEqual(decimal.Zero) {
+			return decimal.Zero
+		}
+		// An amount already on the boundary has nothing to round up
+		remainder := amount.Mod(roundTo)
+		if remainder.IsZero() {
+			return decimal.Zero
+		}
+		save = roundTo.Sub(remainder)
+	case AutoSavePercentOfInflow:
+		pct, err := decimal.NewFromString(rule.Percentage.String)
+		if err != nil {
+			return decimal.Zero
+		}
+		save = amount.Mul(pct).Div(decimal.NewFromInt(100)).RoundDown(2)
+	default:
+		return decimal.Zero
+	}
+
+	if rule.MaxPerTransaction.Valid {
+		if limit, err := decimal.NewFromString(rule.MaxPerTransaction.String); err == nil && save.GreaterThan(limit) {
+			save = limit
+		}
+	}
+	return save
+}
+
+// ============================================================================
+// BATCHED DEPOSITS
+// ============================================================================
+
+// ProcessAutoSaveBatches deposits every rule's round-ups from before today as one vault deposit
+// per rule. Batches a crashed run left half-done are completed or put back in the queue first.
+func (s *VaultService) ProcessAutoSaveBatches(ctx context.Context, limit int32) (int, int, error) {
+	s.recoverStaleAutoSaveBatches(ctx)
+
+	now := time.Now()
+	cutoff := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	batches, err := s.store.GetDueAutoSaveBatches(ctx, db.GetDueAutoSaveBatchesParams{
+		CreatedAt: cutoff,
+		Limit:     limit,
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get due auto-save batches: %w", err)
+	}
+
+	successCount, failureCount := 0, 0
+	for _, batch := range batches {
+		if _, err := s.depositAutoSaveBatch(ctx, batch, cutoff, "Daily round-up savings"); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to deposit auto-save batch for rule %s: %v", batch.RuleID, err))
+			failureCount++
+			continue
+		}
+		successCount++
+	}
+
+	return successCount, failureCount, nil
+}
+
+// depositAutoSaveBatch claims the rule's pending accruals from before cutoff and moves their total
+// from the wallet into the vault. The batch reference doubles as the deposit's idempotency key, which
+// is how a stale batch can tell whether its deposit went through.
+func (s *VaultService) depositAutoSaveBatch(ctx context.Context, batch db.GetDueAutoSaveBatchesRow, cutoff time.Time, description string) (*db.VaultTransaction, error) {
+	reference := utils.NewTxRef("vault_autosave")
+
+	accruals, err := s.store.ClaimAutoSaveAccruals(ctx, db.ClaimAutoSaveAccrualsParams{
+		RuleID:         batch.RuleID,
+		CreatedAt:      cutoff,
+		BatchReference: nullString(reference),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim auto-save accruals: %w", err)
+	}
+	if len(accruals) == 0 {
+		return nil, nil
+	}
+
+	total := decimal.Zero
+	for _, a := range accruals {
+		amount, err := decimal.NewFromString(a.Amount)
+		if err != nil {
+			continue
+		}
+		total = total.Add(amount)
+	}
+
+	fail := func(depositErr error) (*db.VaultTransaction, error) {
+		if err := s.store.FailAutoSaveBatch(ctx, db.FailAutoSaveBatchParams{
+			BatchReference: nullString(reference),
+			FailureReason:  nullString(depositErr.Error()),
+		}); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to mark auto-save batch %s failed: %v", reference, err))
+		}
+		return nil, depositErr
+	}
+
+	wallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: batch.UserID,
+		Currency:   batch.Currency,
+	})
+	if err != nil {
+		return fail(fmt.Errorf("failed to get wallet: %w", err))
+	}
+
+	vaultTx, err := s.Deposit(ctx, DepositRequest{
+		UserID:         batch.UserID,
+		VaultID:        batch.VaultID,
+		FromWalletID:   wallet.ID,
+		Amount:         total.String(),
+		Currency:       batch.Currency,
+		Description:    description,
+		IdempotencyKey: reference,
+	})
+	if err != nil {
+		return fail(err)
+	}
+
+	if err := s.store.CompleteAutoSaveBatch(ctx, nullString(reference)); err != nil {
+		// The deposit is in; the stale batch sweep will find it by reference and complete it
+		s.logger.Error(fmt.Sprintf("Failed to complete auto-save batch %s: %v", reference, err))
+	}
+
+	s.logger.Info(fmt.Sprintf("Auto-saved %s %s into vault %s from %d transactions", total, batch.Currency, batch.VaultID, len(accruals)))
+	return vaultTx, nil
+}
+
+// recoverStaleAutoSaveBatches settles batches stuck in processing: completed if their deposit
+// exists, otherwise released so the next run deposits them again
+func (s *VaultService) recoverStaleAutoSaveBatches(ctx context.Context) {
+	stale, err := s.store.GetStaleAutoSaveBatches(ctx, nullTime(time.Now().Add(-autoSaveStaleAfter)))
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to get stale auto-save batches: %v", err))
+		return
+	}
+
+	for _, b := range stale {
+		_, err := s.store.GetTransactionByIdempotencyKey(ctx, b.BatchReference.String)
+		switch {
+		case err == nil:
+			err = s.store.CompleteAutoSaveBatch(ctx, b.BatchReference)
+		case errors.Is(err, sql.ErrNoRows):
+			err = s.store.ReleaseAutoSaveBatch(ctx, b.BatchReference)
+		}
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to recover auto-save batch %s: %v", b.BatchReference.String, err))
+		}
+	}
+}
+
+// ============================================================================
+// MONTHLY SUMMARY
+// ============================================================================
+
+// SendAutoSaveMonthlySummaries tells every user what their rules saved last month. Each user is
+// marked before sending, so restarts and other replicas never send a month twice.
+func (s *VaultService) SendAutoSaveMonthlySummaries(ctx context.Context) (int, error) {
+	now := time.Now()
+	periodEnd := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	periodStart := periodEnd.AddDate(0, -1, 0)
+
+	totals, err := s.store.GetAutoSaveMonthlyTotals(ctx, db.GetAutoSaveMonthlyTotalsParams{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get auto-save totals: %w", err)
+	}
+
+	// Rows come ordered by user, one per currency
+	sent := 0
+	for start := 0; start < len(totals); {
+		end := start
+		for end < len(totals) && totals[end].UserID == totals[start].UserID {
+			end++
+		}
+		userTotals := totals[start:end]
+		start = end
+
+		n, err := s.store.MarkAutoSaveSummarySent(ctx, db.MarkAutoSaveSummarySentParams{
+			UserID:      userTotals[0].UserID,
+			PeriodStart: periodStart,
+		})
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to mark auto-save summary for user %s: %v", userTotals[0].UserID, err))
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+
+		s.sendAutoSaveSummary(ctx, userTotals, periodStart)
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (s *VaultService) sendAutoSaveSummary(ctx context.Context, totals []db.GetAutoSaveMonthlyTotalsRow, month time.Time) {
+	userID := totals[0].UserID
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to get user for auto-save summary: %v", err))
+		return
+	}
+
+	parts := make([]string, 0, len(totals))
+	for _, t := range totals {
+		parts = append(parts, fmt.Sprintf("%s %s", t.TotalSaved, t.Currency))
+	}
+	saved := strings.Join(parts, " and ")
+	monthName := month.Format("January")
+
+	if s.emailService != nil {
+		if err := s.emailService.SendAutoSaveMonthlySummaryEmail(ctx, &user, month, totals); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to send auto-save summary email: %v", err))
+		}
+	}
+	if s.pushService != nil {
+		if err := s.pushService.SendAutoSaveMonthlySummaryPush(ctx, userID, monthName, saved); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to send auto-save summary push: %v", err))
+		}
+	}
+	if s.notifService != nil {
+		if _, err := s.notifService.CreateWithRecipients(ctx, nil, "Your Auto-Save Summary",
+			fmt.Sprintf("Your auto-save rules saved %s in %s.", saved, monthName), "system", []uuid.UUID{userID}); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to create auto-save summary notification: %v", err))
+		}
+	}
+}
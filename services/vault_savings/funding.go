@@ -0,0 +1,410 @@
+package vaultsavings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/currency"
+	ratequote "github.com/SwiftFiat/SwiftFiat-Backend/services/rate_quote"
+	smartconversion "github.com/SwiftFiat/SwiftFiat-Backend/services/smart_conversion"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ============================================================================
+// FUNDING CONVERTER
+// ============================================================================
+
+// FundingConverter converts between a user's wallets so a vault can be funded from a wallet
+// in another currency. The smart conversion service implements it.
+type FundingConverter interface {
+	QuoteConversion(ctx context.Context, req *smartconversion.ConversionQuoteRequest, user *db.User) (*ratequote.Quote, error)
+	GetQuote(ctx context.Context, quoteID string, userID uuid.UUID) (*ratequote.Quote, error)
+	ExecuteManualConversion(ctx context.Context, req *smartconversion.ManualConversionRequest, user *db.User) (*smartconversion.ManualConversionResponse, error)
+}
+
+// SetFundingConverter enables funding vaults from wallets in another currency
+// Call this during service initialization, once the conversion service exists
+func (s *VaultService) SetFundingConverter(converter FundingConverter) {
+	s.fundingConverter = converter
+	s.logger.Info("Funding converter integrated with vault service")
+}
+
+// ============================================================================
+// FUNDING MODELS
+// ============================================================================
+
+type FundingQuoteRequest struct {
+	SourceCurrency string `json:"source_currency" binding:"required" example:"USD" enums:"NGN,USD,USDC,USDT"`
+	// debited from the source wallet; the vault receives the converted net amount
+	Amount string `json:"amount" binding:"required" example:"100"`
+}
+
+// FundVaultRequest deposits into a vault from the user's wallet in SourceCurrency. Amount is
+// in the source currency and the vault is credited with the converted net amount.
+type FundVaultRequest struct {
+	UserID         uuid.UUID
+	VaultID        uuid.UUID
+	SourceCurrency string
+	Amount         string
+	Description    string
+	IdempotencyKey string
+	// QuoteID executes at the terms the user was shown; empty prices the conversion now
+	QuoteID string
+}
+
+// VaultCurrencySummary totals the user's vaults in one currency
+type VaultCurrencySummary struct {
+	Currency        string `json:"currency"`
+	VaultCount      int64  `json:"vault_count"`
+	ActiveVaults    int64  `json:"active_vaults"`
+	CompletedVaults int64  `json:"completed_vaults"`
+	Balance         string `json:"balance"`
+	YieldEarned     string `json:"yield_earned"`
+	// balance and yield valued in the display currency
+	Value      string `json:"value"`
+	YieldValue string `json:"yield_value"`
+}
+
+// VaultSummaryResponse consolidates the user's vaults across currencies in a display currency
+type VaultSummaryResponse struct {
+	DisplayCurrency  string                 `json:"display_currency"`
+	TotalVaults      int64                  `json:"total_vaults"`
+	ActiveVaults     int64                  `json:"active_vaults"`
+	CompletedVaults  int64                  `json:"completed_vaults"`
+	TotalBalance     string                 `json:"total_balance"`
+	TotalYieldEarned string                 `json:"total_yield_earned"`
+	Currencies       []VaultCurrencySummary `json:"currencies"`
+	ValuedAt         time.Time              `json:"valued_at"`
+}
+
+// ============================================================================
+// CROSS-CURRENCY FUNDING
+// ============================================================================
+
+// QuoteVaultFunding prices converting an amount from the user's wallet in another currency
+// into the vault currency. The quote can be passed back to FundFromWallet to deposit at
+// exactly those terms before it expires.
+func (s *VaultService) QuoteVaultFunding(ctx context.Context, vaultID, userID uuid.UUID, req FundingQuoteRequest) (*ratequote.Quote, error) {
+	if s.fundingConverter == nil {
+		return nil, ErrFundingUnavailable
+	}
+
+	vault, err := s.fundableVault(ctx, vaultID, req.SourceCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return s.fundingConverter.QuoteConversion(ctx, &smartconversion.ConversionQuoteRequest{
+		SourceCurrency: req.SourceCurrency,
+		TargetCurrency: vault.Currency,
+		Amount:         req.Amount,
+	}, &user)
+}
+
+// FundFromWallet converts from the user's wallet in another currency into their wallet in the
+// vault currency, then deposits the converted amount into the vault.
+//
+// The conversion and the deposit commit separately. If the deposit fails after the conversion
+// went through, the converted funds stay in the user's vault-currency wallet and the returned
+// error wraps ErrFundingIncomplete. Retrying with the same idempotency key never converts
+// twice: it deposits what the earlier attempt converted, or returns the deposit it made.
+func (s *VaultService) FundFromWallet(ctx context.Context, req FundVaultRequest) (*db.VaultTransaction, error) {
+	if s.fundingConverter == nil {
+		return nil, ErrFundingUnavailable
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidAmount
+	}
+
+	vault, err := s.fundableVault(ctx, req.VaultID, req.SourceCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// an earlier attempt with this key already deposited
+	deposited, err := s.store.GetVaultTransactionByReference(ctx, sql.NullString{String: req.IdempotencyKey, Valid: true})
+	if err == nil {
+		if deposited.UserID != user.ID || deposited.VaultID != vault.ID {
+			return nil, ErrFundingKeyReused
+		}
+		return &deposited, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check for an earlier deposit: %w", err)
+	}
+
+	conversionRef := req.IdempotencyKey + "-fx"
+	conversion, err := s.priorFundingConversion(ctx, conversionRef, user.ID, req.SourceCurrency, vault.Currency, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if conversion == nil {
+		converted, err := s.convertForFunding(ctx, req, &user, vault, amount, conversionRef)
+		if err != nil {
+			return nil, err
+		}
+		// only deposit what the ledger shows was converted, never the converter's word for it
+		conversion, err = s.priorFundingConversion(ctx, conversionRef, user.ID, req.SourceCurrency, vault.Currency, amount)
+		if err != nil {
+			return nil, err
+		}
+		if conversion == nil {
+			return nil, fmt.Errorf("funding conversion %s was not recorded", conversionRef)
+		}
+		conversion.quoteID = converted.quoteID
+	} else {
+		s.logger.Info(fmt.Sprintf("Resuming vault %s funding %s: conversion %s already completed",
+			vault.ID, req.IdempotencyKey, conversionRef))
+	}
+
+	// the conversion credited the vault-currency wallet, which now funds the deposit
+	targetWallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: user.ID,
+		Currency:   vault.Currency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %s is in your %s wallet: %v", ErrFundingIncomplete, conversion.netAmount, vault.Currency, vault.Currency, err)
+	}
+
+	description := req.Description
+	if description == "" {
+		description = fmt.Sprintf("Funded from %s", req.SourceCurrency)
+	}
+
+	vtx, err := s.Deposit(ctx, DepositRequest{
+		UserID:         user.ID,
+		VaultID:        vault.ID,
+		FromWalletID:   targetWallet.ID,
+		Amount:         conversion.netAmount.String(),
+		Currency:       vault.Currency,
+		Description:    description,
+		IdempotencyKey: req.IdempotencyKey,
+		Metadata: map[string]any{
+			"funding": map[string]any{
+				"source_currency":      req.SourceCurrency,
+				"source_amount":        conversion.sourceAmount.String(),
+				"rate":                 conversion.rate.String(),
+				"fees":                 conversion.fees.String(),
+				"quote_id":             conversion.quoteID,
+				"conversion_reference": conversionRef,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %s is in your %s wallet: %v", ErrFundingIncomplete, conversion.netAmount, vault.Currency, vault.Currency, err)
+	}
+
+	return vtx, nil
+}
+
+// fundingConversion is the conversion that funds a cross-currency deposit
+type fundingConversion struct {
+	sourceAmount decimal.Decimal
+	rate         decimal.Decimal
+	fees         decimal.Decimal
+	netAmount    decimal.Decimal
+	// empty when the conversion was resumed from an earlier attempt
+	quoteID string
+}
+
+// priorFundingConversion returns the conversion an earlier attempt with the same idempotency
+// key completed, or nil if there was none
+func (s *VaultService) priorFundingConversion(ctx context.Context, reference string, userID uuid.UUID, sourceCurrency, targetCurrency string, amount decimal.Decimal) (*fundingConversion, error) {
+	prior, err := s.store.GetTransactionByIdempotencyKey(ctx, reference)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for an earlier conversion: %w", err)
+	}
+	if prior.UserID != userID {
+		return nil, ErrFundingKeyReused
+	}
+
+	history, err := s.store.GetConversionHistoryByTransactionID(ctx, uuid.NullUUID{UUID: prior.ID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get earlier conversion %s: %w", reference, err)
+	}
+
+	if history.Status != "success" {
+		return nil, fmt.Errorf("earlier conversion %s is %s", reference, history.Status)
+	}
+
+	sourceAmount, _ := decimal.NewFromString(history.SourceAmount)
+	if history.SourceCurrency != sourceCurrency || history.TargetCurrency != targetCurrency || !sourceAmount.Equal(amount) {
+		return nil, ErrFundingKeyReused
+	}
+
+	rate, _ := decimal.NewFromString(history.ExecutedRate)
+	fees, _ := decimal.NewFromString(history.Fees.String)
+	netAmount, err := decimal.NewFromString(history.NetAmount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid net amount on conversion %s: %w", reference, err)
+	}
+
+	return &fundingConversion{
+		sourceAmount: sourceAmount,
+		rate:         rate,
+		fees:         fees,
+		netAmount:    netAmount,
+	}, nil
+}
+
+// convertForFunding converts the funding amount into the vault currency at the request's
+// quote, or at a fresh one
+func (s *VaultService) convertForFunding(ctx context.Context, req FundVaultRequest, user *db.User, vault *db.VaultSaving, amount decimal.Decimal, reference string) (*fundingConversion, error) {
+	var quote *ratequote.Quote
+	var err error
+	if req.QuoteID != "" {
+		quote, err = s.fundingConverter.GetQuote(ctx, req.QuoteID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if quote.SourceCurrency != req.SourceCurrency || quote.TargetCurrency != vault.Currency || !quote.SourceAmount.Equal(amount) {
+			return nil, ratequote.ErrQuoteMismatch
+		}
+	} else {
+		quote, err = s.fundingConverter.QuoteConversion(ctx, &smartconversion.ConversionQuoteRequest{
+			SourceCurrency: req.SourceCurrency,
+			TargetCurrency: vault.Currency,
+			Amount:         req.Amount,
+		}, user)
+		if err != nil {
+			return nil, fmt.Errorf("failed to quote conversion: %w", err)
+		}
+	}
+
+	// the conversion takes reference as its idempotency key, so a retry finds it
+	if _, err := s.fundingConverter.ExecuteManualConversion(ctx, &smartconversion.ManualConversionRequest{
+		SourceCurrency: req.SourceCurrency,
+		TargetCurrency: vault.Currency,
+		Amount:         req.Amount,
+		Reference:      reference,
+		QuoteID:        quote.QuoteID,
+	}, user); err != nil {
+		return nil, fmt.Errorf("failed to convert %s to %s: %w", req.SourceCurrency, vault.Currency, err)
+	}
+
+	s.logger.Info(fmt.Sprintf("Converted %s %s to %s %s for vault %s (quote %s)",
+		req.Amount, req.SourceCurrency, quote.NetAmount, vault.Currency, vault.ID, quote.QuoteID))
+
+	return &fundingConversion{
+		sourceAmount: quote.SourceAmount,
+		rate:         quote.Rate,
+		fees:         quote.Fees,
+		netAmount:    quote.NetAmount,
+		quoteID:      quote.QuoteID,
+	}, nil
+}
+
+// fundableVault loads a vault that can take a deposit converted from sourceCurrency
+func (s *VaultService) fundableVault(ctx context.Context, vaultID uuid.UUID, sourceCurrency string) (*db.VaultSaving, error) {
+	vault, err := s.store.GetVaultGoalByID(ctx, vaultID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVaultNotFound
+		}
+		return nil, fmt.Errorf("failed to get vault: %w", err)
+	}
+	if vault.VaultType == string(SavingsTypeLocked) {
+		return nil, ErrVaultLocked
+	}
+	if !currency.IsCurrencyValid(sourceCurrency) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCurrency, sourceCurrency)
+	}
+	if sourceCurrency == vault.Currency {
+		return nil, fmt.Errorf("%w: the vault is already in %s, deposit without converting", ErrInvalidCurrency, sourceCurrency)
+	}
+	return &vault, nil
+}
+
+// ============================================================================
+// CONSOLIDATED SUMMARY
+// ============================================================================
+
+// GetConsolidatedSummary totals the user's open vaults per currency and values them in
+// displayCurrency at current rates
+func (s *VaultService) GetConsolidatedSummary(ctx context.Context, userID uuid.UUID, displayCurrency string) (*VaultSummaryResponse, error) {
+	displayCurrency = strings.ToUpper(displayCurrency)
+	if !currency.IsCurrencyValid(displayCurrency) {
+		return nil, ErrUnsupportedDisplayCurrency
+	}
+
+	rows, err := s.store.GetUserVaultBalancesByCurrency(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vault balances: %w", err)
+	}
+
+	displayPrice, err := utils.ConvertToUSD(ctx, decimal.NewFromInt(1), displayCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price %s in USD: %w", displayCurrency, err)
+	}
+	if !displayPrice.IsPositive() {
+		return nil, fmt.Errorf("invalid USD price for %s", displayCurrency)
+	}
+
+	summary := &VaultSummaryResponse{
+		DisplayCurrency: displayCurrency,
+		Currencies:      []VaultCurrencySummary{},
+		ValuedAt:        time.Now().UTC(),
+	}
+
+	var totalBalance, totalYield decimal.Decimal
+	for _, row := range rows {
+		balance, _ := decimal.NewFromString(row.TotalBalance)
+		yield, _ := decimal.NewFromString(row.TotalYieldEarned)
+
+		// rows are one per currency, so each is priced once
+		price := decimal.NewFromInt(1)
+		if row.Currency != displayCurrency {
+			priceUSD, err := utils.ConvertToUSD(ctx, decimal.NewFromInt(1), row.Currency)
+			if err != nil {
+				return nil, fmt.Errorf("failed to price %s in USD: %w", row.Currency, err)
+			}
+			price = priceUSD.Div(displayPrice)
+		}
+
+		value := balance.Mul(price).Round(2)
+		yieldValue := yield.Mul(price).Round(2)
+		totalBalance = totalBalance.Add(value)
+		totalYield = totalYield.Add(yieldValue)
+
+		summary.TotalVaults += row.VaultCount
+		summary.ActiveVaults += row.ActiveVaults
+		summary.CompletedVaults += row.CompletedVaults
+		summary.Currencies = append(summary.Currencies, VaultCurrencySummary{
+			Currency:        row.Currency,
+			VaultCount:      row.VaultCount,
+			ActiveVaults:    row.ActiveVaults,
+			CompletedVaults: row.CompletedVaults,
+			Balance:         balance.String(),
+			YieldEarned:     yield.String(),
+			Value:           value.StringFixed(2),
+			YieldValue:      yieldValue.StringFixed(2),
+		})
+	}
+
+	summary.TotalBalance = totalBalance.StringFixed(2)
+	summary.TotalYieldEarned = totalYield.StringFixed(2)
+	return summary, nil
+}
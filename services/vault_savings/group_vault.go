@@ -0,0 +1,1288 @@
+package vaultsavings
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/transaction"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// ============================================================================
+// GROUP VAULT TYPES
+// ============================================================================
+
+type GroupWithdrawalRule string
+
+const (
+	// GroupWithdrawalOwnerOnly lets the owner withdraw as from a personal vault
+	GroupWithdrawalOwnerOnly GroupWithdrawalRule = "owner_only"
+	// GroupWithdrawalMajority pays a member once more than half of the active members approve
+	GroupWithdrawalMajority GroupWithdrawalRule = "majority_approval"
+	// GroupWithdrawalRotating pays the whole balance to one member per cycle, ajo style
+	GroupWithdrawalRotating GroupWithdrawalRule = "rotating"
+)
+
+type GroupMemberRole string
+
+const (
+	GroupMemberOwner  GroupMemberRole = "owner"
+	GroupMemberMember GroupMemberRole = "member"
+)
+
+type GroupMemberStatus string
+
+const (
+	GroupMemberInvited  GroupMemberStatus = "invited"
+	GroupMemberActive   GroupMemberStatus = "active"
+	GroupMemberDeclined GroupMemberStatus = "declined"
+	GroupMemberLeft     GroupMemberStatus = "left"
+	GroupMemberRemoved  GroupMemberStatus = "removed"
+)
+
+type GroupActivityType string
+
+const (
+	GroupActivityCreated             GroupActivityType = "created"
+	GroupActivityMemberInvited       GroupActivityType = "member_invited"
+	GroupActivityMemberJoined        GroupActivityType = "member_joined"
+	GroupActivityMemberDeclined      GroupActivityType = "member_declined"
+	GroupActivityMemberLeft          GroupActivityType = "member_left"
+	GroupActivityMemberRemoved       GroupActivityType = "member_removed"
+	GroupActivityContribution        GroupActivityType = "contribution"
+	GroupActivityWithdrawalRequested GroupActivityType = "withdrawal_requested"
+	GroupActivityWithdrawalVoted     GroupActivityType = "withdrawal_voted"
+	GroupActivityWithdrawalExecuted  GroupActivityType = "withdrawal_executed"
+	GroupActivityWithdrawalRejected  GroupActivityType = "withdrawal_rejected"
+	GroupActivityWithdrawalExpired   GroupActivityType = "withdrawal_expired"
+	GroupActivityRotationPayout      GroupActivityType = "rotation_payout"
+)
+
+type WithdrawalRequestStatus string
+
+const (
+	WithdrawalRequestPending  WithdrawalRequestStatus = "pending"
+	WithdrawalRequestExecuted WithdrawalRequestStatus = "executed"
+	WithdrawalRequestRejected WithdrawalRequestStatus = "rejected"
+	WithdrawalRequestExpired  WithdrawalRequestStatus = "expired"
+	WithdrawalRequestFailed   WithdrawalRequestStatus = "failed"
+)
+
+// withdrawalRequestTTL is how long members have to vote on a withdrawal request
+const withdrawalRequestTTL = 72 * time.Hour
+
+// ============================================================================
+// GROUP VAULT MODELS
+// ============================================================================
+
+type CreateGroupVaultRequest struct {
+	CreateVaultGoalRequest
+	WithdrawalRule     string  `json:"withdrawal_rule" default:"owner_only" enums:"owner_only,majority_approval,rotating"`
+	ContributionAmount *string `json:"contribution_amount" example:"10000.00"`
+	// required for rotating groups
+	RotationIntervalDays *int32   `json:"rotation_interval_days" example:"30"`
+	MemberTags           []string `json:"member_tags" example:"ada,tunde"`
+}
+
+type GroupMemberResponse struct {
+	UserID           uuid.UUID `json:"user_id"`
+	UserTag          string    `json:"user_tag"`
+	FirstName        string    `json:"first_name"`
+	LastName         string    `json:"last_name"`
+	Role             string    `json:"role"`
+	Status           string    `json:"status"`
+	PayoutPosition   int32     `json:"payout_position,omitempty"`
+	TotalContributed string    `json:"total_contributed"`
+	TotalReceived    string    `json:"total_received"`
+	// percentage of everything contributed to the group
+	ContributionShare string `json:"contribution_share"`
+	// percentage of the goal this member has contributed
+	GoalProgress string    `json:"goal_progress"`
+	JoinedAt     time.Time `json:"joined_at"`
+}
+
+type GroupWithdrawalRequestResponse struct {
+	ID                uuid.UUID `json:"id"`
+	VaultID           uuid.UUID `json:"vault_id"`
+	RequestedBy       uuid.UUID `json:"requested_by"`
+	Amount            string    `json:"amount"`
+	Reason            string    `json:"reason"`
+	Status            string    `json:"status"`
+	ApprovalsRequired int32     `json:"approvals_required"`
+	Approvals         int64     `json:"approvals"`
+	Rejections        int64     `json:"rejections"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	ResolvedAt        time.Time `json:"resolved_at"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func MapGroupWithdrawalRequestToResponse(r *db.VaultGroupWithdrawalRequest, votes db.CountGroupWithdrawalVotesRow) *GroupWithdrawalRequestResponse {
+	return &GroupWithdrawalRequestResponse{
+		ID:                r.ID,
+		VaultID:           r.VaultID,
+		RequestedBy:       r.RequestedBy,
+		Amount:            r.Amount,
+		Reason:            r.Reason.String,
+		Status:            r.Status,
+		ApprovalsRequired: r.ApprovalsRequired,
+		Approvals:         votes.Approvals,
+		Rejections:        votes.Rejections,
+		ExpiresAt:         r.ExpiresAt,
+		ResolvedAt:        r.ResolvedAt.Time,
+		CreatedAt:         r.CreatedAt,
+	}
+}
+
+type GroupVaultResponse struct {
+	Vault                *VaultSavingResponse  `json:"vault"`
+	OwnerID              uuid.UUID             `json:"owner_id"`
+	WithdrawalRule       string                `json:"withdrawal_rule"`
+	ContributionAmount   string                `json:"contribution_amount"`
+	RotationIntervalDays int32                 `json:"rotation_interval_days,omitempty"`
+	NextPayoutAt         time.Time             `json:"next_payout_at,omitempty"`
+	NextPayoutPosition   int32                 `json:"next_payout_position,omitempty"`
+	TotalContributed     string                `json:"total_contributed"`
+	Members              []GroupMemberResponse `json:"members"`
+	// open majority_approval request, if any
+	PendingWithdrawal *GroupWithdrawalRequestResponse `json:"pending_withdrawal,omitempty"`
+}
+
+type GroupInviteResponse struct {
+	VaultID            uuid.UUID `json:"vault_id"`
+	VaultName          string    `json:"vault_name"`
+	Currency           string    `json:"currency"`
+	GoalAmount         string    `json:"goal_amount"`
+	WithdrawalRule     string    `json:"withdrawal_rule"`
+	ContributionAmount string    `json:"contribution_amount"`
+	InvitedBy          string    `json:"invited_by"`
+	InvitedAt          time.Time `json:"invited_at"`
+}
+
+func MapGroupInviteToResponse(i *db.ListUserGroupInvitesRow) *GroupInviteResponse {
+	return &GroupInviteResponse{
+		VaultID:            i.VaultID,
+		VaultName:          i.VaultName,
+		Currency:           i.Currency,
+		GoalAmount:         i.GoalAmount.String,
+		WithdrawalRule:     i.WithdrawalRule,
+		ContributionAmount: i.ContributionAmount.String,
+		InvitedBy:          i.InvitedByTag.String,
+		InvitedAt:          i.CreatedAt,
+	}
+}
+
+type GroupActivityResponse struct {
+	ID           int64          `json:"id"`
+	ActorID      uuid.UUID      `json:"actor_id"`
+	ActorTag     string         `json:"actor_tag"`
+	ActivityType string         `json:"activity_type"`
+	Amount       string         `json:"amount"`
+	Message      string         `json:"message"`
+	Metadata     map[string]any `json:"metadata"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+func MapGroupActivityToResponse(a *db.ListVaultGroupActivityRow) *GroupActivityResponse {
+	return &GroupActivityResponse{
+		ID:           a.ID,
+		ActorID:      a.ActorID.UUID,
+		ActorTag:     a.ActorTag.String,
+		ActivityType: a.ActivityType,
+		Amount:       a.Amount.String,
+		Message:      a.Message,
+		Metadata:     utils.UnmarshalMetadata(a.Metadata),
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+// ============================================================================
+// CREATE GROUP VAULT
+// ============================================================================
+
+// CreateGroupVault creates a vault goal owned by userID, makes it a group with the requested
+// withdrawal rule and invites the users behind memberTags
+func (s *VaultService) CreateGroupVault(ctx context.Context, req CreateGroupVaultRequest, userID uuid.UUID, ip, ua string) (*GroupVaultResponse, error) {
+	if req.WithdrawalRule == "" {
+		req.WithdrawalRule = string(GroupWithdrawalOwnerOnly)
+	}
+	switch GroupWithdrawalRule(req.WithdrawalRule) {
+	case GroupWithdrawalOwnerOnly, GroupWithdrawalMajority:
+		if req.RotationIntervalDays != nil {
+			return nil, fmt.Errorf("%w: rotation_interval_days is only set on rotating groups", ErrInvalidWithdrawalRule)
+		}
+	case GroupWithdrawalRotating:
+		if req.RotationIntervalDays == nil || *req.RotationIntervalDays <= 0 {
+			return nil, fmt.Errorf("%w: rotating groups need rotation_interval_days", ErrInvalidWithdrawalRule)
+		}
+	default:
+		return nil, ErrInvalidWithdrawalRule
+	}
+
+	if req.ContributionAmount != nil {
+		amount, err := decimal.NewFromString(*req.ContributionAmount)
+		if err != nil || amount.LessThanOrEqual(decimal.Zero) {
+			return nil, fmt.Errorf("%w: contribution amount", ErrInvalidAmount)
+		}
+	}
+
+	// Resolve tags before anything is created, so a typo fails the whole request
+	invitees, err := s.resolveMemberTags(ctx, userID, req.MemberTags)
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := s.CreateVaultGoal(ctx, req.CreateVaultGoalRequest, userID, ip, ua)
+	if err != nil {
+		return nil, err
+	}
+
+	var rotation sql.NullInt32
+	var nextPayout sql.NullTime
+	if req.RotationIntervalDays != nil {
+		rotation = sql.NullInt32{Int32: *req.RotationIntervalDays, Valid: true}
+		nextPayout = nullTime(time.Now().AddDate(0, 0, int(*req.RotationIntervalDays)))
+	}
+
+	err = s.store.ExecTx(ctx, func(q *db.Queries) error {
+		if _, err := q.CreateVaultGroup(ctx, db.CreateVaultGroupParams{
+			VaultID:              goal.ID,
+			OwnerID:              userID,
+			WithdrawalRule:       req.WithdrawalRule,
+			ContributionAmount:   nullString(stringOrEmpty(req.ContributionAmount)),
+			RotationIntervalDays: rotation,
+			NextPayoutAt:         nextPayout,
+		}); err != nil {
+			return fmt.Errorf("failed to create vault group: %w", err)
+		}
+
+		if _, err := q.AddVaultGroupMember(ctx, db.AddVaultGroupMemberParams{
+			VaultID:        goal.ID,
+			UserID:         userID,
+			Role:           string(GroupMemberOwner),
+			Status:         string(GroupMemberActive),
+			PayoutPosition: sql.NullInt32{Int32: 1, Valid: true},
+			JoinedAt:       nullTime(time.Now()),
+		}); err != nil {
+			return fmt.Errorf("failed to add group owner: %w", err)
+		}
+
+		if err := logGroupActivity(ctx, q, goal.ID, &userID, GroupActivityCreated, decimal.Zero,
+			fmt.Sprintf("Created the group with %s withdrawals", strings.ReplaceAll(req.WithdrawalRule, "_", " ")), nil); err != nil {
+			return err
+		}
+
+		return s.addInvites(ctx, q, goal.ID, userID, invitees)
+	})
+	if err != nil {
+		// Don't leave a personal vault behind for a group that was never set up
+		if delErr := s.store.DeleteVaultGoal(ctx, goal.ID); delErr != nil {
+			s.logger.Error(fmt.Sprintf("Failed to remove vault %s after group setup failed: %v", goal.ID, delErr))
+		}
+		return nil, err
+	}
+
+	s.sendGroupInvites(ctx, userID, goal.VaultName, invitees)
+
+	s.logger.Info(fmt.Sprintf("Created group vault %s for user %s with %d invites", goal.ID, userID, len(invitees)))
+	return s.GetGroupVault(ctx, goal.ID, userID)
+}
+
+// ============================================================================
+// MEMBERSHIP
+// ============================================================================
+
+// InviteGroupMembers invites the users behind tags to the group. Only the owner can invite.
+func (s *VaultService) InviteGroupMembers(ctx context.Context, vaultID, userID uuid.UUID, tags []string) (int, error) {
+	group, err := s.getGroup(ctx, vaultID)
+	if err != nil {
+		return 0, err
+	}
+	if group.OwnerID != userID {
+		return 0, ErrNotGroupOwner
+	}
+
+	invitees, err := s.resolveMemberTags(ctx, userID, tags)
+	if err != nil {
+		return 0, err
+	}
+	if len(invitees) == 0 {
+		return 0, errors.New("no users to invite")
+	}
+
+	vault, err := s.store.GetVaultGoalByID(ctx, vaultID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get vault: %w", err)
+	}
+
+	if err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		return s.addInvites(ctx, q, vaultID, userID, invitees)
+	}); err != nil {
+		return 0, err
+	}
+
+	s.sendGroupInvites(ctx, userID, vault.VaultName, invitees)
+	return len(invitees), nil
+}
+
+// addInvites invites each user, skipping those already invited or active
+func (s *VaultService) addInvites(ctx context.Context, q *db.Queries, vaultID, inviterID uuid.UUID, invitees []db.User) error {
+	for i := range invitees {
+		_, err := q.AddVaultGroupMember(ctx, db.AddVaultGroupMemberParams{
+			VaultID:   vaultID,
+			UserID:    invitees[i].ID,
+			Role:      string(GroupMemberMember),
+			Status:    string(GroupMemberInvited),
+			InvitedBy: uuid.NullUUID{UUID: inviterID, Valid: true},
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to invite %s: %w", invitees[i].UserTag.String, err)
+		}
+
+		if err := logGroupActivity(ctx, q, vaultID, &inviterID, GroupActivityMemberInvited, decimal.Zero,
+			fmt.Sprintf("Invited @%s", invitees[i].UserTag.String), map[string]any{"user_id": invitees[i].ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RespondToGroupInvite accepts or declines the user's pending invite. Members who join a rotating
+// group are paid after everyone already in it.
+func (s *VaultService) RespondToGroupInvite(ctx context.Context, vaultID, userID uuid.UUID, accept bool) error {
+	tx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := s.store.WithTx(tx)
+
+	// Serialises joins, so payout positions stay unique
+	if _, err := qtx.LockVaultGroupForUpdate(ctx, vaultID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotGroupVault
+		}
+		return fmt.Errorf("failed to lock vault group: %w", err)
+	}
+
+	activity, message := GroupActivityMemberDeclined, "Declined the invite"
+	if accept {
+		position, err := qtx.NextVaultGroupPayoutPosition(ctx, vaultID)
+		if err != nil {
+			return fmt.Errorf("failed to get payout position: %w", err)
+		}
+		if _, err := qtx.ActivateVaultGroupMember(ctx, db.ActivateVaultGroupMemberParams{
+			VaultID:        vaultID,
+			UserID:         userID,
+			PayoutPosition: sql.NullInt32{Int32: position, Valid: true},
+		}); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNoGroupInvite
+			}
+			return fmt.Errorf("failed to join group: %w", err)
+		}
+		activity, message = GroupActivityMemberJoined, "Joined the group"
+	} else {
+		n, err := qtx.UpdateVaultGroupMemberStatus(ctx, db.UpdateVaultGroupMemberStatusParams{
+			NewStatus: string(GroupMemberDeclined),
+			VaultID:   vaultID,
+			UserID:    userID,
+			OldStatus: string(GroupMemberInvited),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to decline invite: %w", err)
+		}
+		if n == 0 {
+			return ErrNoGroupInvite
+		}
+	}
+
+	if err := logGroupActivity(ctx, qtx, vaultID, &userID, activity, decimal.Zero, message, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if accept {
+		s.notifyGroupMemberChange(ctx, vaultID, userID, "joined")
+	}
+	return nil
+}
+
+// LeaveGroupVault takes the user out of the group. What they contributed stays in the vault.
+func (s *VaultService) LeaveGroupVault(ctx context.Context, vaultID, userID uuid.UUID) error {
+	group, err := s.getGroup(ctx, vaultID)
+	if err != nil {
+		return err
+	}
+	if group.OwnerID == userID {
+		return errors.New("the owner cannot leave the group")
+	}
+
+	return s.endMembership(ctx, vaultID, userID, userID, GroupMemberLeft, GroupActivityMemberLeft, "Left the group", "left")
+}
+
+// RemoveGroupMember takes a member out of the group. Only the owner can remove members.
+func (s *VaultService) RemoveGroupMember(ctx context.Context, vaultID, ownerID, memberID uuid.UUID) error {
+	group, err := s.getGroup(ctx, vaultID)
+	if err != nil {
+		return err
+	}
+	if group.OwnerID != ownerID {
+		return ErrNotGroupOwner
+	}
+	if memberID == ownerID {
+		return errors.New("the owner cannot be removed from the group")
+	}
+
+	member, err := s.store.GetUserByID(ctx, memberID)
+	if err != nil {
+		return fmt.Errorf("failed to get member: %w", err)
+	}
+
+	return s.endMembership(ctx, vaultID, ownerID, memberID, GroupMemberRemoved, GroupActivityMemberRemoved,
+		fmt.Sprintf("Removed @%s", member.UserTag.String), "was removed from")
+}
+
+func (s *VaultService) endMembership(ctx context.Context, vaultID, actorID, memberID uuid.UUID, status GroupMemberStatus, activity GroupActivityType, message, verb string) error {
+	err := s.store.ExecTx(ctx, func(q *db.Queries) error {
+		n, err := q.UpdateVaultGroupMemberStatus(ctx, db.UpdateVaultGroupMemberStatusParams{
+			NewStatus: string(status),
+			VaultID:   vaultID,
+			UserID:    memberID,
+			OldStatus: string(GroupMemberActive),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update membership: %w", err)
+		}
+		if n == 0 {
+			return ErrNotGroupMember
+		}
+		return logGroupActivity(ctx, q, vaultID, &actorID, activity, decimal.Zero, message, map[string]any{"user_id": memberID})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.notifyGroupMemberChange(ctx, vaultID, memberID, verb)
+	return nil
+}
+
+// ============================================================================
+// VIEWING GROUPS
+// ============================================================================
+
+// GetGroupVault returns the group with each member's contributions. Invited users can see the
+// group before they join.
+func (s *VaultService) GetGroupVault(ctx context.Context, vaultID, userID uuid.UUID) (*GroupVaultResponse, error) {
+	group, err := s.getGroup(ctx, vaultID)
+	if err != nil {
+		return nil, err
+	}
+
+	vault, err := s.store.GetVaultGoalByID(ctx, vaultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vault: %w", err)
+	}
+
+	members, err := s.store.ListVaultGroupMembers(ctx, vaultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group members: %w", err)
+	}
+
+	visible := false
+	total := decimal.Zero
+	for _, m := range members {
+		if m.UserID == userID && (m.Status == string(GroupMemberActive) || m.Status == string(GroupMemberInvited)) {
+			visible = true
+		}
+		contributed, _ := decimal.NewFromString(m.TotalContributed)
+		total = total.Add(contributed)
+	}
+	if !visible {
+		return nil, ErrNotGroupMember
+	}
+
+	goal, _ := decimal.NewFromString(vault.GoalAmount.String)
+	hundred := decimal.NewFromInt(100)
+
+	resp := &GroupVaultResponse{
+		Vault:                MapVaultSavingToResponse(&vault),
+		OwnerID:              group.OwnerID,
+		WithdrawalRule:       group.WithdrawalRule,
+		ContributionAmount:   group.ContributionAmount.String,
+		RotationIntervalDays: group.RotationIntervalDays.Int32,
+		NextPayoutAt:         group.NextPayoutAt.Time,
+		TotalContributed:     total.StringFixed(4),
+		Members:              make([]GroupMemberResponse, 0, len(members)),
+	}
+	if group.WithdrawalRule == string(GroupWithdrawalRotating) {
+		resp.NextPayoutPosition = group.NextPayoutPosition
+	}
+
+	for _, m := range members {
+		if m.Status != string(GroupMemberActive) && m.Status != string(GroupMemberInvited) {
+			continue
+		}
+		contributed, _ := decimal.NewFromString(m.TotalContributed)
+		share, progress := decimal.Zero, decimal.Zero
+		if total.GreaterThan(decimal.Zero) {
+			share = contributed.Div(total).Mul(hundred)
+		}
+		if goal.GreaterThan(decimal.Zero) {
+			progress = contributed.Div(goal).Mul(hundred)
+		}
+		resp.Members = append(resp.Members, GroupMemberResponse{
+			UserID:            m.UserID,
+			UserTag:           m.UserTag.String,
+			FirstName:         m.FirstName.String,
+			LastName:          m.LastName.String,
+			Role:              m.Role,
+			Status:            m.Status,
+			PayoutPosition:    m.PayoutPosition.Int32,
+			TotalContributed:  m.TotalContributed,
+			TotalReceived:     m.TotalReceived,
+			ContributionShare: share.StringFixed(2),
+			GoalProgress:      progress.StringFixed(2),
+			JoinedAt:          m.JoinedAt.Time,
+		})
+	}
+
+	if group.WithdrawalRule == string(GroupWithdrawalMajority) {
+		pending, err := s.store.GetPendingGroupWithdrawalRequest(ctx, vaultID)
+		if err == nil {
+			votes, err := s.store.CountGroupWithdrawalVotes(ctx, pending.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to count votes: %w", err)
+			}
+			resp.PendingWithdrawal = MapGroupWithdrawalRequestToResponse(&pending, votes)
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get pending withdrawal: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// ListUserGroupVaults returns the group vaults the user is an active member of
+func (s *VaultService) ListUserGroupVaults(ctx context.Context, userID uuid.UUID) ([]VaultSavingResponse, error) {
+	vaults, err := s.store.ListUserGroupVaults(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group vaults: %w", err)
+	}
+
+	resp := make([]VaultSavingResponse, len(vaults))
+	for i, v := range vaults {
+		resp[i] = *MapVaultSavingToResponse(&v)
+	}
+	return resp, nil
+}
+
+// ListGroupInvites returns the user's pending group vault invites
+func (s *VaultService) ListGroupInvites(ctx context.Context, userID uuid.UUID) ([]GroupInviteResponse, error) {
+	invites, err := s.store.ListUserGroupInvites(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group invites: %w", err)
+	}
+
+	resp := make([]GroupInviteResponse, len(invites))
+	for i, inv := range invites {
+		resp[i] = *MapGroupInviteToResponse(&inv)
+	}
+	return resp, nil
+}
+
+// GetGroupActivity returns the group's activity feed, newest first
+func (s *VaultService) GetGroupActivity(ctx context.Context, vaultID, userID uuid.UUID, limit, offset int32) ([]GroupActivityResponse, error) {
+	if _, err := s.requireActiveMember(ctx, s.store.Queries, vaultID, userID); err != nil {
+		return nil, err
+	}
+
+	activity, err := s.store.ListVaultGroupActivity(ctx, db.ListVaultGroupActivityParams{
+		VaultID: vaultID,
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list group activity: %w", err)
+	}
+
+	resp := make([]GroupActivityResponse, len(activity))
+	for i, a := range activity {
+		resp[i] = *MapGroupActivityToResponse(&a)
+	}
+	return resp, nil
+}
+
+// IsGroupMember reports whether the user is an active member of the group vault
+func (s *VaultService) IsGroupMember(ctx context.Context, vaultID, userID uuid.UUID) bool {
+	_, err := s.requireActiveMember(ctx, s.store.Queries, vaultID, userID)
+	return err == nil
+}
+
+// ============================================================================
+// MAJORITY APPROVAL WITHDRAWALS
+// ============================================================================
+
+// RequestGroupWithdrawal opens a request to pay amount from the group to the requesting member.
+// The request counts as the requester's approval, so a one-member group is paid at once.
+func (s *VaultService) RequestGroupWithdrawal(ctx context.Context, vaultID, userID uuid.UUID, amount, reason string) (*GroupWithdrawalRequestResponse, error) {
+	amt, err := decimal.NewFromString(amount)
+	if err != nil || amt.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidAmount
+	}
+
+	tx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := s.store.WithTx(tx)
+
+	group, err := qtx.LockVaultGroupForUpdate(ctx, vaultID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotGroupVault
+		}
+		return nil, fmt.Errorf("failed to lock vault group: %w", err)
+	}
+	if group.WithdrawalRule != string(GroupWithdrawalMajority) {
+		return nil, ErrGroupWithdrawalRestricted
+	}
+	if _, err := s.requireActiveMember(ctx, qtx, vaultID, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := qtx.GetPendingGroupWithdrawalRequest(ctx, vaultID); err == nil {
+		return nil, ErrWithdrawalRequestPending
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check pending requests: %w", err)
+	}
+
+	vault, err := qtx.GetVaultGoalByID(ctx, vaultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vault: %w", err)
+	}
+	balance, _ := decimal.NewFromString(vault.CurrentBalance.String)
+	if balance.LessThan(amt) {
+		return nil, ErrInsufficientBalance
+	}
+
+	active, err := qtx.CountActiveVaultGroupMembers(ctx, vaultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count members: %w", err)
+	}
+
+	request, err := qtx.CreateGroupWithdrawalRequest(ctx, db.CreateGroupWithdrawalRequestParams{
+		VaultID:           vaultID,
+		RequestedBy:       userID,
+		Amount:            amt.StringFixed(4),
+		Reason:            nullString(reason),
+		ApprovalsRequired: int32(active/2 + 1),
+		ExpiresAt:         time.Now().Add(withdrawalRequestTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create withdrawal request: %w", err)
+	}
+
+	if _, err := qtx.CastGroupWithdrawalVote(ctx, db.CastGroupWithdrawalVoteParams{
+		RequestID: request.ID,
+		UserID:    userID,
+		Approve:   true,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record vote: %w", err)
+	}
+
+	if err := logGroupActivity(ctx, qtx, vaultID, &userID, GroupActivityWithdrawalRequested, amt,
+		fmt.Sprintf("Asked to withdraw %s %s", request.Amount, vault.Currency),
+		map[string]any{"request_id": request.ID, "reason": reason}); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.settleWithdrawalRequest(ctx, qtx, &request, active)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	requester, _ := s.store.GetUserByID(ctx, userID)
+	if resp.Status == string(WithdrawalRequestPending) {
+		s.notifyGroup(ctx, vaultID, userID, "Group Withdrawal Vote Needed",
+			fmt.Sprintf("%s wants to withdraw %s %s from %s. Approve or reject the request.", displayName(&requester), request.Amount, vault.Currency, vault.VaultName))
+	} else {
+		s.notifyWithdrawalOutcome(ctx, &vault, &requester, resp)
+	}
+	return resp, nil
+}
+
+// VoteGroupWithdrawal records a member's vote and settles the request once the outcome is
+// certain
+func (s *VaultService) VoteGroupWithdrawal(ctx context.Context, vaultID, requestID, userID uuid.UUID, approve bool) (*GroupWithdrawalRequestResponse, error) {
+	tx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := s.store.WithTx(tx)
+
+	request, err := qtx.GetGroupWithdrawalRequestForUpdate(ctx, requestID)
+	if err != nil || request.VaultID != vaultID {
+		if err == nil || errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("withdrawal request not found")
+		}
+		return nil, fmt.Errorf("failed to lock withdrawal request: %w", err)
+	}
+	if request.Status != string(WithdrawalRequestPending) || !request.ExpiresAt.After(time.Now()) {
+		return nil, ErrWithdrawalRequestClosed
+	}
+	if _, err := s.requireActiveMember(ctx, qtx, vaultID, userID); err != nil {
+		return nil, err
+	}
+
+	n, err := qtx.CastGroupWithdrawalVote(ctx, db.CastGroupWithdrawalVoteParams{
+		RequestID: requestID,
+		UserID:    userID,
+		Approve:   approve,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record vote: %w", err)
+	}
+	if n == 0 {
+		return nil, ErrAlreadyVoted
+	}
+
+	message := "Rejected the withdrawal request"
+	if approve {
+		message = "Approved the withdrawal request"
+	}
+	if err := logGroupActivity(ctx, qtx, vaultID, &userID, GroupActivityWithdrawalVoted, decimal.Zero, message,
+		map[string]any{"request_id": requestID, "approve": approve}); err != nil {
+		return nil, err
+	}
+
+	active, err := qtx.CountActiveVaultGroupMembers(ctx, vaultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count members: %w", err)
+	}
+
+	resp, err := s.settleWithdrawalRequest(ctx, qtx, &request, active)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if resp.Status != string(WithdrawalRequestPending) {
+		vault, err := s.store.GetVaultGoalByID(ctx, vaultID)
+		if err == nil {
+			requester, _ := s.store.GetUserByID(ctx, request.RequestedBy)
+			s.notifyWithdrawalOutcome(ctx, &vault, &requester, resp)
+		}
+	}
+	return resp, nil
+}
+
+// settleWithdrawalRequest pays the request out once it has enough approvals, or rejects it once
+// enough members have voted against it that it never can
+func (s *VaultService) settleWithdrawalRequest(ctx context.Context, qtx *db.Queries, request *db.VaultGroupWithdrawalRequest, active int64) (*GroupWithdrawalRequestResponse, error) {
+	votes, err := qtx.CountGroupWithdrawalVotes(ctx, request.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count votes: %w", err)
+	}
+
+	required := int64(request.ApprovalsRequired)
+	status := WithdrawalRequestPending
+	var vtxID uuid.NullUUID
+
+	switch {
+	case votes.Approvals >= required:
+		vault, err := qtx.LockVaultForUpdate(ctx, request.VaultID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock vault: %w", err)
+		}
+
+		amount, _ := decimal.NewFromString(request.Amount)
+		balance, _ := decimal.NewFromString(vault.CurrentBalance.String)
+		requester, memberErr := s.requireActiveMember(ctx, qtx, request.VaultID, request.RequestedBy)
+
+		if balance.LessThan(amount) || memberErr != nil {
+			status = WithdrawalRequestFailed
+			break
+		}
+
+		vtx, err := s.payGroupMember(ctx, qtx, &vault, requester.UserID, amount,
+			"Approved group vault withdrawal", map[string]any{"request_id": request.ID})
+		if err != nil {
+			return nil, err
+		}
+		status = WithdrawalRequestExecuted
+		vtxID = uuid.NullUUID{UUID: vtx.ID, Valid: true}
+
+		if err := logGroupActivity(ctx, qtx, request.VaultID, &request.RequestedBy, GroupActivityWithdrawalExecuted, amount,
+			fmt.Sprintf("Withdrew %s %s after approval", request.Amount, vault.Currency), map[string]any{"request_id": request.ID}); err != nil {
+			return nil, err
+		}
+	case votes.Rejections > active-required:
+		status = WithdrawalRequestRejected
+		if err := logGroupActivity(ctx, qtx, request.VaultID, nil, GroupActivityWithdrawalRejected, decimal.Zero,
+			"Withdrawal request rejected", map[string]any{"request_id": request.ID}); err != nil {
+			return nil, err
+		}
+	}
+
+	if status != WithdrawalRequestPending {
+		if err := qtx.ResolveGroupWithdrawalRequest(ctx, db.ResolveGroupWithdrawalRequestParams{
+			ID:                 request.ID,
+			Status:             string(status),
+			VaultTransactionID: vtxID,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to resolve withdrawal request: %w", err)
+		}
+		request.Status = string(status)
+		request.ResolvedAt = nullTime(time.Now())
+	}
+
+	return MapGroupWithdrawalRequestToResponse(request, votes), nil
+}
+
+// ExpireGroupWithdrawalRequests closes requests that ran out of time before enough members voted
+func (s *VaultService) ExpireGroupWithdrawalRequests(ctx context.Context) error {
+	expired, err := s.store.ExpireGroupWithdrawalRequests(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to expire withdrawal requests: %w", err)
+	}
+
+	for _, r := range expired {
+		if err := logGroupActivity(ctx, s.store.Queries, r.VaultID, nil, GroupActivityWithdrawalExpired, decimal.Zero,
+			"Withdrawal request expired before enough members approved", map[string]any{"request_id": r.ID}); err != nil {
+			s.logger.Error(err.Error())
+		}
+		if s.notifService != nil {
+			if _, err := s.notifService.CreateWithRecipients(ctx, nil, "Group Withdrawal Expired",
+				fmt.Sprintf("Your request to withdraw %s expired before enough members approved it.", r.Amount), "system", []uuid.UUID{r.RequestedBy}); err != nil {
+				s.logger.Error(fmt.Sprintf("Failed to create withdrawal expiry notification: %v", err))
+			}
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// ROTATING PAYOUTS
+// ============================================================================
+
+// ProcessGroupRotations pays every rotating group whose payout is due
+func (s *VaultService) ProcessGroupRotations(ctx context.Context, limit int32) (int, int, error) {
+	groups, err := s.store.GetDueGroupRotations(ctx, limit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch due rotations: %w", err)
+	}
+
+	successCount := 0
+	failureCount := 0
+
+	for _, g := range groups {
+		if err := s.processGroupRotation(ctx, g.VaultID); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to process rotation for group vault %s: %v", g.VaultID, err))
+			failureCount++
+		} else {
+			successCount++
+		}
+	}
+
+	return successCount, failureCount, nil
+}
+
+// processGroupRotation pays the vault's balance to the active member whose turn it is, then moves
+// the turn on. Members who left are skipped and the order wraps round after the last position.
+func (s *VaultService) processGroupRotation(ctx context.Context, vaultID uuid.UUID) error {
+	tx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := s.store.WithTx(tx)
+
+	group, err := qtx.LockVaultGroupForUpdate(ctx, vaultID)
+	if err != nil {
+		return fmt.Errorf("failed to lock vault group: %w", err)
+	}
+	now := time.Now()
+	if group.WithdrawalRule != string(GroupWithdrawalRotating) || group.NextPayoutAt.Time.After(now) {
+		return nil
+	}
+
+	vault, err := qtx.LockVaultForUpdate(ctx, vaultID)
+	if err != nil {
+		return fmt.Errorf("failed to lock vault: %w", err)
+	}
+
+	members, err := qtx.ListVaultGroupMembers(ctx, vaultID)
+	if err != nil {
+		return fmt.Errorf("failed to list group members: %w", err)
+	}
+
+	var recipient *db.ListVaultGroupMembersRow
+	for i := range members {
+		m := &members[i]
+		if m.Status != string(GroupMemberActive) || !m.PayoutPosition.Valid {
+			continue
+		}
+		if recipient == nil && m.PayoutPosition.Int32 >= group.NextPayoutPosition {
+			recipient = m
+		}
+	}
+	if recipient == nil {
+		// wrap round to the lowest position
+		for i := range members {
+			if members[i].Status == string(GroupMemberActive) && members[i].PayoutPosition.Valid {
+				recipient = &members[i]
+				break
+			}
+		}
+	}
+
+	nextPosition := group.NextPayoutPosition
+	balance, _ := decimal.NewFromString(vault.CurrentBalance.String)
+	paid := decimal.Zero
+
+	if recipient != nil {
+		nextPosition = recipient.PayoutPosition.Int32 + 1
+		if balance.GreaterThan(decimal.Zero) && vault.Status == string(SavingsStatusActive) {
+			if _, err := s.payGroupMember(ctx, qtx, &vault, recipient.UserID, balance,
+				"Group vault rotating payout", map[string]any{"payout_position": recipient.PayoutPosition.Int32}); err != nil {
+				return err
+			}
+			paid = balance
+
+			if err := logGroupActivity(ctx, qtx, vaultID, &recipient.UserID, GroupActivityRotationPayout, balance,
+				fmt.Sprintf("Received this cycle's payout of %s %s", balance.StringFixed(4), vault.Currency),
+				map[string]any{"payout_position": recipient.PayoutPosition.Int32}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Catch up on cycles missed while the scheduler was down without paying twice
+	nextPayout := group.NextPayoutAt.Time
+	for !nextPayout.After(now) {
+		nextPayout = nextPayout.AddDate(0, 0, int(group.RotationIntervalDays.Int32))
+	}
+
+	if err := qtx.AdvanceGroupRotation(ctx, db.AdvanceGroupRotationParams{
+		VaultID:            vaultID,
+		NextPayoutPosition: nextPosition,
+		NextPayoutAt:       nullTime(nextPayout),
+	}); err != nil {
+		return fmt.Errorf("failed to advance rotation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if paid.GreaterThan(decimal.Zero) {
+		recipientName := "@" + recipient.UserTag.String
+		s.notifyGroup(ctx, vaultID, recipient.UserID, "Group Vault Payout",
+			fmt.Sprintf("%s received this cycle's payout of %s %s from %s", recipientName, paid.StringFixed(2), vault.Currency, vault.VaultName))
+		s.notifyUser(ctx, recipient.UserID, "Group Vault Payout",
+			fmt.Sprintf("It's your turn! %s %s from %s has been paid to your wallet.", paid.StringFixed(2), vault.Currency, vault.VaultName))
+	}
+
+	s.logger.Info(fmt.Sprintf("Processed rotation for group vault %s: paid %s %s", vaultID, paid.StringFixed(4), vault.Currency))
+	return nil
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// payGroupMember moves amount from the group vault to the member's wallet in the vault currency
+func (s *VaultService) payGroupMember(ctx context.Context, qtx *db.Queries, vault *db.VaultSaving, memberID uuid.UUID, amount decimal.Decimal, description string, metadata map[string]any) (*db.VaultTransaction, error) {
+	destWallet, err := qtx.GetWalletByCurrencyForUpdate(ctx, db.GetWalletByCurrencyForUpdateParams{
+		CustomerID: memberID,
+		Currency:   vault.Currency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock destination wallet: %w", err)
+	}
+
+	balance, err := decimal.NewFromString(vault.CurrentBalance.String)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault balance: %w", err)
+	}
+	if balance.LessThan(amount) {
+		return nil, ErrInsufficientBalance
+	}
+	newBalance := balance.Sub(amount)
+
+	amountUsd, err := utils.ConvertToUSD(ctx, amount, vault.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount to USD: %w", err)
+	}
+
+	reference := utils.NewTxRef("vault_group_payout")
+
+	maintx, err := qtx.CreateTransaction(ctx, db.CreateTransactionParams{
+		UserID:          memberID,
+		Type:            string(transaction.Vault),
+		Description:     sql.NullString{String: description, Valid: true},
+		Amount:          amount.StringFixed(4),
+		Currency:        vault.Currency,
+		AmountUsd:       amountUsd.String(),
+		IdempotencyKey:  reference,
+		Status:          string(transaction.Success),
+		TransactionFlow: string(transaction.InPlatform),
+		TFrom:           string(transaction.Vault),
+		TTo:             string(transaction.Wallet),
+		Direction:       string(transaction.Credit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	meta, _ := json.Marshal(metadata)
+	vtx, err := qtx.CreateVaultTransaction(ctx, db.CreateVaultTransactionParams{
+		UserID:            memberID,
+		VaultID:           vault.ID,
+		TransactionType:   string(TransactionTypeWithdrawal),
+		Amount:            amount.StringFixed(4),
+		Currency:          vault.Currency,
+		DestinationWallet: uuid.NullUUID{UUID: destWallet.ID, Valid: true},
+		BalanceBefore:     balance.String(),
+		BalanceAfter:      newBalance.String(),
+		Reference:         nullString(reference),
+		Description:       nullString(description),
+		Metadata:          pqtype.NullRawMessage{RawMessage: meta, Valid: true},
+		Status:            nullString(string(TransactionStatusSuccessful)),
+		Requires2fa:       nullBool(false),
+		TransactionID:     uuid.NullUUID{UUID: maintx.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault transaction: %w", err)
+	}
+
+	if err := qtx.DecrementVaultBalance(ctx, db.DecrementVaultBalanceParams{
+		ID:             vault.ID,
+		CurrentBalance: nullString(amount.StringFixed(4)),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update vault balance: %w", err)
+	}
+
+	if _, err := qtx.IncrementWalletBalance(ctx, db.IncrementWalletBalanceParams{
+		ID:      destWallet.ID,
+		Balance: nullString(amount.StringFixed(4)),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update wallet balance: %w", err)
+	}
+
+	if err := qtx.AddVaultGroupPayout(ctx, db.AddVaultGroupPayoutParams{
+		VaultID:       vault.ID,
+		UserID:        memberID,
+		TotalReceived: amount.StringFixed(4),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record member payout: %w", err)
+	}
+
+	vault.CurrentBalance = nullString(newBalance.String())
+	return &vtx, nil
+}
+
+func (s *VaultService) getGroup(ctx context.Context, vaultID uuid.UUID) (*db.VaultGroup, error) {
+	group, err := s.store.GetVaultGroup(ctx, vaultID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotGroupVault
+		}
+		return nil, fmt.Errorf("failed to get vault group: %w", err)
+	}
+	return &group, nil
+}
+
+func (s *VaultService) requireActiveMember(ctx context.Context, q *db.Queries, vaultID, userID uuid.UUID) (*db.VaultGroupMember, error) {
+	member, err := q.GetVaultGroupMember(ctx, db.GetVaultGroupMemberParams{
+		VaultID: vaultID,
+		UserID:  userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotGroupMember
+		}
+		return nil, fmt.Errorf("failed to get group member: %w", err)
+	}
+	if member.Status != string(GroupMemberActive) {
+		return nil, ErrNotGroupMember
+	}
+	return &member, nil
+}
+
+// resolveMemberTags looks up the users to invite, ignoring the inviter and repeated tags
+func (s *VaultService) resolveMemberTags(ctx context.Context, inviterID uuid.UUID, tags []string) ([]db.User, error) {
+	seen := make(map[uuid.UUID]bool)
+	users := make([]db.User, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "@")
+		if tag == "" {
+			continue
+		}
+		user, err := s.store.GetUserByTag(ctx, sql.NullString{String: tag, Valid: true})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: %s", ErrUserTagNotFound, tag)
+			}
+			return nil, fmt.Errorf("failed to look up %s: %w", tag, err)
+		}
+		if user.ID == inviterID || seen[user.ID] {
+			continue
+		}
+		seen[user.ID] = true
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func logGroupActivity(ctx context.Context, q *db.Queries, vaultID uuid.UUID, actorID *uuid.UUID, activity GroupActivityType, amount decimal.Decimal, message string, metadata map[string]any) error {
+	var actor uuid.NullUUID
+	if actorID != nil {
+		actor = uuid.NullUUID{UUID: *actorID, Valid: true}
+	}
+	var amt sql.NullString
+	if !amount.IsZero() {
+		amt = nullString(amount.StringFixed(4))
+	}
+	var meta pqtype.NullRawMessage
+	if metadata != nil {
+		b, _ := json.Marshal(metadata)
+		meta = pqtype.NullRawMessage{RawMessage: b, Valid: true}
+	}
+
+	if err := q.CreateVaultGroupActivity(ctx, db.CreateVaultGroupActivityParams{
+		VaultID:      vaultID,
+		ActorID:      actor,
+		ActivityType: string(activity),
+		Amount:       amt,
+		Message:      message,
+		Metadata:     meta,
+	}); err != nil {
+		return fmt.Errorf("failed to record group activity: %w", err)
+	}
+	return nil
+}
+
+// notifyGroup sends an in-app and push notification to every active member except exclude
+func (s *VaultService) notifyGroup(ctx context.Context, vaultID, exclude uuid.UUID, title, message string) {
+	members, err := s.store.ListVaultGroupMembers(ctx, vaultID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to list members of group vault %s: %v", vaultID, err))
+		return
+	}
+
+	recipients := make([]uuid.UUID, 0, len(members))
+	for _, m := range members {
+		if m.Status == string(GroupMemberActive) && m.UserID != exclude {
+			recipients = append(recipients, m.UserID)
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	if s.notifService != nil {
+		if _, err := s.notifService.CreateWithRecipients(ctx, nil, title, message, "system", recipients); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to create group notification: %v", err))
+		}
+	}
+	if s.pushService != nil {
+		for _, id := range recipients {
+			if err := s.pushService.SendPushNotification(ctx, id, title, message); err != nil {
+				s.logger.Error(fmt.Sprintf("Failed to send group push to %s: %v", id, err))
+			}
+		}
+	}
+}
+
+func (s *VaultService) notifyUser(ctx context.Context, userID uuid.UUID, title, message string) {
+	if s.notifService != nil {
+		if _, err := s.notifService.CreateWithRecipients(ctx, nil, title, message, "system", []uuid.UUID{userID}); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to create notification: %v", err))
+		}
+	}
+	if s.pushService != nil {
+		if err := s.pushService.SendPushNotification(ctx, userID, title, message); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to send push: %v", err))
+		}
+	}
+}
+
+func (s *VaultService) sendGroupInvites(ctx context.Context, inviterID uuid.UUID, vaultName string, invitees []db.User) {
+	inviter, err := s.store.GetUserByID(ctx, inviterID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to get inviter for group invites: %v", err))
+		return
+	}
+	for i := range invitees {
+		s.notifyUser(ctx, invitees[i].ID, "Group Vault Invite",
+			fmt.Sprintf("%s invited you to save together in the group vault '%s'.", displayName(&inviter), vaultName))
+	}
+}
+
+func (s *VaultService) notifyGroupMemberChange(ctx context.Context, vaultID, memberID uuid.UUID, verb string) {
+	vault, err := s.store.GetVaultGoalByID(ctx, vaultID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to get vault for group notification: %v", err))
+		return
+	}
+	member, _ := s.store.GetUserByID(ctx, memberID)
+	s.notifyGroup(ctx, vaultID, memberID, "Group Vault Members",
+		fmt.Sprintf("%s %s the group vault '%s'.", displayName(&member), verb, vault.VaultName))
+}
+
+func (s *VaultService) notifyWithdrawalOutcome(ctx context.Context, vault *db.VaultSaving, requester *db.User, r *GroupWithdrawalRequestResponse) {
+	switch WithdrawalRequestStatus(r.Status) {
+	case WithdrawalRequestExecuted:
+		s.notifyGroup(ctx, vault.ID, requester.ID, "Group Withdrawal Approved",
+			fmt.Sprintf("%s withdrew %s %s from %s after the group approved it.", displayName(requester), r.Amount, vault.Currency, vault.VaultName))
+		s.notifyUser(ctx, requester.ID, "Group Withdrawal Approved",
+			fmt.Sprintf("Your request was approved and %s %s from %s has been paid to your wallet.", r.Amount, vault.Currency, vault.VaultName))
+	case WithdrawalRequestRejected:
+		s.notifyGroup(ctx, vault.ID, uuid.Nil, "Group Withdrawal Rejected",
+			fmt.Sprintf("The request by %s to withdraw %s %s from %s was rejected.", displayName(requester), r.Amount, vault.Currency, vault.VaultName))
+	case WithdrawalRequestFailed:
+		s.notifyUser(ctx, requester.ID, "Group Withdrawal Failed",
+			fmt.Sprintf("Your approved request to withdraw %s %s from %s could not be paid.", r.Amount, vault.Currency, vault.VaultName))
+	}
+}
+
+// displayName is how members are shown to each other
+func displayName(u *db.User) string {
+	if u.UserTag.Valid && u.UserTag.String != "" {
+		return "@" + u.UserTag.String
+	}
+	if u.FirstName.Valid && u.FirstName.String != "" {
+		return u.FirstName.String
+	}
+	return "A member"
+}
@@ -0,0 +1,715 @@
+package vaultsavings
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/transaction"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// ============================================================================
+// LOCKED VAULT TYPES
+// ============================================================================
+
+// LockTerms are the lengths, in days, a vault can be locked for
+var LockTerms = []int32{30, 60, 90, 180, 365}
+
+type MaturityAction string
+
+const (
+	// MaturityActionRollover locks the matured balance for another term at the product's rate then
+	MaturityActionRollover MaturityAction = "rollover"
+	// MaturityActionPayout moves the matured balance to the user's wallet
+	MaturityActionPayout MaturityAction = "payout"
+)
+
+type EarlyWithdrawalPolicy string
+
+const (
+	// EarlyWithdrawalForfeitYield pays out the principal and keeps the yield accrued this term
+	EarlyWithdrawalForfeitYield EarlyWithdrawalPolicy = "forfeit_yield"
+	// EarlyWithdrawalPenalty pays out the balance less a percentage of the principal
+	EarlyWithdrawalPenalty EarlyWithdrawalPolicy = "penalty"
+)
+
+func isLockTerm(days int32) bool {
+	for _, term := range LockTerms {
+		if term == days {
+			return true
+		}
+	}
+	return false
+}
+
+func isMaturityAction(action string) bool {
+	return action == string(MaturityActionRollover) || action == string(MaturityActionPayout)
+}
+
+// ============================================================================
+// LOCKED VAULT MODELS
+// ============================================================================
+
+type CreateLockedVaultRequest struct {
+	Name           string  `json:"name" binding:"required" example:"Rent Lock"`
+	Description    *string `json:"description" example:"Locked until rent is due"`
+	Amount         string  `json:"amount" binding:"required" example:"500.00"`
+	Currency       string  `json:"currency" binding:"required" example:"USDT" enums:"NGN,USD,USDC,USDT"`
+	LockTermDays   int32   `json:"lock_term_days" binding:"required" example:"90" enums:"30,60,90,180,365"`
+	MaturityAction string  `json:"maturity_action,omitempty" default:"payout" enums:"rollover,payout"`
+	IdempotencyKey string  `json:"idempotency_key" binding:"required"`
+}
+
+// LockTermsResponse is the lock a vault currently runs under
+type LockTermsResponse struct {
+	LockTermDays               int32     `json:"lock_term_days"`
+	LockedApy                  string    `json:"locked_apy"`
+	LockedPrincipal            string    `json:"locked_principal"`
+	LockedAt                   time.Time `json:"locked_at"`
+	MaturesAt                  time.Time `json:"matures_at"`
+	DaysToMaturity             int       `json:"days_to_maturity"`
+	MaturityAction             string    `json:"maturity_action"`
+	EarlyWithdrawalPolicy      string    `json:"early_withdrawal_policy"`
+	EarlyWithdrawalPenaltyRate string    `json:"early_withdrawal_penalty_rate"`
+	RolloverCount              int32     `json:"rollover_count"`
+}
+
+func MapLockTermsToResponse(vs *db.VaultSaving) *LockTermsResponse {
+	if vs.VaultType != string(SavingsTypeLocked) {
+		return nil
+	}
+	return &LockTermsResponse{
+		LockTermDays:               vs.LockTermDays.Int32,
+		LockedApy:                  vs.LockedApy.String,
+		LockedPrincipal:            vs.LockedPrincipal.String,
+		LockedAt:                   vs.LockedAt.Time,
+		MaturesAt:                  vs.MaturesAt.Time,
+		DaysToMaturity:             daysUntil(vs.MaturesAt.Time),
+		MaturityAction:             vs.MaturityAction.String,
+		EarlyWithdrawalPolicy:      vs.EarlyWithdrawalPolicy.String,
+		EarlyWithdrawalPenaltyRate: vs.EarlyWithdrawalPenaltyRate.String,
+		RolloverCount:              vs.RolloverCount,
+	}
+}
+
+// LockedProductResponse is a locked term a user can open a vault for
+type LockedProductResponse struct {
+	ConfigID                   uuid.UUID `json:"config_id"`
+	Currency                   string    `json:"currency"`
+	LockTermDays               int32     `json:"lock_term_days"`
+	ApyRate                    string    `json:"apy_rate"`
+	MinimumAmount              string    `json:"minimum_amount"`
+	CompoundFrequency          string    `json:"compound_frequency"`
+	EarlyWithdrawalPolicy      string    `json:"early_withdrawal_policy"`
+	EarlyWithdrawalPenaltyRate string    `json:"early_withdrawal_penalty_rate"`
+}
+
+func MapLockedProductToResponse(c *db.VaultYieldConfig) *LockedProductResponse {
+	return &LockedProductResponse{
+		ConfigID:                   c.ID,
+		Currency:                   c.Currency,
+		LockTermDays:               c.LockTermDays.Int32,
+		ApyRate:                    c.ApyRate,
+		MinimumAmount:              c.MinBalanceForYield,
+		CompoundFrequency:          c.CompoundFrequency.String,
+		EarlyWithdrawalPolicy:      c.EarlyWithdrawalPolicy.String,
+		EarlyWithdrawalPenaltyRate: c.EarlyWithdrawalPenaltyRate,
+	}
+}
+
+// EarlyWithdrawalQuote is what breaking a lock today pays out and what it costs
+type EarlyWithdrawalQuote struct {
+	VaultID         uuid.UUID `json:"vault_id"`
+	Currency        string    `json:"currency"`
+	Balance         string    `json:"balance"`
+	LockedPrincipal string    `json:"locked_principal"`
+	AccruedYield    string    `json:"accrued_yield"`
+	Policy          string    `json:"policy"`
+	PenaltyRate     string    `json:"penalty_rate"`
+	// yield given up under the forfeit policy
+	ForfeitedYield string `json:"forfeited_yield"`
+	// amount charged under the penalty policy
+	Penalty        string    `json:"penalty"`
+	Payout         string    `json:"payout"`
+	MaturesAt      time.Time `json:"matures_at"`
+	DaysToMaturity int       `json:"days_to_maturity"`
+}
+
+type EarlyWithdrawalResult struct {
+	EarlyWithdrawalQuote
+	Reference   string    `json:"reference"`
+	ToWalletID  uuid.UUID `json:"to_wallet_id"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// ============================================================================
+// LOCKED PRODUCTS
+// ============================================================================
+
+// ListLockedProducts returns the current terms of each locked product, for one currency when
+// currency is set
+func (s *VaultService) ListLockedProducts(ctx context.Context, currency string) ([]LockedProductResponse, error) {
+	configs, err := s.store.ListActiveLockedYieldConfigs(ctx, sql.NullString{String: currency, Valid: currency != ""})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locked products: %w", err)
+	}
+
+	products := make([]LockedProductResponse, len(configs))
+	for i, c := range configs {
+		products[i] = *MapLockedProductToResponse(&c)
+	}
+	return products, nil
+}
+
+// ============================================================================
+// CREATE LOCKED VAULT
+// ============================================================================
+
+// CreateLockedVault opens a vault locked for the requested term and funds it from the user's
+// wallet in the vault currency. The APY and early-withdrawal terms of the active product are
+// copied onto the vault, so they hold for the whole term.
+func (s *VaultService) CreateLockedVault(ctx context.Context, req CreateLockedVaultRequest, userID uuid.UUID) (*VaultSavingResponse, error) {
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !user.Verified {
+		return nil, fmt.Errorf("you need to verify your email")
+	}
+
+	if !user.IsActive {
+		return nil, fmt.Errorf("account inactive")
+	}
+
+	kyc, err := s.store.Queries.GetKYCByUserID(ctx, user.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("Err_KYC_NOT_FOUND")
+		}
+		return nil, fmt.Errorf("failed to fetch KYC: %w", err)
+	}
+
+	if kyc.Tier == "tier_1" {
+		s.pushService.SendPushNotification(ctx, user.ID, "Verification required.", "This feature requires Tier 2 verification. Complete identity verification to continue")
+		return nil, fmt.Errorf("Err_KYC_NEED_TIER_2")
+	}
+
+	if err := s.validateCreateRequest(CreateVaultGoalRequest{Name: req.Name, Currency: req.Currency, TargetAmount: req.Amount}); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := s.checkVaultName(ctx, userID, req.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidAmount
+	}
+
+	if !isLockTerm(req.LockTermDays) {
+		return nil, ErrInvalidLockTerm
+	}
+
+	if req.MaturityAction == "" {
+		req.MaturityAction = string(MaturityActionPayout)
+	}
+	if !isMaturityAction(req.MaturityAction) {
+		return nil, ErrInvalidMaturityAction
+	}
+
+	product, err := s.store.GetActiveLockedYieldConfig(ctx, db.GetActiveLockedYieldConfigParams{
+		Currency:     req.Currency,
+		LockTermDays: sql.NullInt32{Int32: req.LockTermDays, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrLockedProductUnavailable
+		}
+		return nil, fmt.Errorf("failed to get locked product: %w", err)
+	}
+
+	minAmount, _ := decimal.NewFromString(product.MinBalanceForYield)
+	if amount.LessThan(minAmount) {
+		return nil, fmt.Errorf("%w: minimum is %s %s", ErrBelowMinimumLock, minAmount.StringFixed(2), req.Currency)
+	}
+
+	lockedAt := time.Now()
+	maturesAt := lockedAt.AddDate(0, 0, int(req.LockTermDays))
+	principal := amount.StringFixed(4)
+
+	// Start transaction
+	tx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := s.store.WithTx(tx)
+
+	vault, err := qtx.CreateLockedVault(ctx, db.CreateLockedVaultParams{
+		UserID:                     userID,
+		VaultName:                  req.Name,
+		Description:                nullString(stringOrEmpty(req.Description)),
+		GoalAmount:                 nullString(principal),
+		Currency:                   req.Currency,
+		YieldConfigID:              uuid.NullUUID{UUID: product.ID, Valid: true},
+		LockTermDays:               sql.NullInt32{Int32: req.LockTermDays, Valid: true},
+		LockedApy:                  nullString(product.ApyRate),
+		LockedPrincipal:            nullString(principal),
+		LockedAt:                   nullTime(lockedAt),
+		MaturesAt:                  nullTime(maturesAt),
+		MaturityAction:             nullString(req.MaturityAction),
+		EarlyWithdrawalPolicy:      product.EarlyWithdrawalPolicy,
+		EarlyWithdrawalPenaltyRate: nullString(product.EarlyWithdrawalPenaltyRate),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create locked vault: %w", err)
+	}
+
+	// Lock source wallet
+	sourceWallet, err := qtx.GetWalletByCurrencyForUpdate(ctx, db.GetWalletByCurrencyForUpdateParams{
+		CustomerID: userID,
+		Currency:   req.Currency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock source wallet: %w", err)
+	}
+
+	walletBalance, err := decimal.NewFromString(sourceWallet.Balance.String)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet balance: %w", err)
+	}
+
+	if walletBalance.LessThan(amount) {
+		return nil, fmt.Errorf("%w: have %s, need %s", ErrInsufficientBalance, walletBalance.String(), amount.String())
+	}
+
+	amountUsd, err := utils.ConvertToUSD(ctx, amount, req.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount to USD: %w", err)
+	}
+
+	description := fmt.Sprintf("Locked for %d days at %s%% APY", req.LockTermDays, product.ApyRate)
+
+	maintx, err := qtx.CreateTransaction(ctx, db.CreateTransactionParams{
+		UserID:          userID,
+		Type:            string(transaction.Vault),
+		Description:     sql.NullString{String: description, Valid: true},
+		Amount:          principal,
+		Currency:        req.Currency,
+		AmountUsd:       amountUsd.String(),
+		Status:          string(transaction.Success),
+		TransactionFlow: string(transaction.InPlatform),
+		IdempotencyKey:  req.IdempotencyKey,
+		TFrom:           string(transaction.Wallet),
+		TTo:             string(transaction.Vault),
+		Direction:       string(transaction.Debit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	_, err = qtx.CreateVaultTransaction(ctx, db.CreateVaultTransactionParams{
+		UserID:          userID,
+		VaultID:         vault.ID,
+		TransactionType: string(TransactionTypeDeposit),
+		Amount:          principal,
+		Currency:        req.Currency,
+		SourceWallet:    uuid.NullUUID{UUID: sourceWallet.ID, Valid: true},
+		BalanceBefore:   "0",
+		BalanceAfter:    principal,
+		Reference:       nullString(req.IdempotencyKey),
+		Description:     nullString(description),
+		Status:          nullString(string(TransactionStatusSuccessful)),
+		Requires2fa:     nullBool(false),
+		TransactionID:   uuid.NullUUID{UUID: maintx.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault transaction: %w", err)
+	}
+
+	if err := qtx.IncrementVaultBalance(ctx, db.IncrementVaultBalanceParams{
+		ID:             vault.ID,
+		CurrentBalance: nullString(principal),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update vault balance: %w", err)
+	}
+
+	if _, err := qtx.DecrementWalletBalance(ctx, db.DecrementWalletBalanceParams{
+		ID:      sourceWallet.ID,
+		Balance: nullString(principal),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update wallet balance: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	vault.CurrentBalance = nullString(principal)
+
+	if err := s.streakScheduler.UpdateStreakOnTransaction(ctx, userID, maintx.ID, "vault"); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to update user streak: %v", err))
+	}
+
+	title := "Vault Locked"
+	message := fmt.Sprintf("You have locked %s %s in %s for %d days at %s%% APY. It matures on %s.",
+		principal, req.Currency, vault.VaultName, req.LockTermDays, product.ApyRate, maturesAt.Format("02 Jan 2006"))
+	if s.notifService != nil {
+		if _, err := s.notifService.CreateWithRecipients(ctx, nil, title, message, "system", []uuid.UUID{userID}); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to create locked vault notification: %v", err))
+		}
+	}
+	if s.pushService != nil {
+		if err := s.pushService.SendPushNotification(ctx, userID, title, message); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to send locked vault push: %v", err))
+		}
+	}
+
+	s.logger.Info(fmt.Sprintf("Created locked vault %s for user %s: %s %s for %d days", vault.ID, userID, principal, req.Currency, req.LockTermDays))
+	return MapVaultSavingToResponse(&vault), nil
+}
+
+// ============================================================================
+// EARLY WITHDRAWAL
+// ============================================================================
+
+// SetYieldService lets early withdrawals settle the yield a locked vault has accrued but not yet
+// been credited. Call this during service initialization.
+func (s *VaultService) SetYieldService(yieldService *YieldService) {
+	s.yieldService = yieldService
+}
+
+// settleLockedYield accrues the vault's yield up to now and capitalises all of it inside the
+// caller's transaction, then returns the vault as it stands afterwards
+func (s *VaultService) settleLockedYield(ctx context.Context, qtx *db.Queries, vault db.VaultSaving) (db.VaultSaving, error) {
+	if s.yieldService == nil {
+		return vault, errors.New("yield service is not configured")
+	}
+	if _, err := s.yieldService.settleYield(ctx, qtx, &vault, time.Now().UTC(), true); err != nil {
+		return vault, fmt.Errorf("failed to settle accrued yield: %w", err)
+	}
+	settled, err := qtx.LockVaultForUpdate(ctx, vault.ID)
+	if err != nil {
+		return vault, fmt.Errorf("failed to reload vault: %w", err)
+	}
+	return settled, nil
+}
+
+// quoteEarlyWithdrawal works out the payout for breaking the vault's lock now. Under the forfeit
+// policy the yield accrued this term stays behind; under the penalty policy a share of the
+// principal does, capped at the balance.
+func quoteEarlyWithdrawal(vault *db.VaultSaving) (*EarlyWithdrawalQuote, error) {
+	balance, err := decimal.NewFromString(vault.CurrentBalance.String)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault balance: %w", err)
+	}
+	principal, err := decimal.NewFromString(vault.LockedPrincipal.String)
+	if err != nil {
+		return nil, fmt.Errorf("invalid locked principal: %w", err)
+	}
+
+	accrued := decimal.Max(balance.Sub(principal), decimal.Zero)
+	forfeited := decimal.Zero
+	penalty := decimal.Zero
+	penaltyRate := decimal.Zero
+
+	switch EarlyWithdrawalPolicy(vault.EarlyWithdrawalPolicy.String) {
+	case EarlyWithdrawalForfeitYield:
+		forfeited = accrued
+	case EarlyWithdrawalPenalty:
+		penaltyRate, err = decimal.NewFromString(vault.EarlyWithdrawalPenaltyRate.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid penalty rate: %w", err)
+		}
+		penalty = decimal.Min(principal.Mul(penaltyRate).Div(decimal.NewFromInt(100)).Round(4), balance)
+	default:
+		return nil, fmt.Errorf("unknown early withdrawal policy %q", vault.EarlyWithdrawalPolicy.String)
+	}
+
+	return &EarlyWithdrawalQuote{
+		VaultID:         vault.ID,
+		Currency:        vault.Currency,
+		Balance:         balance.StringFixed(4),
+		LockedPrincipal: principal.StringFixed(4),
+		AccruedYield:    accrued.StringFixed(4),
+		Policy:          vault.EarlyWithdrawalPolicy.String,
+		PenaltyRate:     penaltyRate.StringFixed(2),
+		ForfeitedYield:  forfeited.StringFixed(4),
+		Penalty:         penalty.StringFixed(4),
+		Payout:          balance.Sub(forfeited).Sub(penalty).StringFixed(4),
+		MaturesAt:       vault.MaturesAt.Time,
+		DaysToMaturity:  daysUntil(vault.MaturesAt.Time),
+	}, nil
+}
+
+// GetEarlyWithdrawalQuote returns what breaking the vault's lock would pay out now. Yield accrued
+// since the last capitalisation is settled in a transaction that is rolled back, so the quote
+// matches what BreakLock would pay without crediting anything.
+func (s *VaultService) GetEarlyWithdrawalQuote(ctx context.Context, vaultID uuid.UUID) (*EarlyWithdrawalQuote, error) {
+	tx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := s.store.WithTx(tx)
+
+	vault, err := qtx.LockVaultForUpdate(ctx, vaultID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrVaultNotFound
+		}
+		return nil, fmt.Errorf("failed to get vault: %w", err)
+	}
+
+	if err := checkBreakable(&vault); err != nil {
+		return nil, err
+	}
+	if vault, err = s.settleLockedYield(ctx, qtx, vault); err != nil {
+		return nil, err
+	}
+	return quoteEarlyWithdrawal(&vault)
+}
+
+func checkBreakable(vault *db.VaultSaving) error {
+	if vault.VaultType != string(SavingsTypeLocked) {
+		return ErrVaultNotLocked
+	}
+	if vault.Status != string(SavingsStatusActive) {
+		return ErrVaultNotFound
+	}
+	if !vault.MaturesAt.Time.After(time.Now()) {
+		return ErrVaultMatured
+	}
+	return nil
+}
+
+// BreakLock closes a locked vault before maturity. What the policy withholds is recorded
+// against the vault, and the rest is paid to the user's wallet in the vault currency.
+func (s *VaultService) BreakLock(ctx context.Context, userID, vaultID uuid.UUID) (*EarlyWithdrawalResult, error) {
+	s.logger.Info(fmt.Sprintf("Processing early withdrawal from locked vault %s", vaultID))
+
+	tx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := s.store.WithTx(tx)
+
+	vault, err := qtx.LockVaultForUpdate(ctx, vaultID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrVaultNotFound
+		}
+		return nil, fmt.Errorf("failed to lock vault: %w", err)
+	}
+
+	if vault.UserID != userID {
+		return nil, ErrVaultNotFound
+	}
+	if err := checkBreakable(&vault); err != nil {
+		return nil, err
+	}
+
+	// accrued yield is paid or forfeited with the rest; the completed vault never accrues again
+	if vault, err = s.settleLockedYield(ctx, qtx, vault); err != nil {
+		return nil, err
+	}
+
+	quote, err := quoteEarlyWithdrawal(&vault)
+	if err != nil {
+		return nil, err
+	}
+	balance, _ := decimal.NewFromString(quote.Balance)
+	payout, _ := decimal.NewFromString(quote.Payout)
+	withheld := balance.Sub(payout)
+
+	destWallet, err := qtx.GetWalletByCurrencyForUpdate(ctx, db.GetWalletByCurrencyForUpdateParams{
+		CustomerID: userID,
+		Currency:   vault.Currency,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock destination wallet: %w", err)
+	}
+
+	reference := utils.NewTxRef("vault_early_withdrawal")
+
+	if withheld.GreaterThan(decimal.Zero) {
+		txType := TransactionTypeYieldForfeit
+		description := "Accrued yield forfeited on early withdrawal"
+		if quote.Policy == string(EarlyWithdrawalPenalty) {
+			txType = TransactionTypeEarlyPenalty
+			description = fmt.Sprintf("Early withdrawal penalty: %s%% of principal", quote.PenaltyRate)
+		}
+
+		metadata, _ := json.Marshal(map[string]any{
+			"policy":           quote.Policy,
+			"penalty_rate":     quote.PenaltyRate,
+			"locked_principal": quote.LockedPrincipal,
+			"accrued_yield":    quote.AccruedYield,
+			"matures_at":       quote.MaturesAt,
+		})
+
+		if _, err := qtx.CreateVaultTransaction(ctx, db.CreateVaultTransactionParams{
+			UserID:          userID,
+			VaultID:         vault.ID,
+			TransactionType: string(txType),
+			Amount:          withheld.StringFixed(4),
+			Currency:        vault.Currency,
+			BalanceBefore:   quote.Balance,
+			BalanceAfter:    quote.Payout,
+			Reference:       nullString(reference + "-withheld"),
+			Description:     nullString(description),
+			Metadata:        pqtype.NullRawMessage{RawMessage: metadata, Valid: true},
+			Status:          nullString(string(TransactionStatusSuccessful)),
+			Requires2fa:     nullBool(false),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to record withheld amount: %w", err)
+		}
+	}
+
+	if payout.GreaterThan(decimal.Zero) {
+		amountUsd, err := utils.ConvertToUSD(ctx, payout, vault.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert amount to USD: %w", err)
+		}
+
+		maintx, err := qtx.CreateTransaction(ctx, db.CreateTransactionParams{
+			UserID:          userID,
+			Type:            string(transaction.Vault),
+			Description:     sql.NullString{String: "Locked vault early withdrawal", Valid: true},
+			Amount:          quote.Payout,
+			Currency:        vault.Currency,
+			AmountUsd:       amountUsd.String(),
+			IdempotencyKey:  reference,
+			Status:          string(transaction.Success),
+			TransactionFlow: string(transaction.InPlatform),
+			TFrom:           string(transaction.Vault),
+			TTo:             string(transaction.Wallet),
+			Direction:       string(transaction.Credit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create transaction record: %w", err)
+		}
+
+		if _, err := qtx.CreateVaultTransaction(ctx, db.CreateVaultTransactionParams{
+			UserID:            userID,
+			VaultID:           vault.ID,
+			TransactionType:   string(TransactionTypeWithdrawal),
+			Amount:            quote.Payout,
+			Currency:          vault.Currency,
+			DestinationWallet: uuid.NullUUID{UUID: destWallet.ID, Valid: true},
+			BalanceBefore:     quote.Payout,
+			BalanceAfter:      "0",
+			Reference:         nullString(reference),
+			Description:       nullString("Early withdrawal from locked vault"),
+			Status:            nullString(string(TransactionStatusSuccessful)),
+			Requires2fa:       nullBool(false),
+			TransactionID:     uuid.NullUUID{UUID: maintx.ID, Valid: true},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create vault transaction: %w", err)
+		}
+
+		if _, err := qtx.IncrementWalletBalance(ctx, db.IncrementWalletBalanceParams{
+			ID:      destWallet.ID,
+			Balance: nullString(quote.Payout),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to update wallet balance: %w", err)
+		}
+	}
+
+	if err := qtx.DecrementVaultBalance(ctx, db.DecrementVaultBalanceParams{
+		ID:             vault.ID,
+		CurrentBalance: nullString(quote.Balance),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update vault balance: %w", err)
+	}
+
+	if err := qtx.UpdateVaultStatus(ctx, db.UpdateVaultStatusParams{
+		ID:     vault.ID,
+		Status: string(SavingsStatusCompleted),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to close vault: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to get user for notifications: %v", err))
+	} else {
+		if s.emailService != nil {
+			if err := s.emailService.SendWithdrawalSuccessEmail(ctx, &user, vault.VaultName, quote.Payout, vault.Currency, reference); err != nil {
+				s.logger.Error(fmt.Sprintf("Failed to send early withdrawal email: %v", err))
+			}
+		}
+
+		message := fmt.Sprintf("You have withdrawn %s %s from your locked vault %s before maturity.", quote.Payout, vault.Currency, vault.VaultName)
+		if s.pushService != nil {
+			if err := s.pushService.SendPushNotification(ctx, userID, "Locked Vault Withdrawn", message); err != nil {
+				s.logger.Error(fmt.Sprintf("Failed to send early withdrawal push: %v", err))
+			}
+		}
+		if s.notifService != nil {
+			if _, err := s.notifService.CreateWithRecipients(ctx, nil, "Locked Vault Withdrawn", message, "system", []uuid.UUID{userID}); err != nil {
+				s.logger.Error(fmt.Sprintf("Failed to create early withdrawal notification: %v", err))
+			}
+		}
+	}
+
+	s.logger.Info(fmt.Sprintf("Broke lock on vault %s: paid %s, withheld %s %s", vault.ID, quote.Payout, withheld.StringFixed(4), vault.Currency))
+	return &EarlyWithdrawalResult{
+		EarlyWithdrawalQuote: *quote,
+		Reference:            reference,
+		ToWalletID:           destWallet.ID,
+		CompletedAt:          time.Now(),
+	}, nil
+}
+
+// ============================================================================
+// MATURITY ACTION
+// ============================================================================
+
+// UpdateMaturityAction changes what happens to a locked vault when its term ends
+func (s *VaultService) UpdateMaturityAction(ctx context.Context, vaultID uuid.UUID, action string) error {
+	if !isMaturityAction(action) {
+		return ErrInvalidMaturityAction
+	}
+
+	vault, err := s.store.GetVaultGoalByID(ctx, vaultID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrVaultNotFound
+		}
+		return fmt.Errorf("failed to get vault: %w", err)
+	}
+	if err := checkBreakable(&vault); err != nil {
+		return err
+	}
+
+	return s.store.UpdateMaturityAction(ctx, db.UpdateMaturityActionParams{
+		ID:             vaultID,
+		MaturityAction: nullString(action),
+	})
+}
+
+// daysUntil counts the whole days left before t, rounding up and never below zero
+func daysUntil(t time.Time) int {
+	left := time.Until(t)
+	if left <= 0 {
+		return 0
+	}
+	return int((left + 24*time.Hour - 1) / (24 * time.Hour))
+}
@@ -0,0 +1,305 @@
+package vaultsavings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/transaction"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ============================================================================
+// LOCKED VAULT MATURITY
+// ============================================================================
+
+// maturityOutcome is what happened to a vault when its term ended
+type maturityOutcome struct {
+	vault       db.VaultSaving
+	action      MaturityAction
+	amount      string
+	yieldEarned string
+	reference   string
+	apy         string
+	maturesAt   time.Time
+}
+
+// ProcessMaturedVaults settles every locked vault whose term has ended
+func (ys *YieldService) ProcessMaturedVaults(ctx context.Context, limit int32) (int, int, error) {
+	vaults, err := ys.store.GetMaturedLockedVaults(ctx, limit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch matured vaults: %w", err)
+	}
+
+	if len(vaults) == 0 {
+		return 0, 0, nil
+	}
+
+	ys.logger.Info(fmt.Sprintf("Found %d matured locked vaults", len(vaults)))
+
+	successCount := 0
+	failureCount := 0
+
+	for _, vault := range vaults {
+		if err := ys.ProcessVaultMaturity(ctx, vault.ID); err != nil {
+			ys.logger.Error(fmt.Sprintf("Failed to process maturity for vault %s: %v", vault.ID, err))
+			failureCount++
+		} else {
+			successCount++
+		}
+	}
+
+	ys.logger.Info(fmt.Sprintf("Maturity processing complete: %d succeeded, %d failed", successCount, failureCount))
+	return successCount, failureCount, nil
+}
+
+// ProcessVaultMaturity credits a matured vault's outstanding yield up to maturity, then either
+// locks the balance for another term or pays it to the user's wallet. A rollover falls back to
+// a payout when the product is no longer offered.
+func (ys *YieldService) ProcessVaultMaturity(ctx context.Context, vaultID uuid.UUID) error {
+	if err := ys.ProcessVaultYield(ctx, vaultID); err != nil {
+		return fmt.Errorf("failed to credit final yield: %w", err)
+	}
+
+	tx, err := ys.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := ys.store.WithTx(tx)
+
+	vault, err := qtx.LockVaultForUpdate(ctx, vaultID)
+	if err != nil {
+		return fmt.Errorf("failed to lock vault: %w", err)
+	}
+
+	// Another run may have settled it already
+	if vault.VaultType != string(SavingsTypeLocked) ||
+		vault.Status != string(SavingsStatusActive) ||
+		vault.MaturesAt.Time.After(time.Now()) {
+		return nil
+	}
+
+	balance, err := decimal.NewFromString(vault.CurrentBalance.String)
+	if err != nil {
+		return fmt.Errorf("invalid vault balance: %w", err)
+	}
+	principal, _ := decimal.NewFromString(vault.LockedPrincipal.String)
+
+	outcome := maturityOutcome{
+		vault:       vault,
+		action:      MaturityActionPayout,
+		amount:      balance.StringFixed(4),
+		yieldEarned: decimal.Max(balance.Sub(principal), decimal.Zero).StringFixed(4),
+	}
+
+	if vault.MaturityAction.String == string(MaturityActionRollover) && balance.GreaterThan(decimal.Zero) {
+		product, err := qtx.GetActiveLockedYieldConfig(ctx, db.GetActiveLockedYieldConfigParams{
+			Currency:     vault.Currency,
+			LockTermDays: vault.LockTermDays,
+		})
+		switch {
+		case err == nil:
+			if err := ys.rolloverVault(ctx, qtx, &vault, &product, &outcome); err != nil {
+				return err
+			}
+		case errors.Is(err, sql.ErrNoRows):
+			ys.logger.Warn(fmt.Sprintf("No %d-day %s product to roll vault %s into, paying out", vault.LockTermDays.Int32, vault.Currency, vault.ID))
+		default:
+			return fmt.Errorf("failed to get locked product: %w", err)
+		}
+	}
+
+	if outcome.action == MaturityActionPayout {
+		if err := ys.payoutVault(ctx, qtx, &vault, balance, &outcome); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	ys.logger.Info(fmt.Sprintf("Settled matured vault %s: %s %s %s", vault.ID, outcome.action, outcome.amount, vault.Currency))
+	go ys.sendMaturityNotification(&outcome)
+	return nil
+}
+
+// rolloverVault starts the next term from the old maturity date, on the product's current terms
+func (ys *YieldService) rolloverVault(ctx context.Context, qtx *db.Queries, vault *db.VaultSaving, product *db.VaultYieldConfig, outcome *maturityOutcome) error {
+	lockedAt := vault.MaturesAt.Time
+	maturesAt := lockedAt.AddDate(0, 0, int(vault.LockTermDays.Int32))
+
+	if err := qtx.RolloverLockedVault(ctx, db.RolloverLockedVaultParams{
+		ID:                         vault.ID,
+		YieldConfigID:              uuid.NullUUID{UUID: product.ID, Valid: true},
+		LockedApy:                  nullString(product.ApyRate),
+		LockedPrincipal:            nullString(outcome.amount),
+		LockedAt:                   nullTime(lockedAt),
+		MaturesAt:                  nullTime(maturesAt),
+		EarlyWithdrawalPolicy:      product.EarlyWithdrawalPolicy,
+		EarlyWithdrawalPenaltyRate: nullString(product.EarlyWithdrawalPenaltyRate),
+	}); err != nil {
+		return fmt.Errorf("failed to roll over vault: %w", err)
+	}
+
+	reference := utils.NewTxRef("vault_rollover")
+	if _, err := qtx.CreateVaultTransaction(ctx, db.CreateVaultTransactionParams{
+		UserID:          vault.UserID,
+		VaultID:         vault.ID,
+		TransactionType: string(TransactionTypeRollover),
+		Amount:          outcome.amount,
+		Currency:        vault.Currency,
+		BalanceBefore:   outcome.amount,
+		BalanceAfter:    outcome.amount,
+		Reference:       nullString(reference),
+		Description:     nullString(fmt.Sprintf("Locked for another %d days at %s%% APY", vault.LockTermDays.Int32, product.ApyRate)),
+		Status:          nullString(string(TransactionStatusSuccessful)),
+		Requires2fa:     nullBool(false),
+	}); err != nil {
+		return fmt.Errorf("failed to record rollover: %w", err)
+	}
+
+	outcome.action = MaturityActionRollover
+	outcome.reference = reference
+	outcome.apy = product.ApyRate
+	outcome.maturesAt = maturesAt
+	return nil
+}
+
+// payoutVault moves the matured balance to the user's wallet in the vault currency and closes
+// the vault
+func (ys *YieldService) payoutVault(ctx context.Context, qtx *db.Queries, vault *db.VaultSaving, balance decimal.Decimal, outcome *maturityOutcome) error {
+	reference := utils.NewTxRef("vault_maturity")
+
+	if balance.GreaterThan(decimal.Zero) {
+		destWallet, err := qtx.GetWalletByCurrencyForUpdate(ctx, db.GetWalletByCurrencyForUpdateParams{
+			CustomerID: vault.UserID,
+			Currency:   vault.Currency,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to lock destination wallet: %w", err)
+		}
+
+		amountUsd, err := utils.ConvertToUSD(ctx, balance, vault.Currency)
+		if err != nil {
+			return fmt.Errorf("failed to convert amount to USD: %w", err)
+		}
+
+		maintx, err := qtx.CreateTransaction(ctx, db.CreateTransactionParams{
+			UserID:          vault.UserID,
+			Type:            string(transaction.Vault),
+			Description:     sql.NullString{String: "Locked vault maturity payout", Valid: true},
+			Amount:          outcome.amount,
+			Currency:        vault.Currency,
+			AmountUsd:       amountUsd.String(),
+			IdempotencyKey:  reference,
+			Status:          string(transaction.Success),
+			TransactionFlow: string(transaction.InPlatform),
+			TFrom:           string(transaction.Vault),
+			TTo:             string(transaction.Wallet),
+			Direction:       string(transaction.Credit),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transaction record: %w", err)
+		}
+
+		if _, err := qtx.CreateVaultTransaction(ctx, db.CreateVaultTransactionParams{
+			UserID:            vault.UserID,
+			VaultID:           vault.ID,
+			TransactionType:   string(TransactionTypeMaturityPayout),
+			Amount:            outcome.amount,
+			Currency:          vault.Currency,
+			DestinationWallet: uuid.NullUUID{UUID: destWallet.ID, Valid: true},
+			BalanceBefore:     outcome.amount,
+			BalanceAfter:      "0",
+			Reference:         nullString(reference),
+			Description:       nullString("Locked vault matured"),
+			Status:            nullString(string(TransactionStatusSuccessful)),
+			Requires2fa:       nullBool(false),
+			TransactionID:     uuid.NullUUID{UUID: maintx.ID, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to create vault transaction: %w", err)
+		}
+
+		if err := qtx.DecrementVaultBalance(ctx, db.DecrementVaultBalanceParams{
+			ID:             vault.ID,
+			CurrentBalance: nullString(outcome.amount),
+		}); err != nil {
+			return fmt.Errorf("failed to update vault balance: %w", err)
+		}
+
+		if _, err := qtx.IncrementWalletBalance(ctx, db.IncrementWalletBalanceParams{
+			ID:      destWallet.ID,
+			Balance: nullString(outcome.amount),
+		}); err != nil {
+			return fmt.Errorf("failed to update wallet balance: %w", err)
+		}
+	}
+
+	if err := qtx.UpdateVaultStatus(ctx, db.UpdateVaultStatusParams{
+		ID:     vault.ID,
+		Status: string(SavingsStatusCompleted),
+	}); err != nil {
+		return fmt.Errorf("failed to close vault: %w", err)
+	}
+
+	outcome.action = MaturityActionPayout
+	outcome.reference = reference
+	return nil
+}
+
+// sendMaturityNotification tells the user how their matured vault was settled
+func (ys *YieldService) sendMaturityNotification(outcome *maturityOutcome) {
+	ctx := context.Background()
+	vault := &outcome.vault
+
+	user, err := ys.store.GetUserByID(ctx, vault.UserID)
+	if err != nil {
+		ys.logger.Error(fmt.Sprintf("Failed to get user for notification: %v", err))
+		return
+	}
+
+	if outcome.action == MaturityActionRollover {
+		if err := ys.emailService.SendLockedVaultRolledOverEmail(
+			ctx,
+			&user,
+			vault.VaultName,
+			outcome.amount,
+			vault.Currency,
+			outcome.yieldEarned,
+			outcome.apy,
+			vault.LockTermDays.Int32,
+			outcome.maturesAt,
+		); err != nil {
+			ys.logger.Error(fmt.Sprintf("Failed to send rollover email: %v", err))
+		}
+
+		if err := ys.pushService.SendLockedVaultRolledOverPush(ctx, vault.UserID, vault.VaultName, outcome.apy, outcome.maturesAt); err != nil {
+			ys.logger.Error(fmt.Sprintf("Failed to send rollover push: %v", err))
+		}
+		return
+	}
+
+	if err := ys.emailService.SendLockedVaultMaturedEmail(
+		ctx,
+		&user,
+		vault.VaultName,
+		outcome.amount,
+		vault.Currency,
+		outcome.yieldEarned,
+		outcome.reference,
+	); err != nil {
+		ys.logger.Error(fmt.Sprintf("Failed to send maturity email: %v", err))
+	}
+
+	if err := ys.pushService.SendLockedVaultMaturedPush(ctx, vault.UserID, vault.VaultName, outcome.amount, vault.Currency); err != nil {
+		ys.logger.Error(fmt.Sprintf("Failed to send maturity push: %v", err))
+	}
+}
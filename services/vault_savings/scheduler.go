@@ -113,6 +113,52 @@ func (vs *VaultScheduler) Start() error {
 		return fmt.Errorf("failed to schedule vault task: %w", err)
 	}
 
+	// Group vault payouts and withdrawal request expiry share the same interval
+	_, err = vs.taskScheduler.AddTask(
+		"vault-group-rotations",
+		"Process Group Vault Payouts",
+		vs.processGroupVaults,
+		vs.checkInterval,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add group vault task: %w", err)
+	}
+
+	if err := vs.taskScheduler.ScheduleTask("vault-group-rotations", 30*time.Second); err != nil {
+		return fmt.Errorf("failed to schedule group vault task: %w", err)
+	}
+
+	// Auto-save round-ups are batched once a day; checking on the same interval lets a
+	// restarted replica catch up without waiting for the next day
+	_, err = vs.taskScheduler.AddTask(
+		"vault-auto-save",
+		"Process Vault Auto-Save Batches",
+		vs.processAutoSave,
+		vs.checkInterval,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add vault auto-save task: %w", err)
+	}
+
+	if err := vs.taskScheduler.ScheduleTask("vault-auto-save", 45*time.Second); err != nil {
+		return fmt.Errorf("failed to schedule vault auto-save task: %w", err)
+	}
+
+	// Sweep rules each run at their own time of day, so they are checked on the same interval
+	_, err = vs.taskScheduler.AddTask(
+		"vault-sweeps",
+		"Process Wallet Sweep Rules",
+		vs.processSweeps,
+		vs.checkInterval,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add vault sweep task: %w", err)
+	}
+
+	if err := vs.taskScheduler.ScheduleTask("vault-sweeps", 50*time.Second); err != nil {
+		return fmt.Errorf("failed to schedule vault sweep task: %w", err)
+	}
+
 	vs.logger.Info(fmt.Sprintf("Vault scheduler started. Checking for recurring deposits every %s", vs.checkInterval))
 	return nil
 }
@@ -131,6 +177,18 @@ func (vs *VaultScheduler) Stop() error {
 		vs.logger.Warn(fmt.Sprintf("Failed to remove vault scheduler task: %v", err))
 	}
 
+	if err := vs.taskScheduler.RemoveTask("vault-group-rotations"); err != nil {
+		vs.logger.Warn(fmt.Sprintf("Failed to remove group vault task: %v", err))
+	}
+
+	if err := vs.taskScheduler.RemoveTask("vault-auto-save"); err != nil {
+		vs.logger.Warn(fmt.Sprintf("Failed to remove vault auto-save task: %v", err))
+	}
+
+	if err := vs.taskScheduler.RemoveTask("vault-sweeps"); err != nil {
+		vs.logger.Warn(fmt.Sprintf("Failed to remove vault sweep task: %v", err))
+	}
+
 	vs.logger.Info("Vault scheduler stopped")
 	return nil
 }
@@ -166,6 +224,9 @@ func (vs *VaultScheduler) processRecurringDeposits(ctx context.Context) error {
 
 	vs.logger.Info(fmt.Sprintf("Found %d vaults with due recurring deposits", len(vaults)))
 
+	// Next execution times skip declared public holidays
+	vs.vaultService.refreshHolidays(ctx)
+
 	// Process each vault
 	successCount := 0
 	failureCount := 0
@@ -183,6 +244,66 @@ func (vs *VaultScheduler) processRecurringDeposits(ctx context.Context) error {
 	return nil
 }
 
+// processGroupVaults expires withdrawal requests nobody settled in time, then pays every
+// rotating group whose turn is due
+func (vs *VaultScheduler) processGroupVaults(ctx context.Context) error {
+	if err := vs.vaultService.ExpireGroupWithdrawalRequests(ctx); err != nil {
+		vs.logger.Error(fmt.Sprintf("Failed to expire group withdrawal requests: %v", err))
+	}
+
+	successCount, failureCount, err := vs.vaultService.ProcessGroupRotations(ctx, 100)
+	if err != nil {
+		vs.logger.Error(fmt.Sprintf("Failed to process group rotations: %v", err))
+		return fmt.Errorf("failed to process group rotations: %w", err)
+	}
+
+	if successCount > 0 || failureCount > 0 {
+		vs.logger.Info(fmt.Sprintf("Group rotations processed: %d succeeded, %d failed", successCount, failureCount))
+	}
+
+	return nil
+}
+
+// processAutoSave deposits the previous days' round-ups, then sends last month's auto-save
+// summaries to anyone who has not had theirs yet
+func (vs *VaultScheduler) processAutoSave(ctx context.Context) error {
+	successCount, failureCount, err := vs.vaultService.ProcessAutoSaveBatches(ctx, 100)
+	if err != nil {
+		vs.logger.Error(fmt.Sprintf("Failed to process auto-save batches: %v", err))
+		return fmt.Errorf("failed to process auto-save batches: %w", err)
+	}
+
+	if successCount > 0 || failureCount > 0 {
+		vs.logger.Info(fmt.Sprintf("Auto-save batches processed: %d succeeded, %d failed", successCount, failureCount))
+	}
+
+	sent, err := vs.vaultService.SendAutoSaveMonthlySummaries(ctx)
+	if err != nil {
+		vs.logger.Error(fmt.Sprintf("Failed to send auto-save summaries: %v", err))
+		return fmt.Errorf("failed to send auto-save summaries: %w", err)
+	}
+	if sent > 0 {
+		vs.logger.Info(fmt.Sprintf("Sent %d auto-save monthly summaries", sent))
+	}
+
+	return nil
+}
+
+// processSweeps runs the wallet sweep rules that are due
+func (vs *VaultScheduler) processSweeps(ctx context.Context) error {
+	successCount, failureCount, err := vs.vaultService.ProcessSweepRules(ctx, 100)
+	if err != nil {
+		vs.logger.Error(fmt.Sprintf("Failed to process sweep rules: %v", err))
+		return fmt.Errorf("failed to process sweep rules: %w", err)
+	}
+
+	if successCount > 0 || failureCount > 0 {
+		vs.logger.Info(fmt.Sprintf("Sweep rules processed: %d succeeded, %d failed", successCount, failureCount))
+	}
+
+	return nil
+}
+
 // processVaultDeposit handles the deposit for a single vault.
 // It checks the recurring rule, validates the wallet balance,
 // performs the deposit, and updates the rule accordingly.
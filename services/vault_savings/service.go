@@ -6,11 +6,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
 	service "github.com/SwiftFiat/SwiftFiat-Backend/services/notification"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/recurrence"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/streaks"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/transaction"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/wallet"
@@ -28,6 +31,14 @@ type VaultService struct {
 	pushService     *service.PushNotificationService
 	notifService    *service.Notification
 	streakScheduler *streaks.StreakScheduler
+
+	// converts between the user's wallets for cross-currency funding; see SetFundingConverter
+	fundingConverter FundingConverter
+	// settles a locked vault's outstanding yield before an early withdrawal; see SetYieldService
+	yieldService *YieldService
+
+	holidaysMu       sync.Mutex
+	holidaysLoadedAt time.Time
 }
 
 func NewVaultService(
@@ -60,8 +71,45 @@ var (
 	ErrInvalidAmount        = errors.New("invalid amount")
 	ErrInvalidCurrency      = errors.New("invalid currency")
 	ErrWalletNotFound       = errors.New("wallet not found")
-	ErrVaultAlreadyExists   = errors.New("vault already exists for this currency")
+	ErrVaultNameTaken       = errors.New("you already have a vault with this name")
 	ErrRecurringRuleInvalid = errors.New("invalid recurring rule configuration")
+
+	ErrVaultLocked              = errors.New("vault is locked until maturity")
+	ErrVaultNotLocked           = errors.New("vault is not a locked vault")
+	ErrVaultMatured             = errors.New("vault has matured and is being settled")
+	ErrInvalidLockTerm          = errors.New("invalid lock term")
+	ErrInvalidMaturityAction    = errors.New("invalid maturity action")
+	ErrLockedProductUnavailable = errors.New("no locked savings product for this currency and term")
+	ErrBelowMinimumLock         = errors.New("amount is below the minimum for this locked product")
+
+	ErrNotGroupVault             = errors.New("vault is not a group vault")
+	ErrNotGroupMember            = errors.New("not an active member of this group vault")
+	ErrNotGroupOwner             = errors.New("only the group owner can do this")
+	ErrInvalidWithdrawalRule     = errors.New("invalid group withdrawal rule")
+	ErrGroupWithdrawalRestricted = errors.New("withdrawals from this group vault follow its withdrawal rule")
+	ErrUserTagNotFound           = errors.New("no user with this tag")
+	ErrNoGroupInvite             = errors.New("no pending invite to this group vault")
+	ErrWithdrawalRequestPending  = errors.New("this group already has a pending withdrawal request")
+	ErrWithdrawalRequestClosed   = errors.New("withdrawal request is no longer pending")
+	ErrAlreadyVoted              = errors.New("already voted on this withdrawal request")
+
+	ErrAutoSaveRuleNotFound = errors.New("auto-save rule not found")
+	ErrInvalidAutoSaveRule  = errors.New("invalid auto-save rule")
+
+	ErrInvalidYieldTiers     = errors.New("invalid yield tiers")
+	ErrInvalidTaxYear        = errors.New("invalid tax year")
+	ErrInvalidWithholdingTax = errors.New("withholding_tax_rate must be between 0 and 100")
+
+	ErrSweepRuleNotFound = errors.New("sweep rule not found")
+	ErrInvalidSweepRule  = errors.New("invalid sweep rule")
+	ErrSweepRuleConflict = errors.New("this wallet already has an active sweep rule of this type")
+
+	ErrFundingUnavailable         = errors.New("funding a vault from another currency is not available")
+	ErrFundingIncomplete          = errors.New("the conversion completed but the vault deposit failed")
+	ErrFundingKeyReused           = errors.New("idempotency key was already used for a different funding")
+	ErrUnsupportedDisplayCurrency = errors.New("unsupported display currency")
+
+	ErrInvalidRecurringRule = errors.New("invalid recurring rule")
 )
 
 type Weekday int
@@ -107,6 +155,10 @@ const (
 	TransactionTypeWithdrawal        TransactionType = "withdrawal"
 	TransactionTypeAutoSave          TransactionType = "auto_save"
 	TransactionTypeYieldCredit       TransactionType = "yield_credit"
+	TransactionTypeYieldForfeit      TransactionType = "yield_forfeit"
+	TransactionTypeEarlyPenalty      TransactionType = "early_withdrawal_penalty"
+	TransactionTypeMaturityPayout    TransactionType = "maturity_payout"
+	TransactionTypeRollover          TransactionType = "rollover"
 	TransactionTypeSavingsDeposit    TransactionType = "savings_deposit"
 	TransactionTypeSavingsWithdrawal TransactionType = "savings_withdrawal"
 )
@@ -136,6 +188,7 @@ const (
 	IntervalDaily   Interval = "daily"   // Execute once per day
 	IntervalWeekly  Interval = "weekly"  // Execute once per week
 	IntervalMonthly Interval = "monthly" // Execute once per month
+	IntervalCron    Interval = "cron"    // Execute on a cron expression
 )
 
 // RecurringRule represents the configuration for automated recurring deposits to a vault.
@@ -172,9 +225,14 @@ type RecurringRule struct {
 	Amount string `json:"amount" example:"100.00"`
 
 	// Interval defines how frequently deposits occur.
-	// Valid values: "daily", "weekly", "monthly"
+	// Valid values: "daily", "weekly", "monthly", "cron"
 	// Determines which calculation method is used for NextExecutionAt.
-	Interval Interval `json:"interval" enum:"daily,weekly,monthly"`
+	Interval Interval `json:"interval" enum:"daily,weekly,monthly,cron"`
+
+	// Cron is a standard five-field cron expression (minute hour day month weekday).
+	// Required when Interval is "cron"; TimeOfDay, DayOfWeek and DayOfMonth are ignored.
+	// Example: "0 9 1,15 * *" = 9 AM on the 1st and 15th
+	Cron string `json:"cron,omitempty" example:"0 9 1,15 * *"`
 
 	// ========================================================================
 	// SCHEDULING
@@ -204,12 +262,20 @@ type RecurringRule struct {
 	// Example: 1 = first of month, 15 = mid-month, -1 = last day
 	DayOfMonth *int `json:"day_of_month,omitempty" example:"15"`
 
+	// LastBusinessDay runs a monthly rule on the last weekday of the month that is
+	// not a public holiday, like a salary date. DayOfMonth is ignored when set.
+	LastBusinessDay bool `json:"last_business_day,omitempty"`
+
 	// TimeOfDay specifies when deposits execute (format: "HH:MM").
-	// Uses 24-hour format. Timezone is server timezone.
+	// Uses 24-hour format in Timezone.
 	// Example: "09:00" = 9 AM, "14:30" = 2:30 PM, "00:00" = midnight
 	// Applied to all intervals (daily/weekly/monthly).
 	TimeOfDay string `json:"time_of_day" example:"09:00"`
 
+	// Timezone is the user's IANA timezone that TimeOfDay and Cron are read in.
+	// Empty means server timezone, as for rules created before timezones existed.
+	Timezone string `json:"timezone,omitempty" example:"Africa/Lagos"`
+
 	// NextExecutionAt is the calculated timestamp for the next deposit.
 	// Automatically updated after each execution via CalculateNextExecution().
 	// Scheduler checks this field to determine if execution is due.
@@ -248,6 +314,10 @@ type RecurringRule struct {
 	// Only affects calculated NextExecutionAt, not stored schedules
 	SkipWeekends bool `json:"skip_weekends"`
 
+	// SkipHolidays postpones executions that fall on a Nigerian public holiday to the
+	// next business day. Cron executions on a holiday are skipped instead.
+	SkipHolidays bool `json:"skip_holidays"`
+
 	// RetryOnFailure enables automatic retry of failed deposits.
 	// When true, failed deposits will be retried up to MaxRetries times.
 	// Failures may occur due to insufficient wallet balance, network issues, etc.
@@ -390,252 +460,67 @@ func (r *RecurringRule) IsActive() bool {
 // EXECUTION TIME CALCULATION
 // ============================================================================
 
-func (r *RecurringRule) CalculateNextExecution() time.Time {
-	now := time.Now()
-
-	// if last execution exists, calculate from there
-	if r.LastExecutionAt != nil {
-		return r.CalculateFromLastExecution(now)
-	}
-
-	// first execution - find the next valid execution time
-	return r.calculateFirstExecution(now)
-}
-func (r *RecurringRule) CalculateFromLastExecution(now time.Time) time.Time {
-
-	var next time.Time
-
-	switch r.Interval {
-	case IntervalDaily:
-		next = r.LastExecutionAt.Add(24 * time.Hour)
-	case IntervalWeekly:
-		next = r.calculateNextWeekly(now)
-	case IntervalMonthly:
-		next = r.calculateNextMonthly(now)
-	default:
-		next = now.Add(24 * time.Hour)
-	}
-
-	// Apply time of day
-	next = r.applyTimeOfDay(next)
-
-	// Skip weekends if configured
-	if r.SkipWeekends {
-		next = r.skipWeekends(next)
-	}
-
-	return next
+// Schedule converts the rule's timing fields into a recurrence schedule.
+// Weekday values 1-7 (Monday-Sunday) and 0 (Sunday) are both accepted.
+func (r *RecurringRule) Schedule() recurrence.Schedule {
+	schedule := recurrence.Schedule{
+		Frequency:       recurrence.Frequency(r.Interval),
+		Cron:            r.Cron,
+		DayOfMonth:      r.DayOfMonth,
+		LastBusinessDay: r.LastBusinessDay,
+		TimeOfDay:       r.TimeOfDay,
+		Timezone:        r.Timezone,
+		SkipWeekends:    r.SkipWeekends,
+		SkipHolidays:    r.SkipHolidays,
+	}
+	if r.DayOfWeek != nil {
+		day := int(*r.DayOfWeek) % 7
+		schedule.DayOfWeek = &day
+	}
+	return schedule
 }
 
-func (r *RecurringRule) calculateFirstExecution(now time.Time) time.Time {
-	var next time.Time
-
-	switch r.Interval {
-	case IntervalDaily:
-		next = now
-	case IntervalWeekly:
-		next = r.calculateNextWeekly(now)
-	case IntervalMonthly:
-		next = r.calculateNextMonthly(now)
-	default:
-		next = now
-	}
-
-	next = r.applyTimeOfDay(next)
-
-	// if calcuated time is in the past, move to the next interval
-	if next.Before(now) || next.Equal(now) {
-		switch r.Interval {
-		case IntervalDaily:
-			next = next.Add(24 * time.Hour)
-		case IntervalWeekly:
-			next = next.Add(7 * 24 * time.Hour)
-		case IntervalMonthly:
-			next = r.addMonth(next)
-		}
+// CalculateNextExecution returns the next execution after now, never before StartDate.
+// An invalid schedule falls back to 24 hours from now; rules are validated before
+// they are saved, so this only guards rules stored by older versions.
+func (r *RecurringRule) CalculateNextExecution() time.Time {
+	now := time.Now()
+	from := now
+	if r.StartDate.After(now) {
+		from = r.StartDate.Add(-time.Nanosecond)
 	}
 
-	// Skip weekends if configured
-	if r.SkipWeekends {
-		next = r.skipWeekends(next)
+	next, err := r.Schedule().Next(from)
+	if err != nil {
+		return now.Add(24 * time.Hour)
 	}
-
 	return next
 }
 
-func (r *RecurringRule) addMonth(t time.Time) time.Time {
-	// Add one month, handling year rollover
-	return time.Date(t.Year(), t.Month()+1, t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
-}
-
-// calculateNextWeekly computes next execution for weekly interval.
-// Advances by 7 days, then adjusts to configured DayOfWeek if set.
-//
-// Example:
-//
-//	If DayOfWeek=Friday and today is Monday,
-//	returns next Friday instead of next Monday.
-func (r *RecurringRule) calculateNextWeekly(from time.Time) time.Time {
-	if r.DayOfWeek == nil {
-		// NO specific day, use simple weekly calculation for next executiom
-
-		if r.LastExecutionAt != nil {
-			return r.LastExecutionAt.Add(7 * 24 * time.Hour)
-		}
-
-		// First execution - if todays time hasnt passed, use today
-		todayExecution := r.applyTimeOfDay(from)
-		if todayExecution.After(from) {
-			return todayExecution
-		}
-		// Otherwise, schedule for next week
-		return todayExecution.Add(7 * 24 * time.Hour)
-	}
-
-	targetWeekday := time.Weekday(*r.DayOfWeek)
-	currentWeekday := from.Weekday()
-
-	if currentWeekday == targetWeekday {
-		todayExecution := r.applyTimeOfDay(from)
-		// If today's execution time hasn't passed yet, use today
-		if todayExecution.After(from) {
-			return todayExecution
-		}
-		// If time has passed, schedule for next week
-		return todayExecution.Add(7 * 24 * time.Hour)
-	}
-
-	// calculate days until target weekday
-	daysUntiTarget := int(targetWeekday - currentWeekday)
-	if daysUntiTarget <= 0 {
-		daysUntiTarget += 7 // move to next week
-	}
-
-	nextDate := from.Add(time.Duration(daysUntiTarget) * 24 * time.Hour)
-	nextExecution := r.applyTimeOfDay(nextDate)
-
-	// If this calculated time is in the past (shouldn't happen with proper logic),
-	// move to next week
-	if nextExecution.Before(from) || nextExecution.Equal(from) {
-		nextExecution = nextExecution.Add(7 * 24 * time.Hour)
+// Preview lists the next count executions the rule would make
+func (r *RecurringRule) Preview(count int) ([]time.Time, error) {
+	from := time.Now()
+	if r.StartDate.After(from) {
+		from = r.StartDate.Add(-time.Nanosecond)
 	}
 
-	return nextExecution
-}
-
-// calculateNextMonthly computes next execution for monthly interval.
-// Moves to next month, then sets to configured DayOfMonth if specified.
-//
-// Special handling:
-//   - DayOfMonth=-1 means last day of month
-//   - If DayOfMonth > days in month, uses last day (e.g., Feb 30 → Feb 28/29)
-//
-// Example:
-//
-//	If DayOfMonth=15 and today is Jan 20,
-//	returns Feb 15
-func (r *RecurringRule) calculateNextMonthly(from time.Time) time.Time {
-	now := time.Now()
-	year, month, day := now.Date()
-
-	targetDay := 1
-	if r.DayOfMonth != nil {
-		if *r.DayOfMonth == -1 {
-			// last day of month
-			targetDay = r.lastDayOfMonth(year, month)
-		} else {
-			targetDay = *r.DayOfMonth
-			// ensure day is valid for the month
-			lastDay := r.lastDayOfMonth(year, month)
-			if targetDay > lastDay {
-				targetDay = lastDay
-			}
-		}
+	times, err := r.Schedule().Preview(from, count)
+	if err != nil {
+		return nil, err
 	}
 
-	// check if execution can be done this month
-	if day <= targetDay {
-		// We're before or on the target day this month
-		thisMonthExecution := time.Date(year, month, targetDay, 0, 0, 0, 0, from.Location())
-
-		// if execution time has not passed, use this month
-		if thisMonthExecution.After(now) {
-			return thisMonthExecution
+	// Stop at EndDate and MaxExecutions
+	previews := make([]time.Time, 0, len(times))
+	for i, t := range times {
+		if r.EndDate != nil && t.After(*r.EndDate) {
+			break
 		}
-	}
-
-	// else move to next month
-	month++
-	if month > 12 {
-		month = 1
-		year++
-	}
-
-	// determine day for next month
-	if r.DayOfMonth != nil {
-		if *r.DayOfMonth == -1 {
-			// last day of month
-			targetDay = r.lastDayOfMonth(year, month)
-		} else {
-			targetDay = *r.DayOfMonth
-			// ensure day is valid for the month
-			lastDay := r.lastDayOfMonth(year, month)
-			if targetDay > lastDay {
-				targetDay = lastDay
-			}
+		if r.MaxExecutions != nil && r.ExecutionCount+i >= *r.MaxExecutions {
+			break
 		}
+		previews = append(previews, t)
 	}
-
-	nextExecution := time.Date(year, month, targetDay, 0, 0, 0, 0, from.Location())
-	return r.applyTimeOfDay(nextExecution)
-}
-
-// lastDayOfMonth returns the last day number for a given month/year.
-// Accounts for leap years (February 29).
-//
-// Example:
-//
-//	lastDayOfMonth(2024, February) = 29 (leap year)
-//	lastDayOfMonth(2023, February) = 28
-//	lastDayOfMonth(2024, January) = 31
-func (r *RecurringRule) lastDayOfMonth(year int, month time.Month) int {
-	// First day of next month minus one day
-	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
-}
-
-// applyTimeOfDay sets the execution time to the configured TimeOfDay.
-// Preserves the date but changes the hour and minute.
-//
-// Format: "HH:MM" (24-hour)
-// Example:
-//
-//	If t is "2024-01-15 10:30" and TimeOfDay is "14:00",
-//	returns "2024-01-15 14:00"
-func (r *RecurringRule) applyTimeOfDay(t time.Time) time.Time {
-	if r.TimeOfDay == "" {
-		return t
-	}
-
-	// Parse time (format: "HH:MM")
-	var hour, minute int
-	fmt.Sscanf(r.TimeOfDay, "%d:%d", &hour, &minute)
-
-	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
-}
-
-// skipWeekends moves execution from weekend to next Monday.
-// Only moves forward, never backward.
-//
-// Example:
-//
-//	Saturday → Monday
-//	Sunday → Monday
-//	Weekday → unchanged
-func (r *RecurringRule) skipWeekends(t time.Time) time.Time {
-	for t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
-		t = t.Add(24 * time.Hour)
-	}
-	return t
+	return previews, nil
 }
 
 // ============================================================================
@@ -786,6 +671,10 @@ type CreateVaultGoalRequest struct {
 	Currency      string         `json:"currency" binding:"required" example:"NGN" enums:"NGN,USD,USDC,USDT"`
 	VaultType     string         `json:"vault_type,omitempty" default:"flexible" enums:"flexible,locked"`
 	RecurringRule *RecurringRule `json:"recurring_rule,omitempty"`
+	// FundingCurrency is the wallet recurring deposits are debited from when it differs from
+	// the vault currency, e.g. a USDT vault funded from USD. The recurring amount is in this
+	// currency and is converted at the rate quoted on each run.
+	FundingCurrency *string `json:"funding_currency,omitempty" example:"USD" enums:"NGN,USD,USDC,USDT"`
 }
 
 type VaultSavingResponse struct {
@@ -796,6 +685,7 @@ type VaultSavingResponse struct {
 	GoalAmount           string         `json:"goal_amount"`
 	CurrentBalance       string         `json:"current_balance"`
 	Currency             string         `json:"currency"`
+	FundingCurrency      string         `json:"funding_currency,omitempty"`
 	AutoSaveEnabled      bool           `json:"auto_save_enabled"`
 	AutoSaveFrequency    string         `json:"auto_save_frequency"`
 	AutoSaveAmount       string         `json:"auto_save_amount"`
@@ -809,6 +699,8 @@ type VaultSavingResponse struct {
 	CreatedAt            time.Time      `json:"created_at"`
 	UpdatedAt            time.Time      `json:"updated_at"`
 	CompletedAt          time.Time      `json:"completed_at"`
+	// set for locked vaults only
+	Lock *LockTermsResponse `json:"lock,omitempty"`
 }
 
 func MapVaultSavingToResponse(vs *db.VaultSaving) *VaultSavingResponse {
@@ -820,6 +712,7 @@ func MapVaultSavingToResponse(vs *db.VaultSaving) *VaultSavingResponse {
 		GoalAmount:           vs.GoalAmount.String,
 		CurrentBalance:       vs.CurrentBalance.String,
 		Currency:             vs.Currency,
+		FundingCurrency:      vs.FundingCurrency.String,
 		AutoSaveEnabled:      vs.AutoSaveEnabled,
 		AutoSaveFrequency:    vs.AutoSaveFrequency.String,
 		AutoSaveAmount:       vs.AutoSaveAmount.String,
@@ -833,6 +726,7 @@ func MapVaultSavingToResponse(vs *db.VaultSaving) *VaultSavingResponse {
 		CreatedAt:            vs.CreatedAt,
 		UpdatedAt:            vs.UpdatedAt,
 		CompletedAt:          vs.CompletedAt.Time,
+		Lock:                 MapLockTermsToResponse(vs),
 	}
 }
 
@@ -864,6 +758,8 @@ type DepositRequest struct {
 	Currency       string    `json:"currency"`
 	Description    string    `json:"description"`
 	IdempotencyKey string    `json:"idempotency_key" binding:"required"`
+	// Metadata is stored on the vault transaction, e.g. the conversion that funded it
+	Metadata map[string]any `json:"-"`
 }
 
 type DepositResponse struct {
@@ -923,14 +819,24 @@ type WithdrawRequest struct {
 }
 
 type UpdateRecurringRuleRequest struct {
-	Enabled       *bool     `json:"enabled,omitempty"`
-	Amount        *string   `json:"amount,omitempty"`
-	Interval      *Interval `json:"interval,omitempty"`
-	DayOfWeek     *Weekday  `json:"day_of_week,omitempty"`
-	DayOfMonth    *int      `json:"day_of_month,omitempty"`
-	TimeOfDay     *string   `json:"time_of_day,omitempty"`
-	SkipWeekends  *bool     `json:"skip_weekends,omitempty"`
-	MaxExecutions *int      `json:"max_executions,omitempty"`
+	Enabled         *bool     `json:"enabled,omitempty"`
+	Amount          *string   `json:"amount,omitempty"`
+	Interval        *Interval `json:"interval,omitempty"`
+	Cron            *string   `json:"cron,omitempty"`
+	DayOfWeek       *Weekday  `json:"day_of_week,omitempty"`
+	DayOfMonth      *int      `json:"day_of_month,omitempty"`
+	LastBusinessDay *bool     `json:"last_business_day,omitempty"`
+	TimeOfDay       *string   `json:"time_of_day,omitempty"`
+	Timezone        *string   `json:"timezone,omitempty"`
+	SkipWeekends    *bool     `json:"skip_weekends,omitempty"`
+	SkipHolidays    *bool     `json:"skip_holidays,omitempty"`
+	MaxExecutions   *int      `json:"max_executions,omitempty"`
+}
+
+// RecurringPreviewResponse lists the upcoming executions of a recurring rule
+type RecurringPreviewResponse struct {
+	Timezone       string      `json:"timezone"`
+	NextExecutions []time.Time `json:"next_executions"`
 }
 
 // ============================================================================
@@ -969,13 +875,19 @@ func (s *VaultService) CreateVaultGoal(ctx context.Context, req CreateVaultGoalR
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	// A user can hold several vaults in a currency, so names have to tell them apart
+	if err := s.checkVaultName(ctx, userID, req.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
 	// If recurring rule is provided, validate and initialize it
 	if req.RecurringRule != nil {
 		if err := s.validateRecurringRule(req.RecurringRule); err != nil {
-			return nil, fmt.Errorf("invalid recurring rule: %w", err)
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRecurringRule, err)
 		}
 
 		// Calculate first execution time
+		s.refreshHolidays(ctx)
 		req.RecurringRule.NextExecutionAt = req.RecurringRule.CalculateNextExecution()
 	}
 
@@ -983,6 +895,9 @@ func (s *VaultService) CreateVaultGoal(ctx context.Context, req CreateVaultGoalR
 	if req.VaultType == "" {
 		req.VaultType = string(SavingsTypeFlexible)
 	}
+	if req.VaultType == string(SavingsTypeLocked) {
+		return nil, fmt.Errorf("locked vaults are opened with an amount and a lock term: %w", ErrInvalidLockTerm)
+	}
 
 	var freq, amt string
 	var nextAuto sql.NullTime
@@ -1013,6 +928,7 @@ func (s *VaultService) CreateVaultGoal(ctx context.Context, req CreateVaultGoalR
 		RecurringRule:     recurring,
 		Status:            string(SavingsStatusActive),
 		VaultType:         req.VaultType,
+		FundingCurrency:   nullString(fundingCurrency(req)),
 	}
 
 	vault, err := s.store.CreateVaultGoal(ctx, params)
@@ -1071,6 +987,25 @@ func (s *VaultService) GetUserActiveVaults(ctx context.Context, userID uuid.UUID
 	return s.store.GetActiveVaultGoalsByUserID(ctx, userID)
 }
 
+// GetUserVaultsByCurrency lists the user's open vaults in one currency, optionally only the active ones
+func (s *VaultService) GetUserVaultsByCurrency(ctx context.Context, userID uuid.UUID, currency string, activeOnly bool) ([]db.VaultSaving, error) {
+	vaults, err := s.store.GetVaultGoalsByUserIDAndCurrency(ctx, db.GetVaultGoalsByUserIDAndCurrencyParams{
+		UserID:   userID,
+		Currency: currency,
+	})
+	if err != nil || !activeOnly {
+		return vaults, err
+	}
+
+	active := vaults[:0]
+	for _, vault := range vaults {
+		if vault.Status == string(SavingsStatusActive) {
+			active = append(active, vault)
+		}
+	}
+	return active, nil
+}
+
 func (s *VaultService) GetUserVaultSummary(ctx context.Context, userID uuid.UUID) (*db.GetUserVaultsSummaryRow, error) {
 	summary, err := s.store.GetUserVaultsSummary(ctx, userID)
 	if err != nil {
@@ -1134,6 +1069,26 @@ func (s *VaultService) Deposit(ctx context.Context, req DepositRequest) (*db.Vau
 		return nil, fmt.Errorf("failed to lock vault: %w", err)
 	}
 
+	if vault.VaultType == string(SavingsTypeLocked) {
+		return nil, ErrVaultLocked
+	}
+
+	// Group vaults take contributions from any active member
+	group, err := qtx.GetVaultGroup(ctx, vault.ID)
+	isGroup := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get vault group: %w", err)
+	}
+	if isGroup {
+		member, err := qtx.GetVaultGroupMember(ctx, db.GetVaultGroupMemberParams{
+			VaultID: vault.ID,
+			UserID:  req.UserID,
+		})
+		if err != nil || member.Status != string(GroupMemberActive) {
+			return nil, ErrNotGroupMember
+		}
+	}
+
 	// Verify currency matches
 	if vault.Currency != req.Currency {
 		return nil, fmt.Errorf("currency mismatch: vault uses %s, provided %s", vault.Currency, req.Currency)
@@ -1201,7 +1156,7 @@ func (s *VaultService) Deposit(ctx context.Context, req DepositRequest) (*db.Vau
 		TransactionType: string(TransactionTypeDeposit),
 		Amount:          req.Amount,
 		Currency:        req.Currency,
-		SourceWallet:    uuid.NullUUID{UUID: req.FromWalletID, Valid: true},
+		SourceWallet:    uuid.NullUUID{UUID: sourceWallet.ID, Valid: true},
 		BalanceBefore:   vault.CurrentBalance.String,
 		BalanceAfter:    newVaultBalance.String(),
 		Reference:       sql.NullString{String: reference, Valid: true},
@@ -1210,6 +1165,10 @@ func (s *VaultService) Deposit(ctx context.Context, req DepositRequest) (*db.Vau
 		Requires2fa:     sql.NullBool{Bool: false, Valid: true},
 		TransactionID:   uuid.NullUUID{UUID: maintx.ID, Valid: true},
 	}
+	if req.Metadata != nil {
+		b, _ := json.Marshal(req.Metadata)
+		txParams.Metadata = pqtype.NullRawMessage{RawMessage: b, Valid: true}
+	}
 
 	vtx, err := qtx.CreateVaultTransaction(ctx, txParams)
 	if err != nil {
@@ -1226,7 +1185,7 @@ func (s *VaultService) Deposit(ctx context.Context, req DepositRequest) (*db.Vau
 
 	// Update wallet balance
 	_, err = qtx.DecrementWalletBalance(ctx, db.DecrementWalletBalanceParams{
-		ID:      req.FromWalletID,
+		ID:      sourceWallet.ID,
 		Balance: sql.NullString{String: req.Amount, Valid: true},
 	})
 	if err != nil {
@@ -1250,10 +1209,25 @@ func (s *VaultService) Deposit(ctx context.Context, req DepositRequest) (*db.Vau
 		return nil, fmt.Errorf("failed to update vault transaction status: %w", err)
 	}
 
-	// Check if goal reached
+	if isGroup {
+		if err := qtx.AddVaultGroupContribution(ctx, db.AddVaultGroupContributionParams{
+			VaultID:          vault.ID,
+			UserID:           req.UserID,
+			TotalContributed: req.Amount,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to record group contribution: %w", err)
+		}
+		if err := logGroupActivity(ctx, qtx, vault.ID, &req.UserID, GroupActivityContribution, amount,
+			fmt.Sprintf("Contributed %s %s", req.Amount, vault.Currency), map[string]any{"reference": reference}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check if goal reached. Rotating groups pay out every cycle, so they never complete.
 	goalAmount, err := decimal.NewFromString(vault.GoalAmount.String)
 	goalReached := false
-	if err == nil && newVaultBalance.GreaterThanOrEqual(goalAmount) {
+	if err == nil && newVaultBalance.GreaterThanOrEqual(goalAmount) &&
+		!(isGroup && group.WithdrawalRule == string(GroupWithdrawalRotating)) {
 		goalReached = true
 		if err := qtx.UpdateVaultStatus(ctx, db.UpdateVaultStatusParams{
 			ID:     req.VaultID,
@@ -1328,6 +1302,11 @@ func (s *VaultService) Deposit(ctx context.Context, req DepositRequest) (*db.Vau
 		}
 		// }()
 	}
+
+	if isGroup {
+		s.notifyGroup(ctx, vault.ID, req.UserID, "Group Vault Contribution",
+			fmt.Sprintf("%s contributed %s %s to %s", displayName(&user), req.Amount, req.Currency, vault.VaultName))
+	}
 	s.logger.Info(fmt.Sprintf("Successfully processed deposit: %s", vtx.ID))
 	return &vtx, nil
 }
@@ -1366,6 +1345,21 @@ func (s *VaultService) Withdraw(ctx context.Context, req WithdrawRequest) (*db.V
 		return nil, fmt.Errorf("failed to lock vault: %w", err)
 	}
 
+	// Locked vaults only pay out at maturity or through an early withdrawal
+	if vault.VaultType == string(SavingsTypeLocked) {
+		return nil, ErrVaultLocked
+	}
+
+	// Only the owner of an owner_only group withdraws directly; the other rules pay out through
+	// approved requests or the rotation
+	if group, err := qtx.GetVaultGroup(ctx, vault.ID); err == nil {
+		if group.WithdrawalRule != string(GroupWithdrawalOwnerOnly) || req.UserID != group.OwnerID {
+			return nil, ErrGroupWithdrawalRestricted
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get vault group: %w", err)
+	}
+
 	// Check balance
 	currentBalance, err := decimal.NewFromString(vault.CurrentBalance.String)
 	if err != nil {
@@ -1580,13 +1574,19 @@ func (s *VaultService) processRecurringDeposit(ctx context.Context, vault db.Vau
 		return nil
 	}
 
+	// Vaults with a funding currency are paid from that wallet and converted on each run
+	walletCurrency := vault.Currency
+	if vault.FundingCurrency.Valid && vault.FundingCurrency.String != vault.Currency {
+		walletCurrency = vault.FundingCurrency.String
+	}
+
 	// Get user's wallet for this currency
 	wallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
 		CustomerID: vault.UserID,
-		Currency:   vault.Currency,
+		Currency:   walletCurrency,
 	})
 	if err != nil {
-		return fmt.Errorf("no wallet found for user %d with currency %s", vault.UserID, vault.Currency)
+		return fmt.Errorf("no wallet found for user %d with currency %s", vault.UserID, walletCurrency)
 	}
 
 	sourceWallet := wallet
@@ -1611,7 +1611,7 @@ func (s *VaultService) processRecurringDeposit(ctx context.Context, vault db.Vau
 		if rule.NotifyOnFailure {
 			user, _ := s.store.GetUserByID(ctx, vault.UserID)
 			if s.emailService != nil {
-				_ = s.emailService.SendRecurringDepositFailedEmail(ctx, &user, vault.VaultName, rule.Amount, vault.Currency, "Insufficient balance", *rule.LastExecutionAt)
+				_ = s.emailService.SendRecurringDepositFailedEmail(ctx, &user, vault.VaultName, rule.Amount, walletCurrency, "Insufficient balance", *rule.LastExecutionAt)
 			}
 		}
 		return nil
@@ -1628,7 +1628,18 @@ func (s *VaultService) processRecurringDeposit(ctx context.Context, vault db.Vau
 		IdempotencyKey: utils.WatRequestID(),
 	}
 
-	_, err = s.Deposit(ctx, depositReq)
+	if walletCurrency != vault.Currency {
+		_, err = s.FundFromWallet(ctx, FundVaultRequest{
+			UserID:         vault.UserID,
+			VaultID:        vault.ID,
+			SourceCurrency: walletCurrency,
+			Amount:         rule.Amount,
+			Description:    depositReq.Description,
+			IdempotencyKey: depositReq.IdempotencyKey,
+		})
+	} else {
+		_, err = s.Deposit(ctx, depositReq)
+	}
 	if err != nil {
 		s.logger.Error(fmt.Sprintf("Failed to process recurring deposit: %v", err))
 
@@ -1663,20 +1674,20 @@ func (s *VaultService) processRecurringDeposit(ctx context.Context, vault db.Vau
 	if rule.NotifyOnSuccess {
 		user, _ := s.store.GetUserByID(ctx, vault.UserID)
 		if s.emailService != nil {
-			_ = s.emailService.SendRecurringDepositSuccessEmail(ctx, &user, vault.VaultName, rule.Amount, vault.Currency, depositReq.IdempotencyKey, depositDate)
+			_ = s.emailService.SendRecurringDepositSuccessEmail(ctx, &user, vault.VaultName, rule.Amount, walletCurrency, depositReq.IdempotencyKey, depositDate)
 		}
 		if s.pushService != nil {
-			_ = s.pushService.SendRecurringDepositSuccessPush(ctx, vault.UserID, vault.VaultName, rule.Amount, vault.Currency)
+			_ = s.pushService.SendRecurringDepositSuccessPush(ctx, vault.UserID, vault.VaultName, rule.Amount, walletCurrency)
 		}
 		if s.notifService != nil {
-			if _, err := s.notifService.CreateWithRecipients(ctx, nil, "Recurring Deposit Successful", fmt.Sprintf("A recurring deposit of %s %s has been made to your vault: %s", rule.Amount, vault.Currency, vault.VaultName), "system", []uuid.UUID{vault.UserID}); err != nil {
+			if _, err := s.notifService.CreateWithRecipients(ctx, nil, "Recurring Deposit Successful", fmt.Sprintf("A recurring deposit of %s %s has been made to your vault: %s", rule.Amount, walletCurrency, vault.VaultName), "system", []uuid.UUID{vault.UserID}); err != nil {
 				s.logger.Error(fmt.Sprintf("Failed to create recurring deposit success notification: %v", err))
 			}
 		}
 	}
 
 	s.logger.Info(fmt.Sprintf("Successfully processed recurring deposit for vault %s: %s %s",
-		vault.ID, rule.Amount, vault.Currency))
+		vault.ID, rule.Amount, walletCurrency))
 
 	return nil
 }
@@ -1701,7 +1712,28 @@ func (s *VaultService) UpdateRecurringRule(ctx context.Context, vaultID uuid.UUI
 		return fmt.Errorf("invalid recurring rule json: %w", err)
 	}
 
-	// Apply updates
+	applyRecurringUpdates(&rule, updates)
+	if err := s.validateRecurringRule(&rule); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRecurringRule, err)
+	}
+
+	// Recalculate next execution
+	if rule.Enabled {
+		s.refreshHolidays(ctx)
+		rule.NextExecutionAt = rule.CalculateNextExecution()
+	}
+
+	b, _ := json.Marshal(rule)
+
+	// Update database
+	return s.store.UpdateRecurringRule(ctx, db.UpdateRecurringRuleParams{
+		ID:            vaultID,
+		RecurringRule: pqtype.NullRawMessage{RawMessage: b, Valid: true},
+	})
+}
+
+// applyRecurringUpdates copies the fields set in updates onto rule
+func applyRecurringUpdates(rule *RecurringRule, updates UpdateRecurringRuleRequest) {
 	if updates.Enabled != nil {
 		rule.Enabled = *updates.Enabled
 	}
@@ -1710,13 +1742,25 @@ func (s *VaultService) UpdateRecurringRule(ctx context.Context, vaultID uuid.UUI
 	}
 	if updates.Interval != nil {
 		rule.Interval = *updates.Interval
+		if rule.Interval != IntervalCron {
+			rule.Cron = ""
+		}
+	}
+	if updates.Cron != nil {
+		rule.Cron = *updates.Cron
 	}
 	if updates.TimeOfDay != nil {
 		rule.TimeOfDay = *updates.TimeOfDay
 	}
+	if updates.Timezone != nil {
+		rule.Timezone = *updates.Timezone
+	}
 	if updates.SkipWeekends != nil {
 		rule.SkipWeekends = *updates.SkipWeekends
 	}
+	if updates.SkipHolidays != nil {
+		rule.SkipHolidays = *updates.SkipHolidays
+	}
 	if updates.MaxExecutions != nil {
 		rule.MaxExecutions = updates.MaxExecutions
 	}
@@ -1726,19 +1770,88 @@ func (s *VaultService) UpdateRecurringRule(ctx context.Context, vaultID uuid.UUI
 	if updates.DayOfMonth != nil {
 		rule.DayOfMonth = updates.DayOfMonth
 	}
+	if updates.LastBusinessDay != nil {
+		rule.LastBusinessDay = *updates.LastBusinessDay
+	}
+}
 
-	// Recalculate next execution
-	if rule.Enabled {
-		rule.NextExecutionAt = rule.CalculateNextExecution()
+// ============================================================================
+// RECURRING PREVIEW
+// ============================================================================
+
+// PreviewRecurringRule lists the next executions of a rule that has not been saved yet
+func (s *VaultService) PreviewRecurringRule(ctx context.Context, rule RecurringRule, count int) (*RecurringPreviewResponse, error) {
+	if err := rule.Schedule().Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurringRule, err)
 	}
+	s.refreshHolidays(ctx)
 
-	b, _ := json.Marshal(rule)
+	times, err := rule.Preview(count)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurringRule, err)
+	}
+	return recurringPreview(rule, times), nil
+}
 
-	// Update database
-	return s.store.UpdateRecurringRule(ctx, db.UpdateRecurringRuleParams{
-		ID:            vaultID,
-		RecurringRule: pqtype.NullRawMessage{RawMessage: b, Valid: true},
+// PreviewRecurringRuleUpdate lists the next executions the vault's rule would make
+// with updates applied, without saving them
+func (s *VaultService) PreviewRecurringRuleUpdate(ctx context.Context, vaultID uuid.UUID, updates UpdateRecurringRuleRequest, count int) (*RecurringPreviewResponse, error) {
+	vault, err := s.store.GetVaultGoalByID(ctx, vaultID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrVaultNotFound
+		}
+		return nil, fmt.Errorf("failed to get vault: %w", err)
+	}
+
+	var rule RecurringRule
+	if vault.RecurringRule.Valid && len(vault.RecurringRule.RawMessage) > 0 {
+		if err := json.Unmarshal(vault.RecurringRule.RawMessage, &rule); err != nil {
+			return nil, fmt.Errorf("invalid recurring rule json: %w", err)
+		}
+	}
+	applyRecurringUpdates(&rule, updates)
+
+	return s.PreviewRecurringRule(ctx, rule, count)
+}
+
+func recurringPreview(rule RecurringRule, times []time.Time) *RecurringPreviewResponse {
+	timezone := rule.Timezone
+	if timezone == "" {
+		timezone = time.Local.String()
+	}
+	return &RecurringPreviewResponse{
+		Timezone:       timezone,
+		NextExecutions: times,
+	}
+}
+
+// refreshHolidays loads the public holidays declared by admins, such as the Eid
+// dates, into the Nigerian holiday calendar. Loads at most once an hour.
+func (s *VaultService) refreshHolidays(ctx context.Context) {
+	s.holidaysMu.Lock()
+	defer s.holidaysMu.Unlock()
+
+	if time.Since(s.holidaysLoadedAt) < time.Hour {
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	rows, err := s.store.ListPublicHolidays(ctx, db.ListPublicHolidaysParams{
+		StartDate: today.AddDate(0, 0, -7),
+		EndDate:   today.AddDate(1, 1, 0),
 	})
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to load public holidays: %v", err))
+		return
+	}
+
+	holidays := make(map[string]string, len(rows))
+	for _, h := range rows {
+		holidays[h.HolidayDate.Format("2006-01-02")] = h.Name
+	}
+	recurrence.NigerianHolidays.SetDeclared(holidays)
+	s.holidaysLoadedAt = time.Now()
 }
 
 // ============================================================================
@@ -1763,6 +1876,16 @@ func (s *VaultService) UpdateVaultDetails(ctx context.Context, vaultID uuid.UUID
 	}
 
 	if name != nil {
+		vault, err := s.store.GetVaultGoalByID(ctx, vaultID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrVaultNotFound
+			}
+			return fmt.Errorf("failed to get vault: %w", err)
+		}
+		if err := s.checkVaultName(ctx, vault.UserID, *name, vaultID); err != nil {
+			return err
+		}
 		params.VaultName = sql.NullString{String: *name, Valid: true}
 	}
 	if description != nil {
@@ -1814,6 +1937,9 @@ func (s *VaultService) validateCreateRequest(req CreateVaultGoalRequest) error {
 	if !validCurrencies[req.Currency] {
 		return ErrInvalidCurrency
 	}
+	if req.FundingCurrency != nil && *req.FundingCurrency != "" && !validCurrencies[*req.FundingCurrency] {
+		return fmt.Errorf("%w: funding currency %s", ErrInvalidCurrency, *req.FundingCurrency)
+	}
 
 	// Validate amount
 	if req.TargetAmount != "" {
@@ -1826,6 +1952,31 @@ func (s *VaultService) validateCreateRequest(req CreateVaultGoalRequest) error {
 	return nil
 }
 
+// checkVaultName rejects a name the user already uses on another open vault, ignoring case
+func (s *VaultService) checkVaultName(ctx context.Context, userID uuid.UUID, name string, excludeID uuid.UUID) error {
+	taken, err := s.store.VaultNameTaken(ctx, db.VaultNameTakenParams{
+		UserID:    userID,
+		VaultName: strings.TrimSpace(name),
+		ExcludeID: excludeID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check vault name: %w", err)
+	}
+	if taken {
+		return ErrVaultNameTaken
+	}
+	return nil
+}
+
+// fundingCurrency is the currency to store as the vault's funding currency; empty when it is
+// the vault currency itself
+func fundingCurrency(req CreateVaultGoalRequest) string {
+	if req.FundingCurrency == nil || *req.FundingCurrency == req.Currency {
+		return ""
+	}
+	return *req.FundingCurrency
+}
+
 func (s *VaultService) validateRecurringRule(rule *RecurringRule) error {
 	if !rule.Enabled {
 		return nil
@@ -1844,12 +1995,13 @@ func (s *VaultService) validateRecurringRule(rule *RecurringRule) error {
 		IntervalDaily:   true,
 		IntervalWeekly:  true,
 		IntervalMonthly: true,
+		IntervalCron:    true,
 	}
 	if !validIntervals[rule.Interval] {
 		return errors.New("invalid interval")
 	}
 
-	return nil
+	return rule.Schedule().Validate()
 }
 
 func stringOrEmpty(s *string) string {
@@ -0,0 +1,567 @@
+package vaultsavings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ============================================================================
+// SWEEP TYPES
+// ============================================================================
+
+type SweepRuleType string
+
+const (
+	// SweepExcess deposits whatever the wallet holds above the target balance into the vault
+	SweepExcess SweepRuleType = "sweep_excess"
+	// SweepTopUp withdraws from the vault to bring the wallet back up to the target balance
+	SweepTopUp SweepRuleType = "top_up"
+)
+
+type SweepRunStatus string
+
+const (
+	SweepRunCompleted SweepRunStatus = "completed"
+	SweepRunSkipped   SweepRunStatus = "skipped"
+	SweepRunFailed    SweepRunStatus = "failed"
+)
+
+// defaultSweepRunTime is when rules run if the user doesn't pick a time: overnight, once the
+// day's spending is done
+const defaultSweepRunTime = "00:00"
+
+// ============================================================================
+// SWEEP MODELS
+// ============================================================================
+
+type CreateSweepRuleRequest struct {
+	VaultID  uuid.UUID `json:"vault_id" binding:"required"`
+	RuleType string    `json:"rule_type" binding:"required" enums:"sweep_excess,top_up"`
+	// wallet balance to keep: the excess above it is swept, or a shortfall below it topped up
+	TargetBalance string `json:"target_balance" binding:"required" example:"50000"`
+	// moves smaller than this are skipped
+	MinAmount *string `json:"min_amount" example:"1000"`
+	// "HH:MM", defaults to 00:00
+	RunTime *string `json:"run_time" example:"23:30"`
+}
+
+type UpdateSweepRuleRequest struct {
+	TargetBalance *string `json:"target_balance,omitempty"`
+	MinAmount     *string `json:"min_amount,omitempty"`
+	RunTime       *string `json:"run_time,omitempty"`
+	IsActive      *bool   `json:"is_active,omitempty"`
+}
+
+type SweepRuleResponse struct {
+	ID            uuid.UUID `json:"id"`
+	VaultID       uuid.UUID `json:"vault_id"`
+	VaultName     string    `json:"vault_name,omitempty"`
+	Currency      string    `json:"currency"`
+	RuleType      string    `json:"rule_type"`
+	TargetBalance string    `json:"target_balance"`
+	MinAmount     string    `json:"min_amount"`
+	RunTime       string    `json:"run_time"`
+	IsActive      bool      `json:"is_active"`
+	NextRunAt     time.Time `json:"next_run_at"`
+	LastRunAt     time.Time `json:"last_run_at,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func MapSweepRuleToResponse(r *db.VaultSweepRule) *SweepRuleResponse {
+	return &SweepRuleResponse{
+		ID:            r.ID,
+		VaultID:       r.VaultID,
+		Currency:      r.Currency,
+		RuleType:      r.RuleType,
+		TargetBalance: r.TargetBalance,
+		MinAmount:     r.MinAmount,
+		RunTime:       r.RunTime,
+		IsActive:      r.IsActive,
+		NextRunAt:     r.NextRunAt,
+		LastRunAt:     r.LastRunAt.Time,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+func MapListUserSweepRulesRowToResponse(r *db.ListUserSweepRulesRow) *SweepRuleResponse {
+	return &SweepRuleResponse{
+		ID:            r.ID,
+		VaultID:       r.VaultID,
+		VaultName:     r.VaultName,
+		Currency:      r.Currency,
+		RuleType:      r.RuleType,
+		TargetBalance: r.TargetBalance,
+		MinAmount:     r.MinAmount,
+		RunTime:       r.RunTime,
+		IsActive:      r.IsActive,
+		NextRunAt:     r.NextRunAt,
+		LastRunAt:     r.LastRunAt.Time,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+type SweepRunResponse struct {
+	ID                  uuid.UUID  `json:"id"`
+	RuleID              uuid.UUID  `json:"rule_id"`
+	VaultID             uuid.UUID  `json:"vault_id"`
+	RuleType            string     `json:"rule_type"`
+	Currency            string     `json:"currency"`
+	WalletBalanceBefore string     `json:"wallet_balance_before"`
+	VaultBalanceBefore  string     `json:"vault_balance_before"`
+	TargetBalance       string     `json:"target_balance"`
+	Amount              string     `json:"amount"`
+	Status              string     `json:"status"`
+	VaultTransactionID  *uuid.UUID `json:"vault_transaction_id,omitempty"`
+	Reason              string     `json:"reason,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+func MapSweepRunToResponse(r *db.VaultSweepRun) *SweepRunResponse {
+	resp := &SweepRunResponse{
+		ID:                  r.ID,
+		RuleID:              r.RuleID,
+		VaultID:             r.VaultID,
+		RuleType:            r.RuleType,
+		Currency:            r.Currency,
+		WalletBalanceBefore: r.WalletBalanceBefore,
+		VaultBalanceBefore:  r.VaultBalanceBefore,
+		TargetBalance:       r.TargetBalance,
+		Amount:              r.Amount,
+		Status:              r.Status,
+		Reason:              r.Reason.String,
+		CreatedAt:           r.CreatedAt,
+	}
+	if r.VaultTransactionID.Valid {
+		resp.VaultTransactionID = &r.VaultTransactionID.UUID
+	}
+	return resp
+}
+
+// ============================================================================
+// RULE MANAGEMENT
+// ============================================================================
+
+// CreateSweepRule adds a rule between the user's wallet and one of their own flexible vaults in
+// the same currency. A wallet can have one active rule of each type.
+func (s *VaultService) CreateSweepRule(ctx context.Context, userID uuid.UUID, req CreateSweepRuleRequest) (*SweepRuleResponse, error) {
+	ruleType := SweepRuleType(req.RuleType)
+	if ruleType != SweepExcess && ruleType != SweepTopUp {
+		return nil, fmt.Errorf("%w: unknown rule type %q", ErrInvalidSweepRule, req.RuleType)
+	}
+
+	runTime := defaultSweepRunTime
+	if req.RunTime != nil {
+		runTime = *req.RunTime
+	}
+	minAmount := "0"
+	if req.MinAmount != nil {
+		minAmount = *req.MinAmount
+	}
+	target, minimum, err := validateSweepAmounts(req.TargetBalance, minAmount, runTime)
+	if err != nil {
+		return nil, err
+	}
+
+	vault, err := s.sweepableVault(ctx, userID, req.VaultID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSweepConflicts(ctx, userID, vault.Currency, uuid.Nil, ruleType, target); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.store.CreateSweepRule(ctx, db.CreateSweepRuleParams{
+		UserID:        userID,
+		VaultID:       vault.ID,
+		Currency:      vault.Currency,
+		RuleType:      string(ruleType),
+		TargetBalance: target.String(),
+		MinAmount:     minimum.String(),
+		RunTime:       runTime,
+		NextRunAt:     nextSweepRun(runTime, time.Now()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sweep rule: %w", err)
+	}
+
+	s.logger.Info(fmt.Sprintf("Sweep rule %s (%s) created for vault %s", rule.ID, rule.RuleType, vault.ID))
+
+	resp := MapSweepRuleToResponse(&rule)
+	resp.VaultName = vault.VaultName
+	return resp, nil
+}
+
+func (s *VaultService) ListSweepRules(ctx context.Context, userID uuid.UUID) ([]*SweepRuleResponse, error) {
+	rows, err := s.store.ListUserSweepRules(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sweep rules: %w", err)
+	}
+
+	rules := make([]*SweepRuleResponse, 0, len(rows))
+	for i := range rows {
+		rules = append(rules, MapListUserSweepRulesRowToResponse(&rows[i]))
+	}
+	return rules, nil
+}
+
+// UpdateSweepRule changes a rule's target, minimum or run time, or pauses it. The rule type and
+// vault are fixed; create a new rule to change either.
+func (s *VaultService) UpdateSweepRule(ctx context.Context, userID, ruleID uuid.UUID, req UpdateSweepRuleRequest) (*SweepRuleResponse, error) {
+	rule, err := s.store.GetSweepRule(ctx, db.GetSweepRuleParams{ID: ruleID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSweepRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get sweep rule: %w", err)
+	}
+
+	targetBalance, minAmount, runTime := rule.TargetBalance, rule.MinAmount, rule.RunTime
+	if req.TargetBalance != nil {
+		targetBalance = *req.TargetBalance
+	}
+	if req.MinAmount != nil {
+		minAmount = *req.MinAmount
+	}
+	if req.RunTime != nil {
+		runTime = *req.RunTime
+	}
+	target, minimum, err := validateSweepAmounts(targetBalance, minAmount, runTime)
+	if err != nil {
+		return nil, err
+	}
+
+	isActive := rule.IsActive
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	if isActive {
+		if !rule.IsActive {
+			if _, err := s.sweepableVault(ctx, userID, rule.VaultID); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.checkSweepConflicts(ctx, userID, rule.Currency, rule.ID, SweepRuleType(rule.RuleType), target); err != nil {
+			return nil, err
+		}
+	}
+
+	// a new time, or a rule coming back from a pause, starts from the next occurrence
+	nextRunAt := rule.NextRunAt
+	if runTime != rule.RunTime || (isActive && !rule.IsActive) {
+		nextRunAt = nextSweepRun(runTime, time.Now())
+	}
+
+	updated, err := s.store.UpdateSweepRule(ctx, db.UpdateSweepRuleParams{
+		ID:            rule.ID,
+		UserID:        userID,
+		TargetBalance: target.String(),
+		MinAmount:     minimum.String(),
+		RunTime:       runTime,
+		IsActive:      isActive,
+		NextRunAt:     nextRunAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sweep rule: %w", err)
+	}
+
+	return MapSweepRuleToResponse(&updated), nil
+}
+
+// DeleteSweepRule removes a rule along with its run history
+func (s *VaultService) DeleteSweepRule(ctx context.Context, userID, ruleID uuid.UUID) error {
+	n, err := s.store.DeleteSweepRule(ctx, db.DeleteSweepRuleParams{ID: ruleID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to delete sweep rule: %w", err)
+	}
+	if n == 0 {
+		return ErrSweepRuleNotFound
+	}
+	return nil
+}
+
+// ListSweepRuns returns the user's sweep history, newest first, optionally for a single rule
+func (s *VaultService) ListSweepRuns(ctx context.Context, userID uuid.UUID, ruleID *uuid.UUID, limit, offset int32) ([]*SweepRunResponse, error) {
+	params := db.ListUserSweepRunsParams{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	}
+	if ruleID != nil {
+		params.RuleID = uuid.NullUUID{UUID: *ruleID, Valid: true}
+	}
+
+	rows, err := s.store.ListUserSweepRuns(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sweep runs: %w", err)
+	}
+
+	runs := make([]*SweepRunResponse, 0, len(rows))
+	for i := range rows {
+		runs = append(runs, MapSweepRunToResponse(&rows[i]))
+	}
+	return runs, nil
+}
+
+// sweepableVault returns the vault if the user owns it and it can take deposits and withdrawals
+// on its own: flexible, not a group vault and not cancelled
+func (s *VaultService) sweepableVault(ctx context.Context, userID, vaultID uuid.UUID) (*db.VaultSaving, error) {
+	vault, err := s.store.GetVaultGoalByID(ctx, vaultID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && vault.UserID != userID) {
+		return nil, ErrVaultNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vault: %w", err)
+	}
+	if vault.VaultType == string(SavingsTypeLocked) {
+		return nil, ErrVaultLocked
+	}
+	if vault.Status == string(SavingsStatusCancelled) {
+		return nil, fmt.Errorf("%w: vault is %s", ErrInvalidSweepRule, vault.Status)
+	}
+	if _, err := s.store.GetVaultGroup(ctx, vault.ID); err == nil {
+		return nil, fmt.Errorf("%w: group vaults cannot be swept", ErrInvalidSweepRule)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get vault group: %w", err)
+	}
+	return &vault, nil
+}
+
+// sweepRuleDefunct reports whether a sweepableVault error means the rule can never run again
+func sweepRuleDefunct(err error) bool {
+	return errors.Is(err, ErrVaultNotFound) || errors.Is(err, ErrVaultLocked) || errors.Is(err, ErrInvalidSweepRule)
+}
+
+// checkSweepConflicts keeps a wallet to one active rule of each type, and keeps a top-up target
+// at or below the sweep target so the two rules never move the same money back and forth
+func (s *VaultService) checkSweepConflicts(ctx context.Context, userID uuid.UUID, currency string, ruleID uuid.UUID, ruleType SweepRuleType, target decimal.Decimal) error {
+	rules, err := s.store.GetActiveSweepRulesByCurrency(ctx, db.GetActiveSweepRulesByCurrencyParams{
+		UserID:   userID,
+		Currency: currency,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get sweep rules: %w", err)
+	}
+
+	for _, other := range rules {
+		if other.ID == ruleID {
+			continue
+		}
+		if other.RuleType == string(ruleType) {
+			return ErrSweepRuleConflict
+		}
+
+		otherTarget, _ := decimal.NewFromString(other.TargetBalance)
+		sweepTarget, topUpTarget := target, otherTarget
+		if ruleType == SweepTopUp {
+			sweepTarget, topUpTarget = otherTarget, target
+		}
+		if topUpTarget.GreaterThan(sweepTarget) {
+			return fmt.Errorf("%w: the top-up target (%s) is above the sweep target (%s)", ErrInvalidSweepRule, topUpTarget, sweepTarget)
+		}
+	}
+	return nil
+}
+
+func validateSweepAmounts(targetBalance, minAmount, runTime string) (decimal.Decimal, decimal.Decimal, error) {
+	target, err := decimal.NewFromString(targetBalance)
+	if err != nil || target.LessThan(decimal.Zero) {
+		return target, decimal.Zero, fmt.Errorf("%w: target_balance must be zero or more", ErrInvalidSweepRule)
+	}
+	minimum, err := decimal.NewFromString(minAmount)
+	if err != nil || minimum.LessThan(decimal.Zero) {
+		return target, minimum, fmt.Errorf("%w: min_amount must be zero or more", ErrInvalidSweepRule)
+	}
+	if _, err := time.Parse("15:04", runTime); err != nil {
+		return target, minimum, fmt.Errorf("%w: run_time must be HH:MM", ErrInvalidSweepRule)
+	}
+	return target, minimum, nil
+}
+
+// nextSweepRun is the first runTime (server time) after from
+func nextSweepRun(runTime string, from time.Time) time.Time {
+	t, _ := time.Parse("15:04", runTime)
+	next := time.Date(from.Year(), from.Month(), from.Day(), t.Hour(), t.Minute(), 0, 0, from.Location())
+	if !next.After(from) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// ============================================================================
+// SWEEP EXECUTION (SCHEDULER)
+// ============================================================================
+
+// ProcessSweepRules runs every rule that is due. Each rule is moved on to its next run before it
+// executes, so replicas sharing the schedule never run the same rule twice.
+func (s *VaultService) ProcessSweepRules(ctx context.Context, limit int32) (int, int, error) {
+	now := time.Now()
+	rules, err := s.store.GetDueSweepRules(ctx, db.GetDueSweepRulesParams{
+		NextRunAt: now,
+		Limit:     limit,
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get due sweep rules: %w", err)
+	}
+
+	successCount := 0
+	failureCount := 0
+
+	for _, rule := range rules {
+		claimed, err := s.store.ClaimSweepRule(ctx, db.ClaimSweepRuleParams{
+			ID:          rule.ID,
+			NextRunAt:   rule.NextRunAt,
+			NextRunAt_2: nextSweepRun(rule.RunTime, now),
+		})
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to claim sweep rule %s: %v", rule.ID, err))
+			failureCount++
+			continue
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		if err := s.runSweepRule(ctx, &rule); err != nil {
+			s.logger.Error(fmt.Sprintf("Sweep rule %s failed: %v", rule.ID, err))
+			failureCount++
+		} else {
+			successCount++
+		}
+	}
+
+	return successCount, failureCount, nil
+}
+
+// runSweepRule works out what the rule should move now, moves it through Deposit or Withdraw and
+// records the run. It returns an error only when the run failed.
+func (s *VaultService) runSweepRule(ctx context.Context, rule *db.VaultSweepRule) error {
+	run := db.CreateSweepRunParams{
+		RuleID:              rule.ID,
+		UserID:              rule.UserID,
+		VaultID:             rule.VaultID,
+		RuleType:            rule.RuleType,
+		Currency:            rule.Currency,
+		WalletBalanceBefore: "0",
+		VaultBalanceBefore:  "0",
+		TargetBalance:       rule.TargetBalance,
+		Amount:              "0",
+	}
+
+	vault, err := s.sweepableVault(ctx, rule.UserID, rule.VaultID)
+	if err != nil && !sweepRuleDefunct(err) {
+		// a transient failure: the rule stays active and runs again on its next schedule
+		return s.recordSweepRun(ctx, run, SweepRunFailed, err)
+	}
+	if err != nil {
+		// the vault has gone away or can no longer be swept, so the rule stops here
+		if _, perr := s.store.UpdateSweepRule(ctx, db.UpdateSweepRuleParams{
+			ID:            rule.ID,
+			UserID:        rule.UserID,
+			TargetBalance: rule.TargetBalance,
+			MinAmount:     rule.MinAmount,
+			RunTime:       rule.RunTime,
+			IsActive:      false,
+			NextRunAt:     rule.NextRunAt,
+		}); perr != nil {
+			s.logger.Error(fmt.Sprintf("Failed to pause sweep rule %s: %v", rule.ID, perr))
+		}
+		return s.recordSweepRun(ctx, run, SweepRunFailed, err)
+	}
+	run.VaultBalanceBefore = vault.CurrentBalance.String
+
+	wallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: rule.UserID,
+		Currency:   rule.Currency,
+	})
+	if err != nil {
+		return s.recordSweepRun(ctx, run, SweepRunFailed, fmt.Errorf("failed to get wallet: %w", err))
+	}
+	run.WalletBalanceBefore = wallet.Balance.String
+
+	walletBalance, _ := decimal.NewFromString(wallet.Balance.String)
+	vaultBalance, _ := decimal.NewFromString(vault.CurrentBalance.String)
+	target, _ := decimal.NewFromString(rule.TargetBalance)
+	minimum, _ := decimal.NewFromString(rule.MinAmount)
+
+	var amount decimal.Decimal
+	switch SweepRuleType(rule.RuleType) {
+	case SweepExcess:
+		amount = walletBalance.Sub(target)
+		if amount.LessThanOrEqual(decimal.Zero) {
+			return s.recordSweepRun(ctx, run, SweepRunSkipped, errors.New("wallet is at or below the target balance"))
+		}
+	case SweepTopUp:
+		shortfall := target.Sub(walletBalance)
+		if shortfall.LessThanOrEqual(decimal.Zero) {
+			return s.recordSweepRun(ctx, run, SweepRunSkipped, errors.New("wallet is at or above the target balance"))
+		}
+		amount = decimal.Min(shortfall, vaultBalance)
+		if amount.LessThanOrEqual(decimal.Zero) {
+			return s.recordSweepRun(ctx, run, SweepRunSkipped, errors.New("vault is empty"))
+		}
+	default:
+		return s.recordSweepRun(ctx, run, SweepRunFailed, fmt.Errorf("unknown rule type %q", rule.RuleType))
+	}
+
+	if amount.LessThan(minimum) {
+		return s.recordSweepRun(ctx, run, SweepRunSkipped, fmt.Errorf("%s is below the minimum of %s", amount, minimum))
+	}
+	run.Amount = amount.String()
+
+	var vtx *db.VaultTransaction
+	if SweepRuleType(rule.RuleType) == SweepExcess {
+		vtx, err = s.Deposit(ctx, DepositRequest{
+			UserID:         rule.UserID,
+			VaultID:        vault.ID,
+			FromWalletID:   wallet.ID,
+			Amount:         amount.String(),
+			Currency:       rule.Currency,
+			Description:    fmt.Sprintf("Sweep above %s %s", rule.TargetBalance, rule.Currency),
+			IdempotencyKey: utils.NewTxRef("vault_sweep"),
+		})
+	} else {
+		vtx, err = s.Withdraw(ctx, WithdrawRequest{
+			UserID:      rule.UserID,
+			VaultID:     vault.ID,
+			ToWalletID:  wallet.ID,
+			Amount:      amount.String(),
+			Reference:   utils.NewTxRef("vault_sweep"),
+			Description: fmt.Sprintf("Top-up to %s %s", rule.TargetBalance, rule.Currency),
+		})
+	}
+	if err != nil {
+		return s.recordSweepRun(ctx, run, SweepRunFailed, err)
+	}
+
+	run.VaultTransactionID = uuid.NullUUID{UUID: vtx.ID, Valid: true}
+	s.logger.Info(fmt.Sprintf("Sweep rule %s moved %s %s (%s)", rule.ID, run.Amount, rule.Currency, rule.RuleType))
+	return s.recordSweepRun(ctx, run, SweepRunCompleted, nil)
+}
+
+// recordSweepRun stores the run in the rule's history and passes a failure back to the caller
+func (s *VaultService) recordSweepRun(ctx context.Context, run db.CreateSweepRunParams, status SweepRunStatus, reason error) error {
+	run.Status = string(status)
+	if reason != nil {
+		run.Reason = nullString(reason.Error())
+	}
+	if _, err := s.store.CreateSweepRun(ctx, run); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to record sweep run for rule %s: %v", run.RuleID, err))
+	}
+
+	if status == SweepRunFailed {
+		return reason
+	}
+	return nil
+}
@@ -107,6 +107,21 @@ func (ys *YieldScheduler) Start() error {
 		return fmt.Errorf("failed to schedule yield task: %w", err)
 	}
 
+	// Settle locked vaults on the same interval, after the yield run has credited them
+	_, err = ys.taskScheduler.AddTask(
+		"vault-locked-maturities",
+		"Settle Matured Locked Vaults",
+		ys.processLockedMaturities,
+		ys.checkInterval,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add maturity scheduler task: %w", err)
+	}
+
+	if err := ys.taskScheduler.ScheduleTask("vault-locked-maturities", 2*time.Minute); err != nil {
+		return fmt.Errorf("failed to schedule maturity task: %w", err)
+	}
+
 	ys.logger.Info(fmt.Sprintf("Yield scheduler started. Calculating yields every %s", ys.checkInterval))
 	return nil
 }
@@ -121,6 +136,10 @@ func (ys *YieldScheduler) Stop() error {
 		ys.logger.Warn(fmt.Sprintf("Failed to remove yield scheduler task: %v", err))
 	}
 
+	if err := ys.taskScheduler.RemoveTask("vault-locked-maturities"); err != nil {
+		ys.logger.Warn(fmt.Sprintf("Failed to remove maturity scheduler task: %v", err))
+	}
+
 	ys.logger.Info("Yield scheduler stopped")
 	return nil
 }
@@ -160,6 +179,22 @@ func (ys *YieldScheduler) processYieldCalculations(ctx context.Context) error {
 	return nil
 }
 
+// processLockedMaturities pays out or rolls over locked vaults whose term has ended
+func (ys *YieldScheduler) processLockedMaturities(ctx context.Context) error {
+	successCount, failureCount, err := ys.yieldService.ProcessMaturedVaults(ctx, ys.batchSize)
+	if err != nil {
+		ys.logger.Error(fmt.Sprintf("Failed to process matured vaults: %v", err))
+		return fmt.Errorf("failed to process matured vaults: %w", err)
+	}
+
+	if successCount > 0 || failureCount > 0 {
+		ys.logger.Info(fmt.Sprintf("Locked vault maturities complete: %d succeeded, %d failed",
+			successCount, failureCount))
+	}
+
+	return nil
+}
+
 // ============================================================================
 // MANUAL OPERATIONS
 // ============================================================================
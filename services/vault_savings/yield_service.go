@@ -3,6 +3,8 @@ package vaultsavings
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -14,6 +16,7 @@ import (
 	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"github.com/sqlc-dev/pqtype"
 )
 
 // ============================================================================
@@ -84,7 +87,8 @@ type YieldCalculationRequest struct {
 	APY           string // Annual percentage yield
 	PeriodStart   time.Time
 	PeriodEnd     time.Time
-	CompoundDaily bool // Whether to use daily compounding
+	CompoundDaily bool        // Whether to use daily compounding
+	Tiers         []YieldTier // APY bands; when set the rate is blended from the bands the balance spans
 }
 
 // YieldCalculationResult contains the calculated yield details
@@ -128,6 +132,10 @@ func (ys *YieldService) CalculateYield(ctx context.Context, req YieldCalculation
 		return nil, fmt.Errorf("APY cannot be negative")
 	}
 
+	if len(req.Tiers) > 0 {
+		apy = blendedAPY(balance, req.Tiers)
+	}
+
 	// Calculate days in period (as float for higher precision)
 	days := req.PeriodEnd.Sub(req.PeriodStart).Hours() / 24.0
 	if days < 0 {
@@ -193,141 +201,323 @@ func (ys *YieldService) power(base decimal.Decimal, exponent float64) decimal.De
 // PROCESS VAULT YIELD
 // ============================================================================
 
-// ProcessVaultYield calculates and credits yield for a single vault
+// yieldCapitalisationMinimum is the smallest gross yield worth crediting; less stays accrued
+var yieldCapitalisationMinimum = decimal.NewFromFloat(0.0001)
+
+// ProcessVaultYield accrues a vault's yield for every whole day since it was last calculated,
+// then capitalises what has accrued once the config's compounding period has passed. Withholding
+// tax is deducted from each capitalisation and posted to the tax liability ledger. A vault that
+// has matured has everything capitalised so settlement pays out the full yield.
 func (ys *YieldService) ProcessVaultYield(ctx context.Context, vaultID uuid.UUID) error {
 	ys.logger.Info(fmt.Sprintf("Processing yield for vault %s", vaultID))
 
-	// Get vault details
-	vault, err := ys.store.GetVaultGoalByID(ctx, vaultID)
+	tx, err := ys.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := ys.store.WithTx(tx)
+
+	vault, err := qtx.LockVaultForUpdate(ctx, vaultID)
 	if err != nil {
 		return fmt.Errorf("failed to get vault: %w", err)
 	}
 
-	// Skip if vault has no balance
-	balance, err := decimal.NewFromString(vault.CurrentBalance.String)
-	if err != nil || balance.LessThanOrEqual(decimal.Zero) {
-		ys.logger.Info(fmt.Sprintf("Vault %s has no balance (balance=%s), skipping yield", vaultID, vault.CurrentBalance.String))
+	now := time.Now().UTC()
+	matured := vault.MaturesAt.Valid && !vault.MaturesAt.Time.After(now)
+
+	// Days accrue up to the start of today
+	credited, err := ys.settleYield(ctx, qtx, &vault, now.Truncate(24*time.Hour), matured)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if credited == nil {
 		return nil
 	}
 
-	// Get active yield config for this currency
-	yieldConfig, err := ys.store.GetActiveYieldConfigByCurrency(ctx, vault.Currency)
+	ys.logger.Info(fmt.Sprintf("Successfully credited yield %s %s to vault %s",
+		credited.YieldAmount, vault.Currency, vault.ID))
+
+	// Send notifications asynchronously
+	go ys.sendYieldNotification(&vault, credited)
+
+	// Check if goal reached after yield credit
+	go ys.checkGoalCompletion(vault.ID, credited.EndBalance, vault.GoalAmount.String)
+
+	return nil
+}
+
+// settleYield accrues the vault's yield up to accrueUntil (or maturity, if earlier) and capitalises
+// the pending accruals when the compounding period has passed or capitaliseAll is set. It runs in
+// the caller's transaction with the vault row locked, and returns the credited yield, if any.
+func (ys *YieldService) settleYield(ctx context.Context, qtx *db.Queries, vault *db.VaultSaving, accrueUntil time.Time, capitaliseAll bool) (*YieldCalculationResult, error) {
+	// Get the yield terms for this vault
+	yieldConfig, err := ys.yieldTermsFor(ctx, vault)
 	if err != nil {
 		ys.logger.Warn(fmt.Sprintf("No active yield config for currency %s: %v", vault.Currency, err))
-		return nil // Not an error, just no yield available
+		return nil, nil // Not an error, just no yield available
 	}
 
-	// Check if balance meets minimum requirement
-	minBalance, _ := decimal.NewFromString(yieldConfig.MinBalanceForYield)
-	if balance.LessThan(minBalance) {
-		ys.logger.Info(fmt.Sprintf("Vault %s balance (%.2f) below minimum (%.2f) for yield, skipping", vaultID, balance.InexactFloat64(), minBalance.InexactFloat64()))
-		return nil
-	}
+	now := time.Now().UTC()
 
-	ys.logger.Info(fmt.Sprintf("Vault %s passed all checks. Currency=%s, Balance=%.2f, MinBalance=%.2f, APY=%s",
-		vaultID, vault.Currency, balance.InexactFloat64(), minBalance.InexactFloat64(), yieldConfig.ApyRate))
+	// locked vaults accrue up to maturity and no further
+	if vault.MaturesAt.Valid && vault.MaturesAt.Time.Before(accrueUntil) {
+		accrueUntil = vault.MaturesAt.Time.UTC()
+	}
 
-	// Determine calculation period
-	var periodStart time.Time
+	cursor := vault.CreatedAt
 	if vault.LastYieldCalculation.Valid {
-		periodStart = vault.LastYieldCalculation.Time
-	} else {
-		// First time - calculate from vault creation
-		periodStart = vault.CreatedAt
+		cursor = vault.LastYieldCalculation.Time
 	}
+	cursor = cursor.UTC()
 
-	periodEnd := time.Now()
+	if accrueUntil.After(cursor) {
+		if err := ys.accrueDailyYield(ctx, qtx, vault, &yieldConfig, cursor, accrueUntil); err != nil {
+			return nil, err
+		}
+		cursor = accrueUntil
+	}
 
-	// Calculate yield
-	calcReq := YieldCalculationRequest{
-		VaultID:       vaultID,
-		Balance:       vault.CurrentBalance.String,
-		APY:           yieldConfig.ApyRate,
-		PeriodStart:   periodStart,
-		PeriodEnd:     periodEnd,
-		CompoundDaily: yieldConfig.CompoundFrequency.Valid && yieldConfig.CompoundFrequency.String == "daily",
+	pending, err := qtx.ListPendingYieldAccruals(ctx, vault.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending accruals: %w", err)
 	}
 
-	result, err := ys.CalculateYield(ctx, calcReq)
+	totalYieldEarned, _ := decimal.NewFromString(vault.TotalYieldEarned.String)
+	var credited *YieldCalculationResult
+	if len(pending) > 0 && (capitaliseAll || capitalisationDue(yieldConfig.CompoundFrequency.String, pending[0].AccrualDate, now)) {
+		credited, err = ys.capitaliseYield(ctx, qtx, vault, &yieldConfig, pending)
+		if err != nil {
+			return nil, err
+		}
+		if credited != nil {
+			net, _ := decimal.NewFromString(credited.YieldAmount)
+			totalYieldEarned = totalYieldEarned.Add(net)
+		}
+	}
+
+	nextCalculation := now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+	if vault.MaturesAt.Valid && vault.MaturesAt.Time.Before(nextCalculation) {
+		nextCalculation = vault.MaturesAt.Time
+	}
+
+	err = qtx.UpdateYieldTracking(ctx, db.UpdateYieldTrackingParams{
+		ID:                   vault.ID,
+		TotalYieldEarned:     nullString(totalYieldEarned.StringFixed(4)),
+		LastYieldCalculation: nullTime(cursor),
+		NextYieldCalculation: nullTime(nextCalculation),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to calculate yield: %w", err)
+		return nil, fmt.Errorf("failed to update yield tracking: %w", err)
 	}
+	return credited, nil
+}
 
-	// Check if yield is significant enough to credit (> 0.0001)
-	yieldAmount, _ := decimal.NewFromString(result.YieldAmount)
-	minYield := decimal.NewFromFloat(0.0001)
-	if yieldAmount.LessThan(minYield) {
-		ys.logger.Info(fmt.Sprintf("Yield amount too small for vault %s: %s", vaultID, result.YieldAmount))
+// accrueDailyYield records one accrual per calendar day (UTC) between from and until on the
+// vault's current balance. Days below the minimum balance earn nothing.
+func (ys *YieldService) accrueDailyYield(
+	ctx context.Context,
+	qtx *db.Queries,
+	vault *db.VaultSaving,
+	config *db.VaultYieldConfig,
+	from, until time.Time,
+) error {
+	balance, err := decimal.NewFromString(vault.CurrentBalance.String)
+	if err != nil || balance.LessThanOrEqual(decimal.Zero) {
+		ys.logger.Info(fmt.Sprintf("Vault %s has no balance (balance=%s), nothing to accrue", vault.ID, vault.CurrentBalance.String))
 		return nil
 	}
 
-	// Credit yield in a transaction
-	return ys.creditYieldToVault(ctx, &vault, result, &yieldConfig)
+	minBalance, _ := decimal.NewFromString(config.MinBalanceForYield)
+	if balance.LessThan(minBalance) {
+		ys.logger.Info(fmt.Sprintf("Vault %s balance (%.2f) below minimum (%.2f) for yield, skipping", vault.ID, balance.InexactFloat64(), minBalance.InexactFloat64()))
+		return nil
+	}
+
+	tiers := yieldTiersFromConfig(config)
+	for start := from; start.Before(until); {
+		day := start.Truncate(24 * time.Hour)
+		end := day.Add(24 * time.Hour)
+		if end.After(until) {
+			end = until
+		}
+
+		result, err := ys.CalculateYield(ctx, YieldCalculationRequest{
+			VaultID:     vault.ID,
+			Balance:     vault.CurrentBalance.String,
+			APY:         config.ApyRate,
+			PeriodStart: start,
+			PeriodEnd:   end,
+			Tiers:       tiers,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to calculate yield: %w", err)
+		}
+
+		amount, _ := decimal.NewFromString(result.YieldAmount)
+		if amount.GreaterThan(decimal.Zero) {
+			err = qtx.CreateYieldAccrual(ctx, db.CreateYieldAccrualParams{
+				VaultID:     vault.ID,
+				UserID:      vault.UserID,
+				AccrualDate: day,
+				PeriodStart: start,
+				PeriodEnd:   end,
+				Balance:     result.StartBalance,
+				ApyRate:     result.EffectiveAPY,
+				Amount:      result.YieldAmount,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record yield accrual: %w", err)
+			}
+		}
+
+		start = end
+	}
+	return nil
+}
+
+// capitalisationDue reports whether accruals pending since oldest should be credited now.
+// Daily configs capitalise every run, weekly ones once the oldest accrual is a week old, and
+// monthly ones once the month it fell in has ended.
+func capitalisationDue(frequency string, oldest, now time.Time) bool {
+	switch frequency {
+	case "weekly":
+		return !now.Before(oldest.AddDate(0, 0, 7))
+	case "monthly":
+		return now.Year() > oldest.Year() || now.Month() > oldest.Month()
+	default:
+		return true
+	}
+}
+
+// yieldTermsFor returns the config a vault accrues under. Locked vaults keep the APY fixed when
+// their term began, whatever the product's rate is now, and have no minimum balance or tiers.
+func (ys *YieldService) yieldTermsFor(ctx context.Context, vault *db.VaultSaving) (db.VaultYieldConfig, error) {
+	if vault.VaultType != string(SavingsTypeLocked) {
+		return ys.store.GetActiveYieldConfigByCurrency(ctx, vault.Currency)
+	}
+
+	var config db.VaultYieldConfig
+	if vault.YieldConfigID.Valid {
+		product, err := ys.store.GetYieldConfigByID(ctx, vault.YieldConfigID.UUID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return config, err
+		}
+		config = product
+	}
+	config.Currency = vault.Currency
+	config.ApyRate = vault.LockedApy.String
+	config.ApyTiers = pqtype.NullRawMessage{}
+	config.MinBalanceForYield = "0"
+	return config, nil
 }
 
 // ============================================================================
 // CREDIT YIELD TO VAULT
 // ============================================================================
 
-// creditYieldToVault credits calculated yield to vault balance
-func (ys *YieldService) creditYieldToVault(
+// capitaliseYield credits the pending accruals to the vault as one yield, less withholding tax.
+// It returns nil when the accrued total is still too small to credit.
+func (ys *YieldService) capitaliseYield(
 	ctx context.Context,
+	qtx *db.Queries,
 	vault *db.VaultSaving,
-	result *YieldCalculationResult,
 	config *db.VaultYieldConfig,
-) error {
-	ys.logger.Info(fmt.Sprintf("Crediting yield %s %s to vault %s",
-		result.YieldAmount, vault.Currency, vault.ID))
+	pending []db.VaultYieldAccrual,
+) (*YieldCalculationResult, error) {
+	gross := decimal.Zero
+	weightedAPY := decimal.Zero
+	days := 0.0
+	for _, a := range pending {
+		amount, _ := decimal.NewFromString(a.Amount)
+		apy, _ := decimal.NewFromString(a.ApyRate)
+		span := a.PeriodEnd.Sub(a.PeriodStart).Hours() / 24.0
+		gross = gross.Add(amount)
+		weightedAPY = weightedAPY.Add(apy.Mul(decimal.NewFromFloat(span)))
+		days += span
+	}
+	gross = gross.Round(8)
+	if gross.LessThan(yieldCapitalisationMinimum) {
+		ys.logger.Info(fmt.Sprintf("Accrued yield too small to credit for vault %s: %s", vault.ID, gross.String()))
+		return nil, nil
+	}
+
+	// Rate credited is the time-weighted APY of the accruals
+	apyRate := config.ApyRate
+	if days > 0 {
+		apyRate = weightedAPY.Div(decimal.NewFromFloat(days)).StringFixed(6)
+	}
+
+	tax, net := withholdTax(gross, config.WithholdingTaxRate)
+	periodStart := pending[0].PeriodStart
+	periodEnd := pending[len(pending)-1].PeriodEnd
+
+	ys.logger.Info(fmt.Sprintf("Crediting yield %s %s (gross %s, tax %s) to vault %s",
+		net.StringFixed(8), vault.Currency, gross.StringFixed(8), tax.StringFixed(8), vault.ID))
 
-	// Parse amounts
-	yieldAmount, _ := decimal.NewFromString(result.YieldAmount)
 	currentBalance, _ := decimal.NewFromString(vault.CurrentBalance.String)
-	newBalance := currentBalance.Add(yieldAmount)
-
-	// Start database transaction
-	tx, err := ys.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
-	if err != nil {
-		return fmt.Errorf("failed to start transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	qtx := ys.store.WithTx(tx)
-
-	// Create yield record
-	periodStart := vault.LastYieldCalculation.Time
-	if !vault.LastYieldCalculation.Valid {
-		periodStart = vault.CreatedAt
-	}
+	newBalance := currentBalance.Add(net)
 
 	yieldRecord, err := qtx.CreateVaultYield(ctx, db.CreateVaultYieldParams{
 		UserID:                 vault.UserID,
 		VaultID:                vault.ID,
-		YieldAmount:            result.YieldAmount,
-		YieldRate:              config.ApyRate,
+		YieldAmount:            gross.StringFixed(8),
+		YieldRate:              apyRate,
 		CalculationPeriodStart: periodStart,
-		CalculationPeriodEnd:   time.Now(),
+		CalculationPeriodEnd:   periodEnd,
 		VaultBalanceSnapshot:   vault.CurrentBalance.String,
 		Status:                 nullString("calculated"),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create yield record: %w", err)
+		return nil, fmt.Errorf("failed to create yield record: %w", err)
+	}
+
+	if err := qtx.CapitaliseYieldAccruals(ctx, db.CapitaliseYieldAccrualsParams{
+		VaultID: vault.ID,
+		YieldID: uuid.NullUUID{UUID: yieldRecord.ID, Valid: true},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to capitalise accruals: %w", err)
+	}
+
+	if tax.GreaterThan(decimal.Zero) {
+		// The yield is taxed in the year it was earned. Period ends are exclusive,
+		// so an accrual ending at midnight on 1 January belongs to the year before.
+		taxYear := periodEnd.Add(-time.Nanosecond).UTC().Year()
+		if _, err := qtx.CreateWithholdingTaxEntry(ctx, db.CreateWithholdingTaxEntryParams{
+			YieldID:    uuid.NullUUID{UUID: yieldRecord.ID, Valid: true},
+			UserID:     vault.UserID,
+			VaultID:    vault.ID,
+			Currency:   vault.Currency,
+			GrossYield: gross.StringFixed(withholdTaxScale),
+			TaxRate:    config.WithholdingTaxRate,
+			TaxAmount:  tax.StringFixed(withholdTaxScale),
+			NetYield:   net.StringFixed(withholdTaxScale),
+			TaxYear:    int32(taxYear),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to record withholding tax: %w", err)
+		}
 	}
 
 	// Create vault transaction for yield credit
 	transactionRef := utils.WatRequestID()
-	balanceBefore := vault.CurrentBalance
 	balanceAfter := newBalance.StringFixed(4)
 
-	amountUsd, err := utils.ConvertToUSD(ctx, yieldAmount, vault.Currency)
+	amountUsd, err := utils.ConvertToUSD(ctx, net, vault.Currency)
 	if err != nil {
-		return fmt.Errorf("failed to convert yield amount to USD: %w", err)
+		return nil, fmt.Errorf("failed to convert yield amount to USD: %w", err)
 	}
 
 	mainTx, err := qtx.CreateTransaction(ctx, db.CreateTransactionParams{
 		UserID:          vault.UserID,
 		Type:            string(transaction.Vault),
 		Description:     sql.NullString{String: "Yield credit", Valid: true},
-		Amount:          result.YieldAmount,
+		Amount:          net.StringFixed(8),
 		Currency:        vault.Currency,
 		AmountUsd:       amountUsd.String(),
 		IdempotencyKey:  utils.WatRequestID(),
@@ -338,60 +528,46 @@ func (ys *YieldService) creditYieldToVault(
 		TFrom:           "Platform",
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create transaction record: %w", err)
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
 	}
 
+	description := fmt.Sprintf("Yield earned: %s%% APY for %.2f days", apyRate, days)
+	if tax.GreaterThan(decimal.Zero) {
+		description = fmt.Sprintf("%s, %s withholding tax at %s%%", description, tax.StringFixed(4), config.WithholdingTaxRate)
+	}
+	metadata, _ := json.Marshal(map[string]any{
+		"gross_yield":          gross.StringFixed(8),
+		"withholding_tax_rate": config.WithholdingTaxRate,
+		"withholding_tax":      tax.StringFixed(8),
+		"accrual_days":         len(pending),
+	})
+
 	_, err = qtx.CreateVaultTransaction(ctx, db.CreateVaultTransactionParams{
 		UserID:          vault.UserID,
 		VaultID:         vault.ID,
 		TransactionType: string(TransactionTypeYieldCredit),
-		Amount:          result.YieldAmount,
+		Amount:          net.StringFixed(8),
 		Currency:        vault.Currency,
-		BalanceBefore:   balanceBefore.String,
+		BalanceBefore:   vault.CurrentBalance.String,
 		BalanceAfter:    balanceAfter,
 		Reference:       nullString(transactionRef),
-		Description:     nullString((fmt.Sprintf("Yield earned: %s%% APY for %.2f days", config.ApyRate, result.DaysInPeriod))),
+		Description:     nullString(description),
+		Metadata:        pqtype.NullRawMessage{RawMessage: metadata, Valid: true},
 		Status:          nullString(string(TransactionStatusSuccessful)),
 		Requires2fa:     nullBool(false),
 		TransactionID:   uuid.NullUUID{UUID: mainTx.ID, Valid: true},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create transaction: %w", err)
-	}
-
-	// Update vault balance and yield tracking
-	totalYieldEarned, _ := decimal.NewFromString(vault.TotalYieldEarned.String)
-	newTotalYield := totalYieldEarned.Add(yieldAmount)
-
-	nextCalculation := time.Now()
-	switch config.CompoundFrequency.String {
-	case "daily":
-		nextCalculation = nextCalculation.Add(24 * time.Hour)
-	case "weekly":
-		nextCalculation = nextCalculation.Add(7 * 24 * time.Hour)
-	case "monthly":
-		nextCalculation = nextCalculation.AddDate(0, 1, 0)
-	default:
-		nextCalculation = nextCalculation.Add(24 * time.Hour) // Default to daily
-	}
-
-	err = qtx.UpdateYieldTracking(ctx, db.UpdateYieldTrackingParams{
-		ID:                   vault.ID,
-		TotalYieldEarned:     nullString(newTotalYield.StringFixed(4)),
-		LastYieldCalculation: nullTime(time.Now()),
-		NextYieldCalculation: nullTime(nextCalculation),
-	})
-	if err != nil {
-		return fmt.Errorf("failed to update yield tracking: %w", err)
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
 	// Update vault balance
 	err = qtx.IncrementVaultBalance(ctx, db.IncrementVaultBalanceParams{
 		ID:             vault.ID,
-		CurrentBalance: nullString(result.YieldAmount),
+		CurrentBalance: nullString(net.StringFixed(8)),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update vault balance: %w", err)
+		return nil, fmt.Errorf("failed to update vault balance: %w", err)
 	}
 
 	// Mark yield as credited
@@ -400,24 +576,18 @@ func (ys *YieldService) creditYieldToVault(
 		Column2: "credited",
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update yield status: %w", err)
-	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to update yield status: %w", err)
 	}
 
-	ys.logger.Info(fmt.Sprintf("Successfully credited yield %s %s to vault %s",
-		result.YieldAmount, vault.Currency, vault.ID))
-
-	// Send notifications asynchronously
-	go ys.sendYieldNotification(vault, result)
-
-	// Check if goal reached after yield credit
-	go ys.checkGoalCompletion(vault.ID, newBalance.String(), vault.GoalAmount.String)
-
-	return nil
+	return &YieldCalculationResult{
+		YieldAmount:  net.StringFixed(8),
+		DaysInPeriod: days,
+		StartBalance: currentBalance.StringFixed(8),
+		EndBalance:   newBalance.StringFixed(8),
+		EffectiveAPY: apyRate,
+		CalculatedAt: time.Now(),
+		Reference:    transactionRef,
+	}, nil
 }
 
 // ============================================================================
@@ -488,13 +658,17 @@ func (ys *YieldService) GetYieldProjection(ctx context.Context, vaultID uuid.UUI
 	}
 
 	// Get yield config
-	config, err := ys.store.GetActiveYieldConfigByCurrency(ctx, vault.Currency)
+	config, err := ys.yieldTermsFor(ctx, &vault)
 	if err != nil {
 		return nil, fmt.Errorf("no active yield config: %w", err)
 	}
 
 	balance, _ := decimal.NewFromString(vault.CurrentBalance.String)
 	apy, _ := decimal.NewFromString(config.ApyRate)
+	tiers := yieldTiersFromConfig(&config)
+	if len(tiers) > 0 {
+		apy = blendedAPY(balance, tiers)
+	}
 
 	// Calculate for requested period
 	periodResult, _ := ys.CalculateYield(ctx, YieldCalculationRequest{
@@ -504,6 +678,7 @@ func (ys *YieldService) GetYieldProjection(ctx context.Context, vaultID uuid.UUI
 		PeriodStart:   time.Now(),
 		PeriodEnd:     time.Now().AddDate(0, 0, days),
 		CompoundDaily: config.CompoundFrequency.String == "daily",
+		Tiers:         tiers,
 	})
 
 	// Calculate daily yield
@@ -514,6 +689,7 @@ func (ys *YieldService) GetYieldProjection(ctx context.Context, vaultID uuid.UUI
 		PeriodStart:   time.Now(),
 		PeriodEnd:     time.Now().AddDate(0, 0, 1),
 		CompoundDaily: false,
+		Tiers:         tiers,
 	})
 
 	// Calculate weekly yield
@@ -524,6 +700,7 @@ func (ys *YieldService) GetYieldProjection(ctx context.Context, vaultID uuid.UUI
 		PeriodStart:   time.Now(),
 		PeriodEnd:     time.Now().AddDate(0, 0, 7),
 		CompoundDaily: config.CompoundFrequency.String == "daily",
+		Tiers:         tiers,
 	})
 
 	// Calculate monthly yield
@@ -534,6 +711,7 @@ func (ys *YieldService) GetYieldProjection(ctx context.Context, vaultID uuid.UUI
 		PeriodStart:   time.Now(),
 		PeriodEnd:     time.Now().AddDate(0, 1, 0),
 		CompoundDaily: config.CompoundFrequency.String == "daily",
+		Tiers:         tiers,
 	})
 
 	// Calculate yearly yield
@@ -544,6 +722,7 @@ func (ys *YieldService) GetYieldProjection(ctx context.Context, vaultID uuid.UUI
 		PeriodStart:   time.Now(),
 		PeriodEnd:     time.Now().AddDate(1, 0, 0),
 		CompoundDaily: config.CompoundFrequency.String == "daily",
+		Tiers:         tiers,
 	})
 
 	estimatedYield, _ := decimal.NewFromString(periodResult.YieldAmount)
@@ -578,6 +757,14 @@ type VaultYieldConfigResponse struct {
 	Notes              string    `json:"notes"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
+
+	ProductType                string `json:"product_type"`
+	LockTermDays               int32  `json:"lock_term_days,omitempty"`
+	EarlyWithdrawalPolicy      string `json:"early_withdrawal_policy,omitempty"`
+	EarlyWithdrawalPenaltyRate string `json:"early_withdrawal_penalty_rate"`
+
+	ApyTiers           []YieldTier `json:"apy_tiers,omitempty"`
+	WithholdingTaxRate string      `json:"withholding_tax_rate"`
 }
 
 func MapVaultYieldConfigToResponse(v *db.VaultYieldConfig) *VaultYieldConfigResponse {
@@ -593,6 +780,14 @@ func MapVaultYieldConfigToResponse(v *db.VaultYieldConfig) *VaultYieldConfigResp
 		Notes:              v.Notes.String,
 		CreatedAt:          v.CreatedAt,
 		UpdatedAt:          v.UpdatedAt,
+
+		ProductType:                v.ProductType,
+		LockTermDays:               v.LockTermDays.Int32,
+		EarlyWithdrawalPolicy:      v.EarlyWithdrawalPolicy.String,
+		EarlyWithdrawalPenaltyRate: v.EarlyWithdrawalPenaltyRate,
+
+		ApyTiers:           yieldTiersFromConfig(v),
+		WithholdingTaxRate: v.WithholdingTaxRate,
 	}
 }
 
@@ -605,12 +800,82 @@ type CreateYieldConfigParams struct {
 	EffectiveFrom      time.Time  `json:"effective_from" example:"2025-12-19T13:24:54Z"`
 	EffectiveUntil     *time.Time `json:"effective_until" example:"2025-12-19T13:24:54Z"`
 	Notes              *string    `json:"notes"`
+
+	// Locked products set a term and what breaking the lock early costs. For locked products
+	// min_balance_for_yield is the minimum amount that can be locked.
+	ProductType                string  `json:"product_type" default:"flexible" enums:"flexible,locked"`
+	LockTermDays               *int32  `json:"lock_term_days" example:"90" enums:"30,60,90,180,365"`
+	EarlyWithdrawalPolicy      *string `json:"early_withdrawal_policy" example:"penalty" enums:"forfeit_yield,penalty"`
+	EarlyWithdrawalPenaltyRate *string `json:"early_withdrawal_penalty_rate" example:"2.5"`
+
+	// Balance bands with their own APY. apy_rate stays the headline rate shown to users.
+	ApyTiers []YieldTier `json:"apy_tiers"`
+	// Percentage of gross yield withheld at source and owed to the tax authority
+	WithholdingTaxRate *string `json:"withholding_tax_rate" example:"10"`
+}
+
+func validEarlyWithdrawalTerms(policy, penaltyRate *string) error {
+	if policy != nil && *policy != string(EarlyWithdrawalForfeitYield) && *policy != string(EarlyWithdrawalPenalty) {
+		return fmt.Errorf("early_withdrawal_policy must be %s or %s", EarlyWithdrawalForfeitYield, EarlyWithdrawalPenalty)
+	}
+	if penaltyRate != nil {
+		rate, err := decimal.NewFromString(*penaltyRate)
+		if err != nil || rate.LessThan(decimal.Zero) || rate.GreaterThan(decimal.NewFromInt(100)) {
+			return errors.New("early_withdrawal_penalty_rate must be between 0 and 100")
+		}
+	}
+	return nil
 }
 
 // CreateYieldConfig creates a new yield configuration
 func (ys *YieldService) CreateYieldConfig(ctx context.Context, params CreateYieldConfigParams) (*db.VaultYieldConfig, error) {
 	ys.logger.Info(fmt.Sprintf("Creating yield config for %s: %s%% APY", params.Currency, params.ApyRate))
 
+	if params.ProductType == "" {
+		params.ProductType = string(SavingsTypeFlexible)
+	}
+	switch params.ProductType {
+	case string(SavingsTypeFlexible):
+		if params.LockTermDays != nil {
+			return nil, errors.New("lock_term_days is only set on locked products")
+		}
+	case string(SavingsTypeLocked):
+		if params.LockTermDays == nil || !isLockTerm(*params.LockTermDays) {
+			return nil, ErrInvalidLockTerm
+		}
+		if params.EarlyWithdrawalPolicy == nil {
+			return nil, errors.New("early_withdrawal_policy is required for locked products")
+		}
+	default:
+		return nil, fmt.Errorf("invalid product_type %q", params.ProductType)
+	}
+	if err := validEarlyWithdrawalTerms(params.EarlyWithdrawalPolicy, params.EarlyWithdrawalPenaltyRate); err != nil {
+		return nil, err
+	}
+	if err := validWithholdingTaxRate(params.WithholdingTaxRate); err != nil {
+		return nil, err
+	}
+	if params.ProductType == string(SavingsTypeLocked) && len(params.ApyTiers) > 0 {
+		return nil, fmt.Errorf("%w: locked products have a single fixed APY", ErrInvalidYieldTiers)
+	}
+	apyTiers, err := marshalYieldTiers(params.ApyTiers)
+	if err != nil {
+		return nil, err
+	}
+
+	taxRate := "0"
+	if params.WithholdingTaxRate != nil {
+		taxRate = *params.WithholdingTaxRate
+	}
+	penaltyRate := "0"
+	if params.EarlyWithdrawalPenaltyRate != nil {
+		penaltyRate = *params.EarlyWithdrawalPenaltyRate
+	}
+	var lockTermDays sql.NullInt32
+	if params.LockTermDays != nil {
+		lockTermDays = sql.NullInt32{Int32: *params.LockTermDays, Valid: true}
+	}
+
 	args := db.CreateYieldConfigParams{
 		Currency:           params.Currency,
 		ApyRate:            params.ApyRate,
@@ -620,6 +885,14 @@ func (ys *YieldService) CreateYieldConfig(ctx context.Context, params CreateYiel
 		EffectiveFrom:      params.EffectiveFrom,
 		EffectiveUntil:     sql.NullTime{Time: utils.PtrToTime(params.EffectiveUntil), Valid: params.EffectiveUntil != nil},
 		Notes:              sql.NullString{String: utils.PtrToString(params.Notes), Valid: params.Notes != nil},
+
+		ProductType:                params.ProductType,
+		LockTermDays:               lockTermDays,
+		EarlyWithdrawalPolicy:      sql.NullString{String: utils.PtrToString(params.EarlyWithdrawalPolicy), Valid: params.EarlyWithdrawalPolicy != nil},
+		EarlyWithdrawalPenaltyRate: penaltyRate,
+
+		ApyTiers:           apyTiers,
+		WithholdingTaxRate: taxRate,
 	}
 	config, err := ys.store.CreateYieldConfig(ctx, args)
 	if err != nil {
@@ -639,12 +912,31 @@ type UpdateYieldConfigParams struct {
 	EffectiveFrom      time.Time  `json:"effective_from"`
 	EffectiveUntil     *time.Time `json:"effective_until"`
 	Notes              *string    `json:"notes"`
+
+	// changes apply to vaults locked from now on; running locks keep their terms
+	EarlyWithdrawalPolicy      *string `json:"early_withdrawal_policy" enums:"forfeit_yield,penalty"`
+	EarlyWithdrawalPenaltyRate *string `json:"early_withdrawal_penalty_rate"`
+
+	// replaces the bands when set; an empty list leaves them as they are
+	ApyTiers           []YieldTier `json:"apy_tiers"`
+	WithholdingTaxRate *string     `json:"withholding_tax_rate"`
 }
 
 // UpdateYieldConfig updates an existing yield configuration
 func (ys *YieldService) UpdateYieldConfig(ctx context.Context, configID uuid.UUID, params UpdateYieldConfigParams) error {
 	ys.logger.Info(fmt.Sprintf("Updating yield config %s", configID))
 
+	if err := validEarlyWithdrawalTerms(params.EarlyWithdrawalPolicy, params.EarlyWithdrawalPenaltyRate); err != nil {
+		return err
+	}
+	if err := validWithholdingTaxRate(params.WithholdingTaxRate); err != nil {
+		return err
+	}
+	apyTiers, err := marshalYieldTiers(params.ApyTiers)
+	if err != nil {
+		return err
+	}
+
 	args := db.UpdateYieldConfigParams{
 		ID:                 configID,
 		ApyRate:            sql.NullString{String: params.ApyRate, Valid: params.ApyRate != ""},
@@ -653,6 +945,12 @@ func (ys *YieldService) UpdateYieldConfig(ctx context.Context, configID uuid.UUI
 		IsActive:           sql.NullBool{Bool: params.IsActive, Valid: true},
 		EffectiveUntil:     sql.NullTime{Time: utils.PtrToTime(params.EffectiveUntil), Valid: params.EffectiveUntil != nil},
 		Notes:              sql.NullString{String: utils.PtrToString(params.Notes), Valid: params.Notes != nil},
+
+		EarlyWithdrawalPolicy:      sql.NullString{String: utils.PtrToString(params.EarlyWithdrawalPolicy), Valid: params.EarlyWithdrawalPolicy != nil},
+		EarlyWithdrawalPenaltyRate: sql.NullString{String: utils.PtrToString(params.EarlyWithdrawalPenaltyRate), Valid: params.EarlyWithdrawalPenaltyRate != nil},
+
+		ApyTiers:           apyTiers,
+		WithholdingTaxRate: sql.NullString{String: utils.PtrToString(params.WithholdingTaxRate), Valid: params.WithholdingTaxRate != nil},
 	}
 	if err := ys.store.UpdateYieldConfig(ctx, args); err != nil {
 		return fmt.Errorf("failed to update yield config: %w", err)
@@ -0,0 +1,354 @@
+package vaultsavings
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// ============================================================================
+// YIELD TIERS
+// ============================================================================
+
+// YieldTier is one balance band of a tiered yield config. Its APY applies to the part of the
+// balance from MinBalance up to the next band's MinBalance.
+type YieldTier struct {
+	MinBalance string `json:"min_balance" example:"0"`
+	ApyRate    string `json:"apy_rate" example:"4.5"`
+}
+
+// validateYieldTiers checks the bands start at zero, ascend and carry a non-negative APY
+func validateYieldTiers(tiers []YieldTier) error {
+	previous := decimal.NewFromInt(-1)
+	for i, tier := range tiers {
+		lower, err := decimal.NewFromString(tier.MinBalance)
+		if err != nil || lower.LessThan(decimal.Zero) {
+			return fmt.Errorf("%w: band %d has an invalid min_balance", ErrInvalidYieldTiers, i+1)
+		}
+		if i == 0 && !lower.IsZero() {
+			return fmt.Errorf("%w: the first band must start at 0", ErrInvalidYieldTiers)
+		}
+		if !lower.GreaterThan(previous) {
+			return fmt.Errorf("%w: bands must be in ascending order of min_balance", ErrInvalidYieldTiers)
+		}
+		apy, err := decimal.NewFromString(tier.ApyRate)
+		if err != nil || apy.LessThan(decimal.Zero) {
+			return fmt.Errorf("%w: band %d has an invalid apy_rate", ErrInvalidYieldTiers, i+1)
+		}
+		previous = lower
+	}
+	return nil
+}
+
+// yieldTiersFromConfig reads the APY bands of a config; a config without bands has a flat rate
+func yieldTiersFromConfig(config *db.VaultYieldConfig) []YieldTier {
+	if !config.ApyTiers.Valid {
+		return nil
+	}
+	var tiers []YieldTier
+	if err := json.Unmarshal(config.ApyTiers.RawMessage, &tiers); err != nil {
+		return nil
+	}
+	return tiers
+}
+
+func marshalYieldTiers(tiers []YieldTier) (pqtype.NullRawMessage, error) {
+	if len(tiers) == 0 {
+		return pqtype.NullRawMessage{}, nil
+	}
+	if err := validateYieldTiers(tiers); err != nil {
+		return pqtype.NullRawMessage{}, err
+	}
+	raw, err := json.Marshal(tiers)
+	if err != nil {
+		return pqtype.NullRawMessage{}, err
+	}
+	return pqtype.NullRawMessage{RawMessage: raw, Valid: true}, nil
+}
+
+// blendedAPY is the single rate that earns the same as applying each band's APY to the part
+// of the balance inside that band
+func blendedAPY(balance decimal.Decimal, tiers []YieldTier) decimal.Decimal {
+	if balance.LessThanOrEqual(decimal.Zero) || len(tiers) == 0 {
+		return decimal.Zero
+	}
+
+	earned := decimal.Zero
+	for i, tier := range tiers {
+		lower, _ := decimal.NewFromString(tier.MinBalance)
+		if balance.LessThanOrEqual(lower) {
+			break
+		}
+		upper := balance
+		if i+1 < len(tiers) {
+			next, _ := decimal.NewFromString(tiers[i+1].MinBalance)
+			upper = decimal.Min(balance, next)
+		}
+		apy, _ := decimal.NewFromString(tier.ApyRate)
+		earned = earned.Add(upper.Sub(lower).Mul(apy))
+	}
+	return earned.Div(balance).Round(6)
+}
+
+// ============================================================================
+// WITHHOLDING TAX
+// ============================================================================
+
+func validWithholdingTaxRate(rate *string) error {
+	if rate == nil {
+		return nil
+	}
+	r, err := decimal.NewFromString(*rate)
+	if err != nil || r.LessThan(decimal.Zero) || r.GreaterThan(decimal.NewFromInt(100)) {
+		return ErrInvalidWithholdingTax
+	}
+	return nil
+}
+
+// withholdTaxScale is the scale of vault_withholding_tax_entries.tax_amount
+const withholdTaxScale = 4
+
+// withholdTax splits gross yield into the tax withheld at source and what the user keeps.
+// The tax is rounded to the scale it is stored at, so a tax too small to record is zero.
+func withholdTax(gross decimal.Decimal, rate string) (tax, net decimal.Decimal) {
+	r, err := decimal.NewFromString(rate)
+	if err != nil || r.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero, gross
+	}
+	tax = gross.Mul(r).Div(decimal.NewFromInt(100)).Round(withholdTaxScale)
+	return tax, gross.Sub(tax)
+}
+
+// WithholdingTaxLiability is the balance of the tax liability account for one currency and tax year
+type WithholdingTaxLiability struct {
+	Currency    string `json:"currency"`
+	TaxYear     int32  `json:"tax_year"`
+	Entries     int64  `json:"entries"`
+	GrossYield  string `json:"gross_yield"`
+	Outstanding string `json:"outstanding"`
+	Remitted    string `json:"remitted"`
+}
+
+// GetWithholdingTaxLiability sums tax withheld between from and to, split into what is still
+// owed to the tax authority and what has been remitted
+func (ys *YieldService) GetWithholdingTaxLiability(ctx context.Context, from, to time.Time) ([]WithholdingTaxLiability, error) {
+	if !to.After(from) {
+		return nil, errors.New("to must be after from")
+	}
+
+	rows, err := ys.store.GetWithholdingTaxLiability(ctx, db.GetWithholdingTaxLiabilityParams{
+		CreatedAt:   from,
+		CreatedAt_2: to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get withholding tax liability: %w", err)
+	}
+
+	type key struct {
+		currency string
+		year     int32
+	}
+	index := make(map[key]int)
+	liabilities := []WithholdingTaxLiability{}
+	for _, row := range rows {
+		k := key{row.Currency, row.TaxYear}
+		i, ok := index[k]
+		if !ok {
+			liabilities = append(liabilities, WithholdingTaxLiability{
+				Currency:    row.Currency,
+				TaxYear:     row.TaxYear,
+				GrossYield:  "0",
+				Outstanding: "0",
+				Remitted:    "0",
+			})
+			i = len(liabilities) - 1
+			index[k] = i
+		}
+
+		l := &liabilities[i]
+		l.Entries += row.Entries
+		l.GrossYield = addDecimalStrings(l.GrossYield, row.GrossYield)
+		if row.Status == "remitted" {
+			l.Remitted = addDecimalStrings(l.Remitted, row.TaxAmount)
+		} else {
+			l.Outstanding = addDecimalStrings(l.Outstanding, row.TaxAmount)
+		}
+	}
+	return liabilities, nil
+}
+
+func addDecimalStrings(a, b string) string {
+	x, _ := decimal.NewFromString(a)
+	y, _ := decimal.NewFromString(b)
+	return x.Add(y).StringFixed(8)
+}
+
+// RemitWithholdingTaxParams marks tax withheld before a cutoff as paid to the tax authority
+type RemitWithholdingTaxParams struct {
+	Currency  string    `json:"currency" binding:"required" example:"NGN"`
+	TaxYear   int32     `json:"tax_year" binding:"required" example:"2026"`
+	Before    time.Time `json:"before" binding:"required" example:"2027-01-31T00:00:00Z"`
+	Reference string    `json:"reference" binding:"required" example:"FIRS-2026-000123"`
+}
+
+// RemitWithholdingTax settles the outstanding liability for a currency and tax year and returns
+// how many entries it covered
+func (ys *YieldService) RemitWithholdingTax(ctx context.Context, params RemitWithholdingTaxParams) (int64, error) {
+	if params.TaxYear < 2000 || params.TaxYear > int32(time.Now().Year()) {
+		return 0, ErrInvalidTaxYear
+	}
+
+	count, err := ys.store.RemitWithholdingTax(ctx, db.RemitWithholdingTaxParams{
+		Currency:            params.Currency,
+		TaxYear:             params.TaxYear,
+		CreatedAt:           params.Before,
+		RemittanceReference: nullString(params.Reference),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to remit withholding tax: %w", err)
+	}
+
+	ys.logger.Info(fmt.Sprintf("Remitted %d withholding tax entries for %s %d (ref %s)", count, params.Currency, params.TaxYear, params.Reference))
+	return count, nil
+}
+
+// ============================================================================
+// YIELD STATEMENTS
+// ============================================================================
+
+// YieldStatement lists the yield a vault was credited in a tax year and the tax withheld from it
+type YieldStatement struct {
+	VaultID     uuid.UUID            `json:"vault_id"`
+	VaultName   string               `json:"vault_name"`
+	Currency    string               `json:"currency"`
+	TaxYear     int32                `json:"tax_year"`
+	GrossYield  string               `json:"gross_yield"`
+	TaxWithheld string               `json:"tax_withheld"`
+	NetYield    string               `json:"net_yield"`
+	Lines       []YieldStatementLine `json:"lines"`
+	GeneratedAt time.Time            `json:"generated_at"`
+}
+
+type YieldStatementLine struct {
+	YieldID     uuid.UUID `json:"yield_id"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Balance     string    `json:"balance"`
+	ApyRate     string    `json:"apy_rate"`
+	GrossYield  string    `json:"gross_yield"`
+	TaxRate     string    `json:"tax_rate"`
+	TaxWithheld string    `json:"tax_withheld"`
+	NetYield    string    `json:"net_yield"`
+	CreditedAt  time.Time `json:"credited_at"`
+}
+
+// GetVaultYieldStatement builds the yearly yield statement of a vault
+func (ys *YieldService) GetVaultYieldStatement(ctx context.Context, vaultID uuid.UUID, taxYear int32) (*YieldStatement, error) {
+	if taxYear < 2000 || taxYear > int32(time.Now().Year()) {
+		return nil, ErrInvalidTaxYear
+	}
+
+	vault, err := ys.store.GetVaultGoalByID(ctx, vaultID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrVaultNotFound
+		}
+		return nil, fmt.Errorf("failed to get vault: %w", err)
+	}
+
+	rows, err := ys.store.GetVaultYieldStatement(ctx, db.GetVaultYieldStatementParams{
+		VaultID: vaultID,
+		TaxYear: taxYear,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get yield statement: %w", err)
+	}
+
+	gross, tax, net := decimal.Zero, decimal.Zero, decimal.Zero
+	lines := make([]YieldStatementLine, 0, len(rows))
+	for _, row := range rows {
+		g, _ := decimal.NewFromString(row.GrossYield)
+		t, _ := decimal.NewFromString(row.TaxAmount)
+		n, _ := decimal.NewFromString(row.NetYield)
+		gross, tax, net = gross.Add(g), tax.Add(t), net.Add(n)
+
+		lines = append(lines, YieldStatementLine{
+			YieldID:     row.ID,
+			PeriodStart: row.CalculationPeriodStart,
+			PeriodEnd:   row.CalculationPeriodEnd,
+			Balance:     row.VaultBalanceSnapshot,
+			ApyRate:     row.YieldRate,
+			GrossYield:  g.StringFixed(8),
+			TaxRate:     row.TaxRate,
+			TaxWithheld: t.StringFixed(8),
+			NetYield:    n.StringFixed(8),
+			CreditedAt:  row.CreditedAt.Time,
+		})
+	}
+
+	return &YieldStatement{
+		VaultID:     vault.ID,
+		VaultName:   vault.VaultName,
+		Currency:    vault.Currency,
+		TaxYear:     taxYear,
+		GrossYield:  gross.StringFixed(8),
+		TaxWithheld: tax.StringFixed(8),
+		NetYield:    net.StringFixed(8),
+		Lines:       lines,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+var yieldStatementHeader = []string{
+	"yield_id",
+	"period_start",
+	"period_end",
+	"balance",
+	"apy_rate",
+	"gross_yield",
+	"tax_rate",
+	"tax_withheld",
+	"net_yield",
+	"credited_at",
+}
+
+// WriteYieldStatementCSV writes the statement lines followed by a totals row
+func WriteYieldStatementCSV(w io.Writer, statement *YieldStatement) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(yieldStatementHeader); err != nil {
+		return err
+	}
+	for _, l := range statement.Lines {
+		record := []string{
+			l.YieldID.String(),
+			l.PeriodStart.UTC().Format(time.RFC3339),
+			l.PeriodEnd.UTC().Format(time.RFC3339),
+			l.Balance,
+			l.ApyRate,
+			l.GrossYield,
+			l.TaxRate,
+			l.TaxWithheld,
+			l.NetYield,
+			l.CreditedAt.UTC().Format(time.RFC3339),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	total := []string{"total", "", "", "", "", statement.GrossYield, "", statement.TaxWithheld, statement.NetYield, ""}
+	if err := cw.Write(total); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,591 @@
+package virtualcard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/providers/bridgecards"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/transaction"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	BillingTypeMaintenance = "monthly_maintenance"
+
+	BillingRunScheduled = "scheduled"
+	BillingRunManual    = "manual"
+
+	// maintenanceRefPrefix marks card unloads that pay a maintenance fee, so
+	// the unload webhook settles the fee instead of crediting the wallet.
+	maintenanceRefPrefix = "cmf"
+
+	billingBatchSize     = 500
+	billingRetryInterval = 24 * time.Hour
+	billingGracePeriod   = 7 * 24 * time.Hour
+	billingReminderLead  = 3 * 24 * time.Hour
+)
+
+// billingOutcome is what a run did with one due card.
+type billingOutcome int
+
+const (
+	billingCharged billingOutcome = iota
+	billingPending
+	billingFailed
+	billingFrozen
+	billingWaived
+	billingSkipped
+)
+
+// BillingRunResponse summarises one maintenance billing run.
+type BillingRunResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	TriggerType   string     `json:"trigger_type"`
+	TriggeredBy   *uuid.UUID `json:"triggered_by"`
+	Status        string     `json:"status"`
+	CardsDue      int32      `json:"cards_due"`
+	ChargedCount  int32      `json:"charged_count"`
+	ChargedAmount string     `json:"charged_amount"`
+	PendingCount  int32      `json:"pending_count"`
+	FailedCount   int32      `json:"failed_count"`
+	FrozenCount   int32      `json:"frozen_count"`
+	WaivedCount   int32      `json:"waived_count"`
+	SkippedCount  int32      `json:"skipped_count"`
+	RemindersSent int32      `json:"reminders_sent"`
+	Error         *string    `json:"error"`
+	StartedAt     time.Time  `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+}
+
+// BillingRunEntry is one card's maintenance charge as last attempted by a run.
+type BillingRunEntry struct {
+	BillingID          uuid.UUID  `json:"billing_id"`
+	CardID             uuid.UUID  `json:"card_id"`
+	UserID             uuid.UUID  `json:"user_id"`
+	CardName           string     `json:"card_name"`
+	CardStatus         string     `json:"card_status"`
+	PlanName           string     `json:"plan_name"`
+	Amount             string     `json:"amount"`
+	Currency           string     `json:"currency"`
+	BillingPeriodStart time.Time  `json:"billing_period_start"`
+	BillingPeriodEnd   time.Time  `json:"billing_period_end"`
+	Status             string     `json:"status"`
+	FailureReason      *string    `json:"failure_reason"`
+	ChargeSource       *string    `json:"charge_source"`
+	AttemptCount       int32      `json:"attempt_count"`
+	NextRetryAt        *time.Time `json:"next_retry_at"`
+	ProcessedAt        *time.Time `json:"processed_at"`
+}
+
+// BillingRunReport is a run with every charge it attempted.
+type BillingRunReport struct {
+	Run     BillingRunResponse `json:"run"`
+	Entries []BillingRunEntry  `json:"entries"`
+}
+
+type billingTally struct {
+	due, charged, pending, failed, frozen, waived, skipped, reminders int32
+	chargedAmount                                                     decimal.Decimal
+}
+
+func (t *billingTally) add(outcome billingOutcome, amount decimal.Decimal) {
+	switch outcome {
+	case billingCharged:
+		t.charged++
+		t.chargedAmount = t.chargedAmount.Add(amount)
+	case billingPending:
+		t.pending++
+	case billingFailed:
+		t.failed++
+	case billingFrozen:
+		t.frozen++
+	case billingWaived:
+		t.waived++
+	default:
+		t.skipped++
+	}
+}
+
+// ── Maintenance billing ───────────────────────────────────────────────────────
+
+// RunMaintenanceBilling charges the monthly maintenance fee of every active
+// card whose billing date has passed. The USD wallet is charged first and the
+// card balance is unloaded when the wallet is short; cards that cannot pay are
+// retried daily and frozen once the grace period runs out. Scheduled runs also
+// remind cardholders of fees coming up.
+func (s *Service) RunMaintenanceBilling(ctx context.Context, trigger string, triggeredBy *uuid.UUID) (*BillingRunReport, error) {
+	var by uuid.NullUUID
+	if triggeredBy != nil {
+		by = uuid.NullUUID{UUID: *triggeredBy, Valid: true}
+	}
+	run, err := s.store.CreateCardBillingRun(ctx, db.CreateCardBillingRunParams{TriggerType: trigger, TriggeredBy: by})
+	if err != nil {
+		return nil, fmt.Errorf("create billing run: %w", err)
+	}
+
+	now := time.Now()
+	var tally billingTally
+	if trigger == BillingRunScheduled {
+		tally.reminders = s.sendBillingReminders(ctx, now)
+	}
+
+	status := "completed"
+	var runErr sql.NullString
+	cards, err := s.store.GetCardsForBilling(ctx, billingBatchSize)
+	if err != nil {
+		s.logger.Errorf("billing run %s: get due cards: %v", run.ID, err)
+		status = "failed"
+		runErr = sql.NullString{String: err.Error(), Valid: true}
+	}
+	tally.due = int32(len(cards))
+	for _, card := range cards {
+		outcome, amount := s.billCard(ctx, run.ID, card, now)
+		tally.add(outcome, amount)
+	}
+
+	run, err = s.store.FinishCardBillingRun(ctx, db.FinishCardBillingRunParams{
+		ID:            run.ID,
+		Status:        status,
+		CardsDue:      tally.due,
+		ChargedCount:  tally.charged,
+		ChargedAmount: tally.chargedAmount.String(),
+		PendingCount:  tally.pending,
+		FailedCount:   tally.failed,
+		FrozenCount:   tally.frozen,
+		WaivedCount:   tally.waived,
+		SkippedCount:  tally.skipped,
+		RemindersSent: tally.reminders,
+		Error:         runErr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finish billing run: %w", err)
+	}
+	s.logger.Infof("card billing run %s: due=%d charged=%d ($%s) pending=%d failed=%d frozen=%d",
+		run.ID, tally.due, tally.charged, tally.chargedAmount.StringFixed(2), tally.pending, tally.failed, tally.frozen)
+
+	return s.GetBillingRunReport(ctx, run.ID)
+}
+
+// billCard takes one card's maintenance fee for the period its billing date
+// falls in. Errors are logged and reported as skipped so one bad card does
+// not stop the run.
+func (s *Service) billCard(ctx context.Context, runID uuid.UUID, card db.VirtualCard, now time.Time) (billingOutcome, decimal.Decimal) {
+	plan, err := s.store.GetCardPlan(ctx, card.CardPlanID)
+	if err != nil {
+		s.logger.Errorf("billing: get plan %d for card %s: %v", card.CardPlanID, card.ID, err)
+		return billingSkipped, decimal.Zero
+	}
+	fee, err := decimal.NewFromString(plan.MonthlyMaintenanceFee)
+	if err != nil {
+		s.logger.Errorf("billing: bad maintenance fee %q on plan %d: %v", plan.MonthlyMaintenanceFee, plan.ID, err)
+		return billingSkipped, decimal.Zero
+	}
+	if !fee.IsPositive() {
+		s.advanceBillingDate(ctx, card, now)
+		return billingWaived, decimal.Zero
+	}
+
+	dueAt := card.NextBillingDate.Time
+	periodStart, periodEnd := billingPeriod(dueAt)
+	entry, err := s.store.GetMaintenanceBilling(ctx, db.GetMaintenanceBillingParams{
+		CardID: card.ID, BillingPeriodStart: periodStart,
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		entry, err = s.openMaintenanceBilling(ctx, runID, card, fee, periodStart, periodEnd)
+		if err != nil {
+			s.logger.Errorf("billing: open charge for card %s: %v", card.ID, err)
+			return billingSkipped, decimal.Zero
+		}
+	case err != nil:
+		s.logger.Errorf("billing: get charge for card %s: %v", card.ID, err)
+		return billingSkipped, decimal.Zero
+	case entry.Status == string(CardBillingHistoryStatusSuccessful):
+		// Paid, but the card's billing date was never moved on.
+		s.advanceBillingDate(ctx, card, now)
+		return billingSkipped, decimal.Zero
+	}
+
+	entry, err = s.store.ClaimMaintenanceBilling(ctx, db.ClaimMaintenanceBillingParams{
+		ID: entry.ID, BillingRunID: uuid.NullUUID{UUID: runID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// Waiting for a retry, a card unload or another run.
+		return billingSkipped, decimal.Zero
+	}
+	if err != nil {
+		s.logger.Errorf("billing: claim charge for card %s: %v", card.ID, err)
+		return billingSkipped, decimal.Zero
+	}
+
+	err = s.chargeMaintenanceFromWallet(ctx, card, entry, fee, now)
+	if err == nil {
+		s.notifyCard(card.UserID, "Card maintenance fee charged",
+			fmt.Sprintf("$%s was charged from your USD wallet for your %s card's monthly maintenance.", fee.StringFixed(2), card.CardName))
+		return billingCharged, fee
+	}
+	if errors.Is(err, ErrInsufficientFunds) {
+		err = s.chargeMaintenanceFromCard(ctx, card, entry, fee)
+		if err == nil {
+			return billingPending, decimal.Zero
+		}
+	}
+
+	reason := err.Error()
+	if errors.Is(err, ErrInsufficientFunds) {
+		reason = "insufficient funds in USD wallet and card balance"
+	} else {
+		s.logger.Errorf("billing: charge card %s: %v", card.ID, err)
+	}
+	return s.failMaintenanceCharge(ctx, card, entry, fee, reason, now), decimal.Zero
+}
+
+// openMaintenanceBilling records the period's charge with its pending ledger
+// transaction.
+func (s *Service) openMaintenanceBilling(ctx context.Context, runID uuid.UUID, card db.VirtualCard, fee decimal.Decimal, periodStart, periodEnd time.Time) (db.CardBillingHistory, error) {
+	dbTx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelDefault})
+	if err != nil {
+		return db.CardBillingHistory{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+	qtx := s.store.WithTx(dbTx)
+
+	txx, err := qtx.CreateTransaction(ctx, db.CreateTransactionParams{
+		UserID: card.UserID, Type: string(transaction.Card),
+		Description: sql.NullString{String: "card-maintenance-fee", Valid: true},
+		Amount:      fee.String(), Currency: "USD", AmountUsd: fee.String(),
+		Status: string(transaction.Pending), TransactionFlow: string(transaction.Outflow),
+		IdempotencyKey: fmt.Sprintf("card-maintenance-%s-%s", card.ID, periodStart.Format("2006-01")),
+		TFrom:          string(transaction.Wallet), TTo: "card_provider", Direction: string(transaction.Debit),
+	})
+	if err != nil {
+		return db.CardBillingHistory{}, fmt.Errorf("create maintenance tx: %w", err)
+	}
+	entry, err := qtx.CreateMaintenanceBilling(ctx, db.CreateMaintenanceBillingParams{
+		CardID: card.ID, UserID: card.UserID, CardPlanID: card.CardPlanID,
+		Amount: fee.String(), Currency: "USD",
+		BillingPeriodStart: periodStart, BillingPeriodEnd: periodEnd,
+		TransactionID: txx.ID,
+		BillingRunID:  uuid.NullUUID{UUID: runID, Valid: true},
+	})
+	if err != nil {
+		return db.CardBillingHistory{}, fmt.Errorf("create billing record: %w", err)
+	}
+	if err := dbTx.Commit(); err != nil {
+		return db.CardBillingHistory{}, fmt.Errorf("commit: %w", err)
+	}
+	return entry, nil
+}
+
+// chargeMaintenanceFromWallet debits the fee from the user's USD wallet and
+// moves the card's billing date on, all in one transaction.
+func (s *Service) chargeMaintenanceFromWallet(ctx context.Context, card db.VirtualCard, entry db.CardBillingHistory, fee decimal.Decimal, now time.Time) error {
+	usdWallet, err := s.store.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: card.UserID, Currency: "USD",
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInsufficientFunds
+	}
+	if err != nil {
+		return fmt.Errorf("get USD wallet: %w", err)
+	}
+
+	dbTx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelDefault})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+	qtx := s.store.WithTx(dbTx)
+
+	wallet, err := s.walletService.GetWalletForUpdate(ctx, dbTx, usdWallet.ID)
+	if err != nil {
+		return fmt.Errorf("lock wallet: %w", err)
+	}
+	if wallet.Balance.LessThan(fee) {
+		return ErrInsufficientFunds
+	}
+	if _, err = qtx.DecrementWalletBalance(ctx, db.DecrementWalletBalanceParams{
+		Balance: sql.NullString{String: fee.String(), Valid: true}, ID: usdWallet.ID,
+	}); err != nil {
+		return fmt.Errorf("deduct from wallet: %w", err)
+	}
+	if err := s.settleMaintenanceCharge(ctx, qtx, card, entry,
+		uuid.NullUUID{UUID: usdWallet.ID, Valid: true}, "wallet", now); err != nil {
+		return err
+	}
+	return dbTx.Commit()
+}
+
+// chargeMaintenanceFromCard unloads the fee from the card balance. Once the
+// unload is requested the charge stays pending until its webhook settles it,
+// even if the request itself errored.
+func (s *Service) chargeMaintenanceFromCard(ctx context.Context, card db.VirtualCard, entry db.CardBillingHistory, fee decimal.Decimal) error {
+	balance, err := s.bridgeCard.GetCardBalance(ctx, card.BridgecardCardID)
+	if err != nil {
+		return fmt.Errorf("get card balance: %w", err)
+	}
+	balanceCents, err := strconv.ParseInt(balance.Data.Balance, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse card balance %q: %w", balance.Data.Balance, err)
+	}
+	feeCentsStr, err := utils.DollarStringToCentsString(fee.StringFixed(2))
+	if err != nil {
+		return fmt.Errorf("fee to cents: %w", err)
+	}
+	feeCents, err := strconv.ParseInt(feeCentsStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("parse fee cents: %w", err)
+	}
+	if balanceCents < feeCents {
+		return ErrInsufficientFunds
+	}
+
+	// Record the reference first so the webhook can never arrive for an
+	// unload we do not know about.
+	ref := utils.NewTxRef(maintenanceRefPrefix)
+	if err := s.store.MarkMaintenanceBillingAwaitingCard(ctx, db.MarkMaintenanceBillingAwaitingCardParams{
+		ID: entry.ID, ProviderReference: sql.NullString{String: ref, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("mark charge awaiting card: %w", err)
+	}
+	if _, err := s.bridgeCard.WithdrawCard(ctx, bridgecards.WithdrawCardRequest{
+		CardID: card.BridgecardCardID, Amount: feeCentsStr,
+		TransactionReference: ref, Currency: "USD",
+	}); err != nil {
+		// A timeout or bad response does not mean the unload was refused. The
+		// charge keeps waiting for the webhook, which settles or fails it; failing
+		// it here would ignore a successful unload and charge the card again.
+		s.logger.Warnf("billing: unload %s for card %s unconfirmed, awaiting webhook: %v", ref, card.ID, err)
+	}
+	return nil
+}
+
+// settleMaintenanceCharge marks the charge and its ledger transaction paid
+// and moves the card's billing date past the charged period.
+func (s *Service) settleMaintenanceCharge(ctx context.Context, qtx *db.Queries, card db.VirtualCard, entry db.CardBillingHistory, sourceWallet uuid.NullUUID, source string, now time.Time) error {
+	if _, err := qtx.UpdateTransactionStatus(ctx, db.UpdateTransactionStatusParams{
+		ID: entry.TransactionID, Status: string(transaction.Success),
+	}); err != nil {
+		return fmt.Errorf("update maintenance tx: %w", err)
+	}
+	if _, err := qtx.CompleteMaintenanceBilling(ctx, db.CompleteMaintenanceBillingParams{
+		ID: entry.ID, SourceWalletID: sourceWallet,
+		ChargeSource: sql.NullString{String: source, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("complete billing record: %w", err)
+	}
+	if card.NextBillingDate.Valid && card.NextBillingDate.Time.After(entry.BillingPeriodEnd) {
+		return nil
+	}
+	if _, err := qtx.UpdateCardBilling(ctx, db.UpdateCardBillingParams{
+		ID:              card.ID,
+		NextBillingDate: sql.NullTime{Time: card.NextBillingDate.Time.AddDate(0, 1, 0), Valid: true},
+		LastBillingDate: sql.NullTime{Time: now, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("update card billing date: %w", err)
+	}
+	return nil
+}
+
+// failMaintenanceCharge schedules a retry, or freezes the card once the fee
+// has been outstanding for longer than the grace period.
+func (s *Service) failMaintenanceCharge(ctx context.Context, card db.VirtualCard, entry db.CardBillingHistory, fee decimal.Decimal, reason string, now time.Time) billingOutcome {
+	if err := s.store.FailMaintenanceBilling(ctx, db.FailMaintenanceBillingParams{
+		ID:            entry.ID,
+		FailureReason: sql.NullString{String: reason, Valid: true},
+		NextRetryAt:   sql.NullTime{Time: now.Add(billingRetryInterval), Valid: true},
+	}); err != nil {
+		s.logger.Errorf("billing: record failure for card %s: %v", card.ID, err)
+	}
+	if _, err := s.store.UpdateTransactionStatus(ctx, db.UpdateTransactionStatusParams{
+		ID: entry.TransactionID, Status: string(transaction.Failed),
+	}); err != nil {
+		s.logger.Errorf("billing: fail maintenance tx %s: %v", entry.TransactionID, err)
+	}
+
+	freezeAt := card.NextBillingDate.Time.Add(billingGracePeriod)
+	if !now.Before(freezeAt) {
+		if err := s.freezeWithReason(ctx, card, "monthly maintenance fee unpaid"); err != nil {
+			s.logger.Errorf("billing: freeze card %s: %v", card.ID, err)
+			return billingFailed
+		}
+		s.notifyCard(card.UserID, "Card frozen",
+			fmt.Sprintf("We froze your %s card because its $%s monthly maintenance fee is unpaid. Fund your USD wallet and unfreeze the card; the fee is collected on the next daily run.",
+				card.CardName, fee.StringFixed(2)))
+		return billingFrozen
+	}
+
+	// Only the first failure is announced; retries are silent until the freeze.
+	if entry.AttemptCount <= 1 {
+		s.notifyCard(card.UserID, "Card maintenance fee failed",
+			fmt.Sprintf("We could not collect the $%s monthly maintenance fee for your %s card. We will retry daily; fund your USD wallet or card before %s to avoid the card being frozen.",
+				fee.StringFixed(2), card.CardName, freezeAt.Format("2 Jan 2006")))
+	}
+	return billingFailed
+}
+
+// advanceBillingDate moves a card with nothing to pay to its next period.
+func (s *Service) advanceBillingDate(ctx context.Context, card db.VirtualCard, now time.Time) {
+	if _, err := s.store.UpdateCardBilling(ctx, db.UpdateCardBillingParams{
+		ID:              card.ID,
+		NextBillingDate: sql.NullTime{Time: card.NextBillingDate.Time.AddDate(0, 1, 0), Valid: true},
+		LastBillingDate: sql.NullTime{Time: now, Valid: true},
+	}); err != nil {
+		s.logger.Errorf("billing: advance billing date for card %s: %v", card.ID, err)
+	}
+}
+
+// sendBillingReminders tells cardholders about fees due in billingReminderLead.
+// The window is one day wide to match the daily scheduled run.
+func (s *Service) sendBillingReminders(ctx context.Context, now time.Time) int32 {
+	from := now.Add(billingReminderLead)
+	cards, err := s.store.GetCardsDueForBillingReminder(ctx, db.GetCardsDueForBillingReminderParams{
+		NextBillingDate:   sql.NullTime{Time: from, Valid: true},
+		NextBillingDate_2: sql.NullTime{Time: from.Add(24 * time.Hour), Valid: true},
+	})
+	if err != nil {
+		s.logger.Errorf("billing: get cards due for reminder: %v", err)
+		return 0
+	}
+	for _, card := range cards {
+		fee, _ := decimal.NewFromString(card.MonthlyMaintenanceFee)
+		s.notifyCard(card.UserID, "Card maintenance fee due",
+			fmt.Sprintf("The $%s monthly maintenance fee for your %s card will be charged on %s from your USD wallet, or from the card balance if the wallet is short.",
+				fee.StringFixed(2), card.CardName, card.NextBillingDate.Time.Format("2 Jan 2006")))
+	}
+	return int32(len(cards))
+}
+
+// isMaintenanceUnload reports whether an unload reference belongs to a
+// maintenance fee charge.
+func isMaintenanceUnload(ref string) bool {
+	return strings.HasPrefix(ref, maintenanceRefPrefix+"-")
+}
+
+// settleCardMaintenanceUnload completes or fails a card-balance maintenance
+// charge from its unload webhook.
+func (s *Service) settleCardMaintenanceUnload(ctx context.Context, ref, failure string) error {
+	entry, err := s.store.GetCardBillingByProviderReference(ctx, sql.NullString{String: ref, Valid: true})
+	if err != nil {
+		return fmt.Errorf("get maintenance charge %s: %w", ref, err)
+	}
+	if entry.Status != string(CardBillingHistoryStatusPending) {
+		return nil
+	}
+	card, err := s.store.GetVirtualCard(ctx, entry.CardID)
+	if err != nil {
+		return fmt.Errorf("get card %s: %w", entry.CardID, err)
+	}
+	now := time.Now()
+
+	if failure != "" {
+		// Retried by the next run, which also applies the grace period.
+		if err := s.store.FailMaintenanceBilling(ctx, db.FailMaintenanceBillingParams{
+			ID:            entry.ID,
+			FailureReason: sql.NullString{String: "card unload failed: " + failure, Valid: true},
+			NextRetryAt:   sql.NullTime{Time: now, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("record unload failure: %w", err)
+		}
+		return nil
+	}
+
+	dbTx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelDefault})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+	if err := s.settleMaintenanceCharge(ctx, s.store.WithTx(dbTx), card, entry, uuid.NullUUID{}, "card", now); err != nil {
+		return err
+	}
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	s.notifyCard(card.UserID, "Card maintenance fee charged",
+		fmt.Sprintf("$%s was taken from your %s card balance for its monthly maintenance.", entry.Amount, card.CardName))
+	return nil
+}
+
+// ── Billing reports ───────────────────────────────────────────────────────────
+
+// GetBillingRunReport returns a run's totals and the charges it attempted.
+func (s *Service) GetBillingRunReport(ctx context.Context, runID uuid.UUID) (*BillingRunReport, error) {
+	run, err := s.store.GetCardBillingRun(ctx, runID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrBillingRunNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get billing run: %w", err)
+	}
+	rows, err := s.store.ListCardBillingRunEntries(ctx, uuid.NullUUID{UUID: runID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list billing run entries: %w", err)
+	}
+
+	entries := make([]BillingRunEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := BillingRunEntry{
+			BillingID: row.ID, CardID: row.CardID, UserID: row.UserID,
+			CardName: row.CardName, CardStatus: row.CardStatus, PlanName: row.PlanName,
+			Amount: row.Amount, Currency: row.Currency,
+			BillingPeriodStart: row.BillingPeriodStart, BillingPeriodEnd: row.BillingPeriodEnd,
+			Status: row.Status, AttemptCount: row.AttemptCount,
+		}
+		if row.FailureReason.Valid {
+			entry.FailureReason = &row.FailureReason.String
+		}
+		if row.ChargeSource.Valid {
+			entry.ChargeSource = &row.ChargeSource.String
+		}
+		if row.NextRetryAt.Valid {
+			entry.NextRetryAt = &row.NextRetryAt.Time
+		}
+		if row.ProcessedAt.Valid {
+			entry.ProcessedAt = &row.ProcessedAt.Time
+		}
+		entries = append(entries, entry)
+	}
+	return &BillingRunReport{Run: billingRunResponse(run), Entries: entries}, nil
+}
+
+// ListBillingRuns returns runs newest first.
+func (s *Service) ListBillingRuns(ctx context.Context, limit, offset int32) ([]BillingRunResponse, error) {
+	runs, err := s.store.ListCardBillingRuns(ctx, db.ListCardBillingRunsParams{Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, fmt.Errorf("list billing runs: %w", err)
+	}
+	out := make([]BillingRunResponse, 0, len(runs))
+	for _, run := range runs {
+		out = append(out, billingRunResponse(run))
+	}
+	return out, nil
+}
+
+func billingRunResponse(run db.CardBillingRun) BillingRunResponse {
+	resp := BillingRunResponse{
+		ID: run.ID, TriggerType: run.TriggerType, Status: run.Status,
+		CardsDue: run.CardsDue, ChargedCount: run.ChargedCount, ChargedAmount: run.ChargedAmount,
+		PendingCount: run.PendingCount, FailedCount: run.FailedCount, FrozenCount: run.FrozenCount,
+		WaivedCount: run.WaivedCount, SkippedCount: run.SkippedCount, RemindersSent: run.RemindersSent,
+		StartedAt: run.StartedAt,
+	}
+	if run.TriggeredBy.Valid {
+		resp.TriggeredBy = &run.TriggeredBy.UUID
+	}
+	if run.Error.Valid {
+		resp.Error = &run.Error.String
+	}
+	if run.FinishedAt.Valid {
+		resp.FinishedAt = &run.FinishedAt.Time
+	}
+	return resp
+}
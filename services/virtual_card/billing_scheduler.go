@@ -0,0 +1,86 @@
+package virtualcard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/tasks"
+)
+
+const TaskCardMaintenanceBilling = "card_maintenance_billing"
+
+// BillingScheduler runs the monthly maintenance billing job. The job runs
+// daily: cards are charged on their own billing date, and failed charges are
+// retried and reminders sent on the following days.
+type BillingScheduler struct {
+	taskScheduler *tasks.TaskScheduler
+	cards         *Service
+	logger        *logging.Logger
+	checkInterval time.Duration
+}
+
+func NewBillingScheduler(
+	taskScheduler *tasks.TaskScheduler,
+	cards *Service,
+	logger *logging.Logger,
+	checkInterval time.Duration,
+) *BillingScheduler {
+	if checkInterval == 0 {
+		checkInterval = 24 * time.Hour
+	}
+
+	return &BillingScheduler{
+		taskScheduler: taskScheduler,
+		cards:         cards,
+		logger:        logger,
+		checkInterval: checkInterval,
+	}
+}
+
+func (s *BillingScheduler) Start() error {
+	s.logger.Info("Starting card billing scheduler...")
+
+	_, err := s.taskScheduler.AddTask(
+		TaskCardMaintenanceBilling,
+		"Card Monthly Maintenance Billing",
+		s.processMaintenanceBilling,
+		s.checkInterval,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add card maintenance billing task: %w", err)
+	}
+
+	if err := s.taskScheduler.ScheduleTask(TaskCardMaintenanceBilling, 5*time.Second); err != nil {
+		return fmt.Errorf("failed to schedule task %s: %w", TaskCardMaintenanceBilling, err)
+	}
+
+	s.logger.Info("Card billing scheduler started successfully")
+	return nil
+}
+
+func (s *BillingScheduler) processMaintenanceBilling(ctx context.Context) error {
+	s.logger.Info("Processing card maintenance billing...")
+
+	report, err := s.cards.RunMaintenanceBilling(ctx, BillingRunScheduled, nil)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Card maintenance billing failed: %v", err))
+		return err
+	}
+
+	s.logger.Info(fmt.Sprintf("Card maintenance billing run %s completed: %d due, %d charged, %d failed, %d frozen",
+		report.Run.ID, report.Run.CardsDue, report.Run.ChargedCount, report.Run.FailedCount, report.Run.FrozenCount))
+	return nil
+}
+
+func (s *BillingScheduler) Stop() error {
+	s.logger.Info("Stopping card billing scheduler...")
+
+	if err := s.taskScheduler.StopTask(TaskCardMaintenanceBilling); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to stop task %s: %v", TaskCardMaintenanceBilling, err))
+	}
+
+	s.logger.Info("Card billing scheduler stopped")
+	return nil
+}
@@ -0,0 +1,401 @@
+package virtualcard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Merchant category groups a cardholder can block.
+const (
+	CategoryBetting = "betting"
+	CategoryAdult   = "adult"
+	CategoryCrypto  = "crypto"
+)
+
+// categoryMCCs maps each blockable group to the merchant category codes the
+// card networks file those merchants under.
+var categoryMCCs = map[string][]string{
+	CategoryBetting: {"7800", "7801", "7802", "7995", "9406"},
+	CategoryAdult:   {"5967", "7273"},
+	CategoryCrypto:  {"6051"},
+}
+
+// atmMCCs are the cash disbursement codes (manual and automated).
+var atmMCCs = []string{"6010", "6011"}
+
+// CardChannel is where a card transaction took place.
+type CardChannel string
+
+const (
+	CardChannelOnline CardChannel = "online"
+	CardChannelATM    CardChannel = "atm"
+	CardChannelPOS    CardChannel = "pos"
+)
+
+// SpendingControlsRequest replaces a card's spending controls. Nil limits
+// fall back to the card plan's.
+type SpendingControlsRequest struct {
+	BlockedCategories []string `json:"blocked_categories"`
+	OnlineOnly        bool     `json:"online_only"`
+	AtmEnabled        *bool    `json:"atm_enabled"`
+	TransactionLimit  *string  `json:"transaction_limit"`
+	DailyLimit        *string  `json:"daily_limit"`
+	MonthlyLimit      *string  `json:"monthly_limit"`
+}
+
+// SpendingControlsResponse is a card's controls alongside the plan limits
+// they sit under.
+type SpendingControlsResponse struct {
+	CardID               uuid.UUID  `json:"card_id"`
+	BlockedCategories    []string   `json:"blocked_categories"`
+	OnlineOnly           bool       `json:"online_only"`
+	AtmEnabled           bool       `json:"atm_enabled"`
+	TransactionLimit     *string    `json:"transaction_limit"`
+	DailyLimit           *string    `json:"daily_limit"`
+	MonthlyLimit         *string    `json:"monthly_limit"`
+	PlanTransactionLimit string     `json:"plan_transaction_limit"`
+	PlanDailyLimit       *string    `json:"plan_daily_limit"`
+	PlanMonthlyLimit     string     `json:"plan_monthly_limit"`
+	ConsecutiveDeclines  int32      `json:"consecutive_declines"`
+	DeclinesBeforeBlock  *int32     `json:"declines_before_block"`
+	LastViolationReason  *string    `json:"last_violation_reason"`
+	LastViolationAt      *time.Time `json:"last_violation_at"`
+	AvailableCategories  []string   `json:"available_categories"`
+	UsageType            string     `json:"usage_type"`
+	LockedMerchant       *string    `json:"locked_merchant"`
+}
+
+// merchantCategory returns the blockable group an MCC belongs to, if any.
+func merchantCategory(mcc string) string {
+	for category, codes := range categoryMCCs {
+		if slices.Contains(codes, mcc) {
+			return category
+		}
+	}
+	return ""
+}
+
+// transactionChannel infers the channel from the MCC and Bridgecard's
+// transaction type. Anything not identifiably ATM or in-store is online.
+func transactionChannel(mcc, txType string) CardChannel {
+	t := strings.ToUpper(txType)
+	switch {
+	case slices.Contains(atmMCCs, mcc) || strings.Contains(t, "ATM"):
+		return CardChannelATM
+	case strings.Contains(t, "POS"):
+		return CardChannelPOS
+	default:
+		return CardChannelOnline
+	}
+}
+
+func blockableCategories() []string {
+	categories := make([]string, 0, len(categoryMCCs))
+	for category := range categoryMCCs {
+		categories = append(categories, category)
+	}
+	slices.Sort(categories)
+	return categories
+}
+
+// ── Spending controls ─────────────────────────────────────────────────────────
+
+// GetSpendingControls returns the card's controls. Cards that never set any
+// report the defaults: nothing blocked, all channels on, plan limits only.
+func (s *Service) GetSpendingControls(ctx context.Context, cardID string, userID uuid.UUID) (*SpendingControlsResponse, error) {
+	card, err := s.store.GetVirtualCardByBridgeCardID(ctx, cardID)
+	if err != nil {
+		return nil, ErrCardNotFound
+	}
+	if card.UserID != userID {
+		return nil, ErrCardNotOwned
+	}
+	plan, err := s.store.GetCardPlan(ctx, card.CardPlanID)
+	if err != nil {
+		return nil, fmt.Errorf("get card plan: %w", err)
+	}
+	controls, err := s.store.GetCardSpendingControls(ctx, card.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("get spending controls: %w", err)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		controls = db.CardSpendingControl{CardID: card.ID, UserID: card.UserID, AtmEnabled: true}
+	}
+	resp := spendingControlsResponse(controls, plan)
+	if err := s.applyCardUsage(ctx, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateSpendingControls replaces the card's controls. Custom limits may only
+// tighten the card plan's limits.
+func (s *Service) UpdateSpendingControls(ctx context.Context, cardID string, userID uuid.UUID, req SpendingControlsRequest) (*SpendingControlsResponse, error) {
+	card, err := s.store.GetVirtualCardByBridgeCardID(ctx, cardID)
+	if err != nil {
+		return nil, ErrCardNotFound
+	}
+	if card.UserID != userID {
+		return nil, ErrCardNotOwned
+	}
+	if VirtualCardStatus(card.Status) == VirtualCardStatusTerminated {
+		return nil, ErrCardAlreadyTerminated
+	}
+	plan, err := s.store.GetCardPlan(ctx, card.CardPlanID)
+	if err != nil {
+		return nil, fmt.Errorf("get card plan: %w", err)
+	}
+
+	blocked := make([]string, 0, len(req.BlockedCategories))
+	for _, category := range req.BlockedCategories {
+		category = strings.ToLower(strings.TrimSpace(category))
+		if _, ok := categoryMCCs[category]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCategory, category)
+		}
+		if !slices.Contains(blocked, category) {
+			blocked = append(blocked, category)
+		}
+	}
+
+	txLimit, err := controlLimit(req.TransactionLimit, sql.NullString{String: plan.TransactionLimit, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	dailyLimit, err := controlLimit(req.DailyLimit, plan.DailySpendingLimit)
+	if err != nil {
+		return nil, err
+	}
+	monthlyLimit, err := controlLimit(req.MonthlyLimit, sql.NullString{String: plan.MonthlySpendingLimit, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	atmEnabled := true
+	if req.AtmEnabled != nil {
+		atmEnabled = *req.AtmEnabled
+	}
+
+	controls, err := s.store.UpsertCardSpendingControls(ctx, db.UpsertCardSpendingControlsParams{
+		CardID:            card.ID,
+		UserID:            card.UserID,
+		BlockedCategories: blocked,
+		OnlineOnly:        req.OnlineOnly,
+		AtmEnabled:        atmEnabled,
+		TransactionLimit:  txLimit,
+		DailyLimit:        dailyLimit,
+		MonthlyLimit:      monthlyLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("save spending controls: %w", err)
+	}
+	resp := spendingControlsResponse(controls, plan)
+	if err := s.applyCardUsage(ctx, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// controlLimit validates a custom limit against the plan limit it tightens.
+// A plan limit of zero or less means the plan does not cap it.
+func controlLimit(value *string, planLimit sql.NullString) (sql.NullString, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return sql.NullString{}, nil
+	}
+	limit, err := decimal.NewFromString(strings.TrimSpace(*value))
+	if err != nil || !limit.IsPositive() {
+		return sql.NullString{}, ErrInvalidControlLimit
+	}
+	if planLimit.Valid {
+		if planCap, err := decimal.NewFromString(planLimit.String); err == nil && planCap.IsPositive() && limit.GreaterThan(planCap) {
+			return sql.NullString{}, fmt.Errorf("%w (%s)", ErrControlAbovePlanLimit, planCap.String())
+		}
+	}
+	return sql.NullString{String: limit.String(), Valid: true}, nil
+}
+
+func spendingControlsResponse(controls db.CardSpendingControl, plan db.CardPlan) *SpendingControlsResponse {
+	resp := &SpendingControlsResponse{
+		CardID:               controls.CardID,
+		BlockedCategories:    controls.BlockedCategories,
+		OnlineOnly:           controls.OnlineOnly,
+		AtmEnabled:           controls.AtmEnabled,
+		PlanTransactionLimit: plan.TransactionLimit,
+		PlanMonthlyLimit:     plan.MonthlySpendingLimit,
+		ConsecutiveDeclines:  controls.ConsecutiveDeclines,
+		AvailableCategories:  blockableCategories(),
+	}
+	if resp.BlockedCategories == nil {
+		resp.BlockedCategories = []string{}
+	}
+	if controls.TransactionLimit.Valid {
+		resp.TransactionLimit = &controls.TransactionLimit.String
+	}
+	if controls.DailyLimit.Valid {
+		resp.DailyLimit = &controls.DailyLimit.String
+	}
+	if controls.MonthlyLimit.Valid {
+		resp.MonthlyLimit = &controls.MonthlyLimit.String
+	}
+	if plan.DailySpendingLimit.Valid {
+		resp.PlanDailyLimit = &plan.DailySpendingLimit.String
+	}
+	if plan.FailedTxCountBeforeBlock.Valid && plan.FailedTxCountBeforeBlock.Int32 > 0 {
+		resp.DeclinesBeforeBlock = &plan.FailedTxCountBeforeBlock.Int32
+	}
+	if controls.LastViolationReason.Valid {
+		resp.LastViolationReason = &controls.LastViolationReason.String
+	}
+	if controls.LastViolationAt.Valid {
+		resp.LastViolationAt = &controls.LastViolationAt.Time
+	}
+	return resp
+}
+
+// effectiveLimit is the tighter of the custom and plan limits, or zero when
+// neither applies.
+func effectiveLimit(custom, plan sql.NullString) decimal.Decimal {
+	var limit decimal.Decimal
+	for _, v := range []sql.NullString{custom, plan} {
+		if !v.Valid {
+			continue
+		}
+		d, err := decimal.NewFromString(v.String)
+		if err != nil || !d.IsPositive() {
+			continue
+		}
+		if limit.IsZero() || d.LessThan(limit) {
+			limit = d
+		}
+	}
+	return limit
+}
+
+// controlViolation checks a settled debit against the card's controls and
+// plan limits. card must carry the spend totals including this debit.
+// Returns an empty string when the debit is allowed.
+func controlViolation(card db.VirtualCard, plan db.CardPlan, controls db.CardSpendingControl, amount decimal.Decimal, mcc, txType string) string {
+	if category := merchantCategory(mcc); category != "" && slices.Contains(controls.BlockedCategories, category) {
+		return fmt.Sprintf("%s merchants are blocked on this card", category)
+	}
+
+	switch transactionChannel(mcc, txType) {
+	case CardChannelATM:
+		if !controls.AtmEnabled {
+			return "ATM withdrawals are turned off for this card"
+		}
+		if controls.OnlineOnly {
+			return "this card is restricted to online payments"
+		}
+	case CardChannelPOS:
+		if controls.OnlineOnly {
+			return "this card is restricted to online payments"
+		}
+	}
+
+	if limit := effectiveLimit(controls.TransactionLimit, sql.NullString{String: plan.TransactionLimit, Valid: true}); limit.IsPositive() && amount.GreaterThan(limit) {
+		return fmt.Sprintf("$%s exceeds the $%s per-transaction limit", amount.StringFixed(2), limit.StringFixed(2))
+	}
+	if limit := effectiveLimit(controls.DailyLimit, plan.DailySpendingLimit); limit.IsPositive() && decimal.NewFromInt(card.CurrentDaySpend.Int64).GreaterThan(limit) {
+		return fmt.Sprintf("today's spend exceeds the $%s daily limit", limit.StringFixed(2))
+	}
+	if limit := effectiveLimit(controls.MonthlyLimit, sql.NullString{String: plan.MonthlySpendingLimit, Valid: true}); limit.IsPositive() && decimal.NewFromInt(card.CurrentMonthSpend.Int64).GreaterThan(limit) {
+		return fmt.Sprintf("this month's spend exceeds the $%s monthly limit", limit.StringFixed(2))
+	}
+	return ""
+}
+
+// enforceSpendingControls runs after a debit is recorded. Bridgecard does not
+// let us decline at authorisation, so a violating debit has already settled;
+// the card is frozen to stop any further spend.
+func (s *Service) enforceSpendingControls(ctx context.Context, card db.VirtualCard, amount decimal.Decimal, mcc, txType string) {
+	if err := s.store.ResetCardDeclines(ctx, card.ID); err != nil {
+		s.logger.Warnf("reset declines for card %s: %v", card.ID, err)
+	}
+	if VirtualCardStatus(card.Status) != VirtualCardStatusActive {
+		return
+	}
+	plan, err := s.store.GetCardPlan(ctx, card.CardPlanID)
+	if err != nil {
+		s.logger.Errorf("spending controls: get plan %d for card %s: %v", card.CardPlanID, card.ID, err)
+		return
+	}
+	controls, err := s.store.GetCardSpendingControls(ctx, card.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		s.logger.Errorf("spending controls: get controls for card %s: %v", card.ID, err)
+		return
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		controls = db.CardSpendingControl{CardID: card.ID, UserID: card.UserID, AtmEnabled: true}
+	}
+
+	if reason := controlViolation(card, plan, controls, amount, mcc, txType); reason != "" {
+		s.freezeForViolation(ctx, card, reason)
+	}
+}
+
+// recordDecline counts a declined debit and freezes the card once the plan's
+// failed_tx_count_before_block is reached.
+func (s *Service) recordDecline(ctx context.Context, card db.VirtualCard) {
+	count, err := s.store.IncrementCardDeclines(ctx, db.IncrementCardDeclinesParams{
+		CardID: card.ID, UserID: card.UserID,
+	})
+	if err != nil {
+		s.logger.Errorf("count decline for card %s: %v", card.ID, err)
+		return
+	}
+	if VirtualCardStatus(card.Status) != VirtualCardStatusActive {
+		return
+	}
+	plan, err := s.store.GetCardPlan(ctx, card.CardPlanID)
+	if err != nil {
+		s.logger.Errorf("get plan %d for card %s: %v", card.CardPlanID, card.ID, err)
+		return
+	}
+	if !plan.FailedTxCountBeforeBlock.Valid || plan.FailedTxCountBeforeBlock.Int32 <= 0 {
+		return
+	}
+	if count >= plan.FailedTxCountBeforeBlock.Int32 {
+		s.freezeForViolation(ctx, card, fmt.Sprintf("%d declined transactions in a row", count))
+	}
+}
+
+// freezeForViolation freezes the card, records why and tells the cardholder.
+func (s *Service) freezeForViolation(ctx context.Context, card db.VirtualCard, reason string) {
+	if err := s.store.RecordCardControlViolation(ctx, db.RecordCardControlViolationParams{
+		CardID: card.ID, UserID: card.UserID,
+		LastViolationReason: sql.NullString{String: reason, Valid: true},
+	}); err != nil {
+		s.logger.Errorf("record violation for card %s: %v", card.ID, err)
+	}
+	if err := s.freezeWithReason(ctx, card, reason); err != nil {
+		s.logger.Errorf("freeze card %s after violation: %v", card.ID, err)
+		return
+	}
+	s.notifyCard(card.UserID, "Card frozen",
+		fmt.Sprintf("We froze your %s card because %s. Review your spending controls and unfreeze it when ready.", card.CardName, reason))
+	s.logger.Warnf("card %s (user=%s) frozen by spending controls: %s", card.ID, card.UserID, reason)
+}
+
+// freezeWithReason freezes the card with Bridgecard and locally, keeping the
+// reason on the card for support.
+func (s *Service) freezeWithReason(ctx context.Context, card db.VirtualCard, reason string) error {
+	if _, err := s.bridgeCard.FreezeCard(ctx, card.BridgecardCardID); err != nil {
+		return fmt.Errorf("BridgeCard freeze: %w", err)
+	}
+	if _, err := s.store.UpdateCardStatus(ctx, db.UpdateCardStatusParams{
+		ID: card.ID, Status: string(VirtualCardStatusFrozen),
+		StatusReason: sql.NullString{String: reason, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("update card status: %w", err)
+	}
+	return nil
+}
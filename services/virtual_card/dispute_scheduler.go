@@ -0,0 +1,84 @@
+package virtualcard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/tasks"
+)
+
+const TaskCardDisputeSLA = "card_dispute_sla"
+
+// DisputeScheduler periodically checks open card disputes against their
+// review and resolution deadlines and alerts admins about breaches.
+type DisputeScheduler struct {
+	taskScheduler *tasks.TaskScheduler
+	cards         *Service
+	logger        *logging.Logger
+	checkInterval time.Duration
+}
+
+func NewDisputeScheduler(
+	taskScheduler *tasks.TaskScheduler,
+	cards *Service,
+	logger *logging.Logger,
+	checkInterval time.Duration,
+) *DisputeScheduler {
+	if checkInterval == 0 {
+		checkInterval = time.Hour
+	}
+
+	return &DisputeScheduler{
+		taskScheduler: taskScheduler,
+		cards:         cards,
+		logger:        logger,
+		checkInterval: checkInterval,
+	}
+}
+
+func (s *DisputeScheduler) Start() error {
+	s.logger.Info("Starting card dispute scheduler...")
+
+	_, err := s.taskScheduler.AddTask(
+		TaskCardDisputeSLA,
+		"Card Dispute SLA Monitor",
+		s.processDisputeSLAs,
+		s.checkInterval,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add card dispute SLA task: %w", err)
+	}
+
+	if err := s.taskScheduler.ScheduleTask(TaskCardDisputeSLA, 5*time.Second); err != nil {
+		return fmt.Errorf("failed to schedule task %s: %w", TaskCardDisputeSLA, err)
+	}
+
+	s.logger.Info("Card dispute scheduler started successfully")
+	return nil
+}
+
+func (s *DisputeScheduler) processDisputeSLAs(ctx context.Context) error {
+	alerted, err := s.cards.ProcessDisputeSLAs(ctx)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Card dispute SLA check failed: %v", err))
+		return err
+	}
+
+	if alerted > 0 {
+		s.logger.Info(fmt.Sprintf("Card dispute SLA check raised %d breach alerts", alerted))
+	}
+	return nil
+}
+
+func (s *DisputeScheduler) Stop() error {
+	s.logger.Info("Stopping card dispute scheduler...")
+
+	if err := s.taskScheduler.StopTask(TaskCardDisputeSLA); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to stop task %s: %v", TaskCardDisputeSLA, err))
+	}
+
+	s.logger.Info("Card dispute scheduler stopped")
+	return nil
+}
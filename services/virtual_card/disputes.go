@@ -0,0 +1,799 @@
+package virtualcard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	chatsupport "github.com/SwiftFiat/SwiftFiat-Backend/services/chat_support"
+	"github.com/SwiftFiat/SwiftFiat-Backend/services/transaction"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DisputeReasonUnrecognised       = "unrecognised"
+	DisputeReasonDuplicate          = "duplicate"
+	DisputeReasonIncorrectAmount    = "incorrect_amount"
+	DisputeReasonNotReceived        = "not_received"
+	DisputeReasonCancelledRecurring = "cancelled_recurring"
+	DisputeReasonOther              = "other"
+
+	// MaxDisputeEvidence caps the files a cardholder can attach to one dispute.
+	MaxDisputeEvidence = 10
+
+	disputeWindow        = 120 * 24 * time.Hour
+	disputeReviewSLA     = 2 * 24 * time.Hour
+	disputeResolutionSLA = 45 * 24 * time.Hour
+
+	disputeTicketCategory = "card_dispute"
+	disputeAlertSource    = "card-disputes"
+)
+
+var disputeReasonLabels = map[string]string{
+	DisputeReasonUnrecognised:       "I don't recognise this payment",
+	DisputeReasonDuplicate:          "I was charged more than once",
+	DisputeReasonIncorrectAmount:    "I was charged the wrong amount",
+	DisputeReasonNotReceived:        "I didn't receive what I paid for",
+	DisputeReasonCancelledRecurring: "I was charged after cancelling",
+	DisputeReasonOther:              "Other",
+}
+
+type OpenDisputeRequest struct {
+	CardTransactionID string `json:"card_transaction_id" form:"card_transaction_id" binding:"required,uuid"`
+	Reason            string `json:"reason" form:"reason" binding:"required"`
+	Description       string `json:"description" form:"description" binding:"required,max=2000"`
+}
+
+// DisputeEvidenceFile is an uploaded file the API layer has already stored.
+type DisputeEvidenceFile struct {
+	URL      string
+	Name     string
+	Size     int64
+	MimeType string
+}
+
+type DisputeResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	CardTransactionID uuid.UUID  `json:"card_transaction_id"`
+	CardID            uuid.UUID  `json:"card_id"`
+	UserID            uuid.UUID  `json:"user_id"`
+	Reason            string     `json:"reason"`
+	Description       string     `json:"description"`
+	Amount            string     `json:"amount"`
+	Currency          string     `json:"currency"`
+	Status            string     `json:"status"`
+	TicketID          *int64     `json:"ticket_id"`
+	AssignedTo        *uuid.UUID `json:"assigned_to"`
+	ProviderReference *string    `json:"provider_reference"`
+	ReviewDueAt       time.Time  `json:"review_due_at"`
+	ResolutionDueAt   time.Time  `json:"resolution_due_at"`
+	CreditedAt        *time.Time `json:"credited_at"`
+	ReversalShortfall string     `json:"reversal_shortfall"`
+	ResolutionNote    *string    `json:"resolution_note"`
+	ResolvedAt        *time.Time `json:"resolved_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type DisputedTransaction struct {
+	ID              uuid.UUID `json:"id"`
+	MerchantName    *string   `json:"merchant_name"`
+	Currency        string    `json:"currency"`
+	TransactionDate time.Time `json:"transaction_date"`
+}
+
+type DisputeEvent struct {
+	FromStatus *string    `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	ActorID    *uuid.UUID `json:"actor_id"`
+	Note       *string    `json:"note"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// DisputeDetail is a dispute with its card debit, evidence and timeline.
+type DisputeDetail struct {
+	Dispute     DisputeResponse          `json:"dispute"`
+	Transaction DisputedTransaction      `json:"transaction"`
+	Evidence    []db.CardDisputeEvidence `json:"evidence"`
+	Events      []DisputeEvent           `json:"events"`
+}
+
+// DisputeQueueItem is a dispute as listed in the admin case queue.
+type DisputeQueueItem struct {
+	ID                uuid.UUID  `json:"id"`
+	CardTransactionID uuid.UUID  `json:"card_transaction_id"`
+	CardID            uuid.UUID  `json:"card_id"`
+	CardName          string     `json:"card_name"`
+	UserID            uuid.UUID  `json:"user_id"`
+	CustomerName      string     `json:"customer_name"`
+	CustomerEmail     string     `json:"customer_email"`
+	MerchantName      *string    `json:"merchant_name"`
+	TransactionDate   time.Time  `json:"transaction_date"`
+	Reason            string     `json:"reason"`
+	Amount            string     `json:"amount"`
+	Currency          string     `json:"currency"`
+	Status            string     `json:"status"`
+	TicketID          *int64     `json:"ticket_id"`
+	AssignedTo        *uuid.UUID `json:"assigned_to"`
+	ReviewDueAt       time.Time  `json:"review_due_at"`
+	ResolutionDueAt   time.Time  `json:"resolution_due_at"`
+	ReviewOverdue     bool       `json:"review_overdue"`
+	ResolutionOverdue bool       `json:"resolution_overdue"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// ── Cardholder disputes ───────────────────────────────────────────────────────
+
+// OpenDispute disputes a successful card debit. Bridgecard settles debits
+// before we see them, so the case is worked by support and the cardholder
+// is made whole from our side through a wallet credit.
+func (s *Service) OpenDispute(ctx context.Context, userID uuid.UUID, req OpenDisputeRequest) (*DisputeResponse, error) {
+	if _, ok := disputeReasonLabels[req.Reason]; !ok {
+		return nil, ErrInvalidDisputeReason
+	}
+
+	cardTxID, err := uuid.Parse(req.CardTransactionID)
+	if err != nil {
+		return nil, ErrCardTxNotFound
+	}
+	cardTx, err := s.store.GetCardTransaction(ctx, cardTxID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && cardTx.UserID != userID) {
+		return nil, ErrCardTxNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get card transaction: %w", err)
+	}
+	if !strings.EqualFold(cardTx.TransactionType, string(transaction.Debit)) || cardTx.Status != string(CardTransactionStatusSuccessful) {
+		return nil, ErrDisputeNotAllowed
+	}
+	if time.Since(cardTx.TransactionDate) > disputeWindow {
+		return nil, ErrDisputeWindowClosed
+	}
+	if _, err := s.store.GetCardDisputeByTransaction(ctx, cardTx.ID); err == nil {
+		return nil, ErrDisputeExists
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("check existing dispute: %w", err)
+	}
+
+	// card_transactions.amount is truncated to whole units, so the disputed
+	// amount comes from the debit's ledger transaction.
+	ledgerTx, err := s.store.GetTransactionByID(ctx, cardTx.TransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("get debit transaction: %w", err)
+	}
+	// Unloads to the wallet are card debits too, but not spend.
+	if ledgerTx.TransactionFlow != string(transaction.Outflow) {
+		return nil, ErrDisputeNotAllowed
+	}
+	amount, err := decimal.NewFromString(ledgerTx.AmountUsd)
+	if err != nil || !amount.IsPositive() {
+		return nil, ErrDisputeNotAllowed
+	}
+
+	now := time.Now()
+	dbTx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelDefault})
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+	qtx := s.store.WithTx(dbTx)
+
+	dispute, err := qtx.CreateCardDispute(ctx, db.CreateCardDisputeParams{
+		CardTransactionID: cardTx.ID, CardID: cardTx.CardID, UserID: userID,
+		Reason: req.Reason, Description: req.Description,
+		Amount: amount.String(), Currency: "USD",
+		ReviewDueAt:     now.Add(disputeReviewSLA),
+		ResolutionDueAt: now.Add(disputeResolutionSLA),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create dispute: %w", err)
+	}
+	if err := recordDisputeEvent(ctx, qtx, dispute.ID, "", CardDisputeStatusOpened, userID, ""); err != nil {
+		return nil, err
+	}
+	if err := dbTx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	merchant := "an unknown merchant"
+	if cardTx.MerchantName.Valid && cardTx.MerchantName.String != "" {
+		merchant = cardTx.MerchantName.String
+	}
+	s.openDisputeTicket(context.WithoutCancel(ctx), &dispute, fmt.Sprintf(
+		"Card dispute opened for $%s at %s on %s.\nReason: %s\n\n%s",
+		amount.StringFixed(2), merchant, cardTx.TransactionDate.Format("2 Jan 2006"),
+		disputeReasonLabels[req.Reason], req.Description))
+	s.notifyCard(userID, "Dispute opened",
+		fmt.Sprintf("We've opened a dispute for your $%s payment to %s. We'll review it by %s and keep you updated in your support chat.",
+			amount.StringFixed(2), merchant, dispute.ReviewDueAt.Format("2 Jan 2006")))
+
+	resp := disputeResponse(dispute)
+	return &resp, nil
+}
+
+// AddDisputeEvidence attaches an uploaded file to an open dispute.
+func (s *Service) AddDisputeEvidence(ctx context.Context, disputeID, userID uuid.UUID, file DisputeEvidenceFile) (*db.CardDisputeEvidence, error) {
+	dispute, err := s.store.GetCardDispute(ctx, disputeID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && dispute.UserID != userID) {
+		return nil, ErrDisputeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get dispute: %w", err)
+	}
+	if disputeClosed(dispute) {
+		return nil, ErrDisputeClosed
+	}
+	count, err := s.store.CountCardDisputeEvidence(ctx, disputeID)
+	if err != nil {
+		return nil, fmt.Errorf("count evidence: %w", err)
+	}
+	if count >= MaxDisputeEvidence {
+		return nil, ErrTooMuchEvidence
+	}
+
+	evidence, err := s.store.CreateCardDisputeEvidence(ctx, db.CreateCardDisputeEvidenceParams{
+		DisputeID: disputeID, UploadedBy: userID,
+		FileUrl: file.URL, FileName: file.Name,
+		FileSize: int32(file.Size), MimeType: file.MimeType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("save evidence: %w", err)
+	}
+	s.postDisputeMessage(ctx, dispute, userID, fmt.Sprintf("Evidence uploaded: %s (%s)", file.Name, file.URL))
+	return &evidence, nil
+}
+
+// ListUserDisputes returns a cardholder's disputes, newest first.
+func (s *Service) ListUserDisputes(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]DisputeResponse, error) {
+	disputes, err := s.store.ListUserCardDisputes(ctx, db.ListUserCardDisputesParams{
+		UserID: userID, Limit: limit, Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list disputes: %w", err)
+	}
+	out := make([]DisputeResponse, 0, len(disputes))
+	for _, d := range disputes {
+		out = append(out, disputeResponse(d))
+	}
+	return out, nil
+}
+
+// GetDispute returns a dispute with its evidence and timeline. A nil userID
+// is an admin lookup; otherwise the dispute must belong to the user.
+func (s *Service) GetDispute(ctx context.Context, disputeID uuid.UUID, userID *uuid.UUID) (*DisputeDetail, error) {
+	dispute, err := s.store.GetCardDispute(ctx, disputeID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && userID != nil && dispute.UserID != *userID) {
+		return nil, ErrDisputeNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get dispute: %w", err)
+	}
+	cardTx, err := s.store.GetCardTransaction(ctx, dispute.CardTransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("get card transaction: %w", err)
+	}
+	evidence, err := s.store.ListCardDisputeEvidence(ctx, disputeID)
+	if err != nil {
+		return nil, fmt.Errorf("list evidence: %w", err)
+	}
+	rows, err := s.store.ListCardDisputeEvents(ctx, disputeID)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+
+	events := make([]DisputeEvent, 0, len(rows))
+	for _, row := range rows {
+		event := DisputeEvent{ToStatus: row.ToStatus, CreatedAt: row.CreatedAt}
+		if row.FromStatus.Valid {
+			event.FromStatus = &row.FromStatus.String
+		}
+		if row.ActorID.Valid {
+			event.ActorID = &row.ActorID.UUID
+		}
+		if row.Note.Valid {
+			event.Note = &row.Note.String
+		}
+		events = append(events, event)
+	}
+
+	detail := &DisputeDetail{
+		Dispute: disputeResponse(dispute),
+		Transaction: DisputedTransaction{
+			ID: cardTx.ID, Currency: cardTx.Currency, TransactionDate: cardTx.TransactionDate,
+		},
+		Evidence: evidence,
+		Events:   events,
+	}
+	if cardTx.MerchantName.Valid {
+		detail.Transaction.MerchantName = &cardTx.MerchantName.String
+	}
+	return detail, nil
+}
+
+// ── Admin case handling ───────────────────────────────────────────────────────
+
+// ListDisputeQueue returns the admin case queue: open cases first, ordered
+// by resolution deadline. An empty status lists every case.
+func (s *Service) ListDisputeQueue(ctx context.Context, status string, limit, offset int32) ([]DisputeQueueItem, error) {
+	rows, err := s.store.ListCardDisputeQueue(ctx, db.ListCardDisputeQueueParams{
+		Status: sql.NullString{String: status, Valid: status != ""},
+		Limit:  limit, Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list dispute queue: %w", err)
+	}
+
+	now := time.Now()
+	out := make([]DisputeQueueItem, 0, len(rows))
+	for _, row := range rows {
+		open := row.Status != string(CardDisputeStatusWon) && row.Status != string(CardDisputeStatusLost)
+		item := DisputeQueueItem{
+			ID: row.ID, CardTransactionID: row.CardTransactionID,
+			CardID: row.CardID, CardName: row.CardName,
+			UserID: row.UserID, CustomerEmail: row.Email,
+			CustomerName:    fmt.Sprintf("%s %s", row.FirstName.String, row.LastName.String),
+			TransactionDate: row.TransactionDate,
+			Reason:          row.Reason, Amount: row.Amount, Currency: row.Currency, Status: row.Status,
+			ReviewDueAt: row.ReviewDueAt, ResolutionDueAt: row.ResolutionDueAt,
+			ReviewOverdue:     row.Status == string(CardDisputeStatusOpened) && now.After(row.ReviewDueAt),
+			ResolutionOverdue: open && now.After(row.ResolutionDueAt),
+			CreatedAt:         row.CreatedAt,
+		}
+		if row.MerchantName.Valid {
+			item.MerchantName = &row.MerchantName.String
+		}
+		if row.TicketID.Valid {
+			item.TicketID = &row.TicketID.Int64
+		}
+		if row.AssignedTo.Valid {
+			item.AssignedTo = &row.AssignedTo.UUID
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// StartDisputeReview assigns an opened dispute to the reviewing admin.
+// providerRef records a case raised with the card issuer, if any.
+func (s *Service) StartDisputeReview(ctx context.Context, disputeID, adminID uuid.UUID, providerRef, note string) (*DisputeResponse, error) {
+	dbTx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelDefault})
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+	qtx := s.store.WithTx(dbTx)
+
+	dispute, err := lockDispute(ctx, qtx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if dispute.Status != string(CardDisputeStatusOpened) {
+		return nil, disputeTransitionError(dispute)
+	}
+	from := dispute.Status
+	dispute, err = qtx.StartCardDisputeReview(ctx, db.StartCardDisputeReviewParams{
+		ID:                disputeID,
+		AssignedTo:        uuid.NullUUID{UUID: adminID, Valid: true},
+		ProviderReference: sql.NullString{String: providerRef, Valid: providerRef != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("start review: %w", err)
+	}
+	if err := recordDisputeEvent(ctx, qtx, disputeID, from, CardDisputeStatusUnderReview, adminID, note); err != nil {
+		return nil, err
+	}
+	if err := dbTx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	s.postDisputeMessage(ctx, dispute, adminID, withNote("Your dispute is now under review by our team.", note))
+	s.notifyCard(dispute.UserID, "Dispute under review",
+		fmt.Sprintf("Our team is reviewing your $%s card dispute. We aim to resolve it by %s.",
+			disputeAmount(dispute), dispute.ResolutionDueAt.Format("2 Jan 2006")))
+
+	resp := disputeResponse(dispute)
+	return &resp, nil
+}
+
+// GrantProvisionalCredit credits the disputed amount to the cardholder's USD
+// wallet while the case is worked. The credit is reversed if the dispute is
+// lost and kept if it is won.
+func (s *Service) GrantProvisionalCredit(ctx context.Context, disputeID, adminID uuid.UUID, note string) (*DisputeResponse, error) {
+	dbTx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelDefault})
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+	qtx := s.store.WithTx(dbTx)
+
+	dispute, err := lockDispute(ctx, qtx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if dispute.Status != string(CardDisputeStatusOpened) && dispute.Status != string(CardDisputeStatusUnderReview) {
+		return nil, disputeTransitionError(dispute)
+	}
+	from := dispute.Status
+	if dispute, err = s.creditDispute(ctx, dbTx, qtx, dispute, CardDisputeStatusProvisionalCredit); err != nil {
+		return nil, err
+	}
+	if err := recordDisputeEvent(ctx, qtx, disputeID, from, CardDisputeStatusProvisionalCredit, adminID, note); err != nil {
+		return nil, err
+	}
+	if err := dbTx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	msg := fmt.Sprintf("We've credited $%s to your USD wallet while we investigate your dispute. If the dispute is decided against you, this credit will be taken back.",
+		disputeAmount(dispute))
+	s.postDisputeMessage(ctx, dispute, adminID, withNote(msg, note))
+	s.notifyCard(dispute.UserID, "Provisional credit issued", msg)
+
+	resp := disputeResponse(dispute)
+	return &resp, nil
+}
+
+// ResolveDispute closes a dispute as won or lost. A won dispute keeps or
+// receives the wallet credit; a lost one has any provisional credit reversed,
+// as far as the wallet balance allows.
+func (s *Service) ResolveDispute(ctx context.Context, disputeID, adminID uuid.UUID, outcome CardDisputeStatus, note string) (*DisputeResponse, error) {
+	if outcome != CardDisputeStatusWon && outcome != CardDisputeStatusLost {
+		return nil, ErrInvalidDisputeAction
+	}
+
+	dbTx, err := s.store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelDefault})
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+	qtx := s.store.WithTx(dbTx)
+
+	dispute, err := lockDispute(ctx, qtx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if disputeClosed(dispute) {
+		return nil, ErrDisputeClosed
+	}
+	from := dispute.Status
+	credited := dispute.CreditTransactionID.Valid
+
+	var reversalTx uuid.NullUUID
+	shortfall := decimal.Zero
+	switch {
+	case outcome == CardDisputeStatusWon && !credited:
+		if dispute, err = s.creditDispute(ctx, dbTx, qtx, dispute, outcome); err != nil {
+			return nil, err
+		}
+	case outcome == CardDisputeStatusLost && credited:
+		if reversalTx, shortfall, err = s.reverseDisputeCredit(ctx, dbTx, qtx, dispute); err != nil {
+			return nil, err
+		}
+	}
+
+	dispute, err = qtx.ResolveCardDispute(ctx, db.ResolveCardDisputeParams{
+		ID:                    disputeID,
+		Status:                string(outcome),
+		ResolutionNote:        sql.NullString{String: note, Valid: note != ""},
+		ResolvedBy:            uuid.NullUUID{UUID: adminID, Valid: true},
+		ReversalTransactionID: reversalTx,
+		ReversalShortfall:     shortfall.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resolve dispute: %w", err)
+	}
+	if err := recordDisputeEvent(ctx, qtx, disputeID, from, outcome, adminID, note); err != nil {
+		return nil, err
+	}
+	if err := dbTx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	amount := disputeAmount(dispute)
+	var title, msg string
+	switch {
+	case outcome == CardDisputeStatusWon && credited:
+		title, msg = "Dispute won", fmt.Sprintf("Your card dispute was decided in your favour. The $%s credit in your USD wallet is now final.", amount)
+	case outcome == CardDisputeStatusWon:
+		title, msg = "Dispute won", fmt.Sprintf("Your card dispute was decided in your favour. We've credited $%s to your USD wallet.", amount)
+	case credited:
+		title, msg = "Dispute closed", fmt.Sprintf("Your card dispute was not upheld, so the $%s provisional credit has been taken back from your USD wallet.", amount)
+	default:
+		title, msg = "Dispute closed", "Your card dispute was not upheld. Reply in your support chat if you have more information."
+	}
+	s.postDisputeMessage(ctx, dispute, adminID, withNote(msg, note))
+	s.notifyCard(dispute.UserID, title, msg)
+
+	if shortfall.IsPositive() && s.notifySvc != nil {
+		if _, err := s.notifySvc.CreateAdminAlert(context.WithoutCancel(ctx), "warning", "Dispute credit reversal short",
+			fmt.Sprintf("Dispute %s was lost but the user's wallet could only cover part of the provisional credit; $%s is outstanding.",
+				dispute.ID, shortfall.StringFixed(2)), disputeAlertSource); err != nil {
+			s.logger.Errorf("dispute %s: shortfall alert: %v", dispute.ID, err)
+		}
+	}
+	if s.tickets != nil && dispute.TicketID.Valid {
+		if _, err := s.tickets.ResolveTicket(context.WithoutCancel(ctx), dispute.TicketID.Int64); err != nil {
+			s.logger.Errorf("dispute %s: resolve ticket %d: %v", dispute.ID, dispute.TicketID.Int64, err)
+		}
+	}
+
+	resp := disputeResponse(dispute)
+	return &resp, nil
+}
+
+// ProcessDisputeSLAs alerts admins, once per breach, about disputes not
+// picked up for review or not resolved by their deadline.
+func (s *Service) ProcessDisputeSLAs(ctx context.Context) (int, error) {
+	review, err := s.store.ListCardDisputesBreachingReview(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list review breaches: %w", err)
+	}
+	resolution, err := s.store.ListCardDisputesBreachingResolution(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list resolution breaches: %w", err)
+	}
+
+	alerted := 0
+	for _, d := range review {
+		s.alertDisputeSLA(ctx, "warning", "Card dispute awaiting review",
+			fmt.Sprintf("Dispute %s for $%s was due for review on %s and has not been picked up.",
+				d.ID, disputeAmount(d), d.ReviewDueAt.Format(time.RFC1123)))
+		if err := s.store.MarkCardDisputeReviewBreachAlerted(ctx, d.ID); err != nil {
+			s.logger.Errorf("dispute %s: mark review breach: %v", d.ID, err)
+			continue
+		}
+		alerted++
+	}
+	for _, d := range resolution {
+		s.alertDisputeSLA(ctx, "error", "Card dispute past resolution deadline",
+			fmt.Sprintf("Dispute %s for $%s (%s) was due to be resolved on %s.",
+				d.ID, disputeAmount(d), d.Status, d.ResolutionDueAt.Format(time.RFC1123)))
+		if err := s.store.MarkCardDisputeResolutionBreachAlerted(ctx, d.ID); err != nil {
+			s.logger.Errorf("dispute %s: mark resolution breach: %v", d.ID, err)
+			continue
+		}
+		alerted++
+	}
+	return alerted, nil
+}
+
+// ── Dispute ledger postings ───────────────────────────────────────────────────
+
+// creditDispute pays the disputed amount into the user's USD wallet and
+// moves the dispute to status.
+func (s *Service) creditDispute(ctx context.Context, dbTx *sql.Tx, qtx *db.Queries, dispute db.CardDispute, status CardDisputeStatus) (db.CardDispute, error) {
+	usdWallet, err := qtx.GetWalletByCurrency(ctx, db.GetWalletByCurrencyParams{
+		CustomerID: dispute.UserID, Currency: "USD",
+	})
+	if err != nil {
+		return dispute, fmt.Errorf("get USD wallet: %w", err)
+	}
+	if _, err := s.walletService.GetWalletForUpdate(ctx, dbTx, usdWallet.ID); err != nil {
+		return dispute, fmt.Errorf("lock wallet: %w", err)
+	}
+
+	txx, err := qtx.CreateTransaction(ctx, db.CreateTransactionParams{
+		UserID: dispute.UserID, Type: string(transaction.Card),
+		Description: sql.NullString{String: "card-dispute-credit", Valid: true},
+		Amount:      dispute.Amount, Currency: "USD", AmountUsd: dispute.Amount,
+		Status: string(transaction.Success), TransactionFlow: string(transaction.Inflow),
+		IdempotencyKey: "card-dispute-credit-" + dispute.ID.String(),
+		TFrom:          "card_provider", TTo: string(transaction.Wallet), Direction: string(transaction.Credit),
+	})
+	if err != nil {
+		return dispute, fmt.Errorf("create credit tx: %w", err)
+	}
+	if _, err := qtx.IncrementWalletBalance(ctx, db.IncrementWalletBalanceParams{
+		Balance: sql.NullString{String: dispute.Amount, Valid: true}, ID: usdWallet.ID,
+	}); err != nil {
+		return dispute, fmt.Errorf("credit wallet: %w", err)
+	}
+	if _, err := qtx.InsertLedgerEntry(ctx, db.InsertLedgerEntryParams{
+		TransactionID:   uuid.NullUUID{UUID: txx.ID, Valid: true},
+		WalletID:        uuid.NullUUID{UUID: usdWallet.ID, Valid: true},
+		EntryType:       "credit",
+		Amount:          dispute.Amount,
+		SourceType:      string(transaction.OffPlatform),
+		DestinationType: string(transaction.OnPlatform),
+	}); err != nil {
+		return dispute, fmt.Errorf("create credit ledger entry: %w", err)
+	}
+
+	dispute, err = qtx.RecordCardDisputeCredit(ctx, db.RecordCardDisputeCreditParams{
+		ID: dispute.ID, Status: string(status),
+		CreditWalletID:      uuid.NullUUID{UUID: usdWallet.ID, Valid: true},
+		CreditTransactionID: uuid.NullUUID{UUID: txx.ID, Valid: true},
+	})
+	if err != nil {
+		return dispute, fmt.Errorf("record dispute credit: %w", err)
+	}
+	return dispute, nil
+}
+
+// reverseDisputeCredit takes a provisional credit back from the wallet it was
+// paid into. Wallets cannot go negative, so whatever the balance cannot
+// cover is returned as a shortfall for support to recover.
+func (s *Service) reverseDisputeCredit(ctx context.Context, dbTx *sql.Tx, qtx *db.Queries, dispute db.CardDispute) (uuid.NullUUID, decimal.Decimal, error) {
+	credited, err := decimal.NewFromString(dispute.Amount)
+	if err != nil {
+		return uuid.NullUUID{}, decimal.Zero, fmt.Errorf("parse dispute amount: %w", err)
+	}
+	if !dispute.CreditWalletID.Valid {
+		return uuid.NullUUID{}, credited, nil
+	}
+	walletID := dispute.CreditWalletID.UUID
+	wallet, err := s.walletService.GetWalletForUpdate(ctx, dbTx, walletID)
+	if err != nil {
+		return uuid.NullUUID{}, decimal.Zero, fmt.Errorf("lock wallet: %w", err)
+	}
+
+	debit := decimal.Min(credited, wallet.Balance)
+	shortfall := credited.Sub(debit)
+	if !debit.IsPositive() {
+		return uuid.NullUUID{}, shortfall, nil
+	}
+
+	txx, err := qtx.CreateTransaction(ctx, db.CreateTransactionParams{
+		UserID: dispute.UserID, Type: string(transaction.Card),
+		Description: sql.NullString{String: "card-dispute-reversal", Valid: true},
+		Amount:      debit.String(), Currency: "USD", AmountUsd: debit.String(),
+		Status: string(transaction.Success), TransactionFlow: string(transaction.Outflow),
+		IdempotencyKey: "card-dispute-reversal-" + dispute.ID.String(),
+		TFrom:          string(transaction.Wallet), TTo: "card_provider", Direction: string(transaction.Debit),
+	})
+	if err != nil {
+		return uuid.NullUUID{}, decimal.Zero, fmt.Errorf("create reversal tx: %w", err)
+	}
+	if _, err := qtx.DecrementWalletBalance(ctx, db.DecrementWalletBalanceParams{
+		Balance: sql.NullString{String: debit.String(), Valid: true}, ID: walletID,
+	}); err != nil {
+		return uuid.NullUUID{}, decimal.Zero, fmt.Errorf("debit wallet: %w", err)
+	}
+	if _, err := qtx.InsertLedgerEntry(ctx, db.InsertLedgerEntryParams{
+		TransactionID:   uuid.NullUUID{UUID: txx.ID, Valid: true},
+		WalletID:        uuid.NullUUID{UUID: walletID, Valid: true},
+		EntryType:       "debit",
+		Amount:          debit.String(),
+		SourceType:      string(transaction.OnPlatform),
+		DestinationType: string(transaction.OffPlatform),
+	}); err != nil {
+		return uuid.NullUUID{}, decimal.Zero, fmt.Errorf("create reversal ledger entry: %w", err)
+	}
+	return uuid.NullUUID{UUID: txx.ID, Valid: true}, shortfall, nil
+}
+
+// ── Dispute helpers ───────────────────────────────────────────────────────────
+
+// openDisputeTicket opens the support ticket the case is discussed in. A
+// dispute without a ticket still works, so failures are only logged.
+func (s *Service) openDisputeTicket(ctx context.Context, dispute *db.CardDispute, summary string) {
+	if s.tickets == nil {
+		return
+	}
+	ticket, err := s.tickets.CreateTicket(ctx, &chatsupport.CreateTicketParams{
+		UserID:           dispute.UserID,
+		EscalationReason: "user_request",
+		Priority:         "high",
+		Category:         disputeTicketCategory,
+	})
+	if err != nil {
+		s.logger.Errorf("dispute %s: create ticket: %v", dispute.ID, err)
+		return
+	}
+	dispute.TicketID = sql.NullInt64{Int64: ticket.ID, Valid: true}
+	if err := s.store.LinkCardDisputeTicket(ctx, db.LinkCardDisputeTicketParams{
+		ID: dispute.ID, TicketID: dispute.TicketID,
+	}); err != nil {
+		s.logger.Errorf("dispute %s: link ticket %d: %v", dispute.ID, ticket.ID, err)
+	}
+	s.postDisputeMessage(ctx, *dispute, dispute.UserID, summary)
+}
+
+// postDisputeMessage adds a system message to the dispute's ticket.
+func (s *Service) postDisputeMessage(ctx context.Context, dispute db.CardDispute, senderID uuid.UUID, text string) {
+	if !dispute.TicketID.Valid {
+		return
+	}
+	if _, err := s.store.CreateChatMessage(context.WithoutCancel(ctx), db.CreateChatMessageParams{
+		TicketID:    dispute.TicketID.Int64,
+		SenderID:    senderID,
+		SenderType:  "system",
+		MessageText: text,
+	}); err != nil {
+		s.logger.Errorf("dispute %s: post ticket message: %v", dispute.ID, err)
+	}
+}
+
+func (s *Service) alertDisputeSLA(ctx context.Context, severity, title, message string) {
+	if s.notifySvc == nil {
+		return
+	}
+	if _, err := s.notifySvc.CreateAdminAlert(ctx, severity, title, message, disputeAlertSource); err != nil {
+		s.logger.Errorf("dispute SLA alert: %v", err)
+	}
+}
+
+func lockDispute(ctx context.Context, qtx *db.Queries, disputeID uuid.UUID) (db.CardDispute, error) {
+	dispute, err := qtx.GetCardDisputeForUpdate(ctx, disputeID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dispute, ErrDisputeNotFound
+	}
+	if err != nil {
+		return dispute, fmt.Errorf("get dispute: %w", err)
+	}
+	return dispute, nil
+}
+
+func recordDisputeEvent(ctx context.Context, qtx *db.Queries, disputeID uuid.UUID, from string, to CardDisputeStatus, actorID uuid.UUID, note string) error {
+	if err := qtx.CreateCardDisputeEvent(ctx, db.CreateCardDisputeEventParams{
+		DisputeID:  disputeID,
+		FromStatus: sql.NullString{String: from, Valid: from != ""},
+		ToStatus:   string(to),
+		ActorID:    uuid.NullUUID{UUID: actorID, Valid: true},
+		Note:       sql.NullString{String: note, Valid: note != ""},
+	}); err != nil {
+		return fmt.Errorf("record dispute event: %w", err)
+	}
+	return nil
+}
+
+func disputeClosed(dispute db.CardDispute) bool {
+	return dispute.Status == string(CardDisputeStatusWon) || dispute.Status == string(CardDisputeStatusLost)
+}
+
+func disputeTransitionError(dispute db.CardDispute) error {
+	if disputeClosed(dispute) {
+		return ErrDisputeClosed
+	}
+	return ErrInvalidDisputeAction
+}
+
+func disputeAmount(dispute db.CardDispute) string {
+	amount, err := decimal.NewFromString(dispute.Amount)
+	if err != nil {
+		return dispute.Amount
+	}
+	return amount.StringFixed(2)
+}
+
+func withNote(msg, note string) string {
+	if note == "" {
+		return msg
+	}
+	return msg + "\n\nNote from support: " + note
+}
+
+func disputeResponse(d db.CardDispute) DisputeResponse {
+	resp := DisputeResponse{
+		ID: d.ID, CardTransactionID: d.CardTransactionID, CardID: d.CardID, UserID: d.UserID,
+		Reason: d.Reason, Description: d.Description,
+		Amount: d.Amount, Currency: d.Currency, Status: d.Status,
+		ReviewDueAt: d.ReviewDueAt, ResolutionDueAt: d.ResolutionDueAt,
+		ReversalShortfall: d.ReversalShortfall,
+		CreatedAt:         d.CreatedAt, UpdatedAt: d.UpdatedAt,
+	}
+	if d.TicketID.Valid {
+		resp.TicketID = &d.TicketID.Int64
+	}
+	if d.AssignedTo.Valid {
+		resp.AssignedTo = &d.AssignedTo.UUID
+	}
+	if d.ProviderReference.Valid {
+		resp.ProviderReference = &d.ProviderReference.String
+	}
+	if d.CreditedAt.Valid {
+		resp.CreditedAt = &d.CreditedAt.Time
+	}
+	if d.ResolutionNote.Valid {
+		resp.ResolutionNote = &d.ResolutionNote.String
+	}
+	if d.ResolvedAt.Valid {
+		resp.ResolvedAt = &d.ResolvedAt.Time
+	}
+	return resp
+}
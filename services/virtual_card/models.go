@@ -12,6 +12,20 @@ var (
 	ErrInvalidCardPlan       = fmt.Errorf("invalid card plan")
 	ErrCardAlreadyTerminated = fmt.Errorf("card already terminated")
 	ErrSpendingLimitExceeded = fmt.Errorf("spending limit exceeded")
+	ErrCardNotOwned          = fmt.Errorf("card does not belong to user")
+	ErrUnknownCategory       = fmt.Errorf("unknown merchant category")
+	ErrInvalidControlLimit   = fmt.Errorf("spending control limits must be greater than zero")
+	ErrControlAbovePlanLimit = fmt.Errorf("spending control limit is above the card plan limit")
+	ErrBillingRunNotFound    = fmt.Errorf("billing run not found")
+	ErrCardTxNotFound        = fmt.Errorf("card transaction not found")
+	ErrDisputeNotFound       = fmt.Errorf("dispute not found")
+	ErrDisputeExists         = fmt.Errorf("card transaction is already disputed")
+	ErrDisputeNotAllowed     = fmt.Errorf("only successful card debits can be disputed")
+	ErrDisputeWindowClosed   = fmt.Errorf("card transaction is too old to dispute")
+	ErrInvalidDisputeReason  = fmt.Errorf("invalid dispute reason")
+	ErrInvalidDisputeAction  = fmt.Errorf("dispute cannot move to that status")
+	ErrDisputeClosed         = fmt.Errorf("dispute is already resolved")
+	ErrTooMuchEvidence       = fmt.Errorf("dispute evidence limit reached")
 )
 
 // 'active', 'frozen', 'terminated', 'inactive'
@@ -63,3 +77,32 @@ const (
 	CardBillingHistoryStatusFailed     CardBillingHistoryStatus = "failed"
 	CardBillingHistoryStatusWaived     CardBillingHistoryStatus = "waived"
 )
+
+// 'opened', 'under_review', 'provisional_credit', 'won', 'lost'
+type CardDisputeStatus string
+
+const (
+	CardDisputeStatusOpened            CardDisputeStatus = "opened"
+	CardDisputeStatusUnderReview       CardDisputeStatus = "under_review"
+	CardDisputeStatusProvisionalCredit CardDisputeStatus = "provisional_credit"
+	CardDisputeStatusWon               CardDisputeStatus = "won"
+	CardDisputeStatusLost              CardDisputeStatus = "lost"
+)
+
+// 'standard', 'single_use', 'merchant_locked'
+type CardUsageType string
+
+const (
+	CardUsageStandard       CardUsageType = "standard"
+	CardUsageSingleUse      CardUsageType = "single_use"
+	CardUsageMerchantLocked CardUsageType = "merchant_locked"
+)
+
+// 'unloading', 'terminated', 'failed'
+type CardTerminationStatus string
+
+const (
+	CardTerminationUnloading  CardTerminationStatus = "unloading"
+	CardTerminationTerminated CardTerminationStatus = "terminated"
+	CardTerminationFailed     CardTerminationStatus = "failed"
+)
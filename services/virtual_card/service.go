@@ -11,6 +11,8 @@ import (
 
 	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
 	"github.com/SwiftFiat/SwiftFiat-Backend/providers/bridgecards"
+	chatsupport "github.com/SwiftFiat/SwiftFiat-Backend/services/chat_support"
+	fxrevenue "github.com/SwiftFiat/SwiftFiat-Backend/services/fx_revenue"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/monitoring/logging"
 	service "github.com/SwiftFiat/SwiftFiat-Backend/services/notification"
 	"github.com/SwiftFiat/SwiftFiat-Backend/services/streaks"
@@ -34,7 +36,10 @@ type Service struct {
 	email           *service.Plunk
 	pushSvc         *service.PushNotificationService
 	subscriptionSvc *subscriptions.Service
+	revenueSvc      *fxrevenue.Service
 	config          *utils.Config
+	autoSaver       transaction.AutoSaver
+	tickets         *chatsupport.TicketService
 }
 
 func NewService(
@@ -47,6 +52,7 @@ func NewService(
 	email *service.Plunk,
 	pushSvc *service.PushNotificationService,
 	subscriptionSvc *subscriptions.Service,
+	revenueSvc *fxrevenue.Service,
 	config *utils.Config,
 ) *Service {
 	return &Service{
@@ -59,10 +65,22 @@ func NewService(
 		email:           email,
 		pushSvc:         pushSvc,
 		subscriptionSvc: subscriptionSvc,
+		revenueSvc:      revenueSvc,
 		config:          config,
 	}
 }
 
+// SetAutoSaver wires vault auto-save rules into card spend handling.
+func (s *Service) SetAutoSaver(saver transaction.AutoSaver) {
+	s.autoSaver = saver
+}
+
+// SetTicketService links disputes to support tickets so the case
+// conversation lives in chat support.
+func (s *Service) SetTicketService(tickets *chatsupport.TicketService) {
+	s.tickets = tickets
+}
+
 // ── Helpers ───────────────────────────────────────────────────────────────────
 
 // decryptKycField decrypts a KYC string field, falling back to the raw value
@@ -96,6 +114,39 @@ func (s *Service) notifyCard(userID uuid.UUID, title, message string) {
 	}()
 }
 
+// recordFundingRevenue attributes the FX revenue of a completed card funding.
+// Funding debits the wallet one-for-one, so any revenue is the spread against
+// the market rate when the wallet is not in the card currency. Runs after
+// commit: a missing entry surfaces in revenue reconciliation.
+func (s *Service) recordFundingRevenue(ctx context.Context, funding db.CardFundingHistory) {
+	if s.revenueSvc == nil {
+		return
+	}
+	amount, err := decimal.NewFromString(funding.Amount)
+	if err != nil {
+		s.logger.Errorf("fx revenue: bad funding amount %q (funding=%s): %v", funding.Amount, funding.ID, err)
+		return
+	}
+	var txID *uuid.UUID
+	if funding.TransactionID != uuid.Nil {
+		txID = &funding.TransactionID
+	}
+	if err := s.revenueSvc.Record(ctx, nil, fxrevenue.Entry{
+		Channel:        fxrevenue.ChannelCardFunding,
+		ReferenceID:    funding.ID,
+		TransactionID:  txID,
+		UserID:         funding.UserID,
+		SourceCurrency: funding.SourceCurrency,
+		TargetCurrency: funding.Currency,
+		SourceAmount:   amount,
+		AppliedRate:    decimal.NewFromInt(1),
+		RateProvider:   "wallet",
+		OccurredAt:     funding.CreatedAt,
+	}); err != nil {
+		s.logger.Errorf("fx revenue: failed to record card funding %s: %v", funding.ID, err)
+	}
+}
+
 // billingPeriod returns [start, end] for the current calendar month.
 func billingPeriod(t time.Time) (time.Time, time.Time) {
 	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
@@ -352,6 +403,14 @@ func (s *Service) CreateCard(ctx context.Context, params *bridgecards.CreateCard
 	if err != nil {
 		return nil, fmt.Errorf("persist virtual card: %w", err)
 	}
+	// The plan's usage type is fixed on the card at issue.
+	if CardUsageType(plan.UsageType) != CardUsageStandard {
+		if _, err := qtx.CreateCardUsageLock(ctx, db.CreateCardUsageLockParams{
+			CardID: dbCard.ID, UserID: params.UserID, UsageType: plan.UsageType,
+		}); err != nil {
+			return nil, fmt.Errorf("create card usage lock: %w", err)
+		}
+	}
 
 	// 11. Card-level transaction records
 	creationCardTxMeta, err := qtx.CreateCardTransaction(ctx, db.CreateCardTransactionParams{
@@ -385,7 +444,7 @@ func (s *Service) CreateCard(ctx context.Context, params *bridgecards.CreateCard
 		CardID: dbCard.ID, UserID: params.UserID, CardPlanID: params.CardPlanID,
 		BillingType: "creation_fee", Amount: creationFee.String(), Currency: "USD",
 		BillingPeriodStart: billingStart, BillingPeriodEnd: billingEnd,
-		SourceWalletID: usdWallet.ID, Status: string(transaction.Pending),
+		SourceWalletID: uuid.NullUUID{UUID: usdWallet.ID, Valid: true}, Status: string(transaction.Pending),
 		TransactionID: cardCreationTx.ID,
 	})
 	if err != nil {
@@ -452,11 +511,18 @@ func (s *Service) CreateCard(ctx context.Context, params *bridgecards.CreateCard
 			bridgeCardDetails.Data.CardID, err)
 		return nil, fmt.Errorf("commit: %w", err)
 	}
+	s.recordFundingRevenue(ctx, fundingRecord)
 
 	s.logger.Infof("Virtual card %s created for user %d (plan=%s, funding=$%s)",
 		bridgeCardDetails.Data.CardID, params.UserID, plan.Name, params.FundingAmount)
-	s.notifyCard(params.UserID, "Virtual card created",
-		fmt.Sprintf("Your %s virtual card is ready and funded with $%s.", plan.Name, params.FundingAmount))
+	createdMsg := fmt.Sprintf("Your %s virtual card is ready and funded with $%s.", plan.Name, params.FundingAmount)
+	switch CardUsageType(plan.UsageType) {
+	case CardUsageSingleUse:
+		createdMsg += " It closes after its first payment, and anything left on it returns to your USD wallet."
+	case CardUsageMerchantLocked:
+		createdMsg += " Only the first merchant to charge it can keep charging it."
+	}
+	s.notifyCard(params.UserID, "Virtual card created", createdMsg)
 
 	return &bridgecards.CreateCardResponse{
 		Status: bridgeCardDetails.Status, Message: bridgeCardDetails.Message, Data: bridgeCardDetails.Data,
@@ -589,6 +655,7 @@ func (s *Service) FundCard(ctx context.Context, req bridgecards.FundCardRequest,
 	if err := dbTx.Commit(); err != nil {
 		return nil, fmt.Errorf("commit: %w", err)
 	}
+	s.recordFundingRevenue(ctx, fundingRecord)
 
 	s.notifyCard(userID, "Card funded",
 		fmt.Sprintf("$%s has been added to your virtual card.", fundingAmount.String()))
@@ -666,6 +733,9 @@ func (s *Service) UnfreezeCard(ctx context.Context, cardID string, userID uuid.U
 	}); err != nil {
 		return nil, fmt.Errorf("update card status: %w", err)
 	}
+	if err := s.store.ResetCardDeclines(ctx, card.ID); err != nil {
+		s.logger.Warnf("reset declines for card %s: %v", card.ID, err)
+	}
 	s.notifyCard(userID, "Card unfrozen", "Your virtual card is now active again.")
 	return resp, nil
 }
@@ -684,6 +754,9 @@ func (s *Service) AdminUnfreezeCard(ctx context.Context, cardID string, userID u
 	}); err != nil {
 		return nil, fmt.Errorf("update card status: %w", err)
 	}
+	if err := s.store.ResetCardDeclines(ctx, card.ID); err != nil {
+		s.logger.Warnf("reset declines for card %s: %v", card.ID, err)
+	}
 	go func() {
 		bgCtx := context.Background()
 		s.pushSvc.AdminUnfreezeCardNotification(bgCtx, card.UserID, card.CardName)
@@ -1048,12 +1121,13 @@ func (s *Service) handleCardDebitEventSuccess(ctx context.Context, success *brid
 		return "", fmt.Errorf("create card transaction: %w", err)
 	}
 
-	if _, err = qtx.UpdateCardSpending(ctx, db.UpdateCardSpendingParams{
+	spentCard, err := qtx.UpdateCardSpending(ctx, db.UpdateCardSpendingParams{
 		CurrentMonthSpend: sql.NullInt64{Int64: amount.IntPart(), Valid: true},
 		ID:                card.ID,
 		SpendingMonth:     sql.NullString{String: now.Format("2006-01"), Valid: true},
 		SpendingDay:       sql.NullString{String: now.Format("2006-01-02"), Valid: true},
-	}); err != nil {
+	})
+	if err != nil {
 		return "", fmt.Errorf("update card spending: %w", err)
 	}
 
@@ -1061,6 +1135,15 @@ func (s *Service) handleCardDebitEventSuccess(ctx context.Context, success *brid
 		return "", fmt.Errorf("commit: %w", err)
 	}
 
+	go func() {
+		bgCtx := context.Background()
+		if s.enforceCardUsage(bgCtx, spentCard, cardTx.ID, success.Data.Description) {
+			return
+		}
+		s.enforceSpendingControls(bgCtx, spentCard, amount,
+			success.Data.MerchantCategoryCode, success.Data.CardTransactionType)
+	}()
+
 	// Post-commit async work
 	if s.subscriptionSvc != nil {
 		subTx := db.CardTransaction{
@@ -1083,18 +1166,32 @@ func (s *Service) handleCardDebitEventSuccess(ctx context.Context, success *brid
 		s.logger.Warnf("streak update failed for user %d: %v", user.ID, err)
 	}
 
+	if s.autoSaver != nil {
+		movement := transaction.MoneyMovement{
+			UserID: card.UserID, TransactionID: txx.ID, Source: transaction.MovementCardDebit,
+			Amount: amount, Currency: success.Data.Currency,
+		}
+		go func() {
+			if err := s.autoSaver.OnMoneyMovement(context.Background(), movement); err != nil {
+				s.logger.Errorf("auto-save failed for card tx %s: %v", cardTx.BridgecardTransactionID, err)
+			}
+		}()
+	}
+
 	s.notifyCard(user.ID, "Card transaction",
 		fmt.Sprintf("$%s spent at %s.", amount.String(), merchantName))
 	return "card_debit_event.successful", nil
 }
 
-// handleCardDebitEventDeclined notifies the user of a declined transaction.
+// handleCardDebitEventDeclined notifies the user of a declined transaction
+// and counts it towards the plan's decline block.
 func (s *Service) handleCardDebitEventDeclined(ctx context.Context, declined *bridgecards.CardDebitEventDeclined) (string, error) {
 	card, err := s.store.GetVirtualCardByBridgeCardID(ctx, declined.Data.CardID)
 	if err != nil {
 		s.logger.Warnf("card_debit.declined: unknown card %s", declined.Data.CardID)
 		return "card_debit_event.declined", nil
 	}
+	s.recordDecline(ctx, card)
 	amountStr, _ := utils.CentsStringToDollarString(declined.Data.Amount)
 	merchant := declined.Data.Description
 	if merchant == "" {
@@ -1148,12 +1245,13 @@ func (s *Service) handleCardCreditSuccess(ctx context.Context, success *bridgeca
 		return "", fmt.Errorf("get card: %w", err)
 	}
 
-	if _, err = qtx.CreateCardFunding(ctx, db.CreateCardFundingParams{
+	fundingRecord, err := qtx.CreateCardFunding(ctx, db.CreateCardFundingParams{
 		CardID: card.ID, Amount: success.Amount, Currency: success.Currency,
 		SourceWalletID: usdWallet.ID, UserID: user.ID, SourceCurrency: usdWallet.Currency,
 		FundingType: "webhook", InitiatedBy: "system",
 		Status: string(CardFundingStatusSuccessful),
-	}); err != nil {
+	})
+	if err != nil {
 		return "", fmt.Errorf("create funding record: %w", err)
 	}
 
@@ -1185,6 +1283,7 @@ func (s *Service) handleCardCreditSuccess(ctx context.Context, success *bridgeca
 	if err := dbTx.Commit(); err != nil {
 		return "", fmt.Errorf("commit: %w", err)
 	}
+	s.recordFundingRevenue(ctx, fundingRecord)
 
 	if err := s.streak.UpdateStreakOnTransaction(ctx, user.ID, txx.ID, "card"); err != nil {
 		s.logger.Warnf("streak update failed for user %d: %v", user.ID, err)
@@ -1253,6 +1352,12 @@ func (s *Service) handleCardCreditFailed(ctx context.Context, failed *bridgecard
 
 // handleCardUnloadEventSuccess credits the wallet when funds are withdrawn from a card.
 func (s *Service) handleCardUnloadEventSuccess(ctx context.Context, success *bridgecards.CardWithDrawEventSuccessful) (string, error) {
+	if isMaintenanceUnload(success.Data.TransactionReference) {
+		if err := s.settleCardMaintenanceUnload(ctx, success.Data.TransactionReference, ""); err != nil {
+			return "", err
+		}
+		return "card_withdraw.success", nil
+	}
 	user, err := s.store.GetUserByBridgeCardCardholderID(ctx, sql.NullString{String: success.Data.CardholderID, Valid: true})
 	if err != nil {
 		return "", fmt.Errorf("get user: %w", err)
@@ -1321,6 +1426,13 @@ func (s *Service) handleCardUnloadEventSuccess(ctx context.Context, success *bri
 		return "", fmt.Errorf("commit: %w", err)
 	}
 
+	if isSingleUseUnload(success.Data.TransactionReference) {
+		if err := s.settleSingleUseUnload(ctx, success.Data.TransactionReference, amount, ""); err != nil {
+			return "", err
+		}
+		return "card_withdraw.success", nil
+	}
+
 	s.notifyCard(user.ID, "Card withdrawal successful",
 		fmt.Sprintf("$%s has been transferred from your virtual card to your USD wallet.", amount.String()))
 	return "card_withdraw.success", nil
@@ -1328,6 +1440,26 @@ func (s *Service) handleCardUnloadEventSuccess(ctx context.Context, success *bri
 
 // handleCardUnloadEventFailed notifies the user when a card withdrawal fails.
 func (s *Service) handleCardUnloadEventFailed(ctx context.Context, failed *bridgecards.CardWithDrawEventFailed) (string, error) {
+	if isMaintenanceUnload(failed.Data.TransactionReference) {
+		reason := failed.Data.Description
+		if reason == "" {
+			reason = "unload declined"
+		}
+		if err := s.settleCardMaintenanceUnload(ctx, failed.Data.TransactionReference, reason); err != nil {
+			return "", err
+		}
+		return "card_withdraw.failed", nil
+	}
+	if isSingleUseUnload(failed.Data.TransactionReference) {
+		reason := failed.Data.Description
+		if reason == "" {
+			reason = "unload declined"
+		}
+		if err := s.settleSingleUseUnload(ctx, failed.Data.TransactionReference, decimal.Zero, reason); err != nil {
+			return "", err
+		}
+		return "card_withdraw.failed", nil
+	}
 	user, err := s.store.GetUserByBridgeCardCardholderID(ctx, sql.NullString{String: failed.Data.CardholderID, Valid: true})
 	if err != nil {
 		s.logger.Warnf("card_unload.failed: unknown cardholder %s", failed.Data.CardholderID)
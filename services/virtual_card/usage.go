@@ -0,0 +1,317 @@
+package virtualcard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	db "github.com/SwiftFiat/SwiftFiat-Backend/db/sqlc"
+	"github.com/SwiftFiat/SwiftFiat-Backend/providers/bridgecards"
+	"github.com/SwiftFiat/SwiftFiat-Backend/utils"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// singleUseRefPrefix marks the unload that empties a used single-use card.
+const singleUseRefPrefix = "csu"
+
+// ── Single-use and merchant-locked cards ──────────────────────────────────────
+
+// enforceCardUsage applies the card's single-use or merchant lock after a
+// debit is recorded. Like spending controls, it cannot decline: Bridgecard has
+// already settled the debit. It reports whether the card was stopped.
+func (s *Service) enforceCardUsage(ctx context.Context, card db.VirtualCard, cardTxID uuid.UUID, descriptor string) bool {
+	lock, err := s.store.GetCardUsageLock(ctx, card.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false
+	}
+	if err != nil {
+		s.logger.Errorf("card usage: get lock for card %s: %v", card.ID, err)
+		return false
+	}
+
+	switch CardUsageType(lock.UsageType) {
+	case CardUsageSingleUse:
+		s.terminateSingleUseCard(ctx, card, cardTxID)
+		return true
+	case CardUsageMerchantLocked:
+		s.enforceMerchantLock(ctx, card, lock, cardTxID, descriptor)
+	}
+	return false
+}
+
+// enforceMerchantLock locks the card to the first merchant that charges it.
+// A charge from any other merchant has already settled, so it is disputed on
+// the cardholder's behalf; the card itself stays active for the locked merchant.
+func (s *Service) enforceMerchantLock(ctx context.Context, card db.VirtualCard, lock db.CardUsageLock, cardTxID uuid.UUID, descriptor string) {
+	key := s.merchantKey(ctx, descriptor)
+	if key == "" {
+		s.logger.Warnf("merchant lock: card %s debit has no usable merchant descriptor %q", card.ID, descriptor)
+		return
+	}
+
+	if !lock.LockedMerchant.Valid {
+		_, err := s.store.LockCardMerchant(ctx, db.LockCardMerchantParams{
+			CardID:                card.ID,
+			LockedMerchant:        sql.NullString{String: key, Valid: true},
+			LockedMerchantDisplay: sql.NullString{String: descriptor, Valid: true},
+		})
+		if err == nil {
+			s.notifyCard(card.UserID, "Card locked to merchant",
+				fmt.Sprintf("Your %s card is now locked to %s. Charges from any other merchant will be disputed.", card.CardName, descriptor))
+			return
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Errorf("merchant lock: lock card %s: %v", card.ID, err)
+			return
+		}
+		// Another debit locked the card first.
+		if lock, err = s.store.GetCardUsageLock(ctx, card.ID); err != nil {
+			s.logger.Errorf("merchant lock: reload lock for card %s: %v", card.ID, err)
+			return
+		}
+	}
+
+	if lock.LockedMerchant.String == key {
+		return
+	}
+	s.rejectLockedMerchantCharge(ctx, card, lock, cardTxID, descriptor)
+}
+
+// rejectLockedMerchantCharge records the violation and opens a dispute for the
+// one charge so it is returned to the cardholder. If the dispute cannot be
+// opened, support is alerted to handle the charge by hand.
+func (s *Service) rejectLockedMerchantCharge(ctx context.Context, card db.VirtualCard, lock db.CardUsageLock, cardTxID uuid.UUID, descriptor string) {
+	reason := fmt.Sprintf("it was charged by %s but is locked to %s", descriptor, lock.LockedMerchantDisplay.String)
+	if err := s.store.RecordCardControlViolation(ctx, db.RecordCardControlViolationParams{
+		CardID: card.ID, UserID: card.UserID,
+		LastViolationReason: sql.NullString{String: reason, Valid: true},
+	}); err != nil {
+		s.logger.Errorf("merchant lock: record violation for card %s: %v", card.ID, err)
+	}
+	s.logger.Warnf("merchant lock: card %s (user=%s) %s", card.ID, card.UserID, reason)
+
+	_, err := s.OpenDispute(ctx, card.UserID, OpenDisputeRequest{
+		CardTransactionID: cardTxID.String(),
+		Reason:            DisputeReasonOther,
+		Description: fmt.Sprintf("Charged by %s, but the card is locked to %s. Opened automatically by the merchant lock.",
+			descriptor, lock.LockedMerchantDisplay.String),
+	})
+	if err == nil {
+		return
+	}
+	s.logger.Errorf("merchant lock: dispute charge %s on card %s: %v", cardTxID, card.ID, err)
+	if s.notifySvc != nil {
+		if _, err := s.notifySvc.CreateAdminAlert(ctx, "error", "Merchant-locked card charged by another merchant",
+			fmt.Sprintf("Card %s is locked to %s but card transaction %s was charged by %s and could not be disputed automatically: %v.",
+				card.ID, lock.LockedMerchantDisplay.String, cardTxID, descriptor, err),
+			"card-usage"); err != nil {
+			s.logger.Errorf("merchant lock: admin alert for card %s: %v", card.ID, err)
+		}
+	}
+	s.notifyCard(card.UserID, "Charge from another merchant",
+		fmt.Sprintf("Your %s card is locked to %s, but %s charged it. Our team will review the charge; your card is still active.",
+			card.CardName, lock.LockedMerchantDisplay.String, descriptor))
+}
+
+// terminateSingleUseCard closes a single-use card after its first debit. The
+// card is frozen straight away; whatever is left on it is unloaded to the
+// wallet and the card is deleted once the unload webhook confirms it.
+func (s *Service) terminateSingleUseCard(ctx context.Context, card db.VirtualCard, cardTxID uuid.UUID) {
+	if _, err := s.store.ClaimSingleUseTermination(ctx, db.ClaimSingleUseTerminationParams{
+		CardID:                  card.ID,
+		UsedByCardTransactionID: uuid.NullUUID{UUID: cardTxID, Valid: true},
+	}); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Errorf("single-use: claim termination of card %s: %v", card.ID, err)
+		}
+		return
+	}
+
+	if VirtualCardStatus(card.Status) == VirtualCardStatusActive {
+		if err := s.freezeWithReason(ctx, card, "single-use card used"); err != nil {
+			s.logger.Warnf("single-use: freeze card %s: %v", card.ID, err)
+		}
+	}
+
+	balance, err := s.bridgeCard.GetCardBalance(ctx, card.BridgecardCardID)
+	if err != nil {
+		s.failCardTermination(ctx, card, fmt.Sprintf("get card balance: %v", err))
+		return
+	}
+	balanceCents, err := strconv.ParseInt(balance.Data.Balance, 10, 64)
+	if err != nil {
+		s.failCardTermination(ctx, card, fmt.Sprintf("parse card balance %q: %v", balance.Data.Balance, err))
+		return
+	}
+	if balanceCents <= 0 {
+		s.finishCardTermination(ctx, card, decimal.Zero)
+		return
+	}
+	amountStr, err := utils.CentsStringToDollarString(balance.Data.Balance)
+	if err != nil {
+		s.failCardTermination(ctx, card, fmt.Sprintf("card balance to dollars: %v", err))
+		return
+	}
+
+	// Record the reference first so the webhook can never arrive for an
+	// unload we do not know about.
+	ref := utils.NewTxRef(singleUseRefPrefix)
+	if err := s.store.RecordCardUsageUnload(ctx, db.RecordCardUsageUnloadParams{
+		CardID:          card.ID,
+		UnloadReference: sql.NullString{String: ref, Valid: true},
+		UnloadAmount:    sql.NullString{String: amountStr, Valid: true},
+	}); err != nil {
+		s.failCardTermination(ctx, card, fmt.Sprintf("record unload: %v", err))
+		return
+	}
+	if _, err := s.bridgeCard.WithdrawCard(ctx, bridgecards.WithdrawCardRequest{
+		CardID: card.BridgecardCardID, Amount: balance.Data.Balance,
+		TransactionReference: ref, Currency: "USD",
+	}); err != nil {
+		// A timeout or bad response does not mean the unload was refused. The
+		// lock stays unloading until the webhook settles or fails it; failing it
+		// here would hand support a card whose balance already left.
+		s.logger.Warnf("single-use: unload %s for card %s unconfirmed, awaiting webhook: %v", ref, card.ID, err)
+	}
+}
+
+// finishCardTermination deletes a used single-use card once its balance is
+// back in the wallet.
+func (s *Service) finishCardTermination(ctx context.Context, card db.VirtualCard, returned decimal.Decimal) {
+	if _, err := s.bridgeCard.DeleteCard(ctx, card.BridgecardCardID); err != nil {
+		s.failCardTermination(ctx, card, fmt.Sprintf("BridgeCard delete: %v", err))
+		return
+	}
+	if _, err := s.store.TerminateCard(ctx, db.TerminateCardParams{ID: card.ID, UserID: card.UserID}); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		s.logger.Errorf("single-use: terminate card %s in DB: %v", card.ID, err)
+	}
+	if err := s.store.CompleteCardUsageTermination(ctx, card.ID); err != nil {
+		s.logger.Errorf("single-use: complete termination of card %s: %v", card.ID, err)
+	}
+
+	msg := fmt.Sprintf("Your single-use %s card has been used and is now closed.", card.CardName)
+	if returned.IsPositive() {
+		msg += fmt.Sprintf(" The $%s left on it was returned to your USD wallet.", returned.StringFixed(2))
+	}
+	s.notifyCard(card.UserID, "Single-use card closed", msg)
+}
+
+// failCardTermination leaves the card frozen and hands it to support, who
+// recover any balance by hand.
+func (s *Service) failCardTermination(ctx context.Context, card db.VirtualCard, reason string) {
+	s.logger.Errorf("single-use: terminate card %s (user=%s): %s", card.ID, card.UserID, reason)
+	if err := s.store.FailCardUsageTermination(ctx, db.FailCardUsageTerminationParams{
+		CardID: card.ID, FailureReason: sql.NullString{String: reason, Valid: true},
+	}); err != nil {
+		s.logger.Errorf("single-use: record failed termination of card %s: %v", card.ID, err)
+	}
+	if s.notifySvc != nil {
+		if _, err := s.notifySvc.CreateAdminAlert(ctx, "error", "Single-use card not closed",
+			fmt.Sprintf("Single-use card %s was used but could not be closed automatically: %s. It is frozen; unload any balance and delete it manually.", card.ID, reason),
+			"card-usage"); err != nil {
+			s.logger.Errorf("single-use: admin alert for card %s: %v", card.ID, err)
+		}
+	}
+	s.notifyCard(card.UserID, "Single-use card frozen",
+		fmt.Sprintf("Your single-use %s card has been used. We froze it so it can't be charged again, and our team will return any balance left on it to your USD wallet.", card.CardName))
+}
+
+func isSingleUseUnload(ref string) bool {
+	return strings.HasPrefix(ref, singleUseRefPrefix+"-")
+}
+
+// settleSingleUseUnload finishes or fails a single-use termination from its
+// unload webhook.
+func (s *Service) settleSingleUseUnload(ctx context.Context, ref string, returned decimal.Decimal, failure string) error {
+	lock, err := s.store.GetCardUsageLockByUnloadReference(ctx, sql.NullString{String: ref, Valid: true})
+	if err != nil {
+		return fmt.Errorf("get single-use termination %s: %w", ref, err)
+	}
+	if CardTerminationStatus(lock.TerminationStatus.String) != CardTerminationUnloading {
+		return nil
+	}
+	card, err := s.store.GetVirtualCard(ctx, lock.CardID)
+	if err != nil {
+		return fmt.Errorf("get card %s: %w", lock.CardID, err)
+	}
+	if failure != "" {
+		s.failCardTermination(ctx, card, "card unload failed: "+failure)
+		return nil
+	}
+	s.finishCardTermination(ctx, card, returned)
+	return nil
+}
+
+// merchantKey identifies the merchant a card statement descriptor names.
+// Merchants in the subscription merchant directory match by ID, so "AMZN MKTP US"
+// and "AMAZON.COM" are the same merchant; any other merchant matches on its
+// normalised full name.
+func (s *Service) merchantKey(ctx context.Context, descriptor string) string {
+	name := normaliseMerchantName(descriptor)
+	if name == "" {
+		return ""
+	}
+
+	candidates := []string{name}
+	if token := strings.Fields(merchantPart(descriptor)); len(token) > 0 {
+		candidates = append(candidates, strings.Trim(token[0], "*#-,"))
+	}
+	candidates = append(candidates, strings.Fields(name)[0])
+	for _, candidate := range candidates {
+		merchant, err := s.store.FindSubscriptionMerchantByPattern(ctx, strings.ToLower(candidate))
+		if err == nil {
+			return "merchant:" + strconv.FormatInt(merchant.ID, 10)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			s.logger.Warnf("merchant lock: look up merchant %q: %v", candidate, err)
+		}
+	}
+	return name
+}
+
+// merchantPart drops a payment facilitator prefix such as "PAYPAL *" so the
+// merchant behind it is matched.
+func merchantPart(descriptor string) string {
+	d := strings.ToUpper(descriptor)
+	if i := strings.Index(d, "*"); i >= 0 && strings.TrimSpace(d[i+1:]) != "" {
+		return d[i+1:]
+	}
+	return d
+}
+
+// normaliseMerchantName reduces a descriptor to the words naming the merchant,
+// dropping punctuation, store numbers, phone numbers and reference codes.
+func normaliseMerchantName(descriptor string) string {
+	words := strings.FieldsFunc(merchantPart(descriptor), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := words[:0]
+	for _, word := range words {
+		if strings.IndexFunc(word, unicode.IsDigit) < 0 {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// applyCardUsage fills in the card's single-use or merchant lock, if it has one.
+func (s *Service) applyCardUsage(ctx context.Context, resp *SpendingControlsResponse) error {
+	resp.UsageType = string(CardUsageStandard)
+	lock, err := s.store.GetCardUsageLock(ctx, resp.CardID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get card usage lock: %w", err)
+	}
+	resp.UsageType = lock.UsageType
+	if lock.LockedMerchantDisplay.Valid {
+		resp.LockedMerchant = &lock.LockedMerchantDisplay.String
+	}
+	return nil
+}
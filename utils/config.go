@@ -15,6 +15,7 @@ type Config struct {
 	Env                       string   `mapstructure:"ENV"`
 	ServerPort                int      `mapstructure:"SERVER_PORT"`
 	SigningKey                string   `mapstructure:"SIGNING_KEY"`
+	QuoteSigningKey           string   `mapstructure:"QUOTE_SIGNING_KEY"`
 	ServerBaseURL             string   `mapstructure:"SERVER_BASE_URL"`
 	AWSRegion                 string   `mapstructure:"AWS_REGION"`
 	AWSAccessKeyID            string   `mapstructure:"AWS_ACCESS_KEY"`
@@ -95,6 +96,7 @@ func LoadConfig(path string) (*Config, error) {
 	// Bind environment variables explicitly
 	_ = v.BindEnv("SERVER_PORT")
 	_ = v.BindEnv("SIGNING_KEY")
+	_ = v.BindEnv("QUOTE_SIGNING_KEY")
 	_ = v.BindEnv("TOKEN_TTL")
 	_ = v.BindEnv("DB_USERNAME")
 	_ = v.BindEnv("DB_PASSWORD")
@@ -171,6 +173,11 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("server port must be specified")
 	}
 
+	// Quote IDs are HMACs; sharing the PII encryption key would let one leak expose both
+	if config.QuoteSigningKey == "" || config.QuoteSigningKey == config.SigningKey {
+		return fmt.Errorf("quote signing key must be set and differ from the signing key")
+	}
+
 	// Add more validation as needed
 	if config.DBUsername == "" || config.DBPassword == "" {
 		return fmt.Errorf("database credentials must be provided")
@@ -243,6 +250,26 @@ func LoadCustomConfig(path string, val interface{}) error {
 	_ = v.BindEnv("RATES_PROVIDER_NAME")
 	_ = v.BindEnv("COINGECKO_BASE_URL")
 	_ = v.BindEnv("COINGECKO_ACCESS_KEY")
+	_ = v.BindEnv("RATE_MAX_DEVIATION_PCT")
+	_ = v.BindEnv("RATE_TTL_SECONDS")
+	_ = v.BindEnv("RATE_MIN_SOURCES")
+	_ = v.BindEnv("LIMIT_ORDER_LIQUIDITY_CAP_USD")
+	_ = v.BindEnv("LIMIT_ORDER_INTERVAL_SECONDS")
+	_ = v.BindEnv("LIMIT_ORDER_MIN_FILL_USD")
+	_ = v.BindEnv("ALERT_DELIVERY_MAX_ATTEMPTS")
+	_ = v.BindEnv("ALERT_DELIVERY_RETRY_BASE_SECONDS")
+	_ = v.BindEnv("ALERT_DAILY_CAP_PUSH")
+	_ = v.BindEnv("ALERT_DAILY_CAP_EMAIL")
+	_ = v.BindEnv("ALERT_DAILY_CAP_SMS")
+	_ = v.BindEnv("ALERT_DAILY_CAP_IN_APP")
+	_ = v.BindEnv("ALERT_DAILY_CAP_WEBSOCKET")
+	_ = v.BindEnv("ALERT_DAILY_CAP_WEBHOOK")
+	_ = v.BindEnv("ALERT_CONVERSION_MAX_USD")
+	_ = v.BindEnv("ALERT_CONVERSION_DAILY_LIMIT_USD")
+	_ = v.BindEnv("PRICE_ALERT_SHARD_COUNT")
+	_ = v.BindEnv("PRICE_ALERT_LEASE_TTL_SECONDS")
+	_ = v.BindEnv("PRICE_ALERT_INDEX_REBUILD_SECONDS")
+	_ = v.BindEnv("PRICE_ALERT_NODE_ID")
 	_ = v.BindEnv("PLUNK_API_KEY")
 	_ = v.BindEnv("PLUNK_BASE_URL")
 	_ = v.BindEnv("PLUNK_SECRET_KEY")